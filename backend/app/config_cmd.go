@@ -0,0 +1,47 @@
+package main
+
+import (
+	"app/internal/config"
+	"flag"
+	"fmt"
+	"os"
+)
+
+const configUsage = `Usage: app config <command> [flags]
+
+Commands:
+  print [-file PATH]   validate the configuration and print it with secrets redacted
+  check [-file PATH]   validate the configuration only
+`
+
+// runConfig выполняет подкоманду config и возвращает код выхода
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+	cmd, args := args[0], args[1:]
+	fs := flag.NewFlagSet("config "+cmd, flag.ContinueOnError)
+	file := fs.String("file", "", "path to the YAML config file (default $CONFIG_FILE)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	switch cmd {
+	case "print", "check":
+		cfg, err := config.Load(*file)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if cmd == "print" {
+			fmt.Print(cfg)
+		} else {
+			fmt.Println("config is valid")
+		}
+		return 0
+	default:
+		fmt.Fprint(os.Stderr, configUsage)
+		return 2
+	}
+}
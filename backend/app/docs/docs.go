@@ -15,81 +15,69 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/admin/login": {
-            "post": {
-                "description": "Authenticate admin user with password",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/archive/services": {
+            "get": {
+                "description": "Deleted services, most recently deleted first (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Admin login",
+                "summary": "Archived services",
                 "parameters": [
                     {
-                        "description": "Admin login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/admin.AdminLoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/admin.AdminLoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/record/{id}": {
-            "get": {
-                "description": "Get detailed information about a record",
+        "/admin/archive/services/{id}/restore": {
+            "post": {
+                "description": "Restores the service with the slots deleted together with it; the master must not be archived (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get record details",
+                "summary": "Restore service",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Record ID",
+                        "type": "integer",
+                        "description": "Service ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -99,142 +87,231 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Record"
+                            "$ref": "#/definitions/models.Service"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/archive/slots": {
+            "get": {
+                "description": "Deleted slots with their service, most recently deleted first (admin)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Archived slots",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Slot"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/records": {
-            "get": {
-                "description": "Get all records (admin)",
+        "/admin/archive/slots/{id}/restore": {
+            "post": {
+                "description": "Restores the slot as free; its master and service must not be archived, rejected records stay rejected (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get all records",
+                "summary": "Restore slot",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Slot"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/roles": {
+        "/admin/archive/users": {
             "get": {
-                "description": "Get all roles",
+                "description": "Deleted users, most recently deleted first; purged users have empty personal data (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "admin"
+                ],
+                "summary": "Archived users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 50, max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get all roles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.User"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/archive/users/{id}/restore": {
             "post": {
-                "description": "Create role for user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Restores the user with the services and slots deleted together with them. Cancelled records stay cancelled (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "admin"
                 ],
-                "summary": "Create role",
+                "summary": "Restore user",
                 "parameters": [
                     {
-                        "description": "Role data",
-                        "name": "role",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UserRole"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.User"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete role from user",
+            }
+        },
+        "/admin/login": {
+            "post": {
+                "description": "Authenticate admin user with password",
                 "consumes": [
                     "application/json"
                 ],
@@ -242,17 +319,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "admin"
                 ],
-                "summary": "Delete role",
+                "summary": "Admin login",
                 "parameters": [
                     {
-                        "description": "Role data",
-                        "name": "role",
+                        "description": "Admin login credentials",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.UserRole"
+                            "$ref": "#/definitions/admin.AdminLoginRequest"
                         }
                     }
                 ],
@@ -260,8 +337,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/admin.AdminLoginResponse"
                         }
                     },
                     "400": {
@@ -273,6 +349,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -285,20 +370,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/service/{id}": {
+        "/admin/record/{id}": {
             "get": {
-                "description": "Get detailed information about a service",
+                "description": "Get detailed information about a record",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get service details",
+                "summary": "Get record details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Record ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -308,7 +393,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "$ref": "#/definitions/models.Record"
                         }
                     },
                     "400": {
@@ -332,16 +417,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/services": {
+        "/admin/records": {
             "get": {
-                "description": "Get all services (admin)",
+                "description": "Get all records (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get all services",
+                "summary": "Get all records",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -362,144 +447,179 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/slot/{id}": {
+        "/admin/resources/utilization": {
             "get": {
-                "description": "Get detailed information about a slot",
+                "description": "Booked minutes, capacity and peak concurrent use of every resource in [from, to). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get slot details",
+                "summary": "Resource utilization (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slot ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, exclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Slot"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.ResourceUtilization"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete slot by ID",
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "description": "All reviews including hidden ones, newest first (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Delete slot",
+                "summary": "Reviews for moderation",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Slot ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Only hidden (true) or visible (false) reviews",
+                        "name": "hidden",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only flagged (true) or not flagged (false) reviews",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.Review"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/slots": {
-            "get": {
-                "description": "Get all slots with masters (admin)",
+        "/admin/reviews/{id}": {
+            "put": {
+                "description": "Hide a review (it leaves public lists and ratings) or flag it for checking; omitted fields are kept (admin)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get all slots",
+                "summary": "Moderate review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Moderation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewModeration"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/contract.Review"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/admin/stats": {
+        "/admin/roles": {
             "get": {
-                "description": "Get overall statistics including users, slots, records, and services",
+                "description": "Get all roles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "role"
                 ],
-                "summary": "Get system statistics",
+                "summary": "Get all roles",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/admin.AdminStatsResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "500": {
@@ -512,32 +632,36 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/user/{id}": {
-            "get": {
-                "description": "Get detailed information about user including slots, services, and records",
+            },
+            "post": {
+                "description": "Create role for user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "role"
                 ],
-                "summary": "Get user details",
+                "summary": "Create role",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Role data",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRole"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/admin.UserDetailResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -549,15 +673,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -570,21 +685,26 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete user by ID",
+                "description": "Delete role from user",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "admin"
+                    "role"
                 ],
-                "summary": "Delete user",
+                "summary": "Delete role",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Role data",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UserRole"
+                        }
                     }
                 ],
                 "responses": {
@@ -616,20 +736,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/user/{id}/toggle-active": {
-            "put": {
-                "description": "Toggle user active/inactive status",
+        "/admin/service/{id}": {
+            "get": {
+                "description": "Get detailed information about a service",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Toggle user active status",
+                "summary": "Get service details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Service ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -639,8 +759,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Service"
                         }
                     },
                     "400": {
@@ -652,15 +771,6 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -673,37 +783,22 @@ const docTemplate = `{
                 }
             }
         },
-        "/admin/users": {
+        "/admin/services": {
             "get": {
-                "description": "Get paginated list of all users",
+                "description": "Get all services (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "admin"
                 ],
-                "summary": "Get users list",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    }
-                ],
+                "summary": "Get all services",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/admin.UserListResponse"
+                            "type": "object",
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -714,33 +809,24 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
         },
-        "/admin/users/{id}/roles": {
+        "/admin/slot/{id}": {
             "get": {
-                "description": "Get roles for user by ID",
+                "description": "Get detailed information about a slot",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "admin"
                 ],
-                "summary": "Get user roles",
+                "summary": "Get slot details",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Slot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -750,8 +836,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Slot"
                         }
                     },
                     "400": {
@@ -773,32 +858,23 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/admin/users/{id}/roles/{role}": {
-            "get": {
-                "description": "Check if user has specific role",
+            },
+            "delete": {
+                "description": "Delete slot by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "role"
+                    "admin"
                 ],
-                "summary": "Check user role",
+                "summary": "Delete slot",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Slot ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Role name",
-                        "name": "role",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
@@ -830,35 +906,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/metrics/ad-click": {
-            "post": {
-                "description": "Track advertisement click for slot (1 or 2)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/slots": {
+            "get": {
+                "description": "Get all slots with masters (admin)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "metrics"
-                ],
-                "summary": "Track ad click",
-                "parameters": [
-                    {
-                        "description": "Slot click payload",
-                        "name": "slot",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/metrics.clickReq"
-                        }
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Slot via query param",
-                        "name": "slot",
-                        "in": "query"
-                    }
+                    "admin"
                 ],
+                "summary": "Get all slots",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -867,8 +924,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -879,26 +936,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/": {
+        "/admin/stats": {
             "get": {
-                "description": "Get notifications for authenticated user",
+                "description": "Get overall statistics including users, slots, records, and services",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "admin"
                 ],
-                "summary": "Get notifications",
+                "summary": "Get system statistics",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/admin.AdminStatsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -909,19 +965,34 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/mark-all-read": {
-            "post": {
-                "description": "Mark all notifications as read for current user",
+        "/admin/user/{id}": {
+            "get": {
+                "description": "Get detailed information about user including slots, services, and records",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "admin"
+                ],
+                "summary": "Get user details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Mark all notifications read",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/admin.UserDetailResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -929,38 +1000,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
-                    }
-                }
-            }
-        },
-        "/notification/unread-count": {
-            "get": {
-                "description": "Count unread notifications for current user",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "notification"
-                ],
-                "summary": "Count unread notifications",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -969,22 +1019,20 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notification/{id}/mark-read": {
-            "post": {
-                "description": "Mark specific notification as read",
+            },
+            "delete": {
+                "description": "Delete user by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "admin"
                 ],
-                "summary": "Mark notification read",
+                "summary": "Delete user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Notification ID",
+                        "description": "User ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -995,9 +1043,7 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
                     "400": {
@@ -1009,8 +1055,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1021,21 +1067,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/master/confirm/{record_id}": {
-            "post": {
-                "description": "Confirm record by id",
+        "/admin/user/{id}/toggle-active": {
+            "put": {
+                "description": "Toggle user active/inactive status",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "admin"
                 ],
-                "summary": "Confirm record",
+                "summary": "Toggle user active status",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Record ID",
-                        "name": "record_id",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1043,6 +1089,13 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1050,8 +1103,17 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1062,36 +1124,37 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/master/create": {
-            "post": {
-                "description": "Create a new booking record for a slot",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/users": {
+            "get": {
+                "description": "Get paginated list of all users",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "admin"
                 ],
-                "summary": "Create record",
+                "summary": "Get users list",
                 "parameters": [
                     {
-                        "description": "Record data",
-                        "name": "record",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Record"
-                        }
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/admin.UserListResponse"
                         }
                     },
                     "400": {
@@ -1102,32 +1165,36 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/record/master/get": {
-            "post": {
-                "description": "Get records for a specific slot with optional status filter",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/users/{id}/roles": {
+            "get": {
+                "description": "Get roles for user by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "role"
                 ],
-                "summary": "Get records by slot",
+                "summary": "Get user roles",
                 "parameters": [
                     {
-                        "description": "Slot and status request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1146,25 +1213,41 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/record/master/reject/{record_id}": {
-            "post": {
-                "description": "Reject record by id",
+        "/admin/users/{id}/roles/{role}": {
+            "get": {
+                "description": "Check if user has specific role",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "role"
                 ],
-                "summary": "Reject record",
+                "summary": "Check user role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Record ID",
-                        "name": "record_id",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
                         "in": "path",
                         "required": true
                     }
@@ -1172,6 +1255,13 @@ const docTemplate = `{
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1179,8 +1269,8 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1191,69 +1281,145 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/master/status": {
-            "post": {
-                "description": "Update record status by id",
-                "consumes": [
-                    "application/json"
-                ],
+        "/directory/masters": {
+            "get": {
+                "description": "Public discovery of masters: full-text search over master names, service names and descriptions (Russian and English), filters by price, duration, distance from a point and free slots within N days. Masters who opted out are not listed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "directory"
                 ],
-                "summary": "Update record status",
+                "summary": "Master directory",
                 "parameters": [
                     {
-                        "description": "Record status update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "Search text (websearch syntax: quotes, -word, or)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum service price in minor currency units (kopecks, cents)",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum service price in minor currency units",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only services priced in this ISO 4217 currency",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum service duration, minutes",
+                        "name": "min_duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum service duration, minutes",
+                        "name": "max_duration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Latitude of the search point",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Longitude of the search point",
+                        "name": "lon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Only services within this distance from lat/lon (up to 500 km)",
+                        "name": "radius_km",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only masters with a free slot within N days (up to 60)",
+                        "name": "free_within_days",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "relevance (default with q), next_slot (default), rating or distance (needs lat/lon)",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.DirectorySearch"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/record/master/{record_id}": {
-            "delete": {
-                "description": "Delete record by id",
+        "/metrics/ad-click": {
+            "post": {
+                "description": "Track advertisement click for slot (1 or 2)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "metrics"
                 ],
-                "summary": "Delete record",
+                "summary": "Track ad click",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Record ID",
-                        "name": "record_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Slot click payload",
+                        "name": "slot",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/metrics.clickReq"
+                        }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot via query param",
+                        "name": "slot",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1261,13 +1427,11 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1278,25 +1442,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/master/{slot_id}": {
+        "/notification/": {
             "get": {
-                "description": "Get all records for slot by slot_id",
+                "description": "Get notifications for authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
-                ],
-                "summary": "Get records by slot (admin)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Slot ID",
-                        "name": "slot_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notification"
                 ],
+                "summary": "Get notifications",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1305,8 +1460,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1317,40 +1472,28 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/user/filter": {
+        "/notification/mark-all-read": {
             "post": {
-                "description": "Get client records filtered by status with pagination",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark all notifications as read for current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
-                ],
-                "summary": "Get filtered client records",
-                "parameters": [
-                    {
-                        "description": "Filter request",
-                        "name": "filter",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
-                    }
+                    "notification"
                 ],
+                "summary": "Mark all notifications read",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1361,25 +1504,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/record/{uuid}": {
+        "/notification/unread-count": {
             "get": {
-                "description": "Get all records for a client by UUID",
+                "description": "Count unread notifications for current user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
-                ],
-                "summary": "Get client records",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Client UUID",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "notification"
                 ],
+                "summary": "Count unread notifications",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -1388,8 +1522,8 @@ const docTemplate = `{
                             "additionalProperties": true
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1400,28 +1534,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/service/create": {
+        "/notification/{id}/mark-read": {
             "post": {
-                "description": "Create a new service for master",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Mark specific notification as read",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "notification"
                 ],
-                "summary": "Create service",
+                "summary": "Mark notification read",
                 "parameters": [
                     {
-                        "description": "Service data",
-                        "name": "service",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Service"
-                        }
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1429,7 +1558,9 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -1440,54 +1571,49 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/service/master/{uuid}": {
+        "/organization": {
             "get": {
-                "description": "Get services for master by uuid or telegram_id",
+                "description": "Organizations the caller is a member of, with members and roles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "service"
-                ],
-                "summary": "Get services",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Master UUID or telegram_id",
-                        "name": "uuid",
-                        "in": "path",
-                        "required": true
-                    }
+                    "organization"
                 ],
+                "summary": "My organizations",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Service"
+                                "$ref": "#/definitions/models.Organization"
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/service/update": {
-            "put": {
-                "description": "Update service fields",
+            },
+            "post": {
+                "description": "Create a salon; the caller becomes its owner",
                 "consumes": [
                     "application/json"
                 ],
@@ -1495,17 +1621,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "organization"
                 ],
-                "summary": "Update service",
+                "summary": "Create organization",
                 "parameters": [
                     {
-                        "description": "Service update data",
-                        "name": "service",
+                        "description": "Organization name",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "$ref": "#/definitions/contract.OrganizationCreate"
                         }
                     }
                 ],
@@ -1513,36 +1639,38 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/service/{id}": {
+        "/organization/{id}": {
             "get": {
-                "description": "Get service by ID",
+                "description": "Organization with members and roles (members only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "organization"
                 ],
-                "summary": "Get service",
+                "summary": "Get organization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Organization UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1552,36 +1680,51 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Service"
+                            "$ref": "#/definitions/models.Organization"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "Delete service by ID (owner check)",
+            "put": {
+                "description": "Rename organization (owner or manager)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "service"
+                    "organization"
                 ],
-                "summary": "Delete service",
+                "summary": "Rename organization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Service ID",
+                        "description": "Organization UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New name",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.OrganizationCreate"
+                        }
                     }
                 ],
                 "responses": {
@@ -1597,27 +1740,63 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/slot/master/create": {
+        "/organization/{id}/locations": {
+            "get": {
+                "description": "Addresses where the organization receives clients, with coordinates, timezone and working hours (members only)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "location"
+                ],
+                "summary": "Organization locations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Location"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Create a new time slot for master",
+                "description": "Add an address with optional coordinates, an IANA timezone and weekly working hours; empty hours mean no limit (owner or manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1625,17 +1804,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "slot"
+                    "location"
                 ],
-                "summary": "Create slot",
+                "summary": "Create location",
                 "parameters": [
                     {
-                        "description": "Slot data",
-                        "name": "slot",
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.Slot"
+                            "$ref": "#/definitions/contract.LocationRequest"
                         }
                     }
                 ],
@@ -1643,87 +1829,110 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Location"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/slot/master/one/{id}": {
-            "delete": {
-                "description": "Delete single slot with owner verification",
+        "/organization/{id}/locations/{location_id}": {
+            "put": {
+                "description": "Replace address, coordinates, timezone and working hours; existing slots keep the location (owner or manager)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "slot"
+                    "location"
                 ],
-                "summary": "Delete slot (owner)",
+                "summary": "Update location",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slot ID",
+                        "description": "Organization UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "location_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.LocationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Location"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/slot/master/{uuid}": {
+            },
             "delete": {
-                "description": "Delete all slots for master uuid",
+                "description": "Delete a location; its services and slots are left without an address (owner or manager)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "slot"
+                    "location"
                 ],
-                "summary": "Delete slots by master",
+                "summary": "Delete location",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Master UUID",
-                        "name": "uuid",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Location ID",
+                        "name": "location_id",
                         "in": "path",
                         "required": true
                     }
@@ -1741,109 +1950,178 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/slot/one/{id}": {
-            "get": {
-                "description": "Get slot by ID",
+        "/organization/{id}/members": {
+            "post": {
+                "description": "Add a registered user by phone or telegram_id. Owners grant any role, managers only master and receptionist",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "slot"
+                    "organization"
                 ],
-                "summary": "Get slot",
+                "summary": "Add member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Slot ID",
+                        "description": "Organization UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "User and role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.OrganizationMemberAdd"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Slot"
+                            "$ref": "#/definitions/models.OrganizationMember"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/slot/{uuid}": {
-            "get": {
-                "description": "Get slots by master uuid",
+        "/organization/{id}/members/{user_id}": {
+            "put": {
+                "description": "Change the role of a member; the last owner cannot be demoted",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "slot"
+                    "organization"
                 ],
-                "summary": "Get slots",
+                "summary": "Change member role",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Master UUID",
-                        "name": "uuid",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user UUID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.OrganizationMemberRole"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Slot"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/telegram/record/master/upcoming/{telegram_id}": {
-            "get": {
-                "description": "Get upcoming confirmed records for master by telegram_id (internal)",
+            },
+            "delete": {
+                "description": "Remove a member (owner or manager) or leave the organization (user_id of the caller)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "record"
+                    "organization"
                 ],
-                "summary": "Get upcoming records for master",
+                "summary": "Remove member",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member user UUID",
+                        "name": "user_id",
                         "in": "path",
                         "required": true
                     }
@@ -1853,87 +2131,130 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/telegram/user/timezone": {
-            "put": {
-                "description": "Update timezone by telegram_id (internal for Telegram bot)",
-                "consumes": [
-                    "application/json"
-                ],
+        "/organization/{id}/records": {
+            "get": {
+                "description": "Records on the organization slots starting in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "organization"
                 ],
-                "summary": "Update timezone internal",
+                "summary": "Organization records",
                 "parameters": [
                     {
-                        "description": "Timezone update internal request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Record status (pending, confirm, reject, cancel)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, exclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.Record"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/check-login/{telegram_id}": {
-            "get": {
-                "description": "Check if login token is ready for given telegram_id",
+        "/organization/{id}/records/{record_id}/status": {
+            "post": {
+                "description": "Owner or manager confirms or rejects a record on the organization slots; the client is notified as if the master decided",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "organization"
                 ],
-                "summary": "Check login status",
+                "summary": "Confirm or reject record (manager)",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "description": "Record ID",
+                        "name": "record_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "confirm or reject",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.OrganizationRecordStatus"
+                        }
                     }
                 ],
                 "responses": {
@@ -1941,36 +2262,53 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "type": "object",
-                            "additionalProperties": true
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/check/{telegram_id}": {
+        "/organization/{id}/resources": {
             "get": {
-                "description": "Check authentication status by telegram_id",
+                "description": "Chairs, rooms and equipment of the organization (members only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
                 ],
-                "summary": "Check auth",
+                "summary": "Organization resources",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1979,176 +2317,220 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Resource"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/claim-token/{telegram_id}": {
+            },
             "post": {
-                "description": "Issue session token for user after confirmation (internal)",
+                "description": "Add a chair, room or piece of equipment with the number of units that can be busy at once (owner or manager)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
                 ],
-                "summary": "Claim token",
+                "summary": "Create resource",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Resource",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ResourceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/models.Resource"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/confirm-deletion": {
-            "post": {
-                "description": "Confirm account deletion (internal for Telegram)",
+        "/organization/{id}/resources/utilization": {
+            "get": {
+                "description": "Booked minutes, capacity and peak concurrent use of each resource in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
+                ],
+                "summary": "Organization resource utilization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, exclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Confirm account deletion",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.ResourceUtilization"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/confirm-login/{telegram_id}": {
-            "post": {
-                "description": "Confirm login by telegram_id (internal endpoint)",
+        "/organization/{id}/resources/{resource_id}": {
+            "put": {
+                "description": "Change name, quantity and availability; an inactive resource blocks new slots and bookings of its services (owner or manager)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
                 ],
-                "summary": "Confirm login",
+                "summary": "Update resource",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "description": "Resource ID",
+                        "name": "resource_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Resource",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ResourceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/models.Resource"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/g3tter/{telegram_id}": {
-            "get": {
-                "description": "Get user public data by telegram id (internal)",
+            },
+            "delete": {
+                "description": "Delete a resource; services stop using it (owner or manager)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
                 ],
-                "summary": "Get user by telegram id (internal)",
+                "summary": "Delete resource",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Telegram ID",
-                        "name": "telegram_id",
+                        "description": "Resource ID",
+                        "name": "resource_id",
                         "in": "path",
                         "required": true
                     }
@@ -2157,147 +2539,131 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.PublicUserResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/login": {
-            "post": {
-                "description": "Initiate login process by phone number",
-                "consumes": [
-                    "application/json"
-                ],
+        "/organization/{id}/services": {
+            "get": {
+                "description": "Services owned by the organization with assigned masters (members only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "organization"
                 ],
-                "summary": "User login",
+                "summary": "Organization services",
                 "parameters": [
                     {
-                        "description": "Login request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/user/logout": {
+            },
             "post": {
-                "description": "Logout current session",
-                "produces": [
+                "description": "Create a service owned by the organization and assign it to a member (owner or manager)",
+                "consumes": [
                     "application/json"
                 ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Logout",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            }
-        },
-        "/user/public/{uuid}": {
-            "get": {
-                "description": "Get public user info by UUID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "organization"
                 ],
-                "summary": "Get public user",
+                "summary": "Create organization service",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User UUID",
-                        "name": "uuid",
+                        "description": "Organization UUID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Service and assigned master",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.OrganizationService"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/user.PublicUserResponse"
+                            "$ref": "#/definitions/models.Service"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/register": {
-            "post": {
-                "description": "Register a new user with phone and Telegram ID",
+        "/organization/{id}/services/{service_id}/location": {
+            "put": {
+                "description": "New slots of the service take place at this location and must fit its working hours; location_id 0 detaches the service (owner or manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2305,50 +2671,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "location"
                 ],
-                "summary": "Create user",
+                "summary": "Set service location",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "user",
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Location",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/user.UserSageRegister"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": true
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ServiceLocation"
                         }
                     }
-                }
-            }
-        },
-        "/user/request-deletion": {
-            "post": {
-                "description": "Request account deletion, sending confirmation to Telegram",
-                "produces": [
-                    "application/json"
                 ],
-                "tags": [
-                    "user"
-                ],
-                "summary": "Request account deletion",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -2362,27 +2712,27 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/timezone": {
+        "/organization/{id}/services/{service_id}/master": {
             "put": {
-                "description": "Update timezone for authenticated user",
+                "description": "Assign the service to another member; existing slots stay with the previous master (owner or manager)",
                 "consumes": [
                     "application/json"
                 ],
@@ -2390,20 +2740,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "organization"
                 ],
-                "summary": "Update timezone",
+                "summary": "Assign organization service",
                 "parameters": [
                     {
-                        "description": "Timezone update request",
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New master",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.OrganizationServiceAssign"
                         }
                     }
                 ],
@@ -2420,40 +2781,223 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/user/update": {
-            "put": {
-                "description": "Update first_name and surname for user",
-                "consumes": [
-                    "application/json"
-                ],
+        "/organization/{id}/services/{service_id}/resources": {
+            "get": {
+                "description": "Resources a service of the organization occupies during its slots (members only)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "resource"
                 ],
-                "summary": "Update user names",
+                "summary": "Service resources",
                 "parameters": [
                     {
-                        "description": "User update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Resource"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace the resources a service occupies (one unit of each); an empty list detaches all (owner or manager)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "resource"
+                ],
+                "summary": "Set service resources",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Resource IDs",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceResources"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/organization/{id}/slots": {
+            "get": {
+                "description": "Slots for the organization services starting in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Organization slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Organization UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "From date (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "To date, exclusive (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.Slot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/payment/webhook": {
+            "post": {
+                "description": "Payment status notification from the configured provider. The fake provider signs the body with HMAC-SHA256 in X-Payment-Signature; YooKassa notifications are verified by re-reading the payment from its API. A successful payment confirms the record, a late one is refunded",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "payment"
+                ],
+                "summary": "Payment provider webhook",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "hex HMAC-SHA256 of the body (fake provider)",
+                        "name": "X-Payment-Signature",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -2474,215 +3018,4746 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/client/{record_id}/cancel": {
+            "post": {
+                "description": "Cancel own record; the slot is released and the master is notified",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Cancel record (client)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/client/{record_id}/comment": {
+            "post": {
+                "description": "Leave a comment for the master on own record; the master is notified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Comment record (client)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.RecordComment"
+                        }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/client/{record_id}/ics": {
+            "get": {
+                "description": "Download own record as .ics to add it to a calendar",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Record calendar file (client)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/client/{record_id}/reschedule": {
+            "post": {
+                "description": "Move own record to another free slot of the same service and master; the record waits for confirmation again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Reschedule record (client)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New slot",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.RecordReschedule"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/confirm/{record_id}": {
+            "post": {
+                "description": "Confirm record by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Confirm record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/create": {
+            "post": {
+                "description": "Create a new booking record for a slot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Create record",
+                "parameters": [
+                    {
+                        "description": "Record data",
+                        "name": "record",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Record"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/get": {
+            "post": {
+                "description": "Get records for a specific slot with optional status filter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Get records by slot",
+                "parameters": [
+                    {
+                        "description": "Slot and status request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/reject/{record_id}": {
+            "post": {
+                "description": "Reject record by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Reject record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/status": {
+            "post": {
+                "description": "Update record status by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Update record status",
+                "parameters": [
+                    {
+                        "description": "Record status update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.RecordStatusUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/{record_id}": {
+            "delete": {
+                "description": "Delete record by id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Delete record",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/master/{slot_id}": {
+            "get": {
+                "description": "Get all records for slot by slot_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Get records by slot (admin)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slot ID",
+                        "name": "slot_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/record/user/filter": {
+            "post": {
+                "description": "Get client records filtered by status with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Get filtered client records",
+                "parameters": [
+                    {
+                        "description": "Filter request",
+                        "name": "filter",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.RecordFilter"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.RecordPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/record/{uuid}": {
+            "get": {
+                "description": "Get all records for a client by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Get client records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Client UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/review/master/{uuid}": {
+            "get": {
+                "description": "Visible reviews of a master, newest first, with the average rating and the number of reviews. Clients are shown by first name only.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Master reviews",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/record/{record_id}": {
+            "put": {
+                "description": "Change the rating and text of the own review; the master's reply is kept",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Update review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Rate a confirmed record whose slot has ended (1–5) with an optional text; one review per record. The master is notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Create review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Record ID",
+                        "name": "record_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/service/{id}": {
+            "get": {
+                "description": "Visible reviews of a service, newest first, with the average rating and the number of reviews",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Service reviews",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (default 20, max 50)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewList"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/review/{id}/reply": {
+            "put": {
+                "description": "Public reply of the master to a review about them; an empty text removes the reply. The client is notified.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "review"
+                ],
+                "summary": "Reply to review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reply",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ReviewReply"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Review"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/categories": {
+            "post": {
+                "description": "Add a category for own services; names are unique per master ignoring case",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Create service category",
+                "parameters": [
+                    {
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/categories/{id}": {
+            "put": {
+                "description": "Change the name and position of an own category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Update service category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceCategory"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an own category; its services stay without a category",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Delete service category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/create": {
+            "post": {
+                "description": "Create a new service for master",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Create service",
+                "parameters": [
+                    {
+                        "description": "Service data",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not the service master",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/service/master/{uuid}": {
+            "get": {
+                "description": "Get services for master by uuid or telegram_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Get services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master UUID or telegram_id",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Service"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/service/master/{uuid}/categories": {
+            "get": {
+                "description": "Categories of a master in display order; services refer to them by category_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Master service categories",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/contract.ServiceCategory"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/update": {
+            "put": {
+                "description": "Update service fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Update service",
+                "parameters": [
+                    {
+                        "description": "Service update data",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not the service master",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}": {
+            "get": {
+                "description": "Get service by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Get service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete service by ID (owner check)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Delete service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}/add-ons": {
+            "post": {
+                "description": "Add an add-on that adds to the price and extends the duration (minutes, may be 0). Clients pick add-ons as add_on_ids when booking.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Add service add-on",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add-on",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceOptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAddOn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}/add-ons/{add_on_id}": {
+            "put": {
+                "description": "Change an add-on; existing records keep the price and duration they were booked with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Update service add-on",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Add-on ID",
+                        "name": "add_on_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Add-on",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceOptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceAddOn"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an add-on; existing records keep its name and price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Delete service add-on",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Add-on ID",
+                        "name": "add_on_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}/category": {
+            "put": {
+                "description": "Put an own service into an own category; a null category_id removes the service from its category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Set service category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceCategoryAssign"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}/variants": {
+            "post": {
+                "description": "Add a variant with its own price and duration in minutes, e.g. long hair. Clients pick it as variant_id when booking.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Add service variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceOptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/service/{id}/variants/{variant_id}": {
+            "put": {
+                "description": "Change a variant; existing records keep the price and duration they were booked with",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Update service variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Variant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.ServiceOptionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ServiceVariant"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a variant; existing records keep its name and price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "service"
+                ],
+                "summary": "Delete service variant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Variant ID",
+                        "name": "variant_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/slot/master/create": {
+            "post": {
+                "description": "Create a new time slot for master",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slot"
+                ],
+                "summary": "Create slot",
+                "parameters": [
+                    {
+                        "description": "Slot data",
+                        "name": "slot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Slot"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not the slot master or the service belongs to another master",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Resource of the service is fully booked or unavailable, or the slot is outside the working hours of the location",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/slot/master/one/{id}": {
+            "delete": {
+                "description": "Delete single slot with owner verification",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slot"
+                ],
+                "summary": "Delete slot (owner)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/slot/master/{uuid}": {
+            "delete": {
+                "description": "Delete all slots for master uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slot"
+                ],
+                "summary": "Delete slots by master",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/slot/one/{id}": {
+            "get": {
+                "description": "Get slot by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slot"
+                ],
+                "summary": "Get slot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Slot ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Slot"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/slot/{uuid}": {
+            "get": {
+                "description": "Get slots by master uuid",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "slot"
+                ],
+                "summary": "Get slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Slot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/delivery/{id}": {
+            "post": {
+                "description": "Telegram bot reports whether a queued notification was delivered (outbox bookkeeping)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "telegram"
+                ],
+                "summary": "Report Telegram delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Delivery result",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.DeliveryReport"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/master/search": {
+            "get": {
+                "description": "Search masters by name or service name with their services and next free slots (internal, inline mode of the bot)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "master"
+                ],
+                "summary": "Search masters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Master name or service name; empty returns all masters",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of masters (default 10, max 20)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.MasterSearch"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/record/master/upcoming/{telegram_id}": {
+            "get": {
+                "description": "Get upcoming confirmed records for master by telegram_id (internal)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "record"
+                ],
+                "summary": "Get upcoming records for master",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.UpcomingRecords"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/user/digest": {
+            "put": {
+                "description": "Set the time (\"HH:MM\" in the master's timezone) of the daily agenda digest; empty or \"off\" turns it off (internal for Telegram bot)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update digest time internal",
+                "parameters": [
+                    {
+                        "description": "Digest time update internal request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.DigestUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/user/language": {
+            "put": {
+                "description": "Update Telegram bot interface language by telegram_id (internal for Telegram bot)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update language internal",
+                "parameters": [
+                    {
+                        "description": "Language update internal request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.LanguageUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/telegram/user/timezone": {
+            "put": {
+                "description": "Update timezone by telegram_id (internal for Telegram bot)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update timezone internal",
+                "parameters": [
+                    {
+                        "description": "Timezone update internal request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.TimezoneUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/check-login/{telegram_id}": {
+            "get": {
+                "description": "Check if login token is ready for given telegram_id",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Check login status",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/check/{telegram_id}": {
+            "get": {
+                "description": "Check authentication status by telegram_id. A user registered on the site whose phone is not verified yet is not authenticated: the bot asks them to share the contact",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Check auth",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/contract.AuthStatus"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/claim-token/{telegram_id}": {
+            "post": {
+                "description": "Issue session token for user after confirmation (internal)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Claim token",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/confirm-deletion": {
+            "post": {
+                "description": "Confirm account deletion (internal for Telegram)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm account deletion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/confirm-login/{telegram_id}": {
+            "post": {
+                "description": "Confirm login by telegram_id (internal endpoint)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Confirm login",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/currency": {
+            "put": {
+                "description": "Set the master's ISO 4217 currency; new services get it unless they name their own. Prices of existing services and records are not converted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update currency",
+                "parameters": [
+                    {
+                        "description": "Currency",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.CurrencyUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/directory": {
+            "put": {
+                "description": "Opt out of the public master directory and the inline search of the bot (opt_out true) or return to it; direct links keep working",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Directory listing",
+                "parameters": [
+                    {
+                        "description": "Directory listing",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/contract.DirectoryOptOut"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/contract.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/g3tter/{telegram_id}": {
+            "get": {
+                "description": "Get user public data by telegram id (internal)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user by telegram id (internal)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Telegram ID",
+                        "name": "telegram_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.PublicUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login": {
+            "post": {
+                "description": "Initiate login process by phone number",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "Login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login/telegram-webapp": {
+            "post": {
+                "description": "Verify Telegram.WebApp.initData signed with the bot token and issue session token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login via Telegram Mini App",
+                "parameters": [
+                    {
+                        "description": "Mini App init data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/login/telegram-widget": {
+            "post": {
+                "description": "Verify Login Widget data signed with the bot token and issue session token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Login via Telegram Login Widget",
+                "parameters": [
+                    {
+                        "description": "Login Widget data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.TelegramWidgetLogin"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/logout": {
+            "post": {
+                "description": "Logout current session",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Logout",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/public/{uuid}": {
+            "get": {
+                "description": "Get public user info by UUID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get public user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User UUID",
+                        "name": "uuid",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/user.PublicUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register": {
+            "post": {
+                "description": "Register a new user with phone and Telegram ID. Requests from the Telegram bot (X-Internal-Token) carry a verified contact and create the user at once; others get a one-time code in Telegram and must call /user/register/verify",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Create user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/user.UserSageRegister"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "429": {
+                        "description": "Too Many Requests",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/register/verify": {
+            "post": {
+                "description": "Confirm the one-time code sent to Telegram and create the user. The code proves control of the Telegram account only: the phone stays unverified (login returns 403) until its owner shares the contact with the bot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Verify registration",
+                "parameters": [
+                    {
+                        "description": "Verification request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/request-deletion": {
+            "post": {
+                "description": "Request account deletion, sending confirmation to Telegram",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Request account deletion",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/timezone": {
+            "put": {
+                "description": "Update timezone for authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update timezone",
+                "parameters": [
+                    {
+                        "description": "Timezone update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/user/update": {
+            "put": {
+                "description": "Update first_name and surname for user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update user names",
+                "parameters": [
+                    {
+                        "description": "User update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.AdminLoginRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "admin.AdminLoginResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "admin.AdminStatsResponse": {
+            "type": "object",
+            "properties": {
+                "active_users": {
+                    "type": "integer"
+                },
+                "ad_clicks_1": {
+                    "type": "integer"
+                },
+                "ad_clicks_2": {
+                    "type": "integer"
+                },
+                "booked_slots": {
+                    "type": "integer"
+                },
+                "confirmed_records": {
+                    "type": "integer"
+                },
+                "pending_records": {
+                    "type": "integer"
+                },
+                "rejected_records": {
+                    "type": "integer"
+                },
+                "total_records": {
+                    "type": "integer"
+                },
+                "total_services": {
+                    "type": "integer"
+                },
+                "total_slots": {
+                    "type": "integer"
+                },
+                "total_users": {
+                    "type": "integer"
+                }
+            }
+        },
+        "admin.UserDetailResponse": {
+            "type": "object",
+            "properties": {
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Record"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Service"
+                    }
+                },
+                "slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Slot"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/admin.UserInfo"
+                }
+            }
+        },
+        "admin.UserInfo": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "admin.UserListResponse": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.UserInfo"
+                    }
+                }
+            }
+        },
+        "contract.AuthStatus": {
+            "type": "object",
+            "properties": {
+                "authenticated": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "contract.CurrencyUpdate": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.DeliveryReport": {
+            "type": "object",
+            "properties": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.DigestUpdate": {
+            "type": "object",
+            "properties": {
+                "digest_time": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.DirectoryMaster": {
+            "type": "object",
+            "properties": {
+                "distance_km": {
+                    "description": "DistanceKm — до ближайшего места услуг; только при поиске рядом с точкой",
+                    "type": "number"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "next_slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.SlotResponse"
+                    }
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Service"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.DirectoryOptOut": {
+            "type": "object",
+            "properties": {
+                "opt_out": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "contract.DirectorySearch": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.DirectoryMaster"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.LanguageUpdate": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.LocationRequest": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "working_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.WorkingHours"
+                    }
+                }
+            }
+        },
+        "contract.MasterCard": {
+            "type": "object",
+            "properties": {
+                "first_name": {
+                    "type": "string"
+                },
+                "next_slots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.SlotResponse"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Service"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.MasterSearch": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.MasterCard"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.OrganizationCreate": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.OrganizationMemberAdd": {
+            "type": "object",
+            "properties": {
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.OrganizationMemberRole": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.OrganizationRecordStatus": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.OrganizationService": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "description": "Currency — ISO 4217; пустая — валюта мастера",
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "master_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.OrganizationServiceAssign": {
+            "type": "object",
+            "properties": {
+                "master_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "description": "Amount — в минимальных единицах Currency",
+                    "type": "integer"
+                },
+                "confirmation_url": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "record_id": {
+                    "description": "RecordID — пусто, если запись уже удалена",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.RatingSummary": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                }
+            }
+        },
+        "contract.Record": {
+            "type": "object",
+            "properties": {
+                "add_on_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "add_ons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.RecordAddOn"
+                    }
+                },
+                "client": {
+                    "$ref": "#/definitions/contract.User"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "hold_until": {
+                    "description": "HoldUntil — до какого времени слот держится за записью в статусе awaiting_payment;\nPayment — предоплата записи, если услуга её требует",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payment": {
+                    "$ref": "#/definitions/contract.Payment"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "$ref": "#/definitions/contract.Slot"
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "description": "VariantID и AddOnIDs — выбор клиента при записи; без варианта действует базовая цена услуги",
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "description": "VariantName, Price, Currency, Duration и AddOns — снимок на момент записи;\nPrice — в минимальных единицах Currency",
+                    "type": "string"
+                }
+            }
+        },
+        "contract.RecordAddOn": {
+            "type": "object",
+            "properties": {
+                "add_on_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.RecordComment": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.RecordFilter": {
+            "type": "object",
+            "properties": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.RecordPage": {
+            "type": "object",
+            "properties": {
+                "has_next": {
+                    "type": "boolean"
+                },
+                "has_prev": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "records": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Record"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.RecordReschedule": {
+            "type": "object",
+            "properties": {
+                "slot_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.RecordStatusUpdate": {
+            "type": "object",
+            "properties": {
+                "record_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.ResourceRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ResourceUtilization": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "booked_minutes": {
+                    "type": "integer"
+                },
+                "booked_slots": {
+                    "type": "integer"
+                },
+                "capacity_minutes": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "peak": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "resource_id": {
+                    "type": "integer"
+                },
+                "utilization": {
+                    "type": "number"
+                }
+            }
+        },
+        "contract.Review": {
+            "type": "object",
+            "properties": {
+                "client_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "master_id": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "record_id": {
+                    "type": "integer"
+                },
+                "replied_at": {
+                    "type": "string"
+                },
+                "reply": {
+                    "type": "string"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.ReviewList": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Review"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "summary": {
+                    "$ref": "#/definitions/contract.RatingSummary"
+                }
+            }
+        },
+        "contract.ReviewModeration": {
+            "type": "object",
+            "properties": {
+                "flagged": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "contract.ReviewReply": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.ReviewRequest": {
+            "type": "object",
+            "properties": {
+                "rating": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.Service": {
+            "type": "object",
+            "properties": {
+                "add_ons": {
+                    "description": "AddOns — дополнительные опции, добавляют к цене и длительности",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.ServiceAddOn"
+                    }
+                },
+                "category_id": {
+                    "description": "CategoryID — категория мастера, в которой показывается услуга",
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "deposit": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "master_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "description": "OrganizationID — организация, которой принадлежит услуга (личная у мастера-одиночки)",
+                    "type": "string"
+                },
+                "prepayment": {
+                    "description": "Prepayment — что клиент оплачивает при записи: \"\" — ничего, deposit — задаток Deposit, full — всю цену.\nDeposit — в минимальных единицах Currency",
+                    "type": "string"
+                },
+                "price": {
+                    "description": "Price — цена в минимальных единицах валюты Currency (копейки, центы), см. пакет money",
+                    "type": "integer"
+                },
+                "rating": {
+                    "description": "Rating и RatingCount — средняя оценка и число видимых отзывов об услуге",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
+                "variants": {
+                    "description": "Variants — варианты услуги со своей ценой и длительностью; без них действуют Price и Duration",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.ServiceVariant"
+                    }
+                }
+            }
+        },
+        "contract.ServiceAddOn": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceCategory": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "master_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceCategoryAssign": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceCategoryRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceLocation": {
+            "type": "object",
+            "properties": {
+                "location_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceOptionRequest": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.ServiceResources": {
+            "type": "object",
+            "properties": {
+                "resource_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "contract.ServiceVariant": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "integer"
+                }
+            }
+        },
+        "contract.Slot": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_booked": {
+                    "type": "boolean"
+                },
+                "master": {
+                    "$ref": "#/definitions/contract.User"
+                },
+                "master_id": {
+                    "type": "string"
+                },
+                "service": {
+                    "$ref": "#/definitions/contract.Service"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.SlotResponse": {
+            "type": "object",
+            "properties": {
+                "end_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_booked": {
+                    "type": "boolean"
+                },
+                "master_name": {
+                    "type": "string"
+                },
+                "master_phone": {
+                    "type": "string"
+                },
+                "master_surname": {
+                    "type": "string"
+                },
+                "master_telegram_id": {
+                    "type": "integer"
+                },
+                "master_timezone": {
+                    "type": "string"
+                },
+                "service_currency": {
+                    "type": "string"
+                },
+                "service_description": {
+                    "type": "string"
+                },
+                "service_duration": {
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "service_name": {
+                    "type": "string"
+                },
+                "service_price": {
+                    "description": "ServicePrice — в минимальных единицах ServiceCurrency",
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.TimezoneUpdate": {
+            "type": "object",
+            "properties": {
+                "telegram_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.UpcomingRecords": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Record"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.User": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "digest_time": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "language": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.UserRole"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/contract.Service"
+                    }
+                },
+                "surname": {
+                    "type": "string"
+                },
+                "telegram_id": {
+                    "type": "integer"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.UserRole": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "contract.WorkingHours": {
+            "type": "object",
+            "properties": {
+                "closes": {
+                    "type": "string"
+                },
+                "opens": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
+        "gorm.DeletedAt": {
+            "type": "object",
+            "properties": {
+                "time": {
+                    "type": "string"
+                },
+                "valid": {
+                    "description": "Valid is true if Time is not NULL",
+                    "type": "boolean"
+                }
+            }
+        },
+        "metrics.clickReq": {
+            "type": "object",
+            "properties": {
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Location": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "latitude": {
+                    "type": "number"
+                },
+                "longitude": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                },
+                "working_hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WorkingHours"
+                    }
+                }
+            }
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.OrganizationMember"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "personal_owner_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationMember": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Payment": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "integer"
+                },
+                "confirmation_url": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "paid_at": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "record_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Record": {
+            "type": "object",
+            "properties": {
+                "add_on_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "add_ons": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RecordAddOn"
+                    }
+                },
+                "client": {
+                    "$ref": "#/definitions/models.User"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "comment": {
+                    "description": "Comment — комментарий клиента для мастера",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "hold_until": {
+                    "description": "HoldUntil — запись ждёт оплаты (awaiting_payment) и держит слот до этого времени",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "payment": {
+                    "$ref": "#/definitions/models.Payment"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "slot": {
+                    "description": "Expose slot in JSON so Telegram can render date/time/service/master",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Slot"
+                        }
+                    ]
+                },
+                "slot_id": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "variant_id": {
+                    "description": "VariantID и AddOnIDs — выбор клиента при записи; без варианта действуют цена и длительность услуги",
+                    "type": "integer"
+                },
+                "variant_name": {
+                    "description": "VariantName, Price, Currency, Duration и AddOns — снимок на момент записи: изменение прайса их не меняет",
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "admin.AdminLoginRequest": {
+        },
+        "models.RecordAddOn": {
             "type": "object",
-            "required": [
-                "password"
-            ],
             "properties": {
-                "password": {
+                "add_on_id": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
+                },
+                "price": {
+                    "type": "integer"
                 }
             }
         },
-        "admin.AdminLoginResponse": {
+        "models.Resource": {
             "type": "object",
             "properties": {
-                "message": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization_id": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "admin.AdminStatsResponse": {
+        "models.Review": {
             "type": "object",
             "properties": {
-                "active_users": {
-                    "type": "integer"
+                "client_id": {
+                    "type": "string"
                 },
-                "ad_clicks_1": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "ad_clicks_2": {
-                    "type": "integer"
+                "flagged": {
+                    "type": "boolean"
                 },
-                "booked_slots": {
-                    "type": "integer"
+                "hidden": {
+                    "type": "boolean"
                 },
-                "confirmed_records": {
+                "id": {
                     "type": "integer"
                 },
-                "pending_records": {
-                    "type": "integer"
+                "master_id": {
+                    "type": "string"
                 },
-                "rejected_records": {
+                "rating": {
                     "type": "integer"
                 },
-                "total_records": {
+                "record_id": {
                     "type": "integer"
                 },
-                "total_services": {
-                    "type": "integer"
+                "replied_at": {
+                    "type": "string"
                 },
-                "total_slots": {
-                    "type": "integer"
+                "reply": {
+                    "type": "string"
                 },
-                "total_users": {
+                "service_id": {
                     "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "admin.UserDetailResponse": {
+        "models.Service": {
             "type": "object",
             "properties": {
-                "records": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Record"
-                    }
-                },
-                "services": {
+                "add_ons": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Service"
+                        "$ref": "#/definitions/models.ServiceAddOn"
                     }
                 },
-                "slots": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Slot"
-                    }
+                "category_id": {
+                    "description": "CategoryID — категория мастера; меняется через PUT /service/{id}/category",
+                    "type": "integer"
                 },
-                "user": {
-                    "$ref": "#/definitions/admin.UserInfo"
-                }
-            }
-        },
-        "admin.UserInfo": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+                "currency": {
                     "type": "string"
                 },
-                "first_name": {
+                "deleted_at": {
+                    "description": "DeletedAt — услуга в архиве: скрыта из каталога, но остаётся в истории записей",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
+                },
+                "deposit": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
+                "duration": {
+                    "type": "integer"
+                },
                 "id": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "location_id": {
+                    "description": "LocationID — где оказывается услуга; новые слоты получают это место",
+                    "type": "integer"
                 },
-                "phone": {
+                "master_id": {
                     "type": "string"
                 },
-                "roles": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "name": {
+                    "type": "string"
                 },
-                "surname": {
+                "organization_id": {
+                    "description": "OrganizationID — организация, которой принадлежит услуга; MasterID — назначенный мастер",
                     "type": "string"
                 },
-                "telegram_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "admin.UserListResponse": {
-            "type": "object",
-            "properties": {
-                "limit": {
-                    "type": "integer"
+                "prepayment": {
+                    "description": "Prepayment — что клиент оплачивает при записи: \"\" — ничего, deposit — задаток Deposit, full — всю цену записи",
+                    "type": "string"
                 },
-                "page": {
+                "price": {
+                    "description": "Price — в минимальных единицах Currency (копейки, центы), см. contract/money",
                     "type": "integer"
                 },
-                "total": {
+                "rating": {
+                    "description": "Rating и RatingCount — агрегат видимых отзывов, пересчитывается при каждом изменении отзыва",
+                    "type": "number"
+                },
+                "rating_count": {
                     "type": "integer"
                 },
-                "users": {
+                "variants": {
+                    "description": "Variants и AddOns — варианты и опции услуги; без вариантов действуют Price и Duration.\nМеняются только через свои маршруты, Create и Save услуги их не трогают",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/admin.UserInfo"
+                        "$ref": "#/definitions/models.ServiceVariant"
                     }
                 }
             }
         },
-        "metrics.clickReq": {
+        "models.ServiceAddOn": {
             "type": "object",
             "properties": {
-                "slot": {
+                "duration": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.Record": {
-            "type": "object",
-            "properties": {
-                "client": {
-                    "$ref": "#/definitions/models.User"
                 },
-                "client_id": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "created_at": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "position": {
                     "type": "integer"
                 },
-                "slot": {
-                    "description": "Expose slot in JSON so Telegram can render date/time/service/master",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.Slot"
-                        }
-                    ]
-                },
-                "slot_id": {
+                "price": {
                     "type": "integer"
                 },
-                "status": {
-                    "type": "string"
+                "service_id": {
+                    "type": "integer"
                 }
             }
         },
-        "models.Service": {
+        "models.ServiceVariant": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
-                },
                 "duration": {
                     "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "master_id": {
-                    "type": "string"
-                },
                 "name": {
                     "type": "string"
                 },
+                "position": {
+                    "type": "integer"
+                },
                 "price": {
-                    "type": "number"
+                    "type": "integer"
+                },
+                "service_id": {
+                    "type": "integer"
                 }
             }
         },
         "models.Slot": {
             "type": "object",
             "properties": {
+                "deleted_at": {
+                    "description": "DeletedAt — слот в архиве; записи на него сохраняются",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
+                },
                 "end_time": {
                     "type": "string"
                 },
@@ -2692,6 +7767,13 @@ const docTemplate = `{
                 "is_booked": {
                     "type": "boolean"
                 },
+                "location": {
+                    "$ref": "#/definitions/models.Location"
+                },
+                "location_id": {
+                    "description": "LocationID — место услуги на момент создания слота",
+                    "type": "integer"
+                },
                 "master": {
                     "$ref": "#/definitions/models.User"
                 },
@@ -2718,18 +7800,53 @@ const docTemplate = `{
                 "consent_given_at": {
                     "type": "string"
                 },
+                "currency": {
+                    "description": "Currency — ISO 4217, валюта новых услуг мастера",
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "DeletedAt — пользователь в архиве; PurgedAt — когда по сроку хранения стёрты его персональные данные",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/gorm.DeletedAt"
+                        }
+                    ]
+                },
+                "digest_time": {
+                    "type": "string"
+                },
+                "directory_opt_out": {
+                    "description": "DirectoryOptOut — мастер не показывается в каталоге и inline-поиске, прямые ссылки работают",
+                    "type": "boolean"
+                },
                 "first_name": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
+                "language": {
+                    "type": "string"
+                },
                 "phone": {
                     "type": "string"
                 },
+                "phone_verified_at": {
+                    "type": "string"
+                },
                 "privacy_policy_accepted_at": {
                     "type": "string"
                 },
+                "purged_at": {
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "Rating и RatingCount — средняя оценка мастера и число видимых отзывов (пересчитывает usecase/review)",
+                    "type": "number"
+                },
+                "rating_count": {
+                    "type": "integer"
+                },
                 "roles": {
                     "type": "array",
                     "items": {
@@ -2767,6 +7884,20 @@ const docTemplate = `{
                 }
             }
         },
+        "models.WorkingHours": {
+            "type": "object",
+            "properties": {
+                "closes": {
+                    "type": "string"
+                },
+                "opens": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer"
+                }
+            }
+        },
         "user.PublicUserResponse": {
             "type": "object",
             "properties": {
@@ -2776,6 +7907,9 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "language": {
+                    "type": "string"
+                },
                 "services": {
                     "type": "array",
                     "items": {
@@ -2784,6 +7918,40 @@ const docTemplate = `{
                 },
                 "surname": {
                     "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "user.TelegramWidgetLogin": {
+            "type": "object",
+            "required": [
+                "auth_date",
+                "hash",
+                "id"
+            ],
+            "properties": {
+                "auth_date": {
+                    "type": "integer"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
                 }
             }
         },
@@ -2793,6 +7961,9 @@ const docTemplate = `{
                 "first_name": {
                     "type": "string"
                 },
+                "language": {
+                    "type": "string"
+                },
                 "phone": {
                     "type": "string"
                 },
@@ -1,29 +1,51 @@
 package encoder
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt"
 	"github.com/google/uuid"
 )
 
-var secretKey = []byte("ZZZ_VVV")
+// tokenTTL — срок жизни выданного токена
+const tokenTTL = 12 * time.Hour
 
-// generateToken возвращает JWT-токен по user_id и secretKey, исключение
-func GenerateToken(userID uuid.UUID) (string, error) {
+// Encoder выпускает и проверяет JWT-токены сессий, подписанные секретом из конфигурации
+type Encoder struct {
+	secret []byte
+}
+
+// New создаёт Encoder с секретом подписи (security.jwt_secret / JWT_SECRET)
+func New(secret string) *Encoder {
+	return &Encoder{secret: []byte(secret)}
+}
+
+// GenerateToken возвращает JWT-токен по user_id, исключение
+func (e *Encoder) GenerateToken(userID uuid.UUID) (string, error) {
+	if len(e.secret) == 0 {
+		return "", errors.New("jwt secret is not configured")
+	}
 	claims := jwt.MapClaims{
 		"user_id": userID.String(),
-		"exp":     time.Now().Add(time.Hour * 12).Unix(),
+		"exp":     time.Now().Add(tokenTTL).Unix(),
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(secretKey)
-
+	return token.SignedString(e.secret)
 }
 
-// parseToken расшифровывает JWT-токен, возвращает токен и исключение
-func ParseToken(tokenString string) (*jwt.Token, error) {
+// ParseToken расшифровывает JWT-токен, возвращает токен и исключение.
+// Принимаются только токены, подписанные HMAC
+func (e *Encoder) ParseToken(tokenString string) (*jwt.Token, error) {
 	return jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return secretKey, nil
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		if len(e.secret) == 0 {
+			return nil, errors.New("jwt secret is not configured")
+		}
+		return e.secret, nil
 	})
 }
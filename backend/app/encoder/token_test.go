@@ -0,0 +1,46 @@
+package encoder_test
+
+import (
+	"app/encoder"
+	"testing"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/google/uuid"
+)
+
+func TestTokenRoundTrip(t *testing.T) {
+	enc := encoder.New("first-secret")
+	id := uuid.New()
+	token, err := enc.GenerateToken(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := enc.ParseToken(token)
+	if err != nil || !parsed.Valid {
+		t.Fatalf("ParseToken() error = %v", err)
+	}
+	if got := parsed.Claims.(jwt.MapClaims)["user_id"]; got != id.String() {
+		t.Errorf("user_id = %v, want %s", got, id)
+	}
+
+	if _, err := encoder.New("second-secret").ParseToken(token); err == nil {
+		t.Error("ParseToken() accepted a token signed with another secret")
+	}
+}
+
+func TestTokenRejectsUnsigned(t *testing.T) {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"user_id": uuid.NewString()}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := encoder.New("secret").ParseToken(token); err == nil {
+		t.Error("ParseToken() accepted an unsigned token")
+	}
+}
+
+func TestEmptySecret(t *testing.T) {
+	if _, err := encoder.New("").GenerateToken(uuid.New()); err == nil {
+		t.Error("GenerateToken() with an empty secret returned nil error")
+	}
+}
@@ -1,10 +1,8 @@
 package admin
 
 import (
-	"app/encoder"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -31,9 +29,9 @@ func (h *Handler) AdminLogin(ctx *gin.Context) {
 		return
 	}
 
-	expectedPassword := os.Getenv("ADMIN_PASSWORD")
+	expectedPassword := h.adminPassword
 	if expectedPassword == "" {
-		h.logger.Errorf("AdminLogin: ADMIN_PASSWORD environment variable is not set")
+		h.logger.Errorf("AdminLogin: admin password is not configured (security.admin_password / ADMIN_PASSWORD)")
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Server configuration error"})
 		return
 	}
@@ -45,7 +43,7 @@ func (h *Handler) AdminLogin(ctx *gin.Context) {
 
 	// Generate JWT token for admin (using fixed ID)
 	adminID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	token, err := encoder.GenerateToken(adminID)
+	token, err := h.tokens.GenerateToken(adminID)
 	if err != nil {
 		h.logger.Errorf("Handler.AdminLogin: failed to generate token: %v", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Token generation failed"})
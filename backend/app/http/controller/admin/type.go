@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"app/encoder"
 	"app/http/repository/metrics"
 	"app/http/repository/record"
 	"app/http/repository/service"
@@ -27,6 +28,9 @@ type Handler struct {
 	serviceServ *serviceServ.Service
 
 	logger Logger
+
+	adminPassword string
+	tokens        *encoder.Encoder
 }
 
 type Logger interface {
@@ -60,6 +64,18 @@ func NewHandler(
 	}
 }
 
+// WithAdminPassword задаёт пароль для входа в админку
+func (h *Handler) WithAdminPassword(password string) *Handler {
+	h.adminPassword = password
+	return h
+}
+
+// WithTokenEncoder задаёт выпуск JWT-токенов для входа в админку
+func (h *Handler) WithTokenEncoder(tokens *encoder.Encoder) *Handler {
+	h.tokens = tokens
+	return h
+}
+
 // AdminStatsResponse структура для статистики
 type AdminStatsResponse struct {
 	TotalUsers       int64 `json:"total_users"`
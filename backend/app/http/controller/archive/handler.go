@@ -0,0 +1,198 @@
+package archive
+
+import (
+	ucase "app/http/usecase/archive"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetArchivedUsers returns deleted users
+// @Summary Archived users
+// @Description Deleted users, most recently deleted first; purged users have empty personal data (admin)
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} models.User
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /admin/archive/users [get]
+func (h *Handler) GetArchivedUsers(ctx *gin.Context) {
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.Users(limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetArchivedUsers", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetArchivedServices returns deleted services
+// @Summary Archived services
+// @Description Deleted services, most recently deleted first (admin)
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} models.Service
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /admin/archive/services [get]
+func (h *Handler) GetArchivedServices(ctx *gin.Context) {
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.Services(limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetArchivedServices", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetArchivedSlots returns deleted slots
+// @Summary Archived slots
+// @Description Deleted slots with their service, most recently deleted first (admin)
+// @Tags admin
+// @Produce json
+// @Param limit query int false "Page size (default 50, max 200)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} models.Slot
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /admin/archive/slots [get]
+func (h *Handler) GetArchivedSlots(ctx *gin.Context) {
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.Slots(limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetArchivedSlots", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// RestoreUser restores a deleted user
+// @Summary Restore user
+// @Description Restores the user with the services and slots deleted together with them. Cancelled records stay cancelled (admin)
+// @Tags admin
+// @Produce json
+// @Param id path string true "User ID"
+// @Success 200 {object} models.User
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Failure 410 {object} contract.ErrorResponse
+// @Router /admin/archive/users/{id}/restore [post]
+func (h *Handler) RestoreUser(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+	u, err := h.service.RestoreUser(id)
+	if err != nil {
+		h.actionError(ctx, "RestoreUser", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, u)
+}
+
+// RestoreService restores a deleted service
+// @Summary Restore service
+// @Description Restores the service with the slots deleted together with it; the master must not be archived (admin)
+// @Tags admin
+// @Produce json
+// @Param id path int true "Service ID"
+// @Success 200 {object} models.Service
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /admin/archive/services/{id}/restore [post]
+func (h *Handler) RestoreService(ctx *gin.Context) {
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	svc, err := h.service.RestoreService(id)
+	if err != nil {
+		h.actionError(ctx, "RestoreService", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, svc)
+}
+
+// RestoreSlot restores a deleted slot
+// @Summary Restore slot
+// @Description Restores the slot as free; its master and service must not be archived, rejected records stay rejected (admin)
+// @Tags admin
+// @Produce json
+// @Param id path int true "Slot ID"
+// @Success 200 {object} models.Slot
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /admin/archive/slots/{id}/restore [post]
+func (h *Handler) RestoreSlot(ctx *gin.Context) {
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	sl, err := h.service.RestoreSlot(id)
+	if err != nil {
+		h.actionError(ctx, "RestoreSlot", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, sl)
+}
+
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrNotArchived):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrParentArchived), errors.Is(err, ucase.ErrContactTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrPurged):
+		ctx.JSON(http.StatusGone, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "archive request failed"})
+	}
+}
+
+func uintParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// page разбирает limit и offset; пустые значения оставляют размер страницы по умолчанию
+func page(ctx *gin.Context) (int, int, bool) {
+	var values [2]int
+	for i, name := range []string{"limit", "offset"} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+			return 0, 0, false
+		}
+		values[i] = n
+	}
+	return values[0], values[1], true
+}
@@ -0,0 +1,19 @@
+package archive
+
+import (
+	"app/http/usecase/archive"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *archive.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *archive.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
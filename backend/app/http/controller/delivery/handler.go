@@ -0,0 +1,52 @@
+package delivery
+
+import (
+	ucase "app/http/usecase/delivery"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ReportDelivery records the result of sending a notification (internal, Telegram)
+// @Summary Report Telegram delivery
+// @Description Telegram bot reports whether a queued notification was delivered (outbox bookkeeping)
+// @Tags telegram
+// @Accept json
+// @Produce json
+// @Param id path string true "Delivery ID"
+// @Param request body contract.DeliveryReport true "Delivery result"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /telegram/delivery/{id} [post]
+func (h *Handler) ReportDelivery(ctx *gin.Context) {
+	id, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid delivery id"})
+		return
+	}
+	var body ucase.Report
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.ReportDelivery: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+
+	err = h.service.Report(id, body)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, gin.H{"message": "Delivery recorded"})
+	case errors.Is(err, ucase.ErrInvalidStatus):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrAlreadyFinal):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.ReportDelivery: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record delivery"})
+	}
+}
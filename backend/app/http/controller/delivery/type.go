@@ -0,0 +1,19 @@
+package delivery
+
+import (
+	"app/http/usecase/delivery"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *delivery.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *delivery.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
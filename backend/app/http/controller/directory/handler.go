@@ -0,0 +1,151 @@
+package directory
+
+import (
+	"app/http/usecase/directory"
+	"app/pkg/geo"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SearchMasters returns masters matching the inline query of the bot
+// @Summary Search masters
+// @Description Search masters by name or service name with their services and next free slots (internal, inline mode of the bot)
+// @Tags master
+// @Produce json
+// @Param q query string false "Master name or service name; empty returns all masters"
+// @Param limit query int false "Maximum number of masters (default 10, max 20)"
+// @Success 200 {object} contract.MasterSearch
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /telegram/master/search [get]
+func (h *Handler) SearchMasters(ctx *gin.Context) {
+	limit := 0
+	if raw := ctx.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			h.logger.Errorf("Handler.SearchMasters: invalid limit %q", raw)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid limit"})
+			return
+		}
+		limit = n
+	}
+
+	cards, err := h.service.Search(ctx.Query("q"), limit)
+	if err != nil {
+		h.logger.Errorf("Handler.SearchMasters: service error: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search masters"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, contract.MasterSearch{
+		Message: "Success",
+		Data:    cards,
+	})
+}
+
+// DiscoverMasters returns the public master directory
+// @Summary Master directory
+// @Description Public discovery of masters: full-text search over master names, service names and descriptions (Russian and English), filters by price, duration, distance from a point and free slots within N days. Masters who opted out are not listed.
+// @Tags directory
+// @Produce json
+// @Param q query string false "Search text (websearch syntax: quotes, -word, or)"
+// @Param min_price query int false "Minimum service price in minor currency units (kopecks, cents)"
+// @Param max_price query int false "Maximum service price in minor currency units"
+// @Param currency query string false "Only services priced in this ISO 4217 currency"
+// @Param min_duration query int false "Minimum service duration, minutes"
+// @Param max_duration query int false "Maximum service duration, minutes"
+// @Param lat query number false "Latitude of the search point"
+// @Param lon query number false "Longitude of the search point"
+// @Param radius_km query number false "Only services within this distance from lat/lon (up to 500 km)"
+// @Param free_within_days query int false "Only masters with a free slot within N days (up to 60)"
+// @Param sort query string false "relevance (default with q), next_slot (default), rating or distance (needs lat/lon)"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} contract.DirectorySearch
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /directory/masters [get]
+func (h *Handler) DiscoverMasters(ctx *gin.Context) {
+	q, err := directoryQuery(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	masters, err := h.service.Discover(q)
+	switch {
+	case errors.Is(err, directory.ErrInvalidFilter), errors.Is(err, directory.ErrInvalidPoint),
+		errors.Is(err, directory.ErrInvalidRadius), errors.Is(err, directory.ErrInvalidWindow),
+		errors.Is(err, directory.ErrInvalidSort), errors.Is(err, directory.ErrInvalidCurrency):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		h.logger.Errorf("Handler.DiscoverMasters: service error: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to search masters"})
+		return
+	}
+	limit := min(q.Limit, directory.DiscoverMaxLimit)
+	if limit <= 0 {
+		limit = directory.DiscoverDefaultLimit
+	}
+	ctx.JSON(http.StatusOK, contract.DirectorySearch{
+		Message: "Success",
+		Data:    masters,
+		Limit:   limit,
+		Offset:  q.Offset,
+	})
+}
+
+// directoryQuery разбирает параметры каталога; проверку диапазонов делает usecase
+func directoryQuery(ctx *gin.Context) (models.DirectoryQuery, error) {
+	q := models.DirectoryQuery{Text: ctx.Query("q"), Sort: ctx.Query("sort"), Currency: ctx.Query("currency")}
+	if raw := ctx.Query("radius_km"); raw != "" {
+		v, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return q, fmt.Errorf("invalid radius_km")
+		}
+		q.RadiusKm = v
+	}
+	prices := map[string]*int64{"min_price": &q.MinPrice, "max_price": &q.MaxPrice}
+	for name, dst := range prices {
+		if raw := ctx.Query(name); raw != "" {
+			v, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil {
+				return q, fmt.Errorf("invalid %s", name)
+			}
+			*dst = v
+		}
+	}
+	var days int
+	ints := map[string]*int{"min_duration": &q.MinDuration, "max_duration": &q.MaxDuration,
+		"free_within_days": &days, "limit": &q.Limit, "offset": &q.Offset}
+	for name, dst := range ints {
+		if raw := ctx.Query(name); raw != "" {
+			v, err := strconv.Atoi(raw)
+			if err != nil {
+				return q, fmt.Errorf("invalid %s", name)
+			}
+			*dst = v
+		}
+	}
+	q.FreeWithin = time.Duration(days) * 24 * time.Hour
+
+	lat, lon := ctx.Query("lat"), ctx.Query("lon")
+	if lat != "" || lon != "" {
+		var p geo.Point
+		var err1, err2 error
+		p.Latitude, err1 = strconv.ParseFloat(lat, 64)
+		p.Longitude, err2 = strconv.ParseFloat(lon, 64)
+		if err1 != nil || err2 != nil {
+			return q, directory.ErrInvalidPoint
+		}
+		q.Near = &p
+	}
+	return q, nil
+}
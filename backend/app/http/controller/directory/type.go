@@ -0,0 +1,19 @@
+package directory
+
+import (
+	"app/http/usecase/directory"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *directory.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *directory.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,209 @@
+package location
+
+import (
+	ucase "app/http/usecase/location"
+	orgUcase "app/http/usecase/organization"
+	"app/http/utils"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetLocations returns locations of an organization
+// @Summary Organization locations
+// @Description Addresses where the organization receives clients, with coordinates, timezone and working hours (members only)
+// @Tags location
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Success 200 {array} models.Location
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/locations [get]
+func (h *Handler) GetLocations(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetLocations")
+	if !ok {
+		return
+	}
+	list, err := h.service.List(orgID, userID)
+	if err != nil {
+		h.actionError(ctx, "GetLocations", err)
+		return
+	}
+	if list == nil {
+		list = []models.Location{}
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// CreateLocation adds a location to an organization
+// @Summary Create location
+// @Description Add an address with optional coordinates, an IANA timezone and weekly working hours; empty hours mean no limit (owner or manager)
+// @Tags location
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param request body contract.LocationRequest true "Location"
+// @Success 200 {object} models.Location
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/locations [post]
+func (h *Handler) CreateLocation(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "CreateLocation")
+	if !ok {
+		return
+	}
+	var req contract.LocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	l, err := h.service.Create(orgID, userID, req)
+	if err != nil {
+		h.actionError(ctx, "CreateLocation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, l)
+}
+
+// UpdateLocation changes a location
+// @Summary Update location
+// @Description Replace address, coordinates, timezone and working hours; existing slots keep the location (owner or manager)
+// @Tags location
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param location_id path int true "Location ID"
+// @Param request body contract.LocationRequest true "Location"
+// @Success 200 {object} models.Location
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/locations/{location_id} [put]
+func (h *Handler) UpdateLocation(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "UpdateLocation")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "location_id")
+	if !ok {
+		return
+	}
+	var req contract.LocationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	l, err := h.service.Update(orgID, userID, id, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateLocation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, l)
+}
+
+// DeleteLocation deletes a location
+// @Summary Delete location
+// @Description Delete a location; its services and slots are left without an address (owner or manager)
+// @Tags location
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param location_id path int true "Location ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/locations/{location_id} [delete]
+func (h *Handler) DeleteLocation(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "DeleteLocation")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "location_id")
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(orgID, userID, id); err != nil {
+		h.actionError(ctx, "DeleteLocation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Location deleted"})
+}
+
+// SetServiceLocation sets the location of a service
+// @Summary Set service location
+// @Description New slots of the service take place at this location and must fit its working hours; location_id 0 detaches the service (owner or manager)
+// @Tags location
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param service_id path int true "Service ID"
+// @Param request body contract.ServiceLocation true "Location"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/services/{service_id}/location [put]
+func (h *Handler) SetServiceLocation(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "SetServiceLocation")
+	if !ok {
+		return
+	}
+	serviceID, ok := uintParam(ctx, "service_id")
+	if !ok {
+		return
+	}
+	var req contract.ServiceLocation
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.SetServiceLocation(orgID, userID, serviceID, req.LocationID); err != nil {
+		h.actionError(ctx, "SetServiceLocation", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Service location updated"})
+}
+
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidLocation), errors.Is(err, ucase.ErrInvalidCoordinates),
+		errors.Is(err, ucase.ErrInvalidTimezone), errors.Is(err, ucase.ErrInvalidHours):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, orgUcase.ErrNotMember), errors.Is(err, orgUcase.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrLocationNotFound), errors.Is(err, ucase.ErrServiceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "location request failed"})
+	}
+}
+
+func (h *Handler) organization(ctx *gin.Context, name string) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	orgID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return orgID, userID, true
+}
+
+func uintParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,19 @@
+package location
+
+import (
+	"app/http/usecase/location"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *location.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *location.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
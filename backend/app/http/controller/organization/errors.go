@@ -0,0 +1,34 @@
+package organization
+
+import (
+	ucase "app/http/usecase/organization"
+	recordUcase "app/http/usecase/record"
+	resourceUcase "app/http/usecase/resource"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// actionError переводит ошибку usecase организации в HTTP-статус
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidName), errors.Is(err, ucase.ErrInvalidRole),
+		errors.Is(err, ucase.ErrNotAssignable), errors.Is(err, ucase.ErrInvalidService),
+		errors.Is(err, ucase.ErrInvalidStatus), errors.Is(err, ucase.ErrInvalidPeriod),
+		errors.Is(err, ucase.ErrInvalidCurrency):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrNotMember), errors.Is(err, ucase.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrOrganizationNotFound), errors.Is(err, ucase.ErrUserNotFound),
+		errors.Is(err, ucase.ErrServiceNotFound), errors.Is(err, ucase.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrAlreadyMember), errors.Is(err, ucase.ErrLastOwner),
+		errors.Is(err, recordUcase.ErrRecordClosed),
+		errors.Is(err, resourceUcase.ErrResourceBusy), errors.Is(err, resourceUcase.ErrResourceUnavailable):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "organization request failed"})
+	}
+}
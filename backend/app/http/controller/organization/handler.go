@@ -0,0 +1,434 @@
+package organization
+
+import (
+	"app/http/utils"
+	"app/pkg/models"
+	"contract"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CreateOrganization creates an organization owned by the caller
+// @Summary Create organization
+// @Description Create a salon; the caller becomes its owner
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param request body contract.OrganizationCreate true "Organization name"
+// @Success 200 {object} models.Organization
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Router /organization [post]
+func (h *Handler) CreateOrganization(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "CreateOrganization")
+	if !ok {
+		return
+	}
+	var req contract.OrganizationCreate
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	org, err := h.service.Create(userID, req.Name)
+	if err != nil {
+		h.actionError(ctx, "CreateOrganization", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, org)
+}
+
+// GetOrganizations returns organizations of the caller
+// @Summary My organizations
+// @Description Organizations the caller is a member of, with members and roles
+// @Tags organization
+// @Produce json
+// @Success 200 {array} models.Organization
+// @Failure 401 {object} contract.ErrorResponse
+// @Router /organization [get]
+func (h *Handler) GetOrganizations(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "GetOrganizations")
+	if !ok {
+		return
+	}
+	orgs, err := h.service.List(userID)
+	if err != nil {
+		h.actionError(ctx, "GetOrganizations", err)
+		return
+	}
+	if orgs == nil {
+		orgs = []models.Organization{}
+	}
+	ctx.JSON(http.StatusOK, orgs)
+}
+
+// GetOrganization returns an organization with members
+// @Summary Get organization
+// @Description Organization with members and roles (members only)
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Success 200 {object} models.Organization
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id} [get]
+func (h *Handler) GetOrganization(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetOrganization")
+	if !ok {
+		return
+	}
+	org, err := h.service.Get(orgID, userID)
+	if err != nil {
+		h.actionError(ctx, "GetOrganization", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, org)
+}
+
+// RenameOrganization renames an organization
+// @Summary Rename organization
+// @Description Rename organization (owner or manager)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param request body contract.OrganizationCreate true "New name"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id} [put]
+func (h *Handler) RenameOrganization(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "RenameOrganization")
+	if !ok {
+		return
+	}
+	var req contract.OrganizationCreate
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.Rename(orgID, userID, req.Name); err != nil {
+		h.actionError(ctx, "RenameOrganization", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Organization renamed"})
+}
+
+// AddMember adds a user to an organization
+// @Summary Add member
+// @Description Add a registered user by phone or telegram_id. Owners grant any role, managers only master and receptionist
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param request body contract.OrganizationMemberAdd true "User and role"
+// @Success 200 {object} models.OrganizationMember
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /organization/{id}/members [post]
+func (h *Handler) AddMember(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "AddMember")
+	if !ok {
+		return
+	}
+	var req contract.OrganizationMemberAdd
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	m, err := h.service.AddMember(orgID, userID, req)
+	if err != nil {
+		h.actionError(ctx, "AddMember", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, m)
+}
+
+// UpdateMemberRole changes the role of a member
+// @Summary Change member role
+// @Description Change the role of a member; the last owner cannot be demoted
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param user_id path string true "Member user UUID"
+// @Param request body contract.OrganizationMemberRole true "New role"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /organization/{id}/members/{user_id} [put]
+func (h *Handler) UpdateMemberRole(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "UpdateMemberRole")
+	if !ok {
+		return
+	}
+	memberID, ok := h.uuidParam(ctx, "user_id")
+	if !ok {
+		return
+	}
+	var req contract.OrganizationMemberRole
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.UpdateMemberRole(orgID, userID, memberID, req.Role); err != nil {
+		h.actionError(ctx, "UpdateMemberRole", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Role updated"})
+}
+
+// RemoveMember removes a member or lets the caller leave
+// @Summary Remove member
+// @Description Remove a member (owner or manager) or leave the organization (user_id of the caller)
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param user_id path string true "Member user UUID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /organization/{id}/members/{user_id} [delete]
+func (h *Handler) RemoveMember(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "RemoveMember")
+	if !ok {
+		return
+	}
+	memberID, ok := h.uuidParam(ctx, "user_id")
+	if !ok {
+		return
+	}
+	if err := h.service.RemoveMember(orgID, userID, memberID); err != nil {
+		h.actionError(ctx, "RemoveMember", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Member removed"})
+}
+
+// GetServices returns services of an organization
+// @Summary Organization services
+// @Description Services owned by the organization with assigned masters (members only)
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Success 200 {array} models.Service
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/services [get]
+func (h *Handler) GetServices(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetServices")
+	if !ok {
+		return
+	}
+	services, err := h.service.Services(orgID, userID)
+	if err != nil {
+		h.actionError(ctx, "GetServices", err)
+		return
+	}
+	if services == nil {
+		services = []models.Service{}
+	}
+	ctx.JSON(http.StatusOK, services)
+}
+
+// CreateService creates a service of an organization assigned to a master
+// @Summary Create organization service
+// @Description Create a service owned by the organization and assign it to a member (owner or manager)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param request body contract.OrganizationService true "Service and assigned master"
+// @Success 200 {object} models.Service
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/services [post]
+func (h *Handler) CreateService(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "CreateService")
+	if !ok {
+		return
+	}
+	var req contract.OrganizationService
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	svc, err := h.service.CreateService(orgID, userID, req)
+	if err != nil {
+		h.actionError(ctx, "CreateService", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, svc)
+}
+
+// AssignService assigns a service of an organization to another master
+// @Summary Assign organization service
+// @Description Assign the service to another member; existing slots stay with the previous master (owner or manager)
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param service_id path int true "Service ID"
+// @Param request body contract.OrganizationServiceAssign true "New master"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/services/{service_id}/master [put]
+func (h *Handler) AssignService(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "AssignService")
+	if !ok {
+		return
+	}
+	serviceID, err := strconv.ParseUint(ctx.Param("service_id"), 10, 0)
+	if err != nil || serviceID == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid service_id"})
+		return
+	}
+	var req contract.OrganizationServiceAssign
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.AssignService(orgID, userID, uint(serviceID), req.MasterID); err != nil {
+		h.actionError(ctx, "AssignService", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Service assigned"})
+}
+
+// GetSlots returns slots of all masters of an organization
+// @Summary Organization slots
+// @Description Slots for the organization services starting in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, exclusive (YYYY-MM-DD)"
+// @Success 200 {array} contract.Slot
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/slots [get]
+func (h *Handler) GetSlots(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetSlots")
+	if !ok {
+		return
+	}
+	from, to, ok := utils.DatePeriod(ctx)
+	if !ok {
+		return
+	}
+	slots, err := h.service.Slots(orgID, userID, from, to)
+	if err != nil {
+		h.actionError(ctx, "GetSlots", err)
+		return
+	}
+	out := make([]contract.Slot, 0, len(slots))
+	for _, sl := range slots {
+		out = append(out, sl.Contract())
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// GetRecords returns records of all masters of an organization
+// @Summary Organization records
+// @Description Records on the organization slots starting in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days
+// @Tags organization
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param status query string false "Record status (pending, confirm, reject, cancel)"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, exclusive (YYYY-MM-DD)"
+// @Success 200 {array} contract.Record
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/records [get]
+func (h *Handler) GetRecords(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetRecords")
+	if !ok {
+		return
+	}
+	from, to, ok := utils.DatePeriod(ctx)
+	if !ok {
+		return
+	}
+	records, err := h.service.Records(orgID, userID, ctx.Query("status"), from, to)
+	if err != nil {
+		h.actionError(ctx, "GetRecords", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, models.ContractRecords(records))
+}
+
+// UpdateRecordStatus confirms or rejects a record on behalf of its master
+// @Summary Confirm or reject record (manager)
+// @Description Owner or manager confirms or rejects a record on the organization slots; the client is notified as if the master decided
+// @Tags organization
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param record_id path int true "Record ID"
+// @Param request body contract.OrganizationRecordStatus true "confirm or reject"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /organization/{id}/records/{record_id}/status [post]
+func (h *Handler) UpdateRecordStatus(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "UpdateRecordStatus")
+	if !ok {
+		return
+	}
+	recordID, err := strconv.ParseUint(ctx.Param("record_id"), 10, 0)
+	if err != nil || recordID == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid record_id"})
+		return
+	}
+	var req contract.OrganizationRecordStatus
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.UpdateRecordStatus(orgID, userID, uint(recordID), req.Status); err != nil {
+		h.actionError(ctx, "UpdateRecordStatus", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Success"})
+}
+
+// currentUser — пользователь сессии или мастер, от имени которого действует бот
+func (h *Handler) currentUser(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// organization разбирает id организации из пути и текущего пользователя
+func (h *Handler) organization(ctx *gin.Context, name string) (uuid.UUID, uuid.UUID, bool) {
+	userID, ok := h.currentUser(ctx, name)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	orgID, ok := h.uuidParam(ctx, "id")
+	return orgID, userID, ok
+}
+
+func (h *Handler) uuidParam(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(ctx.Param(name))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return uuid.Nil, false
+	}
+	return id, true
+}
@@ -0,0 +1,19 @@
+package organization
+
+import (
+	"app/http/usecase/organization"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *organization.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *organization.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
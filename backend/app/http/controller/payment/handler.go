@@ -0,0 +1,50 @@
+package payment
+
+import (
+	ucase "app/http/usecase/payment"
+	"app/pkg/payment"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookBody — вебхуки провайдеров укладываются в несколько килобайт
+const maxWebhookBody = 64 << 10
+
+// PaymentWebhook applies a payment provider notification
+// @Summary Payment provider webhook
+// @Description Payment status notification from the configured provider. The fake provider signs the body with HMAC-SHA256 in X-Payment-Signature; YooKassa notifications are verified by re-reading the payment from its API. A successful payment confirms the record, a late one is refunded
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param X-Payment-Signature header string false "hex HMAC-SHA256 of the body (fake provider)"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /payment/webhook [post]
+func (h *Handler) PaymentWebhook(ctx *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(ctx.Request.Body, maxWebhookBody))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	err = h.service.HandleWebhook(ctx.Request.Context(), ctx.Request.Header, body)
+	switch {
+	case err == nil:
+		ctx.JSON(http.StatusOK, gin.H{"message": "OK"})
+	case errors.Is(err, payment.ErrInvalidSignature):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+	case errors.Is(err, payment.ErrInvalidEvent), errors.Is(err, ucase.ErrAmountMismatch):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrPaymentNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		// Провайдер повторит вебхук позже
+		h.logger.Errorf("Handler.PaymentWebhook: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to process payment"})
+	}
+}
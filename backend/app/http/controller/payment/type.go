@@ -0,0 +1,19 @@
+package payment
+
+import (
+	"app/http/usecase/payment"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *payment.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *payment.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,170 @@
+package record
+
+import (
+	ucase "app/http/usecase/record"
+	resourceUcase "app/http/usecase/resource"
+	"app/http/utils"
+	"contract"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CancelRecordByClient cancels the caller's record
+// @Summary Cancel record (client)
+// @Description Cancel own record; the slot is released and the master is notified
+// @Tags record
+// @Produce json
+// @Param record_id path int true "Record ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /record/client/{record_id}/cancel [post]
+func (h *Handler) CancelRecordByClient(ctx *gin.Context) {
+	recordID, clientID, ok := h.clientRecord(ctx, "CancelRecordByClient")
+	if !ok {
+		return
+	}
+	if err := h.service.CancelByClient(recordID, clientID); err != nil {
+		h.clientActionError(ctx, "CancelRecordByClient", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Record cancelled"})
+}
+
+// RescheduleRecordByClient moves the caller's record to another slot of the same service and master
+// @Summary Reschedule record (client)
+// @Description Move own record to another free slot of the same service and master; the record waits for confirmation again
+// @Tags record
+// @Accept json
+// @Produce json
+// @Param record_id path int true "Record ID"
+// @Param request body contract.RecordReschedule true "New slot"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /record/client/{record_id}/reschedule [post]
+func (h *Handler) RescheduleRecordByClient(ctx *gin.Context) {
+	recordID, clientID, ok := h.clientRecord(ctx, "RescheduleRecordByClient")
+	if !ok {
+		return
+	}
+	var body contract.RecordReschedule
+	if err := ctx.ShouldBindJSON(&body); err != nil || body.SlotID == 0 {
+		h.logger.Errorf("Handler.RescheduleRecordByClient: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "slot_id is required"})
+		return
+	}
+	if err := h.service.RescheduleByClient(recordID, clientID, body.SlotID); err != nil {
+		h.clientActionError(ctx, "RescheduleRecordByClient", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Record rescheduled"})
+}
+
+// CommentRecordByClient saves the caller's comment for the master
+// @Summary Comment record (client)
+// @Description Leave a comment for the master on own record; the master is notified
+// @Tags record
+// @Accept json
+// @Produce json
+// @Param record_id path int true "Record ID"
+// @Param request body contract.RecordComment true "Comment"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /record/client/{record_id}/comment [post]
+func (h *Handler) CommentRecordByClient(ctx *gin.Context) {
+	recordID, clientID, ok := h.clientRecord(ctx, "CommentRecordByClient")
+	if !ok {
+		return
+	}
+	var body contract.RecordComment
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.CommentRecordByClient: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.CommentByClient(recordID, clientID, body.Comment); err != nil {
+		h.clientActionError(ctx, "CommentRecordByClient", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Comment saved"})
+}
+
+// RecordCalendar returns the caller's record as an iCalendar file
+// @Summary Record calendar file (client)
+// @Description Download own record as .ics to add it to a calendar
+// @Tags record
+// @Produce text/calendar
+// @Param record_id path int true "Record ID"
+// @Success 200 {file} file
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /record/client/{record_id}/ics [get]
+func (h *Handler) RecordCalendar(ctx *gin.Context) {
+	recordID, clientID, ok := h.clientRecord(ctx, "RecordCalendar")
+	if !ok {
+		return
+	}
+	ics, err := h.service.CalendarByClient(recordID, clientID)
+	if err != nil {
+		h.clientActionError(ctx, "RecordCalendar", err)
+		return
+	}
+	ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="record-%d.ics"`, recordID))
+	ctx.Data(http.StatusOK, "text/calendar; charset=utf-8", ics)
+}
+
+// clientRecord разбирает record_id и текущего пользователя (сессия или бот от имени клиента)
+func (h *Handler) clientRecord(ctx *gin.Context, name string) (uint, uuid.UUID, bool) {
+	clientID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return 0, uuid.Nil, false
+	}
+	recordID, err := strconv.ParseUint(ctx.Param("record_id"), 10, 0)
+	if err != nil || recordID == 0 {
+		h.logger.Errorf("Handler.%s: invalid record_id: %q", name, ctx.Param("record_id"))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid record_id"})
+		return 0, uuid.Nil, false
+	}
+	return uint(recordID), clientID, true
+}
+
+// clientActionError переводит ошибку действия клиента в HTTP-статус
+func (h *Handler) clientActionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidComment):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrNotRecordOwner):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrRecordClosed), errors.Is(err, ucase.ErrRecordStarted),
+		errors.Is(err, ucase.ErrSlotUnavailable), errors.Is(err, ucase.ErrRecordExists), errors.Is(err, ucase.ErrSlotTooShort),
+		errors.Is(err, ucase.ErrSlotBooked), errors.Is(err, ucase.ErrSlotHeld), errors.Is(err, ucase.ErrAwaitingPayment),
+		errors.Is(err, resourceUcase.ErrResourceBusy), errors.Is(err, resourceUcase.ErrResourceUnavailable):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "failed to update record"})
+	}
+}
@@ -2,6 +2,7 @@ package record
 
 import (
 	"app/pkg/models"
+	"contract"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -91,17 +92,12 @@ func (h *Handler) GetClientRecords(ctx *gin.Context) {
 // @Tags record
 // @Accept json
 // @Produce json
-// @Param filter body object true "Filter request"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
+// @Param filter body contract.RecordFilter true "Filter request"
+// @Success 200 {object} contract.RecordPage
+// @Failure 400 {object} contract.ErrorResponse
 // @Router /record/user/filter [post]
 func (h *Handler) GetClientRecordsFiltered(ctx *gin.Context) {
-	var request struct {
-		UserID string `json:"user_id"`
-		Status string `json:"status"`
-		Page   int    `json:"page"`
-		Limit  int    `json:"limit"`
-	}
+	var request contract.RecordFilter
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		h.logger.Errorf("Handler.GetClientRecordsFiltered: invalid request: %v", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
@@ -135,14 +131,14 @@ func (h *Handler) GetClientRecordsFiltered(ctx *gin.Context) {
 	if end > len(records) {
 		end = len(records)
 	}
-	ctx.JSON(http.StatusOK, gin.H{
-		"message":  "Success",
-		"total":    len(records),
-		"page":     page,
-		"limit":    limit,
-		"records":  records[start:end],
-		"has_next": end < len(records),
-		"has_prev": start > 0,
+	ctx.JSON(http.StatusOK, contract.RecordPage{
+		Message: "Success",
+		Total:   len(records),
+		Page:    page,
+		Limit:   limit,
+		Records: models.ContractRecords(records[start:end]),
+		HasNext: end < len(records),
+		HasPrev: start > 0,
 	})
 }
 
@@ -266,15 +262,12 @@ func (h *Handler) RejectRecord(ctx *gin.Context) {
 // @Tags record
 // @Accept json
 // @Produce json
-// @Param request body object true "Record status update request"
+// @Param request body contract.RecordStatusUpdate true "Record status update request"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
 // @Router /record/master/status [post]
 func (h *Handler) UpdateRecordStatus(ctx *gin.Context) {
-	var req struct {
-		RecordID uint   `json:"record_id"`
-		Status   string `json:"status"`
-	}
+	var req contract.RecordStatusUpdate
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		h.logger.Errorf("Handler.UpdateRecordStatus: invalid request: %v", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid request: %v", err)})
@@ -320,8 +313,9 @@ func (h *Handler) DeleteRecord(ctx *gin.Context) {
 // @Tags record
 // @Produce json
 // @Param telegram_id path string true "Telegram ID"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} contract.UpcomingRecords
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
 // @Router /telegram/record/master/upcoming/{telegram_id} [get]
 func (h *Handler) GetUpcomingRecordsByMasterTelegramID(ctx *gin.Context) {
 	telegramIDStr := ctx.Param("telegram_id")
@@ -339,8 +333,8 @@ func (h *Handler) GetUpcomingRecordsByMasterTelegramID(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{
-		"message": "Success",
-		"data":    records,
+	ctx.JSON(http.StatusOK, contract.UpcomingRecords{
+		Message: "Success",
+		Data:    models.ContractRecords(records),
 	})
 }
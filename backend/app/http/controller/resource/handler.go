@@ -0,0 +1,294 @@
+package resource
+
+import (
+	orgUcase "app/http/usecase/organization"
+	ucase "app/http/usecase/resource"
+	"app/http/utils"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetResources returns resources of an organization
+// @Summary Organization resources
+// @Description Chairs, rooms and equipment of the organization (members only)
+// @Tags resource
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Success 200 {array} models.Resource
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/resources [get]
+func (h *Handler) GetResources(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetResources")
+	if !ok {
+		return
+	}
+	list, err := h.service.List(orgID, userID)
+	if err != nil {
+		h.actionError(ctx, "GetResources", err)
+		return
+	}
+	if list == nil {
+		list = []models.Resource{}
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// CreateResource adds a resource to an organization
+// @Summary Create resource
+// @Description Add a chair, room or piece of equipment with the number of units that can be busy at once (owner or manager)
+// @Tags resource
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param request body contract.ResourceRequest true "Resource"
+// @Success 200 {object} models.Resource
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/resources [post]
+func (h *Handler) CreateResource(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "CreateResource")
+	if !ok {
+		return
+	}
+	var req contract.ResourceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	res, err := h.service.Create(orgID, userID, req)
+	if err != nil {
+		h.actionError(ctx, "CreateResource", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// UpdateResource changes a resource
+// @Summary Update resource
+// @Description Change name, quantity and availability; an inactive resource blocks new slots and bookings of its services (owner or manager)
+// @Tags resource
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param resource_id path int true "Resource ID"
+// @Param request body contract.ResourceRequest true "Resource"
+// @Success 200 {object} models.Resource
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/resources/{resource_id} [put]
+func (h *Handler) UpdateResource(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "UpdateResource")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "resource_id")
+	if !ok {
+		return
+	}
+	var req contract.ResourceRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	res, err := h.service.Update(orgID, userID, id, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateResource", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// DeleteResource deletes a resource
+// @Summary Delete resource
+// @Description Delete a resource; services stop using it (owner or manager)
+// @Tags resource
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param resource_id path int true "Resource ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/resources/{resource_id} [delete]
+func (h *Handler) DeleteResource(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "DeleteResource")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "resource_id")
+	if !ok {
+		return
+	}
+	if err := h.service.Delete(orgID, userID, id); err != nil {
+		h.actionError(ctx, "DeleteResource", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Resource deleted"})
+}
+
+// GetServiceResources returns resources used by a service
+// @Summary Service resources
+// @Description Resources a service of the organization occupies during its slots (members only)
+// @Tags resource
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param service_id path int true "Service ID"
+// @Success 200 {array} models.Resource
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/services/{service_id}/resources [get]
+func (h *Handler) GetServiceResources(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetServiceResources")
+	if !ok {
+		return
+	}
+	serviceID, ok := uintParam(ctx, "service_id")
+	if !ok {
+		return
+	}
+	list, err := h.service.ServiceResources(orgID, userID, serviceID)
+	if err != nil {
+		h.actionError(ctx, "GetServiceResources", err)
+		return
+	}
+	if list == nil {
+		list = []models.Resource{}
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// SetServiceResources replaces resources used by a service
+// @Summary Set service resources
+// @Description Replace the resources a service occupies (one unit of each); an empty list detaches all (owner or manager)
+// @Tags resource
+// @Accept json
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param service_id path int true "Service ID"
+// @Param request body contract.ServiceResources true "Resource IDs"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /organization/{id}/services/{service_id}/resources [put]
+func (h *Handler) SetServiceResources(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "SetServiceResources")
+	if !ok {
+		return
+	}
+	serviceID, ok := uintParam(ctx, "service_id")
+	if !ok {
+		return
+	}
+	var req contract.ServiceResources
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.SetServiceResources(orgID, userID, serviceID, req.ResourceIDs); err != nil {
+		h.actionError(ctx, "SetServiceResources", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Service resources updated"})
+}
+
+// GetUtilization returns resource utilization of an organization
+// @Summary Organization resource utilization
+// @Description Booked minutes, capacity and peak concurrent use of each resource in [from, to) (members only). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days
+// @Tags resource
+// @Produce json
+// @Param id path string true "Organization UUID"
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, exclusive (YYYY-MM-DD)"
+// @Success 200 {array} contract.ResourceUtilization
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Router /organization/{id}/resources/utilization [get]
+func (h *Handler) GetUtilization(ctx *gin.Context) {
+	orgID, userID, ok := h.organization(ctx, "GetUtilization")
+	if !ok {
+		return
+	}
+	from, to, ok := utils.DatePeriod(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.Utilization(orgID, userID, from, to)
+	if err != nil {
+		h.actionError(ctx, "GetUtilization", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetAllUtilization returns resource utilization of all organizations
+// @Summary Resource utilization (admin)
+// @Description Booked minutes, capacity and peak concurrent use of every resource in [from, to). Dates are YYYY-MM-DD in UTC, default is the next 7 days, at most 31 days
+// @Tags admin
+// @Produce json
+// @Param from query string false "From date (YYYY-MM-DD)"
+// @Param to query string false "To date, exclusive (YYYY-MM-DD)"
+// @Success 200 {array} contract.ResourceUtilization
+// @Failure 400 {object} contract.ErrorResponse
+// @Router /admin/resources/utilization [get]
+func (h *Handler) GetAllUtilization(ctx *gin.Context) {
+	from, to, ok := utils.DatePeriod(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.AllUtilization(from, to)
+	if err != nil {
+		h.actionError(ctx, "GetAllUtilization", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// actionError переводит ошибку usecase ресурсов в HTTP-статус
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidResource), errors.Is(err, ucase.ErrInvalidPeriod):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, orgUcase.ErrNotMember), errors.Is(err, orgUcase.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrResourceNotFound), errors.Is(err, ucase.ErrServiceNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "resource request failed"})
+	}
+}
+
+// organization разбирает id организации из пути и текущего пользователя
+func (h *Handler) organization(ctx *gin.Context, name string) (uuid.UUID, uuid.UUID, bool) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	orgID, err := uuid.Parse(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return orgID, userID, true
+}
+
+func uintParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
@@ -0,0 +1,19 @@
+package resource
+
+import (
+	"app/http/usecase/resource"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *resource.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *resource.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,312 @@
+package review
+
+import (
+	ucase "app/http/usecase/review"
+	"app/http/utils"
+	"contract"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetMasterReviews returns public reviews of a master
+// @Summary Master reviews
+// @Description Visible reviews of a master, newest first, with the average rating and the number of reviews. Clients are shown by first name only.
+// @Tags review
+// @Produce json
+// @Param uuid path string true "Master UUID"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} contract.ReviewList
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /review/master/{uuid} [get]
+func (h *Handler) GetMasterReviews(ctx *gin.Context) {
+	masterID, err := uuid.Parse(ctx.Param("uuid"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid uuid"})
+		return
+	}
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.MasterReviews(masterID, limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetMasterReviews", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// GetServiceReviews returns public reviews of a service
+// @Summary Service reviews
+// @Description Visible reviews of a service, newest first, with the average rating and the number of reviews
+// @Tags review
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Page offset"
+// @Success 200 {object} contract.ReviewList
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /review/service/{id} [get]
+func (h *Handler) GetServiceReviews(ctx *gin.Context) {
+	serviceID, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.ServiceReviews(serviceID, limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetServiceReviews", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// CreateReview leaves a review for a completed record
+// @Summary Create review
+// @Description Rate a confirmed record whose slot has ended (1–5) with an optional text; one review per record. The master is notified.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param record_id path int true "Record ID"
+// @Param request body contract.ReviewRequest true "Review"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /review/record/{record_id} [post]
+func (h *Handler) CreateReview(ctx *gin.Context) {
+	userID, recordID, req, ok := h.reviewRequest(ctx, "CreateReview")
+	if !ok {
+		return
+	}
+	rv, err := h.service.Create(userID, recordID, req)
+	if err != nil {
+		h.actionError(ctx, "CreateReview", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rv)
+}
+
+// UpdateReview changes the client's review of a record
+// @Summary Update review
+// @Description Change the rating and text of the own review; the master's reply is kept
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param record_id path int true "Record ID"
+// @Param request body contract.ReviewRequest true "Review"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /review/record/{record_id} [put]
+func (h *Handler) UpdateReview(ctx *gin.Context) {
+	userID, recordID, req, ok := h.reviewRequest(ctx, "UpdateReview")
+	if !ok {
+		return
+	}
+	rv, err := h.service.UpdateByClient(userID, recordID, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateReview", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rv)
+}
+
+// ReplyReview sets the master's public reply to a review
+// @Summary Reply to review
+// @Description Public reply of the master to a review about them; an empty text removes the reply. The client is notified.
+// @Tags review
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body contract.ReviewReply true "Reply"
+// @Success 200 {object} models.Review
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 403 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /review/{id}/reply [put]
+func (h *Handler) ReplyReview(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "ReplyReview")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var req contract.ReviewReply
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	rv, err := h.service.Reply(userID, id, req.Text)
+	if err != nil {
+		h.actionError(ctx, "ReplyReview", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rv)
+}
+
+// GetModerationReviews returns reviews for moderation
+// @Summary Reviews for moderation
+// @Description All reviews including hidden ones, newest first (admin)
+// @Tags admin
+// @Produce json
+// @Param hidden query bool false "Only hidden (true) or visible (false) reviews"
+// @Param flagged query bool false "Only flagged (true) or not flagged (false) reviews"
+// @Param limit query int false "Page size (default 20, max 50)"
+// @Param offset query int false "Page offset"
+// @Success 200 {array} contract.Review
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /admin/reviews [get]
+func (h *Handler) GetModerationReviews(ctx *gin.Context) {
+	hidden, ok := boolQuery(ctx, "hidden")
+	if !ok {
+		return
+	}
+	flagged, ok := boolQuery(ctx, "flagged")
+	if !ok {
+		return
+	}
+	limit, offset, ok := page(ctx)
+	if !ok {
+		return
+	}
+	list, err := h.service.Moderation(hidden, flagged, limit, offset)
+	if err != nil {
+		h.actionError(ctx, "GetModerationReviews", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, list)
+}
+
+// ModerateReview hides or flags a review
+// @Summary Moderate review
+// @Description Hide a review (it leaves public lists and ratings) or flag it for checking; omitted fields are kept (admin)
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "Review ID"
+// @Param request body contract.ReviewModeration true "Moderation"
+// @Success 200 {object} contract.Review
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /admin/reviews/{id} [put]
+func (h *Handler) ModerateReview(ctx *gin.Context) {
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var req contract.ReviewModeration
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	rv, err := h.service.Moderate(id, req)
+	if err != nil {
+		h.actionError(ctx, "ModerateReview", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, rv)
+}
+
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidRating), errors.Is(err, ucase.ErrInvalidText),
+		errors.Is(err, ucase.ErrInvalidReply), errors.Is(err, ucase.ErrInvalidModeration),
+		errors.Is(err, ucase.ErrRecordNotCompleted):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrNotRecordOwner), errors.Is(err, ucase.ErrNotReviewMaster):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrRecordNotFound), errors.Is(err, ucase.ErrReviewNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrReviewExists):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "review request failed"})
+	}
+}
+
+func (h *Handler) currentUser(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+func (h *Handler) reviewRequest(ctx *gin.Context, name string) (uuid.UUID, uint, contract.ReviewRequest, bool) {
+	var req contract.ReviewRequest
+	userID, ok := h.currentUser(ctx, name)
+	if !ok {
+		return uuid.Nil, 0, req, false
+	}
+	recordID, ok := uintParam(ctx, "record_id")
+	if !ok {
+		return uuid.Nil, 0, req, false
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return uuid.Nil, 0, req, false
+	}
+	return userID, recordID, req, true
+}
+
+func uintParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
+
+// page разбирает limit и offset; пустые значения оставляют размер страницы по умолчанию
+func page(ctx *gin.Context) (int, int, bool) {
+	var values [2]int
+	for i, name := range []string{"limit", "offset"} {
+		raw := ctx.Query(name)
+		if raw == "" {
+			continue
+		}
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+			return 0, 0, false
+		}
+		values[i] = n
+	}
+	return values[0], values[1], true
+}
+
+func boolQuery(ctx *gin.Context, name string) (*bool, bool) {
+	raw := ctx.Query(name)
+	if raw == "" {
+		return nil, true
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	return &v, true
+}
@@ -0,0 +1,19 @@
+package review
+
+import (
+	"app/http/usecase/review"
+
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct {
+	service *review.Service
+	logger  *logrus.Logger
+}
+
+func NewHandler(service *review.Service, logger *logrus.Logger) *Handler {
+	return &Handler{
+		service: service,
+		logger:  logger,
+	}
+}
@@ -0,0 +1,389 @@
+package service
+
+import (
+	ucase "app/http/usecase/service"
+	"app/http/utils"
+	"contract"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetCategories returns the categories of a master
+// @Summary Master service categories
+// @Description Categories of a master in display order; services refer to them by category_id
+// @Tags service
+// @Produce json
+// @Param uuid path string true "Master UUID"
+// @Success 200 {array} contract.ServiceCategory
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 500 {object} contract.ErrorResponse
+// @Router /service/master/{uuid}/categories [get]
+func (h *Handler) GetCategories(ctx *gin.Context) {
+	masterID, err := uuid.Parse(ctx.Param("uuid"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid uuid"})
+		return
+	}
+	list, err := h.service.Categories(masterID)
+	if err != nil {
+		h.actionError(ctx, "GetCategories", err)
+		return
+	}
+	out := make([]contract.ServiceCategory, 0, len(list))
+	for _, c := range list {
+		out = append(out, c.Contract())
+	}
+	ctx.JSON(http.StatusOK, out)
+}
+
+// CreateCategory adds a category of the current master
+// @Summary Create service category
+// @Description Add a category for own services; names are unique per master ignoring case
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param request body contract.ServiceCategoryRequest true "Category"
+// @Success 200 {object} contract.ServiceCategory
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /service/categories [post]
+func (h *Handler) CreateCategory(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "CreateCategory")
+	if !ok {
+		return
+	}
+	var req contract.ServiceCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	c, err := h.service.CreateCategory(userID, req)
+	if err != nil {
+		h.actionError(ctx, "CreateCategory", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, c.Contract())
+}
+
+// UpdateCategory renames or moves a category of the current master
+// @Summary Update service category
+// @Description Change the name and position of an own category
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Category ID"
+// @Param request body contract.ServiceCategoryRequest true "Category"
+// @Success 200 {object} contract.ServiceCategory
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Failure 409 {object} contract.ErrorResponse
+// @Router /service/categories/{id} [put]
+func (h *Handler) UpdateCategory(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "UpdateCategory")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var req contract.ServiceCategoryRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	c, err := h.service.UpdateCategory(userID, id, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateCategory", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, c.Contract())
+}
+
+// DeleteCategory deletes a category of the current master
+// @Summary Delete service category
+// @Description Delete an own category; its services stay without a category
+// @Tags service
+// @Produce json
+// @Param id path int true "Category ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/categories/{id} [delete]
+func (h *Handler) DeleteCategory(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "DeleteCategory")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteCategory(userID, id); err != nil {
+		h.actionError(ctx, "DeleteCategory", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Category deleted"})
+}
+
+// SetServiceCategory moves a service to a category
+// @Summary Set service category
+// @Description Put an own service into an own category; a null category_id removes the service from its category
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param request body contract.ServiceCategoryAssign true "Category"
+// @Success 200 {object} models.Service
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/category [put]
+func (h *Handler) SetServiceCategory(ctx *gin.Context) {
+	userID, ok := h.currentUser(ctx, "SetServiceCategory")
+	if !ok {
+		return
+	}
+	id, ok := uintParam(ctx, "id")
+	if !ok {
+		return
+	}
+	var req contract.ServiceCategoryAssign
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	svc, err := h.service.SetCategory(userID, id, req.CategoryID)
+	if err != nil {
+		h.actionError(ctx, "SetServiceCategory", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, svc)
+}
+
+// AddVariant adds a variant to a service
+// @Summary Add service variant
+// @Description Add a variant with its own price and duration in minutes, e.g. long hair. Clients pick it as variant_id when booking.
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param request body contract.ServiceOptionRequest true "Variant"
+// @Success 200 {object} models.ServiceVariant
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/variants [post]
+func (h *Handler) AddVariant(ctx *gin.Context) {
+	userID, serviceID, _, req, ok := h.optionRequest(ctx, "AddVariant", "")
+	if !ok {
+		return
+	}
+	v, err := h.service.AddVariant(userID, serviceID, req)
+	if err != nil {
+		h.actionError(ctx, "AddVariant", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, v)
+}
+
+// UpdateVariant changes a variant of a service
+// @Summary Update service variant
+// @Description Change a variant; existing records keep the price and duration they were booked with
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param variant_id path int true "Variant ID"
+// @Param request body contract.ServiceOptionRequest true "Variant"
+// @Success 200 {object} models.ServiceVariant
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/variants/{variant_id} [put]
+func (h *Handler) UpdateVariant(ctx *gin.Context) {
+	userID, serviceID, variantID, req, ok := h.optionRequest(ctx, "UpdateVariant", "variant_id")
+	if !ok {
+		return
+	}
+	v, err := h.service.UpdateVariant(userID, serviceID, variantID, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateVariant", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, v)
+}
+
+// DeleteVariant deletes a variant of a service
+// @Summary Delete service variant
+// @Description Delete a variant; existing records keep its name and price
+// @Tags service
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param variant_id path int true "Variant ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/variants/{variant_id} [delete]
+func (h *Handler) DeleteVariant(ctx *gin.Context) {
+	userID, serviceID, variantID, ok := h.optionParams(ctx, "DeleteVariant", "variant_id")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteVariant(userID, serviceID, variantID); err != nil {
+		h.actionError(ctx, "DeleteVariant", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Variant deleted"})
+}
+
+// AddAddOn adds an optional add-on to a service
+// @Summary Add service add-on
+// @Description Add an add-on that adds to the price and extends the duration (minutes, may be 0). Clients pick add-ons as add_on_ids when booking.
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param request body contract.ServiceOptionRequest true "Add-on"
+// @Success 200 {object} models.ServiceAddOn
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/add-ons [post]
+func (h *Handler) AddAddOn(ctx *gin.Context) {
+	userID, serviceID, _, req, ok := h.optionRequest(ctx, "AddAddOn", "")
+	if !ok {
+		return
+	}
+	a, err := h.service.AddAddOn(userID, serviceID, req)
+	if err != nil {
+		h.actionError(ctx, "AddAddOn", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, a)
+}
+
+// UpdateAddOn changes an add-on of a service
+// @Summary Update service add-on
+// @Description Change an add-on; existing records keep the price and duration they were booked with
+// @Tags service
+// @Accept json
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param add_on_id path int true "Add-on ID"
+// @Param request body contract.ServiceOptionRequest true "Add-on"
+// @Success 200 {object} models.ServiceAddOn
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/add-ons/{add_on_id} [put]
+func (h *Handler) UpdateAddOn(ctx *gin.Context) {
+	userID, serviceID, addOnID, req, ok := h.optionRequest(ctx, "UpdateAddOn", "add_on_id")
+	if !ok {
+		return
+	}
+	a, err := h.service.UpdateAddOn(userID, serviceID, addOnID, req)
+	if err != nil {
+		h.actionError(ctx, "UpdateAddOn", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, a)
+}
+
+// DeleteAddOn deletes an add-on of a service
+// @Summary Delete service add-on
+// @Description Delete an add-on; existing records keep its name and price
+// @Tags service
+// @Produce json
+// @Param id path int true "Service ID"
+// @Param add_on_id path int true "Add-on ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Failure 404 {object} contract.ErrorResponse
+// @Router /service/{id}/add-ons/{add_on_id} [delete]
+func (h *Handler) DeleteAddOn(ctx *gin.Context) {
+	userID, serviceID, addOnID, ok := h.optionParams(ctx, "DeleteAddOn", "add_on_id")
+	if !ok {
+		return
+	}
+	if err := h.service.DeleteAddOn(userID, serviceID, addOnID); err != nil {
+		h.actionError(ctx, "DeleteAddOn", err)
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Add-on deleted"})
+}
+
+func (h *Handler) actionError(ctx *gin.Context, name string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidCategory), errors.Is(err, ucase.ErrInvalidVariant), errors.Is(err, ucase.ErrInvalidAddOn):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrServiceNotFound), errors.Is(err, ucase.ErrCategoryNotFound),
+		errors.Is(err, ucase.ErrVariantNotFound), errors.Is(err, ucase.ErrAddOnNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	case errors.Is(err, ucase.ErrCategoryExists):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	default:
+		h.logger.Errorf("Handler.%s: %v", name, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "service catalog request failed"})
+	}
+}
+
+func (h *Handler) currentUser(ctx *gin.Context, name string) (uuid.UUID, bool) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.%s: auth error: %v", name, err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
+
+// optionParams разбирает пользователя, ID услуги и, если задан idName, ID варианта или опции
+func (h *Handler) optionParams(ctx *gin.Context, name, idName string) (uuid.UUID, uint, uint, bool) {
+	userID, ok := h.currentUser(ctx, name)
+	if !ok {
+		return uuid.Nil, 0, 0, false
+	}
+	serviceID, ok := uintParam(ctx, "id")
+	if !ok {
+		return uuid.Nil, 0, 0, false
+	}
+	var id uint
+	if idName != "" {
+		if id, ok = uintParam(ctx, idName); !ok {
+			return uuid.Nil, 0, 0, false
+		}
+	}
+	return userID, serviceID, id, true
+}
+
+func (h *Handler) optionRequest(ctx *gin.Context, name, idName string) (uuid.UUID, uint, uint, contract.ServiceOptionRequest, bool) {
+	var req contract.ServiceOptionRequest
+	userID, serviceID, id, ok := h.optionParams(ctx, name, idName)
+	if !ok {
+		return uuid.Nil, 0, 0, req, false
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return uuid.Nil, 0, 0, req, false
+	}
+	return userID, serviceID, id, req, true
+}
+
+func uintParam(ctx *gin.Context, name string) (uint, bool) {
+	id, err := strconv.ParseUint(ctx.Param(name), 10, 0)
+	if err != nil || id == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return 0, false
+	}
+	return uint(id), true
+}
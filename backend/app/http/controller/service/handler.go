@@ -20,6 +20,7 @@ import (
 // @Param service body models.Service true "Service data"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Caller is not the service master"
 // @Router /service/create [post]
 func (h *Handler) CreateService(ctx *gin.Context) {
 	var service models.Service
@@ -34,6 +35,10 @@ func (h *Handler) CreateService(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "master_id is required"})
 		return
 	}
+	if userID, err := utils.CurrentUserID(ctx); err != nil || userID != service.MasterID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
 
 	// Валидация данных услуги
 	if len(service.Name) < 1 || len(service.Name) > 100 {
@@ -143,6 +148,7 @@ func (h *Handler) GetService(ctx *gin.Context) {
 // @Param service body models.Service true "Service update data"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Caller is not the service master"
 // @Router /service/update [put]
 func (h *Handler) UpdateService(ctx *gin.Context) {
 	var service models.Service
@@ -155,6 +161,10 @@ func (h *Handler) UpdateService(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "master_id is required"})
 		return
 	}
+	if userID, err := utils.CurrentUserID(ctx); err != nil || userID != service.MasterID {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
 
 	// Валидация данных услуги
 	if len(service.Name) < 1 || len(service.Name) > 100 {
@@ -195,8 +205,8 @@ func (h *Handler) UpdateService(ctx *gin.Context) {
 // @Failure 401 {object} map[string]string
 // @Router /service/{id} [delete]
 func (h *Handler) DeleteService(ctx *gin.Context) {
-	// Извлекаем user_id из токена
-	userID, err := utils.ExtractUserIDFromToken(ctx)
+	// Извлекаем user_id из сессии или внутреннего вызова бота
+	userID, err := utils.CurrentUserID(ctx)
 	if err != nil {
 		h.logger.Errorf("Handler.DeleteService: auth error: %v", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
@@ -0,0 +1,122 @@
+package service_test
+
+import (
+	serviceCtrl "app/http/controller/service"
+	"app/http/middleware"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/service"
+	"app/pkg/models"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+const internalToken = "secret"
+
+type fixture struct {
+	*memtest.Fixture
+	router *gin.Engine
+	other  models.User
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	f := &fixture{Fixture: memtest.New(t)}
+	f.other = f.User(t, models.User{TelegramID: 1002, FirstName: "Ольга"})
+	h := serviceCtrl.NewHandler(service.NewService(f.Store.Services(), f.Store.Users(), f.Logger), f.Logger)
+	f.router = gin.New()
+	f.router.Use(middleware.TelegramActorMiddleware(internalToken, f.Store.Users()))
+	f.router.POST("/service/create", h.CreateService)
+	f.router.PUT("/service/update", h.UpdateService)
+	return f
+}
+
+// do отправляет запрос от имени пользователя с telegramID (0 — без актёра)
+func (f *fixture) do(t *testing.T, method, path string, telegramID int64, token string, body any) int {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(raw))
+	req.Header.Set("Content-Type", "application/json")
+	if telegramID != 0 {
+		req.Header.Set("X-Telegram-ID", strconv.FormatInt(telegramID, 10))
+		req.Header.Set("X-Internal-Token", token)
+	}
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestCreateServiceOwnership(t *testing.T) {
+	f := newFixture(t)
+	own := models.Service{MasterID: f.Master.ID, Name: "Стрижка", Price: 150000, Duration: 60}
+	foreign := models.Service{MasterID: f.other.ID, Name: "Стрижка", Price: 150000, Duration: 60}
+
+	cases := []struct {
+		name       string
+		telegramID int64
+		token      string
+		body       models.Service
+		want       int
+	}{
+		{"owner", f.Master.TelegramID, internalToken, own, http.StatusOK},
+		{"another master", f.Master.TelegramID, internalToken, foreign, http.StatusForbidden},
+		{"no actor", 0, "", own, http.StatusForbidden},
+		{"wrong token", f.Master.TelegramID, "wrong", own, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := f.do(t, http.MethodPost, "/service/create", tc.telegramID, tc.token, tc.body); got != tc.want {
+				t.Errorf("status = %d, want %d", got, tc.want)
+			}
+		})
+	}
+
+	services, err := f.Store.Services().GetServices(f.other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(services) != 0 {
+		t.Errorf("services of another master = %+v, want none", services)
+	}
+}
+
+func TestUpdateServiceOwnership(t *testing.T) {
+	f := newFixture(t)
+	theirs := f.AddService(t, models.Service{MasterID: f.other.ID, Name: "Окрашивание", Currency: "RUB"})
+	mine := f.Service
+
+	// Чужой master_id не проходит проверку актёра
+	hijack := models.Service{ID: theirs.ID, MasterID: f.other.ID, Name: "Взлом", Price: 100, Duration: 10}
+	if got := f.do(t, http.MethodPut, "/service/update", f.Master.TelegramID, internalToken, hijack); got != http.StatusForbidden {
+		t.Errorf("update with another master_id: status = %d, want %d", got, http.StatusForbidden)
+	}
+	// Свой master_id с чужой услугой отклоняет usecase
+	hijack.MasterID = f.Master.ID
+	if got := f.do(t, http.MethodPut, "/service/update", f.Master.TelegramID, internalToken, hijack); got != http.StatusBadRequest {
+		t.Errorf("update of a foreign service: status = %d, want %d", got, http.StatusBadRequest)
+	}
+	got, err := f.Store.Services().GetService(theirs.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Name != "Окрашивание" || got.MasterID != f.other.ID || got.Price != 150000 {
+		t.Errorf("foreign service changed: %+v", got)
+	}
+
+	mine.Price = 200000
+	if code := f.do(t, http.MethodPut, "/service/update", f.Master.TelegramID, internalToken, mine); code != http.StatusOK {
+		t.Fatalf("owner update: status = %d, want %d", code, http.StatusOK)
+	}
+	if got, err := f.Store.Services().GetService(mine.ID); err != nil || got.Price != 200000 {
+		t.Errorf("owner update = %+v, %v; want price 200000", got, err)
+	}
+}
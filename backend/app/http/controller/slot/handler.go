@@ -1,8 +1,12 @@
 package slot
 
 import (
+	locationUcase "app/http/usecase/location"
+	resourceUcase "app/http/usecase/resource"
+	slotUcase "app/http/usecase/slot"
 	"app/http/utils"
 	"app/pkg/models"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -20,6 +24,8 @@ import (
 // @Param slot body models.Slot true "Slot data"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string "Caller is not the slot master or the service belongs to another master"
+// @Failure 409 {object} map[string]string "Resource of the service is fully booked or unavailable, or the slot is outside the working hours of the location"
 // @Router /slot/master/create [post]
 func (h *Handler) CreateSlot(ctx *gin.Context) {
 	var slot models.Slot
@@ -33,6 +39,11 @@ func (h *Handler) CreateSlot(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "master_id is required"})
 		return
 	}
+	if userID, err := utils.CurrentUserID(ctx); err != nil || userID != slot.MasterID {
+		h.logger.Errorf("CreateSlot: master_id does not match caller, master_id: %s", slot.MasterID.String())
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+		return
+	}
 
 	// Validate slot data
 	if slot.StartTime.IsZero() || slot.EndTime.IsZero() {
@@ -55,6 +66,15 @@ func (h *Handler) CreateSlot(ctx *gin.Context) {
 	}
 
 	if err := h.service.CreateSlot(&slot); err != nil {
+		if errors.Is(err, slotUcase.ErrServiceNotOwned) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return
+		}
+		if errors.Is(err, resourceUcase.ErrResourceBusy) || errors.Is(err, resourceUcase.ErrResourceUnavailable) ||
+			errors.Is(err, locationUcase.ErrOutsideHours) {
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
 		h.logger.Errorf("CreateSlot: failed to create slot in service layer: %v, master_id: %s, service_id: %d, start_time: %v, end_time: %v", err, slot.MasterID.String(), slot.ServiceID, slot.StartTime, slot.EndTime)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Failed to create slot. Please check selected service and time."})
 		return
@@ -160,8 +180,8 @@ func (h *Handler) DeleteSlots(ctx *gin.Context) {
 // @Failure 401 {object} map[string]string
 // @Router /slot/master/one/{id} [delete]
 func (h *Handler) DeleteSlot(ctx *gin.Context) {
-	// Extract user_id from token
-	userID, err := utils.ExtractUserIDFromToken(ctx)
+	// Extract user_id from session or internal bot caller
+	userID, err := utils.CurrentUserID(ctx)
 	if err != nil {
 		h.logger.Errorf("DeleteSlot: auth error: %v", err)
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
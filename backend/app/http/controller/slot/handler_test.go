@@ -0,0 +1,72 @@
+package slot_test
+
+import (
+	slotCtrl "app/http/controller/slot"
+	"app/http/middleware"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/slot"
+	"app/pkg/models"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const internalToken = "secret"
+
+func TestCreateSlotOwnership(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	f := memtest.New(t)
+	master, mine := f.Master, f.Service
+	other := f.User(t, models.User{TelegramID: 1002, FirstName: "Ольга"})
+	theirs := f.AddService(t, models.Service{MasterID: other.ID, Name: "Окрашивание"})
+
+	h := slotCtrl.NewHandler(slot.NewService(f.Store.Slots(), f.Logger).WithServices(f.Store.Services()), f.Logger)
+	router := gin.New()
+	router.Use(middleware.TelegramActorMiddleware(internalToken, f.Store.Users()))
+	router.POST("/slot/master/create", h.CreateSlot)
+
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name string
+		body models.Slot
+		want int
+	}{
+		{"owner", models.Slot{MasterID: master.ID, ServiceID: mine.ID, StartTime: start, EndTime: start.Add(time.Hour)}, http.StatusOK},
+		{"another master", models.Slot{MasterID: other.ID, ServiceID: theirs.ID, StartTime: start, EndTime: start.Add(time.Hour)}, http.StatusForbidden},
+		{"foreign service", models.Slot{MasterID: master.ID, ServiceID: theirs.ID, StartTime: start, EndTime: start.Add(time.Hour)}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw, err := json.Marshal(tc.body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := httptest.NewRequest(http.MethodPost, "/slot/master/create", bytes.NewReader(raw))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Telegram-ID", strconv.FormatInt(master.TelegramID, 10))
+			req.Header.Set("X-Internal-Token", internalToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tc.want {
+				t.Errorf("status = %d, want %d: %s", w.Code, tc.want, w.Body.String())
+			}
+		})
+	}
+
+	slots, err := f.Store.Slots().FindSlots(other.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 0 {
+		t.Errorf("slots of another master = %+v, want none", slots)
+	}
+	if slots, _ := f.Store.Slots().FindSlots(master.ID); len(slots) != 1 || slots[0].ServiceID != mine.ID {
+		t.Errorf("slots of the master = %+v, want one on their own service", slots)
+	}
+}
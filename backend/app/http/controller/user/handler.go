@@ -1,9 +1,11 @@
 package user
 
 import (
-	"app/http/sender"
 	ucase "app/http/usecase/user"
+	"app/http/utils"
 	"app/pkg/models"
+	"contract"
+	"errors"
 	"fmt"
 	_ "fmt"
 	"net/http"
@@ -18,17 +20,21 @@ type UserSageRegister struct {
 	TelegramID int64  `json:"telegram_id" gorm:"index; column:telegram_id"`
 	FirstName  string `json:"first_name"  gorm:"column:first_name; not null"`
 	Surname    string `json:"surname"     gorm:"column:surname"`
+	Language   string `json:"language"`
 }
 
 // CreateUser creates a new user
 // @Summary Create user
-// @Description Register a new user with phone and Telegram ID
+// @Description Register a new user with phone and Telegram ID. Requests from the Telegram bot (X-Internal-Token) carry a verified contact and create the user at once; others get a one-time code in Telegram and must call /user/register/verify
 // @Tags user
 // @Accept json
 // @Produce json
 // @Param user body UserSageRegister true "User registration data"
 // @Success 200 {object} map[string]interface{}
+// @Success 202 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 429 {object} map[string]string
 // @Router /user/register [post]
 func (h *Handler) CreateUser(ctx *gin.Context) {
 	var safeUser UserSageRegister
@@ -39,12 +45,6 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 	}
 
 	// Validate input data
-	if len(safeUser.Phone) < 10 || len(safeUser.Phone) > 15 {
-		h.logger.Errorf("CreateUser: invalid phone number length: %s", safeUser.Phone)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number format"})
-		return
-	}
-
 	if len(safeUser.FirstName) < 1 || len(safeUser.FirstName) > 50 {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "First name must be between 1 and 50 characters"})
 		return
@@ -65,10 +65,26 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 		TelegramID: safeUser.TelegramID,
 		FirstName:  safeUser.FirstName,
 		Surname:    safeUser.Surname,
+		Language:   safeUser.Language,
+	}
+	// Contact shared with the bot is the verified number; other sources confirm via one-time code
+	if !ctx.GetBool("internal_caller") {
+		phone, err := h.service.RequestRegistration(&user)
+		if err != nil {
+			h.logger.Errorf("CreateUser: failed to request registration: %v, phone: %s, telegram_id: %d", err, safeUser.Phone, safeUser.TelegramID)
+			h.respondPhoneError(ctx, err, "Register error")
+			return
+		}
+		ctx.JSON(http.StatusAccepted, gin.H{
+			"message": "Verification code sent to Telegram",
+			"phone":   phone,
+			"pending": true,
+		})
+		return
 	}
 	if err := h.service.Register(&user); err != nil {
 		h.logger.Errorf("CreateUser: failed to register user in service layer: %v, phone: %s, telegram_id: %d", err, safeUser.Phone, safeUser.TelegramID)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Register error"})
+		h.respondPhoneError(ctx, err, "Register error")
 		return
 	}
 
@@ -80,6 +96,58 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 	})
 }
 
+// VerifyRegistration confirms registration with the one-time code sent to Telegram
+// @Summary Verify registration
+// @Description Confirm the one-time code sent to Telegram and create the user. The code proves control of the Telegram account only: the phone stays unverified (login returns 403) until its owner shares the contact with the bot
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body object true "Verification request" example({"phone": "+79991234567", "telegram_id": 123456789, "code": "123456"})
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Router /user/register/verify [post]
+func (h *Handler) VerifyRegistration(ctx *gin.Context) {
+	var req struct {
+		Phone      string `json:"phone" binding:"required"`
+		TelegramID int64  `json:"telegram_id" binding:"required"`
+		Code       string `json:"code" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Errorf("Handler.VerifyRegistration: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	user, err := h.service.ConfirmRegistration(req.Phone, req.TelegramID, req.Code)
+	if err != nil {
+		h.logger.Errorf("VerifyRegistration: failed to confirm registration: %v, phone: %s", err, req.Phone)
+		h.respondPhoneError(ctx, err, "Register error")
+		return
+	}
+	h.logger.Infof("VerifyRegistration: user registered successfully, phone: %s, telegram_id: %d", user.Phone, user.TelegramID)
+	ctx.JSON(http.StatusOK, gin.H{
+		"message": "User created successfully",
+		"result":  true,
+	})
+}
+
+// respondPhoneError maps phone validation and verification errors to HTTP responses
+func (h *Handler) respondPhoneError(ctx *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, ucase.ErrInvalidPhone):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number format"})
+	case errors.Is(err, ucase.ErrPhoneTaken):
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Phone already registered"})
+	case errors.Is(err, ucase.ErrInvalidCode):
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid or expired code"})
+	case errors.Is(err, ucase.ErrCodeRecentlySent):
+		ctx.JSON(http.StatusTooManyRequests, gin.H{"error": "Code already sent, try again in a minute"})
+	case errors.Is(err, ucase.ErrPhoneNotVerified):
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Phone not verified: share your contact with the Telegram bot"})
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fallback})
+	}
+}
+
 // Login initiates user login process
 // @Summary User login
 // @Description Initiate login process by phone number
@@ -89,6 +157,7 @@ func (h *Handler) CreateUser(ctx *gin.Context) {
 // @Param request body object true "Login request" example({"phone": "+79991234567"})
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
 // @Router /user/login [post]
 func (h *Handler) Login(ctx *gin.Context) {
 	var jsonData struct {
@@ -100,17 +169,10 @@ func (h *Handler) Login(ctx *gin.Context) {
 		return
 	}
 
-	// Validate phone number format
-	if len(jsonData.Phone) < 10 || len(jsonData.Phone) > 15 {
-		h.logger.Errorf("Login: invalid phone number format: %s", jsonData.Phone)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid phone number format"})
-		return
-	}
-
 	user, message, err := h.service.Login(jsonData.Phone)
 	if err != nil {
 		h.logger.Errorf("Login: failed to process login in service layer: %v, phone: %s", err, jsonData.Phone)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Login error"})
+		h.respondPhoneError(ctx, err, "Login error")
 		return
 	}
 
@@ -125,7 +187,7 @@ func (h *Handler) Login(ctx *gin.Context) {
 		} else if country != "" {
 			loc = country
 		}
-		if err := sender.LoginNotify(*user, ip, loc); err != nil {
+		if err := h.service.NotifyLogin(user, ip, loc); err != nil {
 			h.logger.Errorf("Login: failed to send Telegram notification: %v, user_id: %s", err, user.ID.String())
 		} else {
 			h.logger.Infof("Login: Telegram notification sent successfully, user_id: %s, ip: %s, location: %s", user.ID.String(), ip, loc)
@@ -154,12 +216,12 @@ func (h *Handler) Login(ctx *gin.Context) {
 
 // CheckAuth checks if user is authenticated by telegram_id
 // @Summary Check auth
-// @Description Check authentication status by telegram_id
+// @Description Check authentication status by telegram_id. A user registered on the site whose phone is not verified yet is not authenticated: the bot asks them to share the contact
 // @Tags user
 // @Produce json
 // @Param telegram_id path int true "Telegram ID"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
+// @Success 200 {object} contract.AuthStatus
+// @Failure 400 {object} contract.ErrorResponse
 // @Router /user/check/{telegram_id} [get]
 func (h *Handler) CheckAuth(ctx *gin.Context) {
 	telegramID, err := strconv.ParseInt(ctx.Param("telegram_id"), 10, 64)
@@ -177,18 +239,20 @@ func (h *Handler) CheckAuth(ctx *gin.Context) {
 	}
 	if user == nil {
 		h.logger.Infof("CheckAuth: user not found, telegram_id: %d", telegramID)
-		ctx.JSON(http.StatusOK, gin.H{"authenticated": false})
+		ctx.JSON(http.StatusOK, contract.AuthStatus{Authenticated: false})
 		return
 	}
 
-	h.logger.Infof("CheckAuth: user authentication check completed, telegram_id: %d, user_id: %s, active: %v", telegramID, user.ID.String(), user.Active)
-	ctx.JSON(http.StatusOK, gin.H{"authenticated": true})
+	h.logger.Infof("CheckAuth: user authentication check completed, telegram_id: %d, user_id: %s, active: %v, phone verified: %v", telegramID, user.ID.String(), user.Active, user.PhoneVerifiedAt != nil)
+	ctx.JSON(http.StatusOK, contract.AuthStatus{Authenticated: user.PhoneVerifiedAt != nil})
 }
 
 type PublicUserResponse struct {
 	ID        string           `json:"id"`
 	FirstName string           `json:"first_name"`
 	Surname   string           `json:"surname"`
+	Language  string           `json:"language,omitempty"`
+	Timezone  string           `json:"timezone,omitempty"`
 	Services  []models.Service `json:"services,omitempty"`
 }
 
@@ -220,6 +284,8 @@ func (h *Handler) GetUserByTelegramID(ctx *gin.Context) {
 		ID:        user.ID.String(),
 		FirstName: user.FirstName,
 		Surname:   user.Surname,
+		Language:  user.Language,
+		Timezone:  user.Timezone,
 		Services:  user.Services,
 	}
 
@@ -294,7 +360,13 @@ func (h *Handler) ClaimToken(ctx *gin.Context) {
 	}
 
 	h.logger.Infof("ClaimToken: token issued successfully, telegram_id: %d", telegramID)
-	// Return session temporary token without JWT, including additional fields
+	// Clear login_flow cookie on successful token issuance
+	ctx.SetCookie("login_flow", "", -1, "/", "", false, true)
+	ctx.JSON(http.StatusOK, tokenIssuedResponse(user, claimedToken))
+}
+
+// tokenIssuedResponse builds the session response shared by every login path
+func tokenIssuedResponse(user *models.User, token string) gin.H {
 	safeUser := gin.H{
 		"id":          user.ID.String(),
 		"first_name":  user.FirstName,
@@ -304,13 +376,108 @@ func (h *Handler) ClaimToken(ctx *gin.Context) {
 		"roles":       user.Roles,
 		"timezone":    user.Timezone,
 	}
-	// Clear login_flow cookie on successful token issuance
-	ctx.SetCookie("login_flow", "", -1, "/", "", false, true)
-	ctx.JSON(http.StatusOK, gin.H{
+	return gin.H{
 		"message": "Token issued",
-		"token":   claimedToken,
+		"token":   token,
 		"user":    safeUser,
-	})
+	}
+}
+
+// TelegramWidgetLogin is the payload produced by the Telegram Login Widget
+type TelegramWidgetLogin struct {
+	ID        int64  `json:"id" binding:"required"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Username  string `json:"username"`
+	PhotoURL  string `json:"photo_url"`
+	AuthDate  int64  `json:"auth_date" binding:"required"`
+	Hash      string `json:"hash" binding:"required"`
+}
+
+// fields returns widget data in the form Telegram signs it (absent fields are omitted)
+func (w TelegramWidgetLogin) fields() map[string]string {
+	fields := map[string]string{
+		"id":        strconv.FormatInt(w.ID, 10),
+		"auth_date": strconv.FormatInt(w.AuthDate, 10),
+		"hash":      w.Hash,
+	}
+	optional := map[string]string{
+		"first_name": w.FirstName,
+		"last_name":  w.LastName,
+		"username":   w.Username,
+		"photo_url":  w.PhotoURL,
+	}
+	for k, v := range optional {
+		if v != "" {
+			fields[k] = v
+		}
+	}
+	return fields
+}
+
+// LoginTelegramWidget issues session token from Telegram Login Widget data
+// @Summary Login via Telegram Login Widget
+// @Description Verify Login Widget data signed with the bot token and issue session token
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body TelegramWidgetLogin true "Login Widget data"
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /user/login/telegram-widget [post]
+func (h *Handler) LoginTelegramWidget(ctx *gin.Context) {
+	var req TelegramWidgetLogin
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Errorf("Handler.LoginTelegramWidget: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	user, token, err := h.service.LoginByTelegramWidget(req.fields())
+	h.respondTelegramLogin(ctx, "LoginTelegramWidget", user, token, err)
+}
+
+// LoginTelegramWebApp issues session token from Telegram Web App initData
+// @Summary Login via Telegram Mini App
+// @Description Verify Telegram.WebApp.initData signed with the bot token and issue session token
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body object true "Mini App init data" example({"init_data": "query_id=...&user=...&auth_date=...&hash=..."})
+// @Success 200 {object} map[string]interface{}
+// @Failure 400 {object} map[string]string
+// @Failure 401 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /user/login/telegram-webapp [post]
+func (h *Handler) LoginTelegramWebApp(ctx *gin.Context) {
+	var req struct {
+		InitData string `json:"init_data" binding:"required"`
+	}
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		h.logger.Errorf("Handler.LoginTelegramWebApp: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	user, token, err := h.service.LoginByTelegramInitData(req.InitData)
+	h.respondTelegramLogin(ctx, "LoginTelegramWebApp", user, token, err)
+}
+
+func (h *Handler) respondTelegramLogin(ctx *gin.Context, op string, user *models.User, token string, err error) {
+	switch {
+	case errors.Is(err, ucase.ErrTelegramAuthInvalid):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid Telegram auth data"})
+		return
+	case errors.Is(err, ucase.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
+		return
+	case err != nil:
+		h.logger.Errorf("Handler.%s: failed to issue token: %v", op, err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Login error"})
+		return
+	}
+	h.logger.Infof("%s: token issued successfully, telegram_id: %d", op, user.TelegramID)
+	ctx.JSON(http.StatusOK, tokenIssuedResponse(user, token))
 }
 
 // CheckLogin checks pending login token status
@@ -370,6 +537,7 @@ func (h *Handler) GetPublic(ctx *gin.Context) {
 		ID:        user.ID.String(),
 		FirstName: user.FirstName,
 		Surname:   user.Surname,
+		Timezone:  user.Timezone,
 		Services:  user.Services,
 	}
 	if user == nil {
@@ -456,21 +624,85 @@ func (h *Handler) UpdateTimezone(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Timezone updated successfully"})
 }
 
+// UpdateDirectoryOptOut hides the caller from the public directory or lists them again
+// @Summary Directory listing
+// @Description Opt out of the public master directory and the inline search of the bot (opt_out true) or return to it; direct links keep working
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body contract.DirectoryOptOut true "Directory listing"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Router /user/directory [put]
+func (h *Handler) UpdateDirectoryOptOut(ctx *gin.Context) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.UpdateDirectoryOptOut: auth error: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	var body contract.DirectoryOptOut
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.UpdateDirectoryOptOut: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.UpdateDirectoryOptOut(userID, body.OptOut); err != nil {
+		h.logger.Errorf("Handler.UpdateDirectoryOptOut: update error: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update directory listing"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Directory listing updated"})
+}
+
+// UpdateCurrency sets the caller's default currency for new services
+// @Summary Update currency
+// @Description Set the master's ISO 4217 currency; new services get it unless they name their own. Prices of existing services and records are not converted
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body contract.CurrencyUpdate true "Currency"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Failure 401 {object} contract.ErrorResponse
+// @Router /user/currency [put]
+func (h *Handler) UpdateCurrency(ctx *gin.Context) {
+	userID, err := utils.CurrentUserID(ctx)
+	if err != nil {
+		h.logger.Errorf("Handler.UpdateCurrency: auth error: %v", err)
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	var body contract.CurrencyUpdate
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.UpdateCurrency: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if err := h.service.UpdateCurrency(userID, body.Currency); errors.Is(err, ucase.ErrUnsupportedCurrency) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	} else if err != nil {
+		h.logger.Errorf("Handler.UpdateCurrency: update error: %v", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update currency"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Currency updated"})
+}
+
 // UpdateTimezoneInternal updates timezone by telegram_id (internal, Telegram)
 // @Summary Update timezone internal
 // @Description Update timezone by telegram_id (internal for Telegram bot)
 // @Tags user
 // @Accept json
 // @Produce json
-// @Param request body map[string]string true "Timezone update internal request"
+// @Param request body contract.TimezoneUpdate true "Timezone update internal request"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
 // @Router /telegram/user/timezone [put]
 func (h *Handler) UpdateTimezoneInternal(ctx *gin.Context) {
-	var body struct {
-		TelegramID int64  `json:"telegram_id"`
-		Timezone   string `json:"timezone"`
-	}
+	var body contract.TimezoneUpdate
 	if err := ctx.ShouldBindJSON(&body); err != nil {
 		h.logger.Errorf("Handler.UpdateTimezoneInternal: invalid request: %v", err)
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
@@ -494,6 +726,76 @@ func (h *Handler) UpdateTimezoneInternal(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"message": "Timezone updated successfully"})
 }
 
+// UpdateLanguageInternal updates bot interface language by telegram_id (internal, Telegram)
+// @Summary Update language internal
+// @Description Update Telegram bot interface language by telegram_id (internal for Telegram bot)
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body contract.LanguageUpdate true "Language update internal request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Router /telegram/user/language [put]
+func (h *Handler) UpdateLanguageInternal(ctx *gin.Context) {
+	var body contract.LanguageUpdate
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.UpdateLanguageInternal: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if body.TelegramID == 0 || body.Language == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "telegram_id and language are required"})
+		return
+	}
+	user, err := h.service.GetByTelegramID(body.TelegramID)
+	if err != nil || user == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		return
+	}
+	req := ucase.UpdateLanguageRequest{UserID: user.ID.String(), Language: body.Language}
+	if err := h.service.UpdateLanguage(req); err != nil {
+		h.logger.Errorf("Handler.UpdateLanguageInternal: update error: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%v", err)})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Language updated successfully"})
+}
+
+// UpdateDigestTimeInternal sets the master's daily digest time by telegram_id (internal, Telegram)
+// @Summary Update digest time internal
+// @Description Set the time ("HH:MM" in the master's timezone) of the daily agenda digest; empty or "off" turns it off (internal for Telegram bot)
+// @Tags user
+// @Accept json
+// @Produce json
+// @Param request body contract.DigestUpdate true "Digest time update internal request"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} contract.ErrorResponse
+// @Router /telegram/user/digest [put]
+func (h *Handler) UpdateDigestTimeInternal(ctx *gin.Context) {
+	var body contract.DigestUpdate
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		h.logger.Errorf("Handler.UpdateDigestTimeInternal: invalid request: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request"})
+		return
+	}
+	if body.TelegramID == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "telegram_id is required"})
+		return
+	}
+	user, err := h.service.GetByTelegramID(body.TelegramID)
+	if err != nil || user == nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "user not found"})
+		return
+	}
+	req := ucase.UpdateDigestTimeRequest{UserID: user.ID.String(), DigestTime: body.DigestTime}
+	if err := h.service.UpdateDigestTime(req); err != nil {
+		h.logger.Errorf("Handler.UpdateDigestTimeInternal: update error: %v", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("%v", err)})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Digest time updated successfully"})
+}
+
 func (h *Handler) GetPublicUser(ctx *gin.Context) {
 	userID := ctx.Param("uuid")
 	if userID == "" {
@@ -510,7 +812,7 @@ func (h *Handler) GetPublicUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, gin.H{"user": nil})
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"id": user.ID, "first_name": user.FirstName, "surname": user.Surname})
+	ctx.JSON(http.StatusOK, gin.H{"id": user.ID, "first_name": user.FirstName, "surname": user.Surname, "rating": user.Rating, "rating_count": user.RatingCount})
 }
 
 // RequestAccountDeletion requests account deletion via Telegram
@@ -1,7 +1,8 @@
 package middleware
 
 import (
-	"app/http/utils"
+	"app/encoder"
+	phonenum "app/pkg/phone"
 	"net/http"
 	"strings"
 
@@ -10,8 +11,9 @@ import (
 	"github.com/google/uuid"
 )
 
-// AdminPhoneMiddleware проверяет, что запрос от админа по номеру телефона
-func AdminPhoneMiddleware() gin.HandlerFunc {
+// AdminPhoneMiddleware проверяет, что запрос от админа по номеру телефона;
+// номера без кода страны разбираются по региону region
+func AdminPhoneMiddleware(region string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Получаем номер телефона из заголовка или параметра
 		phone := c.GetHeader("X-Admin-Phone")
@@ -20,7 +22,7 @@ func AdminPhoneMiddleware() gin.HandlerFunc {
 		}
 
 		// Проверяем, что это номер админа
-		if phone != "79876038494" {
+		if !isAdminPhone(phone, region) {
 			c.JSON(http.StatusForbidden, gin.H{"error": "Access denied: admin phone required"})
 			c.Abort()
 			return
@@ -69,13 +71,13 @@ func AdminAuthMiddleware() gin.HandlerFunc {
 }
 
 // AdminOrPhoneMiddleware проверяет либо админскую авторизацию, либо номер телефона
-func AdminOrPhoneMiddleware() gin.HandlerFunc {
+func AdminOrPhoneMiddleware(enc *encoder.Encoder, region string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Сначала проверяем авторизацию через JWT токен
 		auth := c.GetHeader("Authorization")
 		if auth != "" && strings.HasPrefix(auth, "Bearer ") {
 			tokenString := strings.TrimPrefix(auth, "Bearer ")
-			token, err := utils.ParseToken(tokenString)
+			token, err := enc.ParseToken(tokenString)
 			if err == nil && token.Valid {
 				claims, ok := token.Claims.(jwt.MapClaims)
 				if ok {
@@ -101,7 +103,7 @@ func AdminOrPhoneMiddleware() gin.HandlerFunc {
 			phone = c.Query("admin_phone")
 		}
 
-		if phone == "79876038494" {
+		if isAdminPhone(phone, region) {
 			c.Set("is_admin", true)
 			c.Set("admin_phone", phone)
 			c.Next()
@@ -112,3 +114,13 @@ func AdminOrPhoneMiddleware() gin.HandlerFunc {
 		c.Abort()
 	}
 }
+
+// isAdminPhone сравнивает номера в E.164, чтобы "+7…", "8…" и "7…" считались одним номером
+func isAdminPhone(phone, region string) bool {
+	normalized, err := phonenum.NormalizeRegion(phone, region)
+	if err != nil {
+		return false
+	}
+	admin, _ := phonenum.NormalizeRegion("79876038494", region)
+	return normalized == admin
+}
@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"app/pkg/utils"
 	"net/http"
 	"strings"
 
@@ -9,90 +8,6 @@ import (
 	"github.com/google/uuid"
 )
 
-// AuthMiddleware проверяет JWT токен и устанавливает пользователя в контекст
-func AuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header required"})
-			c.Abort()
-			return
-		}
-
-		// Проверяем формат "Bearer <token>"
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization format"})
-			c.Abort()
-			return
-		}
-
-		token := parts[1]
-
-		// Валидируем JWT токен
-		claims, err := utils.ValidateJWT(token)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
-			c.Abort()
-			return
-		}
-
-		// Извлекаем user_id из claims
-		userIDStr, ok := claims["user_id"].(string)
-		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
-			c.Abort()
-			return
-		}
-
-		userID, err := uuid.Parse(userIDStr)
-		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid user ID in token"})
-			c.Abort()
-			return
-		}
-
-		// Устанавливаем user_id в контекст для использования в хендлерах
-		c.Set("user_id", userID)
-		c.Set("user_telegram_id", claims["telegram_id"])
-
-		c.Next()
-	}
-}
-
-// OptionalAuthMiddleware проверяет токен если он есть, но не требует его обязательного наличия
-func OptionalAuthMiddleware() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
-			c.Next()
-			return
-		}
-
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.Next()
-			return
-		}
-
-		token := parts[1]
-		claims, err := utils.ValidateJWT(token)
-		if err != nil {
-			c.Next()
-			return
-		}
-
-		if userIDStr, ok := claims["user_id"].(string); ok {
-			if userID, err := uuid.Parse(userIDStr); err == nil {
-				c.Set("user_id", userID)
-				c.Set("user_telegram_id", claims["telegram_id"])
-			}
-		}
-
-		c.Next()
-	}
-}
-
 // RequireRoleMiddleware проверяет, что у пользователя есть определенная роль
 func RequireRoleMiddleware(requiredRole string) gin.HandlerFunc {
 	return func(c *gin.Context) {
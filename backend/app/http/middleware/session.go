@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"app/encoder"
 	"app/http/utils"
 	"net/http"
 
@@ -9,9 +10,14 @@ import (
 )
 
 // SessionAuthMiddleware проверяет сессионный токен и устанавливает пользователя в контекст
-func SessionAuthMiddleware() gin.HandlerFunc {
+func SessionAuthMiddleware(enc *encoder.Encoder) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID, err := utils.ExtractUserIDFromToken(c)
+		// Пользователь уже определён предыдущим middleware (например, TelegramActorMiddleware)
+		if _, ok := c.Get("user_id"); ok {
+			c.Next()
+			return
+		}
+		userID, err := utils.ExtractUserIDFromToken(c, enc)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 			c.Abort()
@@ -25,9 +31,9 @@ func SessionAuthMiddleware() gin.HandlerFunc {
 }
 
 // SessionAuthMiddlewareWithTelegramID проверяет сессионный токен и устанавливает пользователя с telegram_id в контекст
-func SessionAuthMiddlewareWithTelegramID() gin.HandlerFunc {
+func SessionAuthMiddlewareWithTelegramID(enc *encoder.Encoder) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID, err := utils.ExtractUserIDFromToken(c)
+		userID, err := utils.ExtractUserIDFromToken(c, enc)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 			c.Abort()
@@ -44,7 +50,7 @@ func SessionAuthMiddlewareWithTelegramID() gin.HandlerFunc {
 
 		// Парсим токен для получения telegram_id
 		tokenString := auth[7:] // Убираем "Bearer "
-		token, err := utils.ParseToken(tokenString)
+		token, err := enc.ParseToken(tokenString)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid token"})
 			c.Abort()
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"app/pkg/models"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// TelegramUserFinder ищет пользователя по telegram_id
+type TelegramUserFinder interface {
+	FindByTelegramID(telegramID int64) (*models.User, error)
+}
+
+// TelegramActorMiddleware позволяет Telegram-боту действовать от имени мастера.
+// Запрос с заголовком X-Telegram-ID должен быть подписан внутренним токеном;
+// пользователь ищется по telegram_id и кладётся в контекст так же, как это делает
+// SessionAuthMiddleware. Запросы без X-Telegram-ID проходят дальше без изменений.
+func TelegramActorMiddleware(allowedInternal string, users TelegramUserFinder) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		raw := c.GetHeader("X-Telegram-ID")
+		if raw == "" {
+			c.Next()
+			return
+		}
+		if allowedInternal == "" || c.GetHeader("X-Internal-Token") != allowedInternal {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Forbidden"})
+			c.Abort()
+			return
+		}
+		telegramID, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || telegramID == 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid X-Telegram-ID"})
+			c.Abort()
+			return
+		}
+		user, err := users.FindByTelegramID(telegramID)
+		if err != nil || user == nil {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			c.Abort()
+			return
+		}
+
+		c.Set("user_id", user.ID)
+		c.Set("user_telegram_id", telegramID)
+		c.Set("internal_caller", true)
+		c.Next()
+	}
+}
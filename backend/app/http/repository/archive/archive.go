@@ -0,0 +1,66 @@
+package archive
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// activeStatuses — заявки, которые архивирование закрывает, если слот ещё не начался
+var activeStatuses = []string{"pending", "confirm"}
+
+// Slots переносит в архив слоты по условию query в транзакции tx.
+// Заявки на ещё не начавшиеся слоты отклоняются, прошедшие визиты остаются как были.
+// Все строки получают один deleted_at = now: по нему восстановление находит удалённое вместе
+func Slots(tx *gorm.DB, now time.Time, query string, args ...any) error {
+	future := tx.Model(&models.Slot{}).Select("id").Where(query, args...).Where("start_time > ?", now)
+	if err := tx.Model(&models.Record{}).
+		Where("slot_id IN (?) AND status IN ?", future, activeStatuses).
+		Update("status", "reject").Error; err != nil {
+		return err
+	}
+	return tx.Model(&models.Slot{}).Where(query, args...).Updates(map[string]any{
+		"deleted_at": now,
+		"is_booked":  gorm.Expr("is_booked AND start_time <= ?", now),
+	}).Error
+}
+
+// Services переносит в архив услуги по условию query вместе с их слотами
+func Services(tx *gorm.DB, now time.Time, query string, args ...any) error {
+	ids := tx.Model(&models.Service{}).Select("id").Where(query, args...)
+	if err := Slots(tx, now, "service_id IN (?)", ids); err != nil {
+		return err
+	}
+	return tx.Model(&models.Service{}).Where(query, args...).Update("deleted_at", now).Error
+}
+
+// User переносит пользователя в архив: его будущие заявки отменяются, услуги и слоты уходят в архив.
+// Роли и членство в организациях остаются до очистки, чтобы восстановление вернуло всё как было
+func User(tx *gorm.DB, now time.Time, id uuid.UUID) error {
+	future := tx.Model(&models.Slot{}).Select("id").Where("start_time > ?", now)
+	var freed []uint
+	if err := tx.Model(&models.Record{}).
+		Where("client_id = ? AND status = ? AND slot_id IN (?)", id, "confirm", future).
+		Pluck("slot_id", &freed).Error; err != nil {
+		return err
+	}
+	if err := tx.Model(&models.Record{}).
+		Where("client_id = ? AND status IN ? AND slot_id IN (?)", id, activeStatuses, future).
+		Update("status", "cancel").Error; err != nil {
+		return err
+	}
+	if len(freed) > 0 {
+		if err := tx.Model(&models.Slot{}).Where("id IN ?", freed).Update("is_booked", false).Error; err != nil {
+			return err
+		}
+	}
+	if err := Services(tx, now, "master_id = ?", id); err != nil {
+		return err
+	}
+	if err := Slots(tx, now, "master_id = ?", id); err != nil {
+		return err
+	}
+	return tx.Model(&models.User{}).Where("id = ?", id).Update("deleted_at", now).Error
+}
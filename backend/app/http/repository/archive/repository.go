@@ -0,0 +1,153 @@
+package archive
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// archived — только строки в архиве, новые удаления первыми
+func archived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC")
+}
+
+func (r *Repository) ArchivedUsers(limit, offset int) ([]models.User, error) {
+	var users []models.User
+	err := r.db.Scopes(archived).Order("id ASC").Limit(limit).Offset(offset).Find(&users).Error
+	if err != nil {
+		r.logger.Errorf("Repository.ArchivedUsers: query failed: %v", err)
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *Repository) ArchivedServices(limit, offset int) ([]models.Service, error) {
+	var services []models.Service
+	err := r.db.Scopes(archived).Order("id ASC").Limit(limit).Offset(offset).Find(&services).Error
+	if err != nil {
+		r.logger.Errorf("Repository.ArchivedServices: query failed: %v", err)
+		return nil, err
+	}
+	return services, nil
+}
+
+func (r *Repository) ArchivedSlots(limit, offset int) ([]models.Slot, error) {
+	var slots []models.Slot
+	err := r.db.Scopes(archived).Preload("Service", models.WithArchived).
+		Order("id ASC").Limit(limit).Offset(offset).Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.ArchivedSlots: query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
+
+// FindUser, FindService и FindSlot ищут и среди действующих, и среди архивных строк
+func (r *Repository) FindUser(id uuid.UUID) (*models.User, error) {
+	var u models.User
+	if err := r.db.Unscoped().First(&u, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+func (r *Repository) FindService(id uint) (*models.Service, error) {
+	var svc models.Service
+	if err := r.db.Unscoped().First(&svc, id).Error; err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
+func (r *Repository) FindSlot(id uint) (*models.Slot, error) {
+	var sl models.Slot
+	if err := r.db.Unscoped().First(&sl, id).Error; err != nil {
+		return nil, err
+	}
+	return &sl, nil
+}
+
+// ContactTaken — телефон или Telegram пользователя u уже заняты действующим пользователем
+func (r *Repository) ContactTaken(u *models.User) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).
+		Where("id <> ? AND (phone = ? OR (telegram_id <> 0 AND telegram_id = ?))", u.ID, u.Phone, u.TelegramID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *Repository) RestoreSlot(sl *models.Slot) error {
+	return r.db.Unscoped().Model(&models.Slot{}).Where("id = ?", sl.ID).Update("deleted_at", nil).Error
+}
+
+// RestoreService возвращает услугу и слоты, ушедшие в архив вместе с ней
+func (r *Repository) RestoreService(svc *models.Service) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&models.Slot{}).
+			Where("service_id = ? AND deleted_at = ?", svc.ID, svc.DeletedAt.Time).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.Service{}).Where("id = ?", svc.ID).Update("deleted_at", nil).Error
+	})
+}
+
+// RestoreUser возвращает пользователя, его услуги и слоты, ушедшие в архив вместе с ним
+func (r *Repository) RestoreUser(u *models.User) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		at := u.DeletedAt.Time
+		if err := tx.Unscoped().Model(&models.Slot{}).
+			Where("master_id = ? AND deleted_at = ?", u.ID, at).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&models.Service{}).
+			Where("master_id = ? AND deleted_at = ?", u.ID, at).
+			Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.User{}).Where("id = ?", u.ID).Update("deleted_at", nil).Error
+	})
+}
+
+// Purge стирает персональные данные пользователей, удалённых раньше before: имя, телефон, Telegram,
+// роли, уведомления, членство в организациях и комментарии к заявкам. Сами заявки и отзывы
+// остаются в статистике мастеров. Возвращает число очищенных пользователей
+func (r *Repository) Purge(before, now time.Time) (int, error) {
+	var ids []uuid.UUID
+	err := r.db.Unscoped().Model(&models.User{}).
+		Where("deleted_at < ? AND purged_at IS NULL", before).
+		Pluck("id", &ids).Error
+	if err != nil || len(ids) == 0 {
+		return 0, err
+	}
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.Notification{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id IN ?", ids).Delete(&models.OrganizationMember{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.Record{}).Where("client_id IN ?", ids).Update("comment", "").Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&models.User{}).Where("id IN ?", ids).Updates(map[string]any{
+			"phone":       "",
+			"telegram_id": 0,
+			"first_name":  "",
+			"surname":     "",
+			"purged_at":   now,
+		}).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.Purge: failed: %v", err)
+		return 0, err
+	}
+	r.logger.Infof("Repository.Purge: purged %d users deleted before %s", len(ids), before.Format(time.RFC3339))
+	return len(ids), nil
+}
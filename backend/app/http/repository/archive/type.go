@@ -0,0 +1,19 @@
+package archive
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Repository — архив услуг, слотов и пользователей: просмотр, восстановление и очистка по сроку хранения
+type Repository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRepository(db *gorm.DB, logger *logrus.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
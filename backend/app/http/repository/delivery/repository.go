@@ -0,0 +1,46 @@
+package delivery
+
+import (
+	"app/pkg/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) Create(d *models.TelegramDelivery) error {
+	if err := r.db.Table("telegram_deliveries").Create(d).Error; err != nil {
+		r.logger.Errorf("Repository.Create (delivery): failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (r *Repository) FindByID(id uuid.UUID) (*models.TelegramDelivery, error) {
+	var d models.TelegramDelivery
+	if err := r.db.Table("telegram_deliveries").Where("id = ?", id).First(&d).Error; err != nil {
+		r.logger.Errorf("Repository.FindByID (delivery): id=%s: %v", id, err)
+		return nil, err
+	}
+	return &d, nil
+}
+
+// Save обновляет результат доставки
+func (r *Repository) Save(d *models.TelegramDelivery) error {
+	result := r.db.Table("telegram_deliveries").Where("id = ?", d.ID).Updates(map[string]any{
+		"status":       d.Status,
+		"attempts":     d.Attempts,
+		"message_id":   d.MessageID,
+		"last_error":   d.LastError,
+		"updated_at":   d.UpdatedAt,
+		"delivered_at": d.DeliveredAt,
+	})
+	if result.Error != nil {
+		r.logger.Errorf("Repository.Save (delivery): query failed: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	r.logger.Infof("Repository.Save (delivery): id=%s status=%s attempts=%d", d.ID, d.Status, d.Attempts)
+	return nil
+}
@@ -0,0 +1,18 @@
+package delivery
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type Repository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRepository(db *gorm.DB, logger *logrus.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
@@ -0,0 +1,101 @@
+package digest
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm/clause"
+)
+
+// Subscribers возвращает мастеров (есть хотя бы одна услуга), выбравших время сводки
+func (r *Repository) Subscribers() ([]models.User, error) {
+	var users []models.User
+	err := r.db.
+		Where("digest_time <> '' AND telegram_id <> 0").
+		Where("EXISTS (SELECT 1 FROM services WHERE services.master_id = users.id AND services.deleted_at IS NULL)").
+		Find(&users).Error
+	if err != nil {
+		r.logger.Errorf("Repository.Subscribers (digest): query failed: %v", err)
+		return nil, err
+	}
+	return users, nil
+}
+
+// Records возвращает подтверждённые и ожидающие заявки на слоты мастера, начинающиеся в [from, to)
+func (r *Repository) Records(masterID uuid.UUID, from, to time.Time) ([]models.Record, error) {
+	var records []models.Record
+	err := r.db.
+		Preload("Slot.Service").
+		Preload("Client").
+		Joins("JOIN slots ON slots.id = records.slot_id").
+		Where("slots.master_id = ? AND slots.start_time >= ? AND slots.start_time < ?", masterID, from, to).
+		Where("records.status IN ?", []string{"confirm", "pending"}).
+		Order("slots.start_time ASC").
+		Find(&records).Error
+	if err != nil {
+		r.logger.Errorf("Repository.Records (digest): query failed: %v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// PendingRecords возвращает заявки, ждущие решения мастера, на слоты начиная с from
+func (r *Repository) PendingRecords(masterID uuid.UUID, from time.Time) ([]models.Record, error) {
+	var records []models.Record
+	err := r.db.
+		Preload("Slot.Service").
+		Preload("Client").
+		Joins("JOIN slots ON slots.id = records.slot_id").
+		Where("slots.master_id = ? AND slots.start_time >= ? AND records.status = ?", masterID, from, "pending").
+		Order("slots.start_time ASC").
+		Find(&records).Error
+	if err != nil {
+		r.logger.Errorf("Repository.PendingRecords (digest): query failed: %v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// FreeSlots возвращает незанятые слоты мастера, начинающиеся в [from, to)
+func (r *Repository) FreeSlots(masterID uuid.UUID, from, to time.Time) ([]models.Slot, error) {
+	var slots []models.Slot
+	err := r.db.
+		Preload("Service").
+		Where("master_id = ? AND is_booked = ? AND start_time >= ? AND start_time < ?", masterID, false, from, to).
+		Order("start_time ASC").
+		Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FreeSlots (digest): query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
+
+// Claim отмечает сводку kind за период period отправленной.
+// false — сводку уже отправили (другой экземпляр API или до перезапуска).
+func (r *Repository) Claim(masterID uuid.UUID, kind string, period time.Time) (bool, error) {
+	result := r.db.Table("master_digests").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]any{
+			"master_id": masterID,
+			"kind":      kind,
+			"period":    period.Format(time.DateOnly),
+			"sent_at":   time.Now(),
+		})
+	if result.Error != nil {
+		r.logger.Errorf("Repository.Claim (digest): insert failed: %v", result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// Release снимает отметку, если сводку не удалось передать боту: её повторят на следующем тике
+func (r *Repository) Release(masterID uuid.UUID, kind string, period time.Time) error {
+	err := r.db.Exec(`DELETE FROM "master_digests" WHERE master_id = ? AND kind = ? AND period = ?`,
+		masterID, kind, period.Format(time.DateOnly)).Error
+	if err != nil {
+		r.logger.Errorf("Repository.Release (digest): delete failed: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,19 @@
+package digest
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Repository — выборки для утренней и недельной сводки мастера
+type Repository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRepository(db *gorm.DB, logger *logrus.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
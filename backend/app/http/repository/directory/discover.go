@@ -0,0 +1,168 @@
+package directory
+
+import (
+	"app/pkg/geo"
+	"app/pkg/models"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// tsQuery — запрос в русской и английской конфигурациях: совпадение в любой из них засчитывается
+const tsQuery = "(websearch_to_tsquery('russian', @text) || websearch_to_tsquery('english', @text))"
+
+// haversine — расстояние в км от @lat/@lon до места услуги (l), как geo.DistanceKm
+var haversine = fmt.Sprintf("2 * %g * asin(least(1, sqrt("+
+	"power(sin(radians(l.latitude - @lat) / 2), 2) + "+
+	"cos(radians(@lat)) * cos(radians(l.latitude)) * power(sin(radians(l.longitude - @lon) / 2), 2))))", geo.EarthRadiusKm)
+
+// directoryOrder — ORDER BY для каждого порядка выдачи; при равенстве — по имени
+var directoryOrder = map[string]string{
+	models.DirectorySortRelevance: "hits.rank DESC, hits.next_free ASC NULLS LAST",
+	models.DirectorySortNextSlot:  "hits.next_free ASC NULLS LAST",
+	models.DirectorySortRating:    "u.rating DESC, u.rating_count DESC, hits.next_free ASC NULLS LAST",
+	models.DirectorySortDistance:  "hits.distance ASC NULLS LAST, hits.next_free ASC NULLS LAST",
+}
+
+type directoryRow struct {
+	MasterID   uuid.UUID `gorm:"column:master_id"`
+	Distance   *float64  `gorm:"column:distance"`
+	Rank       float64   `gorm:"column:rank"`
+	ServiceIDs string    `gorm:"column:service_ids"`
+}
+
+// Discover ищет мастеров публичного каталога. Услуги фильтруются один раз в CTE matched:
+// текст ищется по имени мастера вместе с названием и описанием услуги (tsvector),
+// цена, длительность и радиус — по самой услуге. Мастер попадает в выдачу с подходящими услугами.
+func (r *Repository) Discover(q models.DirectoryQuery) ([]models.DirectoryHit, error) {
+	args := map[string]interface{}{"now": q.Now, "limit": q.Limit, "offset": q.Offset}
+	conds := []string{"s.deleted_at IS NULL", "u.deleted_at IS NULL", "u.telegram_id <> 0", "NOT u.directory_opt_out"}
+	rank, distance := "0::real", "NULL::double precision"
+	if q.Text != "" {
+		args["text"] = q.Text
+		conds = append(conds, "(u.search_vector || s.search_vector) @@ "+tsQuery)
+		rank = "ts_rank(u.search_vector || s.search_vector, " + tsQuery + ")"
+	}
+	if q.MinPrice > 0 {
+		args["min_price"] = q.MinPrice
+		conds = append(conds, "s.price >= @min_price")
+	}
+	if q.MaxPrice > 0 {
+		args["max_price"] = q.MaxPrice
+		conds = append(conds, "s.price <= @max_price")
+	}
+	if q.Currency != "" {
+		args["currency"] = q.Currency
+		conds = append(conds, "s.currency = @currency")
+	}
+	if q.MinDuration > 0 {
+		args["min_duration"] = q.MinDuration
+		conds = append(conds, "s.duration >= @min_duration")
+	}
+	if q.MaxDuration > 0 {
+		args["max_duration"] = q.MaxDuration
+		conds = append(conds, "s.duration <= @max_duration")
+	}
+	if q.Near != nil {
+		args["lat"], args["lon"] = q.Near.Latitude, q.Near.Longitude
+		distance = "CASE WHEN l.latitude IS NULL THEN NULL ELSE " + haversine + " END"
+		if q.RadiusKm > 0 {
+			args["radius"] = q.RadiusKm
+			conds = append(conds, "l.latitude IS NOT NULL AND "+haversine+" <= @radius")
+		}
+	}
+	freeWithin := ""
+	if q.FreeWithin > 0 {
+		args["until"] = q.Now.Add(q.FreeWithin)
+		freeWithin = "WHERE hits.next_free <= @until"
+	}
+	order, ok := directoryOrder[q.Sort]
+	if !ok {
+		order = directoryOrder[models.DirectorySortNextSlot]
+	}
+
+	sql := `WITH matched AS (
+	SELECT s.id, s.master_id, ` + distance + ` AS distance, ` + rank + ` AS rank,
+		(SELECT MIN(sl.start_time) FROM slots sl
+			WHERE sl.service_id = s.id AND sl.deleted_at IS NULL AND NOT sl.is_booked AND sl.start_time > @now) AS next_free
+	FROM services s
+	JOIN users u ON u.id = s.master_id
+	LEFT JOIN locations l ON l.id = s.location_id
+	WHERE ` + strings.Join(conds, " AND ") + `
+), hits AS (
+	SELECT master_id, MIN(distance) AS distance, MAX(rank) AS rank, MIN(next_free) AS next_free,
+		json_agg(id ORDER BY id)::text AS service_ids
+	FROM matched GROUP BY master_id
+)
+SELECT hits.master_id, hits.distance, hits.rank, hits.service_ids
+FROM hits JOIN users u ON u.id = hits.master_id
+` + freeWithin + `
+ORDER BY ` + order + `, u.first_name ASC, u.id ASC
+LIMIT @limit OFFSET @offset`
+
+	var rows []directoryRow
+	if err := r.db.Raw(sql, args).Scan(&rows).Error; err != nil {
+		r.logger.Errorf("Repository.Discover: query failed: %v", err)
+		return nil, err
+	}
+	hits, err := r.loadHits(rows)
+	if err != nil {
+		r.logger.Errorf("Repository.Discover: load masters failed: %v", err)
+		return nil, err
+	}
+	r.logger.Infof("Repository.Discover: text=%q sort=%s count=%d", q.Text, q.Sort, len(hits))
+	return hits, nil
+}
+
+// loadHits загружает мастеров и подходящие услуги в порядке rows
+func (r *Repository) loadHits(rows []directoryRow) ([]models.DirectoryHit, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	masterIDs := make([]uuid.UUID, 0, len(rows))
+	var serviceIDs []uint
+	perMaster := make(map[uuid.UUID][]uint, len(rows))
+	for _, row := range rows {
+		var ids []uint
+		if err := json.Unmarshal([]byte(row.ServiceIDs), &ids); err != nil {
+			return nil, err
+		}
+		masterIDs = append(masterIDs, row.MasterID)
+		serviceIDs = append(serviceIDs, ids...)
+		perMaster[row.MasterID] = ids
+	}
+	var users []models.User
+	if err := r.db.Where("id IN ?", masterIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	var services []models.Service
+	if err := r.db.Where("id IN ?", serviceIDs).Find(&services).Error; err != nil {
+		return nil, err
+	}
+	usersByID := make(map[uuid.UUID]models.User, len(users))
+	for _, u := range users {
+		usersByID[u.ID] = u
+	}
+	servicesByID := make(map[uint]models.Service, len(services))
+	for _, svc := range services {
+		servicesByID[svc.ID] = svc
+	}
+
+	hits := make([]models.DirectoryHit, 0, len(rows))
+	for _, row := range rows {
+		u, ok := usersByID[row.MasterID]
+		if !ok {
+			continue
+		}
+		u.Services = nil
+		for _, id := range perMaster[row.MasterID] {
+			if svc, ok := servicesByID[id]; ok {
+				u.Services = append(u.Services, svc)
+			}
+		}
+		hits = append(hits, models.DirectoryHit{Master: u, DistanceKm: row.Distance, Rank: row.Rank})
+	}
+	return hits, nil
+}
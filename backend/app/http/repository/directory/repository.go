@@ -0,0 +1,74 @@
+package directory
+
+import (
+	"app/pkg/models"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// likeEscaper экранирует спецсимволы LIKE, чтобы запрос «50%» искал текст, а не шаблон
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// SearchMasters возвращает мастеров (есть хотя бы одна услуга, мастер не отказался от каталога),
+// у которых имя, фамилия или название услуги содержат query; пустой query — все мастера.
+// Первыми идут мастера с ближайшим свободным слотом.
+func (r *Repository) SearchMasters(query string, limit int) ([]models.User, error) {
+	var users []models.User
+	q := r.db.
+		Preload("Services", func(db *gorm.DB) *gorm.DB { return db.Order("services.id ASC") }).
+		Where("users.telegram_id <> 0 AND NOT users.directory_opt_out").
+		Where("EXISTS (SELECT 1 FROM services WHERE services.master_id = users.id AND services.deleted_at IS NULL)")
+	if query != "" {
+		like := "%" + likeEscaper.Replace(query) + "%"
+		q = q.Where("(users.first_name || ' ' || users.surname) ILIKE ? OR EXISTS (SELECT 1 FROM services WHERE services.master_id = users.id AND services.deleted_at IS NULL AND services.name ILIKE ?)", like, like)
+	}
+	err := q.
+		Order("(SELECT MIN(slots.start_time) FROM slots WHERE slots.master_id = users.id AND slots.deleted_at IS NULL AND NOT slots.is_booked AND slots.start_time > now()) ASC NULLS LAST").
+		Order("users.first_name ASC").
+		Limit(limit).
+		Find(&users).Error
+	if err != nil {
+		r.logger.Errorf("Repository.SearchMasters: query failed: %v", err)
+		return nil, err
+	}
+	r.logger.Infof("Repository.SearchMasters: query=%q count=%d", query, len(users))
+	return users, nil
+}
+
+// NextFreeSlots возвращает до limit ближайших свободных слотов мастера, начинающихся после from
+func (r *Repository) NextFreeSlots(masterID uuid.UUID, from time.Time, limit int) ([]models.Slot, error) {
+	var slots []models.Slot
+	err := r.db.
+		Preload("Service").
+		Where("master_id = ? AND NOT is_booked AND start_time > ?", masterID, from).
+		Order("start_time ASC").
+		Limit(limit).
+		Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.NextFreeSlots: query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
+
+// NextServiceSlots возвращает до limit ближайших свободных слотов услуг serviceIDs, начинающихся после from
+func (r *Repository) NextServiceSlots(serviceIDs []uint, from time.Time, limit int) ([]models.Slot, error) {
+	if len(serviceIDs) == 0 {
+		return nil, nil
+	}
+	var slots []models.Slot
+	err := r.db.
+		Preload("Service").
+		Where("service_id IN ? AND NOT is_booked AND start_time > ?", serviceIDs, from).
+		Order("start_time ASC").
+		Limit(limit).
+		Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.NextServiceSlots: query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
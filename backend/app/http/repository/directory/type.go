@@ -0,0 +1,19 @@
+package directory
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Repository — поиск мастеров: inline-режим бота и публичный каталог
+type Repository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRepository(db *gorm.DB, logger *logrus.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
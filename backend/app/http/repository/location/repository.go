@@ -0,0 +1,60 @@
+package location
+
+import (
+	"app/pkg/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *Repository) Create(l *models.Location) error {
+	return r.db.Create(l).Error
+}
+
+func (r *Repository) FindByID(orgID uuid.UUID, id uint) (*models.Location, error) {
+	var l models.Location
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgID).First(&l).Error
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *Repository) FindByOrganization(orgID uuid.UUID) ([]models.Location, error) {
+	var list []models.Location
+	err := r.db.Where("organization_id = ?", orgID).Order("id ASC").Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindByOrganization (location): query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *Repository) Update(l *models.Location) error {
+	return r.db.Model(&models.Location{}).Where("id = ?", l.ID).
+		Select("name", "address", "latitude", "longitude", "timezone", "working_hours").
+		Updates(l).Error
+}
+
+// Delete удаляет место; location_id услуг и слотов обнуляет ON DELETE SET NULL
+func (r *Repository) Delete(orgID uuid.UUID, id uint) error {
+	return r.db.Where("id = ? AND organization_id = ?", id, orgID).Delete(&models.Location{}).Error
+}
+
+func (r *Repository) SetServiceLocation(serviceID uint, locationID *uint) error {
+	return r.db.Model(&models.Service{}).Where("id = ?", serviceID).Update("location_id", locationID).Error
+}
+
+func (r *Repository) FindServiceLocation(serviceID uint) (*models.Location, error) {
+	var l models.Location
+	err := r.db.Joins("JOIN services ON services.location_id = locations.id").
+		Where("services.id = ?", serviceID).First(&l).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
@@ -0,0 +1,19 @@
+package location
+
+import (
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// Repository — места организаций и их привязка к услугам
+type Repository struct {
+	db     *gorm.DB
+	logger *logrus.Logger
+}
+
+func NewRepository(db *gorm.DB, logger *logrus.Logger) *Repository {
+	return &Repository{
+		db:     db,
+		logger: logger,
+	}
+}
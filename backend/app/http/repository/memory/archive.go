@@ -0,0 +1,287 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ArchiveRepository — представление Store, совместимое с repository/archive.
+// Архивные строки лежат в отдельных картах, поэтому остальные репозитории их не видят,
+// а подробности записей и отзывов (…WithDetailsLocked) находят их через *AnyLocked
+type ArchiveRepository struct{ s *Store }
+
+func (s *Store) Archive() *ArchiveRepository { return &ArchiveRepository{s: s} }
+
+func archivedAt(now time.Time) gorm.DeletedAt {
+	return gorm.DeletedAt{Time: now, Valid: true}
+}
+
+func (s *Store) userAnyLocked(id uuid.UUID) models.User {
+	if u, ok := s.users[id]; ok {
+		return u
+	}
+	return s.archivedUsers[id]
+}
+
+func (s *Store) serviceAnyLocked(id uint) (models.Service, bool) {
+	if svc, ok := s.services[id]; ok {
+		return svc, true
+	}
+	svc, ok := s.archivedServices[id]
+	return svc, ok
+}
+
+func (s *Store) slotAnyLocked(id uint) models.Slot {
+	if sl, ok := s.slots[id]; ok {
+		return sl
+	}
+	return s.archivedSlots[id]
+}
+
+// archiveSlotsLocked переносит в архив слоты, подходящие под match (как archive.Slots)
+func (s *Store) archiveSlotsLocked(now time.Time, match func(models.Slot) bool) {
+	for id, sl := range s.slots {
+		if !match(sl) {
+			continue
+		}
+		if sl.StartTime.After(now) {
+			for rid, rec := range s.records {
+				if rec.SlotID == id && (rec.Status == "pending" || rec.Status == "confirm") {
+					rec.Status = "reject"
+					s.records[rid] = rec
+				}
+			}
+			sl.IsBooked = false
+		}
+		sl.DeletedAt = archivedAt(now)
+		s.archivedSlots[id] = sl
+		delete(s.slots, id)
+	}
+}
+
+func (s *Store) archiveServiceLocked(now time.Time, id uint) {
+	svc, ok := s.services[id]
+	if !ok {
+		return
+	}
+	s.archiveSlotsLocked(now, func(sl models.Slot) bool { return sl.ServiceID == id })
+	svc.DeletedAt = archivedAt(now)
+	s.archivedServices[id] = svc
+	delete(s.services, id)
+}
+
+// archiveUserLocked переносит пользователя в архив (как archive.User)
+func (s *Store) archiveUserLocked(now time.Time, id uuid.UUID) {
+	u, ok := s.users[id]
+	if !ok {
+		return
+	}
+	for rid, rec := range s.records {
+		sl, ok := s.slots[rec.SlotID]
+		if rec.ClientID != id || !ok || !sl.StartTime.After(now) {
+			continue
+		}
+		switch rec.Status {
+		case "confirm":
+			sl.IsBooked = false
+			s.slots[rec.SlotID] = sl
+			fallthrough
+		case "pending":
+			rec.Status = "cancel"
+			s.records[rid] = rec
+		}
+	}
+	for sid, svc := range s.services {
+		if svc.MasterID == id {
+			s.archiveServiceLocked(now, sid)
+		}
+	}
+	s.archiveSlotsLocked(now, func(sl models.Slot) bool { return sl.MasterID == id })
+	u.DeletedAt = archivedAt(now)
+	s.archivedUsers[id] = u
+	delete(s.users, id)
+}
+
+// page возвращает строки [offset, offset+limit) после сортировки: новые удаления первыми
+func page[T any](rows []T, at func(T) time.Time, limit, offset int) []T {
+	sort.SliceStable(rows, func(i, j int) bool { return at(rows[i]).After(at(rows[j])) })
+	if offset >= len(rows) {
+		return nil
+	}
+	rows = rows[offset:]
+	if limit > 0 && limit < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows
+}
+
+func (r *ArchiveRepository) ArchivedUsers(limit, offset int) ([]models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.User
+	for _, u := range r.s.archivedUsers {
+		out = append(out, u)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID.String() < out[j].ID.String() })
+	return page(out, func(u models.User) time.Time { return u.DeletedAt.Time }, limit, offset), nil
+}
+
+func (r *ArchiveRepository) ArchivedServices(limit, offset int) ([]models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Service
+	for _, svc := range r.s.archivedServices {
+		out = append(out, svc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return page(out, func(svc models.Service) time.Time { return svc.DeletedAt.Time }, limit, offset), nil
+}
+
+func (r *ArchiveRepository) ArchivedSlots(limit, offset int) ([]models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Slot
+	for _, sl := range r.s.archivedSlots {
+		sl.Service, _ = r.s.serviceAnyLocked(sl.ServiceID)
+		out = append(out, sl)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return page(out, func(sl models.Slot) time.Time { return sl.DeletedAt.Time }, limit, offset), nil
+}
+
+func (r *ArchiveRepository) FindUser(id uuid.UUID) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		if u, ok = r.s.archivedUsers[id]; !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+	return &u, nil
+}
+
+func (r *ArchiveRepository) FindService(id uint) (*models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.serviceAnyLocked(id)
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &svc, nil
+}
+
+func (r *ArchiveRepository) FindSlot(id uint) (*models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[id]
+	if !ok {
+		if sl, ok = r.s.archivedSlots[id]; !ok {
+			return nil, gorm.ErrRecordNotFound
+		}
+	}
+	return &sl, nil
+}
+
+func (r *ArchiveRepository) ContactTaken(u *models.User) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, other := range r.s.users {
+		if id != u.ID && (other.Phone == u.Phone || (u.TelegramID != 0 && other.TelegramID == u.TelegramID)) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *ArchiveRepository) RestoreSlot(sl *models.Slot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.restoreSlotsLocked(func(a models.Slot) bool { return a.ID == sl.ID })
+	return nil
+}
+
+func (r *ArchiveRepository) RestoreService(svc *models.Service) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.restoreServiceLocked(svc.ID, svc.DeletedAt.Time)
+	return nil
+}
+
+func (r *ArchiveRepository) RestoreUser(u *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	a, ok := r.s.archivedUsers[u.ID]
+	if !ok {
+		return nil
+	}
+	at := a.DeletedAt.Time
+	for sid, svc := range r.s.archivedServices {
+		if svc.MasterID == u.ID && svc.DeletedAt.Time.Equal(at) {
+			r.s.restoreServiceLocked(sid, at)
+		}
+	}
+	r.s.restoreSlotsLocked(func(sl models.Slot) bool { return sl.MasterID == u.ID && sl.DeletedAt.Time.Equal(at) })
+	a.DeletedAt = gorm.DeletedAt{}
+	r.s.users[u.ID] = a
+	delete(r.s.archivedUsers, u.ID)
+	return nil
+}
+
+func (s *Store) restoreServiceLocked(id uint, at time.Time) {
+	svc, ok := s.archivedServices[id]
+	if !ok {
+		return
+	}
+	s.restoreSlotsLocked(func(sl models.Slot) bool { return sl.ServiceID == id && sl.DeletedAt.Time.Equal(at) })
+	svc.DeletedAt = gorm.DeletedAt{}
+	s.services[id] = svc
+	delete(s.archivedServices, id)
+}
+
+func (s *Store) restoreSlotsLocked(match func(models.Slot) bool) {
+	for id, sl := range s.archivedSlots {
+		if match(sl) {
+			sl.DeletedAt = gorm.DeletedAt{}
+			s.slots[id] = sl
+			delete(s.archivedSlots, id)
+		}
+	}
+}
+
+func (r *ArchiveRepository) Purge(before, now time.Time) (int, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	purged := 0
+	for id, u := range r.s.archivedUsers {
+		if u.PurgedAt != nil || !u.DeletedAt.Time.Before(before) {
+			continue
+		}
+		delete(r.s.roles, id)
+		for nid, n := range r.s.notifications {
+			if n.UserID == id {
+				delete(r.s.notifications, nid)
+			}
+		}
+		for key := range r.s.members {
+			if key.user == id {
+				delete(r.s.members, key)
+			}
+		}
+		for rid, rec := range r.s.records {
+			if rec.ClientID == id {
+				rec.Comment = ""
+				r.s.records[rid] = rec
+			}
+		}
+		at := now
+		u.Phone, u.TelegramID, u.FirstName, u.Surname, u.PurgedAt = "", 0, "", "", &at
+		r.s.archivedUsers[id] = u
+		purged++
+	}
+	return purged, nil
+}
@@ -0,0 +1,244 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (r *ServiceRepository) CreateCategory(c *models.ServiceCategory) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[c.MasterID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, existing := range r.s.categories {
+		if existing.MasterID == c.MasterID && strings.EqualFold(existing.Name, c.Name) {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	r.s.lastCategoryID++
+	c.ID = r.s.lastCategoryID
+	r.s.categories[c.ID] = *c
+	return nil
+}
+
+func (r *ServiceRepository) GetCategories(masterID uuid.UUID) ([]models.ServiceCategory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.ServiceCategory
+	for _, c := range r.s.categories {
+		if c.MasterID == masterID {
+			out = append(out, c)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return positionLess(out[i].Position, out[j].Position, out[i].ID, out[j].ID) })
+	return out, nil
+}
+
+func (r *ServiceRepository) GetCategory(id uint) (models.ServiceCategory, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	c, ok := r.s.categories[id]
+	if !ok {
+		return models.ServiceCategory{}, gorm.ErrRecordNotFound
+	}
+	return c, nil
+}
+
+func (r *ServiceRepository) UpdateCategory(c *models.ServiceCategory) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.categories[c.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name, stored.Position = c.Name, c.Position
+	r.s.categories[c.ID] = stored
+	return nil
+}
+
+func (r *ServiceRepository) DeleteCategory(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.deleteCategoryLocked(id)
+	return nil
+}
+
+func (r *ServiceRepository) SetServiceCategory(serviceID uint, categoryID *uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok {
+		return nil
+	}
+	if categoryID != nil {
+		if _, ok := r.s.categories[*categoryID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	svc.CategoryID = categoryID
+	r.s.services[serviceID] = svc
+	return nil
+}
+
+func (r *ServiceRepository) CreateVariant(v *models.ServiceVariant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.services[v.ServiceID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastVariantID++
+	v.ID = r.s.lastVariantID
+	r.s.variants[v.ID] = *v
+	return nil
+}
+
+func (r *ServiceRepository) GetVariant(id uint) (models.ServiceVariant, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	v, ok := r.s.variants[id]
+	if !ok {
+		return models.ServiceVariant{}, gorm.ErrRecordNotFound
+	}
+	return v, nil
+}
+
+func (r *ServiceRepository) UpdateVariant(v *models.ServiceVariant) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.variants[v.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name, stored.Price, stored.Duration, stored.Position = v.Name, v.Price, v.Duration, v.Position
+	r.s.variants[v.ID] = stored
+	return nil
+}
+
+func (r *ServiceRepository) DeleteVariant(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.deleteVariantLocked(id)
+	return nil
+}
+
+func (r *ServiceRepository) CreateAddOn(a *models.ServiceAddOn) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.services[a.ServiceID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastAddOnID++
+	a.ID = r.s.lastAddOnID
+	r.s.addOns[a.ID] = *a
+	return nil
+}
+
+func (r *ServiceRepository) GetAddOn(id uint) (models.ServiceAddOn, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	a, ok := r.s.addOns[id]
+	if !ok {
+		return models.ServiceAddOn{}, gorm.ErrRecordNotFound
+	}
+	return a, nil
+}
+
+func (r *ServiceRepository) UpdateAddOn(a *models.ServiceAddOn) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.addOns[a.ID]
+	if !ok {
+		return nil
+	}
+	stored.Name, stored.Price, stored.Duration, stored.Position = a.Name, a.Price, a.Duration, a.Position
+	r.s.addOns[a.ID] = stored
+	return nil
+}
+
+func (r *ServiceRepository) DeleteAddOn(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.deleteAddOnLocked(id)
+	return nil
+}
+
+// deleteCategoryLocked удаляет категорию; услуги остаются без категории (ON DELETE SET NULL)
+func (s *Store) deleteCategoryLocked(id uint) {
+	delete(s.categories, id)
+	for sid, svc := range s.services {
+		if svc.CategoryID != nil && *svc.CategoryID == id {
+			svc.CategoryID = nil
+			s.services[sid] = svc
+		}
+	}
+}
+
+// deleteVariantLocked удаляет вариант; записи сохраняют снимок без variant_id (ON DELETE SET NULL)
+func (s *Store) deleteVariantLocked(id uint) {
+	delete(s.variants, id)
+	for rid, rec := range s.records {
+		if rec.VariantID != nil && *rec.VariantID == id {
+			rec.VariantID = nil
+			s.records[rid] = rec
+		}
+	}
+}
+
+// deleteAddOnLocked удаляет опцию; выбранные в записях опции остаются без add_on_id (ON DELETE SET NULL)
+func (s *Store) deleteAddOnLocked(id uint) {
+	delete(s.addOns, id)
+	for rid, rec := range s.records {
+		changed := false
+		addOns := append([]models.RecordAddOn(nil), rec.AddOns...)
+		for i, a := range addOns {
+			if a.AddOnID != nil && *a.AddOnID == id {
+				addOns[i].AddOnID = nil
+				changed = true
+			}
+		}
+		if changed {
+			rec.AddOns = addOns
+			s.records[rid] = rec
+		}
+	}
+}
+
+// serviceWithOptionsLocked возвращает услугу с вариантами и опциями в порядке прайса
+func (s *Store) serviceWithOptionsLocked(svc models.Service) models.Service {
+	svc.Variants, svc.AddOns = nil, nil
+	for _, v := range s.variants {
+		if v.ServiceID == svc.ID {
+			svc.Variants = append(svc.Variants, v)
+		}
+	}
+	for _, a := range s.addOns {
+		if a.ServiceID == svc.ID {
+			svc.AddOns = append(svc.AddOns, a)
+		}
+	}
+	sort.Slice(svc.Variants, func(i, j int) bool {
+		return positionLess(svc.Variants[i].Position, svc.Variants[j].Position, svc.Variants[i].ID, svc.Variants[j].ID)
+	})
+	sort.Slice(svc.AddOns, func(i, j int) bool {
+		return positionLess(svc.AddOns[i].Position, svc.AddOns[j].Position, svc.AddOns[i].ID, svc.AddOns[j].ID)
+	})
+	return svc
+}
+
+// serviceRow — услуга без вариантов и опций, как её сохраняет Omit(clause.Associations)
+func serviceRow(svc models.Service) models.Service {
+	svc.Variants, svc.AddOns = nil, nil
+	return svc
+}
+
+func positionLess(pi, pj int, idi, idj uint) bool {
+	if pi != pj {
+		return pi < pj
+	}
+	return idi < idj
+}
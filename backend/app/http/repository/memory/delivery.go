@@ -0,0 +1,40 @@
+package memory
+
+import (
+	"app/pkg/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeliveryRepository — outbox уведомлений в Telegram
+type DeliveryRepository struct {
+	s *Store
+}
+
+func (r *DeliveryRepository) Create(d *models.TelegramDelivery) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.deliveries[d.ID] = *d
+	return nil
+}
+
+func (r *DeliveryRepository) FindByID(id uuid.UUID) (*models.TelegramDelivery, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	d, ok := r.s.deliveries[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &d, nil
+}
+
+func (r *DeliveryRepository) Save(d *models.TelegramDelivery) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.deliveries[d.ID]; !ok {
+		return gorm.ErrRecordNotFound
+	}
+	r.s.deliveries[d.ID] = *d
+	return nil
+}
@@ -0,0 +1,271 @@
+package memory
+
+import (
+	"app/pkg/geo"
+	"app/pkg/models"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// DirectoryRepository — поиск мастеров: inline-режим бота и публичный каталог
+type DirectoryRepository struct {
+	s *Store
+}
+
+func (r *DirectoryRepository) SearchMasters(query string, limit int) ([]models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	query = strings.ToLower(query)
+	now := time.Now()
+	type candidate struct {
+		user     models.User
+		nextFree time.Time
+	}
+	var found []candidate
+	for _, u := range r.s.users {
+		if u.TelegramID == 0 || u.DirectoryOptOut {
+			continue
+		}
+		var services []models.Service
+		serviceMatch := false
+		for _, svc := range r.s.services {
+			if svc.MasterID == u.ID {
+				services = append(services, svc)
+				serviceMatch = serviceMatch || strings.Contains(strings.ToLower(svc.Name), query)
+			}
+		}
+		if len(services) == 0 {
+			continue
+		}
+		if !serviceMatch && !strings.Contains(strings.ToLower(u.FirstName+" "+u.Surname), query) {
+			continue
+		}
+		sort.Slice(services, func(i, j int) bool { return services[i].ID < services[j].ID })
+		u.Services = services
+		c := candidate{user: u}
+		for _, sl := range r.s.slots {
+			if sl.MasterID == u.ID && !sl.IsBooked && sl.StartTime.After(now) && (c.nextFree.IsZero() || sl.StartTime.Before(c.nextFree)) {
+				c.nextFree = sl.StartTime
+			}
+		}
+		found = append(found, c)
+	}
+	// Как ORDER BY ... NULLS LAST в repository/directory: мастера без свободных слотов — в конце
+	sort.Slice(found, func(i, j int) bool {
+		a, b := found[i], found[j]
+		if a.nextFree.IsZero() != b.nextFree.IsZero() {
+			return b.nextFree.IsZero()
+		}
+		if !a.nextFree.Equal(b.nextFree) {
+			return a.nextFree.Before(b.nextFree)
+		}
+		return a.user.FirstName < b.user.FirstName
+	})
+	if len(found) > limit {
+		found = found[:limit]
+	}
+	out := make([]models.User, 0, len(found))
+	for _, c := range found {
+		out = append(out, c.user)
+	}
+	return out, nil
+}
+
+func (r *DirectoryRepository) NextFreeSlots(masterID uuid.UUID, from time.Time, limit int) ([]models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Slot
+	for _, sl := range r.s.slots {
+		if sl.MasterID == masterID && !sl.IsBooked && sl.StartTime.After(from) {
+			sl.Service = r.s.services[sl.ServiceID]
+			out = append(out, sl)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func (r *DirectoryRepository) NextServiceSlots(serviceIDs []uint, from time.Time, limit int) ([]models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	wanted := make(map[uint]bool, len(serviceIDs))
+	for _, id := range serviceIDs {
+		wanted[id] = true
+	}
+	var out []models.Slot
+	for _, sl := range r.s.slots {
+		if wanted[sl.ServiceID] && !sl.IsBooked && sl.StartTime.After(from) {
+			sl.Service = r.s.services[sl.ServiceID]
+			out = append(out, sl)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// directoryHit — мастер-кандидат каталога с агрегатами по подходящим услугам
+type directoryHit struct {
+	hit      models.DirectoryHit
+	nextFree time.Time
+}
+
+// Discover повторяет repository/directory. Вместо стемминга Postgres слово запроса
+// совпадает со словом текста по префиксу основы (слово без последней буквы),
+// синтаксис websearch (кавычки, «-», «or») не поддерживается
+func (r *DirectoryRepository) Discover(q models.DirectoryQuery) ([]models.DirectoryHit, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	terms := strings.Fields(strings.ToLower(q.Text))
+	byMaster := make(map[uuid.UUID]*directoryHit)
+	for _, svc := range r.s.services {
+		u, ok := r.s.users[svc.MasterID]
+		if !ok || u.TelegramID == 0 || u.DirectoryOptOut {
+			continue
+		}
+		if (q.MinPrice > 0 && svc.Price < q.MinPrice) || (q.MaxPrice > 0 && svc.Price > q.MaxPrice) ||
+			(q.Currency != "" && svc.Currency != q.Currency) ||
+			(q.MinDuration > 0 && svc.Duration < q.MinDuration) || (q.MaxDuration > 0 && svc.Duration > q.MaxDuration) {
+			continue
+		}
+		rank, ok := textRank(terms, u.FirstName+" "+u.Surname+" "+svc.Name, svc.Description)
+		if !ok {
+			continue
+		}
+		var distance *float64
+		if q.Near != nil {
+			if l, ok := r.s.locations[derefUint(svc.LocationID)]; ok && l.Latitude != nil {
+				d := geo.DistanceKm(*q.Near, geo.Point{Latitude: *l.Latitude, Longitude: *l.Longitude})
+				distance = &d
+			}
+			if q.RadiusKm > 0 && (distance == nil || *distance > q.RadiusKm) {
+				continue
+			}
+		}
+
+		h, ok := byMaster[u.ID]
+		if !ok {
+			h = &directoryHit{hit: models.DirectoryHit{Master: u}}
+			h.hit.Master.Services = nil
+			byMaster[u.ID] = h
+		}
+		h.hit.Master.Services = append(h.hit.Master.Services, svc)
+		if rank > h.hit.Rank {
+			h.hit.Rank = rank
+		}
+		if distance != nil && (h.hit.DistanceKm == nil || *distance < *h.hit.DistanceKm) {
+			h.hit.DistanceKm = distance
+		}
+		for _, sl := range r.s.slots {
+			if sl.ServiceID == svc.ID && !sl.IsBooked && sl.StartTime.After(q.Now) && (h.nextFree.IsZero() || sl.StartTime.Before(h.nextFree)) {
+				h.nextFree = sl.StartTime
+			}
+		}
+	}
+
+	var found []*directoryHit
+	for _, h := range byMaster {
+		if q.FreeWithin > 0 && (h.nextFree.IsZero() || h.nextFree.After(q.Now.Add(q.FreeWithin))) {
+			continue
+		}
+		sort.Slice(h.hit.Master.Services, func(i, j int) bool { return h.hit.Master.Services[i].ID < h.hit.Master.Services[j].ID })
+		found = append(found, h)
+	}
+	sort.Slice(found, func(i, j int) bool { return directoryLess(q.Sort, found[i], found[j]) })
+
+	out := make([]models.DirectoryHit, 0, len(found))
+	for i := q.Offset; i < len(found) && len(out) < q.Limit; i++ {
+		out = append(out, found[i].hit)
+	}
+	return out, nil
+}
+
+// directoryLess — ORDER BY из repository/directory: NULLS LAST, при равенстве — по имени и id
+func directoryLess(order string, a, b *directoryHit) bool {
+	nextFree := func() (bool, bool) {
+		if a.nextFree.IsZero() != b.nextFree.IsZero() {
+			return b.nextFree.IsZero(), true
+		}
+		if !a.nextFree.Equal(b.nextFree) {
+			return a.nextFree.Before(b.nextFree), true
+		}
+		return false, false
+	}
+	switch order {
+	case models.DirectorySortRelevance:
+		if a.hit.Rank != b.hit.Rank {
+			return a.hit.Rank > b.hit.Rank
+		}
+	case models.DirectorySortRating:
+		ma, mb := a.hit.Master, b.hit.Master
+		if ma.Rating != mb.Rating {
+			return ma.Rating > mb.Rating
+		}
+		if ma.RatingCount != mb.RatingCount {
+			return ma.RatingCount > mb.RatingCount
+		}
+	case models.DirectorySortDistance:
+		da, db := a.hit.DistanceKm, b.hit.DistanceKm
+		if (da == nil) != (db == nil) {
+			return db == nil
+		}
+		if da != nil && *da != *db {
+			return *da < *db
+		}
+	}
+	if less, ok := nextFree(); ok {
+		return less
+	}
+	if a.hit.Master.FirstName != b.hit.Master.FirstName {
+		return a.hit.Master.FirstName < b.hit.Master.FirstName
+	}
+	return a.hit.Master.ID.String() < b.hit.Master.ID.String()
+}
+
+// textRank — все слова запроса должны найтись в title или description;
+// слово из title весит 1, из description — 0.4 (веса A и B в tsvector)
+func textRank(terms []string, title, description string) (float64, bool) {
+	titleWords := strings.Fields(strings.ToLower(title))
+	descriptionWords := strings.Fields(strings.ToLower(description))
+	var rank float64
+	for _, term := range terms {
+		switch {
+		case matchesWord(term, titleWords):
+			rank++
+		case matchesWord(term, descriptionWords):
+			rank += 0.4
+		default:
+			return 0, false
+		}
+	}
+	return rank, true
+}
+
+func matchesWord(term string, words []string) bool {
+	stem := term
+	if utf8.RuneCountInString(term) > 4 {
+		stem = string([]rune(term)[:utf8.RuneCountInString(term)-1])
+	}
+	for _, w := range words {
+		if strings.HasPrefix(strings.Trim(w, ".,;:!?()«»\""), stem) {
+			return true
+		}
+	}
+	return false
+}
+
+func derefUint(v *uint) uint {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// LocationRepository — места организаций и их привязка к услугам
+type LocationRepository struct {
+	s *Store
+}
+
+func (r *LocationRepository) Create(l *models.Location) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.organizations[l.OrganizationID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastLocationID++
+	l.ID = r.s.lastLocationID
+	l.CreatedAt = time.Now()
+	r.s.locations[l.ID] = *l
+	return nil
+}
+
+func (r *LocationRepository) FindByID(orgID uuid.UUID, id uint) (*models.Location, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	l, ok := r.s.locations[id]
+	if !ok || l.OrganizationID != orgID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &l, nil
+}
+
+func (r *LocationRepository) FindByOrganization(orgID uuid.UUID) ([]models.Location, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Location
+	for _, l := range r.s.locations {
+		if l.OrganizationID == orgID {
+			out = append(out, l)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *LocationRepository) Update(l *models.Location) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	row, ok := r.s.locations[l.ID]
+	if !ok {
+		return nil
+	}
+	row.Name, row.Address, row.Latitude, row.Longitude = l.Name, l.Address, l.Latitude, l.Longitude
+	row.Timezone, row.WorkingHours = l.Timezone, l.WorkingHours
+	r.s.locations[l.ID] = row
+	return nil
+}
+
+func (r *LocationRepository) Delete(orgID uuid.UUID, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if l, ok := r.s.locations[id]; ok && l.OrganizationID == orgID {
+		r.s.deleteLocationLocked(id)
+	}
+	return nil
+}
+
+func (r *LocationRepository) SetServiceLocation(serviceID uint, locationID *uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok {
+		return nil
+	}
+	if locationID != nil {
+		if _, ok := r.s.locations[*locationID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	svc.LocationID = locationID
+	r.s.services[serviceID] = svc
+	return nil
+}
+
+func (r *LocationRepository) FindServiceLocation(serviceID uint) (*models.Location, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok || svc.LocationID == nil {
+		return nil, nil
+	}
+	l, ok := r.s.locations[*svc.LocationID]
+	if !ok {
+		return nil, nil
+	}
+	return &l, nil
+}
@@ -0,0 +1,145 @@
+// Package memtest — общая фикстура тестов usecase-слоя поверх хранилища в памяти.
+// Импортируется только из _test.go файлов.
+package memtest
+
+import (
+	"app/http/repository/memory"
+	"app/pkg/models"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Значения по умолчанию для услуг AddService
+const (
+	DefaultServiceName     = "Стрижка"
+	DefaultServicePrice    = 150000 // 1 500 ₽ в копейках
+	DefaultServiceDuration = 60
+)
+
+// SlotStart — начало слота, на который записываются клиенты в тестах заявок
+var SlotStart = time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC)
+
+// Booker создаёт заявку через usecase (record.Service)
+type Booker interface {
+	Create(rec *models.Record) error
+}
+
+// Fixture — пустое хранилище, Telegram вместо нотификатора, логгер без вывода,
+// мастер «Анна Мастер» (1001) с услугой по умолчанию и два клиента (2001, 2002).
+// Тесты встраивают её в свои фикстуры.
+type Fixture struct {
+	Store   *memory.Store
+	TG      *memory.Telegram
+	Logger  *logrus.Logger
+	Master  models.User
+	Clients []models.User
+	Service models.Service
+}
+
+func New(t testing.TB) *Fixture {
+	t.Helper()
+	f := Empty()
+	f.Master = f.User(t, models.User{TelegramID: 1001, FirstName: "Анна", Surname: "Мастер", Timezone: "Europe/Moscow"})
+	f.Clients = []models.User{
+		f.User(t, models.User{TelegramID: 2001, FirstName: "Ольга", Surname: "Клиент"}),
+		f.User(t, models.User{TelegramID: 2002, FirstName: "Мария", Surname: "Клиент"}),
+	}
+	f.Service = f.AddService(t, models.Service{MasterID: f.Master.ID})
+	return f
+}
+
+// Empty — фикстура без пользователей и услуг
+func Empty() *Fixture {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return &Fixture{Store: memory.NewStore(), TG: &memory.Telegram{}, Logger: logger}
+}
+
+// User создаёт пользователя; без телефона он получает +7999000XXXX по telegram_id
+func (f *Fixture) User(t testing.TB, u models.User) models.User {
+	t.Helper()
+	if u.Phone == "" {
+		u.Phone = fmt.Sprintf("+7999000%04d", u.TelegramID)
+	}
+	if err := f.Store.Users().Create(&u); err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// AddService создаёт услугу; пустые имя, цена и длительность берутся из Default*
+func (f *Fixture) AddService(t testing.TB, s models.Service) models.Service {
+	t.Helper()
+	if s.Name == "" {
+		s.Name = DefaultServiceName
+	}
+	if s.Price == 0 {
+		s.Price = DefaultServicePrice
+	}
+	if s.Duration == 0 {
+		s.Duration = DefaultServiceDuration
+	}
+	if err := f.Store.Services().CreateService(&s); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// UpdateService меняет услугу по умолчанию и сохраняет её
+func (f *Fixture) UpdateService(t testing.TB, change func(s *models.Service)) {
+	t.Helper()
+	change(&f.Service)
+	if err := f.Store.Services().UpdateService(&f.Service); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// AddSlot создаёт слот услуги с начала start на её длительность, минуя проверки usecase
+func (f *Fixture) AddSlot(t testing.TB, s models.Service, start time.Time) models.Slot {
+	t.Helper()
+	sl := models.Slot{MasterID: s.MasterID, ServiceID: s.ID, StartTime: start, EndTime: start.Add(time.Duration(s.Duration) * time.Minute)}
+	if err := f.Store.Slots().Create(&sl); err != nil {
+		t.Fatal(err)
+	}
+	return sl
+}
+
+// Record сохраняет заявку клиента со статусом status напрямую в хранилище
+func (f *Fixture) Record(t testing.TB, client models.User, sl models.Slot, status string) models.Record {
+	t.Helper()
+	rec := models.Record{SlotID: sl.ID, ClientID: client.ID, Status: status}
+	if _, err := f.Store.Records().Create(&rec); err != nil {
+		t.Fatal(err)
+	}
+	return rec
+}
+
+// Visit создаёт слот услуги по умолчанию на start и заявку client на него со статусом status
+func (f *Fixture) Visit(t testing.TB, client models.User, start time.Time, status string) models.Record {
+	t.Helper()
+	return f.Record(t, client, f.AddSlot(t, f.Service, start), status)
+}
+
+// Book записывает клиента на слот через usecase
+func (f *Fixture) Book(t testing.TB, b Booker, client models.User, sl models.Slot) models.Record {
+	t.Helper()
+	rec := models.Record{SlotID: sl.ID, ClientID: client.ID}
+	if err := b.Create(&rec); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	return rec
+}
+
+// Get читает заявку со слотом, услугой, мастером и клиентом
+func (f *Fixture) Get(t testing.TB, id uint) models.Record {
+	t.Helper()
+	rec, err := f.Store.Records().GetRecordByIDWithDetails(id)
+	if err != nil {
+		t.Fatalf("record %d: %v", id, err)
+	}
+	return rec
+}
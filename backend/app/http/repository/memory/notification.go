@@ -0,0 +1,76 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NotificationRepository — уведомления на сайте
+type NotificationRepository struct {
+	s *Store
+}
+
+func (r *NotificationRepository) Create(n *models.Notification) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.lastNotificationID++
+	n.ID = r.s.lastNotificationID
+	if n.CreatedAt.IsZero() {
+		n.CreatedAt = time.Now()
+	}
+	r.s.notifications[n.ID] = *n
+	return nil
+}
+
+func (r *NotificationRepository) FindUserNotifications(userID uuid.UUID) ([]models.Notification, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Notification
+	for _, n := range r.s.notifications {
+		if n.UserID == userID {
+			out = append(out, n)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *NotificationRepository) CountUnreadUserNotifications(userID uuid.UUID) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var count int64
+	for _, n := range r.s.notifications {
+		if n.UserID == userID && !n.IsRead {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *NotificationRepository) MarkIsReadNotification(id uint, userID uuid.UUID, isRead bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	n, ok := r.s.notifications[id]
+	if !ok || n.UserID != userID {
+		return fmt.Errorf("notification not found or access denied")
+	}
+	n.IsRead = isRead
+	r.s.notifications[id] = n
+	return nil
+}
+
+func (r *NotificationRepository) MarkReadAllNotifications(userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for id, n := range r.s.notifications {
+		if n.UserID == userID {
+			n.IsRead = true
+			r.s.notifications[id] = n
+		}
+	}
+	return nil
+}
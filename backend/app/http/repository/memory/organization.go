@@ -0,0 +1,252 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository — организации, их участники и услуги
+type OrganizationRepository struct {
+	s *Store
+}
+
+type memberKey struct {
+	org, user uuid.UUID
+}
+
+func (r *OrganizationRepository) Create(org *models.Organization, ownerID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[ownerID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.createLocked(org, ownerID)
+	return nil
+}
+
+func (r *OrganizationRepository) createLocked(org *models.Organization, ownerID uuid.UUID) {
+	org.ID = uuid.New()
+	org.CreatedAt = time.Now()
+	row := *org
+	row.Members = nil
+	r.s.organizations[org.ID] = row
+	r.s.members[memberKey{org.ID, ownerID}] = models.OrganizationMember{
+		OrganizationID: org.ID, UserID: ownerID, Role: models.OrgRoleOwner, CreatedAt: org.CreatedAt,
+	}
+}
+
+func (r *OrganizationRepository) FindByID(id uuid.UUID) (*models.Organization, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	org, ok := r.s.organizations[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	org = r.withMembersLocked(org)
+	return &org, nil
+}
+
+func (r *OrganizationRepository) FindByMember(userID uuid.UUID) ([]models.Organization, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Organization
+	for key := range r.s.members {
+		if key.user == userID {
+			out = append(out, r.withMembersLocked(r.s.organizations[key.org]))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+func (r *OrganizationRepository) Rename(id uuid.UUID, name string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	org, ok := r.s.organizations[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	org.Name = name
+	r.s.organizations[id] = org
+	return nil
+}
+
+func (r *OrganizationRepository) Personal(userID uuid.UUID) (*models.Organization, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, org := range r.s.organizations {
+		if org.PersonalOwnerID != nil && *org.PersonalOwnerID == userID {
+			return &org, nil
+		}
+	}
+	u, ok := r.s.users[userID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	name := strings.TrimSpace(u.FirstName + " " + u.Surname)
+	if name == "" {
+		name = u.Phone
+	}
+	org := &models.Organization{Name: name, PersonalOwnerID: &userID}
+	r.createLocked(org, userID)
+	return org, nil
+}
+
+func (r *OrganizationRepository) FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	m, ok := r.s.members[memberKey{orgID, userID}]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &m, nil
+}
+
+func (r *OrganizationRepository) AddMember(m *models.OrganizationMember) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.organizations[m.OrganizationID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if _, ok := r.s.users[m.UserID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	key := memberKey{m.OrganizationID, m.UserID}
+	if _, ok := r.s.members[key]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	m.CreatedAt = time.Now()
+	row := *m
+	row.User = models.User{}
+	r.s.members[key] = row
+	return nil
+}
+
+func (r *OrganizationRepository) UpdateMemberRole(orgID, userID uuid.UUID, role string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	key := memberKey{orgID, userID}
+	m, ok := r.s.members[key]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	m.Role = role
+	r.s.members[key] = m
+	return nil
+}
+
+func (r *OrganizationRepository) RemoveMember(orgID, userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.members, memberKey{orgID, userID})
+	return nil
+}
+
+func (r *OrganizationRepository) CountOwners(orgID uuid.UUID) (int64, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var n int64
+	for key, m := range r.s.members {
+		if key.org == orgID && m.Role == models.OrgRoleOwner {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (r *OrganizationRepository) CreateService(svc *models.Service) error {
+	return r.s.Services().CreateService(svc)
+}
+
+func (r *OrganizationRepository) FindServices(orgID uuid.UUID) ([]models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Service
+	for _, svc := range r.s.services {
+		if inOrganization(svc, orgID) {
+			out = append(out, svc)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *OrganizationRepository) FindService(orgID uuid.UUID, serviceID uint) (*models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok || !inOrganization(svc, orgID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &svc, nil
+}
+
+func (r *OrganizationRepository) AssignService(serviceID uint, masterID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	svc.MasterID = masterID
+	r.s.services[serviceID] = svc
+	return nil
+}
+
+func (r *OrganizationRepository) FindSlots(orgID uuid.UUID, from, to time.Time) ([]models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Slot
+	for _, sl := range r.s.slots {
+		if inOrganization(r.s.services[sl.ServiceID], orgID) && !sl.StartTime.Before(from) && sl.StartTime.Before(to) {
+			out = append(out, r.s.slotWithDetailsLocked(sl))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	return out, nil
+}
+
+func (r *OrganizationRepository) FindRecords(orgID uuid.UUID, status string, from, to time.Time) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	out := r.s.filterRecordsLocked(func(rec models.Record) bool {
+		sl := r.s.slots[rec.SlotID]
+		return inOrganization(r.s.services[sl.ServiceID], orgID) &&
+			(status == "" || rec.Status == status) &&
+			!sl.StartTime.Before(from) && sl.StartTime.Before(to)
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Slot.StartTime.Before(out[j].Slot.StartTime) })
+	return out, nil
+}
+
+func (r *OrganizationRepository) FindRecord(orgID uuid.UUID, recordID uint) (*models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok || !inOrganization(r.s.services[r.s.slots[rec.SlotID].ServiceID], orgID) {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &rec, nil
+}
+
+// withMembersLocked подгружает участников организации с пользователями, по времени вступления
+func (r *OrganizationRepository) withMembersLocked(org models.Organization) models.Organization {
+	org.Members = nil
+	for key, m := range r.s.members {
+		if key.org == org.ID {
+			m.User = r.s.users[m.UserID]
+			org.Members = append(org.Members, m)
+		}
+	}
+	sort.Slice(org.Members, func(i, j int) bool { return org.Members[i].CreatedAt.Before(org.Members[j].CreatedAt) })
+	return org
+}
+
+func inOrganization(svc models.Service, orgID uuid.UUID) bool {
+	return svc.OrganizationID != nil && *svc.OrganizationID == orgID
+}
@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PaymentRepository — платежи за записи и брони в ожидании оплаты, как repository/payment
+type PaymentRepository struct {
+	s *Store
+}
+
+func (r *PaymentRepository) Create(p *models.Payment) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if p.RecordID != nil {
+		if _, ok := r.s.records[*p.RecordID]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	for _, existing := range r.s.payments {
+		if existing.Provider == p.Provider && existing.ExternalID == p.ExternalID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if p.Status == "" {
+		p.Status = models.PaymentPending
+	}
+	r.s.lastPaymentID++
+	p.ID = r.s.lastPaymentID
+	p.CreatedAt = time.Now()
+	r.s.payments[p.ID] = *p
+	return nil
+}
+
+func (r *PaymentRepository) FindByExternalID(provider, externalID string) (models.Payment, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, p := range r.s.payments {
+		if p.Provider == provider && p.ExternalID == externalID {
+			return p, nil
+		}
+	}
+	return models.Payment{}, gorm.ErrRecordNotFound
+}
+
+func (r *PaymentRepository) UpdateStatus(id uint, status string, paidAt *time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	p, ok := r.s.payments[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	p.Status, p.PaidAt = status, paidAt
+	r.s.payments[id] = p
+	return nil
+}
+
+// ExpiredHolds возвращает заявки в ожидании оплаты с истёкшей бронью, раньше истёкшие первыми
+func (r *PaymentRepository) ExpiredHolds(now time.Time, limit int) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	out := r.s.filterRecordsLocked(func(rec models.Record) bool {
+		return rec.Status == models.RecordAwaitingPayment && rec.HoldUntil != nil && !rec.HoldUntil.After(now)
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].HoldUntil.Before(*out[j].HoldUntil) })
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+// ReleaseHold отменяет заявку, если она всё ещё ждёт оплаты
+func (r *PaymentRepository) ReleaseHold(recordID uint) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok || rec.Status != models.RecordAwaitingPayment {
+		return false, nil
+	}
+	rec.Status = "cancel"
+	r.s.records[recordID] = rec
+	return true, nil
+}
@@ -0,0 +1,351 @@
+package memory
+
+import (
+	"app/http/repository/record"
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecordRepository — заявки клиентов на слоты
+type RecordRepository struct {
+	s *Store
+}
+
+// Create проверяет слот и сохраняет заявку под одной блокировкой, как транзакция с FOR UPDATE в repository/record
+func (r *RecordRepository) Create(book *models.Record) (uint, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[book.SlotID]
+	if !ok {
+		return 0, gorm.ErrRecordNotFound
+	}
+	if sl.IsBooked {
+		return 0, record.ErrSlotBooked
+	}
+	now := time.Now()
+	for _, rec := range r.s.records {
+		if rec.SlotID != book.SlotID {
+			continue
+		}
+		if rec.ClientID == book.ClientID {
+			return 0, record.ErrRecordExists
+		}
+		if rec.Status == models.RecordAwaitingPayment && rec.HoldUntil != nil && rec.HoldUntil.After(now) {
+			return 0, record.ErrSlotHeld
+		}
+	}
+	if _, ok := r.s.users[book.ClientID]; !ok {
+		return 0, gorm.ErrForeignKeyViolated
+	}
+	if book.Status == "" {
+		book.Status = "pending"
+	}
+	if book.CreatedAt.IsZero() {
+		book.CreatedAt = time.Now()
+	}
+	r.s.lastRecordID++
+	book.ID = r.s.lastRecordID
+	for i := range book.AddOns {
+		r.s.lastRecordAddOnID++
+		book.AddOns[i].ID, book.AddOns[i].RecordID = r.s.lastRecordAddOnID, book.ID
+	}
+	row := *book
+	row.Slot, row.Client, row.AddOnIDs, row.Payment = models.Slot{}, models.User{}, nil, nil
+	row.AddOns = append([]models.RecordAddOn(nil), book.AddOns...)
+	r.s.records[book.ID] = row
+	return book.ID, nil
+}
+
+func (r *RecordRepository) ExistsRecord(slotID uint, clientID uuid.UUID) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, rec := range r.s.records {
+		if rec.SlotID == slotID && rec.ClientID == clientID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *RecordRepository) FindRecordsByClient(clientID uuid.UUID) ([]models.Record, error) {
+	return r.FindRecordsByClientWithStatus(clientID, "")
+}
+
+// FindRecordsByClientWithStatus возвращает заявки клиента (новые первыми), опционально по статусу
+func (r *RecordRepository) FindRecordsByClientWithStatus(clientID uuid.UUID, status string) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	out := r.s.filterRecordsLocked(func(rec models.Record) bool {
+		return rec.ClientID == clientID && (status == "" || rec.Status == status)
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].ID > out[j].ID })
+	return out, nil
+}
+
+func (r *RecordRepository) FindRecordsBySlot(slotID uint, status string) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.filterRecordsLocked(func(rec models.Record) bool {
+		return rec.SlotID == slotID && rec.Status == status
+	}), nil
+}
+
+func (r *RecordRepository) FindAllRecordsBySlot(slotID uint) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.filterRecordsLocked(func(rec models.Record) bool { return rec.SlotID == slotID }), nil
+}
+
+// FindDetailRecord как и LEFT JOIN + Scan в Postgres возвращает пустую структуру, если заявки нет
+func (r *RecordRepository) FindDetailRecord(recordID uint) (models.RecordResponce, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok {
+		return models.RecordResponce{}, nil
+	}
+	rec = r.s.recordWithDetailsLocked(rec)
+	return models.RecordResponce{
+		ID:               rec.ID,
+		Status:           rec.Status,
+		Comment:          rec.Comment,
+		CreatedAt:        rec.CreatedAt,
+		ClientID:         rec.ClientID,
+		ClientTelegramID: rec.Client.TelegramID,
+		ClientName:       rec.Client.FirstName,
+		ClientSurname:    rec.Client.Surname,
+		ClientPhone:      rec.Client.Phone,
+		SlotID:           rec.SlotID,
+		SlotName:         rec.Slot.Service.Name,
+		SlotPrice:        rec.Slot.Service.Price,
+		SlotDuration:     rec.Slot.Service.Duration,
+		MasterID:         rec.Slot.MasterID,
+		MasterTelegramID: rec.Slot.Master.TelegramID,
+		MasterName:       rec.Slot.Master.FirstName,
+		MasterSurname:    rec.Slot.Master.Surname,
+		MasterPhone:      rec.Slot.Master.Phone,
+	}, nil
+}
+
+// FindUpcomingRecordsByMasterTelegramID возвращает будущие подтверждённые заявки мастера по времени начала
+func (r *RecordRepository) FindUpcomingRecordsByMasterTelegramID(masterTelegramID int64) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	now := time.Now()
+	out := r.s.filterRecordsLocked(func(rec models.Record) bool {
+		sl := r.s.slots[rec.SlotID]
+		return rec.Status == "confirm" &&
+			r.s.users[sl.MasterID].TelegramID == masterTelegramID &&
+			sl.StartTime.After(now)
+	})
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Slot.StartTime.Before(out[j].Slot.StartTime) })
+	return out, nil
+}
+
+// FindConfirmedRecordsStartingBetween возвращает подтверждённые заявки, слот которых начинается в [from, to]
+func (r *RecordRepository) FindConfirmedRecordsStartingBetween(from, to time.Time) ([]models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return r.s.filterRecordsLocked(func(rec models.Record) bool {
+		start := r.s.slots[rec.SlotID].StartTime
+		return rec.Status == "confirm" && !start.Before(from) && !start.After(to)
+	}), nil
+}
+
+// ChangeRecordStatus меняет статус; при подтверждении отклоняет остальные заявки слота и помечает слот занятым
+func (r *RecordRepository) ChangeRecordStatus(recordID uint, status string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	rec.Status = status
+	r.s.records[recordID] = rec
+	if status == "confirm" {
+		r.confirmLocked(rec)
+	}
+	return nil
+}
+
+// ConfirmPaid подтверждает оплаченную заявку; false — заявка уже не ждёт оплаты или слот занят (тогда она отменяется)
+func (r *RecordRepository) ConfirmPaid(recordID uint) (bool, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok {
+		return false, gorm.ErrRecordNotFound
+	}
+	if rec.Status != models.RecordAwaitingPayment {
+		return rec.Status == "confirm", nil
+	}
+	if sl, ok := r.s.slots[rec.SlotID]; ok && sl.IsBooked {
+		rec.Status = "cancel"
+		r.s.records[recordID] = rec
+		return false, nil
+	}
+	rec.Status = "confirm"
+	r.s.records[recordID] = rec
+	r.confirmLocked(rec)
+	return true, nil
+}
+
+// UpdateRecordStatus дополнительно пересчитывает is_booked, если подтверждённая заявка перестала быть подтверждённой
+func (r *RecordRepository) UpdateRecordStatus(recordID uint, status string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	prevStatus := rec.Status
+	rec.Status = status
+	r.s.records[recordID] = rec
+	if status == "confirm" {
+		r.confirmLocked(rec)
+	} else if prevStatus == "confirm" {
+		r.recountBookedLocked(rec.SlotID)
+	}
+	return nil
+}
+
+// MoveRecord переносит заявку на другой слот и возвращает её в ожидание подтверждения.
+// check вызывается до мьютекса (проверка ресурсов сама читает хранилище), занятость, повторную
+// заявку и чужую бронь слот проверяет под мьютексом, как Create
+func (r *RecordRepository) MoveRecord(recordID, slotID uint, check func(rec models.Record, slot models.Slot) error) error {
+	r.s.mu.Lock()
+	rec, okRec := r.s.records[recordID]
+	sl, okSlot := r.s.slots[slotID]
+	r.s.mu.Unlock()
+	if !okSlot || !okRec {
+		return gorm.ErrRecordNotFound
+	}
+	if err := check(rec, sl); err != nil {
+		return err
+	}
+
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, sl = r.s.records[recordID], r.s.slots[slotID]
+	if sl.IsBooked {
+		return record.ErrSlotBooked
+	}
+	now := time.Now()
+	for id, other := range r.s.records {
+		if id == recordID || other.SlotID != slotID {
+			continue
+		}
+		if other.ClientID == rec.ClientID {
+			return record.ErrRecordExists
+		}
+		if other.Status == models.RecordAwaitingPayment && other.HoldUntil != nil && other.HoldUntil.After(now) {
+			return record.ErrSlotHeld
+		}
+	}
+	prev := rec
+	rec.SlotID, rec.Status = slotID, "pending"
+	r.s.records[recordID] = rec
+	if prev.Status == "confirm" {
+		r.recountBookedLocked(prev.SlotID)
+	}
+	return nil
+}
+
+// UpdateRecordComment сохраняет комментарий клиента к заявке
+func (r *RecordRepository) UpdateRecordComment(recordID uint, comment string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[recordID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	rec.Comment = comment
+	r.s.records[recordID] = rec
+	return nil
+}
+
+func (r *RecordRepository) DeleteRecord(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	r.s.deleteRecordLocked(id)
+	if rec.Status == "confirm" {
+		r.recountBookedLocked(rec.SlotID)
+	}
+	return nil
+}
+
+func (r *RecordRepository) confirmLocked(confirmed models.Record) {
+	for id, rec := range r.s.records {
+		if rec.SlotID == confirmed.SlotID && id != confirmed.ID && rec.Status != "reject" {
+			rec.Status = "reject"
+			r.s.records[id] = rec
+		}
+	}
+	if sl, ok := r.s.slots[confirmed.SlotID]; ok {
+		sl.IsBooked = true
+		r.s.slots[sl.ID] = sl
+	}
+}
+
+func (r *RecordRepository) recountBookedLocked(slotID uint) {
+	sl, ok := r.s.slots[slotID]
+	if !ok {
+		return
+	}
+	sl.IsBooked = false
+	for _, rec := range r.s.records {
+		if rec.SlotID == slotID && rec.Status == "confirm" {
+			sl.IsBooked = true
+			break
+		}
+	}
+	r.s.slots[slotID] = sl
+}
+
+func (r *RecordRepository) GetSlotByID(id uint) (models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[id]
+	if !ok {
+		return models.Slot{}, gorm.ErrRecordNotFound
+	}
+	return sl, nil
+}
+
+func (r *RecordRepository) GetSlotByIDWithDetails(id uint) (models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[id]
+	if !ok {
+		return models.Slot{}, gorm.ErrRecordNotFound
+	}
+	return r.s.slotWithDetailsLocked(sl), nil
+}
+
+func (r *RecordRepository) GetRecordByIDWithDetails(id uint) (models.Record, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rec, ok := r.s.records[id]
+	if !ok {
+		return models.Record{}, gorm.ErrRecordNotFound
+	}
+	return r.s.recordWithDetailsLocked(rec), nil
+}
+
+func (r *RecordRepository) GetUserByID(id uuid.UUID) (models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		return models.User{}, gorm.ErrRecordNotFound
+	}
+	return u, nil
+}
@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ResourceRepository — ресурсы организаций и их привязка к услугам
+type ResourceRepository struct {
+	s *Store
+}
+
+func (r *ResourceRepository) Create(res *models.Resource) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.organizations[res.OrganizationID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastResourceID++
+	res.ID = r.s.lastResourceID
+	res.CreatedAt = time.Now()
+	r.s.resources[res.ID] = *res
+	return nil
+}
+
+func (r *ResourceRepository) FindByID(orgID uuid.UUID, id uint) (*models.Resource, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	res, ok := r.s.resources[id]
+	if !ok || res.OrganizationID != orgID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &res, nil
+}
+
+func (r *ResourceRepository) FindByOrganization(orgID uuid.UUID) ([]models.Resource, error) {
+	return r.filter(func(res models.Resource) bool { return res.OrganizationID == orgID }), nil
+}
+
+func (r *ResourceRepository) FindAll() ([]models.Resource, error) {
+	return r.filter(func(models.Resource) bool { return true }), nil
+}
+
+func (r *ResourceRepository) Update(res *models.Resource) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	row, ok := r.s.resources[res.ID]
+	if !ok {
+		return nil
+	}
+	row.Name, row.Quantity, row.Active = res.Name, res.Quantity, res.Active
+	r.s.resources[res.ID] = row
+	return nil
+}
+
+func (r *ResourceRepository) Delete(orgID uuid.UUID, id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if res, ok := r.s.resources[id]; ok && res.OrganizationID == orgID {
+		r.s.deleteResourceLocked(id)
+	}
+	return nil
+}
+
+func (r *ResourceRepository) SetServiceResources(serviceID uint, resourceIDs []uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.services[serviceID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, id := range resourceIDs {
+		if _, ok := r.s.resources[id]; !ok {
+			return gorm.ErrForeignKeyViolated
+		}
+	}
+	r.s.serviceResources[serviceID] = append([]uint(nil), resourceIDs...)
+	return nil
+}
+
+func (r *ResourceRepository) FindServiceResources(serviceID uint) ([]models.Resource, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Resource
+	for _, id := range r.s.serviceResources[serviceID] {
+		out = append(out, r.s.resources[id])
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *ResourceRepository) FindBookedSlots(resourceID uint, from, to time.Time, exceptSlotID uint) ([]models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Slot
+	for _, sl := range r.s.slots {
+		if !sl.IsBooked || sl.ID == exceptSlotID || !sl.StartTime.Before(to) || !sl.EndTime.After(from) {
+			continue
+		}
+		for _, id := range r.s.serviceResources[sl.ServiceID] {
+			if id == resourceID {
+				out = append(out, sl)
+				break
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	return out, nil
+}
+
+func (r *ResourceRepository) filter(match func(models.Resource) bool) []models.Resource {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Resource
+	for _, res := range r.s.resources {
+		if match(res) {
+			out = append(out, res)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
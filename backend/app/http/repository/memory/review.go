@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"contract"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReviewRepository — отзывы; как repository/review, пересчитывает рейтинг мастера и услуги
+type ReviewRepository struct {
+	s *Store
+}
+
+func (r *ReviewRepository) Create(rv *models.Review) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if rv.RecordID != nil {
+		for _, existing := range r.s.reviews {
+			if existing.RecordID != nil && *existing.RecordID == *rv.RecordID {
+				return gorm.ErrDuplicatedKey
+			}
+		}
+	}
+	if _, ok := r.s.users[rv.MasterID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastReviewID++
+	rv.ID = r.s.lastReviewID
+	rv.CreatedAt = time.Now()
+	rv.UpdatedAt = rv.CreatedAt
+	stored := *rv
+	stored.Client, stored.Service = nil, nil
+	r.s.reviews[rv.ID] = stored
+	r.s.refreshRatingsLocked(rv.MasterID, rv.ServiceID)
+	return nil
+}
+
+func (r *ReviewRepository) FindByID(id uint) (*models.Review, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	rv, ok := r.s.reviews[id]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	rv = r.s.reviewWithDetailsLocked(rv)
+	return &rv, nil
+}
+
+func (r *ReviewRepository) FindByRecord(recordID uint) (*models.Review, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, rv := range r.s.reviews {
+		if rv.RecordID != nil && *rv.RecordID == recordID {
+			rv = r.s.reviewWithDetailsLocked(rv)
+			return &rv, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *ReviewRepository) Find(f models.ReviewFilter) ([]models.Review, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	list := r.s.filterReviewsLocked(f)
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].CreatedAt.Equal(list[j].CreatedAt) {
+			return list[i].CreatedAt.After(list[j].CreatedAt)
+		}
+		return list[i].ID > list[j].ID
+	})
+	var out []models.Review
+	for i := f.Offset; i < len(list) && (f.Limit <= 0 || len(out) < f.Limit); i++ {
+		out = append(out, r.s.reviewWithDetailsLocked(list[i]))
+	}
+	return out, nil
+}
+
+func (r *ReviewRepository) Summary(f models.ReviewFilter) (contract.RatingSummary, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	return summary(r.s.filterReviewsLocked(f)), nil
+}
+
+func (r *ReviewRepository) Update(rv *models.Review) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	stored, ok := r.s.reviews[rv.ID]
+	if !ok {
+		return nil
+	}
+	stored.Rating, stored.Text = rv.Rating, rv.Text
+	stored.Reply, stored.RepliedAt = rv.Reply, rv.RepliedAt
+	stored.Hidden, stored.Flagged = rv.Hidden, rv.Flagged
+	stored.UpdatedAt = time.Now()
+	r.s.reviews[rv.ID] = stored
+	r.s.refreshRatingsLocked(stored.MasterID, stored.ServiceID)
+	return nil
+}
+
+func (s *Store) filterReviewsLocked(f models.ReviewFilter) []models.Review {
+	var out []models.Review
+	for _, rv := range s.reviews {
+		if (f.MasterID != nil && rv.MasterID != *f.MasterID) ||
+			(f.ServiceID != nil && (rv.ServiceID == nil || *rv.ServiceID != *f.ServiceID)) ||
+			(f.Hidden != nil && rv.Hidden != *f.Hidden) ||
+			(f.Flagged != nil && rv.Flagged != *f.Flagged) {
+			continue
+		}
+		out = append(out, rv)
+	}
+	return out
+}
+
+// reviewWithDetailsLocked возвращает отзыв с подгруженными Client и Service
+func (s *Store) reviewWithDetailsLocked(rv models.Review) models.Review {
+	rv.Client, rv.Service = nil, nil
+	if rv.ClientID != nil {
+		if u := s.userAnyLocked(*rv.ClientID); u.ID != uuid.Nil {
+			rv.Client = &u
+		}
+	}
+	if rv.ServiceID != nil {
+		if svc, ok := s.serviceAnyLocked(*rv.ServiceID); ok {
+			rv.Service = &svc
+		}
+	}
+	return rv
+}
+
+// refreshRatingsLocked пересчитывает users.rating и services.rating по видимым отзывам
+func (s *Store) refreshRatingsLocked(masterID uuid.UUID, serviceID *uint) {
+	visible := false
+	if u, ok := s.users[masterID]; ok {
+		sum := summary(s.filterReviewsLocked(models.ReviewFilter{MasterID: &masterID, Hidden: &visible}))
+		u.Rating, u.RatingCount = sum.Rating, sum.Count
+		s.users[masterID] = u
+	}
+	if serviceID == nil {
+		return
+	}
+	if svc, ok := s.services[*serviceID]; ok {
+		sum := summary(s.filterReviewsLocked(models.ReviewFilter{ServiceID: serviceID, Hidden: &visible}))
+		svc.Rating, svc.RatingCount = sum.Rating, sum.Count
+		s.services[*serviceID] = svc
+	}
+}
+
+// summary — среднее с округлением до сотых, как round(AVG(rating), 2) в Postgres
+func summary(list []models.Review) contract.RatingSummary {
+	if len(list) == 0 {
+		return contract.RatingSummary{}
+	}
+	total := 0
+	for _, rv := range list {
+		total += rv.Rating
+	}
+	avg := float64(total) / float64(len(list))
+	return contract.RatingSummary{Rating: math.Round(avg*100) / 100, Count: len(list)}
+}
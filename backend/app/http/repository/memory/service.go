@@ -0,0 +1,111 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"contract/money"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ServiceRepository — услуги мастеров
+type ServiceRepository struct {
+	s *Store
+}
+
+func (r *ServiceRepository) CreateService(service *models.Service) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[service.MasterID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if service.Currency == "" {
+		service.Currency = money.Default
+	}
+	r.s.lastServiceID++
+	service.ID = r.s.lastServiceID
+	r.s.services[service.ID] = serviceRow(*service)
+	return nil
+}
+
+func (r *ServiceRepository) GetServices(userID uuid.UUID) ([]models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []models.Service
+	for _, svc := range r.s.services {
+		if svc.MasterID == userID {
+			out = append(out, r.s.serviceWithOptionsLocked(svc))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (r *ServiceRepository) GetService(id uint) (models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[id]
+	if !ok {
+		return models.Service{}, gorm.ErrRecordNotFound
+	}
+	return r.s.serviceWithOptionsLocked(svc), nil
+}
+
+func (r *ServiceRepository) GetDetailService(serviceID uint) (models.ServiceResponse, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok {
+		return models.ServiceResponse{}, gorm.ErrRecordNotFound
+	}
+	master, ok := r.s.users[svc.MasterID]
+	if !ok {
+		return models.ServiceResponse{}, gorm.ErrRecordNotFound
+	}
+	return models.ServiceResponse{
+		ID:               svc.ID,
+		MasterID:         svc.MasterID,
+		Name:             svc.Name,
+		Price:            svc.Price,
+		Currency:         svc.Currency,
+		Description:      svc.Description,
+		Duration:         svc.Duration,
+		MasterTelegramID: master.TelegramID,
+		MasterName:       master.FirstName,
+		MasterSurname:    master.Surname,
+		MasterPhone:      master.Phone,
+	}, nil
+}
+
+func (r *ServiceRepository) GetServiceByIDAndOwner(serviceID uint, ownerID uuid.UUID) (*models.Service, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	svc, ok := r.s.services[serviceID]
+	if !ok || svc.MasterID != ownerID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &svc, nil
+}
+
+// UpdateService ведёт себя как gorm Save: без ID создаёт запись, иначе перезаписывает её
+func (r *ServiceRepository) UpdateService(service *models.Service) error {
+	if service.ID == 0 {
+		return r.CreateService(service)
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[service.MasterID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.services[service.ID] = serviceRow(*service)
+	return nil
+}
+
+func (r *ServiceRepository) DeleteService(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.archiveServiceLocked(time.Now(), id)
+	return nil
+}
@@ -0,0 +1,104 @@
+package memory
+
+import (
+	"app/http/repository/slot"
+	"app/pkg/models"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SlotRepository — слоты мастеров
+type SlotRepository struct {
+	s *Store
+}
+
+func (r *SlotRepository) Create(sl *models.Slot) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[sl.MasterID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	if _, ok := r.s.services[sl.ServiceID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	r.s.lastSlotID++
+	sl.ID = r.s.lastSlotID
+	row := *sl
+	row.Service, row.Master = models.Service{}, models.User{}
+	r.s.slots[sl.ID] = row
+	return nil
+}
+
+func (r *SlotRepository) FindSlots(userID uuid.UUID) ([]slot.SlotWithMaster, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	var out []slot.SlotWithMaster
+	for _, sl := range r.s.slots {
+		if sl.MasterID != userID {
+			continue
+		}
+		master := r.s.users[sl.MasterID]
+		out = append(out, slot.SlotWithMaster{
+			Slot:             sl,
+			ServiceName:      r.s.services[sl.ServiceID].Name,
+			MasterTelegramID: master.TelegramID,
+			MasterName:       master.FirstName,
+			MasterPhone:      master.Phone,
+			MasterSurname:    master.Surname,
+			MasterTimezone:   master.Timezone,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	return out, nil
+}
+
+func (r *SlotRepository) FindSlot(slotID uint) (*slot.SlotWithMasterAndService, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[slotID]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	svc := r.s.services[sl.ServiceID]
+	master := r.s.users[sl.MasterID]
+	return &slot.SlotWithMasterAndService{
+		Slot:               sl,
+		ServiceName:        svc.Name,
+		ServiceDescription: svc.Description,
+		ServicePrice:       svc.Price,
+		ServiceCurrency:    svc.Currency,
+		ServiceDuration:    svc.Duration,
+		MasterTelegramID:   master.TelegramID,
+		MasterName:         master.FirstName,
+		MasterPhone:        master.Phone,
+		MasterSurname:      master.Surname,
+		MasterTimezone:     master.Timezone,
+	}, nil
+}
+
+func (r *SlotRepository) GetSlotByIDAndOwner(slotID uint, ownerID uuid.UUID) (*models.Slot, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	sl, ok := r.s.slots[slotID]
+	if !ok || sl.MasterID != ownerID {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &sl, nil
+}
+
+func (r *SlotRepository) DeleteSlots(userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.archiveSlotsLocked(time.Now(), func(sl models.Slot) bool { return sl.MasterID == userID })
+	return nil
+}
+
+func (r *SlotRepository) DeleteSlot(id uint) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.archiveSlotsLocked(time.Now(), func(sl models.Slot) bool { return sl.ID == id })
+	return nil
+}
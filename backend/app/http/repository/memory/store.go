@@ -0,0 +1,276 @@
+// Package memory — хранилище в памяти с теми же инвариантами, что и репозитории на Postgres
+// (уникальные ключи, каскадное удаление, статусы заявок). Используется в тестах usecase-слоя.
+package memory
+
+import (
+	"app/http/repository/user"
+	"app/pkg/models"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Store хранит все таблицы; репозитории — представления над одним Store,
+// поэтому изменения, сделанные через один репозиторий, видны в остальных.
+type Store struct {
+	mu sync.Mutex
+
+	users         map[uuid.UUID]models.User
+	roles         map[uuid.UUID][]string
+	services      map[uint]models.Service
+	slots         map[uint]models.Slot
+	records       map[uint]models.Record
+	notifications map[uint]models.Notification
+	deliveries    map[uuid.UUID]models.TelegramDelivery
+	organizations map[uuid.UUID]models.Organization
+	members       map[memberKey]models.OrganizationMember
+	resources     map[uint]models.Resource
+	// serviceResources — service_resources: услуга → ресурсы
+	serviceResources map[uint][]uint
+	locations        map[uint]models.Location
+	reviews          map[uint]models.Review
+	categories       map[uint]models.ServiceCategory
+	variants         map[uint]models.ServiceVariant
+	addOns           map[uint]models.ServiceAddOn
+	payments         map[uint]models.Payment
+	// archived* — строки с deleted_at: обычные выборки их не видят (см. archive.go)
+	archivedUsers    map[uuid.UUID]models.User
+	archivedServices map[uint]models.Service
+	archivedSlots    map[uint]models.Slot
+
+	tokens  map[int64]tempToken
+	pending map[pendingKey]user.PendingRegistration
+
+	lastServiceID      uint
+	lastSlotID         uint
+	lastRecordID       uint
+	lastNotificationID uint
+	lastResourceID     uint
+	lastLocationID     uint
+	lastReviewID       uint
+	lastCategoryID     uint
+	lastVariantID      uint
+	lastAddOnID        uint
+	lastRecordAddOnID  uint
+	lastPaymentID      uint
+}
+
+type tempToken struct {
+	value     string
+	createdAt time.Time
+}
+
+// pendingKey — регистрация ждёт кода по паре номер + Telegram, как в repository/user
+type pendingKey struct {
+	phone      string
+	telegramID int64
+}
+
+// Время жизни временных токенов и кодов — как в repository/user
+const (
+	tokenTTL     = time.Hour
+	phoneCodeTTL = 10 * time.Minute
+)
+
+func NewStore() *Store {
+	return &Store{
+		users:            make(map[uuid.UUID]models.User),
+		roles:            make(map[uuid.UUID][]string),
+		services:         make(map[uint]models.Service),
+		slots:            make(map[uint]models.Slot),
+		records:          make(map[uint]models.Record),
+		notifications:    make(map[uint]models.Notification),
+		deliveries:       make(map[uuid.UUID]models.TelegramDelivery),
+		organizations:    make(map[uuid.UUID]models.Organization),
+		members:          make(map[memberKey]models.OrganizationMember),
+		resources:        make(map[uint]models.Resource),
+		serviceResources: make(map[uint][]uint),
+		locations:        make(map[uint]models.Location),
+		reviews:          make(map[uint]models.Review),
+		categories:       make(map[uint]models.ServiceCategory),
+		variants:         make(map[uint]models.ServiceVariant),
+		addOns:           make(map[uint]models.ServiceAddOn),
+		payments:         make(map[uint]models.Payment),
+		archivedUsers:    make(map[uuid.UUID]models.User),
+		archivedServices: make(map[uint]models.Service),
+		archivedSlots:    make(map[uint]models.Slot),
+		tokens:           make(map[int64]tempToken),
+		pending:          make(map[pendingKey]user.PendingRegistration),
+	}
+}
+
+func (s *Store) Users() *UserRepository                 { return &UserRepository{s: s} }
+func (s *Store) Services() *ServiceRepository           { return &ServiceRepository{s: s} }
+func (s *Store) Slots() *SlotRepository                 { return &SlotRepository{s: s} }
+func (s *Store) Records() *RecordRepository             { return &RecordRepository{s: s} }
+func (s *Store) Notifications() *NotificationRepository { return &NotificationRepository{s: s} }
+func (s *Store) Deliveries() *DeliveryRepository        { return &DeliveryRepository{s: s} }
+func (s *Store) Directory() *DirectoryRepository        { return &DirectoryRepository{s: s} }
+func (s *Store) Organizations() *OrganizationRepository { return &OrganizationRepository{s: s} }
+func (s *Store) Resources() *ResourceRepository         { return &ResourceRepository{s: s} }
+func (s *Store) Locations() *LocationRepository         { return &LocationRepository{s: s} }
+func (s *Store) Reviews() *ReviewRepository             { return &ReviewRepository{s: s} }
+func (s *Store) Payments() *PaymentRepository           { return &PaymentRepository{s: s} }
+
+func (s *Store) deleteOrganizationLocked(id uuid.UUID) {
+	delete(s.organizations, id)
+	for key := range s.members {
+		if key.org == id {
+			delete(s.members, key)
+		}
+	}
+	// ON DELETE CASCADE не различает действующие и архивные услуги
+	for sid, svc := range s.services {
+		if svc.OrganizationID != nil && *svc.OrganizationID == id {
+			s.deleteServiceLocked(sid)
+		}
+	}
+	for sid, svc := range s.archivedServices {
+		if svc.OrganizationID != nil && *svc.OrganizationID == id {
+			s.deleteServiceLocked(sid)
+		}
+	}
+	for rid, res := range s.resources {
+		if res.OrganizationID == id {
+			s.deleteResourceLocked(rid)
+		}
+	}
+	for lid, l := range s.locations {
+		if l.OrganizationID == id {
+			s.deleteLocationLocked(lid)
+		}
+	}
+}
+
+// deleteLocationLocked удаляет место; услуги и слоты остаются без места (ON DELETE SET NULL)
+func (s *Store) deleteLocationLocked(id uint) {
+	delete(s.locations, id)
+	for sid, svc := range s.services {
+		if svc.LocationID != nil && *svc.LocationID == id {
+			svc.LocationID = nil
+			s.services[sid] = svc
+		}
+	}
+	for sid, sl := range s.slots {
+		if sl.LocationID != nil && *sl.LocationID == id {
+			sl.LocationID = nil
+			s.slots[sid] = sl
+		}
+	}
+}
+
+func (s *Store) deleteResourceLocked(id uint) {
+	delete(s.resources, id)
+	for sid, ids := range s.serviceResources {
+		kept := ids[:0]
+		for _, rid := range ids {
+			if rid != id {
+				kept = append(kept, rid)
+			}
+		}
+		s.serviceResources[sid] = kept
+	}
+}
+
+func (s *Store) deleteServiceLocked(id uint) {
+	delete(s.services, id)
+	delete(s.archivedServices, id)
+	delete(s.serviceResources, id)
+	for vid, v := range s.variants {
+		if v.ServiceID == id {
+			s.deleteVariantLocked(vid)
+		}
+	}
+	for aid, a := range s.addOns {
+		if a.ServiceID == id {
+			s.deleteAddOnLocked(aid)
+		}
+	}
+	for sid, sl := range s.slots {
+		if sl.ServiceID == id {
+			s.deleteSlotLocked(sid)
+		}
+	}
+	for sid, sl := range s.archivedSlots {
+		if sl.ServiceID == id {
+			s.deleteSlotLocked(sid)
+		}
+	}
+	for rid, rv := range s.reviews {
+		if rv.ServiceID != nil && *rv.ServiceID == id {
+			rv.ServiceID = nil
+			s.reviews[rid] = rv
+		}
+	}
+}
+
+func (s *Store) deleteSlotLocked(id uint) {
+	delete(s.slots, id)
+	delete(s.archivedSlots, id)
+	for rid, rec := range s.records {
+		if rec.SlotID == id {
+			s.deleteRecordLocked(rid)
+		}
+	}
+}
+
+// deleteRecordLocked удаляет заявку; отзыв и платёж по ней остаются без записи (ON DELETE SET NULL)
+func (s *Store) deleteRecordLocked(id uint) {
+	delete(s.records, id)
+	for rid, rv := range s.reviews {
+		if rv.RecordID != nil && *rv.RecordID == id {
+			rv.RecordID = nil
+			s.reviews[rid] = rv
+		}
+	}
+	for pid, p := range s.payments {
+		if p.RecordID != nil && *p.RecordID == id {
+			p.RecordID = nil
+			s.payments[pid] = p
+		}
+	}
+}
+
+// slotWithDetailsLocked возвращает слот с подгруженными Service (с вариантами и опциями), Master и Location;
+// услуга и мастер находятся и в архиве
+func (s *Store) slotWithDetailsLocked(sl models.Slot) models.Slot {
+	svc, _ := s.serviceAnyLocked(sl.ServiceID)
+	sl.Service = s.serviceWithOptionsLocked(svc)
+	sl.Master = s.userAnyLocked(sl.MasterID)
+	sl.Location = nil
+	if sl.LocationID != nil {
+		if l, ok := s.locations[*sl.LocationID]; ok {
+			sl.Location = &l
+		}
+	}
+	return sl
+}
+
+// recordWithDetailsLocked возвращает заявку с подгруженными Slot.Service, Slot.Master, Client
+// (в том числе архивными) и платежом
+func (s *Store) recordWithDetailsLocked(rec models.Record) models.Record {
+	rec.Slot = s.slotWithDetailsLocked(s.slotAnyLocked(rec.SlotID))
+	rec.Client = s.userAnyLocked(rec.ClientID)
+	rec.Payment = nil
+	for _, p := range s.payments {
+		if p.RecordID != nil && *p.RecordID == rec.ID {
+			rec.Payment = &p
+			break
+		}
+	}
+	return rec
+}
+
+// filterRecordsLocked возвращает заявки с подробностями, удовлетворяющие условию, по возрастанию id
+func (s *Store) filterRecordsLocked(match func(models.Record) bool) []models.Record {
+	var out []models.Record
+	for _, rec := range s.records {
+		if match(rec) {
+			out = append(out, s.recordWithDetailsLocked(rec))
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
@@ -0,0 +1,69 @@
+package memory
+
+import (
+	"app/pkg/models"
+	"contract"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// TelegramMessage — сообщение, которое ушло бы в telegram-bot через sender
+type TelegramMessage struct {
+	Kind       string // login, record, record_status, venue, phone_code, account_deletion
+	TelegramID int64
+	RecordID   uint
+	Title      string
+	Message    string
+	Code       string
+	Venue      contract.Venue
+}
+
+// Telegram записывает отправленные сообщения вместо HTTP-вызова нотификатора.
+// Реализует интерфейсы Sender usecase-пакетов. Err, если задана, возвращается из всех методов.
+type Telegram struct {
+	mu       sync.Mutex
+	messages []TelegramMessage
+	Err      error
+}
+
+func (t *Telegram) add(m TelegramMessage) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Err != nil {
+		return t.Err
+	}
+	t.messages = append(t.messages, m)
+	return nil
+}
+
+// Messages возвращает копию отправленных сообщений
+func (t *Telegram) Messages() []TelegramMessage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]TelegramMessage(nil), t.messages...)
+}
+
+func (t *Telegram) LoginNotify(user models.User, ip string, location string) error {
+	return t.add(TelegramMessage{Kind: "login", TelegramID: user.TelegramID, Message: ip + " " + location})
+}
+
+func (t *Telegram) RecordNotify(recordID uint, telegramID int64, title, message string) error {
+	return t.add(TelegramMessage{Kind: "record", RecordID: recordID, TelegramID: telegramID, Title: title, Message: message})
+}
+
+func (t *Telegram) RecordStatusNotify(telegramID int64, title, message string) error {
+	return t.add(TelegramMessage{Kind: "record_status", TelegramID: telegramID, Title: title, Message: message})
+}
+
+func (t *Telegram) VenueNotify(telegramID int64, venue contract.Venue) error {
+	return t.add(TelegramMessage{Kind: "venue", TelegramID: telegramID, Title: venue.Title, Message: venue.Address, Venue: venue})
+}
+
+func (t *Telegram) PhoneCodeNotify(telegramID int64, phone string, code string) error {
+	return t.add(TelegramMessage{Kind: "phone_code", TelegramID: telegramID, Message: phone, Code: code})
+}
+
+func (t *Telegram) RequestAccountDeletionConfirmation(userID uuid.UUID, telegramID int64) error {
+	return t.add(TelegramMessage{Kind: "account_deletion", TelegramID: telegramID, Message: userID.String()})
+}
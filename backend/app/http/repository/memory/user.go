@@ -0,0 +1,253 @@
+package memory
+
+import (
+	"app/http/repository/user"
+	"app/pkg/models"
+	"contract/money"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserRepository — пользователи, роли и временные токены/коды входа
+type UserRepository struct {
+	s *Store
+}
+
+func (r *UserRepository) Create(u *models.User) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, existing := range r.s.users {
+		if existing.Phone == u.Phone || existing.TelegramID == u.TelegramID {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	} else if _, ok := r.s.users[u.ID]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	if u.Timezone == "" {
+		u.Timezone = "Europe/Moscow"
+	}
+	if u.Currency == "" {
+		u.Currency = money.Default
+	}
+	now := time.Now()
+	u.ConsentGivenAt = now
+	u.PrivacyPolicyAcceptedAt = now
+	u.TermsAcceptedAt = now
+
+	row := *u
+	row.Roles, row.Services = nil, nil
+	r.s.users[u.ID] = row
+	r.s.roles[u.ID] = []string{"client"}
+	return nil
+}
+
+// SetRole добавляет пользователю роль, если её ещё нет
+func (r *UserRepository) SetRole(userID uuid.UUID, role string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if _, ok := r.s.users[userID]; !ok {
+		return gorm.ErrForeignKeyViolated
+	}
+	for _, existing := range r.s.roles[userID] {
+		if existing == role {
+			return nil
+		}
+	}
+	r.s.roles[userID] = append(r.s.roles[userID], role)
+	return nil
+}
+
+func (r *UserRepository) FindByID(id uuid.UUID) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	u, ok := r.s.users[id]
+	if !ok {
+		return nil, nil
+	}
+	return &u, nil
+}
+
+func (r *UserRepository) FindByPhone(phone string) (*models.User, error) {
+	return r.find(func(u models.User) bool { return u.Phone == phone }, false)
+}
+
+func (r *UserRepository) FindByTelegramID(telegramID int64) (*models.User, error) {
+	return r.find(func(u models.User) bool { return u.TelegramID == telegramID }, true)
+}
+
+// find ищет пользователя с ролями (и услугами, если withServices); не найден — nil, nil
+func (r *UserRepository) find(match func(models.User) bool, withServices bool) (*models.User, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for _, u := range r.s.users {
+		if !match(u) {
+			continue
+		}
+		for _, role := range r.s.roles[u.ID] {
+			u.Roles = append(u.Roles, models.UserRole{UserID: u.ID, Role: role})
+		}
+		if withServices {
+			for _, svc := range r.s.services {
+				if svc.MasterID == u.ID {
+					u.Services = append(u.Services, svc)
+				}
+			}
+		}
+		return &u, nil
+	}
+	return nil, nil
+}
+
+func (r *UserRepository) UpdateNames(userID uuid.UUID, firstName string, surname string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.FirstName, u.Surname = firstName, surname
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateTimezone(userID uuid.UUID, timezone string) error {
+	if timezone == "" {
+		return nil
+	}
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.Timezone = timezone
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateLanguage(userID uuid.UUID, language string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.Language = language
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateDigestTime(userID uuid.UUID, digestTime string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.DigestTime = digestTime
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateDirectoryOptOut(userID uuid.UUID, optOut bool) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.DirectoryOptOut = optOut
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) UpdateCurrency(userID uuid.UUID, currency string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.Currency = currency
+		r.s.users[userID] = u
+	}
+	return nil
+}
+
+func (r *UserRepository) DeleteUser(userID uuid.UUID) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.archiveUserLocked(time.Now(), userID)
+	return nil
+}
+
+func (r *UserRepository) StorageToken(telegramID int64, token string) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	r.s.tokens[telegramID] = tempToken{value: token, createdAt: time.Now()}
+	return nil
+}
+
+func (r *UserRepository) ClaimUserToken(telegramID int64) (string, error) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	t, ok := r.s.tokens[telegramID]
+	delete(r.s.tokens, telegramID)
+	if !ok || time.Since(t.createdAt) > tokenTTL {
+		return "", nil
+	}
+	return t.value, nil
+}
+
+func (r *UserRepository) CheckUserToken(telegramID int64) bool {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	t, ok := r.s.tokens[telegramID]
+	if ok && time.Since(t.createdAt) > tokenTTL {
+		delete(r.s.tokens, telegramID)
+		return false
+	}
+	return ok
+}
+
+func (r *UserRepository) DeleteToken(telegramID int64) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.tokens, telegramID)
+	return nil
+}
+
+func (r *UserRepository) StoragePendingRegistration(phone string, telegramID int64, pending user.PendingRegistration) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	for k, p := range r.s.pending {
+		if time.Since(p.CreatedAt) > phoneCodeTTL {
+			delete(r.s.pending, k)
+		}
+	}
+	r.s.pending[pendingKey{phone, telegramID}] = pending
+}
+
+func (r *UserRepository) LoadPendingRegistration(phone string, telegramID int64) (*user.PendingRegistration, bool) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	key := pendingKey{phone, telegramID}
+	p, ok := r.s.pending[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Since(p.CreatedAt) > phoneCodeTTL {
+		delete(r.s.pending, key)
+		return nil, false
+	}
+	return &p, true
+}
+
+func (r *UserRepository) DeletePendingRegistration(phone string, telegramID int64) {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	delete(r.s.pending, pendingKey{phone, telegramID})
+}
+
+func (r *UserRepository) VerifyPhone(userID uuid.UUID, telegramID int64, at time.Time) error {
+	r.s.mu.Lock()
+	defer r.s.mu.Unlock()
+	if u, ok := r.s.users[userID]; ok {
+		u.TelegramID = telegramID
+		u.PhoneVerifiedAt = &at
+		r.s.users[userID] = u
+	}
+	return nil
+}
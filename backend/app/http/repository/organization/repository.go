@@ -0,0 +1,227 @@
+package organization
+
+import (
+	"app/pkg/models"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// organizationServices — условие «слот по услуге организации» для выборок слотов и записей
+const organizationServices = "slots.service_id IN (SELECT id FROM services WHERE organization_id = ?)"
+
+// Create сохраняет организацию и её владельца в одной транзакции
+func (r *Repository) Create(org *models.Organization, ownerID uuid.UUID) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Members").Create(org).Error; err != nil {
+			return err
+		}
+		return tx.Create(&models.OrganizationMember{OrganizationID: org.ID, UserID: ownerID, Role: models.OrgRoleOwner}).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.Create (organization): %v", err)
+	}
+	return err
+}
+
+// FindByID возвращает организацию с участниками
+func (r *Repository) FindByID(id uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	err := r.withMembers(r.db).Where("id = ?", id).First(&org).Error
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// FindByMember возвращает организации, в которых состоит пользователь
+func (r *Repository) FindByMember(userID uuid.UUID) ([]models.Organization, error) {
+	var orgs []models.Organization
+	err := r.withMembers(r.db).
+		Where("id IN (SELECT organization_id FROM organization_members WHERE user_id = ?)", userID).
+		Order("created_at ASC").
+		Find(&orgs).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindByMember (organization): query failed: %v", err)
+		return nil, err
+	}
+	return orgs, nil
+}
+
+func (r *Repository) Rename(id uuid.UUID, name string) error {
+	return r.db.Model(&models.Organization{}).Where("id = ?", id).Update("name", name).Error
+}
+
+// Personal возвращает личную организацию пользователя, создавая её вместе с членством владельца
+func (r *Repository) Personal(userID uuid.UUID) (*models.Organization, error) {
+	var org models.Organization
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("personal_owner_id = ?", userID).First(&org).Error
+		if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		var user models.User
+		if err := tx.Where("id = ?", userID).First(&user).Error; err != nil {
+			return err
+		}
+		org = models.Organization{Name: personalName(user), PersonalOwnerID: &userID}
+		created := tx.Omit("Members").
+			Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "personal_owner_id"}}, DoNothing: true}).
+			Create(&org)
+		if created.Error != nil {
+			return created.Error
+		}
+		if created.RowsAffected == 0 {
+			// Параллельный запрос создал её раньше
+			return tx.Where("personal_owner_id = ?", userID).First(&org).Error
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&models.OrganizationMember{OrganizationID: org.ID, UserID: userID, Role: models.OrgRoleOwner}).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.Personal (organization): user_id=%s: %v", userID, err)
+		return nil, err
+	}
+	return &org, nil
+}
+
+func (r *Repository) FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	var m models.OrganizationMember
+	err := r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).First(&m).Error
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// AddMember добавляет участника; уже состоящий в организации — gorm.ErrDuplicatedKey
+func (r *Repository) AddMember(m *models.OrganizationMember) error {
+	result := r.db.Omit("User").Clauses(clause.OnConflict{DoNothing: true}).Create(m)
+	if result.Error != nil {
+		r.logger.Errorf("Repository.AddMember (organization): insert failed: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
+}
+
+func (r *Repository) UpdateMemberRole(orgID, userID uuid.UUID, role string) error {
+	return r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND user_id = ?", orgID, userID).
+		Update("role", role).Error
+}
+
+func (r *Repository) RemoveMember(orgID, userID uuid.UUID) error {
+	return r.db.Where("organization_id = ? AND user_id = ?", orgID, userID).Delete(&models.OrganizationMember{}).Error
+}
+
+func (r *Repository) CountOwners(orgID uuid.UUID) (int64, error) {
+	var n int64
+	err := r.db.Model(&models.OrganizationMember{}).
+		Where("organization_id = ? AND role = ?", orgID, models.OrgRoleOwner).
+		Count(&n).Error
+	return n, err
+}
+
+func (r *Repository) CreateService(svc *models.Service) error {
+	return r.db.Create(svc).Error
+}
+
+func (r *Repository) FindServices(orgID uuid.UUID) ([]models.Service, error) {
+	var services []models.Service
+	err := r.db.Where("organization_id = ?", orgID).Order("id ASC").Find(&services).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindServices (organization): query failed: %v", err)
+		return nil, err
+	}
+	return services, nil
+}
+
+func (r *Repository) FindService(orgID uuid.UUID, serviceID uint) (*models.Service, error) {
+	var svc models.Service
+	err := r.db.Where("id = ? AND organization_id = ?", serviceID, orgID).First(&svc).Error
+	if err != nil {
+		return nil, err
+	}
+	return &svc, nil
+}
+
+func (r *Repository) AssignService(serviceID uint, masterID uuid.UUID) error {
+	return r.db.Model(&models.Service{}).Where("id = ?", serviceID).Update("master_id", masterID).Error
+}
+
+// FindSlots возвращает слоты по услугам организации, начинающиеся в [from, to)
+func (r *Repository) FindSlots(orgID uuid.UUID, from, to time.Time) ([]models.Slot, error) {
+	var slots []models.Slot
+	err := r.db.
+		Preload("Service").
+		Preload("Master").
+		Where(organizationServices, orgID).
+		Where("slots.start_time >= ? AND slots.start_time < ?", from, to).
+		Order("slots.start_time ASC").
+		Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindSlots (organization): query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
+
+// FindRecords возвращает записи на слоты по услугам организации в [from, to); status == "" — все
+func (r *Repository) FindRecords(orgID uuid.UUID, status string, from, to time.Time) ([]models.Record, error) {
+	var records []models.Record
+	q := r.db.
+		Preload("Slot", models.WithArchived).
+		Preload("Slot.Service", models.WithArchived).
+		Preload("Slot.Master", models.WithArchived).
+		Preload("Client", models.WithArchived).
+		Joins("JOIN slots ON slots.id = records.slot_id").
+		Where(organizationServices, orgID).
+		Where("slots.start_time >= ? AND slots.start_time < ?", from, to)
+	if status != "" {
+		q = q.Where("records.status = ?", status)
+	}
+	err := q.Order("slots.start_time ASC").Find(&records).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindRecords (organization): query failed: %v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+func (r *Repository) FindRecord(orgID uuid.UUID, recordID uint) (*models.Record, error) {
+	var rec models.Record
+	err := r.db.
+		Joins("JOIN slots ON slots.id = records.slot_id").
+		Where(organizationServices, orgID).
+		Where("records.id = ?", recordID).
+		First(&rec).Error
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
+
+func (r *Repository) withMembers(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Members", func(db *gorm.DB) *gorm.DB {
+			// участники в архиве не показываются, но остаются в организации до восстановления или очистки
+			return db.Where("organization_members.user_id IN (SELECT id FROM users WHERE deleted_at IS NULL)").
+				Order("organization_members.created_at ASC")
+		}).
+		Preload("Members.User")
+}
+
+// personalName — название личной организации: имя мастера или его телефон
+func personalName(u models.User) string {
+	if name := strings.TrimSpace(u.FirstName + " " + u.Surname); name != "" {
+		return name
+	}
+	return u.Phone
+}
@@ -0,0 +1,38 @@
+package payment
+
+import (
+	"app/pkg/models"
+	"time"
+)
+
+// ExpiredHolds возвращает записи, бронь которых истекла без оплаты, с клиентом, услугой и мастером
+func (r *Repository) ExpiredHolds(now time.Time, limit int) ([]models.Record, error) {
+	var records []models.Record
+	err := r.db.
+		Preload("Client", models.WithArchived).
+		Preload("Slot", models.WithArchived).
+		Preload("Slot.Service", models.WithArchived).
+		Preload("Slot.Master", models.WithArchived).
+		Where("status = ? AND hold_until <= ?", models.RecordAwaitingPayment, now).
+		Order("hold_until ASC").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		r.logger.Errorf("Repository.ExpiredHolds: query failed: %v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// ReleaseHold отменяет неоплаченную запись; false — её уже оплатили или сняли
+// (другой экземпляр API или вебхук успел раньше)
+func (r *Repository) ReleaseHold(recordID uint) (bool, error) {
+	result := r.db.Model(&models.Record{}).
+		Where("id = ? AND status = ?", recordID, models.RecordAwaitingPayment).
+		Update("status", "cancel")
+	if result.Error != nil {
+		r.logger.Errorf("Repository.ReleaseHold: record_id=%d: %v", recordID, result.Error)
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
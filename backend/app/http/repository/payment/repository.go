@@ -0,0 +1,31 @@
+package payment
+
+import (
+	"app/pkg/models"
+	"time"
+)
+
+func (r *Repository) Create(p *models.Payment) error {
+	if err := r.db.Create(p).Error; err != nil {
+		r.logger.Errorf("Repository.Create (payment): insert failed: %v", err)
+		return err
+	}
+	return nil
+}
+
+// FindByExternalID ищет платёж по ID у провайдера; нет такого — gorm.ErrRecordNotFound
+func (r *Repository) FindByExternalID(provider, externalID string) (models.Payment, error) {
+	var p models.Payment
+	err := r.db.Where("provider = ? AND external_id = ?", provider, externalID).First(&p).Error
+	return p, err
+}
+
+// UpdateStatus меняет статус платежа; paidAt записывается вместе с succeeded
+func (r *Repository) UpdateStatus(id uint, status string, paidAt *time.Time) error {
+	if err := r.db.Model(&models.Payment{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "paid_at": paidAt}).Error; err != nil {
+		r.logger.Errorf("Repository.UpdateStatus (payment): id=%d: %v", id, err)
+		return err
+	}
+	return nil
+}
@@ -2,35 +2,87 @@ package record
 
 import (
 	"app/pkg/models"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// Ошибки Create, MoveRecord и ConfirmPaid: слот или заявку успели занять, пока шла проверка
+var (
+	ErrSlotBooked   = errors.New("slot is already booked")
+	ErrRecordExists = errors.New("user already has a record for this slot")
+	ErrSlotHeld     = errors.New("slot is held for another client's payment")
+)
+
+// Create сохраняет заявку. Слот блокируется (SELECT … FOR UPDATE) на время проверок и вставки,
+// поэтому две параллельные заявки не займут один свободный слот
 func (r *Repository) Create(book *models.Record) (uint, error) {
-	exists, err := r.ExistsRecord(book.SlotID, book.ClientID)
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, book.SlotID)
+		if err != nil {
+			return err
+		}
+		if err := checkFree(tx, slot, book.ClientID); err != nil {
+			return err
+		}
+		return tx.Create(book).Error
+	})
 	if err != nil {
-		r.logger.Errorf("Repository.Create (record): check existing failed: %v", err)
-		return 0, err
-	}
-	if exists {
-		r.logger.Errorf("Repository.Create (record): record already exists for slot_id=%d client_id=%s", book.SlotID, book.ClientID)
-		return 0, fmt.Errorf("user already has a record for this slot")
-	}
-
-	if err := r.db.Create(book).Error; err != nil {
-		r.logger.Errorf("Repository.Create (record): create failed: %v", err)
+		r.logger.Errorf("Repository.Create (record): slot_id=%d client_id=%s: %v", book.SlotID, book.ClientID, err)
 		return 0, err
 	}
 	r.logger.Infof("Repository.Create (record): created id=%d", book.ID)
 	return book.ID, nil
 }
 
+// checkFree проверяет заблокированный слот перед заявкой клиента: слот не занят, у клиента
+// нет на него заявки и слот не держит чужая неоплаченная бронь
+func checkFree(tx *gorm.DB, slot models.Slot, clientID uuid.UUID) error {
+	if slot.IsBooked {
+		return ErrSlotBooked
+	}
+	var count int64
+	if err := tx.Model(&models.Record{}).Where("slot_id = ? AND client_id = ?", slot.ID, clientID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrRecordExists
+	}
+	// Чужая неоплаченная бронь держит слот до истечения
+	if err := tx.Model(&models.Record{}).
+		Where("slot_id = ? AND status = ? AND hold_until > ?", slot.ID, models.RecordAwaitingPayment, time.Now()).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrSlotHeld
+	}
+	return nil
+}
+
+// lockSlot читает слот с блокировкой строки до конца транзакции
+func lockSlot(tx *gorm.DB, id uint) (models.Slot, error) {
+	var slot models.Slot
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&slot, id).Error
+	return slot, err
+}
+
+// withHistory подгружает слот записи с услугой, мастером и местом, включая архивные:
+// история визитов не должна терять строки после удаления услуги или мастера
+func withHistory(db *gorm.DB) *gorm.DB {
+	return db.Preload("Slot", models.WithArchived).
+		Preload("Slot.Service", models.WithArchived).
+		Preload("Slot.Master", models.WithArchived).
+		Preload("Slot.Location")
+}
+
 func (r *Repository) FindRecordsByClient(client_id uuid.UUID) (records []models.Record, err error) {
-	err = r.db.Preload("Slot.Service").Preload("Slot.Master").
+	err = r.db.Scopes(withHistory).Preload("AddOns").Preload("Payment").
 		Where("client_id = ?", client_id).
 		Order("id DESC").
 		Find(&records).Error
@@ -44,7 +96,7 @@ func (r *Repository) FindRecordsByClient(client_id uuid.UUID) (records []models.
 
 // FindRecordsByClientWithStatus returns records for a client optionally filtered by status
 func (r *Repository) FindRecordsByClientWithStatus(clientID uuid.UUID, status string) (records []models.Record, err error) {
-	q := r.db.Preload("Slot.Service").Preload("Slot.Master")
+	q := r.db.Scopes(withHistory).Preload("AddOns").Preload("Payment")
 	if status != "" {
 		q = q.Where("status = ?", status)
 	}
@@ -60,7 +112,7 @@ func (r *Repository) FindRecordsByClientWithStatus(clientID uuid.UUID, status st
 }
 
 func (r *Repository) FindRecordsBySlot(slot_id uint, status string) (records []models.Record, err error) {
-	err = r.db.Preload("Client").Where("slot_id = ? AND status = ?", slot_id, status).Order("id ASC").Find(&records).Error
+	err = r.db.Preload("Client", models.WithArchived).Preload("AddOns").Where("slot_id = ? AND status = ?", slot_id, status).Order("id ASC").Find(&records).Error
 	if err != nil {
 		r.logger.Errorf("Repository.FindRecordBySlot (record): query failed: %v", err)
 		return
@@ -73,6 +125,7 @@ func (r *Repository) FindDetailRecord(recordID uint) (record models.RecordRespon
 		Select(`
 			records.id,
 			records.status,
+			records.comment,
 			records.created_at,
 			records.client_id,
 			clients.telegram_id as client_telegram_id,
@@ -81,8 +134,9 @@ func (r *Repository) FindDetailRecord(recordID uint) (record models.RecordRespon
 			clients.phone as client_phone,
 			records.slot_id,
 			services.name as slot_name,
-			services.price as slot_price,
-			services.duration as slot_duration,
+			records.price as slot_price,
+			records.currency as slot_currency,
+			records.duration as slot_duration,
 			slots.master_id,
 			masters.telegram_id as master_telegram_id,
 			masters.first_name as master_name,
@@ -103,7 +157,7 @@ func (r *Repository) FindDetailRecord(recordID uint) (record models.RecordRespon
 	return
 }
 func (r *Repository) FindAllRecordsBySlot(slot_id uint) (records []models.Record, err error) {
-	err = r.db.Preload("Client").Where("slot_id = ?", slot_id).Order("id ASC").Find(&records).Error
+	err = r.db.Preload("Client", models.WithArchived).Preload("AddOns").Where("slot_id = ?", slot_id).Order("id ASC").Find(&records).Error
 	if err != nil {
 		r.logger.Errorf("Repository.FindRecordBySlot (record): query failed: %v", err)
 		return
@@ -119,24 +173,22 @@ func (r *Repository) ChangeRecordStatus(record_id uint, status string) (err erro
 			r.logger.Errorf("Repository.ChangeRecordStatus: load record failed: %v", err)
 			return err
 		}
+		// Подтверждение сериализуется с Create и ConfirmPaid по блокировке слота
+		if status == "confirm" {
+			if _, err := lockSlot(tx, rec.SlotID); err != nil {
+				r.logger.Errorf("Repository.ChangeRecordStatus: lock slot failed: %v", err)
+				return err
+			}
+		}
 
 		if err := tx.Model(&models.Record{}).Where("id = ?", record_id).Update("status", status).Error; err != nil {
 			r.logger.Errorf("Repository.ChangeRecordStatus: set confirm failed: %v", err)
 			return err
 		}
 
-		// Отклоняем все остальные записи по этому слоту
 		if status == "confirm" {
-			if err := tx.Model(&models.Record{}).
-				Where("slot_id = ? AND id <> ? AND status <> ?", rec.SlotID, record_id, "reject").
-				Update("status", "reject").Error; err != nil {
-				r.logger.Errorf("Repository.ChangeRecordStatus: reject others failed: %v", err)
-				return err
-			}
-
-			// Помечаем слот занятым
-			if err := tx.Model(&models.Slot{}).Where("id = ?", rec.SlotID).Update("is_booked", true).Error; err != nil {
-				r.logger.Errorf("Repository.ChangeRecordStatus: mark slot booked failed: %v", err)
+			if err := bookSlot(tx, rec); err != nil {
+				r.logger.Errorf("Repository.ChangeRecordStatus: %v", err)
 				return err
 			}
 		}
@@ -146,6 +198,56 @@ func (r *Repository) ChangeRecordStatus(record_id uint, status string) (err erro
 	})
 }
 
+// bookSlot отклоняет остальные заявки на слот подтверждённой записи rec и помечает слот занятым
+func bookSlot(tx *gorm.DB, rec models.Record) error {
+	if err := tx.Model(&models.Record{}).
+		Where("slot_id = ? AND id <> ? AND status <> ?", rec.SlotID, rec.ID, "reject").
+		Update("status", "reject").Error; err != nil {
+		return fmt.Errorf("reject others: %w", err)
+	}
+	if err := tx.Model(&models.Slot{}).Where("id = ?", rec.SlotID).Update("is_booked", true).Error; err != nil {
+		return fmt.Errorf("mark slot booked: %w", err)
+	}
+	return nil
+}
+
+// ConfirmPaid подтверждает оплаченную заявку под блокировкой слота. false — заявка уже не ждёт
+// оплаты или слот успел занять другой клиент; во втором случае заявка отменяется
+func (r *Repository) ConfirmPaid(recordID uint) (confirmed bool, err error) {
+	err = r.db.Transaction(func(tx *gorm.DB) error {
+		var rec models.Record
+		if err := tx.First(&rec, recordID).Error; err != nil {
+			return err
+		}
+		slot, err := lockSlot(tx, rec.SlotID)
+		if err != nil {
+			return err
+		}
+		// Статус перечитываем под блокировкой: мастер мог подтвердить другую заявку
+		if err := tx.First(&rec, recordID).Error; err != nil {
+			return err
+		}
+		if rec.Status != models.RecordAwaitingPayment {
+			confirmed = rec.Status == "confirm"
+			return nil
+		}
+		if slot.IsBooked {
+			return tx.Model(&models.Record{}).Where("id = ?", recordID).Update("status", "cancel").Error
+		}
+		if err := tx.Model(&models.Record{}).Where("id = ?", recordID).Update("status", "confirm").Error; err != nil {
+			return err
+		}
+		confirmed = true
+		return bookSlot(tx, rec)
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.ConfirmPaid: record_id=%d: %v", recordID, err)
+		return false, err
+	}
+	r.logger.Infof("Repository.ConfirmPaid: record_id=%d confirmed=%t", recordID, confirmed)
+	return confirmed, nil
+}
+
 func (r *Repository) DeleteRecord(id uint) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		var rec models.Record
@@ -175,20 +277,21 @@ func (r *Repository) DeleteRecord(id uint) error {
 	})
 }
 
-// GetSlotByIDWithDetails returns slot by id with service and master loaded
+// GetSlotByIDWithDetails returns slot by id with service (variants and add-ons included), master and location loaded
 func (r *Repository) GetSlotByIDWithDetails(id uint) (models.Slot, error) {
 	var slot models.Slot
-	if err := r.db.Preload("Service").Preload("Master").First(&slot, id).Error; err != nil {
+	if err := r.db.Preload("Service").Preload("Service.Variants").Preload("Service.AddOns").
+		Preload("Master").Preload("Location").First(&slot, id).Error; err != nil {
 		r.logger.Errorf("Repository.GetSlotByIDWithDetails: load failed: %v", err)
 		return slot, err
 	}
 	return slot, nil
 }
 
-// GetRecordByIDWithDetails returns a record by id with slot, service, master and client loaded
+// GetRecordByIDWithDetails returns a record by id with slot, service, master, location and client loaded
 func (r *Repository) GetRecordByIDWithDetails(id uint) (models.Record, error) {
 	var rec models.Record
-	if err := r.db.Preload("Slot.Service").Preload("Slot.Master").Preload("Client").First(&rec, id).Error; err != nil {
+	if err := r.db.Scopes(withHistory).Preload("Client", models.WithArchived).Preload("AddOns").Preload("Payment").First(&rec, id).Error; err != nil {
 		r.logger.Errorf("Repository.GetRecordByIDWithDetails: load failed: %v", err)
 		return rec, err
 	}
@@ -322,13 +425,12 @@ func (r *Repository) GetAllRecords() ([]models.Record, error) {
 }
 
 // FindConfirmedRecordsStartingBetween finds confirmed records whose slot starts within [from, to].
-// Preloads Slot.Service, Slot.Master, and Client for composing reminders.
+// Preloads Slot.Service, Slot.Master, Slot.Location, and Client for composing reminders.
 func (r *Repository) FindConfirmedRecordsStartingBetween(from, to time.Time) ([]models.Record, error) {
 	var records []models.Record
 	q := r.db.
-		Preload("Slot.Service").
-		Preload("Slot.Master").
-		Preload("Client").
+		Scopes(withHistory).
+		Preload("Client", models.WithArchived).
 		Joins("JOIN slots ON slots.id = records.slot_id").
 		Where("records.status = ? AND slots.start_time BETWEEN ? AND ?", "confirm", from, to)
 	if err := q.Find(&records).Error; err != nil {
@@ -344,11 +446,10 @@ func (r *Repository) FindUpcomingRecordsByMasterTelegramID(masterTelegramID int6
 	now := time.Now()
 
 	err := r.db.
-		Preload("Slot.Service").
-		Preload("Slot.Master").
-		Preload("Client").
+		Scopes(withHistory).
+		Preload("Client", models.WithArchived).
 		Joins("JOIN slots ON slots.id = records.slot_id").
-		Joins("JOIN users ON slots.master_id = users.id").
+		Joins("JOIN users ON slots.master_id = users.id AND users.deleted_at IS NULL").
 		Where("users.telegram_id = ? AND slots.start_time > ? AND records.status = ?", masterTelegramID, now, "confirm").
 		Order("slots.start_time ASC").
 		Find(&records).Error
@@ -361,3 +462,65 @@ func (r *Repository) FindUpcomingRecordsByMasterTelegramID(masterTelegramID int6
 	r.logger.Infof("Repository.FindUpcomingRecordsByMasterTelegramID: master_telegram_id=%d count=%d", masterTelegramID, len(records))
 	return records, nil
 }
+
+// MoveRecord переносит запись на другой слот и возвращает её в ожидание подтверждения.
+// Целевой слот блокируется, как в Create: check (проверки вызывающего) получает запись,
+// перечитанную под блокировкой, и сам слот, затем проверяются занятость, повторная заявка
+// и чужая бронь (ErrSlotBooked, ErrRecordExists, ErrSlotHeld).
+// Если запись была подтверждена, пересчитывает is_booked старого слота.
+func (r *Repository) MoveRecord(recordID, slotID uint, check func(rec models.Record, slot models.Slot) error) error {
+	var from uint
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		slot, err := lockSlot(tx, slotID)
+		if err != nil {
+			return err
+		}
+		// Статус записи читаем под блокировкой: её могли отменить или подтвердить
+		var rec models.Record
+		if err := tx.First(&rec, recordID).Error; err != nil {
+			return err
+		}
+		from = rec.SlotID
+		if err := check(rec, slot); err != nil {
+			return err
+		}
+		if err := checkFree(tx, slot, rec.ClientID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.Record{}).Where("id = ?", recordID).
+			Updates(map[string]interface{}{"slot_id": slotID, "status": "pending"}).Error; err != nil {
+			return err
+		}
+
+		if rec.Status == "confirm" {
+			var cnt int64
+			if err := tx.Model(&models.Record{}).Where("slot_id = ? AND status = ?", rec.SlotID, "confirm").Count(&cnt).Error; err != nil {
+				return err
+			}
+			if err := tx.Model(&models.Slot{}).Where("id = ?", rec.SlotID).Update("is_booked", cnt > 0).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.MoveRecord: record_id=%d slot_id=%d: %v", recordID, slotID, err)
+		return err
+	}
+	r.logger.Infof("Repository.MoveRecord: record_id=%d slot_id=%d -> %d", recordID, from, slotID)
+	return nil
+}
+
+// UpdateRecordComment сохраняет комментарий клиента к записи
+func (r *Repository) UpdateRecordComment(recordID uint, comment string) error {
+	res := r.db.Model(&models.Record{}).Where("id = ?", recordID).Update("comment", comment)
+	if res.Error != nil {
+		r.logger.Errorf("Repository.UpdateRecordComment: update failed: %v", res.Error)
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
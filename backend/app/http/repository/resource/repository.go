@@ -0,0 +1,104 @@
+package resource
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// resourceServices — условие «слот по услуге, которой нужен ресурс»
+const resourceServices = "slots.service_id IN (SELECT service_id FROM service_resources WHERE resource_id = ?)"
+
+func (r *Repository) Create(res *models.Resource) error {
+	return r.db.Create(res).Error
+}
+
+func (r *Repository) FindByID(orgID uuid.UUID, id uint) (*models.Resource, error) {
+	var res models.Resource
+	err := r.db.Where("id = ? AND organization_id = ?", id, orgID).First(&res).Error
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *Repository) FindByOrganization(orgID uuid.UUID) ([]models.Resource, error) {
+	var list []models.Resource
+	err := r.db.Where("organization_id = ?", orgID).Order("id ASC").Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindByOrganization (resource): query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *Repository) FindAll() ([]models.Resource, error) {
+	var list []models.Resource
+	err := r.db.Order("organization_id ASC, id ASC").Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindAll (resource): query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *Repository) Update(res *models.Resource) error {
+	return r.db.Model(&models.Resource{}).Where("id = ?", res.ID).
+		Updates(map[string]interface{}{"name": res.Name, "quantity": res.Quantity, "active": res.Active}).Error
+}
+
+func (r *Repository) Delete(orgID uuid.UUID, id uint) error {
+	return r.db.Where("id = ? AND organization_id = ?", id, orgID).Delete(&models.Resource{}).Error
+}
+
+// SetServiceResources заменяет ресурсы услуги в одной транзакции
+func (r *Repository) SetServiceResources(serviceID uint, resourceIDs []uint) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("service_id = ?", serviceID).Delete(&models.ServiceResource{}).Error; err != nil {
+			return err
+		}
+		if len(resourceIDs) == 0 {
+			return nil
+		}
+		rows := make([]models.ServiceResource, 0, len(resourceIDs))
+		for _, id := range resourceIDs {
+			rows = append(rows, models.ServiceResource{ServiceID: serviceID, ResourceID: id})
+		}
+		return tx.Create(&rows).Error
+	})
+	if err != nil {
+		r.logger.Errorf("Repository.SetServiceResources (resource): service_id=%d: %v", serviceID, err)
+	}
+	return err
+}
+
+func (r *Repository) FindServiceResources(serviceID uint) ([]models.Resource, error) {
+	var list []models.Resource
+	err := r.db.
+		Where("id IN (SELECT resource_id FROM service_resources WHERE service_id = ?)", serviceID).
+		Order("id ASC").
+		Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindServiceResources (resource): query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// FindBookedSlots возвращает забронированные слоты всех мастеров, которым нужен ресурс,
+// пересекающиеся с [from, to), кроме exceptSlotID
+func (r *Repository) FindBookedSlots(resourceID uint, from, to time.Time, exceptSlotID uint) ([]models.Slot, error) {
+	var slots []models.Slot
+	err := r.db.
+		Where(resourceServices, resourceID).
+		Where("slots.is_booked AND slots.start_time < ? AND slots.end_time > ? AND slots.id <> ?", to, from, exceptSlotID).
+		Order("slots.start_time ASC").
+		Find(&slots).Error
+	if err != nil {
+		r.logger.Errorf("Repository.FindBookedSlots (resource): query failed: %v", err)
+		return nil, err
+	}
+	return slots, nil
+}
@@ -0,0 +1,44 @@
+package review
+
+import (
+	"app/pkg/models"
+	"time"
+)
+
+// PromptCandidates — подтверждённые записи, слот которых закончился в [from, to),
+// без отзыва и без отправленного приглашения; клиент должен быть в Telegram
+func (r *Repository) PromptCandidates(from, to time.Time, limit int) ([]models.Record, error) {
+	var records []models.Record
+	err := r.db.
+		Preload("Client").
+		Preload("Slot", models.WithArchived).
+		Preload("Slot.Service", models.WithArchived).
+		Preload("Slot.Master", models.WithArchived).
+		Joins("JOIN slots ON slots.id = records.slot_id").
+		Joins("JOIN users clients ON clients.id = records.client_id AND clients.deleted_at IS NULL").
+		Where("records.status = ? AND records.review_prompted_at IS NULL", "confirm").
+		Where("slots.end_time >= ? AND slots.end_time < ?", from, to).
+		Where("clients.telegram_id <> 0").
+		Where("NOT EXISTS (SELECT 1 FROM reviews WHERE reviews.record_id = records.id)").
+		Order("slots.end_time ASC").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		r.logger.Errorf("Repository.PromptCandidates: query failed: %v", err)
+		return nil, err
+	}
+	return records, nil
+}
+
+// ClaimPrompt отмечает приглашение отправленным; false — его уже отправил другой экземпляр API
+func (r *Repository) ClaimPrompt(recordID uint, at time.Time) (bool, error) {
+	result := r.db.Model(&models.Record{}).
+		Where("id = ? AND review_prompted_at IS NULL", recordID).
+		Update("review_prompted_at", at)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReleasePrompt снимает отметку, если бот приглашение не принял
+func (r *Repository) ReleasePrompt(recordID uint) error {
+	return r.db.Model(&models.Record{}).Where("id = ?", recordID).Update("review_prompted_at", nil).Error
+}
@@ -0,0 +1,111 @@
+package review
+
+import (
+	"app/pkg/models"
+	"contract"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Create сохраняет отзыв и пересчитывает рейтинг; отзыв на эту запись уже есть — gorm.ErrDuplicatedKey
+func (r *Repository) Create(rv *models.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Omit("Client", "Service").Clauses(clause.OnConflict{DoNothing: true}).Create(rv)
+		if result.Error != nil {
+			r.logger.Errorf("Repository.Create (review): insert failed: %v", result.Error)
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrDuplicatedKey
+		}
+		return refreshRatings(tx, rv.MasterID, rv.ServiceID)
+	})
+}
+
+func (r *Repository) FindByID(id uint) (*models.Review, error) {
+	var rv models.Review
+	if err := r.db.Preload("Client", models.WithArchived).Preload("Service", models.WithArchived).First(&rv, id).Error; err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+func (r *Repository) FindByRecord(recordID uint) (*models.Review, error) {
+	var rv models.Review
+	if err := r.db.Preload("Client", models.WithArchived).Preload("Service", models.WithArchived).Where("record_id = ?", recordID).First(&rv).Error; err != nil {
+		return nil, err
+	}
+	return &rv, nil
+}
+
+// Find возвращает отзывы по фильтру, новые первыми
+func (r *Repository) Find(f models.ReviewFilter) ([]models.Review, error) {
+	var list []models.Review
+	err := filtered(r.db, f).Preload("Client", models.WithArchived).Preload("Service", models.WithArchived).
+		Order("created_at DESC, id DESC").Limit(f.Limit).Offset(f.Offset).
+		Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.Find (review): query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// Summary — средняя оценка и число отзывов по фильтру (без Limit и Offset)
+func (r *Repository) Summary(f models.ReviewFilter) (contract.RatingSummary, error) {
+	var s contract.RatingSummary
+	err := filtered(r.db, f).Select("COALESCE(round(AVG(rating)::numeric, 2), 0)::double precision AS rating, COUNT(*) AS count").
+		Scan(&s).Error
+	if err != nil {
+		r.logger.Errorf("Repository.Summary (review): query failed: %v", err)
+	}
+	return s, err
+}
+
+// Update сохраняет оценку, текст, ответ и модерацию и пересчитывает рейтинг
+func (r *Repository) Update(rv *models.Review) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.Review{}).Where("id = ?", rv.ID).
+			Select("rating", "text", "reply", "replied_at", "hidden", "flagged", "updated_at").
+			Updates(rv).Error
+		if err != nil {
+			r.logger.Errorf("Repository.Update (review): update failed: %v", err)
+			return err
+		}
+		return refreshRatings(tx, rv.MasterID, rv.ServiceID)
+	})
+}
+
+func filtered(db *gorm.DB, f models.ReviewFilter) *gorm.DB {
+	q := db.Model(&models.Review{})
+	if f.MasterID != nil {
+		q = q.Where("master_id = ?", *f.MasterID)
+	}
+	if f.ServiceID != nil {
+		q = q.Where("service_id = ?", *f.ServiceID)
+	}
+	if f.Hidden != nil {
+		q = q.Where("hidden = ?", *f.Hidden)
+	}
+	if f.Flagged != nil {
+		q = q.Where("flagged = ?", *f.Flagged)
+	}
+	return q
+}
+
+// refreshRatings пересчитывает users.rating мастера и services.rating услуги по видимым отзывам
+func refreshRatings(tx *gorm.DB, masterID uuid.UUID, serviceID *uint) error {
+	err := tx.Exec(`UPDATE users SET
+		rating = COALESCE((SELECT round(AVG(rating)::numeric, 2) FROM reviews WHERE master_id = @id AND NOT hidden), 0),
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE master_id = @id AND NOT hidden)
+		WHERE id = @id`, map[string]interface{}{"id": masterID}).Error
+	if err != nil || serviceID == nil {
+		return err
+	}
+	return tx.Exec(`UPDATE services SET
+		rating = COALESCE((SELECT round(AVG(rating)::numeric, 2) FROM reviews WHERE service_id = @id AND NOT hidden), 0),
+		rating_count = (SELECT COUNT(*) FROM reviews WHERE service_id = @id AND NOT hidden)
+		WHERE id = @id`, map[string]interface{}{"id": *serviceID}).Error
+}
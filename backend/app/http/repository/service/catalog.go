@@ -0,0 +1,102 @@
+package service
+
+import (
+	"app/pkg/models"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// byPosition — порядок вариантов и опций в прайсе
+func byPosition(db *gorm.DB) *gorm.DB {
+	return db.Order("position ASC, id ASC")
+}
+
+// withOptions подгружает варианты и опции услуги
+func withOptions(db *gorm.DB) *gorm.DB {
+	return db.Preload("Variants", byPosition).Preload("AddOns", byPosition)
+}
+
+// CreateCategory сохраняет категорию; категория с таким названием у мастера уже есть — gorm.ErrDuplicatedKey
+func (r *Repository) CreateCategory(c *models.ServiceCategory) error {
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(c)
+	if result.Error != nil {
+		r.logger.Errorf("Repository.CreateCategory: insert failed: %v", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrDuplicatedKey
+	}
+	return nil
+}
+
+func (r *Repository) GetCategories(masterID uuid.UUID) ([]models.ServiceCategory, error) {
+	var list []models.ServiceCategory
+	err := r.db.Where("master_id = ?", masterID).Order("position ASC, id ASC").Find(&list).Error
+	if err != nil {
+		r.logger.Errorf("Repository.GetCategories: query failed: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+func (r *Repository) GetCategory(id uint) (models.ServiceCategory, error) {
+	var c models.ServiceCategory
+	err := r.db.First(&c, id).Error
+	return c, err
+}
+
+func (r *Repository) UpdateCategory(c *models.ServiceCategory) error {
+	return r.db.Model(&models.ServiceCategory{}).Where("id = ?", c.ID).
+		Select("name", "position").Updates(c).Error
+}
+
+// DeleteCategory удаляет категорию; category_id услуг обнуляет ON DELETE SET NULL
+func (r *Repository) DeleteCategory(id uint) error {
+	return r.db.Delete(&models.ServiceCategory{}, id).Error
+}
+
+func (r *Repository) SetServiceCategory(serviceID uint, categoryID *uint) error {
+	return r.db.Model(&models.Service{}).Where("id = ?", serviceID).Update("category_id", categoryID).Error
+}
+
+func (r *Repository) CreateVariant(v *models.ServiceVariant) error {
+	return r.db.Create(v).Error
+}
+
+func (r *Repository) GetVariant(id uint) (models.ServiceVariant, error) {
+	var v models.ServiceVariant
+	err := r.db.First(&v, id).Error
+	return v, err
+}
+
+func (r *Repository) UpdateVariant(v *models.ServiceVariant) error {
+	return r.db.Model(&models.ServiceVariant{}).Where("id = ?", v.ID).
+		Select("name", "price", "duration", "position").Updates(v).Error
+}
+
+// DeleteVariant удаляет вариант; записи сохраняют снимок цены, variant_id обнуляется
+func (r *Repository) DeleteVariant(id uint) error {
+	return r.db.Delete(&models.ServiceVariant{}, id).Error
+}
+
+func (r *Repository) CreateAddOn(a *models.ServiceAddOn) error {
+	return r.db.Create(a).Error
+}
+
+func (r *Repository) GetAddOn(id uint) (models.ServiceAddOn, error) {
+	var a models.ServiceAddOn
+	err := r.db.First(&a, id).Error
+	return a, err
+}
+
+func (r *Repository) UpdateAddOn(a *models.ServiceAddOn) error {
+	return r.db.Model(&models.ServiceAddOn{}).Where("id = ?", a.ID).
+		Select("name", "price", "duration", "position").Updates(a).Error
+}
+
+// DeleteAddOn удаляет опцию; выбранные в записях опции остаются снимком без add_on_id
+func (r *Repository) DeleteAddOn(id uint) error {
+	return r.db.Delete(&models.ServiceAddOn{}, id).Error
+}
@@ -1,13 +1,17 @@
 package service
 
 import (
+	"app/http/repository/archive"
 	"app/pkg/models"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func (r *Repository) CreateService(slot *models.Service) error {
-	if err := r.db.Create(slot).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Create(slot).Error; err != nil {
 		r.logger.Errorf("Repository.CreateService: create failed: %v", err)
 		return err
 	}
@@ -17,8 +21,9 @@ func (r *Repository) CreateService(slot *models.Service) error {
 
 func (r *Repository) GetServices(userID uuid.UUID) ([]models.Service, error) {
 	var services []models.Service
-	err := r.db.
+	err := r.db.Scopes(withOptions).
 		Where("master_id = ?", userID).
+		Order("id ASC").
 		Find(&services).Error
 	if err != nil {
 		r.logger.Errorf("Repository.GetServices: query failed: %v", err)
@@ -41,7 +46,7 @@ func (r *Repository) GetAllServices() ([]models.Service, error) {
 }
 func (r *Repository) GetService(id uint) (models.Service, error) {
 	var service models.Service
-	err := r.db.
+	err := r.db.Scopes(withOptions).
 		Where("id = ?", id).
 		First(&service).Error
 	if err != nil {
@@ -58,7 +63,7 @@ func (r *Repository) GetDetailService(serviceID uint) (models.ServiceResponse, e
 		Table("services").
 		Select("services.*, users.first_name as master_name, users.surname as master_surname, users.phone as master_phone, users.telegram_id as master_telegram_id").
 		Joins("JOIN users ON users.id = services.master_id").
-		Where("services.id = ?", serviceID).
+		Where("services.id = ? AND services.deleted_at IS NULL AND users.deleted_at IS NULL", serviceID).
 		First(&service).Error
 	if err != nil {
 		r.logger.Errorf("Repository.GetService: query failed: %v", err)
@@ -67,7 +72,7 @@ func (r *Repository) GetDetailService(serviceID uint) (models.ServiceResponse, e
 	return service, nil
 }
 func (r *Repository) UpdateService(service *models.Service) error {
-	if err := r.db.Save(service).Error; err != nil {
+	if err := r.db.Omit(clause.Associations).Save(service).Error; err != nil {
 		r.logger.Errorf("Repository.UpdateService: update failed: %v", err)
 		return err
 	}
@@ -75,9 +80,12 @@ func (r *Repository) UpdateService(service *models.Service) error {
 	return nil
 }
 
+// DeleteService переносит услугу и её слоты в архив; записи о визитах остаются
 func (r *Repository) DeleteService(id uint) error {
-	var service models.Service
-	if err := r.db.Where("id = ?", id).Delete(&service).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return archive.Services(tx, time.Now(), "id = ?", id)
+	})
+	if err != nil {
 		r.logger.Errorf("Repository.DeleteService: delete failed: %v", err)
 		return err
 	}
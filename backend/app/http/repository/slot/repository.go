@@ -1,9 +1,12 @@
 package slot
 
 import (
+	"app/http/repository/archive"
 	"app/pkg/models"
+	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 func (r *Repository) Create(slot *models.Slot) error {
@@ -15,6 +18,9 @@ func (r *Repository) Create(slot *models.Slot) error {
 	return nil
 }
 
+// active — условие выборок со слотами: ни слот, ни его услуга, ни мастер не в архиве
+const active = "slots.deleted_at IS NULL AND services.deleted_at IS NULL AND users.deleted_at IS NULL"
+
 type SlotWithMaster struct {
 	models.Slot
 	ServiceName      string `json:"service_name"`
@@ -27,10 +33,11 @@ type SlotWithMaster struct {
 type SlotWithMasterAndService struct {
 	models.Slot
 
-	ServiceName        string  `json:"service_name" gorm:"->;column:service_name"`
-	ServiceDescription string  `json:"service_description" gorm:"->;column:service_description"`
-	ServicePrice       float64 `json:"service_price" gorm:"->;column:service_price"`
-	ServiceDuration    int     `json:"service_duration" gorm:"->;column:service_duration"`
+	ServiceName        string `json:"service_name" gorm:"->;column:service_name"`
+	ServiceDescription string `json:"service_description" gorm:"->;column:service_description"`
+	ServicePrice       int64  `json:"service_price" gorm:"->;column:service_price"`
+	ServiceCurrency    string `json:"service_currency" gorm:"->;column:service_currency"`
+	ServiceDuration    int    `json:"service_duration" gorm:"->;column:service_duration"`
 
 	MasterTelegramID int64  `json:"master_telegram_id" gorm:"->;column:master_telegram_id"`
 	MasterName       string `json:"master_name" gorm:"->;column:master_name"`
@@ -47,6 +54,7 @@ func (r *Repository) FindSlots(userID uuid.UUID) ([]SlotWithMaster, error) {
 		Select("slots.*, users.first_name as master_name, users.surname as master_surname, users.phone as master_phone, users.telegram_id as master_telegram_id, users.timezone as master_timezone, services.name as service_name").
 		Joins("JOIN users ON users.id = slots.master_id").
 		Joins("JOIN services ON services.id = slots.service_id").
+		Where(active).
 		Where("users.id = ?", userID).
 		Order("start_time ASC").
 		Find(&result).Error
@@ -65,6 +73,7 @@ func (r *Repository) FindAllSlots() ([]SlotWithMaster, error) {
 		Select("slots.*, users.first_name as master_name, users.surname as master_surname, users.phone as master_phone, users.telegram_id as master_telegram_id, users.timezone as master_timezone, services.name as service_name").
 		Joins("JOIN users ON users.id = slots.master_id").
 		Joins("JOIN services ON services.id = slots.service_id").
+		Where(active).
 		Order("start_time ASC").
 		Find(&result).Error
 	if err != nil {
@@ -78,9 +87,10 @@ func (r *Repository) FindSlot(slotID uint) (*SlotWithMasterAndService, error) {
 	var result *SlotWithMasterAndService
 	err := r.db.
 		Table("slots").
-		Select("slots.*, users.first_name as master_name, users.surname as master_surname, users.phone as master_phone, users.telegram_id as master_telegram_id, users.timezone as master_timezone, services.name as service_name, services.description as service_description, services.price as service_price,services.duration as service_duration").
+		Select("slots.*, users.first_name as master_name, users.surname as master_surname, users.phone as master_phone, users.telegram_id as master_telegram_id, users.timezone as master_timezone, services.name as service_name, services.description as service_description, services.price as service_price, services.currency as service_currency, services.duration as service_duration").
 		Joins("JOIN users ON users.id = slots.master_id").
 		Joins("JOIN services ON services.id = slots.service_id").
+		Where(active).
 		Where("slots.id = ?", slotID).
 		First(&result).Error
 	if err != nil {
@@ -90,9 +100,13 @@ func (r *Repository) FindSlot(slotID uint) (*SlotWithMasterAndService, error) {
 	r.logger.Infof("Repository.FindSlots (slot): slot_id=%v", slotID)
 	return result, nil
 }
+
+// DeleteSlots переносит в архив все слоты мастера; заявки на будущие слоты отклоняются
 func (r *Repository) DeleteSlots(userID uuid.UUID) error {
-	var slots []models.Slot
-	if err := r.db.Where("master_id IN (SELECT id FROM users WHERE id = ?)", userID).Delete(&slots).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return archive.Slots(tx, time.Now(), "master_id = ?", userID)
+	})
+	if err != nil {
 		r.logger.Errorf("Repository.DeleteSlots (slot): delete failed: %v", err)
 		return err
 	}
@@ -100,9 +114,12 @@ func (r *Repository) DeleteSlots(userID uuid.UUID) error {
 	return nil
 }
 
+// DeleteSlot переносит слот в архив; заявки на него отклоняются, если он ещё не начался
 func (r *Repository) DeleteSlot(id uint) error {
-	var slot models.Slot
-	if err := r.db.Where("id = ?", id).Delete(&slot).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return archive.Slots(tx, time.Now(), "id = ?", id)
+	})
+	if err != nil {
 		r.logger.Errorf("Repository.DeleteSlot (slot): delete failed: %v", err)
 		return err
 	}
@@ -1,6 +1,7 @@
 package user
 
 import (
+	"app/http/repository/archive"
 	"app/pkg/models"
 	"errors"
 	"time"
@@ -136,6 +137,68 @@ func (r *Repository) CheckUserToken(telegram_id int64) bool {
 	return true
 }
 
+// phoneCodeKey — ключ регистрации, ожидающей кода: номер и Telegram, куда ушёл код.
+// Отделяет такие записи от токенов в общем KV-хранилище; чужой запрос на тот же номер
+// не перезаписывает ожидающую регистрацию
+type phoneCodeKey struct {
+	phone      string
+	telegramID int64
+}
+
+// PendingRegistration — регистрация, ожидающая подтверждения одноразовым кодом
+type PendingRegistration struct {
+	User      models.User
+	Code      string
+	Attempts  int
+	CreatedAt time.Time
+}
+
+const phoneCodeTTL = 10 * time.Minute
+
+// Store pending registration by normalized phone and Telegram ID at KV-repository;
+// expired entries of other registrations are swept on the way
+func (r *Repository) StoragePendingRegistration(phone string, telegramID int64, pending PendingRegistration) {
+	r.mapToken.Range(func(k, v any) bool {
+		if _, ok := k.(phoneCodeKey); ok && time.Since(v.(PendingRegistration).CreatedAt) > phoneCodeTTL {
+			r.mapToken.Delete(k)
+		}
+		return true
+	})
+	r.mapToken.Store(phoneCodeKey{phone: phone, telegramID: telegramID}, pending)
+}
+
+// Load pending registration by normalized phone and Telegram ID; expired entries are dropped
+func (r *Repository) LoadPendingRegistration(phone string, telegramID int64) (*PendingRegistration, bool) {
+	key := phoneCodeKey{phone: phone, telegramID: telegramID}
+	v, ok := r.mapToken.Load(key)
+	if !ok {
+		return nil, false
+	}
+	pending := v.(PendingRegistration)
+	if time.Since(pending.CreatedAt) > phoneCodeTTL {
+		r.mapToken.Delete(key)
+		r.logger.Infof("Repository.LoadPendingRegistration (user): expired phone=%s telegram_id=%d", phone, telegramID)
+		return nil, false
+	}
+	return &pending, true
+}
+
+func (r *Repository) DeletePendingRegistration(phone string, telegramID int64) {
+	r.mapToken.Delete(phoneCodeKey{phone: phone, telegramID: telegramID})
+}
+
+// VerifyPhone отмечает номер пользователя подтверждённым контактом из Telegram telegramID
+func (r *Repository) VerifyPhone(userID uuid.UUID, telegramID int64, at time.Time) error {
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Updates(map[string]interface{}{"telegram_id": telegramID, "phone_verified_at": at}).Error; err != nil {
+		r.logger.Errorf("Repository.VerifyPhone (user): update failed: %v", err)
+		return err
+	}
+	r.logger.Infof("Repository.VerifyPhone (user): verified id=%s telegram_id=%d", userID, telegramID)
+	return nil
+}
+
 // Find user by phone
 func (r *Repository) FindByPhone(phone string) (*models.User, error) {
 	var user models.User
@@ -202,9 +265,61 @@ func (r *Repository) UpdateTimezone(userID uuid.UUID, timezone string) error {
 	return nil
 }
 
+// Update user field language
+func (r *Repository) UpdateLanguage(userID uuid.UUID, language string) error {
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("language", language).Error; err != nil {
+		r.logger.Errorf("Repository.UpdateLanguage (user): update failed: %v", err)
+		return err
+	}
+	r.logger.Infof("Repository.UpdateLanguage (user): updated id=%s", userID)
+	return nil
+}
+
+// Update user field digest_time
+func (r *Repository) UpdateDigestTime(userID uuid.UUID, digestTime string) error {
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("digest_time", digestTime).Error; err != nil {
+		r.logger.Errorf("Repository.UpdateDigestTime (user): update failed: %v", err)
+		return err
+	}
+	r.logger.Infof("Repository.UpdateDigestTime (user): updated id=%s", userID)
+	return nil
+}
+
+// Update user field directory_opt_out
+func (r *Repository) UpdateDirectoryOptOut(userID uuid.UUID, optOut bool) error {
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("directory_opt_out", optOut).Error; err != nil {
+		r.logger.Errorf("Repository.UpdateDirectoryOptOut (user): update failed: %v", err)
+		return err
+	}
+	r.logger.Infof("Repository.UpdateDirectoryOptOut (user): updated id=%s", userID)
+	return nil
+}
+
+// Update user field currency
+func (r *Repository) UpdateCurrency(userID uuid.UUID, currency string) error {
+	if err := r.db.Model(&models.User{}).
+		Where("id = ?", userID).
+		Update("currency", currency).Error; err != nil {
+		r.logger.Errorf("Repository.UpdateCurrency (user): update failed: %v", err)
+		return err
+	}
+	r.logger.Infof("Repository.UpdateCurrency (user): updated id=%s", userID)
+	return nil
+}
+
 // Delete user by uuid
+// DeleteUser переносит пользователя в архив вместе с услугами и слотами.
+// Персональные данные стираются позже, по сроку хранения (scheduler.Retention)
 func (r *Repository) DeleteUser(userID uuid.UUID) error {
-	err := r.db.Where("id = ?", userID).Delete(&models.User{}).Error
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		return archive.User(tx, time.Now(), userID)
+	})
 	if err != nil {
 		r.logger.Errorf("Repository.DeleteUser (user): delete failed: %v", err)
 		return err
@@ -1,19 +1,34 @@
 package router
 
 import (
+	"app/encoder"
 	"app/http/controller/admin"
+	archiveCtrl "app/http/controller/archive"
+	resourceCtrl "app/http/controller/resource"
+	reviewCtrl "app/http/controller/review"
 	"app/http/controller/role"
 	"app/http/middleware"
+	archiveRepo "app/http/repository/archive"
+	locationRepo "app/http/repository/location"
 	mrepo "app/http/repository/metrics"
+	orgRepo "app/http/repository/organization"
 	"app/http/repository/record"
+	resourceRepo "app/http/repository/resource"
+	reviewRepo "app/http/repository/review"
 	"app/http/repository/service"
 	"app/http/repository/slot"
 	"app/http/repository/user"
+	"app/http/sender"
+	archiveServ "app/http/usecase/archive"
+	locationServ "app/http/usecase/location"
 	"app/http/usecase/notification"
 	recordServ "app/http/usecase/record"
+	resourceServ "app/http/usecase/resource"
+	reviewServ "app/http/usecase/review"
 	serviceServ "app/http/usecase/service"
 	slotServ "app/http/usecase/slot"
 	userServ "app/http/usecase/user"
+	"app/internal/config"
 	"app/internal/database"
 	"app/internal/logger"
 	"sync"
@@ -22,7 +37,7 @@ import (
 )
 
 // SetupAdminRoutes настраивает маршруты для админки
-func SetupAdminRoutes(r *gin.Engine, db *database.Database, logger *logger.Logger, roleHandler *role.Handler, notifyServ *notification.Service) {
+func SetupAdminRoutes(r *gin.Engine, db *database.Database, logger *logger.Logger, roleHandler *role.Handler, notifyServ *notification.Service, snd *sender.Sender, tokens *encoder.Encoder, cfg *config.Config) {
 	// Создаем репозитории с logrus.Logger
 	logrusLogger := logger.Logger
 	tokenMap := &sync.Map{}
@@ -32,12 +47,30 @@ func SetupAdminRoutes(r *gin.Engine, db *database.Database, logger *logger.Logge
 	recordRepo := record.NewRepository(db.DB, logrusLogger)
 	metricsRepo := mrepo.NewRepository(db.DB, logrusLogger)
 
-	recordService := recordServ.NewService(recordRepo, notifyServ, logrusLogger)
-	serviceService := serviceServ.NewService(serviceRepo, userRepo, logrusLogger)
-	slotService := slotServ.NewService(slotRepo, logrusLogger)
-	userService := userServ.NewService(userRepo, logrusLogger)
+	resourceService := resourceServ.NewService(resourceRepo.NewRepository(db.DB, logrusLogger), orgRepo.NewRepository(db.DB, logrusLogger), logrusLogger)
+	recordService := recordServ.NewService(recordRepo, notifyServ, logrusLogger).
+		WithSender(snd).
+		WithResources(resourceService)
+	serviceService := serviceServ.NewService(serviceRepo, userRepo, logrusLogger).
+		WithOrganizations(orgRepo.NewRepository(db.DB, logrusLogger))
+	slotService := slotServ.NewService(slotRepo, logrusLogger).
+		WithSender(snd).
+		WithResources(resourceService).
+		WithLocations(locationServ.NewService(locationRepo.NewRepository(db.DB, logrusLogger), orgRepo.NewRepository(db.DB, logrusLogger), logrusLogger)).
+		WithServices(serviceRepo)
+	userService := userServ.NewService(userRepo, logrusLogger).
+		WithSender(snd).
+		WithTokenEncoder(tokens).
+		WithPhoneRegion(cfg.Phone.DefaultRegion)
 	// Создаем админский хендлер
-	adminHandler := admin.NewHandler(userRepo, slotRepo, serviceRepo, recordRepo, metricsRepo, userService, slotService, serviceService, recordService, logger)
+	adminHandler := admin.NewHandler(userRepo, slotRepo, serviceRepo, recordRepo, metricsRepo, userService, slotService, serviceService, recordService, logger).
+		WithAdminPassword(cfg.Security.AdminPassword).
+		WithTokenEncoder(tokens)
+	resourceHandler := resourceCtrl.NewHandler(resourceService, logrusLogger)
+	reviewService := reviewServ.NewService(reviewRepo.NewRepository(db.DB, logrusLogger), recordRepo, notifyServ, logrusLogger).
+		WithSender(snd)
+	reviewHandler := reviewCtrl.NewHandler(reviewService, logrusLogger)
+	archiveHandler := archiveCtrl.NewHandler(archiveServ.NewService(archiveRepo.NewRepository(db.DB, logrusLogger), logrusLogger), logrusLogger)
 
 	// Группа маршрутов для админки
 	adminGroup := r.Group("/admin")
@@ -47,7 +80,7 @@ func SetupAdminRoutes(r *gin.Engine, db *database.Database, logger *logger.Logge
 
 		// Защищенные маршруты (требуют админскую авторизацию)
 		protected := adminGroup.Group("")
-		protected.Use(middleware.AdminOrPhoneMiddleware())
+		protected.Use(middleware.AdminOrPhoneMiddleware(tokens, cfg.Phone.DefaultRegion))
 		{
 			// Статистика
 			protected.GET("/stats", adminHandler.GetStats)
@@ -70,6 +103,19 @@ func SetupAdminRoutes(r *gin.Engine, db *database.Database, logger *logger.Logge
 			protected.GET("/records/:id", adminHandler.GetDetailRecord)
 			protected.GET("/services", adminHandler.GetAllServices)
 			protected.GET("/records", adminHandler.GetAllRecords)
+			protected.GET("/resources/utilization", resourceHandler.GetAllUtilization)
+
+			// Модерация отзывов
+			protected.GET("/reviews", reviewHandler.GetModerationReviews)
+			protected.PUT("/reviews/:id", reviewHandler.ModerateReview)
+
+			// Архив удалённых пользователей, услуг и слотов
+			protected.GET("/archive/users", archiveHandler.GetArchivedUsers)
+			protected.GET("/archive/services", archiveHandler.GetArchivedServices)
+			protected.GET("/archive/slots", archiveHandler.GetArchivedSlots)
+			protected.POST("/archive/users/:id/restore", archiveHandler.RestoreUser)
+			protected.POST("/archive/services/:id/restore", archiveHandler.RestoreService)
+			protected.POST("/archive/slots/:id/restore", archiveHandler.RestoreSlot)
 
 			// Роли конкретного пользователя
 			adminUserRoleGroup := protected.Group("/users/:id/roles")
@@ -0,0 +1,14 @@
+package router
+
+import (
+	deliveryCtrl "app/http/controller/delivery"
+	deliveryRepo "app/http/repository/delivery"
+	deliveryServ "app/http/usecase/delivery"
+)
+
+func (s *Client) GetDeliveryHandler() *deliveryCtrl.Handler {
+	Repo := deliveryRepo.NewRepository(s.gormDB, s.logger)
+	Serv := deliveryServ.NewService(Repo, s.logger)
+	Ctrl := deliveryCtrl.NewHandler(Serv, s.logger)
+	return Ctrl
+}
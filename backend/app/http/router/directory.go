@@ -0,0 +1,14 @@
+package router
+
+import (
+	directoryCtrl "app/http/controller/directory"
+	directoryRepo "app/http/repository/directory"
+	directoryServ "app/http/usecase/directory"
+)
+
+func (s *Client) GetDirectoryHandler() *directoryCtrl.Handler {
+	Repo := directoryRepo.NewRepository(s.gormDB, s.logger)
+	Serv := directoryServ.NewService(Repo, s.logger)
+	Ctrl := directoryCtrl.NewHandler(Serv, s.logger)
+	return Ctrl
+}
@@ -0,0 +1,17 @@
+package router
+
+import (
+	locationCtrl "app/http/controller/location"
+	locationRepo "app/http/repository/location"
+	orgRepo "app/http/repository/organization"
+	locationServ "app/http/usecase/location"
+)
+
+// locationService — места организаций; им же новые слоты получают место услуги
+func (s *Client) locationService() *locationServ.Service {
+	return locationServ.NewService(locationRepo.NewRepository(s.gormDB, s.logger), orgRepo.NewRepository(s.gormDB, s.logger), s.logger)
+}
+
+func (s *Client) GetLocationHandler() *locationCtrl.Handler {
+	return locationCtrl.NewHandler(s.locationService(), s.logger)
+}
@@ -0,0 +1,26 @@
+package router
+
+import (
+	orgCtrl "app/http/controller/organization"
+	notifyRepo "app/http/repository/notification"
+	orgRepo "app/http/repository/organization"
+	recordRepo "app/http/repository/record"
+	userRepo "app/http/repository/user"
+	notifyServ "app/http/usecase/notification"
+	orgServ "app/http/usecase/organization"
+	recordServ "app/http/usecase/record"
+	"sync"
+)
+
+func (s *Client) GetOrganizationHandler(tokenMap *sync.Map) *orgCtrl.Handler {
+	notificationService := notifyServ.NewService(notifyRepo.NewRepository(s.gormDB, s.logger), s.logger)
+	records := recordServ.NewService(recordRepo.NewRepository(s.gormDB, s.logger), notificationService, s.logger).
+		WithSender(s.sender).
+		WithResources(s.resourceService())
+	Repo := orgRepo.NewRepository(s.gormDB, s.logger)
+	UserRepo := userRepo.NewRepository(s.gormDB, s.logger, tokenMap)
+	Serv := orgServ.NewService(Repo, UserRepo, records, s.logger).
+		WithPhoneRegion(s.cfg.Phone.DefaultRegion)
+	Ctrl := orgCtrl.NewHandler(Serv, s.logger)
+	return Ctrl
+}
@@ -0,0 +1,51 @@
+package router
+
+import (
+	paymentCtrl "app/http/controller/payment"
+	notifyRepo "app/http/repository/notification"
+	paymentRepo "app/http/repository/payment"
+	recordRepo "app/http/repository/record"
+	notifyServ "app/http/usecase/notification"
+	paymentServ "app/http/usecase/payment"
+	recordServ "app/http/usecase/record"
+	"app/pkg/payment"
+)
+
+// paymentProvider — провайдер предоплаты из payment.provider; nil — предоплата выключена
+func (s *Client) paymentProvider() payment.Provider {
+	cfg := s.cfg.Payment
+	switch cfg.Provider {
+	case "fake":
+		return payment.NewFake(cfg.WebhookSecret)
+	case "yookassa":
+		return payment.NewYooKassa(cfg.YooKassa.ShopID, cfg.YooKassa.SecretKey, cfg.YooKassa.APIBase)
+	}
+	return nil
+}
+
+// recordService — сервис записей; с провайдером предоплаты он связан с сервисом платежей
+// в обе стороны: записи создают платежи, вебхуки об оплате подтверждают записи
+func (s *Client) recordService() (*recordServ.Service, *paymentServ.Service) {
+	notificationService := notifyServ.NewService(notifyRepo.NewRepository(s.gormDB, s.logger), s.logger)
+	records := recordServ.NewService(recordRepo.NewRepository(s.gormDB, s.logger), notificationService, s.logger).
+		WithSender(s.sender).
+		WithResources(s.resourceService())
+	provider := s.paymentProvider()
+	if provider == nil {
+		return records, nil
+	}
+	payments := paymentServ.NewService(paymentRepo.NewRepository(s.gormDB, s.logger), provider, s.logger).
+		WithRecords(records).
+		WithReturnURL(s.cfg.Payment.ReturnURL)
+	records.WithPayments(payments, s.cfg.Payment.Hold())
+	return records, payments
+}
+
+// GetPaymentHandler — вебхуки провайдера; nil, если предоплата выключена
+func (s *Client) GetPaymentHandler() *paymentCtrl.Handler {
+	_, payments := s.recordService()
+	if payments == nil {
+		return nil
+	}
+	return paymentCtrl.NewHandler(payments, s.logger)
+}
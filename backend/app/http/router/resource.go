@@ -0,0 +1,17 @@
+package router
+
+import (
+	resourceCtrl "app/http/controller/resource"
+	orgRepo "app/http/repository/organization"
+	resourceRepo "app/http/repository/resource"
+	resourceServ "app/http/usecase/resource"
+)
+
+// resourceService — ресурсы организаций; им же проверяют слоты и записи
+func (s *Client) resourceService() *resourceServ.Service {
+	return resourceServ.NewService(resourceRepo.NewRepository(s.gormDB, s.logger), orgRepo.NewRepository(s.gormDB, s.logger), s.logger)
+}
+
+func (s *Client) GetResourceHandler() *resourceCtrl.Handler {
+	return resourceCtrl.NewHandler(s.resourceService(), s.logger)
+}
@@ -0,0 +1,19 @@
+package router
+
+import (
+	reviewCtrl "app/http/controller/review"
+	notifyRepo "app/http/repository/notification"
+	recordRepo "app/http/repository/record"
+	reviewRepo "app/http/repository/review"
+	notifyServ "app/http/usecase/notification"
+	reviewServ "app/http/usecase/review"
+)
+
+func (s *Client) GetReviewHandler() *reviewCtrl.Handler {
+	notificationService := notifyServ.NewService(notifyRepo.NewRepository(s.gormDB, s.logger), s.logger)
+	Repo := reviewRepo.NewRepository(s.gormDB, s.logger)
+	Serv := reviewServ.NewService(Repo, recordRepo.NewRepository(s.gormDB, s.logger), notificationService, s.logger).
+		WithSender(s.sender)
+	Ctrl := reviewCtrl.NewHandler(Serv, s.logger)
+	return Ctrl
+}
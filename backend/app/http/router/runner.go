@@ -12,7 +12,6 @@ import (
 	"app/internal/logger"
 	"fmt"
 	"net/http"
-	"os"
 	"strings"
 	"sync"
 	"time"
@@ -22,9 +21,7 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
 )
 
-func InternalAuthMiddleware() gin.HandlerFunc {
-	allowedInternal := os.Getenv("INTERNAL_TOKEN")
-	allowedFrontend := os.Getenv("FRONTEND_SECRET")
+func InternalAuthMiddleware(allowedInternal, allowedFrontend string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Allow claim-token via short-lived login flow cookie set after /user/login
 		if strings.HasPrefix(c.Request.URL.Path, "/user/claim-token/") {
@@ -45,18 +42,26 @@ func InternalAuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// InternalCallerMiddleware marks requests signed with the internal token (the Telegram bot)
+// as internal_caller without rejecting the others
+func InternalCallerMiddleware(allowedInternal string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("internal_caller", allowedInternal != "" && c.GetHeader("X-Internal-Token") == allowedInternal)
+		c.Next()
+	}
+}
+
 func (s *Client) Run() error {
+	internalAuth := InternalAuthMiddleware(s.cfg.Security.InternalToken, s.cfg.Security.FrontendSecret)
+	telegramActor := s.GetTelegramActorMiddleware()
+
 	// CORS middleware with secure configuration
+	allowedOrigins := s.cfg.CORS.AllowedOrigins
 	s.router.Use(func(c *gin.Context) {
-		// Allowed domains from ALLOWED_ORIGINS environment variable (comma-separated)
+		// Allowed domains from cors.allowed_origins (ALLOWED_ORIGINS, comma-separated)
 		origin := c.Request.Header.Get("Origin")
-		allowed := os.Getenv("ALLOWED_ORIGINS")
-		if allowed == "" {
-			s.logger.Warn("ALLOWED_ORIGINS not set, using defaults for development")
-			allowed = "http://localhost:3000,http://localhost:5173,http://localhost:8091"
-		}
-		for _, o := range strings.Split(allowed, ",") {
-			if strings.TrimSpace(o) == origin {
+		for _, o := range allowedOrigins {
+			if o == origin {
 				c.Header("Access-Control-Allow-Origin", origin)
 				break
 			}
@@ -95,31 +100,46 @@ func (s *Client) Run() error {
 		userGroup.GET("/check/:telegram_id", userHandler.CheckAuth)
 		userGroup.GET("/check-login/:telegram_id", userHandler.CheckLogin)
 		userGroup.GET("/public/:uuid", userHandler.GetPublicUser)
-		userGroup.POST("/register", userHandler.CreateUser)
+		userGroup.POST("/register", InternalCallerMiddleware(s.cfg.Security.InternalToken), userHandler.CreateUser)
+		userGroup.POST("/register/verify", userHandler.VerifyRegistration)
 		userGroup.POST("/login", userHandler.Login)
+		userGroup.POST("/login/telegram-widget", userHandler.LoginTelegramWidget)
+		userGroup.POST("/login/telegram-webapp", userHandler.LoginTelegramWebApp)
 
 		// Internal endpoints (for Telegram bot only)
 		userGroup.GET("/g3tter/:telegram_id", userHandler.GetUserByTelegramID)
 		// Internal token confirmation and claim endpoints require internal token
 		internalUser := userGroup.Group("")
-		internalUser.Use(InternalAuthMiddleware())
+		internalUser.Use(internalAuth)
 		internalUser.POST("/confirm-login/:telegram_id", userHandler.ConfirmLogin)
 		internalUser.POST("/claim-token/:telegram_id", userHandler.ClaimToken)
 		userGroup.POST("/confirm-deletion", userHandler.ConfirmAccountDeletion)
 
 		// Protected endpoints (require session authentication)
-		userGroup.Use(middleware.SessionAuthMiddleware())
+		userGroup.Use(middleware.SessionAuthMiddleware(s.tokens))
 		userGroup.POST("/logout", userHandler.Logout)
 		userGroup.PUT("/update", userHandler.UpdateUser)
 		userGroup.PUT("/timezone", userHandler.UpdateTimezone)
+		userGroup.PUT("/directory", userHandler.UpdateDirectoryOptOut)
+		userGroup.PUT("/currency", userHandler.UpdateCurrency)
 		userGroup.POST("/request-deletion", userHandler.RequestAccountDeletion)
 	}
 
 	// Internal routes for Telegram bot (without user session)
 	userTelegramGroup := s.router.Group("/telegram/user")
 	{
-		userTelegramGroup.Use(InternalAuthMiddleware())
+		userTelegramGroup.Use(internalAuth)
 		userTelegramGroup.PUT("/timezone", userHandler.UpdateTimezoneInternal)
+		userTelegramGroup.PUT("/language", userHandler.UpdateLanguageInternal)
+		userTelegramGroup.PUT("/digest", userHandler.UpdateDigestTimeInternal)
+	}
+
+	// Outbox bookkeeping: the Telegram bot reports delivery results
+	deliveryHandler := s.GetDeliveryHandler()
+	deliveryTelegramGroup := s.router.Group("/telegram/delivery")
+	{
+		deliveryTelegramGroup.Use(internalAuth)
+		deliveryTelegramGroup.POST("/:id", deliveryHandler.ReportDelivery)
 	}
 
 	slotHandler := s.GetSlotHandler()
@@ -129,8 +149,8 @@ func (s *Client) Run() error {
 		slotGroup.GET("/:uuid", slotHandler.GetSlots)
 		slotGroup.GET("/one/:id", slotHandler.GetSlot)
 
-		// Protected endpoints (require session authentication)
-		slotGroup.Use(middleware.SessionAuthMiddleware())
+		// Protected endpoints (require session authentication or the bot acting for a master)
+		slotGroup.Use(telegramActor, middleware.SessionAuthMiddleware(s.tokens))
 		slotGroup.POST("/master/create", middleware.CreateRateLimitMiddleware(), slotHandler.CreateSlot)
 		slotGroup.DELETE("/master/:uuid", slotHandler.DeleteSlots)
 		slotGroup.DELETE("/master/one/:id", slotHandler.DeleteSlot)
@@ -144,7 +164,7 @@ func (s *Client) Run() error {
 		recordGroup.POST("/user/filter", recordHandler.GetClientRecordsFiltered)
 
 		// Protected endpoints (require session authentication)
-		recordGroup.Use(middleware.SessionAuthMiddleware())
+		recordGroup.Use(middleware.SessionAuthMiddleware(s.tokens))
 		recordGroup.GET("/master/:slot_id", recordHandler.GetAllRecordsBySlot)
 		recordGroup.POST("/master/create", recordHandler.CreateRecord)
 		recordGroup.POST("/master/get", recordHandler.GetRecordsBySlot)
@@ -153,28 +173,113 @@ func (s *Client) Run() error {
 		recordGroup.POST("/master/reject/:record_id", recordHandler.RejectRecord)
 		recordGroup.DELETE("/master/:record_id", recordHandler.DeleteRecord)
 	}
+	recordClientGroup := s.router.Group("/record/client")
+	{
+		// Client actions on own records (session or the bot acting for the client)
+		recordClientGroup.Use(telegramActor, middleware.SessionAuthMiddleware(s.tokens))
+		recordClientGroup.POST("/:record_id/cancel", recordHandler.CancelRecordByClient)
+		recordClientGroup.POST("/:record_id/reschedule", recordHandler.RescheduleRecordByClient)
+		recordClientGroup.POST("/:record_id/comment", recordHandler.CommentRecordByClient)
+		recordClientGroup.GET("/:record_id/ics", recordHandler.RecordCalendar)
+	}
 	recordTelegramGroup := s.router.Group("/telegram/record")
 	{
 		// Protected endpoints (require internal authentication)
-		recordTelegramGroup.Use(InternalAuthMiddleware())
+		recordTelegramGroup.Use(internalAuth)
 		recordTelegramGroup.POST("/master/create", recordHandler.CreateRecord)
 		recordTelegramGroup.POST("/master/status", recordHandler.UpdateRecordStatus)
 		recordTelegramGroup.POST("/master/confirm/:record_id", recordHandler.ConfirmRecord)
 		recordTelegramGroup.GET("/master/upcoming/:telegram_id", recordHandler.GetUpcomingRecordsByMasterTelegramID)
 	}
+	// Payment provider webhooks (public, verified by the provider adapter)
+	if paymentHandler := s.GetPaymentHandler(); paymentHandler != nil {
+		s.router.POST("/payment/webhook", paymentHandler.PaymentWebhook)
+	}
+
+	directoryHandler := s.GetDirectoryHandler()
+	masterTelegramGroup := s.router.Group("/telegram/master")
+	{
+		// Master search for the bot inline mode (require internal authentication)
+		masterTelegramGroup.Use(internalAuth)
+		masterTelegramGroup.GET("/search", directoryHandler.SearchMasters)
+	}
+
+	// Public master directory routes
+	directoryGroup := s.router.Group("/directory")
+	{
+		directoryGroup.GET("/masters", directoryHandler.DiscoverMasters)
+	}
+	reviewHandler := s.GetReviewHandler()
+	reviewGroup := s.router.Group("/review")
+	{
+		// Public endpoints (no authentication required)
+		reviewGroup.GET("/master/:uuid", reviewHandler.GetMasterReviews)
+		reviewGroup.GET("/service/:id", reviewHandler.GetServiceReviews)
+
+		// Client reviews and master replies (session or the bot acting for the user)
+		reviewGroup.Use(telegramActor, middleware.SessionAuthMiddleware(s.tokens))
+		reviewGroup.POST("/record/:record_id", reviewHandler.CreateReview)
+		reviewGroup.PUT("/record/:record_id", reviewHandler.UpdateReview)
+		reviewGroup.PUT("/:id/reply", reviewHandler.ReplyReview)
+	}
 	tokenMap := &sync.Map{}
 	serviceHandler := s.GetServiceHandler(tokenMap)
 	serviceGroup := s.router.Group("/service")
 	{
 		// Public endpoints (no authentication required)
 		serviceGroup.GET("/master/:uuid", serviceHandler.GetServices)
+		serviceGroup.GET("/master/:uuid/categories", serviceHandler.GetCategories)
 		serviceGroup.GET("/:id", serviceHandler.GetService)
 
-		// Protected endpoints (require session authentication)
-		serviceGroup.Use(middleware.SessionAuthMiddleware())
+		// Protected endpoints (require session authentication or the bot acting for a master)
+		serviceGroup.Use(telegramActor, middleware.SessionAuthMiddleware(s.tokens))
 		serviceGroup.POST("/create", serviceHandler.CreateService)
 		serviceGroup.PUT("/update", serviceHandler.UpdateService)
 		serviceGroup.DELETE("/:id", serviceHandler.DeleteService)
+		serviceGroup.POST("/categories", serviceHandler.CreateCategory)
+		serviceGroup.PUT("/categories/:id", serviceHandler.UpdateCategory)
+		serviceGroup.DELETE("/categories/:id", serviceHandler.DeleteCategory)
+		serviceGroup.PUT("/:id/category", serviceHandler.SetServiceCategory)
+		serviceGroup.POST("/:id/variants", serviceHandler.AddVariant)
+		serviceGroup.PUT("/:id/variants/:variant_id", serviceHandler.UpdateVariant)
+		serviceGroup.DELETE("/:id/variants/:variant_id", serviceHandler.DeleteVariant)
+		serviceGroup.POST("/:id/add-ons", serviceHandler.AddAddOn)
+		serviceGroup.PUT("/:id/add-ons/:add_on_id", serviceHandler.UpdateAddOn)
+		serviceGroup.DELETE("/:id/add-ons/:add_on_id", serviceHandler.DeleteAddOn)
+	}
+
+	organizationHandler := s.GetOrganizationHandler(tokenMap)
+	resourceHandler := s.GetResourceHandler()
+	locationHandler := s.GetLocationHandler()
+	organizationGroup := s.router.Group("/organization")
+	{
+		// Protected endpoints (require session authentication or the bot acting for a member)
+		organizationGroup.Use(telegramActor, middleware.SessionAuthMiddleware(s.tokens))
+		organizationGroup.POST("", organizationHandler.CreateOrganization)
+		organizationGroup.GET("", organizationHandler.GetOrganizations)
+		organizationGroup.GET("/:id", organizationHandler.GetOrganization)
+		organizationGroup.PUT("/:id", organizationHandler.RenameOrganization)
+		organizationGroup.POST("/:id/members", organizationHandler.AddMember)
+		organizationGroup.PUT("/:id/members/:user_id", organizationHandler.UpdateMemberRole)
+		organizationGroup.DELETE("/:id/members/:user_id", organizationHandler.RemoveMember)
+		organizationGroup.GET("/:id/services", organizationHandler.GetServices)
+		organizationGroup.POST("/:id/services", organizationHandler.CreateService)
+		organizationGroup.PUT("/:id/services/:service_id/master", organizationHandler.AssignService)
+		organizationGroup.GET("/:id/slots", organizationHandler.GetSlots)
+		organizationGroup.GET("/:id/records", organizationHandler.GetRecords)
+		organizationGroup.POST("/:id/records/:record_id/status", organizationHandler.UpdateRecordStatus)
+		organizationGroup.GET("/:id/resources", resourceHandler.GetResources)
+		organizationGroup.POST("/:id/resources", resourceHandler.CreateResource)
+		organizationGroup.GET("/:id/resources/utilization", resourceHandler.GetUtilization)
+		organizationGroup.PUT("/:id/resources/:resource_id", resourceHandler.UpdateResource)
+		organizationGroup.DELETE("/:id/resources/:resource_id", resourceHandler.DeleteResource)
+		organizationGroup.GET("/:id/services/:service_id/resources", resourceHandler.GetServiceResources)
+		organizationGroup.PUT("/:id/services/:service_id/resources", resourceHandler.SetServiceResources)
+		organizationGroup.GET("/:id/locations", locationHandler.GetLocations)
+		organizationGroup.POST("/:id/locations", locationHandler.CreateLocation)
+		organizationGroup.PUT("/:id/locations/:location_id", locationHandler.UpdateLocation)
+		organizationGroup.DELETE("/:id/locations/:location_id", locationHandler.DeleteLocation)
+		organizationGroup.PUT("/:id/services/:service_id/location", locationHandler.SetServiceLocation)
 	}
 
 	// Notification routes
@@ -184,7 +289,7 @@ func (s *Client) Run() error {
 	notifyGroup := s.router.Group("/notification")
 	{
 		// Notifications require authentication
-		notifyGroup.Use(middleware.SessionAuthMiddleware())
+		notifyGroup.Use(middleware.SessionAuthMiddleware(s.tokens))
 		notifyGroup.GET("/", notifyHandler.GetClientNotifications)
 		notifyGroup.GET("/unread-count", notifyHandler.CountUnreadUserNotifications)
 		notifyGroup.POST("/:id/mark-read", notifyHandler.MarkIsReadUserNotification)
@@ -194,7 +299,7 @@ func (s *Client) Run() error {
 	// Admin routes
 	adminLogger := &logger.Logger{Logger: s.logger}
 	dbStruct := &database.Database{DB: s.gormDB}
-	SetupAdminRoutes(s.router, dbStruct, adminLogger, s.GetRoleHandler(), notifyServ)
+	SetupAdminRoutes(s.router, dbStruct, adminLogger, s.GetRoleHandler(), notifyServ, s.sender, s.tokens, s.cfg)
 
 	// Metrics routes (public, rate-limited globally)
 	mrepo := metricsRepo.NewRepository(s.gormDB, s.logger)
@@ -202,11 +307,11 @@ func (s *Client) Run() error {
 	s.router.POST("/metrics/ad-click", mhandler.TrackAdClick)
 
 	// Swagger documentation
-	s.logger.Infof("API documentation available at: http://localhost:8090/swagger/index.html#/")
+	s.logger.Infof("API documentation available at: http://localhost%s/swagger/index.html#/", s.cfg.Server.Addr())
 	s.router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Start server
-	s.logger.Infof("Starting server on :8090")
+	s.logger.Infof("Starting server on %s", s.httpServer.server.Addr)
 	if err := s.httpServer.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("Server failse: %v", err)
 	}
@@ -2,6 +2,7 @@ package router
 
 import (
 	serviceCtrl "app/http/controller/service"
+	orgRepo "app/http/repository/organization"
 	serviceRepo "app/http/repository/service"
 	userRepo "app/http/repository/user"
 	serviceServ "app/http/usecase/service"
@@ -11,7 +12,8 @@ import (
 func (s *Client) GetServiceHandler(tokenMap *sync.Map) *serviceCtrl.Handler {
 	Repo := serviceRepo.NewRepository(s.gormDB, s.logger)
 	UserRepo := userRepo.NewRepository(s.gormDB, s.logger, tokenMap)
-	Serv := serviceServ.NewService(Repo, UserRepo, s.logger)
+	Serv := serviceServ.NewService(Repo, UserRepo, s.logger).
+		WithOrganizations(orgRepo.NewRepository(s.gormDB, s.logger))
 	Ctrl := serviceCtrl.NewHandler(Serv, s.logger)
 	return Ctrl
 }
@@ -4,6 +4,7 @@ import (
 	slotCtrl "app/http/controller/slot"
 	notifyRepo "app/http/repository/notification"
 	recordRepo "app/http/repository/record"
+	serviceRepo "app/http/repository/service"
 	slotRepo "app/http/repository/slot"
 	notifyServ "app/http/usecase/notification"
 	slotServ "app/http/usecase/slot"
@@ -16,7 +17,14 @@ func (s *Client) GetSlotHandler() *slotCtrl.Handler {
 	nServ := notifyServ.NewService(nRepo, s.logger)
 	rRepo := recordRepo.NewRepository(s.gormDB, s.logger)
 
-	Serv := slotServ.NewService(Repo, s.logger).WithNotification(nServ).WithRecordRepository(rRepo)
+	Serv := slotServ.NewService(Repo, s.logger).
+		WithNotification(nServ).
+		WithRecordRepository(rRepo).
+		WithSender(s.sender).
+		WithResources(s.resourceService()).
+		WithLocations(s.locationService()).
+		WithServices(serviceRepo.NewRepository(s.gormDB, s.logger)).
+		WithLocation(s.cfg.Telegram.Location())
 	Ctrl := slotCtrl.NewHandler(Serv, s.logger)
 	return Ctrl
 }
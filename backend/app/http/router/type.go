@@ -1,6 +1,9 @@
 package router
 
 import (
+	"app/encoder"
+	"app/http/sender"
+	"app/internal/config"
 	"context"
 	"net/http"
 
@@ -28,6 +31,9 @@ type Client struct {
 	router     *gin.Engine
 	logger     *logrus.Logger
 	httpServer *HttpServer
+	cfg        *config.Config
+	sender     *sender.Sender
+	tokens     *encoder.Encoder
 }
 
 type DataBase struct {
@@ -39,11 +45,14 @@ func (s *Client) DB() *gorm.DB {
 	return s.gormDB
 }
 
-func NewClient(db *gorm.DB, logger *logrus.Logger, httpServer *HttpServer, r *gin.Engine) *Client {
+func NewClient(db *gorm.DB, logger *logrus.Logger, httpServer *HttpServer, r *gin.Engine, cfg *config.Config, snd *sender.Sender) *Client {
 	return &Client{
 		router:     r,
 		DataBase:   DataBase{gormDB: db},
 		logger:     logger,
 		httpServer: httpServer,
+		cfg:        cfg,
+		sender:     snd,
+		tokens:     encoder.New(cfg.Security.JWTSecret),
 	}
 }
@@ -2,16 +2,29 @@ package router
 
 import (
 	userCtrl "app/http/controller/user"
+	"app/http/middleware"
 	userRepo "app/http/repository/user"
 	userServ "app/http/usecase/user"
 	"sync"
+
+	"github.com/gin-gonic/gin"
 )
 
 var tokenMap = &sync.Map{}
 
 func (s *Client) GetUserHandler() *userCtrl.Handler {
 	Repo := userRepo.NewRepository(s.gormDB, s.logger, tokenMap)
-	Serv := userServ.NewService(Repo, s.logger)
+	Serv := userServ.NewService(Repo, s.logger).
+		WithTelegramBotToken(s.cfg.Telegram.BotToken).
+		WithSender(s.sender).
+		WithTokenEncoder(s.tokens).
+		WithPhoneRegion(s.cfg.Phone.DefaultRegion)
 	Ctrl := userCtrl.NewHandler(Serv, s.logger)
 	return Ctrl
 }
+
+// GetTelegramActorMiddleware пускает Telegram-бота на защищённые маршруты мастера
+func (s *Client) GetTelegramActorMiddleware() gin.HandlerFunc {
+	Repo := userRepo.NewRepository(s.gormDB, s.logger, tokenMap)
+	return middleware.TelegramActorMiddleware(s.cfg.Security.InternalToken, Repo)
+}
@@ -3,213 +3,274 @@ package sender
 import (
 	"app/pkg/models"
 	"bytes"
+	"contract"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
-	"os"
+	"net/url"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrDisabled возвращается, если отправитель не подключён к сервису (nil *Sender)
+var ErrDisabled = errors.New("telegram notifier is not configured")
+
+// Outbox — журнал доставок (repository/delivery). Каждое уведомление получает
+// delivery_id, по которому бот потом сообщает результат отправки.
+type Outbox interface {
+	Create(d *models.TelegramDelivery) error
+	Save(d *models.TelegramDelivery) error
+}
+
+// Sender отправляет уведомления через HTTP-нотификатор сервиса telegram-bot
+type Sender struct {
+	base   string
+	secret string
+	client *http.Client
+	outbox Outbox
+}
+
+// New создаёт отправителя уведомлений.
+// base — адрес нотификатора (например http://telegram:8091), secret — значение X-Internal-Token.
+func New(base, secret string) *Sender {
+	return &Sender{
+		base:   base,
+		secret: secret,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// WithOutbox включает учёт доставок: уведомления записываются в outbox
+// со статусом pending, бот присылает итог в /telegram/delivery/:id
+func (s *Sender) WithOutbox(outbox Outbox) *Sender {
+	s.outbox = outbox
+	return s
+}
+
 // AuthNotify отправляет запрос в сервис telegram-bot для подтверждения входа
 // Ответ: Возвращает ошибку
-func LoginNotify(user models.User, ip string, location string) error {
-	base := os.Getenv("TELEGRAM_HTTP_BASE")
-	if base == "" {
-		base = "http://telegram:8091"
+func (s *Sender) LoginNotify(user models.User, ip string, location string) error {
+	if s == nil {
+		return ErrDisabled
 	}
-	url := fmt.Sprintf("%s/notify-login/%d", base, user.TelegramID)
+	d := s.track("login", user.TelegramID)
+	endpoint := fmt.Sprintf("%s/notify-login/%d", s.base, user.TelegramID)
 	// pass meta via query params (best-effort)
-	if ip != "" || location != "" {
-		q := "?"
-		if ip != "" {
-			q += "ip=" + ip
-		}
-		if location != "" {
-			if q != "?" {
-				q += "&"
-			}
-			q += "loc=" + location
-		}
-		url += q
+	q := url.Values{}
+	if ip != "" {
+		q.Set("ip", ip)
 	}
-
-	client := &http.Client{
-		Timeout: 5 * time.Second,
+	if location != "" {
+		q.Set("loc", location)
 	}
-
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return fmt.Errorf("ошибка подготовки запроса: %v", err)
-	}
-	// Добавляем общий внутренний токен для защиты HTTP-нотификатора
-	// Use TELEGRAM_HTTP_SECRET if provided, fallback to INTERNAL_TOKEN for compatibility
-	if tok := func() string {
-		if v := os.Getenv("TELEGRAM_HTTP_SECRET"); v != "" {
-			return v
-		}
-		return os.Getenv("INTERNAL_TOKEN")
-	}(); tok != "" {
-		req.Header.Set("X-Internal-Token", tok)
-	}
-	responce, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("ошибка отправки уведомления: %v", err)
+	if d != nil {
+		q.Set("delivery_id", d.ID.String())
 	}
-	defer responce.Body.Close()
-
-	if responce.StatusCode != http.StatusOK {
-		return fmt.Errorf("Сервер вернул статус: %d", responce.StatusCode)
+	if len(q) > 0 {
+		endpoint += "?" + q.Encode()
 	}
 
-	return nil
+	req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+	if err != nil {
+		return s.finish(d, fmt.Errorf("ошибка подготовки запроса: %v", err))
+	}
+	return s.finish(d, s.do(req))
 }
 
 // RecordNotify отправляет POST-запрос на сервис telegram-bot для отправки произвольного уведомления
 // телеграм-пользователю. Используется для уведомлений о записи/статусах.
-func RecordNotify(recordID uint, telegramID int64, title, message string) error {
-	base := os.Getenv("TELEGRAM_HTTP_BASE")
-	if base == "" {
-		base = "http://telegram:8091"
-	}
-	url := fmt.Sprintf("%s/notify-record", base)
-
-	payload := struct {
+func (s *Sender) RecordNotify(recordID uint, telegramID int64, title, message string) error {
+	d := s.track("record", telegramID)
+	return s.finish(d, s.post("/notify-record", struct {
 		RecordID   uint   `json:"record_id"`
 		TelegramID int64  `json:"telegram_id"`
 		Title      string `json:"title"`
 		Message    string `json:"message"`
+		DeliveryID string `json:"delivery_id,omitempty"`
 	}{
 		RecordID:   recordID,
 		TelegramID: telegramID,
 		Title:      title,
 		Message:    message,
-	}
-
-	jsonBody, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("ошибка сериализации тела запроса: %v", err)
-	}
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("ошибка создания запроса: %v", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	if tok := func() string {
-		if v := os.Getenv("TELEGRAM_HTTP_SECRET"); v != "" {
-			return v
-		}
-		return os.Getenv("INTERNAL_TOKEN")
-	}(); tok != "" {
-		req.Header.Set("X-Internal-Token", tok)
-	}
+// RecordStatusNotify отправляет POST-запрос на сервис telegram-bot для отправки уведомления о статусе записи
+func (s *Sender) RecordStatusNotify(telegramID int64, title, message string) error {
+	d := s.track("record_status", telegramID)
+	return s.finish(d, s.post("/notify-record-status", struct {
+		TelegramID int64  `json:"telegram_id"`
+		Title      string `json:"title"`
+		Message    string `json:"message"`
+		DeliveryID string `json:"delivery_id,omitempty"`
+	}{
+		TelegramID: telegramID,
+		Title:      title,
+		Message:    message,
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("ошибка отправки запроса: %v", err)
-	}
-	defer resp.Body.Close()
+// VenueNotify отправляет в telegram-bot место записи: бот покажет его клиенту картой
+func (s *Sender) VenueNotify(telegramID int64, venue contract.Venue) error {
+	d := s.track("venue", telegramID)
+	return s.finish(d, s.post("/notify-venue", struct {
+		TelegramID int64 `json:"telegram_id"`
+		contract.Venue
+		DeliveryID string `json:"delivery_id,omitempty"`
+	}{
+		TelegramID: telegramID,
+		Venue:      venue,
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("сервер вернул статус: %d", resp.StatusCode)
-	}
-	return nil
+// DigestRecord — заявка в сводке мастера, бот добавляет к ней кнопки подтверждения и отклонения
+type DigestRecord struct {
+	RecordID uint   `json:"record_id"`
+	Label    string `json:"label"`
 }
 
-// RecordStatusNotify отправляет POST-запрос на сервис telegram-bot для отправки уведомления о статусе записи
-func RecordStatusNotify(telegramID int64, title, message string) error {
-	base := os.Getenv("TELEGRAM_HTTP_BASE")
-	if base == "" {
-		base = "http://telegram:8091"
-	}
-	url := fmt.Sprintf("%s/notify-record-status", base)
+// DigestNotify отправляет в telegram-bot сводку мастера; pending — заявки, ждущие решения
+func (s *Sender) DigestNotify(telegramID int64, title, message string, pending []DigestRecord) error {
+	d := s.track("digest", telegramID)
+	return s.finish(d, s.post("/notify-digest", struct {
+		TelegramID int64          `json:"telegram_id"`
+		Title      string         `json:"title"`
+		Message    string         `json:"message"`
+		Pending    []DigestRecord `json:"pending,omitempty"`
+		DeliveryID string         `json:"delivery_id,omitempty"`
+	}{
+		TelegramID: telegramID,
+		Title:      title,
+		Message:    message,
+		Pending:    pending,
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	payload := struct {
+// ReviewPromptNotify отправляет в telegram-bot приглашение оценить завершённую запись:
+// бот добавит к сообщению кнопки со звёздами
+func (s *Sender) ReviewPromptNotify(recordID uint, telegramID int64, title, message string) error {
+	d := s.track("review_prompt", telegramID)
+	return s.finish(d, s.post("/notify-review", struct {
+		RecordID   uint   `json:"record_id"`
 		TelegramID int64  `json:"telegram_id"`
 		Title      string `json:"title"`
 		Message    string `json:"message"`
+		DeliveryID string `json:"delivery_id,omitempty"`
 	}{
+		RecordID:   recordID,
 		TelegramID: telegramID,
 		Title:      title,
 		Message:    message,
-	}
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	jsonBody, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("ошибка сериализации тела запроса: %v", err)
-	}
+// RequestAccountDeletionConfirmation отправляет запрос на подтверждение удаления аккаунта в Telegram
+func (s *Sender) RequestAccountDeletionConfirmation(userID uuid.UUID, telegramID int64) error {
+	d := s.track("account_deletion", telegramID)
+	return s.finish(d, s.post("/notify-account-deletion", struct {
+		UserID     string `json:"user_id"`
+		TelegramID int64  `json:"telegram_id"`
+		DeliveryID string `json:"delivery_id,omitempty"`
+	}{
+		UserID:     userID.String(),
+		TelegramID: telegramID,
+		DeliveryID: deliveryID(d),
+	}))
+}
 
-	client := &http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
-	if err != nil {
-		return fmt.Errorf("ошибка создания запроса: %v", err)
+// PhoneCodeNotify отправляет в telegram-bot одноразовый код подтверждения номера телефона
+// Ответ: Возвращает ошибку
+func (s *Sender) PhoneCodeNotify(telegramID int64, phone string, code string) error {
+	d := s.track("phone_code", telegramID)
+	return s.finish(d, s.post("/notify-phone-code", struct {
+		TelegramID int64  `json:"telegram_id"`
+		Phone      string `json:"phone"`
+		Code       string `json:"code"`
+		DeliveryID string `json:"delivery_id,omitempty"`
+	}{
+		TelegramID: telegramID,
+		Phone:      phone,
+		Code:       code,
+		DeliveryID: deliveryID(d),
+	}))
+}
+
+// track записывает уведомление в outbox со статусом pending.
+// Без outbox (или если запись не удалась) уведомление уходит без delivery_id.
+func (s *Sender) track(kind string, telegramID int64) *models.TelegramDelivery {
+	if s == nil || s.outbox == nil {
+		return nil
 	}
-	req.Header.Set("Content-Type", "application/json")
-	if tok := func() string {
-		if v := os.Getenv("TELEGRAM_HTTP_SECRET"); v != "" {
-			return v
-		}
-		return os.Getenv("INTERNAL_TOKEN")
-	}(); tok != "" {
-		req.Header.Set("X-Internal-Token", tok)
+	now := time.Now()
+	d := &models.TelegramDelivery{
+		ID:         uuid.New(),
+		Kind:       kind,
+		TelegramID: telegramID,
+		Status:     models.DeliveryPending,
+		CreatedAt:  now,
+		UpdatedAt:  now,
 	}
-
-	resp, err := client.Do(req)
-	if err != nil {
-		return fmt.Errorf("ошибка отправки запроса: %v", err)
+	if err := s.outbox.Create(d); err != nil {
+		return nil
 	}
-	defer resp.Body.Close()
+	return d
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("сервер вернул статус: %d", resp.StatusCode)
+// finish отмечает доставку неудачной, если бот не принял уведомление
+func (s *Sender) finish(d *models.TelegramDelivery, err error) error {
+	if d == nil || err == nil {
+		return err
 	}
-	return nil
+	d.Status = models.DeliveryFailed
+	d.LastError = err.Error()
+	d.UpdatedAt = time.Now()
+	_ = s.outbox.Save(d)
+	return err
 }
 
-// RequestAccountDeletionConfirmation отправляет запрос на подтверждение удаления аккаунта в Telegram
-func RequestAccountDeletionConfirmation(userID uuid.UUID, telegramID int64) error {
-	base := os.Getenv("TELEGRAM_HTTP_BASE")
-	if base == "" {
-		base = "http://telegram:8091"
+func deliveryID(d *models.TelegramDelivery) string {
+	if d == nil {
+		return ""
 	}
-	url := fmt.Sprintf("%s/notify-account-deletion", base)
+	return d.ID.String()
+}
 
-	payload := struct {
-		UserID     string `json:"user_id"`
-		TelegramID int64  `json:"telegram_id"`
-	}{
-		UserID:     userID.String(),
-		TelegramID: telegramID,
+// post сериализует payload в JSON и отправляет его на path нотификатора
+func (s *Sender) post(path string, payload any) error {
+	if s == nil {
+		return ErrDisabled
 	}
-
 	jsonBody, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("ошибка сериализации тела запроса: %v", err)
 	}
-
-	client := &http.Client{Timeout: 5 * time.Second}
-	req, err := http.NewRequest(http.MethodPost, url, bytes.NewBuffer(jsonBody))
+	req, err := http.NewRequest(http.MethodPost, s.base+path, bytes.NewBuffer(jsonBody))
 	if err != nil {
 		return fmt.Errorf("ошибка создания запроса: %v", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	if tok := func() string {
-		if v := os.Getenv("TELEGRAM_HTTP_SECRET"); v != "" {
-			return v
-		}
-		return os.Getenv("INTERNAL_TOKEN")
-	}(); tok != "" {
-		req.Header.Set("X-Internal-Token", tok)
-	}
+	return s.do(req)
+}
 
-	resp, err := client.Do(req)
+// do добавляет внутренний токен, выполняет запрос и проверяет статус ответа
+func (s *Sender) do(req *http.Request) error {
+	if s.secret != "" {
+		req.Header.Set("X-Internal-Token", s.secret)
+	}
+	resp, err := s.client.Do(req)
 	if err != nil {
 		return fmt.Errorf("ошибка отправки запроса: %v", err)
 	}
 	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
 
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("сервер вернул статус: %d", resp.StatusCode)
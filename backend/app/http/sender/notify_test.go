@@ -0,0 +1,49 @@
+package sender_test
+
+import (
+	"app/http/sender"
+	"app/pkg/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// outbox запоминает последнюю версию каждой доставки
+type outbox map[string]models.TelegramDelivery
+
+func (o outbox) Create(d *models.TelegramDelivery) error { o[d.ID.String()] = *d; return nil }
+func (o outbox) Save(d *models.TelegramDelivery) error   { o[d.ID.String()] = *d; return nil }
+
+func TestOutboxTracksDeliveries(t *testing.T) {
+	var got struct {
+		DeliveryID string `json:"delivery_id"`
+	}
+	bot := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+
+	deliveries := outbox{}
+	snd := sender.New(bot.URL, "secret").WithOutbox(deliveries)
+	if err := snd.RecordStatusNotify(42, "title", "message"); err != nil {
+		t.Fatal(err)
+	}
+	d, ok := deliveries[got.DeliveryID]
+	if !ok || d.Status != models.DeliveryPending || d.Kind != "record_status" || d.TelegramID != 42 {
+		t.Fatalf("delivery_id = %q, delivery = %+v", got.DeliveryID, d)
+	}
+
+	// Бот недоступен — доставка сразу помечается неудачной
+	bot.Close()
+	if err := snd.RecordStatusNotify(43, "title", "message"); err == nil {
+		t.Fatal("RecordStatusNotify() with bot down should fail")
+	}
+	for _, d := range deliveries {
+		if d.TelegramID == 43 && (d.Status != models.DeliveryFailed || d.LastError == "") {
+			t.Fatalf("delivery for unreachable bot = %+v", d)
+		}
+	}
+	if len(deliveries) != 2 {
+		t.Fatalf("outbox has %d deliveries, want 2", len(deliveries))
+	}
+}
@@ -0,0 +1,126 @@
+package archive
+
+import (
+	"app/pkg/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *Service) Users(limit, offset int) ([]models.User, error) {
+	limit, offset = page(limit, offset)
+	return s.repo.ArchivedUsers(limit, offset)
+}
+
+func (s *Service) Services(limit, offset int) ([]models.Service, error) {
+	limit, offset = page(limit, offset)
+	return s.repo.ArchivedServices(limit, offset)
+}
+
+func (s *Service) Slots(limit, offset int) ([]models.Slot, error) {
+	limit, offset = page(limit, offset)
+	return s.repo.ArchivedSlots(limit, offset)
+}
+
+// RestoreUser возвращает пользователя с услугами и слотами, удалёнными вместе с ним.
+// Отменённые при удалении заявки остаются отменёнными
+func (s *Service) RestoreUser(id uuid.UUID) (*models.User, error) {
+	u, err := s.repo.FindUser(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !u.DeletedAt.Valid {
+		return nil, ErrNotArchived
+	}
+	if u.PurgedAt != nil {
+		return nil, ErrPurged
+	}
+	// после удаления телефон и Telegram свободны: их мог занять новый аккаунт
+	taken, err := s.repo.ContactTaken(u)
+	if err != nil {
+		return nil, err
+	}
+	if taken {
+		return nil, ErrContactTaken
+	}
+	if err := s.repo.RestoreUser(u); err != nil {
+		s.logger.Errorf("Archive.RestoreUser: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Archive.RestoreUser: user_id=%s restored", id)
+	return s.repo.FindUser(id)
+}
+
+// RestoreService возвращает услугу и её слоты, удалённые вместе с ней; мастер должен быть действующим
+func (s *Service) RestoreService(id uint) (*models.Service, error) {
+	svc, err := s.repo.FindService(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !svc.DeletedAt.Valid {
+		return nil, ErrNotArchived
+	}
+	if err := s.activeUser(svc.MasterID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.RestoreService(svc); err != nil {
+		s.logger.Errorf("Archive.RestoreService: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Archive.RestoreService: service_id=%d restored", id)
+	return s.repo.FindService(id)
+}
+
+// RestoreSlot возвращает слот; его мастер и услуга должны быть действующими.
+// Отклонённые при удалении заявки не возвращаются, слот снова свободен
+func (s *Service) RestoreSlot(id uint) (*models.Slot, error) {
+	sl, err := s.repo.FindSlot(id)
+	if err != nil {
+		return nil, notFound(err)
+	}
+	if !sl.DeletedAt.Valid {
+		return nil, ErrNotArchived
+	}
+	if err := s.activeUser(sl.MasterID); err != nil {
+		return nil, err
+	}
+	svc, err := s.repo.FindService(sl.ServiceID)
+	if err != nil {
+		return nil, err
+	}
+	if svc.DeletedAt.Valid {
+		return nil, ErrParentArchived
+	}
+	if err := s.repo.RestoreSlot(sl); err != nil {
+		s.logger.Errorf("Archive.RestoreSlot: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Archive.RestoreSlot: slot_id=%d restored", id)
+	return s.repo.FindSlot(id)
+}
+
+func (s *Service) activeUser(id uuid.UUID) error {
+	u, err := s.repo.FindUser(id)
+	if err != nil {
+		return err
+	}
+	if u.DeletedAt.Valid {
+		return ErrParentArchived
+	}
+	return nil
+}
+
+func notFound(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotArchived
+	}
+	return err
+}
+
+func page(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return min(limit, MaxLimit), max(offset, 0)
+}
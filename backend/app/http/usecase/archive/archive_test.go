@@ -0,0 +1,175 @@
+package archive_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/archive"
+	"app/pkg/models"
+	"errors"
+	"testing"
+	"time"
+)
+
+var _ archive.Repository = (*memory.ArchiveRepository)(nil)
+
+type fixture struct {
+	*memtest.Fixture
+	svc    *archive.Service
+	past   models.Record
+	future models.Record
+}
+
+// newFixture: у мастера memtest прошедший визит клиента и ожидающая заявка на будущий слот
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	f.past = f.Visit(t, f.Clients[0], time.Now().Add(-48*time.Hour).Truncate(time.Minute), "confirm")
+	f.future = f.Visit(t, f.Clients[0], time.Now().Add(48*time.Hour).Truncate(time.Minute), "pending")
+	f.svc = archive.NewService(f.Store.Archive(), f.Logger)
+	return f
+}
+
+func TestDeleteKeepsHistory(t *testing.T) {
+	f := newFixture(t)
+	if err := f.Store.Services().DeleteService(f.Service.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Store.Services().GetService(f.Service.ID); err == nil {
+		t.Error("archived service is still listed")
+	}
+	past := f.Get(t, f.past.ID)
+	if past.Status != "confirm" || past.Slot.Service.Name != "Стрижка" || past.Slot.Master.FirstName != "Анна" {
+		t.Errorf("past visit = %s %q by %q, want confirm Стрижка by Анна", past.Status, past.Slot.Service.Name, past.Slot.Master.FirstName)
+	}
+	if got := f.Get(t, f.future.ID).Status; got != "reject" {
+		t.Errorf("future request status = %q, want reject", got)
+	}
+	services, _ := f.svc.Services(0, 0)
+	slots, _ := f.svc.Slots(0, 0)
+	if len(services) != 1 || len(slots) != 2 {
+		t.Errorf("archive = %d services, %d slots, want 1 and 2", len(services), len(slots))
+	}
+}
+
+func TestDeleteClientCancelsFutureRecords(t *testing.T) {
+	f := newFixture(t)
+	if err := f.Store.Users().DeleteUser(f.Clients[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if u, _ := f.Store.Users().FindByPhone(f.Clients[0].Phone); u != nil {
+		t.Error("archived client still found by phone")
+	}
+	if got := f.Get(t, f.past.ID); got.Status != "confirm" || got.Client.FirstName != "Ольга" {
+		t.Errorf("past visit = %s by %q, want confirm by Ольга", got.Status, got.Client.FirstName)
+	}
+	if got := f.Get(t, f.future.ID).Status; got != "cancel" {
+		t.Errorf("future request status = %q, want cancel", got)
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, f *fixture)
+		restore func(f *fixture) error
+		wantErr error
+	}{
+		{
+			name:    "master with services and slots",
+			prepare: func(t *testing.T, f *fixture) { mustDo(t, f.Store.Users().DeleteUser(f.Master.ID)) },
+			restore: func(f *fixture) error { _, err := f.svc.RestoreUser(f.Master.ID); return err },
+		},
+		{
+			name:    "service with its slots",
+			prepare: func(t *testing.T, f *fixture) { mustDo(t, f.Store.Services().DeleteService(f.Service.ID)) },
+			restore: func(f *fixture) error { _, err := f.svc.RestoreService(f.Service.ID); return err },
+		},
+		{
+			name:    "slot of an archived service",
+			prepare: func(t *testing.T, f *fixture) { mustDo(t, f.Store.Services().DeleteService(f.Service.ID)) },
+			restore: func(f *fixture) error { _, err := f.svc.RestoreSlot(f.future.SlotID); return err },
+			wantErr: archive.ErrParentArchived,
+		},
+		{
+			name:    "service that is not archived",
+			prepare: func(*testing.T, *fixture) {},
+			restore: func(f *fixture) error { _, err := f.svc.RestoreService(f.Service.ID); return err },
+			wantErr: archive.ErrNotArchived,
+		},
+		{
+			name: "phone taken by a new account",
+			prepare: func(t *testing.T, f *fixture) {
+				mustDo(t, f.Store.Users().DeleteUser(f.Clients[0].ID))
+				mustDo(t, f.Store.Users().Create(&models.User{Phone: f.Clients[0].Phone, TelegramID: 3001}))
+			},
+			restore: func(f *fixture) error { _, err := f.svc.RestoreUser(f.Clients[0].ID); return err },
+			wantErr: archive.ErrContactTaken,
+		},
+		{
+			name: "purged user",
+			prepare: func(t *testing.T, f *fixture) {
+				mustDo(t, f.Store.Users().DeleteUser(f.Clients[0].ID))
+				if n, err := f.Store.Archive().Purge(time.Now().Add(time.Minute), time.Now()); n != 1 || err != nil {
+					t.Fatalf("Purge() = %d, %v, want 1 user", n, err)
+				}
+			},
+			restore: func(f *fixture) error { _, err := f.svc.RestoreUser(f.Clients[0].ID); return err },
+			wantErr: archive.ErrPurged,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			tt.prepare(t, f)
+			if err := tt.restore(f); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("restore error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if _, err := f.Store.Services().GetService(f.Service.ID); err != nil {
+				t.Errorf("service not restored: %v", err)
+			}
+			for _, id := range []uint{f.past.SlotID, f.future.SlotID} {
+				if _, err := f.Store.Slots().FindSlot(id); err != nil {
+					t.Errorf("slot %d not restored: %v", id, err)
+				}
+			}
+			// отклонённая при удалении заявка не оживает
+			if got := f.Get(t, f.future.ID).Status; got != "reject" {
+				t.Errorf("future request status = %q, want reject", got)
+			}
+		})
+	}
+}
+
+func TestPurgeErasesPersonalData(t *testing.T) {
+	f := newFixture(t)
+	mustDo(t, f.Store.Users().DeleteUser(f.Clients[0].ID))
+
+	if n, _ := f.Store.Archive().Purge(time.Now().Add(-time.Hour), time.Now()); n != 0 {
+		t.Fatalf("Purge() before retention = %d users, want 0", n)
+	}
+	if n, _ := f.Store.Archive().Purge(time.Now().Add(time.Minute), time.Now()); n != 1 {
+		t.Fatalf("Purge() = %d users, want 1", n)
+	}
+	users, _ := f.svc.Users(0, 0)
+	if len(users) != 1 || users[0].Phone != "" || users[0].FirstName != "" || users[0].TelegramID != 0 || users[0].PurgedAt == nil {
+		t.Fatalf("archived users = %+v, want one purged", users)
+	}
+	if got := f.Get(t, f.past.ID); got.Status != "confirm" {
+		t.Errorf("past visit status = %q after purge, want confirm", got.Status)
+	}
+	if n, _ := f.Store.Archive().Purge(time.Now().Add(time.Minute), time.Now()); n != 0 {
+		t.Errorf("second Purge() = %d users, want 0", n)
+	}
+}
+
+func mustDo(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
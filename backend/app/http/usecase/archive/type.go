@@ -0,0 +1,49 @@
+package archive
+
+import (
+	"app/pkg/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultLimit и MaxLimit — размер страницы архива
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+var (
+	ErrNotArchived    = errors.New("entry not found in archive")
+	ErrParentArchived = errors.New("master or service is archived, restore it first")
+	ErrPurged         = errors.New("user personal data has been purged")
+	ErrContactTaken   = errors.New("phone or telegram account belongs to another user")
+)
+
+// Repository — архивные строки; Find* ищут и среди действующих.
+// Restore* возвращают вместе со строкой всё, что ушло в архив с тем же deleted_at
+type Repository interface {
+	ArchivedUsers(limit, offset int) ([]models.User, error)
+	ArchivedServices(limit, offset int) ([]models.Service, error)
+	ArchivedSlots(limit, offset int) ([]models.Slot, error)
+	FindUser(id uuid.UUID) (*models.User, error)
+	FindService(id uint) (*models.Service, error)
+	FindSlot(id uint) (*models.Slot, error)
+	ContactTaken(u *models.User) (bool, error)
+	RestoreUser(u *models.User) error
+	RestoreService(svc *models.Service) error
+	RestoreSlot(sl *models.Slot) error
+}
+
+type Service struct {
+	repo   Repository
+	logger *logrus.Logger
+}
+
+func NewService(repo Repository, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+	}
+}
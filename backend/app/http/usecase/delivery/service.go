@@ -0,0 +1,57 @@
+package delivery
+
+import (
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrNotFound      = errors.New("delivery not found")
+	ErrInvalidStatus = errors.New("status must be sent or failed")
+	// ErrAlreadyFinal — по доставке уже пришёл отчёт с другим итогом
+	ErrAlreadyFinal = errors.New("delivery already has a final status")
+)
+
+// Report — отчёт бота о доставке сообщения (формат общий с ботом)
+type Report = contract.DeliveryReport
+
+// Report фиксирует итог доставки. Повторный отчёт с тем же статусом
+// (бот повторил запрос после таймаута) считается успешным.
+func (s *Service) Report(id uuid.UUID, r Report) error {
+	if r.Status != models.DeliverySent && r.Status != models.DeliveryFailed {
+		return ErrInvalidStatus
+	}
+	d, err := s.repo.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("find delivery: %w", err)
+	}
+	if d.Status != models.DeliveryPending {
+		if d.Status == r.Status {
+			return nil
+		}
+		return ErrAlreadyFinal
+	}
+
+	now := s.now()
+	d.Status = r.Status
+	d.Attempts = r.Attempts
+	d.MessageID = r.MessageID
+	d.LastError = r.Error
+	d.UpdatedAt = now
+	if r.Status == models.DeliverySent {
+		d.DeliveredAt = &now
+	}
+	if err := s.repo.Save(d); err != nil {
+		return fmt.Errorf("save delivery: %w", err)
+	}
+	s.logger.Infof("Service.Report (delivery): id=%s kind=%s status=%s attempts=%d", d.ID, d.Kind, d.Status, d.Attempts)
+	return nil
+}
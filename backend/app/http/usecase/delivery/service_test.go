@@ -0,0 +1,51 @@
+package delivery_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/usecase/delivery"
+	"app/pkg/models"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var _ delivery.Repository = (*memory.DeliveryRepository)(nil)
+
+func TestReport(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	store := memory.NewStore()
+	svc := delivery.NewService(store.Deliveries(), logger)
+
+	d := models.TelegramDelivery{ID: uuid.New(), Kind: "record", TelegramID: 42, Status: models.DeliveryPending, CreatedAt: time.Now()}
+	if err := store.Deliveries().Create(&d); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.Report(d.ID, delivery.Report{Status: "queued"}); !errors.Is(err, delivery.ErrInvalidStatus) {
+		t.Fatalf("Report(queued) error = %v", err)
+	}
+	if err := svc.Report(uuid.New(), delivery.Report{Status: models.DeliverySent}); !errors.Is(err, delivery.ErrNotFound) {
+		t.Fatalf("Report(unknown id) error = %v", err)
+	}
+
+	if err := svc.Report(d.ID, delivery.Report{Status: models.DeliverySent, Attempts: 2, MessageID: 77}); err != nil {
+		t.Fatalf("Report(sent) error = %v", err)
+	}
+	got, _ := store.Deliveries().FindByID(d.ID)
+	if got.Status != models.DeliverySent || got.Attempts != 2 || got.MessageID != 77 || got.DeliveredAt == nil {
+		t.Fatalf("delivery after report = %+v", got)
+	}
+
+	// Повтор того же отчёта — не ошибка, противоположный итог — конфликт
+	if err := svc.Report(d.ID, delivery.Report{Status: models.DeliverySent, Attempts: 2}); err != nil {
+		t.Fatalf("repeated Report(sent) error = %v", err)
+	}
+	if err := svc.Report(d.ID, delivery.Report{Status: models.DeliveryFailed}); !errors.Is(err, delivery.ErrAlreadyFinal) {
+		t.Fatalf("Report(failed) after sent error = %v", err)
+	}
+}
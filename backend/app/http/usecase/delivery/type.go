@@ -0,0 +1,30 @@
+package delivery
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Repository — outbox уведомлений, переданных боту
+type Repository interface {
+	Create(d *models.TelegramDelivery) error
+	FindByID(id uuid.UUID) (*models.TelegramDelivery, error)
+	Save(d *models.TelegramDelivery) error
+}
+
+type Service struct {
+	repo   Repository
+	logger *logrus.Logger
+	now    func() time.Time
+}
+
+func NewService(repo Repository, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,141 @@
+package directory
+
+import (
+	"app/pkg/models"
+	"contract"
+	"contract/money"
+	"errors"
+	"time"
+)
+
+const (
+	// DiscoverDefaultLimit и DiscoverMaxLimit — размер страницы каталога
+	DiscoverDefaultLimit = 20
+	DiscoverMaxLimit     = 50
+	// MaxRadiusKm — дальше искать «рядом» бессмысленно
+	MaxRadiusKm = 500
+	// MaxFreeWithin — горизонт фильтра «есть свободные слоты»
+	MaxFreeWithin = 60 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidFilter   = errors.New("price and duration must be non-negative, with min not above max")
+	ErrInvalidPoint    = errors.New("lat and lon must be set together within -90..90 and -180..180")
+	ErrInvalidRadius   = errors.New("radius_km must be within 0..500 and needs lat and lon")
+	ErrInvalidWindow   = errors.New("free_within_days must be within 0..60")
+	ErrInvalidSort     = errors.New("sort must be relevance, next_slot, rating or distance; distance needs lat and lon")
+	ErrInvalidCurrency = errors.New("currency must be a supported ISO 4217 code")
+)
+
+// Discover ищет мастеров публичного каталога и собирает карточки с подходящими
+// услугами и ближайшими свободными слотами этих услуг
+func (s *Service) Discover(q models.DirectoryQuery) ([]contract.DirectoryMaster, error) {
+	q.Text = NormalizeQuery(q.Text)
+	if err := validate(&q); err != nil {
+		return nil, err
+	}
+	q.Now = s.now()
+	hits, err := s.repo.Discover(q)
+	if err != nil {
+		s.logger.Errorf("Directory.Discover: repo error: %v", err)
+		return nil, err
+	}
+	masters := make([]contract.DirectoryMaster, 0, len(hits))
+	for _, hit := range hits {
+		ids := make([]uint, 0, len(hit.Master.Services))
+		for _, svc := range hit.Master.Services {
+			ids = append(ids, svc.ID)
+		}
+		slots, err := s.repo.NextServiceSlots(ids, q.Now, SlotsPerMaster)
+		if err != nil {
+			s.logger.Errorf("Directory.Discover: slots of master_id=%v: %v", hit.Master.ID, err)
+			return nil, err
+		}
+		masters = append(masters, directoryMaster(hit, slots))
+	}
+	s.logger.Infof("Directory.Discover: text=%q sort=%s count=%d", q.Text, q.Sort, len(masters))
+	return masters, nil
+}
+
+// validate проверяет фильтры и подставляет значения по умолчанию
+func validate(q *models.DirectoryQuery) error {
+	if q.MinPrice < 0 || q.MaxPrice < 0 || (q.MaxPrice > 0 && q.MinPrice > q.MaxPrice) ||
+		q.MinDuration < 0 || q.MaxDuration < 0 || (q.MaxDuration > 0 && q.MinDuration > q.MaxDuration) {
+		return ErrInvalidFilter
+	}
+	if q.Currency != "" {
+		q.Currency = money.Normalize(q.Currency)
+		if !money.Known(q.Currency) {
+			return ErrInvalidCurrency
+		}
+	}
+	if q.Near != nil && !q.Near.Valid() {
+		return ErrInvalidPoint
+	}
+	if q.RadiusKm < 0 || q.RadiusKm > MaxRadiusKm || (q.RadiusKm > 0 && q.Near == nil) {
+		return ErrInvalidRadius
+	}
+	if q.FreeWithin < 0 || q.FreeWithin > MaxFreeWithin {
+		return ErrInvalidWindow
+	}
+	switch q.Sort {
+	case "":
+		q.Sort = models.DirectorySortNextSlot
+		if q.Text != "" {
+			q.Sort = models.DirectorySortRelevance
+		}
+	case models.DirectorySortRelevance, models.DirectorySortNextSlot, models.DirectorySortRating:
+	case models.DirectorySortDistance:
+		if q.Near == nil {
+			return ErrInvalidSort
+		}
+	default:
+		return ErrInvalidSort
+	}
+	if q.Limit <= 0 {
+		q.Limit = DiscoverDefaultLimit
+	}
+	if q.Limit > DiscoverMaxLimit {
+		q.Limit = DiscoverMaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	return nil
+}
+
+func directoryMaster(hit models.DirectoryHit, slots []models.Slot) contract.DirectoryMaster {
+	m := hit.Master
+	card := contract.DirectoryMaster{
+		ID:          m.ID,
+		FirstName:   m.FirstName,
+		Surname:     m.Surname,
+		Timezone:    m.Timezone,
+		Rating:      m.Rating,
+		RatingCount: m.RatingCount,
+		DistanceKm:  hit.DistanceKm,
+		Services:    make([]contract.Service, 0, len(m.Services)),
+		NextSlots:   make([]contract.SlotResponse, 0, len(slots)),
+	}
+	for _, svc := range m.Services {
+		card.Services = append(card.Services, svc.Contract())
+	}
+	for _, sl := range slots {
+		card.NextSlots = append(card.NextSlots, contract.SlotResponse{
+			ID:                 sl.ID,
+			StartTime:          sl.StartTime,
+			EndTime:            sl.EndTime,
+			IsBooked:           sl.IsBooked,
+			ServiceID:          sl.ServiceID,
+			ServiceName:        sl.Service.Name,
+			ServiceDescription: sl.Service.Description,
+			ServicePrice:       sl.Service.Price,
+			ServiceCurrency:    sl.Service.Currency,
+			ServiceDuration:    sl.Service.Duration,
+			MasterName:         m.FirstName,
+			MasterSurname:      m.Surname,
+			MasterTimezone:     m.Timezone,
+		})
+	}
+	return card
+}
@@ -0,0 +1,225 @@
+package directory_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/usecase/directory"
+	"app/pkg/geo"
+	"app/pkg/models"
+	"contract/money"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// deps — хранилище и организация, к которой привязаны филиалы мастеров
+type deps struct {
+	*memory.Store
+	org *models.Organization
+}
+
+// listing — мастер каталога: услуга с ценой (в копейках, если не задана currency) и длительностью, рейтинг,
+// филиал с координатами (nil — без адреса) и свободные слоты через hours часов
+type listing struct {
+	telegramID  int64
+	name        string
+	service     string
+	description string
+	price       int64
+	currency    string
+	duration    int
+	rating      float64
+	ratingCount int
+	at          *geo.Point
+	hours       []int
+}
+
+var (
+	moscow     = geo.Point{Latitude: 55.7558, Longitude: 37.6173}
+	podolsk    = geo.Point{Latitude: 55.4312, Longitude: 37.5446}
+	petersburg = geo.Point{Latitude: 59.9343, Longitude: 30.3351}
+)
+
+func addListing(t *testing.T, store *deps, l listing) (models.User, models.Service) {
+	t.Helper()
+	m := models.User{
+		Phone: fmt.Sprintf("+7999000%04d", l.telegramID), TelegramID: l.telegramID, FirstName: l.name, Surname: "Иванова",
+		Rating: l.rating, RatingCount: l.ratingCount,
+	}
+	if err := store.Users().Create(&m); err != nil {
+		t.Fatal(err)
+	}
+	svc := addService(t, store, m, l)
+	return m, svc
+}
+
+func addService(t *testing.T, store *deps, m models.User, l listing) models.Service {
+	t.Helper()
+	svc := models.Service{MasterID: m.ID, Name: l.service, Description: l.description, Price: l.price, Currency: money.Normalize(l.currency), Duration: l.duration}
+	if err := store.Services().CreateService(&svc); err != nil {
+		t.Fatal(err)
+	}
+	if l.at != nil {
+		lat, lon := l.at.Latitude, l.at.Longitude
+		loc := models.Location{OrganizationID: store.org.ID, Name: l.name, Address: "Адрес " + l.name, Latitude: &lat, Longitude: &lon, Timezone: "Europe/Moscow"}
+		if err := store.Locations().Create(&loc); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Locations().SetServiceLocation(svc.ID, &loc.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, h := range l.hours {
+		start := time.Now().Add(time.Duration(h) * time.Hour)
+		sl := models.Slot{MasterID: m.ID, ServiceID: svc.ID, StartTime: start, EndTime: start.Add(time.Duration(l.duration) * time.Minute)}
+		if err := store.Slots().Create(&sl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return svc
+}
+
+// catalog — пять мастеров; Светлана отказалась от каталога
+func catalog(t *testing.T) (*deps, *directory.Service) {
+	t.Helper()
+	store, svc := newDirectory(t)
+	// Владелец сети без своих услуг в каталог не попадает
+	owner := models.User{Phone: "+79990001000", TelegramID: 1000, FirstName: "Елена", Surname: "Владелец"}
+	if err := store.Users().Create(&owner); err != nil {
+		t.Fatal(err)
+	}
+	d := &deps{Store: store, org: &models.Organization{Name: "Филиалы"}}
+	if err := store.Organizations().Create(d.org, owner.ID); err != nil {
+		t.Fatal(err)
+	}
+	anna, _ := addListing(t, d, listing{telegramID: 1001, name: "Анна", service: "Стрижка", price: 100000, duration: 60,
+		rating: 4.5, ratingCount: 10, at: &moscow, hours: []int{48, -1}})
+	addService(t, d, anna, listing{name: "Анна", service: "Укладка", price: 80000, duration: 40, at: &moscow, hours: []int{5}})
+	addListing(t, d, listing{telegramID: 1002, name: "Мария", service: "Маникюр", description: "Классический маникюр и покрытие гель-лаком",
+		price: 150000, duration: 90, rating: 4.9, ratingCount: 3, at: &petersburg, hours: []int{1}})
+	addListing(t, d, listing{telegramID: 1003, name: "Ольга", service: "Стрижка бороды", price: 500000, currency: "KZT", duration: 30})
+	addListing(t, d, listing{telegramID: 1004, name: "Ирина", service: "Окрашивание", description: "Стрижка кончиков в подарок",
+		price: 300000, duration: 120, rating: 4.5, ratingCount: 2, at: &podolsk, hours: []int{240}})
+	svetlana, _ := addListing(t, d, listing{telegramID: 1005, name: "Светлана", service: "Стрижка", price: 100000, duration: 60, at: &moscow, hours: []int{3}})
+	if err := store.Users().UpdateDirectoryOptOut(svetlana.ID, true); err != nil {
+		t.Fatal(err)
+	}
+	return d, svc
+}
+
+func TestDiscover(t *testing.T) {
+	_, svc := catalog(t)
+
+	tests := []struct {
+		name string
+		q    models.DirectoryQuery
+		want []string
+	}{
+		{name: "no filters, nearest free slot first", q: models.DirectoryQuery{}, want: []string{"Мария", "Анна", "Ирина", "Ольга"}},
+		{name: "text, title before description", q: models.DirectoryQuery{Text: "стрижка"}, want: []string{"Анна", "Ольга", "Ирина"}},
+		{name: "text in description", q: models.DirectoryQuery{Text: "маникюр гель"}, want: []string{"Мария"}},
+		{name: "master name and service", q: models.DirectoryQuery{Text: "Анна стрижка"}, want: []string{"Анна"}},
+		{name: "price range", q: models.DirectoryQuery{MinPrice: 90000, MaxPrice: 120000}, want: []string{"Анна"}},
+		{name: "currency", q: models.DirectoryQuery{Currency: "kzt"}, want: []string{"Ольга"}},
+		{name: "duration", q: models.DirectoryQuery{MaxDuration: 30}, want: []string{"Ольга"}},
+		{name: "radius", q: models.DirectoryQuery{Near: &moscow, RadiusKm: 50}, want: []string{"Анна", "Ирина"}},
+		{name: "distance, unknown last", q: models.DirectoryQuery{Near: &moscow, Sort: models.DirectorySortDistance}, want: []string{"Анна", "Ирина", "Мария", "Ольга"}},
+		{name: "rating, then count", q: models.DirectoryQuery{Sort: models.DirectorySortRating}, want: []string{"Мария", "Анна", "Ирина", "Ольга"}},
+		{name: "free within 3 days", q: models.DirectoryQuery{FreeWithin: 3 * 24 * time.Hour}, want: []string{"Мария", "Анна"}},
+		{name: "page", q: models.DirectoryQuery{Limit: 2, Offset: 1}, want: []string{"Анна", "Ирина"}},
+		{name: "nothing", q: models.DirectoryQuery{Text: "педикюр"}, want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := svc.Discover(tt.q)
+			if err != nil {
+				t.Fatalf("Discover() error = %v", err)
+			}
+			var got []string
+			for _, c := range cards {
+				got = append(got, c.FirstName)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Fatalf("Discover(%+v) = %v, want %v", tt.q, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiscoverCard(t *testing.T) {
+	_, svc := catalog(t)
+
+	cards, err := svc.Discover(models.DirectoryQuery{Text: "стрижка", Near: &moscow})
+	if err != nil || len(cards) != 3 {
+		t.Fatalf("Discover() = %+v, %v", cards, err)
+	}
+	anna := cards[0]
+	if anna.Rating != 4.5 || anna.RatingCount != 10 {
+		t.Errorf("Rating = %v/%d, want 4.5/10", anna.Rating, anna.RatingCount)
+	}
+	if anna.DistanceKm == nil || *anna.DistanceKm > 0.01 {
+		t.Errorf("DistanceKm = %v, want 0", anna.DistanceKm)
+	}
+	// Только подходящая услуга и её слоты: укладка через 5 часов в карточку не попадает
+	if len(anna.Services) != 1 || anna.Services[0].Name != "Стрижка" {
+		t.Errorf("Services = %+v, want Стрижка", anna.Services)
+	}
+	if len(anna.NextSlots) != 1 || anna.NextSlots[0].ServiceName != "Стрижка" || !anna.NextSlots[0].StartTime.After(time.Now()) {
+		t.Errorf("NextSlots = %+v, want the future slot of Стрижка", anna.NextSlots)
+	}
+	if olga := cards[1]; olga.DistanceKm != nil || len(olga.NextSlots) != 0 {
+		t.Errorf("Ольга: DistanceKm = %v, NextSlots = %d, want no address and no slots", olga.DistanceKm, len(olga.NextSlots))
+	}
+}
+
+func TestDiscoverOptOut(t *testing.T) {
+	d, svc := catalog(t)
+
+	cards, err := svc.Search("Светлана", 0)
+	if err != nil || len(cards) != 0 {
+		t.Fatalf("Search() = %+v, %v, want opted out master hidden", cards, err)
+	}
+	found, err := svc.Discover(models.DirectoryQuery{Text: "Светлана"})
+	if err != nil || len(found) != 0 {
+		t.Fatalf("Discover() = %+v, %v, want opted out master hidden", found, err)
+	}
+
+	svetlana, err := d.Users().FindByTelegramID(1005)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Users().UpdateDirectoryOptOut(svetlana.ID, false); err != nil {
+		t.Fatal(err)
+	}
+	if found, err = svc.Discover(models.DirectoryQuery{Text: "Светлана"}); err != nil || len(found) != 1 {
+		t.Fatalf("Discover() after opt in = %+v, %v", found, err)
+	}
+}
+
+func TestDiscoverValidation(t *testing.T) {
+	_, svc := catalog(t)
+	far := geo.Point{Latitude: 91, Longitude: 0}
+
+	tests := []struct {
+		name string
+		q    models.DirectoryQuery
+		want error
+	}{
+		{name: "negative price", q: models.DirectoryQuery{MinPrice: -1}, want: directory.ErrInvalidFilter},
+		{name: "min above max", q: models.DirectoryQuery{MinDuration: 90, MaxDuration: 60}, want: directory.ErrInvalidFilter},
+		{name: "bad point", q: models.DirectoryQuery{Near: &far}, want: directory.ErrInvalidPoint},
+		{name: "radius without point", q: models.DirectoryQuery{RadiusKm: 10}, want: directory.ErrInvalidRadius},
+		{name: "radius too large", q: models.DirectoryQuery{Near: &moscow, RadiusKm: 501}, want: directory.ErrInvalidRadius},
+		{name: "window too long", q: models.DirectoryQuery{FreeWithin: 61 * 24 * time.Hour}, want: directory.ErrInvalidWindow},
+		{name: "distance without point", q: models.DirectoryQuery{Sort: models.DirectorySortDistance}, want: directory.ErrInvalidSort},
+		{name: "unknown sort", q: models.DirectoryQuery{Sort: "price"}, want: directory.ErrInvalidSort},
+		{name: "unknown currency", q: models.DirectoryQuery{Currency: "XYZ"}, want: directory.ErrInvalidCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := svc.Discover(tt.q); !errors.Is(err, tt.want) {
+				t.Errorf("Discover() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
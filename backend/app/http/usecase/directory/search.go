@@ -0,0 +1,89 @@
+package directory
+
+import (
+	"app/pkg/models"
+	"contract"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// DefaultLimit — мастеров в ответе, если limit не передан
+	DefaultLimit = 10
+	// MaxLimit — не больше 50 результатов в ответе на inline-запрос Telegram, берём с запасом
+	MaxLimit = 20
+	// SlotsPerMaster — ближайших свободных слотов в карточке мастера
+	SlotsPerMaster = 3
+	// maxQueryLength — длиннее имя мастера или название услуги не бывает
+	maxQueryLength = 64
+)
+
+// Search ищет мастеров по имени, фамилии или названию услуги и собирает
+// для каждого карточку с услугами и ближайшими свободными слотами
+func (s *Service) Search(query string, limit int) ([]contract.MasterCard, error) {
+	query = NormalizeQuery(query)
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
+	masters, err := s.repo.SearchMasters(query, limit)
+	if err != nil {
+		s.logger.Errorf("Directory.Search: repo error: %v", err)
+		return nil, err
+	}
+	now := s.now()
+	cards := make([]contract.MasterCard, 0, len(masters))
+	for _, m := range masters {
+		slots, err := s.repo.NextFreeSlots(m.ID, now, SlotsPerMaster)
+		if err != nil {
+			s.logger.Errorf("Directory.Search: slots of master_id=%v: %v", m.ID, err)
+			return nil, err
+		}
+		cards = append(cards, masterCard(m, slots))
+	}
+	s.logger.Infof("Directory.Search: query=%q count=%d", query, len(cards))
+	return cards, nil
+}
+
+// NormalizeQuery убирает лишние пробелы и обрезает запрос до maxQueryLength символов
+func NormalizeQuery(query string) string {
+	query = strings.Join(strings.Fields(query), " ")
+	if utf8.RuneCountInString(query) > maxQueryLength {
+		query = strings.TrimSpace(string([]rune(query)[:maxQueryLength]))
+	}
+	return query
+}
+
+func masterCard(m models.User, slots []models.Slot) contract.MasterCard {
+	card := contract.MasterCard{
+		TelegramID: m.TelegramID,
+		FirstName:  m.FirstName,
+		Surname:    m.Surname,
+		Timezone:   m.Timezone,
+		Services:   make([]contract.Service, 0, len(m.Services)),
+		NextSlots:  make([]contract.SlotResponse, 0, len(slots)),
+	}
+	for _, svc := range m.Services {
+		card.Services = append(card.Services, svc.Contract())
+	}
+	for _, sl := range slots {
+		card.NextSlots = append(card.NextSlots, contract.SlotResponse{
+			ID:               sl.ID,
+			StartTime:        sl.StartTime,
+			EndTime:          sl.EndTime,
+			IsBooked:         sl.IsBooked,
+			ServiceID:        sl.ServiceID,
+			ServiceName:      sl.Service.Name,
+			ServicePrice:     sl.Service.Price,
+			ServiceCurrency:  sl.Service.Currency,
+			ServiceDuration:  sl.Service.Duration,
+			MasterTelegramID: m.TelegramID,
+			MasterName:       m.FirstName,
+			MasterSurname:    m.Surname,
+			MasterTimezone:   m.Timezone,
+		})
+	}
+	return card
+}
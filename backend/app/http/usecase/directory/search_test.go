@@ -0,0 +1,134 @@
+package directory_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/usecase/directory"
+	"app/pkg/models"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var _ directory.Repository = (*memory.DirectoryRepository)(nil)
+
+func newDirectory(t *testing.T) (*memory.Store, *directory.Service) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	store := memory.NewStore()
+	return store, directory.NewService(store.Directory(), logger)
+}
+
+// addMaster создаёт мастера с услугой и свободными слотами через hours часов от текущего момента
+func addMaster(t *testing.T, store *memory.Store, telegramID int64, name, service string, hours ...int) models.User {
+	t.Helper()
+	m := models.User{Phone: fmt.Sprintf("+7999000%04d", telegramID), TelegramID: telegramID, FirstName: name, Surname: "Иванова"}
+	if err := store.Users().Create(&m); err != nil {
+		t.Fatal(err)
+	}
+	svc := models.Service{MasterID: m.ID, Name: service, Price: 1000, Duration: 60}
+	if err := store.Services().CreateService(&svc); err != nil {
+		t.Fatal(err)
+	}
+	for _, h := range hours {
+		start := time.Now().Add(time.Duration(h) * time.Hour)
+		sl := models.Slot{MasterID: m.ID, ServiceID: svc.ID, StartTime: start, EndTime: start.Add(time.Hour)}
+		if err := store.Slots().Create(&sl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return m
+}
+
+func TestSearch(t *testing.T) {
+	store, svc := newDirectory(t)
+	addMaster(t, store, 1001, "Анна", "Стрижка", 48, 2, -1, 5, 24)
+	addMaster(t, store, 1002, "Мария", "Маникюр", 1)
+	addMaster(t, store, 1003, "Ольга", "Стрижка бороды")
+	// Клиент без услуг в поиск не попадает
+	client := models.User{Phone: "+79990000099", TelegramID: 2001, FirstName: "Анна", Surname: "Клиент"}
+	if err := store.Users().Create(&client); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []int64
+	}{
+		{name: "all masters, nearest free slot first", query: "", want: []int64{1002, 1001, 1003}},
+		{name: "by service, case insensitive", query: "  СТРИЖКА ", want: []int64{1001, 1003}},
+		{name: "by name", query: "мария", want: []int64{1002}},
+		{name: "by full name", query: "Анна Иванова", want: []int64{1001}},
+		{name: "limit", query: "", limit: 1, want: []int64{1002}},
+		{name: "nothing", query: "педикюр", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := svc.Search(tt.query, tt.limit)
+			if err != nil {
+				t.Fatalf("Search() error = %v", err)
+			}
+			var got []int64
+			for _, c := range cards {
+				got = append(got, c.TelegramID)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Search(%q) = %v, want %v", tt.query, got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchCard(t *testing.T) {
+	store, svc := newDirectory(t)
+	addMaster(t, store, 1001, "Анна", "Стрижка", 48, 2, -1, 5, 24)
+
+	cards, err := svc.Search("анна", 0)
+	if err != nil || len(cards) != 1 {
+		t.Fatalf("Search() = %+v, %v", cards, err)
+	}
+	card := cards[0]
+	if len(card.Services) != 1 || card.Services[0].Name != "Стрижка" {
+		t.Errorf("Services = %+v, want Стрижка", card.Services)
+	}
+	if len(card.NextSlots) != directory.SlotsPerMaster {
+		t.Fatalf("NextSlots = %d, want %d", len(card.NextSlots), directory.SlotsPerMaster)
+	}
+	for i, sl := range card.NextSlots {
+		if !sl.StartTime.After(time.Now()) || sl.IsBooked || sl.ServiceName != "Стрижка" || sl.MasterTelegramID != 1001 {
+			t.Errorf("NextSlots[%d] = %+v, want a future free slot of the master", i, sl)
+		}
+		if i > 0 && sl.StartTime.Before(card.NextSlots[i-1].StartTime) {
+			t.Errorf("NextSlots not sorted: %v before %v", card.NextSlots[i-1].StartTime, sl.StartTime)
+		}
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	long := ""
+	for i := 0; i < 100; i++ {
+		long += "я"
+	}
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"  Анна   Иванова ", "Анна Иванова"},
+		{long, long[:64*len("я")]},
+	}
+	for _, tt := range tests {
+		if got := directory.NormalizeQuery(tt.in); got != tt.want {
+			t.Errorf("NormalizeQuery(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package directory
+
+import (
+	"app/pkg/models"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Repository — поиск мастеров и их свободных слотов
+type Repository interface {
+	SearchMasters(query string, limit int) ([]models.User, error)
+	NextFreeSlots(masterID uuid.UUID, from time.Time, limit int) ([]models.Slot, error)
+	// Discover — публичный каталог; пустой q.Sort — по ближайшему свободному слоту
+	Discover(q models.DirectoryQuery) ([]models.DirectoryHit, error)
+	// NextServiceSlots — до limit ближайших свободных слотов услуг serviceIDs после from
+	NextServiceSlots(serviceIDs []uint, from time.Time, limit int) ([]models.Slot, error)
+}
+
+type Service struct {
+	repo   Repository
+	logger *logrus.Logger
+	now    func() time.Time
+}
+
+func NewService(repo Repository, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
@@ -0,0 +1,61 @@
+package location
+
+import (
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"fmt"
+	"time"
+)
+
+// PrepareSlot привязывает новый слот к месту его услуги и проверяет,
+// что слот целиком попадает в часы работы места (в таймзоне места).
+// Место из тела запроса не учитывается
+func (s *Service) PrepareSlot(slot *models.Slot) error {
+	slot.LocationID = nil
+	l, err := s.repo.FindServiceLocation(slot.ServiceID)
+	if err != nil {
+		s.logger.Errorf("Location.PrepareSlot: repo error: %v", err)
+		return err
+	}
+	if l == nil {
+		return nil
+	}
+	if !open(*l, slot.StartTime, slot.EndTime) {
+		return fmt.Errorf("%w: %s", ErrOutsideHours, l.Name)
+	}
+	slot.LocationID = &l.ID
+	return nil
+}
+
+// open сообщает, что [start, end) попадает в один интервал работы места
+// в день недели начала. Пустое расписание — без ограничений
+func open(l models.Location, start, end time.Time) bool {
+	if len(l.WorkingHours) == 0 {
+		return true
+	}
+	zone := timefmt.Location(nil, l.Timezone)
+	from, to := start.In(zone), end.In(zone)
+	midnight := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, zone)
+	if !to.After(from) || to.Sub(midnight) > 24*time.Hour {
+		return false
+	}
+	first := from.Hour()*60 + from.Minute()
+	last := int(to.Sub(midnight).Round(time.Minute) / time.Minute)
+	for _, h := range l.WorkingHours {
+		opens, _ := minutes(h.Opens)
+		closes, _ := minutes(h.Closes)
+		if h.Weekday == int(from.Weekday()) && opens <= first && last <= closes {
+			return true
+		}
+	}
+	return false
+}
+
+// minutes разбирает "HH:MM" в минуты от начала суток
+func minutes(v string) (int, bool) {
+	t, err := time.Parse("15:04", v)
+	if err != nil {
+		return 0, false
+	}
+	return t.Hour()*60 + t.Minute(), true
+}
@@ -0,0 +1,150 @@
+package location
+
+import (
+	"app/http/usecase/organization"
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"contract"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// List возвращает места организации (любому участнику)
+func (s *Service) List(orgID, userID uuid.UUID) ([]models.Location, error) {
+	if _, err := organization.RequireMember(s.orgs, orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByOrganization(orgID)
+}
+
+// Create добавляет место организации (владелец или управляющий)
+func (s *Service) Create(orgID, actorID uuid.UUID, req contract.LocationRequest) (*models.Location, error) {
+	l, err := build(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := organization.RequireManager(s.orgs, orgID, actorID); err != nil {
+		return nil, err
+	}
+	l.OrganizationID = orgID
+	if err := s.repo.Create(l); err != nil {
+		s.logger.Errorf("Location.Create: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Location.Create: org_id=%s location_id=%d", orgID, l.ID)
+	return l, nil
+}
+
+// Update заменяет данные места; уже созданные слоты остаются в нём
+func (s *Service) Update(orgID, actorID uuid.UUID, id uint, req contract.LocationRequest) (*models.Location, error) {
+	l, err := build(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := organization.RequireManager(s.orgs, orgID, actorID); err != nil {
+		return nil, err
+	}
+	existing, err := s.find(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	l.ID, l.OrganizationID, l.CreatedAt = existing.ID, existing.OrganizationID, existing.CreatedAt
+	if err := s.repo.Update(l); err != nil {
+		s.logger.Errorf("Location.Update: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Location.Update: org_id=%s location_id=%d", orgID, id)
+	return l, nil
+}
+
+// Delete удаляет место; услуги и слоты остаются без места
+func (s *Service) Delete(orgID, actorID uuid.UUID, id uint) error {
+	if _, err := organization.RequireManager(s.orgs, orgID, actorID); err != nil {
+		return err
+	}
+	if _, err := s.find(orgID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(orgID, id); err != nil {
+		s.logger.Errorf("Location.Delete: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Location.Delete: org_id=%s location_id=%d", orgID, id)
+	return nil
+}
+
+// SetServiceLocation задаёт место услуги организации; locationID == 0 — убрать место.
+// Место получают только новые слоты услуги
+func (s *Service) SetServiceLocation(orgID, actorID uuid.UUID, serviceID, locationID uint) error {
+	if _, err := organization.RequireManager(s.orgs, orgID, actorID); err != nil {
+		return err
+	}
+	if _, err := s.orgs.FindService(orgID, serviceID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrServiceNotFound
+	} else if err != nil {
+		return err
+	}
+	var target *uint
+	if locationID != 0 {
+		if _, err := s.find(orgID, locationID); err != nil {
+			return err
+		}
+		target = &locationID
+	}
+	if err := s.repo.SetServiceLocation(serviceID, target); err != nil {
+		s.logger.Errorf("Location.SetServiceLocation: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Location.SetServiceLocation: org_id=%s service_id=%d location_id=%d", orgID, serviceID, locationID)
+	return nil
+}
+
+func (s *Service) find(orgID uuid.UUID, id uint) (*models.Location, error) {
+	l, err := s.repo.FindByID(orgID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrLocationNotFound
+	}
+	return l, err
+}
+
+// build проверяет запрос и собирает место
+func build(req contract.LocationRequest) (*models.Location, error) {
+	name, address := strings.TrimSpace(req.Name), strings.TrimSpace(req.Address)
+	if n := utf8.RuneCountInString(name); n < 1 || n > 100 {
+		return nil, ErrInvalidLocation
+	}
+	if n := utf8.RuneCountInString(address); n < 1 || n > 300 {
+		return nil, ErrInvalidLocation
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return nil, ErrInvalidCoordinates
+	}
+	if req.Latitude != nil && (*req.Latitude < -90 || *req.Latitude > 90 || *req.Longitude < -180 || *req.Longitude > 180) {
+		return nil, ErrInvalidCoordinates
+	}
+	tz := strings.TrimSpace(req.Timezone)
+	if tz == "" || !timefmt.Valid(tz) {
+		return nil, ErrInvalidTimezone
+	}
+	hours := make([]models.WorkingHours, 0, len(req.WorkingHours))
+	for _, h := range req.WorkingHours {
+		opens, ok1 := minutes(h.Opens)
+		closes, ok2 := minutes(h.Closes)
+		if h.Weekday < 0 || h.Weekday > 6 || !ok1 || !ok2 || opens >= closes {
+			return nil, ErrInvalidHours
+		}
+		hours = append(hours, h)
+	}
+	return &models.Location{
+		Name:         name,
+		Address:      address,
+		Latitude:     req.Latitude,
+		Longitude:    req.Longitude,
+		Timezone:     tz,
+		WorkingHours: hours,
+	}, nil
+}
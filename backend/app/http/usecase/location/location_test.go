@@ -0,0 +1,249 @@
+package location_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/location"
+	"app/http/usecase/notification"
+	"app/http/usecase/organization"
+	"app/http/usecase/record"
+	"app/http/usecase/slot"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	_ location.Repository    = (*memory.LocationRepository)(nil)
+	_ location.Organizations = (*memory.OrganizationRepository)(nil)
+)
+
+// fixture — салон с владельцем и мастером memtest; услуга мастера проходит в филиале на Тверской
+type fixture struct {
+	*memtest.Fixture
+	svc     *location.Service
+	orgs    *organization.Service
+	records *record.Service
+	slots   *slot.Service
+	org     *models.Organization
+	owner   models.User
+	service *models.Service
+	branch  *models.Location
+}
+
+// day — вторник; филиал работает по вторникам 10:00–18:00 по Москве (07:00–15:00 UTC)
+var day = time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+
+func branchRequest() contract.LocationRequest {
+	lat, lon := 55.764, 37.605
+	return contract.LocationRequest{
+		Name:         " Филиал на Тверской ",
+		Address:      "Москва, Тверская ул., 10",
+		Latitude:     &lat,
+		Longitude:    &lon,
+		Timezone:     "Europe/Moscow",
+		WorkingHours: []contract.WorkingHours{{Weekday: 2, Opens: "10:00", Closes: "18:00"}},
+	}
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	f.svc = location.NewService(f.Store.Locations(), f.Store.Organizations(), f.Logger)
+	f.records = record.NewService(f.Store.Records(), notification.NewService(f.Store.Notifications(), f.Logger), f.Logger).
+		WithSender(f.TG)
+	f.slots = slot.NewService(f.Store.Slots(), f.Logger).WithLocations(f.svc)
+	f.orgs = organization.NewService(f.Store.Organizations(), f.Store.Users(), f.records, f.Logger)
+
+	f.owner = f.User(t, models.User{TelegramID: 1002, FirstName: "Владелец"})
+	org, err := f.orgs.Create(f.owner.ID, "Салон")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.org = org
+	if _, err := f.orgs.AddMember(org.ID, f.owner.ID, contract.OrganizationMemberAdd{TelegramID: f.Master.TelegramID, Role: models.OrgRoleMaster}); err != nil {
+		t.Fatal(err)
+	}
+	f.service, err = f.orgs.CreateService(org.ID, f.owner.ID, contract.OrganizationService{MasterID: f.Master.ID, Name: "Стрижка", Price: 150000, Duration: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.branch, err = f.svc.Create(org.ID, f.owner.ID, branchRequest())
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := f.svc.SetServiceLocation(org.ID, f.owner.ID, f.service.ID, f.branch.ID); err != nil {
+		t.Fatalf("SetServiceLocation() error = %v", err)
+	}
+	return f
+}
+
+// slotAt создаёт через usecase слот мастера на [day+hour, +minutes) UTC
+func (f *fixture) slotAt(hour, minutes int) (models.Slot, error) {
+	start := day.Add(time.Duration(hour) * time.Hour)
+	sl := models.Slot{MasterID: f.Master.ID, ServiceID: f.service.ID, StartTime: start, EndTime: start.Add(time.Duration(minutes) * time.Minute)}
+	err := f.slots.CreateSlot(&sl)
+	return sl, err
+}
+
+func TestManageLocations(t *testing.T) {
+	f := newFixture(t)
+	if f.branch.Name != "Филиал на Тверской" || f.branch.Line() != "Филиал на Тверской, Москва, Тверская ул., 10" {
+		t.Fatalf("Create() = %+v, want trimmed name and address line", f.branch)
+	}
+
+	for name, tc := range map[string]struct {
+		edit func(*contract.LocationRequest)
+		want error
+	}{
+		"no address":      {func(r *contract.LocationRequest) { r.Address = " " }, location.ErrInvalidLocation},
+		"half of coords":  {func(r *contract.LocationRequest) { r.Longitude = nil }, location.ErrInvalidCoordinates},
+		"latitude range":  {func(r *contract.LocationRequest) { lat := 91.0; r.Latitude = &lat }, location.ErrInvalidCoordinates},
+		"local timezone":  {func(r *contract.LocationRequest) { r.Timezone = "Local" }, location.ErrInvalidTimezone},
+		"closes too soon": {func(r *contract.LocationRequest) { r.WorkingHours[0].Closes = "09:00" }, location.ErrInvalidHours},
+		"bad weekday":     {func(r *contract.LocationRequest) { r.WorkingHours[0].Weekday = 7 }, location.ErrInvalidHours},
+	} {
+		req := branchRequest()
+		tc.edit(&req)
+		if _, err := f.svc.Create(f.org.ID, f.owner.ID, req); !errors.Is(err, tc.want) {
+			t.Errorf("%s: Create() error = %v, want %v", name, err, tc.want)
+		}
+	}
+
+	if _, err := f.svc.Create(f.org.ID, f.Master.ID, branchRequest()); !errors.Is(err, organization.ErrForbidden) {
+		t.Fatalf("Create() by master error = %v, want ErrForbidden", err)
+	}
+	if list, err := f.svc.List(f.org.ID, f.Master.ID); err != nil || len(list) != 1 {
+		t.Fatalf("List() by master = %v, %v", list, err)
+	}
+	if _, err := f.svc.List(f.org.ID, f.Clients[0].ID); !errors.Is(err, organization.ErrNotMember) {
+		t.Fatalf("List() by outsider error = %v, want ErrNotMember", err)
+	}
+
+	req := branchRequest()
+	req.Latitude, req.Longitude = nil, nil
+	updated, err := f.svc.Update(f.org.ID, f.owner.ID, f.branch.ID, req)
+	if err != nil || updated.Latitude != nil || updated.CreatedAt != f.branch.CreatedAt {
+		t.Fatalf("Update() = %+v, %v", updated, err)
+	}
+	if _, ok := updated.Venue(); ok {
+		t.Fatal("Venue() without coordinates should be false")
+	}
+
+	// Место другой организации услуге не назначить
+	other, err := f.orgs.Create(f.owner.ID, "Другой салон")
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := f.svc.Create(other.ID, f.owner.ID, branchRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.SetServiceLocation(f.org.ID, f.owner.ID, f.service.ID, foreign.ID); !errors.Is(err, location.ErrLocationNotFound) {
+		t.Fatalf("SetServiceLocation() with foreign location error = %v", err)
+	}
+	if err := f.svc.SetServiceLocation(f.org.ID, f.owner.ID, 999, f.branch.ID); !errors.Is(err, location.ErrServiceNotFound) {
+		t.Fatalf("SetServiceLocation() for unknown service error = %v", err)
+	}
+
+	sl, err := f.slotAt(8, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.Delete(f.org.ID, f.owner.ID, f.branch.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if got, _ := f.Store.Records().GetSlotByIDWithDetails(sl.ID); got.LocationID != nil || got.Address() != "" {
+		t.Fatalf("slot after location delete = %+v, want no location", got)
+	}
+	if svc, _ := f.Store.Organizations().FindService(f.org.ID, f.service.ID); svc == nil || svc.LocationID != nil {
+		t.Fatalf("service after location delete = %+v, want no location", svc)
+	}
+	if err := f.svc.Delete(f.org.ID, f.owner.ID, f.branch.ID); !errors.Is(err, location.ErrLocationNotFound) {
+		t.Fatalf("Delete() twice error = %v, want ErrLocationNotFound", err)
+	}
+}
+
+func TestSlotWorkingHours(t *testing.T) {
+	f := newFixture(t)
+
+	for name, tc := range map[string]struct {
+		hour, minutes int
+		ok            bool
+	}{
+		"at opening":       {7, 60, true},
+		"until closing":    {14, 60, true},
+		"before opening":   {6, 60, false},
+		"past closing":     {14, 90, false},
+		"wednesday":        {7 + 24, 60, false},
+		"across midnight":  {20, 8 * 60, false},
+		"whole work day":   {7, 8 * 60, true},
+		"one minute later": {7, 8*60 + 1, false},
+	} {
+		sl, err := f.slotAt(tc.hour, tc.minutes)
+		if tc.ok && (err != nil || sl.LocationID == nil || *sl.LocationID != f.branch.ID) {
+			t.Errorf("%s: CreateSlot() = %+v, %v, want slot at the branch", name, sl, err)
+		}
+		if !tc.ok && !errors.Is(err, location.ErrOutsideHours) {
+			t.Errorf("%s: CreateSlot() error = %v, want ErrOutsideHours", name, err)
+		}
+	}
+
+	// Место слота берётся только из услуги
+	if err := f.svc.SetServiceLocation(f.org.ID, f.owner.ID, f.service.ID, 0); err != nil {
+		t.Fatal(err)
+	}
+	start := day.Add(3 * time.Hour)
+	sl := models.Slot{MasterID: f.Master.ID, ServiceID: f.service.ID, StartTime: start, EndTime: start.Add(time.Hour), LocationID: &f.branch.ID}
+	if err := f.slots.CreateSlot(&sl); err != nil || sl.LocationID != nil {
+		t.Fatalf("CreateSlot() without service location = %+v, %v", sl, err)
+	}
+}
+
+func TestConfirmationAddressAndVenue(t *testing.T) {
+	f := newFixture(t)
+	sl, err := f.slotAt(8, 60)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := f.Book(t, f.records, f.Clients[0], sl)
+	if err := f.records.ConfirmRecord(rec.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	var texts []string
+	var venue *memory.TelegramMessage
+	for _, m := range f.TG.Messages() {
+		if m.Kind == "venue" {
+			m := m
+			venue = &m
+			continue
+		}
+		texts = append(texts, m.Message)
+	}
+	if len(texts) != 2 {
+		t.Fatalf("sent %d texts, want to master and to client", len(texts))
+	}
+	for _, text := range texts {
+		if !strings.Contains(text, "Адрес: Филиал на Тверской, Москва, Тверская ул., 10") {
+			t.Errorf("message without address: %q", text)
+		}
+	}
+	if venue == nil || venue.TelegramID != f.Clients[0].TelegramID || venue.Venue.Latitude != 55.764 || venue.Venue.Address != "Москва, Тверская ул., 10" {
+		t.Fatalf("venue = %+v, want branch sent to client", venue)
+	}
+
+	ics, err := f.records.CalendarByClient(rec.ID, f.Clients[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unfolded := strings.ReplaceAll(string(ics), "\r\n ", "")
+	for _, want := range []string{`LOCATION:Филиал на Тверской\, Москва\, Тверская ул.\, 10`, "GEO:55.764000;37.605000"} {
+		if !strings.Contains(unfolded, want) {
+			t.Errorf("CalendarByClient() missing %q in\n%s", want, ics)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package location
+
+import (
+	"app/pkg/models"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrLocationNotFound   = errors.New("location not found in the organization")
+	ErrInvalidLocation    = errors.New("location needs a name of 1..100 and an address of 1..300 characters")
+	ErrInvalidCoordinates = errors.New("latitude and longitude must be set together within -90..90 and -180..180")
+	ErrInvalidTimezone    = errors.New("timezone must be an IANA name, e.g. Europe/Moscow")
+	ErrInvalidHours       = errors.New("working hours need a weekday 0..6 and opens before closes in HH:MM")
+	ErrServiceNotFound    = errors.New("service not found in the organization")
+	ErrOutsideHours       = errors.New("slot is outside the working hours of the location")
+)
+
+// Repository — места организаций и их привязка к услугам
+type Repository interface {
+	Create(l *models.Location) error
+	FindByID(orgID uuid.UUID, id uint) (*models.Location, error)
+	FindByOrganization(orgID uuid.UUID) ([]models.Location, error)
+	Update(l *models.Location) error
+	Delete(orgID uuid.UUID, id uint) error
+
+	SetServiceLocation(serviceID uint, locationID *uint) error
+	// FindServiceLocation — место услуги; nil, nil — место не задано
+	FindServiceLocation(serviceID uint) (*models.Location, error)
+}
+
+// Organizations — участники и услуги организации (repository/organization)
+type Organizations interface {
+	FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	FindService(orgID uuid.UUID, serviceID uint) (*models.Service, error)
+}
+
+type Service struct {
+	repo   Repository
+	orgs   Organizations
+	logger *logrus.Logger
+}
+
+func NewService(repo Repository, orgs Organizations, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		orgs:   orgs,
+		logger: logger,
+	}
+}
@@ -1,7 +1,10 @@
 package notification
 
 import (
+	"app/pkg/locale"
 	"app/pkg/models"
+	"app/pkg/timefmt"
+	"contract/money"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -12,7 +15,23 @@ import (
 
 type NotificationFactory struct{}
 
+// Тексты уведомлений на сайте; язык — из профиля получателя
+var (
+	textRecordCreatedTitle = locale.Text{RU: "Новая запись от клиента", EN: "New booking from a client"}
+	textRecordCreated      = locale.Text{
+		RU: "Клиент %s %s записался на услугу \"%s\" (%s)\nВремя: %s",
+		EN: "Client %s %s booked \"%s\" (%s)\nTime: %s",
+	}
+	textRecordStatus = locale.Text{
+		RU: "%s\n\nУслуга: %s (%s)\nМастер: %s %s\nВремя: %s",
+		EN: "%s\n\nService: %s (%s)\nMaster: %s %s\nTime: %s",
+	}
+	textAddress = locale.Text{RU: "\nАдрес: %s", EN: "\nAddress: %s"}
+)
+
 func (f *NotificationFactory) CreateRecordCreated(masterID uuid.UUID, record *models.Record, firstName, surname string, slot *models.Slot, service *models.Service, master *models.User) *models.Notification {
+	// Получатель — мастер: время в его таймзоне
+	loc := timefmt.Location(nil, master.Timezone)
 	metaData := map[string]interface{}{
 		"record_id":     record.ID,
 		"slot_id":       record.SlotID,
@@ -22,16 +41,20 @@ func (f *NotificationFactory) CreateRecordCreated(masterID uuid.UUID, record *mo
 		"master_name":   fmt.Sprintf("%s %s", master.FirstName, master.Surname),
 		"service_id":    service.ID,
 		"service_name":  service.Name,
-		"service_price": service.Price,
-		"slot_start":    slot.StartTime.Format("02.01.2006 15:04"),
-		"slot_end":      slot.EndTime.Format("02.01.2006 15:04"),
+		"service_price": record.Price,
+		"currency":      money.Normalize(record.Currency),
+		"slot_start":    slot.StartTime.In(loc).Format("02.01.2006 15:04"),
+		"slot_end":      slot.EndTime.In(loc).Format("02.01.2006 15:04"),
+		"timezone":      loc.String(),
 		"action_url":    fmt.Sprintf("records/%d", record.ID),
 	}
 
-	title := "Новая запись от клиента"
-	message := fmt.Sprintf("Клиент %s %s записался на услугу \"%s\" (%s руб.)\nВремя: %s - %s",
-		firstName, surname, service.Name, fmt.Sprintf("%.0f", service.Price),
-		slot.StartTime.Format("02.01.2006 15:04"), slot.EndTime.Format("15:04"))
+	lang := master.Language
+	title := textRecordCreatedTitle.In(lang)
+	message := fmt.Sprintf(textRecordCreated.In(lang),
+		firstName, surname, service.Name, locale.Money(record.Price, record.Currency, lang),
+		timefmt.Span(slot.StartTime, slot.EndTime, loc, loc))
+	message = f.withAddress(message, lang, metaData, slot)
 
 	return &models.Notification{
 		UserID:    masterID,
@@ -45,14 +68,28 @@ func (f *NotificationFactory) CreateRecordCreated(masterID uuid.UUID, record *mo
 
 func (f *NotificationFactory) CreateRecordStatus(clientID uuid.UUID, record *models.Record, status string, slot *models.Slot, service *models.Service, master *models.User) *models.Notification {
 	configs := map[string]struct {
-		title     string
-		message   string
+		title     locale.Text
+		message   locale.Text
 		notifType string
 	}{
-		"confirm": {"Запись подтверждена ✅", "Мастер подтвердил вашу запись", "RECORD_CONFIRMED"},
-		"reject":  {"Запись отклонена ❌", "Мастер отклонил вашу запись", "RECORD_REJECTED"},
+		"confirm": {
+			locale.Text{RU: "Запись подтверждена ✅", EN: "Booking confirmed ✅"},
+			locale.Text{RU: "Мастер подтвердил вашу запись", EN: "The master confirmed your booking"},
+			"RECORD_CONFIRMED",
+		},
+		"reject": {
+			locale.Text{RU: "Запись отклонена ❌", EN: "Booking rejected ❌"},
+			locale.Text{RU: "Мастер отклонил вашу запись", EN: "The master rejected your booking"},
+			"RECORD_REJECTED",
+		},
 	}
 	config := configs[status]
+	lang := record.Client.Language
+
+	// Получатель — клиент: время в его таймзоне (record.Client загружен с деталями записи),
+	// время мастера — вторым
+	masterLoc := timefmt.Location(nil, master.Timezone)
+	loc := timefmt.Location(masterLoc, record.Client.Timezone)
 
 	metadata := map[string]interface{}{
 		"record_id":     record.ID,
@@ -62,28 +99,42 @@ func (f *NotificationFactory) CreateRecordStatus(clientID uuid.UUID, record *mod
 		"master_name":   fmt.Sprintf("%s %s", master.FirstName, master.Surname),
 		"service_id":    service.ID,
 		"service_name":  service.Name,
-		"service_price": service.Price,
-		"slot_start":    slot.StartTime.Format("02.01.2006 15:04"),
-		"slot_end":      slot.EndTime.Format("02.01.2006 15:04"),
+		"service_price": record.Price,
+		"currency":      money.Normalize(record.Currency),
+		"slot_start":    slot.StartTime.In(loc).Format("02.01.2006 15:04"),
+		"slot_end":      slot.EndTime.In(loc).Format("02.01.2006 15:04"),
+		"timezone":      loc.String(),
 		"action_url":    fmt.Sprintf("/my-records/%d", record.ID),
 	}
 
 	// Создаем подробное сообщение
-	message := fmt.Sprintf("%s\n\nУслуга: %s (%s руб.)\nМастер: %s %s\nВремя: %s - %s",
-		config.message, service.Name, fmt.Sprintf("%.0f", service.Price),
+	message := fmt.Sprintf(textRecordStatus.In(lang),
+		config.message.In(lang), service.Name, locale.Money(record.Price, record.Currency, lang),
 		master.FirstName, master.Surname,
-		slot.StartTime.Format("02.01.2006 15:04"), slot.EndTime.Format("15:04"))
+		timefmt.SpanIn(lang, slot.StartTime, slot.EndTime, loc, masterLoc))
+	message = f.withAddress(message, lang, metadata, slot)
 
 	return &models.Notification{
 		UserID:    clientID,
 		Type:      config.notifType,
-		Title:     config.title,
+		Title:     config.title.In(lang),
 		Message:   message,
 		Metadata:  f.toJSON(metadata),
 		ExpiresAt: f.expiresIn(15 * 24 * time.Hour),
 	}
 }
 
+// withAddress добавляет адрес места слота в сообщение на языке lang и в метаданные (address, location_id)
+func (f *NotificationFactory) withAddress(message, lang string, metadata map[string]interface{}, slot *models.Slot) string {
+	address := slot.Address()
+	if address == "" {
+		return message
+	}
+	metadata["address"] = address
+	metadata["location_id"] = slot.Location.ID
+	return message + fmt.Sprintf(textAddress.In(lang), address)
+}
+
 func (f *NotificationFactory) toJSON(data map[string]interface{}) datatypes.JSON {
 	jsonData, _ := json.Marshal(data)
 	return datatypes.JSON(jsonData)
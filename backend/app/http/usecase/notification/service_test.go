@@ -0,0 +1,97 @@
+package notification_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/usecase/notification"
+	"app/pkg/models"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var _ notification.Repository = (*memory.NotificationRepository)(nil)
+
+func newService() *notification.Service {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return notification.NewService(memory.NewStore().Notifications(), logger)
+}
+
+func TestRecordStatusNotification(t *testing.T) {
+	master := &models.User{ID: uuid.New(), FirstName: "Анна", Surname: "Иванова"}
+	service := &models.Service{ID: 7, Name: "Стрижка", Price: 1500}
+	start := time.Date(2030, 1, 1, 10, 0, 0, 0, time.UTC)
+	slot := &models.Slot{ID: 3, StartTime: start, EndTime: start.Add(time.Hour)}
+
+	tests := []struct {
+		status    string
+		wantType  string
+		wantTitle string
+	}{
+		{status: "confirm", wantType: "RECORD_CONFIRMED", wantTitle: "Запись подтверждена ✅"},
+		{status: "reject", wantType: "RECORD_REJECTED", wantTitle: "Запись отклонена ❌"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			svc := newService()
+			clientID := uuid.New()
+			rec := &models.Record{ID: 11, SlotID: slot.ID, ClientID: clientID}
+			if err := svc.CreateRecordStatusNotification(clientID, rec, tt.status, slot, service, master); err != nil {
+				t.Fatal(err)
+			}
+			list, err := svc.GetUserNotifications(clientID)
+			if err != nil || len(list) != 1 {
+				t.Fatalf("GetUserNotifications() = %v, %v", list, err)
+			}
+			n := list[0]
+			if n.Type != tt.wantType || n.Title != tt.wantTitle {
+				t.Errorf("notification = %s %q, want %s %q", n.Type, n.Title, tt.wantType, tt.wantTitle)
+			}
+			var meta map[string]interface{}
+			if err := json.Unmarshal(n.Metadata, &meta); err != nil {
+				t.Fatal(err)
+			}
+			if meta["status"] != tt.status || meta["record_id"] != float64(11) {
+				t.Errorf("metadata = %v", meta)
+			}
+		})
+	}
+}
+
+func TestReadState(t *testing.T) {
+	svc := newService()
+	user, stranger := uuid.New(), uuid.New()
+	for i := 0; i < 3; i++ {
+		if err := svc.CreateGeneric(user, "SYSTEM_MESSAGE", "title", "message", map[string]interface{}{"n": i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	list, _ := svc.GetUserNotifications(user)
+
+	steps := []struct {
+		name       string
+		act        func() error
+		wantErr    bool
+		wantUnread int64
+	}{
+		{name: "initial", act: func() error { return nil }, wantUnread: 3},
+		{name: "mark one read", act: func() error { return svc.MarkIsReadNotification(list[0].ID, user, true) }, wantUnread: 2},
+		{name: "stranger cannot mark", act: func() error { return svc.MarkIsReadNotification(list[1].ID, stranger, true) }, wantErr: true, wantUnread: 2},
+		{name: "mark unread again", act: func() error { return svc.MarkIsReadNotification(list[0].ID, user, false) }, wantUnread: 3},
+		{name: "mark all read", act: func() error { return svc.MarkAllReadNotifications(user) }, wantUnread: 0},
+	}
+	for _, st := range steps {
+		err := st.act()
+		if (err != nil) != st.wantErr {
+			t.Fatalf("%s: error = %v, wantErr %v", st.name, err, st.wantErr)
+		}
+		got, err := svc.CountUserNotifications(user)
+		if err != nil || got != st.wantUnread {
+			t.Fatalf("%s: unread = %d, %v, want %d", st.name, got, err, st.wantUnread)
+		}
+	}
+}
@@ -1,20 +1,28 @@
 package notification
 
 import (
-	"app/http/repository/notification"
 	"app/pkg/models"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// Repository — хранилище уведомлений на сайте
+type Repository interface {
+	Create(notification *models.Notification) error
+	FindUserNotifications(userID uuid.UUID) ([]models.Notification, error)
+	CountUnreadUserNotifications(userID uuid.UUID) (int64, error)
+	MarkIsReadNotification(id uint, userID uuid.UUID, isRead bool) error
+	MarkReadAllNotifications(userID uuid.UUID) error
+}
+
 type Service struct {
-	repo    *notification.Repository
+	repo    Repository
 	factory *NotificationFactory
 	logger  *logrus.Logger
 }
 
-func NewService(repo *notification.Repository, logger *logrus.Logger) *Service {
+func NewService(repo Repository, logger *logrus.Logger) *Service {
 	return &Service{
 		repo:    repo,
 		factory: &NotificationFactory{},
@@ -0,0 +1,134 @@
+package organization
+
+import (
+	"app/pkg/models"
+	"app/pkg/phone"
+	"contract"
+	"errors"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// AddMember добавляет пользователя (по телефону или telegram_id) в организацию с ролью req.Role
+func (s *Service) AddMember(orgID, actorID uuid.UUID, req contract.OrganizationMemberAdd) (*models.OrganizationMember, error) {
+	if !ValidRole(req.Role) {
+		return nil, ErrInvalidRole
+	}
+	actor, err := s.manager(orgID, actorID)
+	if err != nil {
+		return nil, err
+	}
+	if !canGrant(actor.Role, req.Role) {
+		return nil, ErrForbidden
+	}
+	user, err := s.findUser(req)
+	if err != nil {
+		return nil, err
+	}
+	m := &models.OrganizationMember{OrganizationID: orgID, UserID: user.ID, Role: req.Role}
+	if err := s.repo.AddMember(m); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrAlreadyMember
+		}
+		s.logger.Errorf("Organization.AddMember: repo error: %v", err)
+		return nil, err
+	}
+	m.User = *user
+	s.logger.Infof("Organization.AddMember: org_id=%s user_id=%s role=%s by %s", orgID, user.ID, req.Role, actorID)
+	return m, nil
+}
+
+// UpdateMemberRole меняет роль участника; последнего владельца понизить нельзя
+func (s *Service) UpdateMemberRole(orgID, actorID, userID uuid.UUID, role string) error {
+	if !ValidRole(role) {
+		return ErrInvalidRole
+	}
+	actor, err := s.manager(orgID, actorID)
+	if err != nil {
+		return err
+	}
+	target, err := s.member(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !canGrant(actor.Role, target.Role) || !canGrant(actor.Role, role) {
+		return ErrForbidden
+	}
+	if target.Role == models.OrgRoleOwner && role != models.OrgRoleOwner {
+		if err := s.keepOwner(orgID); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.UpdateMemberRole(orgID, userID, role); err != nil {
+		s.logger.Errorf("Organization.UpdateMemberRole: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Organization.UpdateMemberRole: org_id=%s user_id=%s %s -> %s by %s", orgID, userID, target.Role, role, actorID)
+	return nil
+}
+
+// RemoveMember исключает участника; любой участник может выйти сам, кроме последнего владельца.
+// Услуги, назначенные участнику, остаются у организации.
+func (s *Service) RemoveMember(orgID, actorID, userID uuid.UUID) error {
+	target, err := s.member(orgID, userID)
+	if err != nil {
+		return err
+	}
+	if actorID != userID {
+		actor, err := s.manager(orgID, actorID)
+		if err != nil {
+			return err
+		}
+		if !canGrant(actor.Role, target.Role) {
+			return ErrForbidden
+		}
+	}
+	if target.Role == models.OrgRoleOwner {
+		if err := s.keepOwner(orgID); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.RemoveMember(orgID, userID); err != nil {
+		s.logger.Errorf("Organization.RemoveMember: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Organization.RemoveMember: org_id=%s user_id=%s by %s", orgID, userID, actorID)
+	return nil
+}
+
+// keepOwner не даёт убрать последнего владельца организации
+func (s *Service) keepOwner(orgID uuid.UUID) error {
+	owners, err := s.repo.CountOwners(orgID)
+	if err != nil {
+		s.logger.Errorf("Organization.keepOwner: repo error: %v", err)
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+func (s *Service) findUser(req contract.OrganizationMemberAdd) (*models.User, error) {
+	var (
+		user *models.User
+		err  error
+	)
+	switch {
+	case req.TelegramID != 0:
+		user, err = s.users.FindByTelegramID(req.TelegramID)
+	case req.Phone != "":
+		normalized, perr := phone.NormalizeRegion(req.Phone, s.phoneRegion)
+		if perr != nil {
+			return nil, ErrUserNotFound
+		}
+		user, err = s.users.FindByPhone(normalized)
+	default:
+		return nil, ErrUserNotFound
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && user == nil) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
@@ -0,0 +1,150 @@
+package organization
+
+import (
+	"app/pkg/models"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Create создаёт организацию; создатель становится её владельцем
+func (s *Service) Create(ownerID uuid.UUID, name string) (*models.Organization, error) {
+	name, err := normalizeName(name)
+	if err != nil {
+		return nil, err
+	}
+	org := &models.Organization{Name: name}
+	if err := s.repo.Create(org, ownerID); err != nil {
+		s.logger.Errorf("Organization.Create: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Organization.Create: id=%s owner_id=%s", org.ID, ownerID)
+	return s.repo.FindByID(org.ID)
+}
+
+// List возвращает организации пользователя вместе с участниками и их ролями
+func (s *Service) List(userID uuid.UUID) ([]models.Organization, error) {
+	orgs, err := s.repo.FindByMember(userID)
+	if err != nil {
+		s.logger.Errorf("Organization.List: repo error: %v", err)
+		return nil, err
+	}
+	return orgs, nil
+}
+
+// Get возвращает организацию участнику userID
+func (s *Service) Get(orgID, userID uuid.UUID) (*models.Organization, error) {
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	org, err := s.repo.FindByID(orgID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrOrganizationNotFound
+	}
+	return org, err
+}
+
+// Rename меняет название организации (владелец или управляющий)
+func (s *Service) Rename(orgID, actorID uuid.UUID, name string) error {
+	name, err := normalizeName(name)
+	if err != nil {
+		return err
+	}
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return err
+	}
+	if err := s.repo.Rename(orgID, name); err != nil {
+		s.logger.Errorf("Organization.Rename: repo error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// Personal возвращает личную организацию мастера, создавая её при первой услуге
+func (s *Service) Personal(userID uuid.UUID) (*models.Organization, error) {
+	org, err := s.repo.Personal(userID)
+	if err != nil {
+		s.logger.Errorf("Organization.Personal: user_id=%s: %v", userID, err)
+		return nil, err
+	}
+	return org, nil
+}
+
+// Members — поиск участника; им проверяют права и другие usecase организации (ресурсы, места)
+type Members interface {
+	FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+}
+
+// RequireMember возвращает участника userID; чужим организация не видна (ErrNotMember)
+func RequireMember(members Members, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	m, err := members.FindMember(orgID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNotMember
+	}
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// RequireManager возвращает участника userID, если он владелец или управляющий (иначе ErrForbidden)
+func RequireManager(members Members, orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	m, err := RequireMember(members, orgID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !CanManage(m.Role) {
+		return nil, ErrForbidden
+	}
+	return m, nil
+}
+
+func (s *Service) member(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	return RequireMember(s.repo, orgID, userID)
+}
+
+func (s *Service) manager(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	return RequireManager(s.repo, orgID, userID)
+}
+
+// ValidRole — одна из ролей models.OrgRole*
+func ValidRole(role string) bool {
+	switch role {
+	case models.OrgRoleOwner, models.OrgRoleManager, models.OrgRoleMaster, models.OrgRoleReceptionist:
+		return true
+	}
+	return false
+}
+
+// CanManage — роль управляет услугами, участниками и решает по записям за мастеров
+func CanManage(role string) bool {
+	return role == models.OrgRoleOwner || role == models.OrgRoleManager
+}
+
+// CanServe — участнику с этой ролью можно назначать услуги
+func CanServe(role string) bool {
+	return CanManage(role) || role == models.OrgRoleMaster
+}
+
+// canGrant — может ли участник с ролью actor выдать роль role или изменить участника с ней:
+// владелец — любую, управляющий — только мастеров и администраторов
+func canGrant(actor, role string) bool {
+	switch actor {
+	case models.OrgRoleOwner:
+		return true
+	case models.OrgRoleManager:
+		return role == models.OrgRoleMaster || role == models.OrgRoleReceptionist
+	}
+	return false
+}
+
+func normalizeName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if n := utf8.RuneCountInString(name); n < 1 || n > 100 {
+		return "", ErrInvalidName
+	}
+	return name, nil
+}
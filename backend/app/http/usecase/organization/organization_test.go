@@ -0,0 +1,343 @@
+package organization_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	"app/http/usecase/organization"
+	"app/http/usecase/record"
+	"app/http/usecase/service"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	_ organization.Repository     = (*memory.OrganizationRepository)(nil)
+	_ organization.UserRepository = (*memory.UserRepository)(nil)
+)
+
+type fixture struct {
+	*memtest.Fixture
+	svc     *organization.Service
+	org     *models.Organization
+	owner   models.User
+	manager models.User
+	desk    models.User
+}
+
+// newFixture — салон с владельцем, управляющим, мастером и администратором; клиенты вне салона
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	notifications := notification.NewService(f.Store.Notifications(), f.Logger)
+	records := record.NewService(f.Store.Records(), notifications, f.Logger).WithSender(f.TG)
+	f.svc = organization.NewService(f.Store.Organizations(), f.Store.Users(), records, f.Logger)
+
+	f.owner = f.User(t, models.User{TelegramID: 1002, FirstName: "Владелец"})
+	f.manager = f.User(t, models.User{TelegramID: 1003, FirstName: "Управляющий"})
+	f.desk = f.User(t, models.User{TelegramID: 1004, FirstName: "Администратор"})
+
+	org, err := f.svc.Create(f.owner.ID, "  Салон на Ленина ")
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	f.org = org
+	for _, m := range []struct {
+		user models.User
+		role string
+	}{
+		{f.manager, models.OrgRoleManager},
+		{f.Master, models.OrgRoleMaster},
+		{f.desk, models.OrgRoleReceptionist},
+	} {
+		req := contract.OrganizationMemberAdd{TelegramID: m.user.TelegramID, Role: m.role}
+		if _, err := f.svc.AddMember(org.ID, f.owner.ID, req); err != nil {
+			t.Fatalf("AddMember(%s) error = %v", m.role, err)
+		}
+	}
+	return f
+}
+
+func (f *fixture) role(t *testing.T, user models.User) string {
+	t.Helper()
+	m, err := f.Store.Organizations().FindMember(f.org.ID, user.ID)
+	if err != nil {
+		return ""
+	}
+	return m.Role
+}
+
+// book создаёт услугу салона у мастера, слот на start и заявку клиента
+func (f *fixture) book(t *testing.T, start time.Time) uint {
+	t.Helper()
+	svc, err := f.svc.CreateService(f.org.ID, f.manager.ID, contract.OrganizationService{
+		MasterID: f.Master.ID, Name: "Стрижка", Price: 150000, Duration: 60,
+	})
+	if err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	return f.Record(t, f.Clients[0], f.AddSlot(t, *svc, start), "pending").ID
+}
+
+func TestCreateAndList(t *testing.T) {
+	f := newFixture(t)
+	if f.org.Name != "Салон на Ленина" || f.role(t, f.owner) != models.OrgRoleOwner {
+		t.Fatalf("Create() = %+v, owner role %q", f.org, f.role(t, f.owner))
+	}
+	if _, err := f.svc.Create(f.owner.ID, "   "); !errors.Is(err, organization.ErrInvalidName) {
+		t.Errorf("Create(blank) error = %v, want ErrInvalidName", err)
+	}
+
+	orgs, err := f.svc.List(f.desk.ID)
+	if err != nil || len(orgs) != 1 || len(orgs[0].Members) != 4 {
+		t.Fatalf("List() = %+v, %v, want one organization with 4 members", orgs, err)
+	}
+	if _, err := f.svc.Get(f.org.ID, f.Clients[0].ID); !errors.Is(err, organization.ErrNotMember) {
+		t.Errorf("Get() by outsider error = %v, want ErrNotMember", err)
+	}
+	if err := f.svc.Rename(f.org.ID, f.Master.ID, "Новое"); !errors.Is(err, organization.ErrForbidden) {
+		t.Errorf("Rename() by master error = %v, want ErrForbidden", err)
+	}
+	if err := f.svc.Rename(f.org.ID, f.manager.ID, "Новое"); err != nil {
+		t.Errorf("Rename() by manager error = %v", err)
+	}
+}
+
+func TestMembers(t *testing.T) {
+	tests := []struct {
+		name    string
+		act     func(f *fixture) error
+		wantErr error
+		check   func(t *testing.T, f *fixture)
+	}{
+		{
+			name: "add by phone",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.manager.ID, contract.OrganizationMemberAdd{Phone: "8 999 000-20-01", Role: models.OrgRoleMaster})
+				return err
+			},
+			check: func(t *testing.T, f *fixture) {
+				if got := f.role(t, f.Clients[0]); got != models.OrgRoleMaster {
+					t.Errorf("client role = %q, want master", got)
+				}
+			},
+		},
+		{
+			name: "already a member",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.owner.ID, contract.OrganizationMemberAdd{TelegramID: f.Master.TelegramID, Role: models.OrgRoleMaster})
+				return err
+			},
+			wantErr: organization.ErrAlreadyMember,
+		},
+		{
+			name: "unknown user",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.owner.ID, contract.OrganizationMemberAdd{TelegramID: 9999, Role: models.OrgRoleMaster})
+				return err
+			},
+			wantErr: organization.ErrUserNotFound,
+		},
+		{
+			name: "invalid role",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.owner.ID, contract.OrganizationMemberAdd{TelegramID: f.Clients[0].TelegramID, Role: "admin"})
+				return err
+			},
+			wantErr: organization.ErrInvalidRole,
+		},
+		{
+			name: "manager cannot grant manager",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.manager.ID, contract.OrganizationMemberAdd{TelegramID: f.Clients[0].TelegramID, Role: models.OrgRoleManager})
+				return err
+			},
+			wantErr: organization.ErrForbidden,
+		},
+		{
+			name: "receptionist cannot add",
+			act: func(f *fixture) error {
+				_, err := f.svc.AddMember(f.org.ID, f.desk.ID, contract.OrganizationMemberAdd{TelegramID: f.Clients[0].TelegramID, Role: models.OrgRoleMaster})
+				return err
+			},
+			wantErr: organization.ErrForbidden,
+		},
+		{
+			name: "manager cannot demote owner",
+			act: func(f *fixture) error {
+				return f.svc.UpdateMemberRole(f.org.ID, f.manager.ID, f.owner.ID, models.OrgRoleMaster)
+			},
+			wantErr: organization.ErrForbidden,
+		},
+		{
+			name: "last owner cannot step down",
+			act: func(f *fixture) error {
+				return f.svc.UpdateMemberRole(f.org.ID, f.owner.ID, f.owner.ID, models.OrgRoleManager)
+			},
+			wantErr: organization.ErrLastOwner,
+		},
+		{
+			name:    "last owner cannot leave",
+			act:     func(f *fixture) error { return f.svc.RemoveMember(f.org.ID, f.owner.ID, f.owner.ID) },
+			wantErr: organization.ErrLastOwner,
+		},
+		{
+			name: "owner hands over the salon",
+			act: func(f *fixture) error {
+				if err := f.svc.UpdateMemberRole(f.org.ID, f.owner.ID, f.manager.ID, models.OrgRoleOwner); err != nil {
+					return err
+				}
+				return f.svc.RemoveMember(f.org.ID, f.owner.ID, f.owner.ID)
+			},
+			check: func(t *testing.T, f *fixture) {
+				if f.role(t, f.owner) != "" || f.role(t, f.manager) != models.OrgRoleOwner {
+					t.Errorf("roles after handover: owner %q, manager %q", f.role(t, f.owner), f.role(t, f.manager))
+				}
+			},
+		},
+		{
+			name: "manager removes master",
+			act:  func(f *fixture) error { return f.svc.RemoveMember(f.org.ID, f.manager.ID, f.Master.ID) },
+			check: func(t *testing.T, f *fixture) {
+				if got := f.role(t, f.Master); got != "" {
+					t.Errorf("master role = %q, want removed", got)
+				}
+			},
+		},
+		{
+			name: "member leaves",
+			act:  func(f *fixture) error { return f.svc.RemoveMember(f.org.ID, f.desk.ID, f.desk.ID) },
+		},
+		{
+			name:    "master cannot remove others",
+			act:     func(f *fixture) error { return f.svc.RemoveMember(f.org.ID, f.Master.ID, f.desk.ID) },
+			wantErr: organization.ErrForbidden,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			if err := tt.act(f); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.check != nil {
+				tt.check(t, f)
+			}
+		})
+	}
+}
+
+func TestServices(t *testing.T) {
+	f := newFixture(t)
+	req := contract.OrganizationService{MasterID: f.Master.ID, Name: "Маникюр", Price: 120000, Duration: 90}
+
+	if _, err := f.svc.CreateService(f.org.ID, f.Master.ID, req); !errors.Is(err, organization.ErrForbidden) {
+		t.Errorf("CreateService() by master error = %v, want ErrForbidden", err)
+	}
+	toDesk := req
+	toDesk.MasterID = f.desk.ID
+	if _, err := f.svc.CreateService(f.org.ID, f.owner.ID, toDesk); !errors.Is(err, organization.ErrNotAssignable) {
+		t.Errorf("CreateService() for receptionist error = %v, want ErrNotAssignable", err)
+	}
+	toOutsider := req
+	toOutsider.MasterID = f.Clients[0].ID
+	if _, err := f.svc.CreateService(f.org.ID, f.owner.ID, toOutsider); !errors.Is(err, organization.ErrNotAssignable) {
+		t.Errorf("CreateService() for outsider error = %v, want ErrNotAssignable", err)
+	}
+
+	svc, err := f.svc.CreateService(f.org.ID, f.manager.ID, req)
+	if err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	if err := f.svc.AssignService(f.org.ID, f.manager.ID, svc.ID, f.manager.ID); err != nil {
+		t.Fatalf("AssignService() error = %v", err)
+	}
+	if err := f.svc.AssignService(f.org.ID, f.manager.ID, svc.ID+100, f.Master.ID); !errors.Is(err, organization.ErrServiceNotFound) {
+		t.Errorf("AssignService(missing) error = %v, want ErrServiceNotFound", err)
+	}
+
+	list, err := f.svc.Services(f.org.ID, f.desk.ID)
+	if err != nil || len(list) != 1 || list[0].MasterID != f.manager.ID {
+		t.Fatalf("Services() = %+v, %v, want the service assigned to the manager", list, err)
+	}
+}
+
+func TestSlotsAndRecords(t *testing.T) {
+	f := newFixture(t)
+	day := time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+	id := f.book(t, day.Add(10*time.Hour))
+	f.book(t, day.AddDate(0, 0, 10))
+
+	week := day.AddDate(0, 0, 7)
+	slots, err := f.svc.Slots(f.org.ID, f.desk.ID, day, week)
+	if err != nil || len(slots) != 1 {
+		t.Fatalf("Slots() = %d, %v, want 1", len(slots), err)
+	}
+	records, err := f.svc.Records(f.org.ID, f.desk.ID, "pending", day, week)
+	if err != nil || len(records) != 1 || records[0].ID != id {
+		t.Fatalf("Records() = %+v, %v, want record %d", records, err, id)
+	}
+	if _, err := f.svc.Records(f.org.ID, f.Clients[0].ID, "", day, week); !errors.Is(err, organization.ErrNotMember) {
+		t.Errorf("Records() by outsider error = %v, want ErrNotMember", err)
+	}
+	if _, err := f.svc.Slots(f.org.ID, f.owner.ID, day, day.AddDate(0, 2, 0)); !errors.Is(err, organization.ErrInvalidPeriod) {
+		t.Errorf("Slots(two months) error = %v, want ErrInvalidPeriod", err)
+	}
+}
+
+func TestUpdateRecordStatus(t *testing.T) {
+	f := newFixture(t)
+	id := f.book(t, time.Date(2030, 1, 15, 10, 0, 0, 0, time.UTC))
+
+	if err := f.svc.UpdateRecordStatus(f.org.ID, f.desk.ID, id, "confirm"); !errors.Is(err, organization.ErrForbidden) {
+		t.Errorf("UpdateRecordStatus() by receptionist error = %v, want ErrForbidden", err)
+	}
+	if err := f.svc.UpdateRecordStatus(f.org.ID, f.manager.ID, id, "cancel"); !errors.Is(err, organization.ErrInvalidStatus) {
+		t.Errorf("UpdateRecordStatus(cancel) error = %v, want ErrInvalidStatus", err)
+	}
+	if err := f.svc.UpdateRecordStatus(f.org.ID, f.manager.ID, id+100, "confirm"); !errors.Is(err, organization.ErrRecordNotFound) {
+		t.Errorf("UpdateRecordStatus(missing) error = %v, want ErrRecordNotFound", err)
+	}
+
+	if err := f.svc.UpdateRecordStatus(f.org.ID, f.manager.ID, id, "confirm"); err != nil {
+		t.Fatalf("UpdateRecordStatus() by manager error = %v", err)
+	}
+	rec, err := f.Store.Records().GetRecordByIDWithDetails(id)
+	if err != nil || rec.Status != "confirm" {
+		t.Fatalf("record = %+v, %v, want confirmed", rec, err)
+	}
+	var notified bool
+	for _, m := range f.TG.Messages() {
+		notified = notified || m.TelegramID == f.Clients[0].TelegramID
+	}
+	if !notified {
+		t.Error("client was not notified about the manager decision")
+	}
+}
+
+func TestPersonalOrganization(t *testing.T) {
+	f := newFixture(t)
+	services := service.NewService(f.Store.Services(), f.Store.Users(), f.Logger).WithOrganizations(f.svc)
+
+	s := models.Service{MasterID: f.Clients[0].ID, Name: "Массаж", Price: 200000, Duration: 60}
+	if err := services.CreateService(&s); err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	personal, err := f.svc.Personal(f.Clients[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.OrganizationID == nil || *s.OrganizationID != personal.ID {
+		t.Fatalf("service organization = %v, want personal %s", s.OrganizationID, personal.ID)
+	}
+	if again, err := f.svc.Personal(f.Clients[0].ID); err != nil || again.ID != personal.ID {
+		t.Errorf("Personal() again = %v, %v, want the same organization", again, err)
+	}
+	orgs, err := f.svc.List(f.Clients[0].ID)
+	if err != nil || len(orgs) != 1 || orgs[0].Members[0].Role != models.OrgRoleOwner {
+		t.Errorf("List() = %+v, %v, want the personal organization owned by the master", orgs, err)
+	}
+}
@@ -0,0 +1,157 @@
+package organization
+
+import (
+	"app/pkg/models"
+	"contract"
+	"contract/money"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxPeriod — самый длинный период в выборках слотов и записей организации
+const MaxPeriod = 31 * 24 * time.Hour
+
+// Services возвращает услуги организации с назначенными мастерами
+func (s *Service) Services(orgID, userID uuid.UUID) ([]models.Service, error) {
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindServices(orgID)
+}
+
+// CreateService создаёт услугу организации и назначает её мастеру req.MasterID
+func (s *Service) CreateService(orgID, actorID uuid.UUID, req contract.OrganizationService) (*models.Service, error) {
+	name := strings.TrimSpace(req.Name)
+	if n := utf8.RuneCountInString(name); n < 1 || n > 100 || req.Price < 0 || req.Duration <= 0 {
+		return nil, ErrInvalidService
+	}
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return nil, err
+	}
+	if err := s.assignable(orgID, req.MasterID); err != nil {
+		return nil, err
+	}
+	// Без валюты в запросе — валюта назначенного мастера
+	currency := req.Currency
+	if currency == "" {
+		master, err := s.users.FindByID(req.MasterID)
+		if err != nil {
+			s.logger.Errorf("Organization.CreateService: master lookup failed: %v", err)
+			return nil, err
+		}
+		if master != nil {
+			currency = master.Currency
+		}
+	}
+	if currency = money.Normalize(currency); !money.Known(currency) {
+		return nil, ErrInvalidCurrency
+	}
+	svc := &models.Service{
+		MasterID:       req.MasterID,
+		Name:           name,
+		Price:          req.Price,
+		Currency:       currency,
+		Description:    req.Description,
+		Duration:       req.Duration,
+		OrganizationID: &orgID,
+	}
+	if err := s.repo.CreateService(svc); err != nil {
+		s.logger.Errorf("Organization.CreateService: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Organization.CreateService: org_id=%s service_id=%d master_id=%s", orgID, svc.ID, req.MasterID)
+	return svc, nil
+}
+
+// AssignService передаёт услугу организации другому мастеру.
+// Уже созданные слоты остаются у прежнего мастера.
+func (s *Service) AssignService(orgID, actorID uuid.UUID, serviceID uint, masterID uuid.UUID) error {
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindService(orgID, serviceID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrServiceNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := s.assignable(orgID, masterID); err != nil {
+		return err
+	}
+	if err := s.repo.AssignService(serviceID, masterID); err != nil {
+		s.logger.Errorf("Organization.AssignService: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Organization.AssignService: org_id=%s service_id=%d master_id=%s", orgID, serviceID, masterID)
+	return nil
+}
+
+// Slots — слоты всех мастеров по услугам организации, начинающиеся в [from, to)
+func (s *Service) Slots(orgID, userID uuid.UUID, from, to time.Time) ([]models.Slot, error) {
+	if err := validPeriod(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindSlots(orgID, from, to)
+}
+
+// Records — записи на слоты по услугам организации в [from, to); status == "" — все статусы
+func (s *Service) Records(orgID, userID uuid.UUID, status string, from, to time.Time) ([]models.Record, error) {
+	if err := validPeriod(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindRecords(orgID, status, from, to)
+}
+
+// UpdateRecordStatus подтверждает или отклоняет запись за мастера (владелец или управляющий);
+// клиент получает то же уведомление, что и при решении мастера
+func (s *Service) UpdateRecordStatus(orgID, actorID uuid.UUID, recordID uint, status string) error {
+	if status != "confirm" && status != "reject" {
+		return ErrInvalidStatus
+	}
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return err
+	}
+	if _, err := s.repo.FindRecord(orgID, recordID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrRecordNotFound
+	} else if err != nil {
+		return err
+	}
+	if err := s.records.UpdateRecordStatus(recordID, status); err != nil {
+		s.logger.Errorf("Organization.UpdateRecordStatus: record_id=%d: %v", recordID, err)
+		return err
+	}
+	s.logger.Infof("Organization.UpdateRecordStatus: org_id=%s record_id=%d status=%s by %s", orgID, recordID, status, actorID)
+	return nil
+}
+
+// assignable — пользователь состоит в организации в роли, которой можно назначать услуги
+func (s *Service) assignable(orgID, masterID uuid.UUID) error {
+	m, err := s.member(orgID, masterID)
+	if errors.Is(err, ErrNotMember) {
+		return ErrNotAssignable
+	}
+	if err != nil {
+		return err
+	}
+	if !CanServe(m.Role) {
+		return ErrNotAssignable
+	}
+	return nil
+}
+
+func validPeriod(from, to time.Time) error {
+	if !to.After(from) || to.Sub(from) > MaxPeriod {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
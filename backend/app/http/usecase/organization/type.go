@@ -0,0 +1,88 @@
+package organization
+
+import (
+	"app/pkg/models"
+	"app/pkg/phone"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrOrganizationNotFound = errors.New("organization not found")
+	ErrNotMember            = errors.New("not a member of the organization")
+	ErrForbidden            = errors.New("role does not allow this action")
+	ErrInvalidName          = errors.New("organization name must be 1..100 characters")
+	ErrInvalidRole          = errors.New("role must be owner, manager, master or receptionist")
+	ErrUserNotFound         = errors.New("user not found")
+	ErrAlreadyMember        = errors.New("user is already a member")
+	ErrLastOwner            = errors.New("organization must keep at least one owner")
+	ErrNotAssignable        = errors.New("services can be assigned only to owners, managers and masters")
+	ErrInvalidService       = errors.New("service needs a name of 1..100 characters, a non-negative price and a positive duration")
+	ErrServiceNotFound      = errors.New("service not found in the organization")
+	ErrRecordNotFound       = errors.New("record not found in the organization")
+	ErrInvalidStatus        = errors.New("status must be confirm or reject")
+	ErrInvalidPeriod        = errors.New("period must be from 1 to 31 days")
+	ErrInvalidCurrency      = errors.New("currency must be a supported ISO 4217 code")
+)
+
+// Repository — организации, участники и выборки по услугам организации
+type Repository interface {
+	Create(org *models.Organization, ownerID uuid.UUID) error
+	FindByID(id uuid.UUID) (*models.Organization, error)
+	FindByMember(userID uuid.UUID) ([]models.Organization, error)
+	Rename(id uuid.UUID, name string) error
+	Personal(userID uuid.UUID) (*models.Organization, error)
+
+	FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	AddMember(m *models.OrganizationMember) error
+	UpdateMemberRole(orgID, userID uuid.UUID, role string) error
+	RemoveMember(orgID, userID uuid.UUID) error
+	CountOwners(orgID uuid.UUID) (int64, error)
+
+	CreateService(svc *models.Service) error
+	FindServices(orgID uuid.UUID) ([]models.Service, error)
+	FindService(orgID uuid.UUID, serviceID uint) (*models.Service, error)
+	AssignService(serviceID uint, masterID uuid.UUID) error
+	FindSlots(orgID uuid.UUID, from, to time.Time) ([]models.Slot, error)
+	FindRecords(orgID uuid.UUID, status string, from, to time.Time) ([]models.Record, error)
+	FindRecord(orgID uuid.UUID, recordID uint) (*models.Record, error)
+}
+
+// UserRepository — поиск добавляемого участника
+type UserRepository interface {
+	FindByPhone(phone string) (*models.User, error)
+	FindByTelegramID(telegramID int64) (*models.User, error)
+	FindByID(id uuid.UUID) (*models.User, error)
+}
+
+// RecordStatusUpdater — смена статуса записи с уведомлением клиента (usecase/record)
+type RecordStatusUpdater interface {
+	UpdateRecordStatus(recordID uint, status string) error
+}
+
+type Service struct {
+	repo        Repository
+	users       UserRepository
+	records     RecordStatusUpdater
+	logger      *logrus.Logger
+	phoneRegion string
+}
+
+func NewService(repo Repository, users UserRepository, records RecordStatusUpdater, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:        repo,
+		users:       users,
+		records:     records,
+		logger:      logger,
+		phoneRegion: phone.DefaultRegion,
+	}
+}
+
+// WithPhoneRegion задаёт регион для номеров участников, введённых без кода страны
+func (s *Service) WithPhoneRegion(region string) *Service {
+	s.phoneRegion = region
+	return s
+}
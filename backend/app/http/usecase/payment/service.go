@@ -0,0 +1,113 @@
+package payment
+
+import (
+	"app/pkg/models"
+	"app/pkg/payment"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Start создаёт у провайдера платёж на amount по записи rec и сохраняет его;
+// оплатить нужно до rec.HoldUntil
+func (s *Service) Start(rec models.Record, amount int64) (models.Payment, error) {
+	created, err := s.provider.Create(context.Background(), payment.Intent{
+		Reference:   fmt.Sprintf("record-%d", rec.ID),
+		Amount:      amount,
+		Currency:    rec.Currency,
+		Description: fmt.Sprintf("Предоплата записи №%d", rec.ID),
+		ReturnURL:   s.returnURL,
+	})
+	if err != nil {
+		s.logger.Errorf("Service.Start (payment): record_id=%d: provider error: %v", rec.ID, err)
+		return models.Payment{}, err
+	}
+	p := models.Payment{
+		RecordID:        &rec.ID,
+		Provider:        s.provider.Name(),
+		ExternalID:      created.ExternalID,
+		Amount:          amount,
+		Currency:        rec.Currency,
+		Status:          models.PaymentPending,
+		ConfirmationURL: created.ConfirmationURL,
+	}
+	if rec.HoldUntil != nil {
+		p.ExpiresAt = *rec.HoldUntil
+	}
+	if err := s.repo.Create(&p); err != nil {
+		s.logger.Errorf("Service.Start (payment): record_id=%d: repo error: %v", rec.ID, err)
+		return models.Payment{}, err
+	}
+	s.logger.Infof("Service.Start (payment): record_id=%d payment_id=%d %s %d %s", rec.ID, p.ID, p.Provider, p.Amount, p.Currency)
+	return p, nil
+}
+
+// HandleWebhook применяет проверенный вебхук провайдера. Успешная оплата подтверждает запись,
+// а если бронь уже снята — деньги возвращаются. Повторные вебхуки ничего не меняют.
+// Ошибка, кроме payment.ErrInvalidSignature и payment.ErrInvalidEvent, означает «повторите позже»
+func (s *Service) HandleWebhook(ctx context.Context, header http.Header, body []byte) error {
+	ev, err := s.provider.ParseWebhook(ctx, header, body)
+	if err != nil {
+		s.logger.Errorf("Service.HandleWebhook: %v", err)
+		return err
+	}
+	p, err := s.repo.FindByExternalID(s.provider.Name(), ev.ExternalID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrPaymentNotFound
+	}
+	if err != nil {
+		s.logger.Errorf("Service.HandleWebhook: repo error: %v", err)
+		return err
+	}
+	if p.Status != models.PaymentPending {
+		s.logger.Infof("Service.HandleWebhook: payment_id=%d is already %s", p.ID, p.Status)
+		return nil
+	}
+
+	switch ev.Status {
+	case payment.StatusSucceeded:
+		return s.paid(ctx, p, ev)
+	case payment.StatusCanceled:
+		if err := s.repo.UpdateStatus(p.ID, models.PaymentCanceled, nil); err != nil {
+			return err
+		}
+		if p.RecordID != nil && s.records != nil {
+			if err := s.records.ReleaseUnpaid(*p.RecordID); err != nil {
+				s.logger.Errorf("Service.HandleWebhook: release record_id=%d failed: %v", *p.RecordID, err)
+			}
+		}
+		s.logger.Infof("Service.HandleWebhook: payment_id=%d canceled", p.ID)
+	}
+	return nil
+}
+
+// paid подтверждает запись оплаченного платежа или возвращает деньги за опоздавшую оплату
+func (s *Service) paid(ctx context.Context, p models.Payment, ev payment.Event) error {
+	if ev.Amount != p.Amount || ev.Currency != p.Currency {
+		s.logger.Errorf("Service.HandleWebhook: payment_id=%d paid %d %s, want %d %s", p.ID, ev.Amount, ev.Currency, p.Amount, p.Currency)
+		return ErrAmountMismatch
+	}
+	confirmed := false
+	if p.RecordID != nil && s.records != nil {
+		var err error
+		if confirmed, err = s.records.ConfirmPaid(*p.RecordID); err != nil {
+			s.logger.Errorf("Service.HandleWebhook: confirm record_id=%d failed: %v", *p.RecordID, err)
+			return err
+		}
+	}
+	now := s.now()
+	if confirmed {
+		s.logger.Infof("Service.HandleWebhook: payment_id=%d succeeded", p.ID)
+		return s.repo.UpdateStatus(p.ID, models.PaymentSucceeded, &now)
+	}
+	// Бронь снята раньше, чем пришли деньги: возвращаем их клиенту
+	if err := s.provider.Refund(ctx, p.ExternalID, p.Amount, p.Currency); err != nil {
+		s.logger.Errorf("Service.HandleWebhook: refund payment_id=%d failed: %v", p.ID, err)
+		return err
+	}
+	s.logger.Infof("Service.HandleWebhook: payment_id=%d refunded, the hold is gone", p.ID)
+	return s.repo.UpdateStatus(p.ID, models.PaymentRefunded, &now)
+}
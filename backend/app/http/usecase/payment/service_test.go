@@ -0,0 +1,249 @@
+package payment_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	ucase "app/http/usecase/payment"
+	"app/http/usecase/record"
+	"app/pkg/models"
+	"app/pkg/payment"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+var _ ucase.Repository = (*memory.PaymentRepository)(nil)
+var _ ucase.Records = (*record.Service)(nil)
+
+const secret = "webhook-secret"
+
+type fixture struct {
+	*memtest.Fixture
+	provider *payment.Fake
+	records  *record.Service
+	payments *ucase.Service
+	slot     models.Slot
+}
+
+// newFixture — мастер с услугой за 1 500 ₽ и предоплатой prepayment/deposit, один слот и два клиента
+func newFixture(t *testing.T, prepayment string, deposit int64) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t), provider: payment.NewFake(secret)}
+	f.UpdateService(t, func(s *models.Service) { s.Prepayment, s.Deposit = prepayment, deposit })
+	f.slot = f.AddSlot(t, f.Service, memtest.SlotStart)
+
+	notifications := notification.NewService(f.Store.Notifications(), f.Logger)
+	f.records = record.NewService(f.Store.Records(), notifications, f.Logger).WithSender(f.TG)
+	f.payments = ucase.NewService(f.Store.Payments(), f.provider, f.Logger).
+		WithRecords(f.records).
+		WithReturnURL("https://timeslot.example/records")
+	f.records.WithPayments(f.payments, 15*time.Minute)
+	return f
+}
+
+// webhook отправляет подписанный вебхук локального провайдера
+func (f *fixture) webhook(t *testing.T, p models.Payment, status string, amount int64) error {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{"id": p.ExternalID, "status": status, "amount": amount, "currency": p.Currency})
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := http.Header{}
+	header.Set(payment.SignatureHeader, payment.Sign(secret, body))
+	return f.payments.HandleWebhook(context.Background(), header, body)
+}
+
+func TestBookingAwaitsPayment(t *testing.T) {
+	tests := []struct {
+		name       string
+		prepayment string
+		deposit    int64
+		wantAmount int64
+	}{
+		{name: "full prepayment", prepayment: "full", wantAmount: 150000},
+		{name: "deposit", prepayment: "deposit", deposit: 50000, wantAmount: 50000},
+		{name: "deposit above the price", prepayment: "deposit", deposit: 200000, wantAmount: 150000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t, tt.prepayment, tt.deposit)
+			rec := f.Book(t, f.records, f.Clients[0], f.slot)
+
+			if rec.Status != models.RecordAwaitingPayment || rec.HoldUntil == nil {
+				t.Fatalf("record = %s hold %v, want awaiting_payment with a hold", rec.Status, rec.HoldUntil)
+			}
+			p := rec.Payment
+			if p == nil || p.Amount != tt.wantAmount || p.Currency != "RUB" || p.Status != models.PaymentPending || !p.ExpiresAt.Equal(*rec.HoldUntil) {
+				t.Fatalf("payment = %+v, want pending %d RUB until the hold ends", p, tt.wantAmount)
+			}
+			if p.ConfirmationURL == "" {
+				t.Error("payment has no confirmation URL")
+			}
+			// Мастер узнаёт о записи только после оплаты
+			if sent := f.TG.Messages(); len(sent) != 0 {
+				t.Errorf("telegram messages = %+v, want none before payment", sent)
+			}
+			// Другой клиент не может занять слот, пока держится бронь
+			other := models.Record{SlotID: f.slot.ID, ClientID: f.Clients[1].ID}
+			if err := f.records.Create(&other); !errors.Is(err, record.ErrSlotHeld) {
+				t.Errorf("Create() by another client error = %v, want %v", err, record.ErrSlotHeld)
+			}
+			// Решать за неоплаченную запись мастер не может
+			if err := f.records.ConfirmRecord(rec.ID); !errors.Is(err, record.ErrAwaitingPayment) {
+				t.Errorf("ConfirmRecord() error = %v, want %v", err, record.ErrAwaitingPayment)
+			}
+		})
+	}
+}
+
+// Параллельные заявки на свободный слот: бронь получает ровно одна, остальные — ErrSlotHeld
+func TestConcurrentBookingsHoldOnce(t *testing.T) {
+	f := newFixture(t, "full", 0)
+	clients := f.Clients
+	for i := 0; i < 8; i++ {
+		clients = append(clients, f.User(t, models.User{TelegramID: int64(3001 + i), FirstName: "Клиент"}))
+	}
+
+	errs := make([]error, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c models.User) {
+			defer wg.Done()
+			rec := models.Record{SlotID: f.slot.ID, ClientID: c.ID}
+			errs[i] = f.records.Create(&rec)
+		}(i, c)
+	}
+	wg.Wait()
+
+	held := 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			held++
+		case !errors.Is(err, record.ErrSlotHeld):
+			t.Errorf("Create() for client %d error = %v, want nil or %v", i, err, record.ErrSlotHeld)
+		}
+	}
+	if held != 1 {
+		t.Errorf("holds = %d, want exactly 1", held)
+	}
+}
+
+func TestBookingWithoutPrepayment(t *testing.T) {
+	f := newFixture(t, "", 0)
+	rec := f.Book(t, f.records, f.Clients[0], f.slot)
+	if rec.Status != "pending" || rec.Payment != nil || rec.HoldUntil != nil {
+		t.Fatalf("record = %s payment %+v, want a plain pending record", rec.Status, rec.Payment)
+	}
+}
+
+func TestPaidWebhookConfirmsRecord(t *testing.T) {
+	f := newFixture(t, "deposit", 50000)
+	rec := f.Book(t, f.records, f.Clients[0], f.slot)
+
+	if err := f.webhook(t, *rec.Payment, payment.StatusSucceeded, 50000); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+	got := f.Get(t, rec.ID)
+	if got.Status != "confirm" || got.Payment == nil || got.Payment.Status != models.PaymentSucceeded || got.Payment.PaidAt == nil {
+		t.Fatalf("record = %s payment %+v, want confirmed and paid", got.Status, got.Payment)
+	}
+	if sl, _ := f.Store.Records().GetSlotByID(f.slot.ID); !sl.IsBooked {
+		t.Error("slot is not booked after payment")
+	}
+	sent := f.TG.Messages()
+	if len(sent) < 2 || sent[0].TelegramID != f.Master.TelegramID || sent[0].Kind != "record_status" || sent[1].TelegramID != f.Clients[0].TelegramID {
+		t.Errorf("telegram messages = %+v, want the master and then the client notified", sent)
+	}
+
+	// Повтор вебхука ничего не меняет
+	before := len(f.TG.Messages())
+	if err := f.webhook(t, *rec.Payment, payment.StatusSucceeded, 50000); err != nil {
+		t.Fatalf("repeated HandleWebhook() error = %v", err)
+	}
+	if len(f.TG.Messages()) != before || f.provider.Refunded(rec.Payment.ExternalID) != 0 {
+		t.Error("repeated webhook sent messages or refunded the payment")
+	}
+}
+
+func TestLatePaymentIsRefunded(t *testing.T) {
+	f := newFixture(t, "full", 0)
+	rec := f.Book(t, f.records, f.Clients[0], f.slot)
+	// Бронь истекла раньше, чем пришли деньги
+	if released, err := f.Store.Payments().ReleaseHold(rec.ID); err != nil || !released {
+		t.Fatalf("ReleaseHold() = %v, %v", released, err)
+	}
+
+	if err := f.webhook(t, *rec.Payment, payment.StatusSucceeded, 150000); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+	if got := f.provider.Refunded(rec.Payment.ExternalID); got != 150000 {
+		t.Errorf("refunded = %d, want 150000", got)
+	}
+	got := f.Get(t, rec.ID)
+	if got.Status != "cancel" || got.Payment.Status != models.PaymentRefunded {
+		t.Errorf("record = %s payment %s, want cancel and refunded", got.Status, got.Payment.Status)
+	}
+}
+
+func TestCanceledPaymentReleasesSlot(t *testing.T) {
+	f := newFixture(t, "full", 0)
+	rec := f.Book(t, f.records, f.Clients[0], f.slot)
+
+	if err := f.webhook(t, *rec.Payment, payment.StatusCanceled, 150000); err != nil {
+		t.Fatalf("HandleWebhook() error = %v", err)
+	}
+	if got := f.Get(t, rec.ID); got.Status != "cancel" || got.Payment.Status != models.PaymentCanceled {
+		t.Fatalf("record = %s payment %s, want cancel and canceled", got.Status, got.Payment.Status)
+	}
+	// Слот снова свободен для других клиентов
+	f.Book(t, f.records, f.Clients[1], f.slot)
+}
+
+func TestWebhookRejected(t *testing.T) {
+	f := newFixture(t, "full", 0)
+	rec := f.Book(t, f.records, f.Clients[0], f.slot)
+
+	forged := []byte(`{"id":"` + rec.Payment.ExternalID + `","status":"succeeded","amount":150000,"currency":"RUB"}`)
+	header := http.Header{}
+	header.Set(payment.SignatureHeader, payment.Sign("guess", forged))
+	if err := f.payments.HandleWebhook(context.Background(), header, forged); !errors.Is(err, payment.ErrInvalidSignature) {
+		t.Errorf("forged HandleWebhook() error = %v, want %v", err, payment.ErrInvalidSignature)
+	}
+	if err := f.webhook(t, *rec.Payment, payment.StatusSucceeded, 100); !errors.Is(err, ucase.ErrAmountMismatch) {
+		t.Errorf("underpaid HandleWebhook() error = %v, want %v", err, ucase.ErrAmountMismatch)
+	}
+	if err := f.webhook(t, models.Payment{ExternalID: "fake_unknown", Currency: "RUB"}, payment.StatusSucceeded, 100); !errors.Is(err, ucase.ErrPaymentNotFound) {
+		t.Errorf("unknown HandleWebhook() error = %v, want %v", err, ucase.ErrPaymentNotFound)
+	}
+	if got := f.Get(t, rec.ID); got.Status != models.RecordAwaitingPayment {
+		t.Errorf("record status = %s, want still awaiting payment", got.Status)
+	}
+}
+
+// brokenProvider не может создать платёж
+type brokenProvider struct{ *payment.Fake }
+
+func (brokenProvider) Create(context.Context, payment.Intent) (payment.Created, error) {
+	return payment.Created{}, errors.New("provider is down")
+}
+
+func TestProviderDownDropsRecord(t *testing.T) {
+	f := newFixture(t, "full", 0)
+	f.records.WithPayments(ucase.NewService(f.Store.Payments(), brokenProvider{f.provider}, f.Logger), 15*time.Minute)
+
+	rec := models.Record{SlotID: f.slot.ID, ClientID: f.Clients[0].ID}
+	if err := f.records.Create(&rec); !errors.Is(err, record.ErrPaymentFailed) {
+		t.Fatalf("Create() error = %v, want %v", err, record.ErrPaymentFailed)
+	}
+	// Запись без платежа не держит слот и не мешает записаться снова
+	if _, err := f.Store.Records().GetRecordByIDWithDetails(rec.ID); err == nil {
+		t.Error("record without a payment is kept")
+	}
+}
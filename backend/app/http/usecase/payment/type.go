@@ -0,0 +1,61 @@
+package payment
+
+import (
+	"app/pkg/models"
+	"app/pkg/payment"
+	"errors"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrPaymentNotFound = errors.New("payment not found")
+	ErrAmountMismatch  = errors.New("paid amount does not match the payment")
+)
+
+// Repository — платежи за записи (repository/payment)
+type Repository interface {
+	Create(p *models.Payment) error
+	FindByExternalID(provider, externalID string) (models.Payment, error)
+	UpdateStatus(id uint, status string, paidAt *time.Time) error
+}
+
+// Records — судьба записи после оплаты (usecase/record)
+type Records interface {
+	// ConfirmPaid подтверждает оплаченную запись; false — запись уже не ждёт оплаты, деньги нужно вернуть
+	ConfirmPaid(recordID uint) (bool, error)
+	// ReleaseUnpaid снимает бронь записи, платёж по которой отменён
+	ReleaseUnpaid(recordID uint) error
+}
+
+// Service создаёт платежи у провайдера и разбирает его вебхуки
+type Service struct {
+	repo      Repository
+	provider  payment.Provider
+	records   Records
+	returnURL string
+	logger    *logrus.Logger
+	now       func() time.Time
+}
+
+func NewService(repo Repository, provider payment.Provider, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:     repo,
+		provider: provider,
+		logger:   logger,
+		now:      time.Now,
+	}
+}
+
+// WithRecords подключает подтверждение записей по вебхукам об оплате
+func (s *Service) WithRecords(records Records) *Service {
+	s.records = records
+	return s
+}
+
+// WithReturnURL задаёт адрес, куда провайдер вернёт клиента после оплаты
+func (s *Service) WithReturnURL(url string) *Service {
+	s.returnURL = url
+	return s
+}
@@ -0,0 +1,237 @@
+package record
+
+import (
+	"app/pkg/ical"
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MaxCommentLength — ограничение длины комментария клиента в символах
+const MaxCommentLength = 500
+
+var (
+	ErrRecordNotFound  = errors.New("record not found")
+	ErrNotRecordOwner  = errors.New("record belongs to another client")
+	ErrRecordClosed    = errors.New("record is cancelled or rejected")
+	ErrRecordStarted   = errors.New("record has already started")
+	ErrSlotUnavailable = errors.New("slot is not available for rescheduling")
+	ErrInvalidComment  = fmt.Errorf("comment must be 1..%d characters", MaxCommentLength)
+)
+
+// CancelByClient отменяет запись по просьбе клиента; слот освобождается, мастер получает уведомление
+func (s *Service) CancelByClient(recordID uint, clientID uuid.UUID) error {
+	rec, err := s.activeRecordOf(recordID, clientID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateRecordStatus(recordID, "cancel"); err != nil {
+		s.logger.Errorf("Service.CancelByClient: repo error: %v", err)
+		return err
+	}
+	// О неоплаченной записи мастер ещё не знает
+	if rec.Status == models.RecordAwaitingPayment {
+		s.logger.Infof("Service.CancelByClient: unpaid record_id=%d cancelled by client_id=%s", recordID, clientID)
+		return nil
+	}
+
+	title := "Клиент отменил запись"
+	message := fmt.Sprintf("Клиент %s %s отменил запись на услугу \"%s\"\nВремя: %s",
+		rec.Client.FirstName, rec.Client.Surname, rec.Slot.Service.Name, masterSpan(rec.Slot))
+	s.notifyMaster(rec, 0, "RECORD_CANCELLED", title, message, nil)
+
+	s.logger.Infof("Service.CancelByClient: record_id=%d cancelled by client_id=%s", recordID, clientID)
+	return nil
+}
+
+// RescheduleByClient переносит запись на другой свободный слот той же услуги у того же мастера:
+// снимок цены, варианта и длительности остаётся верным. Запись снова ждёт подтверждения:
+// мастер получает уведомление с кнопками. Слот и статус записи проверяются в repo.MoveRecord
+// под блокировкой целевого слота, как при Create.
+func (s *Service) RescheduleByClient(recordID uint, clientID uuid.UUID, slotID uint) error {
+	rec, err := s.activeRecordOf(recordID, clientID)
+	if err != nil {
+		return err
+	}
+	err = s.repo.MoveRecord(recordID, slotID, func(cur models.Record, slot models.Slot) error {
+		if cur.Status == "cancel" || cur.Status == "reject" {
+			return ErrRecordClosed
+		}
+		// Перенос вернул бы запись в ожидание мастера в обход оплаты
+		if cur.Status == models.RecordAwaitingPayment {
+			return ErrAwaitingPayment
+		}
+		if slot.ID == cur.SlotID || slot.MasterID != rec.Slot.MasterID || slot.ServiceID != rec.Slot.ServiceID || !slot.StartTime.After(time.Now()) {
+			return ErrSlotUnavailable
+		}
+		// Вариант и опции записи не меняются, поэтому длительность берём из загруженной записи
+		if !fits(slot, rec) {
+			return ErrSlotTooShort
+		}
+		return s.checkResources(slot)
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = ErrSlotUnavailable
+	}
+	if err != nil {
+		s.logger.Errorf("Service.RescheduleByClient: record_id=%d slot_id=%d: %v", recordID, slotID, err)
+		return err
+	}
+
+	moved, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if err != nil {
+		s.logger.Errorf("Service.RescheduleByClient: load record failed for notification: %v", err)
+	} else {
+		lang := moved.Slot.Master.Language
+		message := fmt.Sprintf(textRescheduled.In(lang),
+			moved.Client.FirstName, moved.Client.Surname, moved.Client.Phone, serviceTitle(moved.Slot.Service, moved), price(moved, lang),
+			masterSpan(rec.Slot), masterSpan(moved.Slot), addressLine(moved.Slot, lang))
+		// Кнопки подтверждения: перенесённая запись снова ждёт решения мастера
+		s.notifyMaster(moved, recordID, "RECORD_RESCHEDULED", textRescheduledTitle.In(lang), message, map[string]interface{}{"previous_slot_id": rec.SlotID})
+	}
+
+	s.logger.Infof("Service.RescheduleByClient: record_id=%d slot_id=%d -> %d", recordID, rec.SlotID, slotID)
+	return nil
+}
+
+// CommentByClient сохраняет комментарий клиента к записи и пересылает его мастеру
+func (s *Service) CommentByClient(recordID uint, clientID uuid.UUID, comment string) error {
+	comment = strings.TrimSpace(comment)
+	if comment == "" || utf8.RuneCountInString(comment) > MaxCommentLength {
+		return ErrInvalidComment
+	}
+	rec, err := s.activeRecordOf(recordID, clientID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateRecordComment(recordID, comment); err != nil {
+		s.logger.Errorf("Service.CommentByClient: repo error: %v", err)
+		return err
+	}
+	// Мастер увидит комментарий вместе с записью после оплаты
+	if rec.Status == models.RecordAwaitingPayment {
+		return nil
+	}
+
+	title := "Комментарий к записи"
+	message := fmt.Sprintf("Клиент %s %s оставил комментарий к записи на услугу \"%s\"\nВремя: %s\n\n%s",
+		rec.Client.FirstName, rec.Client.Surname, rec.Slot.Service.Name, masterSpan(rec.Slot), comment)
+	s.notifyMaster(rec, 0, "RECORD_COMMENTED", title, message, map[string]interface{}{"comment": comment})
+
+	s.logger.Infof("Service.CommentByClient: record_id=%d client_id=%s", recordID, clientID)
+	return nil
+}
+
+// CalendarByClient возвращает запись клиента в формате .ics
+func (s *Service) CalendarByClient(recordID uint, clientID uuid.UUID) ([]byte, error) {
+	rec, err := s.recordOf(recordID, clientID)
+	if err != nil {
+		return nil, err
+	}
+	if rec.Status == "cancel" || rec.Status == "reject" {
+		return nil, ErrRecordClosed
+	}
+
+	status := ical.StatusTentative
+	if rec.Status == "confirm" {
+		status = ical.StatusConfirmed
+	}
+	description := fmt.Sprintf("Мастер: %s %s", rec.Slot.Master.FirstName, rec.Slot.Master.Surname)
+	if rec.Slot.Master.Phone != "" {
+		description += "\nТелефон: " + rec.Slot.Master.Phone
+	}
+	if rec.Comment != "" {
+		description += "\nКомментарий: " + rec.Comment
+	}
+	var geo *ical.Geo
+	if rec.Slot.Location != nil {
+		if venue, ok := rec.Slot.Location.Venue(); ok {
+			geo = &ical.Geo{Latitude: venue.Latitude, Longitude: venue.Longitude}
+		}
+	}
+	s.logger.Infof("Service.CalendarByClient: record_id=%d client_id=%s", recordID, clientID)
+	return ical.Marshal(ical.Event{
+		UID:         fmt.Sprintf("record-%d@timeslot-hub", rec.ID),
+		Start:       rec.Slot.StartTime,
+		End:         rec.Slot.EndTime,
+		Summary:     rec.Slot.Service.Name,
+		Description: description,
+		Location:    rec.Slot.Address(),
+		Geo:         geo,
+		Status:      status,
+		Stamp:       time.Now(),
+	}), nil
+}
+
+// recordOf загружает запись с деталями и проверяет, что она принадлежит клиенту
+func (s *Service) recordOf(recordID uint, clientID uuid.UUID) (models.Record, error) {
+	rec, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return rec, ErrRecordNotFound
+	}
+	if err != nil {
+		s.logger.Errorf("Service.recordOf: load record_id=%d failed: %v", recordID, err)
+		return rec, err
+	}
+	if rec.ClientID != clientID {
+		s.logger.Errorf("Service.recordOf: record_id=%d does not belong to client_id=%s", recordID, clientID)
+		return rec, ErrNotRecordOwner
+	}
+	return rec, nil
+}
+
+// activeRecordOf — запись клиента, которую ещё можно менять: не отменена, не отклонена и не началась
+func (s *Service) activeRecordOf(recordID uint, clientID uuid.UUID) (models.Record, error) {
+	rec, err := s.recordOf(recordID, clientID)
+	if err != nil {
+		return rec, err
+	}
+	if rec.Status == "cancel" || rec.Status == "reject" {
+		return rec, ErrRecordClosed
+	}
+	if !rec.Slot.StartTime.After(time.Now()) {
+		return rec, ErrRecordStarted
+	}
+	return rec, nil
+}
+
+// notifyMaster сообщает мастеру о действии клиента: уведомление на сайте и в Telegram (best-effort).
+// С recordID != 0 сообщение в Telegram уходит с кнопками подтверждения записи.
+func (s *Service) notifyMaster(rec models.Record, recordID uint, notifType, title, message string, extra map[string]interface{}) {
+	master := rec.Slot.Master
+	metadata := map[string]interface{}{
+		"record_id":    rec.ID,
+		"slot_id":      rec.SlotID,
+		"client_id":    rec.ClientID,
+		"client_name":  fmt.Sprintf("%s %s", rec.Client.FirstName, rec.Client.Surname),
+		"service_name": rec.Slot.Service.Name,
+		"action_url":   fmt.Sprintf("records/%d", rec.ID),
+	}
+	for k, v := range extra {
+		metadata[k] = v
+	}
+	if err := s.notificationService.CreateGeneric(master.ID, notifType, title, message, metadata); err != nil {
+		s.logger.Errorf("Service.notifyMaster: send notification failed: %v", err)
+	}
+	if master.TelegramID == 0 || s.sender == nil {
+		return
+	}
+	if recordID != 0 {
+		_ = s.sender.RecordNotify(recordID, master.TelegramID, title, message)
+		return
+	}
+	_ = s.sender.RecordStatusNotify(master.TelegramID, title, message)
+}
+
+// masterSpan форматирует время слота в таймзоне мастера
+func masterSpan(slot models.Slot) string {
+	loc := timefmt.Location(nil, slot.Master.Timezone)
+	return timefmt.Span(slot.StartTime, slot.EndTime, loc, loc)
+}
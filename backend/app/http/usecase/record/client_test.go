@@ -0,0 +1,298 @@
+package record_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/usecase/record"
+	"app/pkg/models"
+	"contract/money"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// masterMessages — сообщения мастеру в Telegram после before-го
+func (f *fixture) masterMessages(before int) []memory.TelegramMessage {
+	var out []memory.TelegramMessage
+	for _, m := range f.TG.Messages()[before:] {
+		if m.TelegramID == f.Master.TelegramID {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+func lastType(types []string) string {
+	if len(types) == 0 {
+		return ""
+	}
+	return types[len(types)-1]
+}
+
+func TestCancelByClient(t *testing.T) {
+	tests := []struct {
+		name       string
+		prepare    func(t *testing.T, f *fixture, id uint)
+		client     int
+		wantErr    error
+		wantStatus string
+	}{
+		{name: "pending record", wantStatus: "cancel"},
+		{
+			name: "confirmed record frees the slot",
+			prepare: func(t *testing.T, f *fixture, id uint) {
+				if err := f.svc.ConfirmRecord(id); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantStatus: "cancel",
+		},
+		{name: "another client", client: 1, wantErr: record.ErrNotRecordOwner, wantStatus: "pending"},
+		{
+			name: "already cancelled",
+			prepare: func(t *testing.T, f *fixture, id uint) {
+				if err := f.svc.CancelByClient(id, f.Clients[0].ID); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:    record.ErrRecordClosed,
+			wantStatus: "cancel",
+		},
+		{
+			name: "rejected by master",
+			prepare: func(t *testing.T, f *fixture, id uint) {
+				if err := f.svc.RejectRecord(id); err != nil {
+					t.Fatal(err)
+				}
+			},
+			wantErr:    record.ErrRecordClosed,
+			wantStatus: "reject",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+			if tt.prepare != nil {
+				tt.prepare(t, f, id)
+			}
+			before := len(f.TG.Messages())
+
+			err := f.svc.CancelByClient(id, f.Clients[tt.client].ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CancelByClient() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := f.Get(t, id).Status; got != tt.wantStatus {
+				t.Errorf("status = %q, want %q", got, tt.wantStatus)
+			}
+			sent := f.masterMessages(before)
+			if tt.wantErr != nil {
+				if len(sent) != 0 {
+					t.Errorf("failed cancel notified the master: %+v", sent)
+				}
+				return
+			}
+			if f.slotBooked(t) {
+				t.Error("slot is still booked after the client cancelled")
+			}
+			if len(sent) != 1 || sent[0].Kind != "record_status" || sent[0].Title != "Клиент отменил запись" {
+				t.Errorf("telegram = %+v, want one cancel message to the master", sent)
+			}
+			if got := lastType(f.notificationTypes(t, f.Master.ID)); got != "RECORD_CANCELLED" {
+				t.Errorf("master notification = %q, want RECORD_CANCELLED", got)
+			}
+		})
+	}
+}
+
+func TestMasterCannotRestoreCancelledRecord(t *testing.T) {
+	f := newFixture(t)
+	id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+	if err := f.svc.CancelByClient(id, f.Clients[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.ConfirmRecord(id); !errors.Is(err, record.ErrRecordClosed) {
+		t.Errorf("ConfirmRecord() error = %v, want %v", err, record.ErrRecordClosed)
+	}
+	if err := f.svc.UpdateRecordStatus(id, "pending"); !errors.Is(err, record.ErrRecordClosed) {
+		t.Errorf("UpdateRecordStatus() error = %v, want %v", err, record.ErrRecordClosed)
+	}
+	if got := f.Get(t, id).Status; got != "cancel" || f.slotBooked(t) {
+		t.Errorf("status = %q booked = %v, want cancel and a free slot", got, f.slotBooked(t))
+	}
+}
+
+func TestRescheduleByClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  func(t *testing.T, f *fixture) models.Slot
+		wantErr error
+	}{
+		{
+			name: "free slot of the same master",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				return f.AddSlot(t, f.Service, f.slot.StartTime.Add(24*time.Hour))
+			},
+		},
+		{
+			name:    "same slot",
+			target:  func(t *testing.T, f *fixture) models.Slot { return f.slot },
+			wantErr: record.ErrSlotUnavailable,
+		},
+		{
+			name: "slot of another master",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				// слот другого мастера с тем же service_id
+				svc := f.Service
+				svc.MasterID = f.User(t, models.User{TelegramID: 1009, FirstName: "Другой", Surname: "Мастер"}).ID
+				return f.AddSlot(t, svc, f.slot.StartTime.Add(24*time.Hour))
+			},
+			wantErr: record.ErrSlotUnavailable,
+		},
+		{
+			name: "slot of another service",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				svc := f.AddService(t, models.Service{MasterID: f.Master.ID, Name: "Окрашивание", Price: 300000})
+				return f.AddSlot(t, svc, f.slot.StartTime.Add(24*time.Hour))
+			},
+			wantErr: record.ErrSlotUnavailable,
+		},
+		{
+			name: "booked slot",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				sl := f.AddSlot(t, f.Service, f.slot.StartTime.Add(24*time.Hour))
+				rec := f.Book(t, f.svc, f.Clients[1], sl)
+				if err := f.svc.ConfirmRecord(rec.ID); err != nil {
+					t.Fatal(err)
+				}
+				return sl
+			},
+			wantErr: record.ErrSlotBooked,
+		},
+		{
+			name: "slot held for another client's payment",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				sl := f.AddSlot(t, f.Service, f.slot.StartTime.Add(24*time.Hour))
+				until := time.Now().Add(time.Hour)
+				held := models.Record{SlotID: sl.ID, ClientID: f.Clients[1].ID, Status: models.RecordAwaitingPayment, HoldUntil: &until}
+				if _, err := f.Store.Records().Create(&held); err != nil {
+					t.Fatal(err)
+				}
+				return sl
+			},
+			wantErr: record.ErrSlotHeld,
+		},
+		{
+			name: "past slot",
+			target: func(t *testing.T, f *fixture) models.Slot {
+				return f.AddSlot(t, f.Service, time.Now().Add(-2*time.Hour))
+			},
+			wantErr: record.ErrSlotUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+			if err := f.svc.ConfirmRecord(id); err != nil {
+				t.Fatal(err)
+			}
+			target := tt.target(t, f)
+			before := len(f.TG.Messages())
+
+			err := f.svc.RescheduleByClient(id, f.Clients[0].ID, target.ID)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RescheduleByClient() error = %v, want %v", err, tt.wantErr)
+			}
+			rec, err := f.Store.Records().GetRecordByIDWithDetails(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sent := f.masterMessages(before)
+			if tt.wantErr != nil {
+				if rec.SlotID != f.slot.ID || rec.Status != "confirm" || !f.slotBooked(t) || len(sent) != 0 {
+					t.Errorf("failed reschedule changed the record: %+v, telegram %+v", rec, sent)
+				}
+				return
+			}
+			if rec.SlotID != target.ID || rec.Status != "pending" {
+				t.Errorf("record = slot %d status %q, want slot %d pending", rec.SlotID, rec.Status, target.ID)
+			}
+			if f.slotBooked(t) {
+				t.Error("old slot is still booked after the record moved")
+			}
+			if len(sent) != 1 || sent[0].Kind != "record" || sent[0].RecordID != id {
+				t.Errorf("telegram = %+v, want a record notification with confirm buttons", sent)
+			}
+			// Цена в уведомлении — из снимка записи
+			if len(sent) == 1 && !strings.Contains(sent[0].Message, money.Format(rec.Price, rec.Currency, "ru")) {
+				t.Errorf("telegram message = %q, want the record price", sent[0].Message)
+			}
+			if got := lastType(f.notificationTypes(t, f.Master.ID)); got != "RECORD_RESCHEDULED" {
+				t.Errorf("master notification = %q, want RECORD_RESCHEDULED", got)
+			}
+		})
+	}
+}
+
+func TestCommentByClient(t *testing.T) {
+	f := newFixture(t)
+	id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+
+	for _, bad := range []string{"  ", strings.Repeat("я", record.MaxCommentLength+1)} {
+		if err := f.svc.CommentByClient(id, f.Clients[0].ID, bad); !errors.Is(err, record.ErrInvalidComment) {
+			t.Errorf("CommentByClient(%d chars) error = %v, want %v", len(bad), err, record.ErrInvalidComment)
+		}
+	}
+	if err := f.svc.CommentByClient(id, f.Clients[1].ID, "чужая"); !errors.Is(err, record.ErrNotRecordOwner) {
+		t.Errorf("CommentByClient() by another client error = %v, want %v", err, record.ErrNotRecordOwner)
+	}
+
+	before := len(f.TG.Messages())
+	if err := f.svc.CommentByClient(id, f.Clients[0].ID, " Опоздаю на 5 минут "); err != nil {
+		t.Fatalf("CommentByClient() error = %v", err)
+	}
+	rec, err := f.Store.Records().GetRecordByIDWithDetails(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rec.Comment != "Опоздаю на 5 минут" {
+		t.Errorf("comment = %q", rec.Comment)
+	}
+	sent := f.masterMessages(before)
+	if len(sent) != 1 || !strings.Contains(sent[0].Message, "Опоздаю на 5 минут") {
+		t.Errorf("telegram = %+v, want the comment forwarded to the master", sent)
+	}
+	if got := lastType(f.notificationTypes(t, f.Master.ID)); got != "RECORD_COMMENTED" {
+		t.Errorf("master notification = %q, want RECORD_COMMENTED", got)
+	}
+}
+
+func TestCalendarByClient(t *testing.T) {
+	f := newFixture(t)
+	id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+
+	if _, err := f.svc.CalendarByClient(id+100, f.Clients[0].ID); !errors.Is(err, record.ErrRecordNotFound) {
+		t.Errorf("CalendarByClient() for a missing record error = %v, want %v", err, record.ErrRecordNotFound)
+	}
+	if _, err := f.svc.CalendarByClient(id, f.Clients[1].ID); !errors.Is(err, record.ErrNotRecordOwner) {
+		t.Errorf("CalendarByClient() by another client error = %v, want %v", err, record.ErrNotRecordOwner)
+	}
+
+	ics, err := f.svc.CalendarByClient(id, f.Clients[0].ID)
+	if err != nil {
+		t.Fatalf("CalendarByClient() error = %v", err)
+	}
+	for _, want := range []string{"DTSTART:20300115T090000Z", "DTEND:20300115T100000Z", "SUMMARY:Стрижка", "STATUS:TENTATIVE", "Мастер: Анна Мастер"} {
+		if !strings.Contains(string(ics), want) {
+			t.Errorf("ics missing %q:\n%s", want, ics)
+		}
+	}
+
+	if err := f.svc.CancelByClient(id, f.Clients[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.svc.CalendarByClient(id, f.Clients[0].ID); !errors.Is(err, record.ErrRecordClosed) {
+		t.Errorf("CalendarByClient() for a cancelled record error = %v, want %v", err, record.ErrRecordClosed)
+	}
+}
@@ -0,0 +1,112 @@
+package record
+
+import (
+	"app/pkg/locale"
+	"app/pkg/models"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrPaymentFailed   = errors.New("payment provider is unavailable, try again later")
+	ErrAwaitingPayment = errors.New("record is awaiting payment")
+)
+
+// ConfirmPaid подтверждает запись после оплаты: слот бронируется за клиентом, остальные заявки
+// на него отклоняются, мастер и клиент получают уведомления. false — запись уже не ждёт оплаты
+// (бронь истекла, клиент отменил, мастер подтвердил другого клиента): деньги нужно вернуть.
+// Повторный вызов для подтверждённой записи возвращает true без уведомлений
+func (s *Service) ConfirmPaid(recordID uint) (bool, error) {
+	rec, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if err != nil {
+		s.logger.Errorf("Service.ConfirmPaid: load record failed: %v", err)
+		return false, err
+	}
+	switch rec.Status {
+	case "confirm":
+		return true, nil
+	case models.RecordAwaitingPayment:
+	default:
+		s.logger.Infof("Service.ConfirmPaid: record_id=%d is %s, payment is late", recordID, rec.Status)
+		return false, nil
+	}
+	if err := s.checkResources(rec.Slot); err != nil {
+		// Ресурс заняли, пока клиент платил: запись не состоится
+		s.logger.Errorf("Service.ConfirmPaid: record_id=%d: %v", recordID, err)
+		if err := s.repo.UpdateRecordStatus(recordID, "cancel"); err != nil {
+			return false, err
+		}
+		return false, nil
+	}
+	// Статус и занятость слота перепроверяются под блокировкой: пока клиент платил,
+	// мастер мог подтвердить другую заявку
+	confirmed, err := s.repo.ConfirmPaid(recordID)
+	if err != nil {
+		s.logger.Errorf("Service.ConfirmPaid: repo error: %v", err)
+		return false, err
+	}
+	if !confirmed {
+		s.logger.Infof("Service.ConfirmPaid: record_id=%d no longer awaits payment or its slot is taken", recordID)
+		return false, nil
+	}
+	rec.Status = "confirm"
+
+	// Мастер видит запись впервые — уже подтверждённой, без кнопок
+	if err := s.notificationService.CreateRecordCreatedNotification(rec.Slot.MasterID, &rec, rec.Client.FirstName, rec.Client.Surname, &rec.Slot, &rec.Slot.Service, &rec.Slot.Master); err != nil {
+		s.logger.Errorf("Service.ConfirmPaid: send notification failed: %v", err)
+	}
+	if err := s.notificationService.CreateRecordStatusNotification(rec.ClientID, &rec, "confirm", &rec.Slot, &rec.Slot.Service, &rec.Slot.Master); err != nil {
+		s.logger.Errorf("Service.ConfirmPaid: send notification failed: %v", err)
+	}
+	if s.sender != nil {
+		if rec.Slot.Master.TelegramID != 0 {
+			lang := rec.Slot.Master.Language
+			message := fmt.Sprintf(textPaid.In(lang),
+				rec.Client.FirstName, rec.Client.Surname, rec.Client.Phone, serviceTitle(rec.Slot.Service, rec), price(rec, lang), paidLine(rec, lang),
+				masterSpan(rec.Slot), addressLine(rec.Slot, lang))
+			_ = s.sender.RecordStatusNotify(rec.Slot.Master.TelegramID, textPaidTitle.In(lang), message)
+		}
+		if rec.Client.TelegramID != 0 {
+			lang := rec.Client.Language
+			message := fmt.Sprintf(textDecision.In(lang), textPaidConfirmed.In(lang),
+				serviceTitle(rec.Slot.Service, rec), price(rec, lang), paidLine(rec, lang),
+				rec.Slot.Master.FirstName, rec.Slot.Master.Surname,
+				clientSpan(rec.Client, rec.Slot), addressLine(rec.Slot, lang))
+			_ = s.sender.RecordStatusNotify(rec.Client.TelegramID, textConfirmedTitle.In(lang), message)
+			s.sendVenue(rec.Client.TelegramID, rec.Slot)
+		}
+	}
+	s.logger.Infof("Service.ConfirmPaid: record_id=%d confirmed after payment", recordID)
+	return true, nil
+}
+
+// ReleaseUnpaid снимает бронь, если платёж отменён: запись отменяется, клиент получает сообщение
+func (s *Service) ReleaseUnpaid(recordID uint) error {
+	rec, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if err != nil {
+		s.logger.Errorf("Service.ReleaseUnpaid: load record failed: %v", err)
+		return err
+	}
+	if rec.Status != models.RecordAwaitingPayment {
+		return nil
+	}
+	if err := s.repo.UpdateRecordStatus(recordID, "cancel"); err != nil {
+		s.logger.Errorf("Service.ReleaseUnpaid: repo error: %v", err)
+		return err
+	}
+	if s.sender != nil && rec.Client.TelegramID != 0 {
+		lang := rec.Client.Language
+		message := fmt.Sprintf(textUnpaid.In(lang), serviceTitle(rec.Slot.Service, rec), clientSpan(rec.Client, rec.Slot))
+		_ = s.sender.RecordStatusNotify(rec.Client.TelegramID, textUnpaidTitle.In(lang), message)
+	}
+	s.logger.Infof("Service.ReleaseUnpaid: record_id=%d cancelled", recordID)
+	return nil
+}
+
+// paidLine — «, оплачено 500 ₽» на языке lang для сообщений об оплаченной записи; пусто, если платёж не загружен
+func paidLine(rec models.Record, lang string) string {
+	if rec.Payment == nil {
+		return ""
+	}
+	return fmt.Sprintf(textPaidLine.In(lang), locale.Money(rec.Payment.Amount, rec.Payment.Currency, lang))
+}
@@ -0,0 +1,71 @@
+package record
+
+import (
+	"app/pkg/locale"
+	"app/pkg/models"
+	"contract/money"
+	"errors"
+	"time"
+)
+
+var (
+	ErrInvalidVariant = errors.New("variant does not belong to the service of the slot")
+	ErrInvalidAddOn   = errors.New("add-on does not belong to the service of the slot or is repeated")
+	ErrSlotTooShort   = errors.New("slot is shorter than the service with the chosen variant and add-ons")
+)
+
+// applyQuote считает цену и длительность записи — вариант (без него — сама услуга)
+// плюс выбранные опции — и сохраняет их снимком в заявке вместе с валютой услуги
+func applyQuote(book *models.Record, svc models.Service) error {
+	book.VariantName, book.Price, book.Duration = "", svc.Price, svc.Duration
+	book.Currency = money.Normalize(svc.Currency)
+	if book.VariantID != nil {
+		found := false
+		for _, v := range svc.Variants {
+			if v.ID == *book.VariantID {
+				book.VariantName, book.Price, book.Duration = v.Name, v.Price, v.Duration
+				found = true
+				break
+			}
+		}
+		if !found {
+			return ErrInvalidVariant
+		}
+	}
+	book.AddOns = nil
+	seen := make(map[uint]bool, len(book.AddOnIDs))
+	for _, id := range book.AddOnIDs {
+		if seen[id] {
+			return ErrInvalidAddOn
+		}
+		seen[id] = true
+		var addOn *models.ServiceAddOn
+		for i := range svc.AddOns {
+			if svc.AddOns[i].ID == id {
+				addOn = &svc.AddOns[i]
+				break
+			}
+		}
+		if addOn == nil {
+			return ErrInvalidAddOn
+		}
+		book.AddOns = append(book.AddOns, models.RecordAddOn{AddOnID: &addOn.ID, Name: addOn.Name, Price: addOn.Price, Duration: addOn.Duration})
+		book.Price += addOn.Price
+		book.Duration += addOn.Duration
+	}
+	return nil
+}
+
+// price — цена записи из снимка для текстов уведомлений на языке получателя lang
+func price(rec models.Record, lang string) string {
+	return locale.Money(rec.Price, rec.Currency, lang)
+}
+
+// fits — помещается ли запись в слот. Слоты мастер нарезает под базовую услугу,
+// поэтому длину проверяем, только если клиент выбрал вариант или опции
+func fits(slot models.Slot, rec models.Record) bool {
+	if rec.VariantName == "" && len(rec.AddOns) == 0 {
+		return true
+	}
+	return slot.EndTime.Sub(slot.StartTime) >= time.Duration(rec.Duration)*time.Minute
+}
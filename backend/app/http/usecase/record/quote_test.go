@@ -0,0 +1,141 @@
+package record_test
+
+import (
+	"app/http/usecase/record"
+	"app/pkg/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// options добавляет услуге слота варианты «Короткие» (1200 ₽, 45 мин) и «Длинные» (2000 ₽, 90 мин)
+// и опцию «Укладка» (500 ₽, 15 мин)
+func (f *fixture) options(t *testing.T) (short, long models.ServiceVariant, styling models.ServiceAddOn) {
+	t.Helper()
+	services := f.Store.Services()
+	short = models.ServiceVariant{ServiceID: f.Service.ID, Name: "Короткие", Price: 120000, Duration: 45}
+	long = models.ServiceVariant{ServiceID: f.Service.ID, Name: "Длинные", Price: 200000, Duration: 90, Position: 1}
+	styling = models.ServiceAddOn{ServiceID: f.Service.ID, Name: "Укладка", Price: 50000, Duration: 15}
+	for _, v := range []*models.ServiceVariant{&short, &long} {
+		if err := services.CreateVariant(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := services.CreateAddOn(&styling); err != nil {
+		t.Fatal(err)
+	}
+	return short, long, styling
+}
+
+func TestCreateWithOptions(t *testing.T) {
+	f := newFixture(t)
+	short, long, styling := f.options(t)
+	foreign := f.AddService(t, models.Service{MasterID: f.Master.ID, Name: "Окрашивание", Price: 400000, Duration: 120})
+	foreignVariant := models.ServiceVariant{ServiceID: foreign.ID, Name: "Корни", Price: 250000, Duration: 60}
+	if err := f.Store.Services().CreateVariant(&foreignVariant); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		variant  *uint
+		addOns   []uint
+		wantErr  error
+		price    int64
+		duration int
+	}{
+		{name: "variant of another service", variant: &foreignVariant.ID, wantErr: record.ErrInvalidVariant},
+		{name: "unknown add-on", addOns: []uint{999}, wantErr: record.ErrInvalidAddOn},
+		{name: "repeated add-on", addOns: []uint{styling.ID, styling.ID}, wantErr: record.ErrInvalidAddOn},
+		{name: "longer than the slot", variant: &long.ID, wantErr: record.ErrSlotTooShort},
+		{name: "add-on pushes past the slot end", addOns: []uint{styling.ID}, wantErr: record.ErrSlotTooShort},
+		{name: "base service", price: 150000, duration: 60},
+		{name: "variant with add-on", variant: &short.ID, addOns: []uint{styling.ID}, price: 170000, duration: 60},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// У каждого клиента одна заявка на слот: успешные случаи — разными клиентами
+			client := f.Clients[i%len(f.Clients)]
+			rec := models.Record{SlotID: f.slot.ID, ClientID: client.ID, VariantID: tt.variant, AddOnIDs: tt.addOns}
+			err := f.svc.Create(&rec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := f.Get(t, rec.ID); got.Price != tt.price || got.Duration != tt.duration || len(got.AddOns) != len(tt.addOns) {
+				t.Errorf("record = %d / %d min, %d add-ons, want %d / %d min, %d add-ons",
+					got.Price, got.Duration, len(got.AddOns), tt.price, tt.duration, len(tt.addOns))
+			}
+		})
+	}
+
+	msgs := f.TG.Messages()
+	if last := msgs[len(msgs)-1].Message; !strings.Contains(last, "Стрижка (Короткие) + Укладка") || !strings.Contains(last, "1\u00a0700\u00a0₽") {
+		t.Errorf("master message = %q, want the variant, the add-on and the total price", last)
+	}
+}
+
+func TestPriceSnapshot(t *testing.T) {
+	f := newFixture(t)
+	short, _, styling := f.options(t)
+	rec := models.Record{SlotID: f.slot.ID, ClientID: f.Clients[0].ID, VariantID: &short.ID, AddOnIDs: []uint{styling.ID}}
+	if err := f.svc.Create(&rec); err != nil {
+		t.Fatal(err)
+	}
+
+	// Прайс меняется и опция удаляется — запись хранит цену на момент записи
+	services := f.Store.Services()
+	short.Price = 140000
+	if err := services.UpdateVariant(&short); err != nil {
+		t.Fatal(err)
+	}
+	if err := services.DeleteAddOn(styling.ID); err != nil {
+		t.Fatal(err)
+	}
+	got := f.Get(t, rec.ID)
+	if got.Price != 170000 || got.Currency != "RUB" || got.VariantName != "Короткие" || *got.VariantID != short.ID {
+		t.Errorf("record = %+v, want the 1700 ₽ snapshot of «Короткие»", got)
+	}
+	if len(got.AddOns) != 1 || got.AddOns[0].Name != "Укладка" || got.AddOns[0].Price != 50000 || got.AddOns[0].AddOnID != nil {
+		t.Errorf("add-ons = %+v, want «Укладка» kept without add_on_id", got.AddOns)
+	}
+
+	// 60 минут с опцией не помещаются в получасовой слот
+	start := f.slot.StartTime.Add(24 * time.Hour)
+	half := models.Slot{MasterID: f.Master.ID, ServiceID: f.Service.ID, StartTime: start, EndTime: start.Add(30 * time.Minute)}
+	if err := f.Store.Slots().Create(&half); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.RescheduleByClient(rec.ID, f.Clients[0].ID, half.ID); !errors.Is(err, record.ErrSlotTooShort) {
+		t.Errorf("RescheduleByClient() error = %v, want ErrSlotTooShort", err)
+	}
+	if err := f.svc.RescheduleByClient(rec.ID, f.Clients[0].ID, f.AddSlot(t, f.Service, start.Add(time.Hour)).ID); err != nil {
+		t.Errorf("RescheduleByClient() to an hour slot error = %v", err)
+	}
+}
+
+func TestMessagesInRecipientLanguage(t *testing.T) {
+	f := newFixture(t)
+	if err := f.Store.Users().UpdateLanguage(f.Master.ID, "en"); err != nil {
+		t.Fatal(err)
+	}
+	rec := f.Book(t, f.svc, f.Clients[0], f.slot)
+	if err := f.svc.ConfirmRecord(rec.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	msgs := f.TG.Messages()
+	if len(msgs) != 2 {
+		t.Fatalf("telegram = %+v, want a message to the master and to the client", msgs)
+	}
+	// Мастеру — по-английски, клиенту без выбранного языка — по-русски
+	if got := msgs[0]; got.Title != "New booking from a client" || !strings.Contains(got.Message, `booked "Стрижка" (₽1,500)`) {
+		t.Errorf("master message = %q: %q, want English with ₽1,500", got.Title, got.Message)
+	}
+	if got := msgs[1]; got.Title != "Запись подтверждена ✅" || !strings.Contains(got.Message, "Услуга: Стрижка (1\u00a0500\u00a0₽)") {
+		t.Errorf("client message = %q: %q, want Russian with 1 500 ₽", got.Title, got.Message)
+	}
+}
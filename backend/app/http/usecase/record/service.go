@@ -1,8 +1,9 @@
 package record
 
 import (
-	"app/http/sender"
+	"app/http/repository/record"
 	"app/pkg/models"
+	"app/pkg/timefmt"
 	"fmt"
 	"time"
 
@@ -10,63 +11,115 @@ import (
 )
 
 func (s *Service) Create(book *models.Record) error {
-	// Проверяем, не существует ли уже запись от этого пользователя на этот слот
-	exists, err := s.repo.ExistsRecord(book.SlotID, book.ClientID)
+	// На уже занятый слот новые заявки не принимаем
+	slot, err := s.repo.GetSlotByIDWithDetails(book.SlotID)
 	if err != nil {
-		s.logger.Errorf("Service.Create (record): check existing failed: %v", err)
+		s.logger.Errorf("Service.Create (record): load slot failed: %v", err)
+		return err
+	}
+	if slot.IsBooked {
+		s.logger.Errorf("Service.Create (record): slot_id=%d is already booked", book.SlotID)
+		return ErrSlotBooked
+	}
+	if err := s.checkResources(slot); err != nil {
+		s.logger.Errorf("Service.Create (record): slot_id=%d: %v", book.SlotID, err)
+		return err
+	}
+	// Цена и длительность — по выбранному варианту и опциям; запись должна поместиться в слот
+	if err := applyQuote(book, slot.Service); err != nil {
+		s.logger.Errorf("Service.Create (record): slot_id=%d: %v", book.SlotID, err)
 		return err
 	}
-	if exists {
-		s.logger.Errorf("Service.Create (record): record already exists for slot_id=%d client_id=%s", book.SlotID, book.ClientID)
-		return fmt.Errorf("user already has a record for this slot")
+	if !fits(slot, *book) {
+		s.logger.Errorf("Service.Create (record): slot_id=%d is shorter than %d min", book.SlotID, book.Duration)
+		return ErrSlotTooShort
 	}
 
+	var due int64
+	if s.payments != nil {
+		due = slot.Service.Due(book.Price)
+	}
+	if due > 0 {
+		until := time.Now().Add(s.hold)
+		book.Status, book.HoldUntil = models.RecordAwaitingPayment, &until
+	}
+
+	// Занятость, чужую бронь и повторную заявку repo.Create проверяет атомарно со вставкой
 	bookID, err := s.repo.Create(book)
 	if err != nil {
-		s.logger.Errorf("Service.Create (record): repo error: %v", err)
+		s.logger.Errorf("Service.Create (record): slot_id=%d: %v", book.SlotID, err)
 		return err
 	}
 
-	// try send notification to master about new record (site notification)
-	// Load slot with details to get master, service info
-	slot, err := s.repo.GetSlotByIDWithDetails(book.SlotID)
-	if err != nil {
-		s.logger.Errorf("Service.Create (record): load slot failed for notification: %v", err)
-	} else {
-		// Load client for name info
-		client, err := s.repo.GetUserByID(book.ClientID)
+	if due > 0 {
+		pay, err := s.payments.Start(*book, due)
 		if err != nil {
-			s.logger.Errorf("Service.Create (record): load client failed for notification: %v", err)
-		} else {
-			if err := s.notificationService.CreateRecordCreatedNotification(slot.MasterID, book, client.FirstName, client.Surname, &slot, &slot.Service, &slot.Master); err != nil {
-				s.logger.Errorf("Service.Create (record): send notification failed: %v", err)
-			}
-			// telegram notify to master (best-effort) with detailed message
-			if slot.Master.TelegramID != 0 {
-				title := "Новая запись от клиента"
-				// Время в таймзоне мастера
-				loc := time.Local
-				if slot.Master.Timezone != "" {
-					if l, err := time.LoadLocation(slot.Master.Timezone); err == nil {
-						loc = l
-					}
-				}
-				start := slot.StartTime.In(loc).Format("02.01.2006 15:04")
-				end := slot.EndTime.In(loc).Format("15:04")
-				tzLabel := slot.Master.Timezone
-				// Добавляем телефон клиента для верификации личности
-				message := fmt.Sprintf("Клиент %s %s (тел: %s) записался на услугу \"%s\" (%s руб.)\nВремя: %s - %s (%s)",
-					client.FirstName, client.Surname, client.Phone, slot.Service.Name, fmt.Sprintf("%.0f", slot.Service.Price),
-					start, end, tzLabel)
-				_ = sender.RecordNotify(bookID, slot.Master.TelegramID, title, message)
+			// Без платежа бронь оплатить нельзя — не держим слот зря
+			s.logger.Errorf("Service.Create (record): start payment for id=%d failed: %v", bookID, err)
+			if err := s.repo.DeleteRecord(bookID); err != nil {
+				s.logger.Errorf("Service.Create (record): drop unpaid id=%d failed: %v", bookID, err)
 			}
+			return fmt.Errorf("%w: %v", ErrPaymentFailed, err)
 		}
+		book.Payment = &pay
+		// Мастер узнает о записи после оплаты (ConfirmPaid)
+		s.logger.Infof("Service.Create (record): created id=%d, awaiting payment %d until %s", bookID, due, book.HoldUntil.Format(time.RFC3339))
+		return nil
 	}
 
+	s.notifyCreated(book)
 	s.logger.Infof("Service.Create (record): created id=%d", book.ID)
 	return nil
 }
 
+// notifyCreated сообщает мастеру о новой заявке: на сайте и в Telegram с кнопками подтверждения (best-effort)
+func (s *Service) notifyCreated(book *models.Record) {
+	// Load slot with details to get master, service info
+	slot, err := s.repo.GetSlotByIDWithDetails(book.SlotID)
+	if err != nil {
+		s.logger.Errorf("Service.Create (record): load slot failed for notification: %v", err)
+		return
+	}
+	// Load client for name info
+	client, err := s.repo.GetUserByID(book.ClientID)
+	if err != nil {
+		s.logger.Errorf("Service.Create (record): load client failed for notification: %v", err)
+		return
+	}
+	if err := s.notificationService.CreateRecordCreatedNotification(slot.MasterID, book, client.FirstName, client.Surname, &slot, &slot.Service, &slot.Master); err != nil {
+		s.logger.Errorf("Service.Create (record): send notification failed: %v", err)
+	}
+	// telegram notify to master (best-effort) with detailed message
+	if slot.Master.TelegramID != 0 && s.sender != nil {
+		lang := slot.Master.Language
+		// Время в таймзоне мастера — он получатель
+		loc := timefmt.Location(nil, slot.Master.Timezone)
+		// Добавляем телефон клиента для верификации личности
+		message := fmt.Sprintf(textCreated.In(lang),
+			client.FirstName, client.Surname, client.Phone, serviceTitle(slot.Service, *book), price(*book, lang),
+			timefmt.Span(slot.StartTime, slot.EndTime, loc, loc), addressLine(slot, lang))
+		_ = s.sender.RecordNotify(book.ID, slot.Master.TelegramID, textCreatedTitle.In(lang), message)
+	}
+}
+
+// serviceTitle — название услуги с вариантом и опциями записи: «Стрижка (длинные волосы) + укладка»
+func serviceTitle(svc models.Service, rec models.Record) string {
+	title := svc.Name
+	if rec.VariantName != "" {
+		title += " (" + rec.VariantName + ")"
+	}
+	for _, a := range rec.AddOns {
+		title += " + " + a.Name
+	}
+	return title
+}
+
+var (
+	ErrSlotBooked   = record.ErrSlotBooked
+	ErrRecordExists = record.ErrRecordExists
+	ErrSlotHeld     = record.ErrSlotHeld
+)
+
 func (s *Service) GetClientRecords(client_id uuid.UUID) ([]models.Record, error) {
 	records, err := s.repo.FindRecordsByClient(client_id)
 	if err != nil {
@@ -117,6 +170,14 @@ func (s *Service) GetAllRecordsBySlot(slot_id uint) ([]models.Record, error) {
 	return records, nil
 }
 func (s *Service) ConfirmRecord(record_id uint) error {
+	if err := s.ensureNotCancelled(record_id); err != nil {
+		s.logger.Errorf("Service.ConfirmRecord: %v", err)
+		return err
+	}
+	if err := s.ensureResources(record_id); err != nil {
+		s.logger.Errorf("Service.ConfirmRecord: %v", err)
+		return err
+	}
 	// confirm in repository
 	if err := s.repo.ChangeRecordStatus(record_id, "confirm"); err != nil {
 		s.logger.Errorf("Service.ConfirmRecord: repo error: %v", err)
@@ -132,28 +193,26 @@ func (s *Service) ConfirmRecord(record_id uint) error {
 		}
 		// telegram notify to client (best-effort) with detailed message
 		client, err2 := s.repo.GetUserByID(rec.ClientID)
-		if err2 == nil && client.TelegramID != 0 {
-			title := "Запись подтверждена ✅"
-			// Время в таймзоне мастера
-			loc := time.Local
-			if rec.Slot.Master.Timezone != "" {
-				if l, err := time.LoadLocation(rec.Slot.Master.Timezone); err == nil {
-					loc = l
-				}
-			}
-			start := rec.Slot.StartTime.In(loc).Format("02.01.2006 15:04")
-			end := rec.Slot.EndTime.In(loc).Format("15:04")
-			message := fmt.Sprintf("Мастер подтвердил вашу запись\n\nУслуга: %s (%s руб.)\nМастер: %s %s\nВремя: %s - %s (%s)",
-				rec.Slot.Service.Name, fmt.Sprintf("%.0f", rec.Slot.Service.Price),
+		if err2 == nil && client.TelegramID != 0 && s.sender != nil {
+			lang := client.Language
+			// Время в таймзоне клиента, время мастера — вторым
+			when := clientSpan(client, rec.Slot)
+			message := fmt.Sprintf(textDecision.In(lang), textConfirmed.In(lang),
+				rec.Slot.Service.Name, price(rec, lang), "",
 				rec.Slot.Master.FirstName, rec.Slot.Master.Surname,
-				start, end, rec.Slot.Master.Timezone)
-			_ = sender.RecordStatusNotify(client.TelegramID, title, message)
+				when, addressLine(rec.Slot, lang))
+			_ = s.sender.RecordStatusNotify(client.TelegramID, textConfirmedTitle.In(lang), message)
+			s.sendVenue(client.TelegramID, rec.Slot)
 		}
 	}
 	s.logger.Infof("Service.ConfirmRecord: record_id=%d confirmed", record_id)
 	return nil
 }
 func (s *Service) RejectRecord(record_id uint) error {
+	if err := s.ensureNotCancelled(record_id); err != nil {
+		s.logger.Errorf("Service.RejectRecord: %v", err)
+		return err
+	}
 	// confirm in repository
 	if err := s.repo.ChangeRecordStatus(record_id, "reject"); err != nil {
 		s.logger.Errorf("Service.RejectRecord: repo error: %v", err)
@@ -164,29 +223,23 @@ func (s *Service) RejectRecord(record_id uint) error {
 	if err != nil {
 		s.logger.Errorf("Service.RejectRecord: load record failed for notification: %v", err)
 	} else {
-		if err := s.notificationService.CreateRecordStatusNotification(rec.ClientID, &rec, "confirm", &rec.Slot, &rec.Slot.Service, &rec.Slot.Master); err != nil {
+		if err := s.notificationService.CreateRecordStatusNotification(rec.ClientID, &rec, "reject", &rec.Slot, &rec.Slot.Service, &rec.Slot.Master); err != nil {
 			s.logger.Errorf("Service.RejectRecord: send notification failed: %v", err)
 		}
 		// telegram notify to client (best-effort) with detailed message
 		client, err2 := s.repo.GetUserByID(rec.ClientID)
-		if err2 == nil && client.TelegramID != 0 {
-			title := "Запись отклонена ❌"
-			loc := time.Local
-			if rec.Slot.Master.Timezone != "" {
-				if l, err := time.LoadLocation(rec.Slot.Master.Timezone); err == nil {
-					loc = l
-				}
-			}
-			start := rec.Slot.StartTime.In(loc).Format("02.01.2006 15:04")
-			end := rec.Slot.EndTime.In(loc).Format("15:04")
-			message := fmt.Sprintf("Мастер отклонил вашу запись\n\nУслуга: %s (%s руб.)\nМастер: %s %s\nВремя: %s - %s (%s)",
-				rec.Slot.Service.Name, fmt.Sprintf("%.0f", rec.Slot.Service.Price),
+		if err2 == nil && client.TelegramID != 0 && s.sender != nil {
+			lang := client.Language
+			// Время в таймзоне клиента, время мастера — вторым
+			when := clientSpan(client, rec.Slot)
+			message := fmt.Sprintf(textDecision.In(lang), textRejected.In(lang),
+				rec.Slot.Service.Name, price(rec, lang), "",
 				rec.Slot.Master.FirstName, rec.Slot.Master.Surname,
-				start, end, rec.Slot.Master.Timezone)
-			_ = sender.RecordStatusNotify(client.TelegramID, title, message)
+				when, addressLine(rec.Slot, lang))
+			_ = s.sender.RecordStatusNotify(client.TelegramID, textRejectedTitle.In(lang), message)
 		}
 	}
-	s.logger.Infof("Service.RejectRecord: record_id=%d confirmed", record_id)
+	s.logger.Infof("Service.RejectRecord: record_id=%d rejected", record_id)
 	return nil
 }
 func (s *Service) DeleteRecord(id uint) error {
@@ -203,6 +256,16 @@ func (s *Service) UpdateRecordStatus(recordID uint, status string) error {
 		s.logger.Errorf("Service.UpdateRecordStatus: invalid status: %s", status)
 		return fmt.Errorf("invalid status")
 	}
+	if err := s.ensureNotCancelled(recordID); err != nil {
+		s.logger.Errorf("Service.UpdateRecordStatus: %v", err)
+		return err
+	}
+	if status == "confirm" {
+		if err := s.ensureResources(recordID); err != nil {
+			s.logger.Errorf("Service.UpdateRecordStatus: %v", err)
+			return err
+		}
+	}
 	if err := s.repo.UpdateRecordStatus(recordID, status); err != nil {
 		s.logger.Errorf("Service.UpdateRecordStatus: repo error: %v", err)
 		return err
@@ -217,29 +280,22 @@ func (s *Service) UpdateRecordStatus(recordID uint, status string) error {
 		}
 		// telegram notify to client (best-effort) with detailed message
 		client, err2 := s.repo.GetUserByID(rec.ClientID)
-		if err2 == nil && client.TelegramID != 0 {
-			var title, emoji string
-			if status == "confirm" {
-				title = "Запись подтверждена ✅"
-				emoji = "подтвердил"
-			} else {
-				title = "Запись отклонена ❌"
-				emoji = "отклонил"
-			}
-			// Форматируем в таймзоне мастера
-			loc := time.Local
-			if rec.Slot.Master.Timezone != "" {
-				if l, err := time.LoadLocation(rec.Slot.Master.Timezone); err == nil {
-					loc = l
-				}
+		if err2 == nil && client.TelegramID != 0 && s.sender != nil {
+			title, headline := textConfirmedTitle, textConfirmed
+			if status == "reject" {
+				title, headline = textRejectedTitle, textRejected
 			}
-			start := rec.Slot.StartTime.In(loc).Format("02.01.2006 15:04")
-			end := rec.Slot.EndTime.In(loc).Format("15:04")
-			message := fmt.Sprintf("Мастер %s вашу запись\n\nУслуга: %s (%s руб.)\nМастер: %s %s\nВремя: %s - %s (%s)",
-				emoji, rec.Slot.Service.Name, fmt.Sprintf("%.0f", rec.Slot.Service.Price),
+			lang := client.Language
+			// Время в таймзоне клиента, время мастера — вторым
+			when := clientSpan(client, rec.Slot)
+			message := fmt.Sprintf(textDecision.In(lang), headline.In(lang),
+				rec.Slot.Service.Name, price(rec, lang), "",
 				rec.Slot.Master.FirstName, rec.Slot.Master.Surname,
-				start, end, rec.Slot.Master.Timezone)
-			_ = sender.RecordStatusNotify(client.TelegramID, title, message)
+				when, addressLine(rec.Slot, lang))
+			_ = s.sender.RecordStatusNotify(client.TelegramID, title.In(lang), message)
+			if status == "confirm" {
+				s.sendVenue(client.TelegramID, rec.Slot)
+			}
 		}
 	}
 	s.logger.Infof("Service.UpdateRecordStatus: record_id=%d status=%s", recordID, status)
@@ -256,3 +312,66 @@ func (s *Service) GetUpcomingRecordsByMasterTelegramID(masterTelegramID int64) (
 	s.logger.Infof("Service.GetUpcomingRecordsByMasterTelegramID: master_telegram_id=%d count=%d", masterTelegramID, len(records))
 	return records, nil
 }
+
+// sendVenue отправляет клиенту место записи картой, если у места есть координаты (best-effort)
+func (s *Service) sendVenue(telegramID int64, slot models.Slot) {
+	if slot.Location == nil {
+		return
+	}
+	if venue, ok := slot.Location.Venue(); ok {
+		_ = s.sender.VenueNotify(telegramID, venue)
+	}
+}
+
+// addressLine — строка «Адрес: …» для сообщений; пустая, если у слота нет места
+func addressLine(slot models.Slot, lang string) string {
+	if address := slot.Address(); address != "" {
+		return fmt.Sprintf(textAddress.In(lang), address)
+	}
+	return ""
+}
+
+// clientSpan форматирует время слота для клиента: в его таймзоне и во времени мастера
+func clientSpan(client models.User, slot models.Slot) string {
+	master := timefmt.Location(nil, slot.Master.Timezone)
+	return timefmt.SpanIn(client.Language, slot.StartTime, slot.EndTime, timefmt.Location(master, client.Timezone), master)
+}
+
+// ensureNotCancelled не даёт мастеру вернуть запись, которую отменил клиент
+// (например, кнопкой из старого уведомления), и решать за неоплаченную запись
+func (s *Service) ensureNotCancelled(recordID uint) error {
+	rec, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if err != nil {
+		return err
+	}
+	if rec.Status == "cancel" {
+		return ErrRecordClosed
+	}
+	if rec.Status == models.RecordAwaitingPayment {
+		return ErrAwaitingPayment
+	}
+	return nil
+}
+
+// ensureResources перед подтверждением проверяет, что ресурсы услуги не заняты
+// подтверждёнными записями других мастеров на это же время
+func (s *Service) ensureResources(recordID uint) error {
+	if s.resources == nil {
+		return nil
+	}
+	rec, err := s.repo.GetRecordByIDWithDetails(recordID)
+	if err != nil {
+		return err
+	}
+	if rec.Status == "confirm" {
+		return nil
+	}
+	return s.resources.CheckSlot(rec.Slot)
+}
+
+func (s *Service) checkResources(slot models.Slot) error {
+	if s.resources == nil {
+		return nil
+	}
+	return s.resources.CheckSlot(slot)
+}
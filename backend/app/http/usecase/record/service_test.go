@@ -0,0 +1,318 @@
+package record_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	"app/http/usecase/record"
+	"app/pkg/models"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var _ record.Repository = (*memory.RecordRepository)(nil)
+
+type fixture struct {
+	*memtest.Fixture
+	svc  *record.Service
+	slot models.Slot
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	f.slot = f.AddSlot(t, f.Service, memtest.SlotStart)
+	notifications := notification.NewService(f.Store.Notifications(), f.Logger)
+	f.svc = record.NewService(f.Store.Records(), notifications, f.Logger).WithSender(f.TG)
+	return f
+}
+
+func (f *fixture) slotBooked(t *testing.T) bool {
+	t.Helper()
+	sl, err := f.Store.Records().GetSlotByID(f.slot.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sl.IsBooked
+}
+
+func (f *fixture) notificationTypes(t *testing.T, userID uuid.UUID) []string {
+	t.Helper()
+	list, err := f.Store.Notifications().FindUserNotifications(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, n := range list {
+		types = append(types, n.Type)
+	}
+	return types
+}
+
+func TestCreate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, f *fixture) models.Record
+		wantErr error
+	}{
+		{
+			name: "new record",
+			prepare: func(t *testing.T, f *fixture) models.Record {
+				return models.Record{SlotID: f.slot.ID, ClientID: f.Clients[0].ID}
+			},
+		},
+		{
+			name: "second record from the same client",
+			prepare: func(t *testing.T, f *fixture) models.Record {
+				f.Book(t, f.svc, f.Clients[0], f.slot)
+				return models.Record{SlotID: f.slot.ID, ClientID: f.Clients[0].ID}
+			},
+			wantErr: record.ErrRecordExists,
+		},
+		{
+			name: "slot already booked",
+			prepare: func(t *testing.T, f *fixture) models.Record {
+				if err := f.svc.ConfirmRecord(f.Book(t, f.svc, f.Clients[0], f.slot).ID); err != nil {
+					t.Fatal(err)
+				}
+				return models.Record{SlotID: f.slot.ID, ClientID: f.Clients[1].ID}
+			},
+			wantErr: record.ErrSlotBooked,
+		},
+		{
+			name: "another client on a pending slot",
+			prepare: func(t *testing.T, f *fixture) models.Record {
+				f.Book(t, f.svc, f.Clients[0], f.slot)
+				return models.Record{SlotID: f.slot.ID, ClientID: f.Clients[1].ID}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			rec := tt.prepare(t, f)
+			before := len(f.TG.Messages())
+
+			err := f.svc.Create(&rec)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			sent := f.TG.Messages()[before:]
+			if tt.wantErr != nil {
+				if len(sent) != 0 {
+					t.Errorf("rejected booking sent %d telegram messages", len(sent))
+				}
+				return
+			}
+			if got := f.Get(t, rec.ID).Status; got != "pending" {
+				t.Errorf("status = %q, want pending", got)
+			}
+			if len(sent) != 1 || sent[0].Kind != "record" || sent[0].TelegramID != f.Master.TelegramID || sent[0].RecordID != rec.ID {
+				t.Errorf("telegram messages = %+v, want one record notification to the master", sent)
+			}
+			types := f.notificationTypes(t, f.Master.ID)
+			if len(types) == 0 || types[len(types)-1] != "RECORD_CREATED" {
+				t.Errorf("master notifications = %v, want RECORD_CREATED", types)
+			}
+		})
+	}
+}
+
+func TestStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name       string
+		act        func(f *fixture, first uint) error
+		wantErr    bool
+		wantFirst  string
+		wantSecond string
+		wantBooked bool
+		wantNotify string
+		wantTitle  string
+	}{
+		{
+			name:       "confirm rejects competing records",
+			act:        func(f *fixture, first uint) error { return f.svc.ConfirmRecord(first) },
+			wantFirst:  "confirm",
+			wantSecond: "reject",
+			wantBooked: true,
+			wantNotify: "RECORD_CONFIRMED",
+			wantTitle:  "Запись подтверждена ✅",
+		},
+		{
+			name:       "reject keeps slot free",
+			act:        func(f *fixture, first uint) error { return f.svc.RejectRecord(first) },
+			wantFirst:  "reject",
+			wantSecond: "pending",
+			wantNotify: "RECORD_REJECTED",
+			wantTitle:  "Запись отклонена ❌",
+		},
+		{
+			name:       "update to confirm",
+			act:        func(f *fixture, first uint) error { return f.svc.UpdateRecordStatus(first, "confirm") },
+			wantFirst:  "confirm",
+			wantSecond: "reject",
+			wantBooked: true,
+			wantNotify: "RECORD_CONFIRMED",
+			wantTitle:  "Запись подтверждена ✅",
+		},
+		{
+			name: "confirmed back to pending frees the slot",
+			act: func(f *fixture, first uint) error {
+				if err := f.svc.UpdateRecordStatus(first, "confirm"); err != nil {
+					return err
+				}
+				return f.svc.UpdateRecordStatus(first, "pending")
+			},
+			wantFirst:  "pending",
+			wantSecond: "reject",
+			wantNotify: "RECORD_CONFIRMED",
+			wantTitle:  "Запись подтверждена ✅",
+		},
+		{
+			name:       "unknown status",
+			act:        func(f *fixture, first uint) error { return f.svc.UpdateRecordStatus(first, "done") },
+			wantErr:    true,
+			wantFirst:  "pending",
+			wantSecond: "pending",
+		},
+		{
+			name:       "missing record",
+			act:        func(f *fixture, first uint) error { return f.svc.ConfirmRecord(first + 100) },
+			wantErr:    true,
+			wantFirst:  "pending",
+			wantSecond: "pending",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			first := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+			second := f.Book(t, f.svc, f.Clients[1], f.slot).ID
+
+			err := tt.act(f, first)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := f.Get(t, first).Status; got != tt.wantFirst {
+				t.Errorf("first status = %q, want %q", got, tt.wantFirst)
+			}
+			if got := f.Get(t, second).Status; got != tt.wantSecond {
+				t.Errorf("second status = %q, want %q", got, tt.wantSecond)
+			}
+			if got := f.slotBooked(t); got != tt.wantBooked {
+				t.Errorf("slot booked = %v, want %v", got, tt.wantBooked)
+			}
+
+			types := f.notificationTypes(t, f.Clients[0].ID)
+			var statusMsgs []memory.TelegramMessage
+			for _, m := range f.TG.Messages() {
+				if m.Kind == "record_status" {
+					statusMsgs = append(statusMsgs, m)
+				}
+			}
+			if tt.wantNotify == "" {
+				if len(types) != 0 || len(statusMsgs) != 0 {
+					t.Errorf("unexpected notifications: site=%v telegram=%+v", types, statusMsgs)
+				}
+				return
+			}
+			if len(types) == 0 || types[0] != tt.wantNotify {
+				t.Errorf("client notifications = %v, want %s first", types, tt.wantNotify)
+			}
+			if len(statusMsgs) == 0 || statusMsgs[0].TelegramID != f.Clients[0].TelegramID || statusMsgs[0].Title != tt.wantTitle {
+				t.Errorf("telegram = %+v, want %q to the client", statusMsgs, tt.wantTitle)
+			}
+		})
+	}
+}
+
+func TestDeleteConfirmedRecordFreesSlot(t *testing.T) {
+	f := newFixture(t)
+	id := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+	if err := f.svc.ConfirmRecord(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.DeleteRecord(id); err != nil {
+		t.Fatalf("DeleteRecord() error = %v", err)
+	}
+	if f.slotBooked(t) {
+		t.Error("slot is still booked after its only confirmed record was deleted")
+	}
+	if err := f.svc.DeleteRecord(id); err == nil {
+		t.Error("DeleteRecord() of a missing record returned nil")
+	}
+}
+
+func TestWithoutSender(t *testing.T) {
+	f := newFixture(t)
+	svc := record.NewService(f.Store.Records(), notification.NewService(f.Store.Notifications(), f.Logger), f.Logger)
+
+	rec := models.Record{SlotID: f.slot.ID, ClientID: f.Clients[0].ID}
+	if err := svc.Create(&rec); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := svc.ConfirmRecord(rec.ID); err != nil {
+		t.Fatalf("ConfirmRecord() error = %v", err)
+	}
+}
+
+func TestGetUpcomingRecordsByMasterTelegramID(t *testing.T) {
+	f := newFixture(t)
+	future := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+	if err := f.svc.ConfirmRecord(future); err != nil {
+		t.Fatal(err)
+	}
+
+	past := models.Slot{MasterID: f.Master.ID, ServiceID: f.slot.ServiceID, StartTime: time.Now().Add(-2 * time.Hour), EndTime: time.Now().Add(-time.Hour)}
+	if err := f.Store.Slots().Create(&past); err != nil {
+		t.Fatal(err)
+	}
+	old := models.Record{SlotID: past.ID, ClientID: f.Clients[1].ID}
+	if err := f.svc.Create(&old); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.ConfirmRecord(old.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := f.svc.GetUpcomingRecordsByMasterTelegramID(f.Master.TelegramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].ID != future || records[0].Slot.Service.Name != "Стрижка" || records[0].Client.ID != f.Clients[0].ID {
+		t.Fatalf("upcoming = %+v, want only record %d with details", records, future)
+	}
+
+	other, err := f.svc.GetUpcomingRecordsByMasterTelegramID(f.Clients[0].TelegramID)
+	if err != nil || len(other) != 0 {
+		t.Fatalf("upcoming for a non-master = %v, %v", other, err)
+	}
+}
+
+func TestClientRecordsByStatus(t *testing.T) {
+	f := newFixture(t)
+	first := f.Book(t, f.svc, f.Clients[0], f.slot).ID
+	if err := f.svc.RejectRecord(first); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		status string
+		want   int
+	}{
+		{status: "", want: 1},
+		{status: "reject", want: 1},
+		{status: "pending", want: 0},
+	}
+	for _, tt := range tests {
+		records, err := f.svc.GetClientRecordsByStatus(f.Clients[0].ID, tt.status)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(records) != tt.want {
+			t.Errorf("GetClientRecordsByStatus(%q) = %d records, want %d", tt.status, len(records), tt.want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package record
+
+import "app/pkg/locale"
+
+// Тексты сообщений о записях: получатель видит их на языке из своего профиля (User.Language)
+var (
+	textCreatedTitle = locale.Text{RU: "Новая запись от клиента", EN: "New booking from a client"}
+	textCreated      = locale.Text{
+		RU: "Клиент %s %s (тел: %s) записался на услугу \"%s\" (%s)\nВремя: %s%s",
+		EN: "Client %s %s (phone: %s) booked \"%s\" (%s)\nTime: %s%s",
+	}
+	textRescheduledTitle = locale.Text{RU: "Клиент перенёс запись", EN: "A client rescheduled a booking"}
+	textRescheduled      = locale.Text{
+		RU: "Клиент %s %s (тел: %s) просит перенести запись на услугу \"%s\" (%s)\nБыло: %s\nСтало: %s%s",
+		EN: "Client %s %s (phone: %s) asks to move the booking for \"%s\" (%s)\nWas: %s\nNow: %s%s",
+	}
+	textPaidTitle = locale.Text{RU: "Новая оплаченная запись 💳", EN: "New paid booking 💳"}
+	textPaid      = locale.Text{
+		RU: "Клиент %s %s (тел: %s) записался и оплатил услугу \"%s\" (%s)%s\nВремя: %s%s",
+		EN: "Client %s %s (phone: %s) booked and paid for \"%s\" (%s)%s\nTime: %s%s",
+	}
+
+	textConfirmedTitle = locale.Text{RU: "Запись подтверждена ✅", EN: "Booking confirmed ✅"}
+	textRejectedTitle  = locale.Text{RU: "Запись отклонена ❌", EN: "Booking rejected ❌"}
+	textConfirmed      = locale.Text{RU: "Мастер подтвердил вашу запись", EN: "The master confirmed your booking"}
+	textRejected       = locale.Text{RU: "Мастер отклонил вашу запись", EN: "The master rejected your booking"}
+	textPaidConfirmed  = locale.Text{RU: "Оплата получена, запись подтверждена", EN: "Payment received, your booking is confirmed"}
+	// textDecision — сообщение клиенту о решении по записи: заголовок, услуга с ценой и оплатой, мастер, время, адрес
+	textDecision = locale.Text{
+		RU: "%s\n\nУслуга: %s (%s)%s\nМастер: %s %s\nВремя: %s%s",
+		EN: "%s\n\nService: %s (%s)%s\nMaster: %s %s\nTime: %s%s",
+	}
+	textUnpaidTitle = locale.Text{RU: "Запись отменена", EN: "Booking cancelled"}
+	textUnpaid      = locale.Text{
+		RU: "Оплата не прошла, бронь снята\n\nУслуга: %s\nВремя: %s",
+		EN: "The payment did not go through, the booking is released\n\nService: %s\nTime: %s",
+	}
+
+	textPaidLine = locale.Text{RU: ", оплачено %s", EN: ", paid %s"}
+	textAddress  = locale.Text{RU: "\nАдрес: %s", EN: "\nAddress: %s"}
+)
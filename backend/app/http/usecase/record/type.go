@@ -1,22 +1,95 @@
 package record
 
 import (
-	"app/http/repository/record"
 	"app/http/usecase/notification"
+	"app/pkg/models"
+	"contract"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// Repository — хранилище заявок, с которым работает сервис записей
+type Repository interface {
+	// Create сохраняет заявку, атомарно проверив, что слот свободен, не удерживается чужой
+	// неоплаченной бронью и у клиента нет заявки на него: ErrSlotBooked, ErrSlotHeld, ErrRecordExists
+	Create(book *models.Record) (uint, error)
+	ExistsRecord(slotID uint, clientID uuid.UUID) (bool, error)
+	FindRecordsByClient(clientID uuid.UUID) ([]models.Record, error)
+	FindRecordsByClientWithStatus(clientID uuid.UUID, status string) ([]models.Record, error)
+	FindRecordsBySlot(slotID uint, status string) ([]models.Record, error)
+	FindAllRecordsBySlot(slotID uint) ([]models.Record, error)
+	FindDetailRecord(recordID uint) (models.RecordResponce, error)
+	FindUpcomingRecordsByMasterTelegramID(masterTelegramID int64) ([]models.Record, error)
+	ChangeRecordStatus(recordID uint, status string) error
+	UpdateRecordStatus(recordID uint, status string) error
+	// MoveRecord переносит заявку под блокировкой целевого слота: check проверяет перечитанную заявку
+	// и слот, затем занятость, повторная заявка и чужая бронь (ErrSlotBooked, ErrRecordExists, ErrSlotHeld)
+	MoveRecord(recordID, slotID uint, check func(rec models.Record, slot models.Slot) error) error
+	UpdateRecordComment(recordID uint, comment string) error
+	DeleteRecord(id uint) error
+	// ConfirmPaid подтверждает оплаченную заявку под блокировкой слота; false — заявка уже не ждёт
+	// оплаты или слот занят другим клиентом (заявка отменяется)
+	ConfirmPaid(recordID uint) (bool, error)
+	GetSlotByID(id uint) (models.Slot, error)
+	GetSlotByIDWithDetails(id uint) (models.Slot, error)
+	GetRecordByIDWithDetails(id uint) (models.Record, error)
+	GetUserByID(id uuid.UUID) (models.User, error)
+}
+
+// Sender — отправка уведомлений о заявках в Telegram
+type Sender interface {
+	RecordNotify(recordID uint, telegramID int64, title, message string) error
+	RecordStatusNotify(telegramID int64, title, message string) error
+	VenueNotify(telegramID int64, venue contract.Venue) error
+}
+
+// ResourceChecker — проверка свободных ресурсов (кресел, кабинетов) на время слота (usecase/resource)
+type ResourceChecker interface {
+	CheckSlot(slot models.Slot) error
+}
+
+// Payments — предоплата записей у платёжного провайдера (usecase/payment)
+type Payments interface {
+	// Start создаёт платёж на amount по записи rec; оплатить его нужно до rec.HoldUntil
+	Start(rec models.Record, amount int64) (models.Payment, error)
+}
+
 type Service struct {
-	repo                *record.Repository
+	repo                Repository
 	notificationService *notification.Service
 	logger              *logrus.Logger
+	sender              Sender
+	resources           ResourceChecker
+	payments            Payments
+	hold                time.Duration
 }
 
-func NewService(repo *record.Repository, notificationService *notification.Service, logger *logrus.Logger) *Service {
+func NewService(repo Repository, notificationService *notification.Service, logger *logrus.Logger) *Service {
 	return &Service{
 		repo:                repo,
 		notificationService: notificationService,
 		logger:              logger,
 	}
 }
+
+// WithSender подключает отправку уведомлений в Telegram
+func (s *Service) WithSender(snd Sender) *Service {
+	s.sender = snd
+	return s
+}
+
+// WithResources включает проверку ресурсов при записи, переносе и подтверждении
+func (s *Service) WithResources(rc ResourceChecker) *Service {
+	s.resources = rc
+	return s
+}
+
+// WithPayments включает предоплату: запись на услугу с задатком или полной оплатой
+// ждёт оплаты и держит слот hold, мастер узнаёт о ней только после оплаты
+func (s *Service) WithPayments(p Payments, hold time.Duration) *Service {
+	s.payments = p
+	s.hold = hold
+	return s
+}
@@ -0,0 +1,146 @@
+package resource
+
+import (
+	"app/pkg/models"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxPeriod — самый длинный период отчёта о загрузке
+const MaxPeriod = 31 * 24 * time.Hour
+
+// CheckSlot проверяет, что у всех ресурсов услуги слота есть свободная единица на всё время слота.
+// Занятыми считаются забронированные слоты всех мастеров; сам слот не учитывается, поэтому
+// проверка годится и для нового слота, и для подтверждения записи на существующий
+func (s *Service) CheckSlot(slot models.Slot) error {
+	resources, err := s.repo.FindServiceResources(slot.ServiceID)
+	if err != nil {
+		s.logger.Errorf("Resource.CheckSlot: service_id=%d: %v", slot.ServiceID, err)
+		return err
+	}
+	for _, r := range resources {
+		if !r.Active {
+			return fmt.Errorf("%w: %s", ErrResourceUnavailable, r.Name)
+		}
+		booked, err := s.repo.FindBookedSlots(r.ID, slot.StartTime, slot.EndTime, slot.ID)
+		if err != nil {
+			s.logger.Errorf("Resource.CheckSlot: resource_id=%d: %v", r.ID, err)
+			return err
+		}
+		if peak(booked, slot.StartTime, slot.EndTime) >= r.Quantity {
+			s.logger.Infof("Resource.CheckSlot: resource_id=%d is fully booked for slot %v–%v", r.ID, slot.StartTime, slot.EndTime)
+			return fmt.Errorf("%w: %s", ErrResourceBusy, r.Name)
+		}
+	}
+	return nil
+}
+
+// Utilization — загрузка ресурсов организации в [from, to) (любому участнику)
+func (s *Service) Utilization(orgID, userID uuid.UUID, from, to time.Time) ([]models.ResourceUtilization, error) {
+	if err := validPeriod(from, to); err != nil {
+		return nil, err
+	}
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	resources, err := s.repo.FindByOrganization(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return s.utilization(resources, from, to)
+}
+
+// AllUtilization — загрузка ресурсов всех организаций в [from, to) для админки
+func (s *Service) AllUtilization(from, to time.Time) ([]models.ResourceUtilization, error) {
+	if err := validPeriod(from, to); err != nil {
+		return nil, err
+	}
+	resources, err := s.repo.FindAll()
+	if err != nil {
+		return nil, err
+	}
+	return s.utilization(resources, from, to)
+}
+
+func (s *Service) utilization(resources []models.Resource, from, to time.Time) ([]models.ResourceUtilization, error) {
+	period := int(to.Sub(from) / time.Minute)
+	out := make([]models.ResourceUtilization, 0, len(resources))
+	for _, r := range resources {
+		booked, err := s.repo.FindBookedSlots(r.ID, from, to, 0)
+		if err != nil {
+			s.logger.Errorf("Resource.utilization: resource_id=%d: %v", r.ID, err)
+			return nil, err
+		}
+		u := models.ResourceUtilization{
+			ResourceID:      r.ID,
+			OrganizationID:  r.OrganizationID,
+			Name:            r.Name,
+			Quantity:        r.Quantity,
+			Active:          r.Active,
+			BookedSlots:     len(booked),
+			CapacityMinutes: r.Quantity * period,
+			Peak:            peak(booked, from, to),
+		}
+		for _, sl := range booked {
+			start, end := clip(sl, from, to)
+			u.BookedMinutes += int(end.Sub(start) / time.Minute)
+		}
+		if u.CapacityMinutes > 0 {
+			u.Utilization = float64(u.BookedMinutes) / float64(u.CapacityMinutes)
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+// peak — наибольшее число слотов, одновременно идущих внутри [from, to)
+func peak(slots []models.Slot, from, to time.Time) int {
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	events := make([]event, 0, 2*len(slots))
+	for _, sl := range slots {
+		start, end := clip(sl, from, to)
+		if !end.After(start) {
+			continue
+		}
+		events = append(events, event{start, 1}, event{end, -1})
+	}
+	// Слот, который заканчивается, освобождает ресурс для начинающегося в ту же минуту
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].at.Equal(events[j].at) {
+			return events[i].delta < events[j].delta
+		}
+		return events[i].at.Before(events[j].at)
+	})
+	var cur, max int
+	for _, e := range events {
+		cur += e.delta
+		if cur > max {
+			max = cur
+		}
+	}
+	return max
+}
+
+func clip(sl models.Slot, from, to time.Time) (time.Time, time.Time) {
+	start, end := sl.StartTime, sl.EndTime
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end
+}
+
+func validPeriod(from, to time.Time) error {
+	if !to.After(from) || to.Sub(from) > MaxPeriod {
+		return ErrInvalidPeriod
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package resource
+
+import (
+	"app/http/usecase/organization"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// List возвращает ресурсы организации (любому участнику)
+func (s *Service) List(orgID, userID uuid.UUID) ([]models.Resource, error) {
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByOrganization(orgID)
+}
+
+// Create добавляет ресурс организации (владелец или управляющий)
+func (s *Service) Create(orgID, actorID uuid.UUID, req contract.ResourceRequest) (*models.Resource, error) {
+	name, err := validate(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return nil, err
+	}
+	r := &models.Resource{OrganizationID: orgID, Name: name, Quantity: req.Quantity, Active: req.Active == nil || *req.Active}
+	if err := s.repo.Create(r); err != nil {
+		s.logger.Errorf("Resource.Create: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Resource.Create: org_id=%s resource_id=%d quantity=%d", orgID, r.ID, r.Quantity)
+	return r, nil
+}
+
+// Update меняет название, количество и доступность ресурса. Уже подтверждённые
+// записи при уменьшении количества не отменяются — ограничение действует для новых
+func (s *Service) Update(orgID, actorID uuid.UUID, id uint, req contract.ResourceRequest) (*models.Resource, error) {
+	name, err := validate(req)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return nil, err
+	}
+	r, err := s.find(orgID, id)
+	if err != nil {
+		return nil, err
+	}
+	r.Name, r.Quantity = name, req.Quantity
+	if req.Active != nil {
+		r.Active = *req.Active
+	}
+	if err := s.repo.Update(r); err != nil {
+		s.logger.Errorf("Resource.Update: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Resource.Update: org_id=%s resource_id=%d quantity=%d active=%t", orgID, id, r.Quantity, r.Active)
+	return r, nil
+}
+
+// Delete удаляет ресурс; услуги перестают его занимать
+func (s *Service) Delete(orgID, actorID uuid.UUID, id uint) error {
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return err
+	}
+	if _, err := s.find(orgID, id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(orgID, id); err != nil {
+		s.logger.Errorf("Resource.Delete: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Resource.Delete: org_id=%s resource_id=%d", orgID, id)
+	return nil
+}
+
+// ServiceResources возвращает ресурсы, которые занимает услуга организации
+func (s *Service) ServiceResources(orgID, userID uuid.UUID, serviceID uint) ([]models.Resource, error) {
+	if _, err := s.member(orgID, userID); err != nil {
+		return nil, err
+	}
+	if err := s.service(orgID, serviceID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindServiceResources(serviceID)
+}
+
+// SetServiceResources заменяет набор ресурсов услуги; все они должны принадлежать той же организации
+func (s *Service) SetServiceResources(orgID, actorID uuid.UUID, serviceID uint, resourceIDs []uint) error {
+	if _, err := s.manager(orgID, actorID); err != nil {
+		return err
+	}
+	if err := s.service(orgID, serviceID); err != nil {
+		return err
+	}
+	ids := make([]uint, 0, len(resourceIDs))
+	seen := make(map[uint]bool, len(resourceIDs))
+	for _, id := range resourceIDs {
+		if seen[id] {
+			continue
+		}
+		if _, err := s.find(orgID, id); err != nil {
+			return err
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if err := s.repo.SetServiceResources(serviceID, ids); err != nil {
+		s.logger.Errorf("Resource.SetServiceResources: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Resource.SetServiceResources: org_id=%s service_id=%d resources=%v", orgID, serviceID, ids)
+	return nil
+}
+
+func (s *Service) find(orgID uuid.UUID, id uint) (*models.Resource, error) {
+	r, err := s.repo.FindByID(orgID, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrResourceNotFound
+	}
+	return r, err
+}
+
+func (s *Service) service(orgID uuid.UUID, serviceID uint) error {
+	_, err := s.orgs.FindService(orgID, serviceID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrServiceNotFound
+	}
+	return err
+}
+
+// member и manager — права как в usecase/organization
+func (s *Service) member(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	return organization.RequireMember(s.orgs, orgID, userID)
+}
+
+func (s *Service) manager(orgID, userID uuid.UUID) (*models.OrganizationMember, error) {
+	return organization.RequireManager(s.orgs, orgID, userID)
+}
+
+func validate(req contract.ResourceRequest) (string, error) {
+	name := strings.TrimSpace(req.Name)
+	if n := utf8.RuneCountInString(name); n < 1 || n > 100 || req.Quantity <= 0 {
+		return "", ErrInvalidResource
+	}
+	return name, nil
+}
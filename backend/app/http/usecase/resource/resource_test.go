@@ -0,0 +1,228 @@
+package resource_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	"app/http/usecase/organization"
+	"app/http/usecase/record"
+	"app/http/usecase/resource"
+	"app/http/usecase/slot"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"testing"
+	"time"
+)
+
+var (
+	_ resource.Repository    = (*memory.ResourceRepository)(nil)
+	_ resource.Organizations = (*memory.OrganizationRepository)(nil)
+)
+
+// fixture — салон с владельцем и двумя мастерами (первый — мастер memtest), у каждого своя услуга лазерной эпиляции
+type fixture struct {
+	*memtest.Fixture
+	svc      *resource.Service
+	records  *record.Service
+	slots    *slot.Service
+	org      *models.Organization
+	owner    models.User
+	masters  []models.User
+	services []models.Service
+	laser    *models.Resource
+}
+
+var day = time.Date(2030, 1, 15, 0, 0, 0, 0, time.UTC)
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	f.svc = resource.NewService(f.Store.Resources(), f.Store.Organizations(), f.Logger)
+	f.records = record.NewService(f.Store.Records(), notification.NewService(f.Store.Notifications(), f.Logger), f.Logger).
+		WithResources(f.svc)
+	f.slots = slot.NewService(f.Store.Slots(), f.Logger).WithResources(f.svc)
+	orgs := organization.NewService(f.Store.Organizations(), f.Store.Users(), f.records, f.Logger)
+
+	f.owner = f.User(t, models.User{TelegramID: 1003, FirstName: "Владелец"})
+	org, err := orgs.Create(f.owner.ID, "Салон")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.org = org
+	for _, m := range []models.User{f.Master, f.User(t, models.User{TelegramID: 1002, FirstName: "Ольга"})} {
+		if _, err := orgs.AddMember(org.ID, f.owner.ID, contract.OrganizationMemberAdd{TelegramID: m.TelegramID, Role: models.OrgRoleMaster}); err != nil {
+			t.Fatal(err)
+		}
+		svc, err := orgs.CreateService(org.ID, f.owner.ID, contract.OrganizationService{MasterID: m.ID, Name: "Лазер", Price: 300000, Duration: 60})
+		if err != nil {
+			t.Fatal(err)
+		}
+		f.masters = append(f.masters, m)
+		f.services = append(f.services, *svc)
+	}
+
+	f.laser, err = f.svc.Create(org.ID, f.owner.ID, contract.ResourceRequest{Name: " Лазерный кабинет ", Quantity: 1})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	for _, svc := range f.services {
+		if err := f.svc.SetServiceResources(org.ID, f.owner.ID, svc.ID, []uint{f.laser.ID, f.laser.ID}); err != nil {
+			t.Fatalf("SetServiceResources() error = %v", err)
+		}
+	}
+	return f
+}
+
+// addSlot создаёт слот i-го мастера на [day+hour, +minutes) без проверки ресурсов
+func (f *fixture) addSlot(t *testing.T, i int, hour, minutes int) models.Slot {
+	t.Helper()
+	start := day.Add(time.Duration(hour) * time.Hour)
+	sl := models.Slot{MasterID: f.masters[i].ID, ServiceID: f.services[i].ID, StartTime: start, EndTime: start.Add(time.Duration(minutes) * time.Minute)}
+	if err := f.Store.Slots().Create(&sl); err != nil {
+		t.Fatal(err)
+	}
+	return sl
+}
+
+func TestManageResources(t *testing.T) {
+	f := newFixture(t)
+	if f.laser.Name != "Лазерный кабинет" || !f.laser.Active {
+		t.Fatalf("Create() = %+v, want trimmed active resource", f.laser)
+	}
+	if _, err := f.svc.Create(f.org.ID, f.owner.ID, contract.ResourceRequest{Name: "Кресло", Quantity: 0}); !errors.Is(err, resource.ErrInvalidResource) {
+		t.Errorf("Create(quantity 0) error = %v, want ErrInvalidResource", err)
+	}
+	if _, err := f.svc.Create(f.org.ID, f.masters[0].ID, contract.ResourceRequest{Name: "Кресло", Quantity: 3}); !errors.Is(err, organization.ErrForbidden) {
+		t.Errorf("Create() by master error = %v, want ErrForbidden", err)
+	}
+	if _, err := f.svc.List(f.org.ID, f.Clients[0].ID); !errors.Is(err, organization.ErrNotMember) {
+		t.Errorf("List() by outsider error = %v, want ErrNotMember", err)
+	}
+
+	inactive := false
+	got, err := f.svc.Update(f.org.ID, f.owner.ID, f.laser.ID, contract.ResourceRequest{Name: "Лазер", Quantity: 2, Active: &inactive})
+	if err != nil || got.Quantity != 2 || got.Active {
+		t.Fatalf("Update() = %+v, %v", got, err)
+	}
+
+	list, err := f.svc.ServiceResources(f.org.ID, f.masters[0].ID, f.services[0].ID)
+	if err != nil || len(list) != 1 {
+		t.Fatalf("ServiceResources() = %+v, %v, want the laser once", list, err)
+	}
+
+	// Ресурс другой организации к услуге не привязать
+	other, err := organization.NewService(f.Store.Organizations(), f.Store.Users(), f.records, f.Logger).Create(f.Clients[0].ID, "Другой салон")
+	if err != nil {
+		t.Fatal(err)
+	}
+	foreign, err := f.svc.Create(other.ID, f.Clients[0].ID, contract.ResourceRequest{Name: "Кресло", Quantity: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := f.svc.SetServiceResources(f.org.ID, f.owner.ID, f.services[0].ID, []uint{foreign.ID}); !errors.Is(err, resource.ErrResourceNotFound) {
+		t.Errorf("SetServiceResources(foreign) error = %v, want ErrResourceNotFound", err)
+	}
+	if err := f.svc.SetServiceResources(other.ID, f.Clients[0].ID, f.services[0].ID, nil); !errors.Is(err, resource.ErrServiceNotFound) {
+		t.Errorf("SetServiceResources(foreign service) error = %v, want ErrServiceNotFound", err)
+	}
+
+	if err := f.svc.Delete(f.org.ID, f.owner.ID, f.laser.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if list, _ := f.svc.ServiceResources(f.org.ID, f.owner.ID, f.services[0].ID); len(list) != 0 {
+		t.Errorf("ServiceResources() after delete = %+v, want none", list)
+	}
+}
+
+func TestCapacityAcrossMasters(t *testing.T) {
+	f := newFixture(t)
+	first := f.addSlot(t, 0, 10, 60)
+	overlapping := f.addSlot(t, 1, 10, 30)
+	after := f.addSlot(t, 1, 11, 60)
+
+	firstRec := f.Book(t, f.records, f.Clients[0], first).ID
+	otherRec := f.Book(t, f.records, f.Clients[0], overlapping).ID
+	if err := f.records.ConfirmRecord(firstRec); err != nil {
+		t.Fatalf("ConfirmRecord() error = %v", err)
+	}
+
+	// Кабинет занят первым мастером: второй не может ни подтвердить, ни принять новую запись
+	if err := f.records.ConfirmRecord(otherRec); !errors.Is(err, resource.ErrResourceBusy) {
+		t.Errorf("ConfirmRecord(overlapping) error = %v, want ErrResourceBusy", err)
+	}
+	if err := f.records.UpdateRecordStatus(otherRec, "confirm"); !errors.Is(err, resource.ErrResourceBusy) {
+		t.Errorf("UpdateRecordStatus(overlapping) error = %v, want ErrResourceBusy", err)
+	}
+	if err := f.records.Create(&models.Record{SlotID: overlapping.ID, ClientID: f.masters[0].ID}); !errors.Is(err, resource.ErrResourceBusy) {
+		t.Errorf("Create(overlapping) error = %v, want ErrResourceBusy", err)
+	}
+	start := day.Add(10*time.Hour + 30*time.Minute)
+	err := f.slots.CreateSlot(&models.Slot{MasterID: f.masters[1].ID, ServiceID: f.services[1].ID, StartTime: start, EndTime: start.Add(time.Hour)})
+	if !errors.Is(err, resource.ErrResourceBusy) {
+		t.Errorf("CreateSlot(overlapping) error = %v, want ErrResourceBusy", err)
+	}
+
+	// Слот сразу после окончания — свободен
+	if err := f.records.ConfirmRecord(f.Book(t, f.records, f.Clients[0], after).ID); err != nil {
+		t.Errorf("ConfirmRecord(back to back) error = %v", err)
+	}
+
+	// Второй кабинет снимает ограничение
+	if _, err := f.svc.Update(f.org.ID, f.owner.ID, f.laser.ID, contract.ResourceRequest{Name: "Лазер", Quantity: 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.records.ConfirmRecord(otherRec); err != nil {
+		t.Errorf("ConfirmRecord() with two rooms error = %v", err)
+	}
+}
+
+func TestUnavailableResource(t *testing.T) {
+	f := newFixture(t)
+	sl := f.addSlot(t, 0, 10, 60)
+	inactive := false
+	if _, err := f.svc.Update(f.org.ID, f.owner.ID, f.laser.ID, contract.ResourceRequest{Name: "Лазер", Quantity: 1, Active: &inactive}); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.records.Create(&models.Record{SlotID: sl.ID, ClientID: f.Clients[0].ID}); !errors.Is(err, resource.ErrResourceUnavailable) {
+		t.Errorf("Create() error = %v, want ErrResourceUnavailable", err)
+	}
+	start := day.Add(14 * time.Hour)
+	err := f.slots.CreateSlot(&models.Slot{MasterID: f.masters[0].ID, ServiceID: f.services[0].ID, StartTime: start, EndTime: start.Add(time.Hour)})
+	if !errors.Is(err, resource.ErrResourceUnavailable) {
+		t.Errorf("CreateSlot() error = %v, want ErrResourceUnavailable", err)
+	}
+}
+
+func TestUtilization(t *testing.T) {
+	f := newFixture(t)
+	if _, err := f.svc.Update(f.org.ID, f.owner.ID, f.laser.ID, contract.ResourceRequest{Name: "Лазер", Quantity: 2}); err != nil {
+		t.Fatal(err)
+	}
+	for _, sl := range []models.Slot{f.addSlot(t, 0, 10, 60), f.addSlot(t, 1, 10, 30), f.addSlot(t, 1, 12, 60)} {
+		if err := f.records.ConfirmRecord(f.Book(t, f.records, f.Clients[0], sl).ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	f.addSlot(t, 0, 15, 60) // свободный слот ресурс не занимает
+
+	got, err := f.svc.Utilization(f.org.ID, f.masters[0].ID, day, day.AddDate(0, 0, 1))
+	if err != nil || len(got) != 1 {
+		t.Fatalf("Utilization() = %+v, %v", got, err)
+	}
+	u := got[0]
+	if u.BookedSlots != 3 || u.BookedMinutes != 150 || u.CapacityMinutes != 2*24*60 || u.Peak != 2 {
+		t.Errorf("Utilization() = %+v, want 3 slots, 150 of 2880 minutes, peak 2", u)
+	}
+	if want := 150.0 / 2880; u.Utilization != want {
+		t.Errorf("Utilization = %v, want %v", u.Utilization, want)
+	}
+
+	all, err := f.svc.AllUtilization(day, day.AddDate(0, 0, 1))
+	if err != nil || len(all) != 1 || all[0].OrganizationID != f.org.ID {
+		t.Errorf("AllUtilization() = %+v, %v", all, err)
+	}
+	if _, err := f.svc.AllUtilization(day, day.AddDate(0, 2, 0)); !errors.Is(err, resource.ErrInvalidPeriod) {
+		t.Errorf("AllUtilization(two months) error = %v, want ErrInvalidPeriod", err)
+	}
+}
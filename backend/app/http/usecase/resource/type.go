@@ -0,0 +1,55 @@
+package resource
+
+import (
+	"app/pkg/models"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrResourceNotFound    = errors.New("resource not found in the organization")
+	ErrInvalidResource     = errors.New("resource needs a name of 1..100 characters and a positive quantity")
+	ErrServiceNotFound     = errors.New("service not found in the organization")
+	ErrInvalidPeriod       = errors.New("period must be from 1 to 31 days")
+	ErrResourceBusy        = errors.New("resource is fully booked at this time")
+	ErrResourceUnavailable = errors.New("resource is unavailable")
+)
+
+// Repository — ресурсы, их привязка к услугам и занятые ими слоты
+type Repository interface {
+	Create(r *models.Resource) error
+	FindByID(orgID uuid.UUID, id uint) (*models.Resource, error)
+	FindByOrganization(orgID uuid.UUID) ([]models.Resource, error)
+	FindAll() ([]models.Resource, error)
+	Update(r *models.Resource) error
+	Delete(orgID uuid.UUID, id uint) error
+
+	SetServiceResources(serviceID uint, resourceIDs []uint) error
+	FindServiceResources(serviceID uint) ([]models.Resource, error)
+	// FindBookedSlots — забронированные слоты услуг с ресурсом resourceID,
+	// пересекающиеся с [from, to), кроме слота exceptSlotID
+	FindBookedSlots(resourceID uint, from, to time.Time, exceptSlotID uint) ([]models.Slot, error)
+}
+
+// Organizations — участники и услуги организации (repository/organization)
+type Organizations interface {
+	FindMember(orgID, userID uuid.UUID) (*models.OrganizationMember, error)
+	FindService(orgID uuid.UUID, serviceID uint) (*models.Service, error)
+}
+
+type Service struct {
+	repo   Repository
+	orgs   Organizations
+	logger *logrus.Logger
+}
+
+func NewService(repo Repository, orgs Organizations, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:   repo,
+		orgs:   orgs,
+		logger: logger,
+	}
+}
@@ -0,0 +1,303 @@
+package review
+
+import (
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Create сохраняет отзыв клиента о его завершённой записи: один отзыв на запись.
+// Мастер получает уведомление с оценкой
+func (s *Service) Create(clientID uuid.UUID, recordID uint, req contract.ReviewRequest) (*models.Review, error) {
+	text, err := validate(req)
+	if err != nil {
+		return nil, err
+	}
+	rec, err := s.records.GetRecordByIDWithDetails(recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRecordNotFound
+	}
+	if err != nil {
+		s.logger.Errorf("Review.Create: load record failed: %v", err)
+		return nil, err
+	}
+	if rec.ClientID != clientID {
+		return nil, ErrNotRecordOwner
+	}
+	if !rec.Completed(s.now()) {
+		return nil, ErrRecordNotCompleted
+	}
+
+	serviceID := rec.Slot.ServiceID
+	rv := &models.Review{
+		RecordID:  &rec.ID,
+		ClientID:  &clientID,
+		MasterID:  rec.Slot.MasterID,
+		ServiceID: &serviceID,
+		Rating:    req.Rating,
+		Text:      text,
+	}
+	if err := s.repo.Create(rv); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrReviewExists
+		}
+		s.logger.Errorf("Review.Create: repo error: %v", err)
+		return nil, err
+	}
+	s.notifyMaster(rec, rv, "REVIEW_CREATED", "Новый отзыв")
+	s.logger.Infof("Review.Create: review_id=%d record_id=%d rating=%d", rv.ID, recordID, rv.Rating)
+	return rv, nil
+}
+
+// UpdateByClient меняет оценку и текст своего отзыва; ответ мастера и модерация сохраняются
+func (s *Service) UpdateByClient(clientID uuid.UUID, recordID uint, req contract.ReviewRequest) (*models.Review, error) {
+	text, err := validate(req)
+	if err != nil {
+		return nil, err
+	}
+	rv, err := s.repo.FindByRecord(recordID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		s.logger.Errorf("Review.UpdateByClient: load failed: %v", err)
+		return nil, err
+	}
+	if rv.ClientID == nil || *rv.ClientID != clientID {
+		return nil, ErrNotRecordOwner
+	}
+	if rv.Rating == req.Rating && rv.Text == text {
+		return rv, nil
+	}
+	rv.Rating, rv.Text = req.Rating, text
+	if err := s.repo.Update(rv); err != nil {
+		s.logger.Errorf("Review.UpdateByClient: repo error: %v", err)
+		return nil, err
+	}
+	if rec, err := s.records.GetRecordByIDWithDetails(recordID); err == nil {
+		s.notifyMaster(rec, rv, "REVIEW_UPDATED", "Клиент изменил отзыв")
+	}
+	s.logger.Infof("Review.UpdateByClient: review_id=%d rating=%d", rv.ID, rv.Rating)
+	return rv, nil
+}
+
+// Reply сохраняет публичный ответ мастера на отзыв о нём; пустой текст удаляет ответ.
+// Клиент получает уведомление о новом ответе
+func (s *Service) Reply(masterID uuid.UUID, reviewID uint, text string) (*models.Review, error) {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) > MaxReplyLength {
+		return nil, ErrInvalidReply
+	}
+	rv, err := s.find(reviewID)
+	if err != nil {
+		return nil, err
+	}
+	if rv.MasterID != masterID {
+		return nil, ErrNotReviewMaster
+	}
+	if rv.Reply == text {
+		return rv, nil
+	}
+	rv.Reply, rv.RepliedAt = text, nil
+	if text != "" {
+		now := s.now()
+		rv.RepliedAt = &now
+	}
+	if err := s.repo.Update(rv); err != nil {
+		s.logger.Errorf("Review.Reply: repo error: %v", err)
+		return nil, err
+	}
+	if text != "" {
+		s.notifyClient(rv)
+	}
+	s.logger.Infof("Review.Reply: review_id=%d replied=%t", rv.ID, text != "")
+	return rv, nil
+}
+
+// Moderate скрывает отзыв (он пропадает из выдачи и рейтинга) или отмечает его для проверки
+func (s *Service) Moderate(reviewID uint, m contract.ReviewModeration) (contract.Review, error) {
+	if m.Hidden == nil && m.Flagged == nil {
+		return contract.Review{}, ErrInvalidModeration
+	}
+	rv, err := s.find(reviewID)
+	if err != nil {
+		return contract.Review{}, err
+	}
+	if m.Hidden != nil {
+		rv.Hidden = *m.Hidden
+	}
+	if m.Flagged != nil {
+		rv.Flagged = *m.Flagged
+	}
+	if err := s.repo.Update(rv); err != nil {
+		s.logger.Errorf("Review.Moderate: repo error: %v", err)
+		return contract.Review{}, err
+	}
+	s.logger.Infof("Review.Moderate: review_id=%d hidden=%t flagged=%t", rv.ID, rv.Hidden, rv.Flagged)
+	return moderated(*rv), nil
+}
+
+// MasterReviews — видимые отзывы о мастере и его рейтинг
+func (s *Service) MasterReviews(masterID uuid.UUID, limit, offset int) (contract.ReviewList, error) {
+	return s.list(models.ReviewFilter{MasterID: &masterID}, limit, offset)
+}
+
+// ServiceReviews — видимые отзывы об услуге и её рейтинг
+func (s *Service) ServiceReviews(serviceID uint, limit, offset int) (contract.ReviewList, error) {
+	return s.list(models.ReviewFilter{ServiceID: &serviceID}, limit, offset)
+}
+
+// Moderation — отзывы для администратора, включая скрытые; hidden и flagged сужают выдачу
+func (s *Service) Moderation(hidden, flagged *bool, limit, offset int) ([]contract.Review, error) {
+	f := models.ReviewFilter{Hidden: hidden, Flagged: flagged}
+	f.Limit, f.Offset = page(limit, offset)
+	list, err := s.repo.Find(f)
+	if err != nil {
+		s.logger.Errorf("Review.Moderation: repo error: %v", err)
+		return nil, err
+	}
+	out := make([]contract.Review, 0, len(list))
+	for _, rv := range list {
+		out = append(out, moderated(rv))
+	}
+	return out, nil
+}
+
+func (s *Service) list(f models.ReviewFilter, limit, offset int) (contract.ReviewList, error) {
+	visible := false
+	f.Hidden = &visible
+	summary, err := s.repo.Summary(f)
+	if err != nil {
+		s.logger.Errorf("Review.list: summary failed: %v", err)
+		return contract.ReviewList{}, err
+	}
+	f.Limit, f.Offset = page(limit, offset)
+	list, err := s.repo.Find(f)
+	if err != nil {
+		s.logger.Errorf("Review.list: repo error: %v", err)
+		return contract.ReviewList{}, err
+	}
+	out := contract.ReviewList{
+		Message: "Success",
+		Summary: summary,
+		Data:    make([]contract.Review, 0, len(list)),
+		Limit:   f.Limit,
+		Offset:  f.Offset,
+	}
+	for _, rv := range list {
+		out.Data = append(out.Data, public(rv))
+	}
+	return out, nil
+}
+
+func (s *Service) find(id uint) (*models.Review, error) {
+	rv, err := s.repo.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrReviewNotFound
+	}
+	if err != nil {
+		s.logger.Errorf("Review.find: load failed: %v", err)
+		return nil, err
+	}
+	return rv, nil
+}
+
+// notifyMaster сообщает мастеру об отзыве в приложении и в Telegram
+func (s *Service) notifyMaster(rec models.Record, rv *models.Review, notifType, title string) {
+	master := rec.Slot.Master
+	message := fmt.Sprintf("%s оценил(а) услугу \"%s\": %s", rec.Client.FirstName, rec.Slot.Service.Name, Stars(rv.Rating))
+	if rv.Text != "" {
+		message += "\n" + rv.Text
+	}
+	if s.notificationService != nil {
+		metadata := map[string]interface{}{
+			"review_id":  rv.ID,
+			"record_id":  rec.ID,
+			"rating":     rv.Rating,
+			"action_url": fmt.Sprintf("reviews/%d", rv.ID),
+		}
+		if err := s.notificationService.CreateGeneric(master.ID, notifType, title, message, metadata); err != nil {
+			s.logger.Errorf("Review.notifyMaster: send notification failed: %v", err)
+		}
+	}
+	if master.TelegramID != 0 && s.sender != nil {
+		_ = s.sender.RecordStatusNotify(master.TelegramID, title, message)
+	}
+}
+
+// notifyClient сообщает клиенту об ответе мастера на его отзыв
+func (s *Service) notifyClient(rv *models.Review) {
+	if rv.Client == nil {
+		return
+	}
+	title := "Мастер ответил на ваш отзыв"
+	message := rv.Reply
+	if s.notificationService != nil {
+		metadata := map[string]interface{}{"review_id": rv.ID}
+		if err := s.notificationService.CreateGeneric(rv.Client.ID, "REVIEW_REPLIED", title, message, metadata); err != nil {
+			s.logger.Errorf("Review.notifyClient: send notification failed: %v", err)
+		}
+	}
+	if rv.Client.TelegramID != 0 && s.sender != nil {
+		_ = s.sender.RecordStatusNotify(rv.Client.TelegramID, title, message)
+	}
+}
+
+// Stars — оценка звёздами: «★★★★☆»
+func Stars(rating int) string {
+	rating = min(max(rating, 0), 5)
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
+
+// validate проверяет оценку и возвращает текст без пробелов по краям
+func validate(req contract.ReviewRequest) (string, error) {
+	if req.Rating < 1 || req.Rating > 5 {
+		return "", ErrInvalidRating
+	}
+	text := strings.TrimSpace(req.Text)
+	if utf8.RuneCountInString(text) > MaxTextLength {
+		return "", ErrInvalidText
+	}
+	return text, nil
+}
+
+func page(limit, offset int) (int, int) {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	return min(limit, MaxLimit), max(offset, 0)
+}
+
+// public — отзыв в публичной выдаче: клиент только по имени
+func public(rv models.Review) contract.Review {
+	out := contract.Review{
+		ID:        rv.ID,
+		MasterID:  rv.MasterID,
+		ServiceID: rv.ServiceID,
+		Rating:    rv.Rating,
+		Text:      rv.Text,
+		Reply:     rv.Reply,
+		RepliedAt: rv.RepliedAt,
+		CreatedAt: rv.CreatedAt,
+	}
+	if rv.Service != nil {
+		out.ServiceName = rv.Service.Name
+	}
+	if rv.Client != nil {
+		out.ClientName = rv.Client.FirstName
+	}
+	return out
+}
+
+// moderated — отзыв для администратора: с записью и состоянием модерации
+func moderated(rv models.Review) contract.Review {
+	out := public(rv)
+	out.RecordID, out.Hidden, out.Flagged = rv.RecordID, rv.Hidden, rv.Flagged
+	return out
+}
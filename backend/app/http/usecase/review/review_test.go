@@ -0,0 +1,193 @@
+package review_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	"app/http/usecase/review"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var (
+	_ review.Repository = (*memory.ReviewRepository)(nil)
+	_ review.Records    = (*memory.RecordRepository)(nil)
+)
+
+type fixture struct {
+	*memtest.Fixture
+	svc *review.Service
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	notifications := notification.NewService(f.Store.Notifications(), f.Logger)
+	f.svc = review.NewService(f.Store.Reviews(), f.Store.Records(), notifications, f.Logger).WithSender(f.TG)
+	return f
+}
+
+// record создаёт запись клиента со статусом status на часовой слот, начавшийся ago назад
+func (f *fixture) record(t *testing.T, client models.User, ago time.Duration, status string) uint {
+	t.Helper()
+	return f.Visit(t, client, time.Now().Add(-ago).Truncate(time.Minute), status).ID
+}
+
+func (f *fixture) rating(t *testing.T) (float64, int, float64, int) {
+	t.Helper()
+	master, err := f.Store.Users().FindByTelegramID(f.Master.TelegramID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc, err := f.Store.Services().GetService(f.Service.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return master.Rating, master.RatingCount, svc.Rating, svc.RatingCount
+}
+
+func TestCreate(t *testing.T) {
+	f := newFixture(t)
+	stranger := f.Clients[1]
+	done := f.record(t, f.Clients[0], 3*time.Hour, "confirm")
+	upcoming := f.record(t, f.Clients[0], -24*time.Hour, "confirm")
+	ongoing := f.record(t, f.Clients[0], 30*time.Minute, "confirm")
+	cancelled := f.record(t, f.Clients[0], 5*time.Hour, "cancel")
+
+	tests := []struct {
+		name     string
+		client   models.User
+		recordID uint
+		req      contract.ReviewRequest
+		wantErr  error
+	}{
+		{name: "zero rating", client: f.Clients[0], recordID: done, req: contract.ReviewRequest{Rating: 0}, wantErr: review.ErrInvalidRating},
+		{name: "rating above five", client: f.Clients[0], recordID: done, req: contract.ReviewRequest{Rating: 6}, wantErr: review.ErrInvalidRating},
+		{name: "text too long", client: f.Clients[0], recordID: done, req: contract.ReviewRequest{Rating: 5, Text: strings.Repeat("я", review.MaxTextLength+1)}, wantErr: review.ErrInvalidText},
+		{name: "unknown record", client: f.Clients[0], recordID: 999, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrRecordNotFound},
+		{name: "another client", client: stranger, recordID: done, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrNotRecordOwner},
+		{name: "upcoming", client: f.Clients[0], recordID: upcoming, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrRecordNotCompleted},
+		{name: "slot not ended", client: f.Clients[0], recordID: ongoing, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrRecordNotCompleted},
+		{name: "cancelled", client: f.Clients[0], recordID: cancelled, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrRecordNotCompleted},
+		{name: "completed", client: f.Clients[0], recordID: done, req: contract.ReviewRequest{Rating: 4, Text: "  Отлично  "}},
+		{name: "second review", client: f.Clients[0], recordID: done, req: contract.ReviewRequest{Rating: 5}, wantErr: review.ErrReviewExists},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rv, err := f.svc.Create(tt.client.ID, tt.recordID, tt.req)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Create() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && (rv.Text != "Отлично" || rv.MasterID != f.Master.ID || *rv.ServiceID != f.Service.ID) {
+				t.Errorf("Create() = %+v", rv)
+			}
+		})
+	}
+
+	msgs := f.TG.Messages()
+	if len(msgs) != 1 || msgs[0].TelegramID != f.Master.TelegramID || !strings.Contains(msgs[0].Message, review.Stars(4)) {
+		t.Errorf("telegram = %+v, want one message to the master with the stars", msgs)
+	}
+	list, err := f.Store.Notifications().FindUserNotifications(f.Master.ID)
+	if err != nil || len(list) != 1 || list[0].Type != "REVIEW_CREATED" {
+		t.Errorf("notifications = %+v, %v, want REVIEW_CREATED", list, err)
+	}
+}
+
+func TestRating(t *testing.T) {
+	f := newFixture(t)
+	other := f.Clients[1]
+	first := f.record(t, f.Clients[0], 3*time.Hour, "confirm")
+	second := f.record(t, other, 5*time.Hour, "confirm")
+
+	if _, err := f.svc.Create(f.Clients[0].ID, first, contract.ReviewRequest{Rating: 5}); err != nil {
+		t.Fatal(err)
+	}
+	rv, err := f.svc.Create(other.ID, second, contract.ReviewRequest{Rating: 2, Text: "Долго ждала"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, n, sr, sn := f.rating(t); r != 3.5 || n != 2 || sr != 3.5 || sn != 2 {
+		t.Fatalf("rating = %v/%d, service %v/%d, want 3.5/2", r, n, sr, sn)
+	}
+
+	// Изменить оценку может только автор отзыва
+	if _, err := f.svc.UpdateByClient(f.Clients[0].ID, second, contract.ReviewRequest{Rating: 1}); !errors.Is(err, review.ErrNotRecordOwner) {
+		t.Fatalf("UpdateByClient() by another client error = %v", err)
+	}
+	if _, err := f.svc.UpdateByClient(other.ID, second, contract.ReviewRequest{Rating: 3, Text: "Долго, но хорошо"}); err != nil {
+		t.Fatal(err)
+	}
+	if r, n, _, _ := f.rating(t); r != 4 || n != 2 {
+		t.Fatalf("rating after update = %v/%d, want 4/2", r, n)
+	}
+
+	// Скрытый отзыв не учитывается в рейтинге и пропадает из выдачи
+	hidden := true
+	moderated, err := f.svc.Moderate(rv.ID, contract.ReviewModeration{Hidden: &hidden})
+	if err != nil || !moderated.Hidden {
+		t.Fatalf("Moderate() = %+v, %v", moderated, err)
+	}
+	if r, n, sr, sn := f.rating(t); r != 5 || n != 1 || sr != 5 || sn != 1 {
+		t.Fatalf("rating after hiding = %v/%d, service %v/%d, want 5/1", r, n, sr, sn)
+	}
+	list, err := f.svc.MasterReviews(f.Master.ID, 0, 0)
+	if err != nil || len(list.Data) != 1 || list.Summary != (contract.RatingSummary{Rating: 5, Count: 1}) {
+		t.Fatalf("MasterReviews() = %+v, %v", list, err)
+	}
+	if list.Limit != review.DefaultLimit {
+		t.Errorf("Limit = %d, want %d", list.Limit, review.DefaultLimit)
+	}
+	if _, err := f.svc.Moderate(rv.ID, contract.ReviewModeration{}); !errors.Is(err, review.ErrInvalidModeration) {
+		t.Errorf("Moderate() without changes error = %v", err)
+	}
+	all, err := f.svc.Moderation(&hidden, nil, 0, 0)
+	if err != nil || len(all) != 1 || all[0].ID != rv.ID || all[0].RecordID == nil {
+		t.Errorf("Moderation(hidden) = %+v, %v", all, err)
+	}
+}
+
+func TestReply(t *testing.T) {
+	f := newFixture(t)
+	rv, err := f.svc.Create(f.Clients[0].ID, f.record(t, f.Clients[0], 3*time.Hour, "confirm"), contract.ReviewRequest{Rating: 5, Text: "Спасибо"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.svc.Reply(f.Clients[0].ID, rv.ID, "Сама себе"); !errors.Is(err, review.ErrNotReviewMaster) {
+		t.Fatalf("Reply() by client error = %v", err)
+	}
+	if _, err := f.svc.Reply(f.Master.ID, 999, "Нет"); !errors.Is(err, review.ErrReviewNotFound) {
+		t.Fatalf("Reply() unknown error = %v", err)
+	}
+	if _, err := f.svc.Reply(f.Master.ID, rv.ID, strings.Repeat("я", review.MaxReplyLength+1)); !errors.Is(err, review.ErrInvalidReply) {
+		t.Fatalf("Reply() too long error = %v", err)
+	}
+	replied, err := f.svc.Reply(f.Master.ID, rv.ID, " Приходите ещё ")
+	if err != nil || replied.Reply != "Приходите ещё" || replied.RepliedAt == nil {
+		t.Fatalf("Reply() = %+v, %v", replied, err)
+	}
+	msgs := f.TG.Messages()
+	if last := msgs[len(msgs)-1]; last.TelegramID != f.Clients[0].TelegramID || last.Message != "Приходите ещё" {
+		t.Errorf("telegram = %+v, want the reply sent to the client", last)
+	}
+
+	// Публичная выдача: имя клиента без модерации
+	list, err := f.svc.ServiceReviews(f.Service.ID, 10, 0)
+	if err != nil || len(list.Data) != 1 {
+		t.Fatalf("ServiceReviews() = %+v, %v", list, err)
+	}
+	got := list.Data[0]
+	if got.ClientName != "Ольга" || got.ServiceName != "Стрижка" || got.Reply != "Приходите ещё" || got.RecordID != nil {
+		t.Errorf("public review = %+v", got)
+	}
+
+	cleared, err := f.svc.Reply(f.Master.ID, rv.ID, "")
+	if err != nil || cleared.Reply != "" || cleared.RepliedAt != nil {
+		t.Errorf("Reply(\"\") = %+v, %v, want reply removed", cleared, err)
+	}
+}
@@ -0,0 +1,79 @@
+package review
+
+import (
+	"app/http/usecase/notification"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxTextLength и MaxReplyLength — длина отзыва и ответа мастера в символах
+	MaxTextLength  = 2000
+	MaxReplyLength = 1000
+	// DefaultLimit и MaxLimit — размер страницы отзывов
+	DefaultLimit = 20
+	MaxLimit     = 50
+)
+
+var (
+	ErrReviewNotFound     = errors.New("review not found")
+	ErrReviewExists       = errors.New("record already has a review")
+	ErrRecordNotFound     = errors.New("record not found")
+	ErrNotRecordOwner     = errors.New("record belongs to another client")
+	ErrRecordNotCompleted = errors.New("only a confirmed record whose slot has ended can be reviewed")
+	ErrNotReviewMaster    = errors.New("review belongs to another master")
+	ErrInvalidRating      = errors.New("rating must be 1..5")
+	ErrInvalidText        = fmt.Errorf("review text must be at most %d characters", MaxTextLength)
+	ErrInvalidReply       = fmt.Errorf("reply must be at most %d characters", MaxReplyLength)
+	ErrInvalidModeration  = errors.New("hidden or flagged is required")
+)
+
+// Repository — отзывы; Create и Update сами пересчитывают рейтинг мастера и услуги
+type Repository interface {
+	Create(rv *models.Review) error
+	FindByID(id uint) (*models.Review, error)
+	FindByRecord(recordID uint) (*models.Review, error)
+	Find(f models.ReviewFilter) ([]models.Review, error)
+	Summary(f models.ReviewFilter) (contract.RatingSummary, error)
+	Update(rv *models.Review) error
+}
+
+// Records — записи с услугой, мастером и клиентом (repository/record)
+type Records interface {
+	GetRecordByIDWithDetails(id uint) (models.Record, error)
+}
+
+// Sender — уведомления об отзывах и ответах в Telegram
+type Sender interface {
+	RecordStatusNotify(telegramID int64, title, message string) error
+}
+
+type Service struct {
+	repo                Repository
+	records             Records
+	notificationService *notification.Service
+	sender              Sender
+	logger              *logrus.Logger
+	now                 func() time.Time
+}
+
+func NewService(repo Repository, records Records, notificationService *notification.Service, logger *logrus.Logger) *Service {
+	return &Service{
+		repo:                repo,
+		records:             records,
+		notificationService: notificationService,
+		logger:              logger,
+		now:                 time.Now,
+	}
+}
+
+// WithSender подключает уведомления в Telegram
+func (s *Service) WithSender(snd Sender) *Service {
+	s.sender = snd
+	return s
+}
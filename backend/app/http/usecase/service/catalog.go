@@ -0,0 +1,235 @@
+package service
+
+import (
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Categories возвращает категории мастера в порядке показа
+func (s *Service) Categories(masterID uuid.UUID) ([]models.ServiceCategory, error) {
+	list, err := s.repo.GetCategories(masterID)
+	if err != nil {
+		s.logger.Errorf("Service.Categories: repo error: %v", err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// CreateCategory добавляет категорию мастера; названия не повторяются без учёта регистра
+func (s *Service) CreateCategory(masterID uuid.UUID, req contract.ServiceCategoryRequest) (*models.ServiceCategory, error) {
+	name := strings.TrimSpace(req.Name)
+	if !validName(name) {
+		return nil, ErrInvalidCategory
+	}
+	c := &models.ServiceCategory{MasterID: masterID, Name: name, Position: req.Position}
+	if err := s.repo.CreateCategory(c); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			return nil, ErrCategoryExists
+		}
+		s.logger.Errorf("Service.CreateCategory: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Service.CreateCategory: master_id=%s category_id=%d", masterID, c.ID)
+	return c, nil
+}
+
+// UpdateCategory переименовывает категорию мастера или меняет её место в списке
+func (s *Service) UpdateCategory(masterID uuid.UUID, id uint, req contract.ServiceCategoryRequest) (*models.ServiceCategory, error) {
+	name := strings.TrimSpace(req.Name)
+	if !validName(name) {
+		return nil, ErrInvalidCategory
+	}
+	c, err := s.categoryOf(masterID, id)
+	if err != nil {
+		return nil, err
+	}
+	others, err := s.repo.GetCategories(masterID)
+	if err != nil {
+		s.logger.Errorf("Service.UpdateCategory: repo error: %v", err)
+		return nil, err
+	}
+	for _, other := range others {
+		if other.ID != id && strings.EqualFold(other.Name, name) {
+			return nil, ErrCategoryExists
+		}
+	}
+	c.Name, c.Position = name, req.Position
+	if err := s.repo.UpdateCategory(&c); err != nil {
+		s.logger.Errorf("Service.UpdateCategory: repo error: %v", err)
+		return nil, err
+	}
+	return &c, nil
+}
+
+// DeleteCategory удаляет категорию; её услуги остаются без категории
+func (s *Service) DeleteCategory(masterID uuid.UUID, id uint) error {
+	if _, err := s.categoryOf(masterID, id); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteCategory(id); err != nil {
+		s.logger.Errorf("Service.DeleteCategory: repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Service.DeleteCategory: master_id=%s category_id=%d", masterID, id)
+	return nil
+}
+
+// SetCategory переносит услугу мастера в его категорию; nil убирает услугу из категории
+func (s *Service) SetCategory(masterID uuid.UUID, serviceID uint, categoryID *uint) (models.Service, error) {
+	if _, err := s.serviceOf(masterID, serviceID); err != nil {
+		return models.Service{}, err
+	}
+	if categoryID != nil {
+		if _, err := s.categoryOf(masterID, *categoryID); err != nil {
+			return models.Service{}, err
+		}
+	}
+	if err := s.repo.SetServiceCategory(serviceID, categoryID); err != nil {
+		s.logger.Errorf("Service.SetCategory: repo error: %v", err)
+		return models.Service{}, err
+	}
+	return s.repo.GetService(serviceID)
+}
+
+// AddVariant добавляет вариант услуги со своей ценой и длительностью
+func (s *Service) AddVariant(masterID uuid.UUID, serviceID uint, req contract.ServiceOptionRequest) (*models.ServiceVariant, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if !validName(req.Name) || req.Price < 0 || req.Duration <= 0 {
+		return nil, ErrInvalidVariant
+	}
+	if _, err := s.serviceOf(masterID, serviceID); err != nil {
+		return nil, err
+	}
+	v := &models.ServiceVariant{ServiceID: serviceID, Name: req.Name, Price: req.Price, Duration: req.Duration, Position: req.Position}
+	if err := s.repo.CreateVariant(v); err != nil {
+		s.logger.Errorf("Service.AddVariant: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Service.AddVariant: service_id=%d variant_id=%d", serviceID, v.ID)
+	return v, nil
+}
+
+// UpdateVariant меняет вариант; уже созданные записи сохраняют прежнюю цену
+func (s *Service) UpdateVariant(masterID uuid.UUID, serviceID, variantID uint, req contract.ServiceOptionRequest) (*models.ServiceVariant, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if !validName(req.Name) || req.Price < 0 || req.Duration <= 0 {
+		return nil, ErrInvalidVariant
+	}
+	v, err := s.variantOf(masterID, serviceID, variantID)
+	if err != nil {
+		return nil, err
+	}
+	v.Name, v.Price, v.Duration, v.Position = req.Name, req.Price, req.Duration, req.Position
+	if err := s.repo.UpdateVariant(&v); err != nil {
+		s.logger.Errorf("Service.UpdateVariant: repo error: %v", err)
+		return nil, err
+	}
+	return &v, nil
+}
+
+func (s *Service) DeleteVariant(masterID uuid.UUID, serviceID, variantID uint) error {
+	if _, err := s.variantOf(masterID, serviceID, variantID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteVariant(variantID); err != nil {
+		s.logger.Errorf("Service.DeleteVariant: repo error: %v", err)
+		return err
+	}
+	return nil
+}
+
+// AddAddOn добавляет опцию, которая увеличивает цену и длительность записи
+func (s *Service) AddAddOn(masterID uuid.UUID, serviceID uint, req contract.ServiceOptionRequest) (*models.ServiceAddOn, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if !validName(req.Name) || req.Price < 0 || req.Duration < 0 {
+		return nil, ErrInvalidAddOn
+	}
+	if _, err := s.serviceOf(masterID, serviceID); err != nil {
+		return nil, err
+	}
+	a := &models.ServiceAddOn{ServiceID: serviceID, Name: req.Name, Price: req.Price, Duration: req.Duration, Position: req.Position}
+	if err := s.repo.CreateAddOn(a); err != nil {
+		s.logger.Errorf("Service.AddAddOn: repo error: %v", err)
+		return nil, err
+	}
+	s.logger.Infof("Service.AddAddOn: service_id=%d add_on_id=%d", serviceID, a.ID)
+	return a, nil
+}
+
+// UpdateAddOn меняет опцию; уже созданные записи сохраняют прежнюю цену
+func (s *Service) UpdateAddOn(masterID uuid.UUID, serviceID, addOnID uint, req contract.ServiceOptionRequest) (*models.ServiceAddOn, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if !validName(req.Name) || req.Price < 0 || req.Duration < 0 {
+		return nil, ErrInvalidAddOn
+	}
+	a, err := s.addOnOf(masterID, serviceID, addOnID)
+	if err != nil {
+		return nil, err
+	}
+	a.Name, a.Price, a.Duration, a.Position = req.Name, req.Price, req.Duration, req.Position
+	if err := s.repo.UpdateAddOn(&a); err != nil {
+		s.logger.Errorf("Service.UpdateAddOn: repo error: %v", err)
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *Service) DeleteAddOn(masterID uuid.UUID, serviceID, addOnID uint) error {
+	if _, err := s.addOnOf(masterID, serviceID, addOnID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteAddOn(addOnID); err != nil {
+		s.logger.Errorf("Service.DeleteAddOn: repo error: %v", err)
+		return err
+	}
+	return nil
+}
+
+func (s *Service) serviceOf(masterID uuid.UUID, serviceID uint) (*models.Service, error) {
+	svc, err := s.repo.GetServiceByIDAndOwner(serviceID, masterID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrServiceNotFound
+	}
+	return svc, err
+}
+
+func (s *Service) categoryOf(masterID uuid.UUID, id uint) (models.ServiceCategory, error) {
+	c, err := s.repo.GetCategory(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && c.MasterID != masterID) {
+		return models.ServiceCategory{}, ErrCategoryNotFound
+	}
+	return c, err
+}
+
+func (s *Service) variantOf(masterID uuid.UUID, serviceID, variantID uint) (models.ServiceVariant, error) {
+	if _, err := s.serviceOf(masterID, serviceID); err != nil {
+		return models.ServiceVariant{}, err
+	}
+	v, err := s.repo.GetVariant(variantID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && v.ServiceID != serviceID) {
+		return models.ServiceVariant{}, ErrVariantNotFound
+	}
+	return v, err
+}
+
+func (s *Service) addOnOf(masterID uuid.UUID, serviceID, addOnID uint) (models.ServiceAddOn, error) {
+	if _, err := s.serviceOf(masterID, serviceID); err != nil {
+		return models.ServiceAddOn{}, err
+	}
+	a, err := s.repo.GetAddOn(addOnID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && a.ServiceID != serviceID) {
+		return models.ServiceAddOn{}, ErrAddOnNotFound
+	}
+	return a, err
+}
+
+func validName(name string) bool {
+	n := utf8.RuneCountInString(name)
+	return n > 0 && n <= MaxNameLength
+}
@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"app/http/usecase/service"
+	"app/pkg/models"
+	"contract"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCategories(t *testing.T) {
+	store, svc, master := newService(t)
+	other := models.User{Phone: "+79990000002", TelegramID: 1002, FirstName: "Ольга"}
+	if err := store.Users().Create(&other); err != nil {
+		t.Fatal(err)
+	}
+	haircut := models.Service{MasterID: master.ID, Name: "Стрижка", Price: 1000, Duration: 30}
+	if err := svc.CreateService(&haircut); err != nil {
+		t.Fatal(err)
+	}
+
+	hair, err := svc.CreateCategory(master.ID, contract.ServiceCategoryRequest{Name: " Волосы ", Position: 1})
+	if err != nil || hair.Name != "Волосы" {
+		t.Fatalf("CreateCategory() = %+v, %v", hair, err)
+	}
+	nails, err := svc.CreateCategory(master.ID, contract.ServiceCategoryRequest{Name: "Ногти"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.CreateCategory(master.ID, contract.ServiceCategoryRequest{Name: "волосы"}); !errors.Is(err, service.ErrCategoryExists) {
+		t.Errorf("CreateCategory(duplicate) error = %v, want ErrCategoryExists", err)
+	}
+	if _, err := svc.CreateCategory(master.ID, contract.ServiceCategoryRequest{Name: strings.Repeat("я", service.MaxNameLength+1)}); !errors.Is(err, service.ErrInvalidCategory) {
+		t.Errorf("CreateCategory(long name) error = %v, want ErrInvalidCategory", err)
+	}
+	if _, err := svc.UpdateCategory(master.ID, nails.ID, contract.ServiceCategoryRequest{Name: "ВОЛОСЫ"}); !errors.Is(err, service.ErrCategoryExists) {
+		t.Errorf("UpdateCategory(rename to existing) error = %v, want ErrCategoryExists", err)
+	}
+	if _, err := svc.UpdateCategory(other.ID, nails.ID, contract.ServiceCategoryRequest{Name: "Маникюр"}); !errors.Is(err, service.ErrCategoryNotFound) {
+		t.Errorf("UpdateCategory() by another master error = %v, want ErrCategoryNotFound", err)
+	}
+	list, err := svc.Categories(master.ID)
+	if err != nil || len(list) != 2 || list[0].ID != nails.ID {
+		t.Fatalf("Categories() = %+v, %v, want «Ногти» first by position", list, err)
+	}
+
+	// Чужую категорию назначить нельзя; своя сохраняется при обновлении услуги
+	foreign, err := svc.CreateCategory(other.ID, contract.ServiceCategoryRequest{Name: "Брови"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SetCategory(master.ID, haircut.ID, &foreign.ID); !errors.Is(err, service.ErrCategoryNotFound) {
+		t.Errorf("SetCategory(foreign category) error = %v, want ErrCategoryNotFound", err)
+	}
+	if _, err := svc.SetCategory(other.ID, haircut.ID, &foreign.ID); !errors.Is(err, service.ErrServiceNotFound) {
+		t.Errorf("SetCategory(foreign service) error = %v, want ErrServiceNotFound", err)
+	}
+	got, err := svc.SetCategory(master.ID, haircut.ID, &hair.ID)
+	if err != nil || got.CategoryID == nil || *got.CategoryID != hair.ID {
+		t.Fatalf("SetCategory() = %+v, %v", got, err)
+	}
+	haircut.CategoryID = nil
+	haircut.Price = 1100
+	if err := svc.UpdateService(&haircut); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := svc.GetService(haircut.ID); got.CategoryID == nil || *got.CategoryID != hair.ID {
+		t.Errorf("category after UpdateService = %v, want kept", got.CategoryID)
+	}
+
+	if err := svc.DeleteCategory(master.ID, hair.ID); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := svc.GetService(haircut.ID); got.CategoryID != nil {
+		t.Errorf("category after DeleteCategory = %v, want none", *got.CategoryID)
+	}
+}
+
+func TestVariantsAndAddOns(t *testing.T) {
+	_, svc, master := newService(t)
+	haircut := models.Service{MasterID: master.ID, Name: "Стрижка", Price: 1000, Duration: 30}
+	coloring := models.Service{MasterID: master.ID, Name: "Окрашивание", Price: 3000, Duration: 120}
+	for _, s := range []*models.Service{&haircut, &coloring} {
+		if err := svc.CreateService(s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name    string
+		call    func() error
+		wantErr error
+	}{
+		{name: "variant without name", call: func() error {
+			_, err := svc.AddVariant(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: " ", Price: 900, Duration: 30})
+			return err
+		}, wantErr: service.ErrInvalidVariant},
+		{name: "variant without duration", call: func() error {
+			_, err := svc.AddVariant(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: "Короткие", Price: 900})
+			return err
+		}, wantErr: service.ErrInvalidVariant},
+		{name: "add-on with negative price", call: func() error {
+			_, err := svc.AddAddOn(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: "Укладка", Price: -1})
+			return err
+		}, wantErr: service.ErrInvalidAddOn},
+		{name: "variant of a missing service", call: func() error {
+			_, err := svc.AddVariant(master.ID, 999, contract.ServiceOptionRequest{Name: "Короткие", Price: 900, Duration: 30})
+			return err
+		}, wantErr: service.ErrServiceNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	long, err := svc.AddVariant(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: "Длинные", Price: 1500, Duration: 60, Position: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	short, err := svc.AddVariant(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: "Короткие", Price: 900, Duration: 30, Position: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Опция без дополнительного времени допустима
+	wash, err := svc.AddAddOn(master.ID, haircut.ID, contract.ServiceOptionRequest{Name: "Мытьё", Price: 200})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.UpdateVariant(master.ID, coloring.ID, long.ID, contract.ServiceOptionRequest{Name: "Длинные", Price: 1, Duration: 60}); !errors.Is(err, service.ErrVariantNotFound) {
+		t.Errorf("UpdateVariant() through another service error = %v, want ErrVariantNotFound", err)
+	}
+	if err := svc.DeleteAddOn(master.ID, coloring.ID, wash.ID); !errors.Is(err, service.ErrAddOnNotFound) {
+		t.Errorf("DeleteAddOn() through another service error = %v, want ErrAddOnNotFound", err)
+	}
+	if _, err := svc.UpdateAddOn(master.ID, haircut.ID, wash.ID, contract.ServiceOptionRequest{Name: "Мытьё", Price: 250, Duration: 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := svc.GetService(haircut.ID)
+	if err != nil || len(got.Variants) != 2 || got.Variants[0].ID != short.ID || len(got.AddOns) != 1 || got.AddOns[0].Price != 250 {
+		t.Fatalf("GetService() = %+v, %v, want both variants by position and the updated add-on", got, err)
+	}
+	if err := svc.DeleteVariant(master.ID, haircut.ID, long.ID); err != nil {
+		t.Fatal(err)
+	}
+	if list, _ := svc.GetServices(master.ID); len(list[0].Variants) != 1 || len(list[1].Variants) != 0 {
+		t.Errorf("GetServices() variants = %+v, %+v, want one left on the haircut", list[0].Variants, list[1].Variants)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"app/pkg/models"
+	"contract/money"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -11,6 +12,29 @@ func (s *Service) CreateService(service *models.Service) error {
 	if service.MasterID.String() == "" {
 		return fmt.Errorf("MasterID is requiered")
 	}
+	// Услуги салона создаются через /organization, здесь — только в личной организации мастера;
+	// место услуги назначается там же
+	service.OrganizationID = nil
+	service.LocationID = nil
+	// Категорию, варианты и опции задают отдельные маршруты /service/{id}/...
+	service.CategoryID = nil
+	service.Variants, service.AddOns = nil, nil
+	if s.orgs != nil {
+		org, err := s.orgs.Personal(service.MasterID)
+		if err != nil {
+			s.logger.Errorf("Service.e: personal organization: %v", err)
+			return err
+		}
+		service.OrganizationID = &org.ID
+	}
+	currency, err := s.currency(service.Currency, service.MasterID)
+	if err != nil {
+		return err
+	}
+	service.Currency = currency
+	if err := checkPrepayment(service); err != nil {
+		return err
+	}
 	if err := s.repo.CreateService(service); err != nil {
 		s.logger.Errorf("Service.e: repo error: %v", err)
 		return err
@@ -19,6 +43,40 @@ func (s *Service) CreateService(service *models.Service) error {
 	return nil
 }
 
+// currency — валюта новой услуги: указанная в запросе, иначе валюта мастера
+func (s *Service) currency(code string, masterID uuid.UUID) (string, error) {
+	if code == "" {
+		master, err := s.userRepo.FindByID(masterID)
+		if err != nil {
+			s.logger.Errorf("Service.currency: master lookup failed: %v", err)
+			return "", err
+		}
+		// Неизвестного мастера отклонит внешний ключ при сохранении
+		if master != nil {
+			code = master.Currency
+		}
+	}
+	if code = money.Normalize(code); !money.Known(code) {
+		return "", ErrInvalidCurrency
+	}
+	return code, nil
+}
+
+// checkPrepayment проверяет предоплату услуги; задаток хранится только у deposit
+func checkPrepayment(service *models.Service) error {
+	switch service.Prepayment {
+	case "", models.PrepaymentFull:
+		service.Deposit = 0
+	case models.PrepaymentDeposit:
+		if service.Deposit <= 0 {
+			return ErrInvalidPrepayment
+		}
+	default:
+		return ErrInvalidPrepayment
+	}
+	return nil
+}
+
 func (s *Service) GetServices(userID uuid.UUID) ([]models.Service, error) {
 	if userID.String() == "" {
 		return nil, fmt.Errorf("MasterID is required")
@@ -62,6 +120,29 @@ func (s *Service) UpdateService(service *models.Service) error {
 	if service.MasterID.String() == "" {
 		return fmt.Errorf("MasterID is required")
 	}
+	// Нельзя переписать чужую услугу, подставив её ID
+	existing, err := s.repo.GetServiceByIDAndOwner(service.ID, service.MasterID)
+	if err != nil {
+		s.logger.Errorf("Service.UpdateService: service not found or not owned: %v", err)
+		return fmt.Errorf("service not found or access denied")
+	}
+	// Организацию и место услуги меняют только через /organization
+	service.OrganizationID = existing.OrganizationID
+	service.LocationID = existing.LocationID
+	// Рейтинг считают отзывы, клиент API его не задаёт
+	service.Rating, service.RatingCount = existing.Rating, existing.RatingCount
+	service.CategoryID = existing.CategoryID
+	service.Variants, service.AddOns = nil, nil
+	// Без валюты в запросе остаётся прежняя; цены вариантов и опций не пересчитываются
+	if service.Currency == "" {
+		service.Currency = existing.Currency
+	}
+	if service.Currency = money.Normalize(service.Currency); !money.Known(service.Currency) {
+		return ErrInvalidCurrency
+	}
+	if err := checkPrepayment(service); err != nil {
+		return err
+	}
 	if err := s.repo.UpdateService(service); err != nil {
 		s.logger.Errorf("Service.UpdateService: repo error: %v", err)
 		return err
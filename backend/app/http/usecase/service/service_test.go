@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/service"
+	"app/pkg/models"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ service.Repository     = (*memory.ServiceRepository)(nil)
+	_ service.UserRepository = (*memory.UserRepository)(nil)
+)
+
+func newService(t *testing.T) (*memory.Store, *service.Service, models.User) {
+	t.Helper()
+	f := memtest.Empty()
+	master := f.User(t, models.User{TelegramID: 1001, FirstName: "Анна", Surname: "Иванова"})
+	return f.Store, service.NewService(f.Store.Services(), f.Store.Users(), f.Logger), master
+}
+
+func TestCurrency(t *testing.T) {
+	store, svc, master := newService(t)
+	if err := store.Users().UpdateCurrency(master.ID, "KZT"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Без валюты услуга получает валюту мастера, явная валюта сохраняется
+	inherited := models.Service{MasterID: master.ID, Name: "Стрижка", Price: 500000, Duration: 30}
+	own := models.Service{MasterID: master.ID, Name: "Окрашивание", Price: 9000, Currency: "eur", Duration: 60}
+	for _, s := range []*models.Service{&inherited, &own} {
+		if err := svc.CreateService(s); err != nil {
+			t.Fatalf("CreateService(%s) error = %v", s.Name, err)
+		}
+	}
+	if inherited.Currency != "KZT" || own.Currency != "EUR" {
+		t.Fatalf("currencies = %s, %s; want KZT, EUR", inherited.Currency, own.Currency)
+	}
+	if err := svc.CreateService(&models.Service{MasterID: master.ID, Name: "Маникюр", Currency: "XYZ", Duration: 30}); !errors.Is(err, service.ErrInvalidCurrency) {
+		t.Fatalf("CreateService(XYZ) error = %v, want %v", err, service.ErrInvalidCurrency)
+	}
+
+	// Обновление без валюты оставляет прежнюю
+	inherited.Currency = ""
+	if err := svc.UpdateService(&inherited); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := svc.GetService(inherited.ID); err != nil || got.Currency != "KZT" {
+		t.Fatalf("GetService() = %+v, %v; want KZT kept", got, err)
+	}
+}
+
+func TestPrepayment(t *testing.T) {
+	tests := []struct {
+		name        string
+		prepayment  string
+		deposit     int64
+		wantDeposit int64
+		wantErr     error
+	}{
+		{name: "none", deposit: 50000, wantDeposit: 0},
+		{name: "deposit", prepayment: "deposit", deposit: 50000, wantDeposit: 50000},
+		{name: "full", prepayment: "full", deposit: 50000, wantDeposit: 0},
+		{name: "deposit without amount", prepayment: "deposit", wantErr: service.ErrInvalidPrepayment},
+		{name: "unknown", prepayment: "half", wantErr: service.ErrInvalidPrepayment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, svc, master := newService(t)
+			s := models.Service{MasterID: master.ID, Name: "Стрижка", Price: 150000, Duration: 30, Prepayment: tt.prepayment, Deposit: tt.deposit}
+			err := svc.CreateService(&s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateService() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			got, err := svc.GetService(s.ID)
+			if err != nil || got.Prepayment != tt.prepayment || got.Deposit != tt.wantDeposit {
+				t.Errorf("GetService() = %q deposit %d, %v; want %q deposit %d", got.Prepayment, got.Deposit, err, tt.prepayment, tt.wantDeposit)
+			}
+		})
+	}
+}
+
+func TestCreateGetUpdate(t *testing.T) {
+	_, svc, master := newService(t)
+
+	s := models.Service{MasterID: master.ID, Name: "Стрижка", Price: 1000, Duration: 30}
+	if err := svc.CreateService(&s); err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	s.Price = 1200
+	if err := svc.UpdateService(&s); err != nil {
+		t.Fatalf("UpdateService() error = %v", err)
+	}
+	got, err := svc.GetService(s.ID)
+	if err != nil || got.Price != 1200 {
+		t.Fatalf("GetService() = %+v, %v", got, err)
+	}
+	detail, err := svc.GetDetailService(s.ID)
+	if err != nil || detail.MasterName != "Анна" || detail.MasterTelegramID != 1001 {
+		t.Fatalf("GetDetailService() = %+v, %v", detail, err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{name: "get zero id", call: func() error { _, err := svc.GetService(0); return err }},
+		{name: "get missing", call: func() error { _, err := svc.GetService(999); return err }},
+		{name: "update without id", call: func() error { return svc.UpdateService(&models.Service{MasterID: master.ID}) }},
+		{name: "update foreign service", call: func() error {
+			return svc.UpdateService(&models.Service{ID: s.ID, MasterID: uuid.New(), Name: "Чужая", Duration: 30})
+		}},
+		{name: "create for unknown master", call: func() error { return svc.CreateService(&models.Service{MasterID: uuid.New()}) }},
+		{name: "delete zero id", call: func() error { return svc.DeleteService(0) }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); err == nil {
+				t.Error("error = nil")
+			}
+		})
+	}
+}
+
+func TestDeleteServiceByOwner(t *testing.T) {
+	tests := []struct {
+		name        string
+		owner       func(master models.User) uuid.UUID
+		wantErr     bool
+		wantDeleted bool
+	}{
+		{name: "owner", owner: func(m models.User) uuid.UUID { return m.ID }, wantDeleted: true},
+		{name: "other user", owner: func(models.User) uuid.UUID { return uuid.New() }, wantErr: true},
+		{name: "nil owner", owner: func(models.User) uuid.UUID { return uuid.Nil }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, svc, master := newService(t)
+			s := models.Service{MasterID: master.ID, Name: "Стрижка"}
+			if err := svc.CreateService(&s); err != nil {
+				t.Fatal(err)
+			}
+			sl := models.Slot{MasterID: master.ID, ServiceID: s.ID, StartTime: time.Now().Add(time.Hour)}
+			if err := store.Slots().Create(&sl); err != nil {
+				t.Fatal(err)
+			}
+
+			err := svc.DeleteServiceByOwner(s.ID, tt.owner(master))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteServiceByOwner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, getErr := svc.GetService(s.ID)
+			if deleted := getErr != nil; deleted != tt.wantDeleted {
+				t.Fatalf("service deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			_, slotErr := store.Slots().FindSlot(sl.ID)
+			if slotDeleted := slotErr != nil; slotDeleted != tt.wantDeleted {
+				t.Errorf("slot deleted = %v, want cascade %v", slotDeleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestGetServicesByTelegramID(t *testing.T) {
+	_, svc, master := newService(t)
+	for _, name := range []string{"Стрижка", "Окрашивание"} {
+		if err := svc.CreateService(&models.Service{MasterID: master.ID, Name: name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name       string
+		telegramID int64
+		want       int
+		wantErr    bool
+	}{
+		{name: "master", telegramID: master.TelegramID, want: 2},
+		{name: "unknown user", telegramID: 42, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services, err := svc.GetServicesByTelegramID(tt.telegramID)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(services) != tt.want {
+				t.Errorf("got %d services, want %d", len(services), tt.want)
+			}
+		})
+	}
+}
@@ -1,22 +1,85 @@
 package service
 
 import (
-	"app/http/repository/service"
-	"app/http/repository/user"
+	"app/pkg/models"
+	"errors"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// MaxNameLength — длина названия категории, варианта и опции в символах
+const MaxNameLength = 100
+
+var (
+	ErrServiceNotFound   = errors.New("service not found or access denied")
+	ErrCategoryNotFound  = errors.New("category not found")
+	ErrCategoryExists    = errors.New("category with this name already exists")
+	ErrInvalidCategory   = errors.New("category name must be 1..100 characters")
+	ErrVariantNotFound   = errors.New("variant not found in the service")
+	ErrInvalidVariant    = errors.New("variant needs a name of 1..100 characters, a price >= 0 and a duration > 0")
+	ErrAddOnNotFound     = errors.New("add-on not found in the service")
+	ErrInvalidAddOn      = errors.New("add-on needs a name of 1..100 characters, a price >= 0 and a duration >= 0")
+	ErrInvalidCurrency   = errors.New("currency must be a supported ISO 4217 code")
+	ErrInvalidPrepayment = errors.New("prepayment must be empty, deposit with a positive deposit, or full")
+)
+
+// Repository — хранилище услуг, их категорий, вариантов и опций
+type Repository interface {
+	CreateService(service *models.Service) error
+	GetServices(userID uuid.UUID) ([]models.Service, error)
+	GetService(id uint) (models.Service, error)
+	GetDetailService(serviceID uint) (models.ServiceResponse, error)
+	GetServiceByIDAndOwner(serviceID uint, ownerID uuid.UUID) (*models.Service, error)
+	UpdateService(service *models.Service) error
+	DeleteService(id uint) error
+
+	CreateCategory(c *models.ServiceCategory) error
+	GetCategories(masterID uuid.UUID) ([]models.ServiceCategory, error)
+	GetCategory(id uint) (models.ServiceCategory, error)
+	UpdateCategory(c *models.ServiceCategory) error
+	DeleteCategory(id uint) error
+	SetServiceCategory(serviceID uint, categoryID *uint) error
+
+	CreateVariant(v *models.ServiceVariant) error
+	GetVariant(id uint) (models.ServiceVariant, error)
+	UpdateVariant(v *models.ServiceVariant) error
+	DeleteVariant(id uint) error
+
+	CreateAddOn(a *models.ServiceAddOn) error
+	GetAddOn(id uint) (models.ServiceAddOn, error)
+	UpdateAddOn(a *models.ServiceAddOn) error
+	DeleteAddOn(id uint) error
+}
+
+// UserRepository — поиск мастера по telegram_id и по id (валюта новых услуг)
+type UserRepository interface {
+	FindByTelegramID(telegramID int64) (*models.User, error)
+	FindByID(id uuid.UUID) (*models.User, error)
+}
+
+// Organizations — личная организация мастера, которой принадлежат его услуги
+type Organizations interface {
+	Personal(userID uuid.UUID) (*models.Organization, error)
+}
+
 type Service struct {
-	repo     *service.Repository
-	userRepo *user.Repository
+	repo     Repository
+	userRepo UserRepository
+	orgs     Organizations
 	logger   *logrus.Logger
 }
 
-func NewService(repo *service.Repository, userRepo *user.Repository, logger *logrus.Logger) *Service {
+func NewService(repo Repository, userRepo UserRepository, logger *logrus.Logger) *Service {
 	return &Service{
 		repo:     repo,
 		userRepo: userRepo,
 		logger:   logger,
 	}
 }
+
+// WithOrganizations привязывает новые услуги к личной организации мастера
+func (s *Service) WithOrganizations(orgs Organizations) *Service {
+	s.orgs = orgs
+	return s
+}
@@ -2,11 +2,9 @@ package slot
 
 import (
 	"app/http/repository/slot"
-	"app/http/sender"
 	"app/pkg/models"
+	"app/pkg/timefmt"
 	"fmt"
-	"os"
-	"time"
 
 	"github.com/google/uuid"
 )
@@ -15,6 +13,25 @@ func (s *Service) CreateSlot(slot *models.Slot) error {
 	if slot.MasterID.String() == "" {
 		return fmt.Errorf("MasterID is requiered")
 	}
+	if s.services != nil {
+		if _, err := s.services.GetServiceByIDAndOwner(slot.ServiceID, slot.MasterID); err != nil {
+			s.logger.Warnf("Service.CreateSlot (slot): service_id=%d master_id=%v: %v", slot.ServiceID, slot.MasterID, err)
+			return ErrServiceNotOwned
+		}
+	}
+	slot.LocationID = nil
+	if s.locations != nil {
+		if err := s.locations.PrepareSlot(slot); err != nil {
+			s.logger.Errorf("Service.CreateSlot (slot): %v", err)
+			return err
+		}
+	}
+	if s.resources != nil {
+		if err := s.resources.CheckSlot(*slot); err != nil {
+			s.logger.Errorf("Service.CreateSlot (slot): %v", err)
+			return err
+		}
+	}
 	if err := s.repo.Create(slot); err != nil {
 		s.logger.Errorf("Service.CreateSlot (slot): repo error: %v", err)
 		return err
@@ -50,14 +67,17 @@ func (s *Service) GetSlot(slotID uint) (*models.SlotResponse, error) {
 		StartTime:          result.StartTime,
 		EndTime:            result.EndTime,
 		IsBooked:           result.IsBooked,
+		ServiceID:          result.ServiceID,
 		ServiceName:        result.ServiceName,
 		ServiceDescription: result.ServiceDescription,
 		ServiceDuration:    result.ServiceDuration,
 		ServicePrice:       result.ServicePrice,
+		ServiceCurrency:    result.ServiceCurrency,
 		MasterTelegramID:   result.MasterTelegramID,
 		MasterName:         result.MasterName,
 		MasterSurname:      result.MasterSurname,
 		MasterPhone:        result.MasterPhone,
+		MasterTimezone:     result.MasterTimezone,
 	}
 
 	s.logger.Infof("Service.GetSlots (slot): slot_id=%v", slotID)
@@ -122,20 +142,8 @@ func (s *Service) DeleteSlotByOwner(slotID uint, ownerID uuid.UUID) error {
 
 	// Best-effort уведомления клиентам: confirm/pending (site + telegram)
 	if s.notify != nil && s.records != nil && slotDetails != nil {
-		// подготавливаем локацию для форматирования времени (совместимо с телеграм-сервисом)
-		tzName := os.Getenv("TELEGRAM_TIMEZONE")
-		if tzName == "" {
-			tzName = os.Getenv("TIMEZONE")
-		}
-		var loc *time.Location
-		if tzName != "" {
-			if l, err := time.LoadLocation(tzName); err == nil {
-				loc = l
-			}
-		}
-		if loc == nil {
-			loc = time.Local
-		}
+		// время мастера — вторым, если у клиента другое смещение
+		master := timefmt.Location(s.location, slotDetails.Master.Timezone)
 		// Общая процедура для всех найденных записей
 		notifyForRecords := func(recs []models.Record, st string) {
 			for _, r := range recs {
@@ -143,11 +151,13 @@ func (s *Service) DeleteSlotByOwner(slotID uint, ownerID uuid.UUID) error {
 					continue
 				}
 				title := "Слот отменен мастером"
+				client, clientErr := s.records.GetUserByID(r.ClientID)
 				var message string
 				if !slotDetails.StartTime.IsZero() && !slotDetails.EndTime.IsZero() {
-					message = fmt.Sprintf("Мастер удалил слот %s–%s по услуге \"%s\".\nВаша заявка была в статусе: %s. Свяжитесь с мастером при необходимости.",
-						slotDetails.StartTime.In(loc).Format("02.01.2006 15:04"),
-						slotDetails.EndTime.In(loc).Format("15:04"),
+					// время в таймзоне клиента
+					loc := timefmt.Location(master, client.Timezone)
+					message = fmt.Sprintf("Мастер удалил слот %s по услуге \"%s\".\nВаша заявка была в статусе: %s. Свяжитесь с мастером при необходимости.",
+						timefmt.Span(slotDetails.StartTime, slotDetails.EndTime, loc, master),
 						slotDetails.Service.Name,
 						st,
 					)
@@ -160,9 +170,8 @@ func (s *Service) DeleteSlotByOwner(slotID uint, ownerID uuid.UUID) error {
 					"status":    r.Status,
 				}
 				_ = s.notify.CreateGeneric(r.ClientID, "SLOT_DELETED", title, message, meta)
-				client, err := s.records.GetUserByID(r.ClientID)
-				if err == nil && client.TelegramID != 0 {
-					_ = sender.RecordStatusNotify(client.TelegramID, title, message)
+				if clientErr == nil && client.TelegramID != 0 && s.sender != nil {
+					_ = s.sender.RecordStatusNotify(client.TelegramID, title, message)
 				}
 			}
 		}
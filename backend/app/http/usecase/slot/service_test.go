@@ -0,0 +1,209 @@
+package slot_test
+
+import (
+	"app/http/repository/memory"
+	"app/http/repository/memory/memtest"
+	"app/http/usecase/notification"
+	"app/http/usecase/slot"
+	"app/pkg/models"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	_ slot.Repository          = (*memory.SlotRepository)(nil)
+	_ slot.RecordRepository    = (*memory.RecordRepository)(nil)
+	_ slot.ServiceOwnerChecker = (*memory.ServiceRepository)(nil)
+)
+
+type fixture struct {
+	*memtest.Fixture
+	svc *slot.Service
+}
+
+// newFixture — мастер в Asia/Yekaterinburg с полуторачасовым маникюром
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	f := &fixture{Fixture: memtest.New(t)}
+	f.Master.Timezone = "Asia/Yekaterinburg"
+	if err := f.Store.Users().UpdateTimezone(f.Master.ID, f.Master.Timezone); err != nil {
+		t.Fatal(err)
+	}
+	f.UpdateService(t, func(s *models.Service) { s.Name, s.Duration = "Маникюр", 90 })
+	loc, err := time.LoadLocation("Asia/Almaty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.svc = slot.NewService(f.Store.Slots(), f.Logger).
+		WithNotification(notification.NewService(f.Store.Notifications(), f.Logger)).
+		WithRecordRepository(f.Store.Records()).
+		WithSender(f.TG).
+		WithLocation(loc).
+		WithServices(f.Store.Services())
+	return f
+}
+
+func (f *fixture) createSlot(t *testing.T, start time.Time) models.Slot {
+	t.Helper()
+	s := models.Slot{MasterID: f.Master.ID, ServiceID: f.Service.ID, StartTime: start, EndTime: start.Add(90 * time.Minute)}
+	if err := f.svc.CreateSlot(&s); err != nil {
+		t.Fatalf("CreateSlot() error = %v", err)
+	}
+	return s
+}
+
+func TestCreateAndGetSlots(t *testing.T) {
+	f := newFixture(t)
+	late := f.createSlot(t, time.Date(2030, 1, 2, 12, 0, 0, 0, time.UTC))
+	early := f.createSlot(t, time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC))
+
+	slots, err := f.svc.GetSlots(f.Master.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(slots) != 2 || slots[0].ID != early.ID || slots[1].ID != late.ID {
+		t.Fatalf("GetSlots() = %+v, want ordered by start time", slots)
+	}
+	if slots[0].ServiceName != "Маникюр" || slots[0].MasterTelegramID != f.Master.TelegramID {
+		t.Errorf("GetSlots()[0] = %+v, want service and master fields", slots[0])
+	}
+
+	got, err := f.svc.GetSlot(early.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ServicePrice != memtest.DefaultServicePrice || got.ServiceDuration != 90 || got.MasterName != "Анна" || got.MasterTimezone != "Asia/Yekaterinburg" {
+		t.Errorf("GetSlot() = %+v", got)
+	}
+	if _, err := f.svc.GetSlot(999); err == nil {
+		t.Error("GetSlot() of a missing slot returned nil error")
+	}
+
+	bad := models.Slot{MasterID: uuid.New(), ServiceID: f.Service.ID}
+	if err := f.svc.CreateSlot(&bad); err == nil {
+		t.Error("CreateSlot() for an unknown master returned nil error")
+	}
+}
+
+func TestCreateSlotForeignService(t *testing.T) {
+	f := newFixture(t)
+	other := f.User(t, models.User{TelegramID: 1002, FirstName: "Ольга"})
+	theirs := f.AddService(t, models.Service{MasterID: other.ID, Name: "Окрашивание"})
+
+	start := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := models.Slot{MasterID: f.Master.ID, ServiceID: theirs.ID, StartTime: start, EndTime: start.Add(90 * time.Minute)}
+	if err := f.svc.CreateSlot(&s); !errors.Is(err, slot.ErrServiceNotOwned) {
+		t.Fatalf("CreateSlot() on another master's service error = %v, want ErrServiceNotOwned", err)
+	}
+	if slots, _ := f.svc.GetSlots(f.Master.ID); len(slots) != 0 {
+		t.Errorf("GetSlots() = %+v, want no slots", slots)
+	}
+}
+
+func TestDeleteSlotByOwner(t *testing.T) {
+	start := time.Date(2030, 3, 10, 6, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		owner       func(f *fixture) uuid.UUID
+		wantErr     bool
+		wantDeleted bool
+	}{
+		{name: "owner deletes slot", owner: func(f *fixture) uuid.UUID { return f.Master.ID }, wantDeleted: true},
+		{name: "foreign master is denied", owner: func(*fixture) uuid.UUID { return uuid.New() }, wantErr: true},
+		{name: "nil owner", owner: func(*fixture) uuid.UUID { return uuid.Nil }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			s := f.createSlot(t, start)
+
+			// три клиента: подтверждённый, ожидающий и отклонённый
+			records := f.Store.Records()
+			var clients []models.User
+			for i, status := range []string{"confirm", "pending", "reject"} {
+				c := models.User{TelegramID: int64(3001 + i), FirstName: status, Timezone: "Asia/Omsk"}
+				if i == 1 {
+					c.Timezone = f.Master.Timezone
+				}
+				c = f.User(t, c)
+				clients = append(clients, c)
+				rec := models.Record{SlotID: s.ID, ClientID: c.ID, Status: status}
+				if _, err := records.Create(&rec); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := f.svc.DeleteSlotByOwner(s.ID, tt.owner(f))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeleteSlotByOwner() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			_, findErr := f.Store.Slots().FindSlot(s.ID)
+			if deleted := findErr != nil; deleted != tt.wantDeleted {
+				t.Fatalf("slot deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			// слот уходит в архив: заявки остаются в истории, активные отклоняются
+			left, _ := records.FindAllRecordsBySlot(s.ID)
+			if len(left) != 3 {
+				t.Fatalf("records = %d, want all 3 kept", len(left))
+			}
+			for i, rec := range left {
+				want := []string{"confirm", "pending", "reject"}[i]
+				if tt.wantDeleted {
+					want = "reject"
+				}
+				if rec.Status != want {
+					t.Errorf("record %d status = %q, want %q", rec.ID, rec.Status, want)
+				}
+			}
+
+			sent := f.TG.Messages()
+			if !tt.wantDeleted {
+				if len(sent) != 0 {
+					t.Errorf("denied delete sent telegram messages: %+v", sent)
+				}
+				return
+			}
+			if len(sent) != 2 || sent[0].TelegramID != clients[0].TelegramID || sent[1].TelegramID != clients[1].TelegramID {
+				t.Fatalf("telegram = %+v, want confirm and pending clients only", sent)
+			}
+			// 06:00 UTC: у клиента в Asia/Omsk — 12:00, у мастера в Asia/Yekaterinburg — 11:00
+			if want := "10.03.2030 12:00 - 13:30 (Asia/Omsk), у мастера 10.03.2030 11:00 - 12:30 (Asia/Yekaterinburg)"; !strings.Contains(sent[0].Message, want) {
+				t.Errorf("message %q does not contain %q", sent[0].Message, want)
+			}
+			if strings.Contains(sent[1].Message, "у мастера") {
+				t.Errorf("message %q repeats the time of a client in the master's timezone", sent[1].Message)
+			}
+			for i, c := range clients {
+				list, _ := f.Store.Notifications().FindUserNotifications(c.ID)
+				want := 1
+				if i == 2 {
+					want = 0
+				}
+				if len(list) != want || (want == 1 && list[0].Type != "SLOT_DELETED") {
+					t.Errorf("client %d notifications = %+v, want %d SLOT_DELETED", i, list, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteSlots(t *testing.T) {
+	f := newFixture(t)
+	f.createSlot(t, time.Date(2030, 1, 1, 9, 0, 0, 0, time.UTC))
+	f.createSlot(t, time.Date(2030, 1, 1, 11, 0, 0, 0, time.UTC))
+	if err := f.svc.DeleteSlots(f.Master.ID); err != nil {
+		t.Fatal(err)
+	}
+	slots, _ := f.svc.GetSlots(f.Master.ID)
+	if len(slots) != 0 {
+		t.Fatalf("GetSlots() after DeleteSlots = %d slots", len(slots))
+	}
+	if err := f.svc.DeleteSlot(0); err == nil {
+		t.Error("DeleteSlot(0) returned nil error")
+	}
+}
@@ -1,21 +1,72 @@
 package slot
 
 import (
-	recordRepo "app/http/repository/record"
 	"app/http/repository/slot"
 	notifyServ "app/http/usecase/notification"
+	"app/pkg/models"
+	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+var ErrServiceNotOwned = errors.New("service does not belong to the slot master")
+
+// Repository — хранилище слотов
+type Repository interface {
+	Create(slot *models.Slot) error
+	FindSlots(userID uuid.UUID) ([]slot.SlotWithMaster, error)
+	FindSlot(slotID uint) (*slot.SlotWithMasterAndService, error)
+	GetSlotByIDAndOwner(slotID uint, ownerID uuid.UUID) (*models.Slot, error)
+	DeleteSlots(userID uuid.UUID) error
+	DeleteSlot(id uint) error
+}
+
+// RecordRepository — заявки, нужные для уведомления клиентов об удалении слота
+type RecordRepository interface {
+	FindRecordsBySlot(slotID uint, status string) ([]models.Record, error)
+	GetSlotByIDWithDetails(id uint) (models.Slot, error)
+	GetUserByID(id uuid.UUID) (models.User, error)
+}
+
+// Sender — отправка уведомлений в Telegram
+type Sender interface {
+	RecordStatusNotify(telegramID int64, title, message string) error
+}
+
+// ResourceChecker — проверка свободных ресурсов (кресел, кабинетов) на время слота (usecase/resource)
+type ResourceChecker interface {
+	CheckSlot(slot models.Slot) error
+}
+
+// LocationPreparer — место услуги и его часы работы (usecase/location)
+type LocationPreparer interface {
+	PrepareSlot(slot *models.Slot) error
+}
+
+// ServiceOwnerChecker — услуги мастера (repository/service)
+type ServiceOwnerChecker interface {
+	GetServiceByIDAndOwner(serviceID uint, ownerID uuid.UUID) (*models.Service, error)
+}
+
 type Service struct {
-	repo    *slot.Repository
+	repo    Repository
 	logger  *logrus.Logger
 	notify  *notifyServ.Service
-	records *recordRepo.Repository
+	records RecordRepository
+	sender  Sender
+	// resources — если задан, слот нельзя создать на время, когда ресурсы услуги заняты
+	resources ResourceChecker
+	// locations — если задан, слот получает место своей услуги и должен попасть в его часы работы
+	locations LocationPreparer
+	// location — таймзона текстов уведомлений, если ни у клиента, ни у мастера она не задана
+	location *time.Location
+	// services — если задан, слот можно создать только на услугу своего мастера
+	services ServiceOwnerChecker
 }
 
-func NewService(repo *slot.Repository, logger *logrus.Logger) *Service {
+func NewService(repo Repository, logger *logrus.Logger) *Service {
 	return &Service{
 		repo:   repo,
 		logger: logger,
@@ -29,7 +80,37 @@ func (s *Service) WithNotification(ns *notifyServ.Service) *Service {
 }
 
 // WithRecordRepository подключает репозиторий заявок
-func (s *Service) WithRecordRepository(r *recordRepo.Repository) *Service {
+func (s *Service) WithRecordRepository(r RecordRepository) *Service {
 	s.records = r
 	return s
 }
+
+// WithSender подключает отправку уведомлений в Telegram
+func (s *Service) WithSender(snd Sender) *Service {
+	s.sender = snd
+	return s
+}
+
+// WithLocation задаёт таймзону текстов уведомлений для пользователей без своей таймзоны
+func (s *Service) WithLocation(loc *time.Location) *Service {
+	s.location = loc
+	return s
+}
+
+// WithResources включает проверку ресурсов услуги при создании слота
+func (s *Service) WithResources(rc ResourceChecker) *Service {
+	s.resources = rc
+	return s
+}
+
+// WithLocations привязывает новые слоты к месту услуги и проверяет часы работы места
+func (s *Service) WithLocations(lp LocationPreparer) *Service {
+	s.locations = lp
+	return s
+}
+
+// WithServices включает проверку, что услуга слота принадлежит его мастеру
+func (s *Service) WithServices(sc ServiceOwnerChecker) *Service {
+	s.services = sc
+	return s
+}
@@ -0,0 +1,26 @@
+package user
+
+import (
+	"contract/money"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnsupportedCurrency — валюты нет среди поддерживаемых (contract/money)
+var ErrUnsupportedCurrency = errors.New("unsupported currency")
+
+// UpdateCurrency сохраняет валюту мастера. Она подставляется в новые услуги;
+// цены уже созданных услуг и сделанных записей не меняются
+func (s *Service) UpdateCurrency(userID uuid.UUID, code string) error {
+	code = money.Normalize(code)
+	if !money.Known(code) {
+		return ErrUnsupportedCurrency
+	}
+	if err := s.repo.UpdateCurrency(userID, code); err != nil {
+		s.logger.Errorf("Service.UpdateCurrency (user): repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Service.UpdateCurrency (user): updated id=%s currency=%s", userID, code)
+	return nil
+}
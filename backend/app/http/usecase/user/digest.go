@@ -0,0 +1,45 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidDigestTime — время сводки не в формате "ЧЧ:ММ"
+var ErrInvalidDigestTime = errors.New("invalid digest time")
+
+// NormalizeDigestTime приводит время сводки к виду "08:00" ("8:00" → "08:00").
+// Пустая строка и "off" выключают сводку и возвращаются как "".
+func NormalizeDigestTime(value string) (string, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if value == "" || value == "off" {
+		return "", nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return "", fmt.Errorf("%w: %q", ErrInvalidDigestTime, value)
+	}
+	return t.Format("15:04"), nil
+}
+
+// UpdateDigestTime сохраняет время утренней сводки мастера (в его таймзоне)
+func (s *Service) UpdateDigestTime(req UpdateDigestTimeRequest) error {
+	id, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id: %w", err)
+	}
+	digestTime, err := NormalizeDigestTime(req.DigestTime)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdateDigestTime(id, digestTime); err != nil {
+		s.logger.Errorf("Service.UpdateDigestTime (user): repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Service.UpdateDigestTime (user): updated id=%s digest_time=%q", id, digestTime)
+	return nil
+}
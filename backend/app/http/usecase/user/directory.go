@@ -0,0 +1,14 @@
+package user
+
+import "github.com/google/uuid"
+
+// UpdateDirectoryOptOut убирает мастера из каталога и inline-поиска (optOut) или возвращает его.
+// Профиль, услуги и слоты остаются доступны по прямым ссылкам
+func (s *Service) UpdateDirectoryOptOut(userID uuid.UUID, optOut bool) error {
+	if err := s.repo.UpdateDirectoryOptOut(userID, optOut); err != nil {
+		s.logger.Errorf("Service.UpdateDirectoryOptOut (user): repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Service.UpdateDirectoryOptOut (user): updated id=%s opt_out=%t", userID, optOut)
+	return nil
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ErrUnsupportedLanguage — язык, для которого у бота нет каталога сообщений
+var ErrUnsupportedLanguage = errors.New("unsupported language")
+
+// supportedLanguages — языки интерфейса Telegram-бота
+var supportedLanguages = map[string]bool{"ru": true, "en": true}
+
+// NormalizeLanguage приводит код языка к виду из supportedLanguages ("en-US" → "en").
+// Для неподдерживаемого языка возвращает пустую строку.
+func NormalizeLanguage(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if base, _, found := strings.Cut(code, "-"); found {
+		code = base
+	}
+	if supportedLanguages[code] {
+		return code
+	}
+	return ""
+}
+
+// UpdateLanguage сохраняет язык интерфейса бота
+func (s *Service) UpdateLanguage(req UpdateLanguageRequest) error {
+	id, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return fmt.Errorf("invalid user_id: %w", err)
+	}
+	lang := NormalizeLanguage(req.Language)
+	if lang == "" {
+		return ErrUnsupportedLanguage
+	}
+	if err := s.repo.UpdateLanguage(id, lang); err != nil {
+		s.logger.Errorf("Service.UpdateLanguage (user): repo error: %v", err)
+		return err
+	}
+	s.logger.Infof("Service.UpdateLanguage (user): updated id=%s language=%s", id, lang)
+	return nil
+}
@@ -0,0 +1,117 @@
+package user
+
+import (
+	"app/http/repository/user"
+	"app/http/sender"
+	"app/pkg/models"
+	phonenum "app/pkg/phone"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+)
+
+const (
+	maxPhoneCodeAttempts = 5
+	// phoneCodeResendDelay — как часто можно запрашивать новый код на ту же пару номер + Telegram
+	phoneCodeResendDelay = time.Minute
+)
+
+var (
+	ErrInvalidPhone     = errors.New("invalid phone number")
+	ErrPhoneTaken       = errors.New("phone already registered")
+	ErrPhoneNotVerified = errors.New("phone not verified, share your contact with the Telegram bot")
+	ErrInvalidCode      = errors.New("invalid or expired code")
+	ErrCodeRecentlySent = errors.New("code already sent, try again later")
+)
+
+// RequestRegistration сохраняет регистрацию из непроверенного источника и отправляет
+// одноразовый код в Telegram. Пользователь создаётся только после ConfirmRegistration.
+// Код доказывает владение Telegram-аккаунтом, но не номером: номер подтверждается позже
+// контактом, отправленным боту (Register).
+// Ответ: Возвращает нормализованный номер и ошибку.
+func (s *Service) RequestRegistration(u *models.User) (string, error) {
+	normalized, err := phonenum.NormalizeRegion(u.Phone, s.phoneRegion)
+	if err != nil {
+		s.logger.Errorf("Service.RequestRegistration (user): invalid phone %q: %v", u.Phone, err)
+		return "", ErrInvalidPhone
+	}
+	existing, err := s.repo.FindByPhone(normalized)
+	if err != nil {
+		return "", fmt.Errorf("database error: %w", err)
+	}
+	if existing != nil {
+		return "", ErrPhoneTaken
+	}
+
+	if prev, ok := s.repo.LoadPendingRegistration(normalized, u.TelegramID); ok && time.Since(prev.CreatedAt) < phoneCodeResendDelay {
+		return "", ErrCodeRecentlySent
+	}
+
+	code, err := generatePhoneCode()
+	if err != nil {
+		s.logger.Errorf("Service.RequestRegistration (user): code generation failed: %v", err)
+		return "", err
+	}
+	pending := *u
+	pending.Phone = normalized
+	s.repo.StoragePendingRegistration(normalized, u.TelegramID, user.PendingRegistration{
+		User:      pending,
+		Code:      code,
+		CreatedAt: time.Now(),
+	})
+	if s.sender == nil {
+		s.repo.DeletePendingRegistration(normalized, u.TelegramID)
+		return "", sender.ErrDisabled
+	}
+	if err := s.sender.PhoneCodeNotify(u.TelegramID, normalized, code); err != nil {
+		s.logger.Errorf("Service.RequestRegistration (user): failed to send code: %v", err)
+		s.repo.DeletePendingRegistration(normalized, u.TelegramID)
+		return "", err
+	}
+	s.logger.Infof("Service.RequestRegistration (user): code sent phone=%s telegram_id=%d", normalized, u.TelegramID)
+	return normalized, nil
+}
+
+// ConfirmRegistration проверяет одноразовый код, отправленный в Telegram telegramID, и создаёт
+// пользователя. Номер остаётся неподтверждённым: войти по нему можно после того,
+// как владелец отправит боту свой контакт.
+// Ответ: Возвращает созданного пользователя и ошибку.
+func (s *Service) ConfirmRegistration(phone string, telegramID int64, code string) (*models.User, error) {
+	normalized, err := phonenum.NormalizeRegion(phone, s.phoneRegion)
+	if err != nil {
+		return nil, ErrInvalidPhone
+	}
+	pending, ok := s.repo.LoadPendingRegistration(normalized, telegramID)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+	if subtle.ConstantTimeCompare([]byte(pending.Code), []byte(code)) != 1 {
+		pending.Attempts++
+		if pending.Attempts >= maxPhoneCodeAttempts {
+			s.repo.DeletePendingRegistration(normalized, telegramID)
+		} else {
+			s.repo.StoragePendingRegistration(normalized, telegramID, *pending)
+		}
+		s.logger.Infof("Service.ConfirmRegistration (user): wrong code phone=%s attempt=%d", normalized, pending.Attempts)
+		return nil, ErrInvalidCode
+	}
+	s.repo.DeletePendingRegistration(normalized, telegramID)
+
+	u := pending.User
+	if err := s.create(&u, nil); err != nil {
+		return nil, err
+	}
+	return &u, nil
+}
+
+// generatePhoneCode возвращает шестизначный одноразовый код
+func generatePhoneCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1000000))
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -1,26 +1,63 @@
 package user
 
 import (
-	"app/encoder"
 	"app/http/sender"
 	"app/pkg/models"
+	phonenum "app/pkg/phone"
+	"app/pkg/timefmt"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 )
 
-// Register отправляет запрос в бд на создание пользователя.
+// Register создаёт пользователя по контакту, который владелец Telegram-аккаунта отправил боту,
+// — это и есть подтверждение номера. Если номер уже зарегистрирован через сайт, но не подтверждён,
+// контакт подтверждает его и привязывает аккаунт к отправившему контакт Telegram.
 // Ответ: Возвращает ошибку.
 func (s *Service) Register(user *models.User) error {
 	if user.Phone == "" {
 		return fmt.Errorf("phone is required")
 	}
+	normalized, err := phonenum.NormalizeRegion(user.Phone, s.phoneRegion)
+	if err != nil {
+		s.logger.Errorf("Service.Register (user): invalid phone %q: %v", user.Phone, err)
+		return ErrInvalidPhone
+	}
+	existing, err := s.repo.FindByPhone(normalized)
+	if err != nil {
+		return fmt.Errorf("database error: %w", err)
+	}
+	verifiedAt := time.Now()
+	if existing != nil {
+		if existing.PhoneVerifiedAt != nil {
+			return ErrPhoneTaken
+		}
+		if err := s.repo.VerifyPhone(existing.ID, user.TelegramID, verifiedAt); err != nil {
+			s.logger.Errorf("Service.Register (user): verify phone failed: %v", err)
+			return err
+		}
+		s.logger.Infof("Service.Register (user): phone verified id=%s telegram_id=%d (was %d)", existing.ID, user.TelegramID, existing.TelegramID)
+		telegramID := user.TelegramID
+		*user = *existing
+		user.TelegramID, user.PhoneVerifiedAt = telegramID, &verifiedAt
+		return nil
+	}
+	user.Phone = normalized
+	return s.create(user, &verifiedAt)
+}
+
+// create сохраняет нового пользователя; verifiedAt == nil — номер ещё не подтверждён
+func (s *Service) create(user *models.User, verifiedAt *time.Time) error {
+	user.Language = NormalizeLanguage(user.Language)
+	user.PhoneVerifiedAt = verifiedAt
 	user.Roles = nil
 	if err := s.repo.Create(user); err != nil {
 		s.logger.Errorf("Service.Register (user): repo error: %v", err)
 		return err
 	}
-	s.logger.Infof("Service.Register (user): created id=%s", user.ID)
+	s.logger.Infof("Service.Register (user): created id=%s verified=%t", user.ID, verifiedAt != nil)
 	return nil
 }
 
@@ -32,6 +69,10 @@ func (s *Service) Login(phone string) (*models.User, string, error) {
 	if phone == "" {
 		return nil, message, fmt.Errorf("phone is required")
 	}
+	phone, err := phonenum.NormalizeRegion(phone, s.phoneRegion)
+	if err != nil {
+		return nil, message, ErrInvalidPhone
+	}
 
 	user, err := s.repo.FindByPhone(phone)
 	if err != nil {
@@ -42,11 +83,23 @@ func (s *Service) Login(phone string) (*models.User, string, error) {
 		s.logger.Infof("Service.Login (user): user not found phone=%s", phone)
 		return nil, "User not found", fmt.Errorf("user not found")
 	}
+	if user.PhoneVerifiedAt == nil {
+		s.logger.Infof("Service.Login (user): phone not verified id=%s", user.ID)
+		return nil, "Phone not verified", ErrPhoneNotVerified
+	}
 
 	// Уведомление отправляет контроллер, чтобы включить IP/локацию
 	return user, "Confirmation required. Please check Telegram", nil
 }
 
+// NotifyLogin отправляет в Telegram запрос на подтверждение входа с IP и локацией
+func (s *Service) NotifyLogin(user *models.User, ip string, location string) error {
+	if s.sender == nil {
+		return sender.ErrDisabled
+	}
+	return s.sender.LoginNotify(*user, ip, location)
+}
+
 func (s *Service) GetByTelegramID(telegram_id int64) (*models.User, error) {
 	user, err := s.repo.FindByTelegramID(telegram_id)
 	if err != nil {
@@ -67,7 +120,7 @@ func (s *Service) ConfirmLoginByTelegramID(telegram_id int64) error {
 		s.logger.Errorf("Service.GetByTelegramID (user): repo error: %v", err)
 		return err
 	}
-	token, err := encoder.GenerateToken(user.ID)
+	token, err := s.generateToken(user.ID)
 	if err != nil {
 		s.logger.Errorf("Service.ConfirmLoginByTelegramID: token generation failed: %v", err)
 		return err
@@ -115,7 +168,10 @@ func (s *Service) UpdateNames(req UpdateNamesRequest) error {
 	return nil
 }
 
-// UpdateTimezone обновляет таймзону пользователя
+// ErrInvalidTimezone — таймзона, которой нет в базе IANA
+var ErrInvalidTimezone = errors.New("invalid timezone")
+
+// UpdateTimezone обновляет таймзону пользователя (имя IANA, например "Asia/Omsk")
 func (s *Service) UpdateTimezone(req UpdateTimezoneRequest) error {
 	if req.UserID == "" {
 		return fmt.Errorf("user_id is required")
@@ -123,6 +179,9 @@ func (s *Service) UpdateTimezone(req UpdateTimezoneRequest) error {
 	if req.Timezone == "" {
 		return fmt.Errorf("timezone is required")
 	}
+	if !timefmt.Valid(req.Timezone) {
+		return fmt.Errorf("%w: %q", ErrInvalidTimezone, req.Timezone)
+	}
 	id, err := uuid.Parse(req.UserID)
 	if err != nil {
 		return fmt.Errorf("invalid user_id: %w", err)
@@ -164,8 +223,11 @@ func (s *Service) GetUserByID(userID uuid.UUID) (*models.User, error) {
 
 // RequestAccountDeletion отправляет запрос на подтверждение удаления в Telegram
 func (s *Service) RequestAccountDeletion(userID uuid.UUID, telegramID int64) error {
+	if s.sender == nil {
+		return sender.ErrDisabled
+	}
 	// Отправляем уведомление в Telegram с кнопками подтверждения
-	err := sender.RequestAccountDeletionConfirmation(userID, telegramID)
+	err := s.sender.RequestAccountDeletionConfirmation(userID, telegramID)
 	if err != nil {
 		s.logger.Errorf("Service.RequestAccountDeletion: failed to send telegram notification: %v", err)
 		return err
@@ -174,7 +236,7 @@ func (s *Service) RequestAccountDeletion(userID uuid.UUID, telegramID int64) err
 	return nil
 }
 
-// DeleteUser удаляет пользователя и все связанные данные
+// DeleteUser переносит пользователя в архив вместе с услугами и слотами; история записей остаётся
 func (s *Service) DeleteUser(userID uuid.UUID) error {
 	user, err := s.GetUserByID(userID)
 	if err != nil {
@@ -0,0 +1,409 @@
+package user_test
+
+import (
+	"app/encoder"
+	"app/http/repository/memory"
+	"app/http/sender"
+	"app/http/usecase/user"
+	"app/pkg/models"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+var _ user.Repository = (*memory.UserRepository)(nil)
+
+func newService() (*memory.Store, *memory.Telegram, *user.Service) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	store := memory.NewStore()
+	tg := &memory.Telegram{}
+	return store, tg, user.NewService(store.Users(), logger).
+		WithSender(tg).
+		WithTokenEncoder(encoder.New("test-secret"))
+}
+
+func TestRegister(t *testing.T) {
+	tests := []struct {
+		name      string
+		phone     string
+		wantPhone string
+		wantErr   error
+	}{
+		{name: "plus seven", phone: "+7 (999) 123-45-67", wantPhone: "+79991234567"},
+		{name: "trunk eight", phone: "89991234567", wantPhone: "+79991234567"},
+		{name: "garbage", phone: "12-34", wantErr: user.ErrInvalidPhone},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, svc := newService()
+			u := models.User{Phone: tt.phone, TelegramID: 1, FirstName: "Иван"}
+			err := svc.Register(&u)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Register() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if u.Phone != tt.wantPhone || u.PhoneVerifiedAt == nil {
+				t.Errorf("registered user = %q verified=%v", u.Phone, u.PhoneVerifiedAt)
+			}
+		})
+	}
+
+	_, _, svc := newService()
+	if err := svc.Register(&models.User{Phone: "+79991234567", TelegramID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.Register(&models.User{Phone: "89991234567", TelegramID: 2}); err == nil {
+		t.Error("Register() of the same number in another format returned nil error")
+	}
+
+	_, _, svc = newService()
+	by := models.User{Phone: "80291234567", TelegramID: 3}
+	if err := svc.WithPhoneRegion("BY").Register(&by); err != nil {
+		t.Fatal(err)
+	}
+	if by.Phone != "+375291234567" {
+		t.Errorf("Register() in region BY = %q, want +375291234567", by.Phone)
+	}
+}
+
+func TestLogin(t *testing.T) {
+	store, _, svc := newService()
+	if err := svc.Register(&models.User{Phone: "+79991234567", TelegramID: 1, FirstName: "Иван"}); err != nil {
+		t.Fatal(err)
+	}
+	// пользователь, созданный до проверки номеров
+	if err := store.Users().Create(&models.User{Phone: "+79997654321", TelegramID: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		phone   string
+		wantErr error
+		wantNil bool
+	}{
+		{name: "verified in other format", phone: "8 999 123 45 67"},
+		{name: "unverified", phone: "+79997654321", wantErr: user.ErrPhoneNotVerified, wantNil: true},
+		{name: "invalid", phone: "abc", wantErr: user.ErrInvalidPhone, wantNil: true},
+		{name: "unknown", phone: "+79990000000", wantNil: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, _, err := svc.Login(tt.phone)
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Login() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && !tt.wantNil && err != nil {
+				t.Fatalf("Login() error = %v", err)
+			}
+			if (u == nil) != tt.wantNil {
+				t.Errorf("Login() user = %+v, want nil %v", u, tt.wantNil)
+			}
+		})
+	}
+}
+
+func TestRegistrationByCode(t *testing.T) {
+	tests := []struct {
+		name      string
+		codes     func(valid string) []string
+		wantUser  bool
+		wantFinal error
+	}{
+		{name: "valid code", codes: func(valid string) []string { return []string{valid} }, wantUser: true},
+		{name: "wrong then valid", codes: func(valid string) []string { return []string{"000000x", valid} }, wantUser: true},
+		{
+			name: "too many attempts",
+			codes: func(valid string) []string {
+				return []string{"x", "x", "x", "x", "x", valid}
+			},
+			wantFinal: user.ErrInvalidCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, tg, svc := newService()
+			phone, err := svc.RequestRegistration(&models.User{Phone: "8 (999) 123-45-67", TelegramID: 77, FirstName: "Иван"})
+			if err != nil {
+				t.Fatalf("RequestRegistration() error = %v", err)
+			}
+			if phone != "+79991234567" {
+				t.Fatalf("RequestRegistration() phone = %q", phone)
+			}
+			sent := tg.Messages()
+			if len(sent) != 1 || sent[0].Kind != "phone_code" || sent[0].TelegramID != 77 || len(sent[0].Code) != 6 {
+				t.Fatalf("telegram = %+v, want a 6-digit code to 77", sent)
+			}
+			if u, _ := store.Users().FindByPhone(phone); u != nil {
+				t.Fatal("user created before confirmation")
+			}
+
+			var (
+				created *models.User
+				lastErr error
+			)
+			for _, code := range tt.codes(sent[0].Code) {
+				created, lastErr = svc.ConfirmRegistration("+7 999 123 45 67", 77, code)
+			}
+			if tt.wantUser {
+				// Код доказывает только владение Telegram: номер ещё не подтверждён
+				if lastErr != nil || created == nil || created.PhoneVerifiedAt != nil {
+					t.Fatalf("ConfirmRegistration() = %+v, %v", created, lastErr)
+				}
+				if u, _ := store.Users().FindByTelegramID(77); u == nil || u.Phone != phone {
+					t.Errorf("stored user = %+v", u)
+				}
+				if _, _, err := svc.Login(phone); !errors.Is(err, user.ErrPhoneNotVerified) {
+					t.Errorf("Login() before sharing the contact error = %v, want %v", err, user.ErrPhoneNotVerified)
+				}
+				// Контакт, отправленный боту, подтверждает номер
+				if err := svc.Register(&models.User{Phone: phone, TelegramID: 77}); err != nil {
+					t.Fatalf("Register() with the shared contact error = %v", err)
+				}
+				if u, _, err := svc.Login(phone); err != nil || u == nil {
+					t.Errorf("Login() after sharing the contact = %+v, %v", u, err)
+				}
+				return
+			}
+			if !errors.Is(lastErr, tt.wantFinal) {
+				t.Fatalf("ConfirmRegistration() error = %v, want %v", lastErr, tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestRequestRegistrationErrors(t *testing.T) {
+	_, tg, svc := newService()
+	if err := svc.Register(&models.User{Phone: "+79991234567", TelegramID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.RequestRegistration(&models.User{Phone: "89991234567", TelegramID: 2}); !errors.Is(err, user.ErrPhoneTaken) {
+		t.Errorf("RequestRegistration() error = %v, want %v", err, user.ErrPhoneTaken)
+	}
+
+	tg.Err = errors.New("bot is down")
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79990000000", TelegramID: 3}); err == nil {
+		t.Fatal("RequestRegistration() with a failing sender returned nil error")
+	}
+	if _, err := svc.ConfirmRegistration("+79990000000", 3, "123456"); !errors.Is(err, user.ErrInvalidCode) {
+		t.Errorf("pending registration survived a failed send: %v", err)
+	}
+}
+
+func TestLoginConfirmationToken(t *testing.T) {
+	_, _, svc := newService()
+	if err := svc.Register(&models.User{Phone: "+79991234567", TelegramID: 5}); err != nil {
+		t.Fatal(err)
+	}
+	if svc.CheckUserTokenByTelegramID(5) {
+		t.Fatal("token exists before confirmation")
+	}
+	if err := svc.ConfirmLoginByTelegramID(5); err != nil {
+		t.Fatal(err)
+	}
+	if !svc.CheckUserTokenByTelegramID(5) {
+		t.Fatal("token missing after confirmation")
+	}
+	token, err := svc.ClaimUserTokenByTelegramID(5)
+	if err != nil || token == "" {
+		t.Fatalf("ClaimUserTokenByTelegramID() = %q, %v", token, err)
+	}
+	if again, _ := svc.ClaimUserTokenByTelegramID(5); again != "" {
+		t.Error("token can be claimed twice")
+	}
+}
+
+func TestDeleteUserCascades(t *testing.T) {
+	store, tg, svc := newService()
+	master := models.User{Phone: "+79991234567", TelegramID: 1}
+	if err := svc.Register(&master); err != nil {
+		t.Fatal(err)
+	}
+	s := models.Service{MasterID: master.ID, Name: "Стрижка"}
+	if err := store.Services().CreateService(&s); err != nil {
+		t.Fatal(err)
+	}
+	sl := models.Slot{MasterID: master.ID, ServiceID: s.ID, StartTime: time.Now()}
+	if err := store.Slots().Create(&sl); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.RequestAccountDeletion(master.ID, master.TelegramID); err != nil {
+		t.Fatal(err)
+	}
+	if sent := tg.Messages(); len(sent) != 1 || sent[0].Kind != "account_deletion" {
+		t.Fatalf("telegram = %+v", sent)
+	}
+	if err := svc.DeleteUser(master.ID); err != nil {
+		t.Fatal(err)
+	}
+	if u, _ := store.Users().FindByID(master.ID); u != nil {
+		t.Error("user survived deletion")
+	}
+	if _, err := store.Services().GetService(s.ID); err == nil {
+		t.Error("service survived deletion of its master")
+	}
+	if _, err := store.Slots().FindSlot(sl.ID); err == nil {
+		t.Error("slot survived deletion of its master")
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	store, _, svc := newService()
+	u := models.User{Phone: "+79991234567", TelegramID: 1, Language: "en-US"}
+	if err := svc.Register(&u); err != nil {
+		t.Fatal(err)
+	}
+	if u.Language != "en" {
+		t.Fatalf("registered language = %q, want en", u.Language)
+	}
+	other := models.User{Phone: "+79991234568", TelegramID: 2, Language: "de"}
+	if err := svc.Register(&other); err != nil || other.Language != "" {
+		t.Fatalf("unsupported language = %q, %v; want empty", other.Language, err)
+	}
+
+	if err := svc.UpdateLanguage(user.UpdateLanguageRequest{UserID: u.ID.String(), Language: "de"}); !errors.Is(err, user.ErrUnsupportedLanguage) {
+		t.Fatalf("UpdateLanguage(de) error = %v, want %v", err, user.ErrUnsupportedLanguage)
+	}
+	if err := svc.UpdateLanguage(user.UpdateLanguageRequest{UserID: u.ID.String(), Language: "RU"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.Language != "ru" {
+		t.Fatalf("stored user = %+v, want language ru", got)
+	}
+}
+
+func TestUpdateCurrency(t *testing.T) {
+	store, _, svc := newService()
+	u := models.User{Phone: "+79991234567", TelegramID: 1}
+	if err := svc.Register(&u); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.Currency != "RUB" {
+		t.Fatalf("registered user = %+v, want currency RUB", got)
+	}
+	if err := svc.UpdateCurrency(u.ID, "XYZ"); !errors.Is(err, user.ErrUnsupportedCurrency) {
+		t.Fatalf("UpdateCurrency(XYZ) error = %v, want %v", err, user.ErrUnsupportedCurrency)
+	}
+	if err := svc.UpdateCurrency(u.ID, " usd "); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.Currency != "USD" {
+		t.Fatalf("stored user = %+v, want currency USD", got)
+	}
+}
+
+func TestUpdateDigestTime(t *testing.T) {
+	store, _, svc := newService()
+	u := models.User{Phone: "+79991234567", TelegramID: 1}
+	if err := svc.Register(&u); err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range []string{"25:00", "8 утра", "08:60"} {
+		if err := svc.UpdateDigestTime(user.UpdateDigestTimeRequest{UserID: u.ID.String(), DigestTime: bad}); !errors.Is(err, user.ErrInvalidDigestTime) {
+			t.Fatalf("UpdateDigestTime(%q) error = %v, want %v", bad, err, user.ErrInvalidDigestTime)
+		}
+	}
+	if err := svc.UpdateDigestTime(user.UpdateDigestTimeRequest{UserID: u.ID.String(), DigestTime: "8:30"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.DigestTime != "08:30" {
+		t.Fatalf("stored user = %+v, want digest_time 08:30", got)
+	}
+	if err := svc.UpdateDigestTime(user.UpdateDigestTimeRequest{UserID: u.ID.String(), DigestTime: "off"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.DigestTime != "" {
+		t.Fatalf("stored user = %+v, want digest turned off", got)
+	}
+}
+
+func TestUpdateTimezone(t *testing.T) {
+	store, _, svc := newService()
+	u := models.User{Phone: "+79991234567", TelegramID: 1}
+	if err := svc.Register(&u); err != nil {
+		t.Fatal(err)
+	}
+	for _, tz := range []string{"Moscow", "Local", "Europe/Atlantis"} {
+		if err := svc.UpdateTimezone(user.UpdateTimezoneRequest{UserID: u.ID.String(), Timezone: tz}); !errors.Is(err, user.ErrInvalidTimezone) {
+			t.Errorf("UpdateTimezone(%q) error = %v, want %v", tz, err, user.ErrInvalidTimezone)
+		}
+	}
+	if err := svc.UpdateTimezone(user.UpdateTimezoneRequest{UserID: u.ID.String(), Timezone: "America/Argentina/Buenos_Aires"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := store.Users().FindByID(u.ID); got == nil || got.Timezone != "America/Argentina/Buenos_Aires" {
+		t.Fatalf("stored user = %+v, want timezone America/Argentina/Buenos_Aires", got)
+	}
+}
+
+func TestWithoutSender(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := user.NewService(memory.NewStore().Users(), logger)
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79991234567", TelegramID: 1}); !errors.Is(err, sender.ErrDisabled) {
+		t.Errorf("RequestRegistration() error = %v, want %v", err, sender.ErrDisabled)
+	}
+	if err := svc.NotifyLogin(&models.User{TelegramID: 1}, "", ""); !errors.Is(err, sender.ErrDisabled) {
+		t.Errorf("NotifyLogin() error = %v, want %v", err, sender.ErrDisabled)
+	}
+}
+
+func TestPendingRegistrationsAreSeparate(t *testing.T) {
+	_, tg, svc := newService()
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79991234567", TelegramID: 10, FirstName: "Владелец"}); err != nil {
+		t.Fatal(err)
+	}
+	// Повторный запрос на ту же пару не перевыпускает код сразу
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79991234567", TelegramID: 10}); !errors.Is(err, user.ErrCodeRecentlySent) {
+		t.Errorf("repeated RequestRegistration() error = %v, want %v", err, user.ErrCodeRecentlySent)
+	}
+	// Чужой запрос на тот же номер не затирает ожидающую регистрацию
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79991234567", TelegramID: 20, FirstName: "Чужой"}); err != nil {
+		t.Fatal(err)
+	}
+	sent := tg.Messages()
+	if len(sent) != 2 {
+		t.Fatalf("telegram = %+v, want two codes", sent)
+	}
+	if sent[0].Code != sent[1].Code {
+		if _, err := svc.ConfirmRegistration("+79991234567", 20, sent[0].Code); !errors.Is(err, user.ErrInvalidCode) {
+			t.Errorf("ConfirmRegistration() with a code sent to another Telegram error = %v, want %v", err, user.ErrInvalidCode)
+		}
+	}
+	created, err := svc.ConfirmRegistration("+79991234567", 10, sent[0].Code)
+	if err != nil || created.FirstName != "Владелец" {
+		t.Fatalf("ConfirmRegistration() = %+v, %v", created, err)
+	}
+}
+
+func TestContactClaimsUnverifiedPhone(t *testing.T) {
+	store, tg, svc := newService()
+	// Номер занят через сайт с чужим Telegram и не подтверждён
+	if _, err := svc.RequestRegistration(&models.User{Phone: "+79991234567", TelegramID: 5, FirstName: "Чужой"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.ConfirmRegistration("+79991234567", 5, tg.Messages()[0].Code); err != nil {
+		t.Fatal(err)
+	}
+
+	// Владелец номера отправляет контакт боту
+	owner := models.User{Phone: "89991234567", TelegramID: 6, FirstName: "Владелец"}
+	if err := svc.Register(&owner); err != nil {
+		t.Fatalf("Register() error = %v", err)
+	}
+	u, _ := store.Users().FindByPhone("+79991234567")
+	if u == nil || u.TelegramID != 6 || u.PhoneVerifiedAt == nil {
+		t.Fatalf("stored user = %+v, want bound to the contact's Telegram and verified", u)
+	}
+	// Подтверждённый номер второй раз не забрать
+	if err := svc.Register(&models.User{Phone: "+79991234567", TelegramID: 7}); !errors.Is(err, user.ErrPhoneTaken) {
+		t.Errorf("Register() of a verified phone error = %v, want %v", err, user.ErrPhoneTaken)
+	}
+}
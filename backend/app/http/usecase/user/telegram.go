@@ -0,0 +1,66 @@
+package user
+
+import (
+	"app/pkg/models"
+	"app/pkg/telegramauth"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTelegramAuthInvalid = errors.New("invalid telegram auth data")
+	ErrUserNotFound        = errors.New("user not found")
+)
+
+// LoginByTelegramWidget проверяет данные Telegram Login Widget и выдаёт сессионный токен.
+// Ответ: Возвращает пользователя, JWT и ошибку.
+func (s *Service) LoginByTelegramWidget(fields map[string]string) (*models.User, string, error) {
+	tgUser, err := telegramauth.VerifyLoginWidget(fields, s.botToken, telegramauth.DefaultMaxAge, time.Now())
+	if err != nil {
+		s.logger.Errorf("Service.LoginByTelegramWidget (user): verify failed: %v", err)
+		return nil, "", fmt.Errorf("%w: %v", ErrTelegramAuthInvalid, err)
+	}
+	return s.issueTokenByTelegramID(tgUser.ID)
+}
+
+// LoginByTelegramInitData проверяет initData мини-приложения и выдаёт сессионный токен.
+// Ответ: Возвращает пользователя, JWT и ошибку.
+func (s *Service) LoginByTelegramInitData(initData string) (*models.User, string, error) {
+	tgUser, err := telegramauth.VerifyInitData(initData, s.botToken, telegramauth.DefaultMaxAge, time.Now())
+	if err != nil {
+		s.logger.Errorf("Service.LoginByTelegramInitData (user): verify failed: %v", err)
+		return nil, "", fmt.Errorf("%w: %v", ErrTelegramAuthInvalid, err)
+	}
+	return s.issueTokenByTelegramID(tgUser.ID)
+}
+
+// issueTokenByTelegramID выдаёт тот же JWT, что и подтверждение входа через бота.
+func (s *Service) issueTokenByTelegramID(telegramID int64) (*models.User, string, error) {
+	user, err := s.repo.FindByTelegramID(telegramID)
+	if err != nil {
+		s.logger.Errorf("Service.issueTokenByTelegramID (user): repo error: %v", err)
+		return nil, "", fmt.Errorf("database error: %w", err)
+	}
+	if user == nil {
+		s.logger.Infof("Service.issueTokenByTelegramID (user): not found telegram_id=%d", telegramID)
+		return nil, "", ErrUserNotFound
+	}
+	token, err := s.generateToken(user.ID)
+	if err != nil {
+		s.logger.Errorf("Service.issueTokenByTelegramID (user): token generation failed: %v", err)
+		return nil, "", err
+	}
+	s.logger.Infof("Service.issueTokenByTelegramID (user): token issued for id=%s", user.ID)
+	return user, token, nil
+}
+
+// generateToken выпускает JWT-токен сессии подключённым TokenEncoder
+func (s *Service) generateToken(userID uuid.UUID) (string, error) {
+	if s.tokens == nil {
+		return "", errors.New("token encoder is not configured")
+	}
+	return s.tokens.GenerateToken(userID)
+}
@@ -2,24 +2,95 @@ package user
 
 import (
 	"app/http/repository/user"
+	"app/pkg/models"
+	phonenum "app/pkg/phone"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+// Repository — хранилище пользователей и временных токенов/кодов входа
+type Repository interface {
+	Create(user *models.User) error
+	FindByID(id uuid.UUID) (*models.User, error)
+	FindByPhone(phone string) (*models.User, error)
+	FindByTelegramID(telegramID int64) (*models.User, error)
+	UpdateNames(userID uuid.UUID, firstName string, surname string) error
+	UpdateTimezone(userID uuid.UUID, timezone string) error
+	UpdateLanguage(userID uuid.UUID, language string) error
+	UpdateDigestTime(userID uuid.UUID, digestTime string) error
+	UpdateDirectoryOptOut(userID uuid.UUID, optOut bool) error
+	UpdateCurrency(userID uuid.UUID, currency string) error
+	DeleteUser(userID uuid.UUID) error
+
+	StorageToken(telegramID int64, token string) error
+	ClaimUserToken(telegramID int64) (string, error)
+	CheckUserToken(telegramID int64) bool
+	DeleteToken(telegramID int64) error
+
+	StoragePendingRegistration(phone string, telegramID int64, pending user.PendingRegistration)
+	LoadPendingRegistration(phone string, telegramID int64) (*user.PendingRegistration, bool)
+	DeletePendingRegistration(phone string, telegramID int64)
+	VerifyPhone(userID uuid.UUID, telegramID int64, at time.Time) error
+}
+
+// Sender — отправка сообщений пользователю в Telegram
+type Sender interface {
+	LoginNotify(user models.User, ip string, location string) error
+	PhoneCodeNotify(telegramID int64, phone string, code string) error
+	RequestAccountDeletionConfirmation(userID uuid.UUID, telegramID int64) error
+}
+
+// TokenEncoder — выпуск JWT-токенов сессии (encoder.Encoder)
+type TokenEncoder interface {
+	GenerateToken(userID uuid.UUID) (string, error)
+}
+
 type Service struct {
-	repo   *user.Repository
-	logger *logrus.Logger
+	repo        Repository
+	logger      *logrus.Logger
+	botToken    string
+	sender      Sender
+	tokens      TokenEncoder
+	phoneRegion string
 }
 
 // NewUserService - конструктор Service.
 // Ответ: Возвращает ссылку на структуру Service.
-func NewService(repo *user.Repository, logger *logrus.Logger) *Service {
+func NewService(repo Repository, logger *logrus.Logger) *Service {
 	return &Service{
-		repo:   repo,
-		logger: logger,
+		repo:        repo,
+		logger:      logger,
+		phoneRegion: phonenum.DefaultRegion,
 	}
 }
 
+// WithTelegramBotToken задаёт токен бота, которым проверяются подписи
+// Telegram Login Widget и Web App initData.
+func (s *Service) WithTelegramBotToken(botToken string) *Service {
+	s.botToken = botToken
+	return s
+}
+
+// WithTokenEncoder подключает выпуск JWT-токенов; без него вход по Telegram невозможен
+func (s *Service) WithTokenEncoder(tokens TokenEncoder) *Service {
+	s.tokens = tokens
+	return s
+}
+
+// WithPhoneRegion задаёт регион для номеров, введённых без кода страны
+func (s *Service) WithPhoneRegion(region string) *Service {
+	s.phoneRegion = region
+	return s
+}
+
+// WithSender подключает отправку уведомлений и кодов в Telegram
+func (s *Service) WithSender(snd Sender) *Service {
+	s.sender = snd
+	return s
+}
+
 // UpdateNamesRequest описывает разрешенные для изменения поля пользователя
 type UpdateNamesRequest struct {
 	UserID    string `json:"user_id"`
@@ -31,3 +102,13 @@ type UpdateTimezoneRequest struct {
 	UserID   string `json:"user_id"`
 	Timezone string `json:"timezone"`
 }
+
+type UpdateLanguageRequest struct {
+	UserID   string `json:"user_id"`
+	Language string `json:"language"`
+}
+
+type UpdateDigestTimeRequest struct {
+	UserID     string `json:"user_id"`
+	DigestTime string `json:"digest_time"`
+}
@@ -11,13 +11,13 @@ import (
 )
 
 // ExtractUserIDFromToken извлекает user_id из Bearer токена
-func ExtractUserIDFromToken(ctx *gin.Context) (uuid.UUID, error) {
+func ExtractUserIDFromToken(ctx *gin.Context, enc *encoder.Encoder) (uuid.UUID, error) {
 	auth := ctx.GetHeader("Authorization")
 	if !strings.HasPrefix(auth, "Bearer ") {
 		return uuid.Nil, fmt.Errorf("missing bearer token")
 	}
 	tokenString := strings.TrimPrefix(auth, "Bearer ")
-	token, err := encoder.ParseToken(tokenString)
+	token, err := enc.ParseToken(tokenString)
 	if err != nil || !token.Valid {
 		return uuid.Nil, fmt.Errorf("invalid token")
 	}
@@ -34,7 +34,13 @@ func ExtractUserIDFromToken(ctx *gin.Context) (uuid.UUID, error) {
 	}
 }
 
-// ParseToken парсит JWT токен
-func ParseToken(tokenString string) (*jwt.Token, error) {
-	return encoder.ParseToken(tokenString)
+// CurrentUserID возвращает пользователя, установленного middleware в контекст
+// (сессия или Telegram-бот)
+func CurrentUserID(ctx *gin.Context) (uuid.UUID, error) {
+	if v, ok := ctx.Get("user_id"); ok {
+		if id, ok := v.(uuid.UUID); ok && id != uuid.Nil {
+			return id, nil
+		}
+	}
+	return uuid.Nil, fmt.Errorf("user not authenticated")
 }
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DefaultPeriod — период выборок по датам, если to не передан
+const DefaultPeriod = 7 * 24 * time.Hour
+
+// DatePeriod разбирает query-параметры from и to (YYYY-MM-DD, UTC, to не включается);
+// по умолчанию — DefaultPeriod с сегодняшнего дня. При ошибке отвечает 400 и возвращает false
+func DatePeriod(ctx *gin.Context) (time.Time, time.Time, bool) {
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	if raw := ctx.Query("from"); raw != "" {
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "from must be YYYY-MM-DD"})
+			return time.Time{}, time.Time{}, false
+		}
+		from = t
+	}
+	to := from.Add(DefaultPeriod)
+	if raw := ctx.Query("to"); raw != "" {
+		t, err := time.Parse(time.DateOnly, raw)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "to must be YYYY-MM-DD"})
+			return time.Time{}, time.Time{}, false
+		}
+		to = t
+	}
+	return from, to, true
+}
@@ -0,0 +1,405 @@
+// Package config собирает настройки API-сервиса: значения по умолчанию,
+// затем YAML-файл (CONFIG_FILE), затем переменные окружения (и .env).
+// Компоненты получают нужные им секции через конструкторы и не читают окружение сами.
+package config
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/pkg/phone"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "******"
+
+// minJWTSecretLen — минимальная длина ключа подписи токенов в release
+const minJWTSecretLen = 32
+
+type Config struct {
+	Server    ServerConfig    `yaml:"server"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Security  SecurityConfig  `yaml:"security"`
+	CORS      CORSConfig      `yaml:"cors"`
+	Telegram  TelegramConfig  `yaml:"telegram"`
+	Phone     PhoneConfig     `yaml:"phone"`
+	Retention RetentionConfig `yaml:"retention"`
+	Payment   PaymentConfig   `yaml:"payment"`
+}
+
+type ServerConfig struct {
+	Port    int    `yaml:"port"`
+	GinMode string `yaml:"gin_mode"`
+}
+
+type DatabaseConfig struct {
+	Host           string `yaml:"host"`
+	Port           int    `yaml:"port"`
+	Name           string `yaml:"name"`
+	User           string `yaml:"user"`
+	Password       string `yaml:"password"`
+	SSLMode        string `yaml:"ssl_mode"`
+	MigrateOnStart string `yaml:"migrate_on_start"`
+}
+
+type SecurityConfig struct {
+	// JWTSecret — ключ подписи токенов сессий; обязателен в release, иначе генерируется при старте
+	JWTSecret      string `yaml:"jwt_secret"`
+	AdminPassword  string `yaml:"admin_password"`
+	InternalToken  string `yaml:"internal_token"`
+	FrontendSecret string `yaml:"frontend_secret"`
+}
+
+type CORSConfig struct {
+	AllowedOrigins []string `yaml:"allowed_origins"`
+}
+
+type TelegramConfig struct {
+	// HTTPBase — адрес HTTP-нотификатора telegram-сервиса
+	HTTPBase string `yaml:"http_base"`
+	// HTTPSecret — токен X-Internal-Token для нотификатора (по умолчанию Security.InternalToken)
+	HTTPSecret string `yaml:"http_secret"`
+	// BotToken — токен бота для проверки Login Widget и Mini App initData
+	BotToken string `yaml:"bot_token"`
+	// Timezone — таймзона для текстов уведомлений, если у получателя она не известна
+	Timezone string `yaml:"timezone"`
+}
+
+type PhoneConfig struct {
+	DefaultRegion string `yaml:"default_region"`
+}
+
+type RetentionConfig struct {
+	// UserDays — через сколько дней после удаления стираются персональные данные пользователя; 0 — не стирать
+	UserDays int `yaml:"user_days"`
+}
+
+type PaymentConfig struct {
+	// Provider — провайдер предоплаты: "" — выключена (услуги записываются без оплаты),
+	// fake — локальный для разработки и тестов, yookassa — ЮKassa
+	Provider string `yaml:"provider"`
+	// HoldMinutes — сколько минут слот держится за клиентом в ожидании оплаты
+	HoldMinutes int `yaml:"hold_minutes"`
+	// ReturnURL — куда провайдер возвращает клиента после оплаты
+	ReturnURL string `yaml:"return_url"`
+	// WebhookSecret — ключ HMAC-подписи вебхуков провайдера fake
+	WebhookSecret string         `yaml:"webhook_secret"`
+	YooKassa      YooKassaConfig `yaml:"yookassa"`
+}
+
+type YooKassaConfig struct {
+	ShopID    string `yaml:"shop_id"`
+	SecretKey string `yaml:"secret_key"`
+	APIBase   string `yaml:"api_base"`
+}
+
+// Default возвращает конфигурацию для локальной разработки
+func Default() Config {
+	return Config{
+		Server: ServerConfig{Port: 8090, GinMode: "debug"},
+		Database: DatabaseConfig{
+			Host:           "localhost",
+			Port:           5432,
+			Name:           "postgres",
+			User:           "postgres",
+			Password:       "password",
+			SSLMode:        "disable",
+			MigrateOnStart: "check",
+		},
+		CORS: CORSConfig{AllowedOrigins: []string{
+			"http://localhost:3000", "http://localhost:5173", "http://localhost:8091",
+		}},
+		Telegram:  TelegramConfig{HTTPBase: "http://telegram:8091"},
+		Phone:     PhoneConfig{DefaultRegion: phone.DefaultRegion},
+		Retention: RetentionConfig{UserDays: 90},
+		Payment: PaymentConfig{
+			HoldMinutes: 15,
+			YooKassa:    YooKassaConfig{APIBase: "https://api.yookassa.ru/v3"},
+		},
+	}
+}
+
+// Load собирает конфигурацию и проверяет её. Путь к файлу берётся из аргумента
+// или CONFIG_FILE; без файла используются значения по умолчанию и окружение.
+func Load(path string) (*Config, error) {
+	loadDotEnv()
+
+	cfg := Default()
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := cfg.readFile(path); err != nil {
+			return nil, err
+		}
+	}
+	envErr := cfg.applyEnv(os.LookupEnv)
+	if cfg.Telegram.HTTPSecret == "" {
+		cfg.Telegram.HTTPSecret = cfg.Security.InternalToken
+	}
+	if err := errors.Join(envErr, cfg.Validate()); err != nil {
+		return nil, err
+	}
+	if cfg.Security.JWTSecret == "" {
+		// Вне release секрет можно не задавать: токены живут до перезапуска процесса
+		cfg.Security.JWTSecret = randomSecret()
+		log.Printf("Notice: security.jwt_secret is not set, using a random one; sessions will not survive a restart")
+	}
+	return &cfg, nil
+}
+
+// randomSecret возвращает случайный ключ подписи для локальной разработки
+func randomSecret() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("config: generate jwt secret: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+// readFile накладывает YAML-файл на текущие значения; неизвестные ключи считаются ошибкой
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: read %s: %w", path, err)
+	}
+	defer f.Close()
+	dec := yaml.NewDecoder(f)
+	dec.KnownFields(true)
+	if err := dec.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: parse %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadDotEnv подхватывает .env из образа и из рабочей директории (не перезаписывая окружение)
+func loadDotEnv() {
+	for _, p := range []string{"/app/.env", ".env"} {
+		if err := godotenv.Load(p); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Notice: Could not load .env file from %s: %v", p, err)
+		}
+	}
+}
+
+// applyEnv накладывает переменные окружения поверх значений из файла
+func (c *Config) applyEnv(lookup func(string) (string, bool)) error {
+	var errs []error
+	str := func(key string, dst *string) {
+		if v, ok := lookup(key); ok {
+			*dst = v
+		}
+	}
+	num := func(key string, dst *int) {
+		if v, ok := lookup(key); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(v))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("config: %s: %q is not a number", key, v))
+				return
+			}
+			*dst = n
+		}
+	}
+
+	num("PORT", &c.Server.Port)
+	str("GIN_MODE", &c.Server.GinMode)
+
+	str("DB_HOST", &c.Database.Host)
+	num("DB_PORT", &c.Database.Port)
+	str("DB_NAME", &c.Database.Name)
+	str("DB_USER", &c.Database.User)
+	str("DB_PASSWORD", &c.Database.Password)
+	str("DB_SSLMODE", &c.Database.SSLMode)
+	str("DB_MIGRATE_ON_START", &c.Database.MigrateOnStart)
+
+	str("JWT_SECRET", &c.Security.JWTSecret)
+	str("ADMIN_PASSWORD", &c.Security.AdminPassword)
+	str("INTERNAL_TOKEN", &c.Security.InternalToken)
+	str("FRONTEND_SECRET", &c.Security.FrontendSecret)
+
+	if v, ok := lookup("ALLOWED_ORIGINS"); ok && strings.TrimSpace(v) != "" {
+		c.CORS.AllowedOrigins = nil
+		for _, o := range strings.Split(v, ",") {
+			if o = strings.TrimSpace(o); o != "" {
+				c.CORS.AllowedOrigins = append(c.CORS.AllowedOrigins, o)
+			}
+		}
+	}
+
+	str("TELEGRAM_HTTP_BASE", &c.Telegram.HTTPBase)
+	str("TELEGRAM_HTTP_SECRET", &c.Telegram.HTTPSecret)
+	str("TELEGRAM_BOT_TOKEN", &c.Telegram.BotToken)
+	str("TIMEZONE", &c.Telegram.Timezone)
+	str("TELEGRAM_TIMEZONE", &c.Telegram.Timezone)
+
+	str("PHONE_DEFAULT_REGION", &c.Phone.DefaultRegion)
+	c.Phone.DefaultRegion = strings.ToUpper(strings.TrimSpace(c.Phone.DefaultRegion))
+
+	num("RETENTION_USER_DAYS", &c.Retention.UserDays)
+
+	str("PAYMENT_PROVIDER", &c.Payment.Provider)
+	c.Payment.Provider = strings.ToLower(strings.TrimSpace(c.Payment.Provider))
+	num("PAYMENT_HOLD_MINUTES", &c.Payment.HoldMinutes)
+	str("PAYMENT_RETURN_URL", &c.Payment.ReturnURL)
+	str("PAYMENT_WEBHOOK_SECRET", &c.Payment.WebhookSecret)
+	str("YOOKASSA_SHOP_ID", &c.Payment.YooKassa.ShopID)
+	str("YOOKASSA_SECRET_KEY", &c.Payment.YooKassa.SecretKey)
+	str("YOOKASSA_API_BASE", &c.Payment.YooKassa.APIBase)
+
+	return errors.Join(errs...)
+}
+
+// Validate проверяет конфигурацию и возвращает все найденные ошибки сразу
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port: %d is out of range", c.Server.Port))
+	}
+	switch c.Server.GinMode {
+	case "debug", "release", "test":
+	default:
+		errs = append(errs, fmt.Errorf("server.gin_mode: %q must be debug, release or test", c.Server.GinMode))
+	}
+
+	if c.Database.Host == "" || c.Database.Name == "" || c.Database.User == "" {
+		errs = append(errs, errors.New("database: host, name and user are required"))
+	}
+	if c.Database.Port < 1 || c.Database.Port > 65535 {
+		errs = append(errs, fmt.Errorf("database.port: %d is out of range", c.Database.Port))
+	}
+	switch c.Database.MigrateOnStart {
+	case "check", "up":
+	default:
+		errs = append(errs, fmt.Errorf("database.migrate_on_start: %q must be check or up", c.Database.MigrateOnStart))
+	}
+
+	if c.Server.Production() {
+		if c.Database.Password == "" || c.Database.Password == Default().Database.Password {
+			errs = append(errs, errors.New("database.password: the default password is not allowed in release mode"))
+		}
+		if len(c.Security.JWTSecret) < minJWTSecretLen {
+			errs = append(errs, fmt.Errorf("security.jwt_secret: at least %d characters are required in release mode", minJWTSecretLen))
+		}
+	}
+
+	for _, o := range c.CORS.AllowedOrigins {
+		if u, err := url.Parse(o); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("cors.allowed_origins: %q is not an origin", o))
+		}
+	}
+
+	if u, err := url.Parse(c.Telegram.HTTPBase); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, fmt.Errorf("telegram.http_base: %q is not a URL", c.Telegram.HTTPBase))
+	}
+	if c.Telegram.Timezone != "" {
+		if _, err := time.LoadLocation(c.Telegram.Timezone); err != nil {
+			errs = append(errs, fmt.Errorf("telegram.timezone: %v", err))
+		}
+	}
+
+	if !phone.SupportedRegion(c.Phone.DefaultRegion) {
+		errs = append(errs, fmt.Errorf("phone.default_region: %q is not supported", c.Phone.DefaultRegion))
+	}
+
+	if c.Retention.UserDays < 0 {
+		errs = append(errs, fmt.Errorf("retention.user_days: %d must not be negative", c.Retention.UserDays))
+	}
+
+	switch c.Payment.Provider {
+	case "":
+	case "fake":
+		if c.Payment.WebhookSecret == "" {
+			errs = append(errs, errors.New("payment.webhook_secret: required for the fake provider"))
+		}
+	case "yookassa":
+		if c.Payment.YooKassa.ShopID == "" || c.Payment.YooKassa.SecretKey == "" {
+			errs = append(errs, errors.New("payment.yookassa: shop_id and secret_key are required"))
+		}
+		if u, err := url.Parse(c.Payment.YooKassa.APIBase); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("payment.yookassa.api_base: %q is not a URL", c.Payment.YooKassa.APIBase))
+		}
+		if c.Payment.ReturnURL == "" {
+			errs = append(errs, errors.New("payment.return_url: required for yookassa"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("payment.provider: %q must be empty, fake or yookassa", c.Payment.Provider))
+	}
+	if c.Payment.HoldMinutes < 1 || c.Payment.HoldMinutes > 1440 {
+		errs = append(errs, fmt.Errorf("payment.hold_minutes: %d must be between 1 and 1440", c.Payment.HoldMinutes))
+	}
+	if c.Payment.ReturnURL != "" {
+		if u, err := url.Parse(c.Payment.ReturnURL); err != nil || u.Scheme == "" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("payment.return_url: %q is not a URL", c.Payment.ReturnURL))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return nil
+}
+
+// Production сообщает, запущен ли сервер в боевом режиме (gin_mode: release)
+func (s ServerConfig) Production() bool {
+	return s.GinMode == "release"
+}
+
+// Addr возвращает адрес, на котором слушает HTTP-сервер
+func (s ServerConfig) Addr() string {
+	return fmt.Sprintf(":%d", s.Port)
+}
+
+// Hold — сколько слот держится за клиентом в ожидании оплаты
+func (p PaymentConfig) Hold() time.Duration {
+	return time.Duration(p.HoldMinutes) * time.Minute
+}
+
+// Location возвращает таймзону для уведомлений (по умолчанию системную)
+func (t TelegramConfig) Location() *time.Location {
+	if t.Timezone != "" {
+		if loc, err := time.LoadLocation(t.Timezone); err == nil {
+			return loc
+		}
+	}
+	return time.Local
+}
+
+// Redacted возвращает копию конфигурации со скрытыми секретами — её можно логировать
+func (c Config) Redacted() Config {
+	hide := func(s string) string {
+		if s == "" {
+			return ""
+		}
+		return redacted
+	}
+	c.Database.Password = hide(c.Database.Password)
+	c.Security.JWTSecret = hide(c.Security.JWTSecret)
+	c.Security.AdminPassword = hide(c.Security.AdminPassword)
+	c.Security.InternalToken = hide(c.Security.InternalToken)
+	c.Security.FrontendSecret = hide(c.Security.FrontendSecret)
+	c.Telegram.HTTPSecret = hide(c.Telegram.HTTPSecret)
+	c.Telegram.BotToken = hide(c.Telegram.BotToken)
+	c.Payment.WebhookSecret = hide(c.Payment.WebhookSecret)
+	c.Payment.YooKassa.SecretKey = hide(c.Payment.YooKassa.SecretKey)
+	c.CORS.AllowedOrigins = append([]string(nil), c.CORS.AllowedOrigins...)
+	return c
+}
+
+// String печатает конфигурацию в YAML без секретов
+func (c Config) String() string {
+	data, err := yaml.Marshal(c.Redacted())
+	if err != nil {
+		return fmt.Sprintf("config: %v", err)
+	}
+	return string(data)
+}
@@ -0,0 +1,192 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadFileAndEnvOverlay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	data := `
+server:
+  port: 9000
+database:
+  host: db.internal
+  password: from-file
+security:
+  internal_token: file-token
+cors:
+  allowed_origins: ["https://example.com"]
+`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("DB_HOST", "db.env")
+	t.Setenv("TELEGRAM_TIMEZONE", "Europe/Moscow")
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server.Addr() != ":9000" {
+		t.Errorf("Server.Addr() = %q, want :9000", cfg.Server.Addr())
+	}
+	if cfg.Database.Host != "db.env" {
+		t.Errorf("Database.Host = %q, env must override the file", cfg.Database.Host)
+	}
+	if cfg.Database.Name != "postgres" {
+		t.Errorf("Database.Name = %q, want default", cfg.Database.Name)
+	}
+	if cfg.Security.JWTSecret == "" {
+		t.Error("Security.JWTSecret is empty, want a generated secret outside release")
+	}
+	if cfg.Telegram.HTTPSecret != "file-token" {
+		t.Errorf("Telegram.HTTPSecret = %q, want fallback to internal token", cfg.Telegram.HTTPSecret)
+	}
+	if got := cfg.Telegram.Location().String(); got != "Europe/Moscow" {
+		t.Errorf("Telegram.Location() = %q", got)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 1 || cfg.CORS.AllowedOrigins[0] != "https://example.com" {
+		t.Errorf("CORS.AllowedOrigins = %v", cfg.CORS.AllowedOrigins)
+	}
+}
+
+func TestLoadRejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  prot: 1\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Fatal("Load() error = nil, want unknown field error")
+	}
+}
+
+func TestApplyEnv(t *testing.T) {
+	env := map[string]string{
+		"PORT":                 "8081",
+		"ALLOWED_ORIGINS":      " https://a.example , ,https://b.example",
+		"PHONE_DEFAULT_REGION": " kz ",
+		"TIMEZONE":             "Asia/Almaty",
+		"TELEGRAM_TIMEZONE":    "Europe/Moscow",
+		"RETENTION_USER_DAYS":  "0",
+		"PAYMENT_PROVIDER":     " YooKassa ",
+		"PAYMENT_HOLD_MINUTES": "30",
+	}
+	cfg := Default()
+	if err := cfg.applyEnv(func(k string) (string, bool) { v, ok := env[k]; return v, ok }); err != nil {
+		t.Fatalf("applyEnv() error = %v", err)
+	}
+	if cfg.Server.Port != 8081 {
+		t.Errorf("Server.Port = %d", cfg.Server.Port)
+	}
+	if strings.Join(cfg.CORS.AllowedOrigins, ",") != "https://a.example,https://b.example" {
+		t.Errorf("CORS.AllowedOrigins = %v", cfg.CORS.AllowedOrigins)
+	}
+	if cfg.Phone.DefaultRegion != "KZ" {
+		t.Errorf("Phone.DefaultRegion = %q", cfg.Phone.DefaultRegion)
+	}
+	if cfg.Telegram.Timezone != "Europe/Moscow" {
+		t.Errorf("Telegram.Timezone = %q, TELEGRAM_TIMEZONE must win over TIMEZONE", cfg.Telegram.Timezone)
+	}
+	if cfg.Retention.UserDays != 0 {
+		t.Errorf("Retention.UserDays = %d, want 0 (purge disabled)", cfg.Retention.UserDays)
+	}
+	if cfg.Payment.Provider != "yookassa" || cfg.Payment.Hold() != 30*time.Minute {
+		t.Errorf("Payment = %q hold %s, want yookassa hold 30m", cfg.Payment.Provider, cfg.Payment.Hold())
+	}
+
+	bad := Default()
+	err := bad.applyEnv(func(k string) (string, bool) {
+		if k == "DB_PORT" {
+			return "five", true
+		}
+		return "", false
+	})
+	if err == nil || !strings.Contains(err.Error(), "DB_PORT") {
+		t.Fatalf("applyEnv() error = %v, want DB_PORT error", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*Config)
+		wantErr string
+	}{
+		{name: "defaults are valid", mutate: func(*Config) {}},
+		{name: "port out of range", mutate: func(c *Config) { c.Server.Port = 70000 }, wantErr: "server.port"},
+		{name: "gin mode", mutate: func(c *Config) { c.Server.GinMode = "prod" }, wantErr: "server.gin_mode"},
+		{name: "missing db host", mutate: func(c *Config) { c.Database.Host = "" }, wantErr: "database"},
+		{name: "migrate mode", mutate: func(c *Config) { c.Database.MigrateOnStart = "auto" }, wantErr: "migrate_on_start"},
+		{name: "bad origin", mutate: func(c *Config) { c.CORS.AllowedOrigins = []string{"localhost"} }, wantErr: "cors.allowed_origins"},
+		{name: "bad telegram base", mutate: func(c *Config) { c.Telegram.HTTPBase = "telegram" }, wantErr: "telegram.http_base"},
+		{name: "bad timezone", mutate: func(c *Config) { c.Telegram.Timezone = "Mars/Olympus" }, wantErr: "telegram.timezone"},
+		{name: "unknown phone region", mutate: func(c *Config) { c.Phone.DefaultRegion = "XX" }, wantErr: "phone.default_region"},
+		{name: "negative retention", mutate: func(c *Config) { c.Retention.UserDays = -1 }, wantErr: "retention.user_days"},
+		{name: "unknown payment provider", mutate: func(c *Config) { c.Payment.Provider = "stripe" }, wantErr: "payment.provider"},
+		{name: "fake provider without secret", mutate: func(c *Config) { c.Payment.Provider = "fake" }, wantErr: "payment.webhook_secret"},
+		{name: "fake provider", mutate: func(c *Config) { c.Payment.Provider, c.Payment.WebhookSecret = "fake", "s" }},
+		{name: "yookassa without keys", mutate: func(c *Config) {
+			c.Payment.Provider, c.Payment.ReturnURL = "yookassa", "https://example.com/records"
+		}, wantErr: "payment.yookassa"},
+		{name: "yookassa without return url", mutate: func(c *Config) {
+			c.Payment.Provider, c.Payment.YooKassa.ShopID, c.Payment.YooKassa.SecretKey = "yookassa", "1", "k"
+		}, wantErr: "payment.return_url"},
+		{name: "release with default db password", mutate: func(c *Config) {
+			c.Server.GinMode, c.Security.JWTSecret = "release", strings.Repeat("k", 32)
+		}, wantErr: "database.password"},
+		{name: "release without jwt secret", mutate: func(c *Config) {
+			c.Server.GinMode, c.Database.Password = "release", "db-secret"
+		}, wantErr: "security.jwt_secret"},
+		{name: "release with short jwt secret", mutate: func(c *Config) {
+			c.Server.GinMode, c.Database.Password, c.Security.JWTSecret = "release", "db-secret", "short"
+		}, wantErr: "security.jwt_secret"},
+		{name: "release", mutate: func(c *Config) {
+			c.Server.GinMode, c.Database.Password, c.Security.JWTSecret = "release", "db-secret", strings.Repeat("k", 32)
+		}},
+		{name: "hold too long", mutate: func(c *Config) { c.Payment.HoldMinutes = 2000 }, wantErr: "payment.hold_minutes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "db-secret"
+	cfg.Security.AdminPassword = "admin-secret"
+	cfg.Security.JWTSecret = "jwt-secret"
+	cfg.Security.InternalToken = "internal-secret"
+	cfg.Telegram.BotToken = "123:bot-secret"
+	cfg.Payment.WebhookSecret = "webhook-secret"
+	cfg.Payment.YooKassa.SecretKey = "shop-secret"
+
+	out := cfg.String()
+	for _, secret := range []string{"db-secret", "admin-secret", "jwt-secret", "internal-secret", "bot-secret", "webhook-secret", "shop-secret"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("String() leaks %q:\n%s", secret, out)
+		}
+	}
+	if cfg.Database.Password != "db-secret" {
+		t.Error("Redacted() must not modify the original config")
+	}
+	if cfg.Redacted().Security.FrontendSecret != "" {
+		t.Error("empty secrets must stay empty")
+	}
+}
@@ -1,15 +1,14 @@
 package database
 
 import (
-	"app/pkg/models"
 	"context"
 	"fmt"
 	"log"
-	"os"
 	"sync"
 	"time"
 
-	"github.com/joho/godotenv"
+	"app/internal/config"
+
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
@@ -25,27 +24,18 @@ type Database struct {
 	name string
 }
 
-// Init initializes database connection and runs migrations
-func Init() *Database {
-	host := GetEnv("DB_HOST", "localhost")
-	port := GetEnv("DB_PORT", "5432")
-	dbname := GetEnv("DB_NAME", "postgres")
-	user := GetEnv("DB_USER", "postgres")
-	password := GetEnv("DB_PASSWORD", "password")
-
-	if host == "" || port == "" || user == "" || password == "" || dbname == "" {
-		log.Fatal("Not all required database environment variables are set")
-	}
-	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=UTC",
-		host, user, password, dbname, port)
+// Init initializes database connection (schema is managed by versioned migrations, see migrate.go)
+func Init(cfg config.DatabaseConfig) *Database {
+	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=%s TimeZone=UTC",
+		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode)
 
-	log.Printf("Connecting to database: host=%s, port=%s, dbname=%s, user=%s", host, port, dbname, user)
+	log.Printf("Connecting to database: host=%s, port=%d, dbname=%s, user=%s", cfg.Host, cfg.Port, cfg.Name, cfg.User)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	conn, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
 	}
-	sqlDB, err := db.DB()
+	sqlDB, err := conn.DB()
 	if err != nil {
 		log.Fatal("Failed to get database instance:", err)
 	}
@@ -54,15 +44,15 @@ func Init() *Database {
 		log.Fatal("Database ping failed:", err)
 	}
 	log.Println("Successfully connected to database")
-	db.AutoMigrate(&models.User{}, &models.UserRole{}, &models.Slot{}, &models.Record{}, &models.Notification{}, &models.AdClickStats{})
-	return &Database{
+	db = &Database{
 		name: "database",
-		DB:   db,
+		DB:   conn,
 	}
+	return db
 }
 
 // GetDB returns initialized database connection
-func GetDB() *Database {
+func GetDB(cfg config.DatabaseConfig) *Database {
 	if db == nil {
 		sleep := 1 * time.Second
 		once.Do(func() {
@@ -70,7 +60,7 @@ func GetDB() *Database {
 				sleep = sleep * 2
 				fmt.Printf("Database is unavailable. Wait for %d sec.\n", sleep)
 				time.Sleep(sleep)
-				db = Init()
+				db = Init(cfg)
 			}
 		})
 	}
@@ -91,15 +81,3 @@ func (d *Database) Shutdown(ctx context.Context) error {
 func (d *Database) Name() string {
 	return d.name
 }
-func GetEnv(key, defaultValue string) string {
-	if err := godotenv.Load("/app/.env"); err != nil {
-		log.Printf("Notice: Could not load .env file from /app/.env: %v", err)
-	}
-	if err := godotenv.Load(".env"); err != nil {
-		log.Printf("Notice: Could not load .env file from /.env: %v", err)
-	}
-	if value, exists := os.LookupEnv(key); exists {
-		return value
-	}
-	return defaultValue
-}
@@ -0,0 +1,218 @@
+package database
+
+import (
+	"app/migrations"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// schemaMigration — запись о применённой миграции
+type schemaMigration struct {
+	Version   int64     `gorm:"primaryKey; autoIncrement:false"`
+	Name      string    `gorm:"not null"`
+	AppliedAt time.Time `gorm:"not null"`
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+// MigrationStatus описывает состояние одной миграции
+type MigrationStatus struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator применяет и откатывает встроенные миграции
+type Migrator struct {
+	db         *gorm.DB
+	migrations []migrations.Migration
+}
+
+// NewMigrator загружает встроенные миграции
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	all, err := migrations.All()
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: all}, nil
+}
+
+func (m *Migrator) ensureTable() error {
+	return m.db.Exec(`CREATE TABLE IF NOT EXISTS "schema_migrations" (
+		"version" bigint PRIMARY KEY,
+		"name" text NOT NULL,
+		"applied_at" timestamptz NOT NULL
+	)`).Error
+}
+
+func (m *Migrator) applied() (map[int64]schemaMigration, error) {
+	if err := m.ensureTable(); err != nil {
+		return nil, err
+	}
+	var rows []schemaMigration
+	if err := m.db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	result := make(map[int64]schemaMigration, len(rows))
+	for _, r := range rows {
+		result[r.Version] = r
+	}
+	return result, nil
+}
+
+// Status возвращает состояние всех известных миграций
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]MigrationStatus, 0, len(m.migrations))
+	for _, mig := range m.migrations {
+		st := MigrationStatus{Version: mig.Version, Name: mig.Name}
+		if row, ok := applied[mig.Version]; ok {
+			appliedAt := row.AppliedAt
+			st.AppliedAt = &appliedAt
+		}
+		result = append(result, st)
+	}
+	return result, nil
+}
+
+// Pending возвращает миграции, которые ещё не применены
+func (m *Migrator) Pending() ([]migrations.Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var pending []migrations.Migration
+	for _, mig := range m.migrations {
+		if _, ok := applied[mig.Version]; !ok {
+			pending = append(pending, mig)
+		}
+	}
+	return pending, nil
+}
+
+// Up применяет все неприменённые миграции, каждую в отдельной транзакции
+func (m *Migrator) Up() (int, error) {
+	pending, err := m.Pending()
+	if err != nil {
+		return 0, err
+	}
+	for i, mig := range pending {
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := mig.Up(tx); err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: mig.Version, Name: mig.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return i, fmt.Errorf("migration %04d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		log.Printf("Migrator.Up: applied %04d_%s", mig.Version, mig.Name)
+	}
+	return len(pending), nil
+}
+
+// Down откатывает последние steps применённых миграций
+func (m *Migrator) Down(steps int) (int, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return 0, err
+	}
+	done := 0
+	for i := len(m.migrations) - 1; i >= 0 && done < steps; i-- {
+		mig := m.migrations[i]
+		if _, ok := applied[mig.Version]; !ok {
+			continue
+		}
+		if mig.Down == nil {
+			return done, fmt.Errorf("migration %04d_%s is irreversible", mig.Version, mig.Name)
+		}
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := mig.Down(tx); err != nil {
+				return err
+			}
+			return tx.Delete(&schemaMigration{}, mig.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		log.Printf("Migrator.Down: reverted %04d_%s", mig.Version, mig.Name)
+		done++
+	}
+	return done, nil
+}
+
+// EnsureMigrated возвращает ошибку, если в БД есть неприменённые миграции
+func (m *Migrator) EnsureMigrated() error {
+	pending, err := m.Pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(pending))
+	for _, mig := range pending {
+		names = append(names, fmt.Sprintf("%04d_%s", mig.Version, mig.Name))
+	}
+	return fmt.Errorf("database schema is not up to date, pending migrations: %s (run `app migrate up`)", strings.Join(names, ", "))
+}
+
+// CreateMigration создаёт пустую пару up/down SQL-файлов со следующим номером версии в dir
+func (m *Migrator) CreateMigration(dir, name string) ([]string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if name == "" {
+		return nil, fmt.Errorf("migration name is required")
+	}
+
+	var next int64 = 1
+	if len(m.migrations) > 0 {
+		next = m.migrations[len(m.migrations)-1].Version + 1
+	}
+	var files []string
+	for _, direction := range []string{"up", "down"} {
+		path := filepath.Join(dir, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+		content := fmt.Sprintf("-- %04d_%s (%s)\n", next, name, direction)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return files, err
+		}
+		files = append(files, path)
+	}
+	return files, nil
+}
+
+// PrepareSchema проверяет схему перед запуском сервиса. В режиме check (по умолчанию)
+// сервис отказывается стартовать на неприменённых миграциях; в режиме up применяет их.
+func PrepareSchema(db *gorm.DB, mode string) error {
+	m, err := NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case "check":
+		return m.EnsureMigrated()
+	case "up":
+		n, err := m.Up()
+		if err != nil {
+			return err
+		}
+		log.Printf("PrepareSchema: applied %d migration(s)", n)
+		return nil
+	default:
+		return fmt.Errorf("unknown migrate-on-start mode %q (expected check or up)", mode)
+	}
+}
@@ -0,0 +1,100 @@
+package database
+
+import (
+	"app/migrations"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// testDB открывает PostgreSQL из MIGRATIONS_TEST_DSN в отдельной пустой схеме.
+// Без переменной тест пропускается; `make migrate-test` задаёт её для postgres из docker compose
+func testDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("MIGRATIONS_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MIGRATIONS_TEST_DSN is not set")
+	}
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Одно соединение — search_path действует на все запросы теста
+	sqlDB.SetMaxOpenConns(1)
+	schema := fmt.Sprintf("migrate_test_%d", time.Now().UnixNano())
+	if err := db.Exec(`CREATE SCHEMA ` + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	if err := db.Exec(`SET search_path TO ` + schema + `, public`).Error; err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = db.Exec(`DROP SCHEMA ` + schema + ` CASCADE`).Error
+		_ = sqlDB.Close()
+	})
+	return db
+}
+
+func TestUpFromEmptySchema(t *testing.T) {
+	db := testDB(t)
+	all, err := migrations.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := m.Up()
+	if err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if n != len(all) {
+		t.Fatalf("Up() applied %d migrations, want %d", n, len(all))
+	}
+	if err := m.EnsureMigrated(); err != nil {
+		t.Fatalf("EnsureMigrated() error = %v", err)
+	}
+
+	// Откат до необратимой 0002 и повторный накат
+	if _, err := m.Down(len(all) - 2); err != nil {
+		t.Fatalf("Down() error = %v", err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("Up() after Down() error = %v", err)
+	}
+}
+
+// Откат мягкого удаления не должен стирать архив каскадом вместе с записями
+func TestSoftDeleteDownKeepsArchive(t *testing.T) {
+	db := testDB(t)
+	m, err := NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatalf("Up() error = %v", err)
+	}
+	if err := db.Exec(`INSERT INTO users (phone, first_name, surname, deleted_at) VALUES ('+79990000001', 'Анна', 'Архив', now())`).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	all, _ := migrations.All()
+	if _, err := m.Down(len(all) - 12); err == nil {
+		t.Fatal("Down() through 0013_soft_delete succeeded with archived users")
+	}
+	var archived int64
+	if err := db.Table("users").Where("deleted_at IS NOT NULL").Count(&archived).Error; err != nil || archived != 1 {
+		t.Errorf("archived users = %d (%v), want the archive kept", archived, err)
+	}
+}
@@ -0,0 +1,271 @@
+package reminder
+
+import (
+	digestrepo "app/http/repository/digest"
+	"app/http/sender"
+	"app/pkg/locale"
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"context"
+	"contract/money"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// digestWindow — сколько после выбранного времени сводку ещё можно отправить
+	// (API был остановлен или бот недоступен)
+	digestWindow = time.Hour
+	// maxDigestPending — заявок с кнопками в одной сводке, остальные только числом
+	maxDigestPending = 10
+
+	digestDaily  = "daily"
+	digestWeekly = "weekly"
+)
+
+// Тексты сводки на языке мастера (User.Language)
+var (
+	textNoRecordsToday = locale.Text{RU: "Подтверждённых записей на сегодня нет\n", EN: "No confirmed bookings for today\n"}
+	textRecordsToday   = locale.Text{RU: "Записи на сегодня (%d):\n", EN: "Bookings for today (%d):\n"}
+	textPending        = locale.Text{RU: "\nЖдут решения (%d):\n", EN: "\nAwaiting your decision (%d):\n"}
+	textPendingMore    = locale.Text{RU: "…и ещё %d, см. /upcoming\n", EN: "…and %d more, see /upcoming\n"}
+	textNoFreeSlots    = locale.Text{RU: "\nСвободных слотов на сегодня нет", EN: "\nNo free slots left for today"}
+	textFreeSlots      = locale.Text{RU: "\nСвободные слоты (%d): %s", EN: "\nFree slots (%d): %s"}
+	textDailyTitle     = locale.Text{RU: "Сводка на %s (%s)", EN: "Digest for %s (%s)"}
+	textWeeklyTitle    = locale.Text{RU: "Неделя %s – %s", EN: "Week %s – %s"}
+	textWeekly         = locale.Text{
+		RU: "Подтверждено записей: %d\nЖдут решения: %d\nСвободных слотов: %d\nОжидаемая выручка: %s (подтверждённые записи)",
+		EN: "Confirmed bookings: %d\nAwaiting decision: %d\nFree slots: %d\nExpected revenue: %s (confirmed bookings)",
+	}
+)
+
+// DigestStore — выборки для сводки и отметки об отправке (repository/digest)
+type DigestStore interface {
+	Subscribers() ([]models.User, error)
+	Records(masterID uuid.UUID, from, to time.Time) ([]models.Record, error)
+	PendingRecords(masterID uuid.UUID, from time.Time) ([]models.Record, error)
+	FreeSlots(masterID uuid.UUID, from, to time.Time) ([]models.Slot, error)
+	Claim(masterID uuid.UUID, kind string, period time.Time) (bool, error)
+	Release(masterID uuid.UUID, kind string, period time.Time) error
+}
+
+// DigestSender — отправка сводки мастеру в Telegram
+type DigestSender interface {
+	DigestNotify(telegramID int64, title, message string, pending []sender.DigestRecord) error
+}
+
+// Digest каждое утро в выбранное мастером время (в его таймзоне) отправляет
+// записи на день, заявки без решения и свободные слоты, а по понедельникам — итоги недели
+type Digest struct {
+	store  DigestStore
+	sender DigestSender
+	logger *logrus.Logger
+}
+
+func NewDigest(db *gorm.DB, logger *logrus.Logger) *Digest {
+	return &Digest{
+		store:  digestrepo.NewRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// WithSender подключает отправку сводок в Telegram
+func (d *Digest) WithSender(snd DigestSender) *Digest {
+	d.sender = snd
+	return d
+}
+
+// StartDigest проверяет раз в минуту, кому из мастеров пора отправить сводку
+func (d *Digest) StartDigest(ctx context.Context) {
+	runEvery(ctx, d.logger, "Digest", time.Minute, d.sendDigests)
+}
+
+func (d *Digest) sendDigests(now time.Time) {
+	masters, err := d.store.Subscribers()
+	if err != nil {
+		d.logger.WithError(err).Warn("digest: query failed")
+		return
+	}
+	for _, m := range masters {
+		loc := timefmt.Location(nil, m.Timezone)
+		local := now.In(loc)
+		if !digestDue(local, m.DigestTime) {
+			continue
+		}
+		day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+		d.send(m, digestDaily, day, func() (string, string, []sender.DigestRecord, error) {
+			return d.daily(m, local, day)
+		})
+		if local.Weekday() == time.Monday {
+			d.send(m, digestWeekly, day, func() (string, string, []sender.DigestRecord, error) {
+				return d.weekly(m, day)
+			})
+		}
+	}
+}
+
+// send отправляет сводку kind один раз за период: отметка ставится до отправки
+// и снимается, если бот сводку не принял
+func (d *Digest) send(m models.User, kind string, period time.Time, build func() (string, string, []sender.DigestRecord, error)) {
+	log := d.logger.WithFields(logrus.Fields{"master_id": m.ID, "kind": kind})
+	claimed, err := d.store.Claim(m.ID, kind, period)
+	if err != nil || !claimed {
+		return
+	}
+	title, message, pending, err := build()
+	if err == nil {
+		err = d.sender.DigestNotify(m.TelegramID, title, message, pending)
+	}
+	if err != nil {
+		log.WithError(err).Warn("digest: not sent")
+		_ = d.store.Release(m.ID, kind, period)
+	}
+}
+
+// daily — записи на сегодня, заявки без решения и оставшиеся свободные слоты
+func (d *Digest) daily(m models.User, now, day time.Time) (string, string, []sender.DigestRecord, error) {
+	loc, lang := day.Location(), m.Language
+	next := day.AddDate(0, 0, 1)
+	records, err := d.store.Records(m.ID, day, next)
+	if err != nil {
+		return "", "", nil, err
+	}
+	pending, err := d.store.PendingRecords(m.ID, now)
+	if err != nil {
+		return "", "", nil, err
+	}
+	free, err := d.store.FreeSlots(m.ID, now, next)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	b := strings.Builder{}
+	var confirmed []models.Record
+	for _, r := range records {
+		if r.Status == "confirm" {
+			confirmed = append(confirmed, r)
+		}
+	}
+	if len(confirmed) == 0 {
+		b.WriteString(textNoRecordsToday.In(lang))
+	} else {
+		fmt.Fprintf(&b, textRecordsToday.In(lang), len(confirmed))
+		for _, r := range confirmed {
+			fmt.Fprintf(&b, "• %s %s — %s\n", clock(r.Slot, loc), r.Slot.Service.Name, clientContact(r.Client))
+		}
+	}
+
+	var buttons []sender.DigestRecord
+	if len(pending) > 0 {
+		fmt.Fprintf(&b, textPending.In(lang), len(pending))
+		for i, r := range pending {
+			if i == maxDigestPending {
+				fmt.Fprintf(&b, textPendingMore.In(lang), len(pending)-maxDigestPending)
+				break
+			}
+			when := r.Slot.StartTime.In(loc).Format("02.01 15:04")
+			fmt.Fprintf(&b, "• %s %s — %s\n", when, r.Slot.Service.Name, clientContact(r.Client))
+			buttons = append(buttons, sender.DigestRecord{RecordID: r.ID, Label: when + " " + r.Client.FirstName})
+		}
+	}
+
+	if len(free) == 0 {
+		b.WriteString(textNoFreeSlots.In(lang))
+	} else {
+		times := make([]string, 0, len(free))
+		for _, s := range free {
+			times = append(times, s.StartTime.In(loc).Format("15:04"))
+		}
+		fmt.Fprintf(&b, textFreeSlots.In(lang), len(free), strings.Join(times, ", "))
+	}
+
+	title := fmt.Sprintf(textDailyTitle.In(lang), day.Format("02.01.2006"), loc)
+	return title, b.String(), buttons, nil
+}
+
+// weekly — итоги недели с понедельника day: записи, заявки, свободные слоты и ожидаемая выручка
+func (d *Digest) weekly(m models.User, day time.Time) (string, string, []sender.DigestRecord, error) {
+	end := day.AddDate(0, 0, 7)
+	records, err := d.store.Records(m.ID, day, end)
+	if err != nil {
+		return "", "", nil, err
+	}
+	free, err := d.store.FreeSlots(m.ID, day, end)
+	if err != nil {
+		return "", "", nil, err
+	}
+	s := summarize(records)
+	title := fmt.Sprintf(textWeeklyTitle.In(m.Language), day.Format("02.01"), end.AddDate(0, 0, -1).Format("02.01.2006"))
+	message := fmt.Sprintf(textWeekly.In(m.Language), s.confirmed, s.pending, len(free), s.revenueText(m.Language))
+	return title, message, nil, nil
+}
+
+// weekSummary — счётчики недельной сводки
+type weekSummary struct {
+	confirmed int
+	pending   int
+	// revenue — сумма снимков цен подтверждённых записей по валютам
+	revenue map[string]int64
+}
+
+// revenueText — выручка по каждой валюте через « + » на языке lang, без записей — ноль в рублях
+func (s weekSummary) revenueText(lang string) string {
+	if len(s.revenue) == 0 {
+		return locale.Money(0, money.Default, lang)
+	}
+	codes := make([]string, 0, len(s.revenue))
+	for code := range s.revenue {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	parts := make([]string, 0, len(codes))
+	for _, code := range codes {
+		parts = append(parts, locale.Money(s.revenue[code], code, lang))
+	}
+	return strings.Join(parts, " + ")
+}
+
+func summarize(records []models.Record) weekSummary {
+	s := weekSummary{revenue: make(map[string]int64)}
+	for _, r := range records {
+		switch r.Status {
+		case "confirm":
+			s.confirmed++
+			s.revenue[money.Normalize(r.Currency)] += r.Price
+		case "pending":
+			s.pending++
+		}
+	}
+	return s
+}
+
+// digestDue — наступило ли время сводки at ("ЧЧ:ММ") в местном времени local
+func digestDue(local time.Time, at string) bool {
+	t, err := time.Parse("15:04", at)
+	if err != nil {
+		return false
+	}
+	start := time.Date(local.Year(), local.Month(), local.Day(), t.Hour(), t.Minute(), 0, 0, local.Location())
+	return !local.Before(start) && local.Before(start.Add(digestWindow))
+}
+
+func clock(s models.Slot, loc *time.Location) string {
+	if s.EndTime.IsZero() {
+		return s.StartTime.In(loc).Format("15:04")
+	}
+	return s.StartTime.In(loc).Format("15:04") + "–" + s.EndTime.In(loc).Format("15:04")
+}
+
+func clientContact(u models.User) string {
+	name := strings.TrimSpace(u.FirstName + " " + u.Surname)
+	if u.Phone == "" {
+		return name
+	}
+	return name + ", " + u.Phone
+}
@@ -0,0 +1,210 @@
+package reminder
+
+import (
+	"app/http/sender"
+	"app/pkg/models"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeDigestStore struct {
+	master  models.User
+	records []models.Record
+	slots   []models.Slot
+	claimed map[string]bool
+}
+
+func (f *fakeDigestStore) Subscribers() ([]models.User, error) { return []models.User{f.master}, nil }
+
+func (f *fakeDigestStore) Records(_ uuid.UUID, from, to time.Time) ([]models.Record, error) {
+	var out []models.Record
+	for _, r := range f.records {
+		if !r.Slot.StartTime.Before(from) && r.Slot.StartTime.Before(to) && r.Status != "reject" {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDigestStore) PendingRecords(_ uuid.UUID, from time.Time) ([]models.Record, error) {
+	var out []models.Record
+	for _, r := range f.records {
+		if r.Status == "pending" && !r.Slot.StartTime.Before(from) {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDigestStore) FreeSlots(_ uuid.UUID, from, to time.Time) ([]models.Slot, error) {
+	var out []models.Slot
+	for _, s := range f.slots {
+		if !s.IsBooked && !s.StartTime.Before(from) && s.StartTime.Before(to) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeDigestStore) Claim(_ uuid.UUID, kind string, period time.Time) (bool, error) {
+	key := kind + period.Format(time.DateOnly)
+	if f.claimed[key] {
+		return false, nil
+	}
+	f.claimed[key] = true
+	return true, nil
+}
+
+func (f *fakeDigestStore) Release(_ uuid.UUID, kind string, period time.Time) error {
+	delete(f.claimed, kind+period.Format(time.DateOnly))
+	return nil
+}
+
+type digestMessage struct {
+	title, message string
+	pending        []sender.DigestRecord
+}
+
+type fakeDigestSender struct {
+	sent []digestMessage
+	err  error
+}
+
+func (f *fakeDigestSender) DigestNotify(_ int64, title, message string, pending []sender.DigestRecord) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, digestMessage{title, message, pending})
+	return nil
+}
+
+func newTestDigest(t *testing.T) (*Digest, *fakeDigestStore, *fakeDigestSender, *time.Location) {
+	t.Helper()
+	omsk, err := time.LoadLocation("Asia/Omsk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	// Понедельник 14.01.2030 по Омску
+	at := func(day, hour int) time.Time { return time.Date(2030, 1, day, hour, 0, 0, 0, omsk) }
+	service := models.Service{Name: "Стрижка", Price: 150000}
+	client := models.User{FirstName: "Анна", Phone: "+79990000001"}
+	store := &fakeDigestStore{
+		master: models.User{ID: uuid.New(), TelegramID: 42, Timezone: "Asia/Omsk", DigestTime: "08:00"},
+		records: []models.Record{
+			{ID: 1, Status: "confirm", Price: 150000, Currency: "RUB", Client: client, Slot: models.Slot{StartTime: at(14, 10), EndTime: at(14, 11), Service: service}},
+			{ID: 2, Status: "pending", Client: client, Slot: models.Slot{StartTime: at(15, 12), Service: service}},
+			{ID: 3, Status: "confirm", Price: 4550, Currency: "EUR", Client: client, Slot: models.Slot{StartTime: at(18, 9), Service: service}},
+			{ID: 4, Status: "reject", Client: client, Slot: models.Slot{StartTime: at(14, 12), Service: service}},
+		},
+		slots: []models.Slot{
+			{StartTime: at(14, 14)},
+			{StartTime: at(14, 16), IsBooked: true},
+			{StartTime: at(16, 10)},
+		},
+		claimed: map[string]bool{},
+	}
+	snd := &fakeDigestSender{}
+	return &Digest{store: store, sender: snd, logger: logger}, store, snd, omsk
+}
+
+func TestDigestDue(t *testing.T) {
+	omsk := time.FixedZone("Asia/Omsk", 6*3600)
+	tests := []struct {
+		at   string
+		now  time.Time
+		want bool
+	}{
+		{"08:00", time.Date(2030, 1, 14, 7, 59, 0, 0, omsk), false},
+		{"08:00", time.Date(2030, 1, 14, 8, 0, 0, 0, omsk), true},
+		{"08:00", time.Date(2030, 1, 14, 8, 59, 0, 0, omsk), true},
+		{"08:00", time.Date(2030, 1, 14, 9, 0, 0, 0, omsk), false},
+		{"", time.Date(2030, 1, 14, 8, 0, 0, 0, omsk), false},
+	}
+	for _, tt := range tests {
+		if got := digestDue(tt.now, tt.at); got != tt.want {
+			t.Errorf("digestDue(%s, %q) = %v, want %v", tt.now.Format("15:04"), tt.at, got, tt.want)
+		}
+	}
+}
+
+func TestSendDigestsOncePerDay(t *testing.T) {
+	d, _, snd, omsk := newTestDigest(t)
+
+	d.sendDigests(time.Date(2030, 1, 14, 7, 30, 0, 0, omsk).UTC())
+	if len(snd.sent) != 0 {
+		t.Fatalf("sent %d digests before 08:00, want none", len(snd.sent))
+	}
+
+	now := time.Date(2030, 1, 14, 8, 1, 0, 0, omsk)
+	d.sendDigests(now.UTC())
+	d.sendDigests(now.Add(time.Minute).UTC())
+	if len(snd.sent) != 2 {
+		t.Fatalf("sent %d digests on Monday, want daily and weekly once", len(snd.sent))
+	}
+
+	daily := snd.sent[0]
+	for _, want := range []string{"Записи на сегодня (1)", "10:00–11:00 Стрижка — Анна, +79990000001", "Ждут решения (1)", "Свободные слоты (1): 14:00"} {
+		if !strings.Contains(daily.message, want) {
+			t.Errorf("daily digest missing %q:\n%s", want, daily.message)
+		}
+	}
+	if len(daily.pending) != 1 || daily.pending[0].RecordID != 2 {
+		t.Errorf("daily pending = %+v, want record 2", daily.pending)
+	}
+
+	weekly := snd.sent[1]
+	for _, want := range []string{"Подтверждено записей: 2", "Ждут решения: 1", "Свободных слотов: 2", "Ожидаемая выручка: 45,50\u00a0€ + 1\u00a0500\u00a0₽"} {
+		if !strings.Contains(weekly.message, want) {
+			t.Errorf("weekly digest missing %q:\n%s", want, weekly.message)
+		}
+	}
+
+	// Во вторник — только утренняя сводка
+	d.sendDigests(time.Date(2030, 1, 15, 8, 0, 0, 0, omsk).UTC())
+	if len(snd.sent) != 3 || strings.HasPrefix(snd.sent[2].title, "Неделя") {
+		t.Fatalf("Tuesday digests = %d (last %q), want one daily", len(snd.sent), snd.sent[len(snd.sent)-1].title)
+	}
+}
+
+func TestDigestInMasterLanguage(t *testing.T) {
+	d, store, snd, omsk := newTestDigest(t)
+	store.master.Language = "en"
+
+	d.sendDigests(time.Date(2030, 1, 14, 8, 0, 0, 0, omsk).UTC())
+	if len(snd.sent) != 2 {
+		t.Fatalf("sent %d digests on Monday, want daily and weekly", len(snd.sent))
+	}
+	if daily := snd.sent[0]; !strings.HasPrefix(daily.title, "Digest for 14.01.2030") || !strings.Contains(daily.message, "Bookings for today (1)") {
+		t.Errorf("daily digest = %q\n%s", daily.title, daily.message)
+	}
+	if want := "Expected revenue: €45.50 + ₽1,500"; !strings.Contains(snd.sent[1].message, want) {
+		t.Errorf("weekly digest missing %q:\n%s", want, snd.sent[1].message)
+	}
+}
+
+func TestDigestRetriedWhenNotAccepted(t *testing.T) {
+	d, store, snd, omsk := newTestDigest(t)
+	store.master.DigestTime = "09:00"
+	now := time.Date(2030, 1, 15, 9, 0, 0, 0, omsk)
+
+	snd.err = errors.New("bot is down")
+	d.sendDigests(now.UTC())
+	if len(store.claimed) != 0 {
+		t.Fatalf("claimed = %v after failed delivery, want released", store.claimed)
+	}
+
+	snd.err = nil
+	d.sendDigests(now.Add(time.Minute).UTC())
+	if len(snd.sent) != 1 {
+		t.Fatalf("sent %d digests after retry, want 1", len(snd.sent))
+	}
+}
@@ -0,0 +1,26 @@
+package reminder
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// runEvery запускает fn раз в interval в отдельной горутине, пока не отменён ctx.
+// fn получает время тика в UTC.
+func runEvery(ctx context.Context, logger *logrus.Logger, name string, interval time.Duration, fn func(now time.Time)) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				fn(now.UTC())
+			case <-ctx.Done():
+				logger.Infof("%s stopped", name)
+				return
+			}
+		}
+	}()
+}
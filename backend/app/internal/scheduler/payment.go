@@ -0,0 +1,75 @@
+package reminder
+
+import (
+	paymentrepo "app/http/repository/payment"
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// holdReleaseBatch — броней, снимаемых за один проход
+const holdReleaseBatch = 100
+
+// HoldStore — записи, бронь которых истекла без оплаты (repository/payment)
+type HoldStore interface {
+	ExpiredHolds(now time.Time, limit int) ([]models.Record, error)
+	ReleaseHold(recordID uint) (bool, error)
+}
+
+// HoldSender — сообщение клиенту о снятой брони
+type HoldSender interface {
+	RecordStatusNotify(telegramID int64, title, message string) error
+}
+
+// PaymentHolds отменяет записи, не оплаченные до конца брони, и освобождает их слоты.
+// Оплата, пришедшая позже, возвращается клиенту (usecase/payment)
+type PaymentHolds struct {
+	store  HoldStore
+	sender HoldSender
+	logger *logrus.Logger
+}
+
+func NewPaymentHolds(db *gorm.DB, logger *logrus.Logger) *PaymentHolds {
+	return &PaymentHolds{
+		store:  paymentrepo.NewRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// WithSender подключает сообщения клиентам в Telegram
+func (h *PaymentHolds) WithSender(snd HoldSender) *PaymentHolds {
+	h.sender = snd
+	return h
+}
+
+// StartPaymentHolds раз в минуту снимает истёкшие брони
+func (h *PaymentHolds) StartPaymentHolds(ctx context.Context) {
+	runEvery(ctx, h.logger, "PaymentHolds", time.Minute, h.release)
+}
+
+func (h *PaymentHolds) release(now time.Time) {
+	records, err := h.store.ExpiredHolds(now, holdReleaseBatch)
+	if err != nil {
+		h.logger.WithError(err).Warn("payment holds: query failed")
+		return
+	}
+	for _, r := range records {
+		released, err := h.store.ReleaseHold(r.ID)
+		if err != nil || !released {
+			continue
+		}
+		h.logger.WithField("record_id", r.ID).Info("payment holds: released unpaid record")
+		if h.sender == nil || r.Client.TelegramID == 0 {
+			continue
+		}
+		loc := timefmt.Location(nil, r.Client.Timezone, r.Slot.Master.Timezone)
+		message := fmt.Sprintf("Оплата не поступила вовремя, бронь снята\n\nУслуга: %s\nВремя: %s",
+			r.Slot.Service.Name, r.Slot.StartTime.In(loc).Format("02.01 15:04"))
+		_ = h.sender.RecordStatusNotify(r.Client.TelegramID, "Запись отменена", message)
+	}
+}
@@ -0,0 +1,66 @@
+package reminder
+
+import (
+	"app/pkg/models"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type fakeHoldStore struct {
+	expired  []models.Record
+	released map[uint]bool
+	taken    map[uint]bool
+}
+
+func (f *fakeHoldStore) ExpiredHolds(now time.Time, limit int) ([]models.Record, error) {
+	return f.expired, nil
+}
+
+func (f *fakeHoldStore) ReleaseHold(recordID uint) (bool, error) {
+	if f.taken[recordID] {
+		return false, nil
+	}
+	f.released[recordID] = true
+	return true, nil
+}
+
+type holdMessage struct {
+	telegramID int64
+	title      string
+}
+
+type fakeHoldSender struct{ sent []holdMessage }
+
+func (f *fakeHoldSender) RecordStatusNotify(telegramID int64, title, message string) error {
+	f.sent = append(f.sent, holdMessage{telegramID, title})
+	return nil
+}
+
+func TestPaymentHoldsRelease(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	slot := models.Slot{StartTime: time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC), Service: models.Service{Name: "Стрижка"}}
+	store := &fakeHoldStore{
+		expired: []models.Record{
+			{ID: 1, Client: models.User{TelegramID: 2001}, Slot: slot},
+			// Уже оплачена: вебхук успел раньше планировщика
+			{ID: 2, Client: models.User{TelegramID: 2002}, Slot: slot},
+			{ID: 3, Slot: slot},
+		},
+		released: map[uint]bool{},
+		taken:    map[uint]bool{2: true},
+	}
+	sender := &fakeHoldSender{}
+	h := &PaymentHolds{store: store, sender: sender, logger: logger}
+
+	h.release(time.Date(2030, 1, 14, 12, 0, 0, 0, time.UTC))
+	if !store.released[1] || store.released[2] || !store.released[3] {
+		t.Errorf("released = %v, want records 1 and 3", store.released)
+	}
+	if len(sender.sent) != 1 || sender.sent[0].telegramID != 2001 {
+		t.Errorf("sent = %+v, want one message to the client of record 1", sender.sent)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	recrepo "app/http/repository/record"
 	"app/http/sender"
 	"app/pkg/models"
+	"app/pkg/timefmt"
 	"context"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 type Reminder struct {
 	db     *gorm.DB
 	logger *logrus.Logger
+	sender *sender.Sender
 }
 
 func NewReminder(db *gorm.DB, logger *logrus.Logger) *Reminder {
@@ -24,28 +26,23 @@ func NewReminder(db *gorm.DB, logger *logrus.Logger) *Reminder {
 	}
 }
 
+// WithSender подключает отправку напоминаний в Telegram
+func (r *Reminder) WithSender(snd *sender.Sender) *Reminder {
+	r.sender = snd
+	return r
+}
+
 // StartReminder launches a lightweight ticker that sends 1-hour reminders for confirmed records.
 func (r *Reminder) StartReminder(ctx context.Context) {
-	go func() {
-		ticker := time.NewTicker(1 * time.Minute)
-		defer ticker.Stop()
-		recordRepo := recrepo.NewRepository(r.db, r.logger)
-		notifRepo := notification.NewRepository(r.db, r.logger)
-		for {
-			select {
-			case <-ticker.C:
-				r.sendReminders(recordRepo, notifRepo, r.logger)
-			case <-ctx.Done():
-				r.logger.Info("Reminder stopped")
-				return
-			}
-		}
-	}()
+	recordRepo := recrepo.NewRepository(r.db, r.logger)
+	notifRepo := notification.NewRepository(r.db, r.logger)
+	runEvery(ctx, r.logger, "Reminder", time.Minute, func(now time.Time) {
+		r.sendReminders(now, recordRepo, notifRepo, r.logger)
+	})
 }
 
-func (r *Reminder) sendReminders(recordRepo *recrepo.Repository, notifRepo *notification.Repository, logger *logrus.Logger) {
+func (r *Reminder) sendReminders(now time.Time, recordRepo *recrepo.Repository, notifRepo *notification.Repository, logger *logrus.Logger) {
 	// Narrow 2-minute window around now+60m to avoid duplicates
-	now := time.Now().UTC()
 	start := now.Add(59 * time.Minute)
 	end := now.Add(61 * time.Minute)
 
@@ -57,28 +54,19 @@ func (r *Reminder) sendReminders(recordRepo *recrepo.Repository, notifRepo *noti
 	if len(records) == 0 {
 		return
 	}
+	snd := r.sender
 	for _, r := range records {
 		clientTg := r.Client.TelegramID
 		if clientTg == 0 {
 			continue
 		}
-		tz := r.Slot.Master.Timezone
-		if tz == "" {
-			tz = "Europe/Moscow"
-		}
-		loc, err := time.LoadLocation(tz)
-		if err != nil {
-			loc = time.FixedZone("Europe/Moscow", 3*3600)
-		}
-		startAt := r.Slot.StartTime.In(loc)
+		// Время в таймзоне клиента, время мастера — вторым
+		master := timefmt.Location(nil, r.Slot.Master.Timezone)
 		endAt := r.Slot.EndTime
 		if endAt.IsZero() && r.Slot.Service.Duration > 0 {
 			endAt = r.Slot.StartTime.Add(time.Duration(r.Slot.Service.Duration) * time.Minute)
 		}
-		endAt = endAt.In(loc)
-
-		dateText := startAt.Format("02.01.2006")
-		timeText := startAt.Format("15:04") + " - " + endAt.Format("15:04") + " (TZ: " + tz + ")"
+		timeText := timefmt.Span(r.Slot.StartTime, endAt, timefmt.Location(master, r.Client.Timezone), master)
 
 		masterName := r.Slot.Master.FirstName + " " + r.Slot.Master.Surname
 		serviceName := r.Slot.Service.Name
@@ -86,10 +74,12 @@ func (r *Reminder) sendReminders(recordRepo *recrepo.Repository, notifRepo *noti
 		title := "Напоминание: запись через 1 час"
 		message := "У вас запись к: " + masterName + "\n" +
 			"Услуга: " + serviceName + "\n" +
-			"Дата: " + dateText + "\n" +
 			"Время: " + timeText
+		if address := r.Slot.Address(); address != "" {
+			message += "\nАдрес: " + address
+		}
 
-		if err := sender.RecordStatusNotify(clientTg, title, message); err != nil {
+		if err := snd.RecordStatusNotify(clientTg, title, message); err != nil {
 			logger.WithError(err).Warn("reminder: telegram notify failed")
 			continue
 		}
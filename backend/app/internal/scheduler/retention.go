@@ -0,0 +1,51 @@
+package reminder
+
+import (
+	archiverepo "app/http/repository/archive"
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+// RetentionStore — очистка персональных данных удалённых пользователей (repository/archive)
+type RetentionStore interface {
+	Purge(before, now time.Time) (int, error)
+}
+
+// Retention стирает персональные данные пользователей, удалённых больше days дней назад.
+// Записи и отзывы остаются, восстановить очищенного пользователя нельзя
+type Retention struct {
+	store  RetentionStore
+	days   int
+	logger *logrus.Logger
+}
+
+func NewRetention(db *gorm.DB, logger *logrus.Logger, days int) *Retention {
+	return &Retention{
+		store:  archiverepo.NewRepository(db, logger),
+		days:   days,
+		logger: logger,
+	}
+}
+
+// StartRetention раз в час очищает пользователей с истёкшим сроком хранения; days == 0 — не запускается
+func (r *Retention) StartRetention(ctx context.Context) {
+	if r.days <= 0 {
+		r.logger.Info("Retention disabled")
+		return
+	}
+	runEvery(ctx, r.logger, "Retention", time.Hour, r.purge)
+}
+
+func (r *Retention) purge(now time.Time) {
+	n, err := r.store.Purge(now.AddDate(0, 0, -r.days), now)
+	if err != nil {
+		r.logger.WithError(err).Warn("retention: purge failed")
+		return
+	}
+	if n > 0 {
+		r.logger.Infof("retention: purged %d users", n)
+	}
+}
@@ -0,0 +1,31 @@
+package reminder
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type fakeRetentionStore struct {
+	before, now time.Time
+}
+
+func (f *fakeRetentionStore) Purge(before, now time.Time) (int, error) {
+	f.before, f.now = before, now
+	return 1, nil
+}
+
+func TestRetentionCutoff(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	store := &fakeRetentionStore{}
+	r := &Retention{store: store, days: 90, logger: logger}
+
+	now := time.Date(2030, 4, 1, 12, 0, 0, 0, time.UTC)
+	r.purge(now)
+	if want := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC); !store.before.Equal(want) || !store.now.Equal(now) {
+		t.Errorf("Purge(%s, %s), want users deleted before %s", store.before, store.now, want)
+	}
+}
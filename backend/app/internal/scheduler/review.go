@@ -0,0 +1,89 @@
+package reminder
+
+import (
+	reviewrepo "app/http/repository/review"
+	"app/pkg/models"
+	"app/pkg/timefmt"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+const (
+	// reviewPromptDelay — через сколько после конца слота клиент получает приглашение оценить визит
+	reviewPromptDelay = 30 * time.Minute
+	// reviewPromptWindow — дольше после конца слота не приглашаем (API был остановлен)
+	reviewPromptWindow = 24 * time.Hour
+	// reviewPromptBatch — приглашений за один проход
+	reviewPromptBatch = 100
+)
+
+// ReviewStore — завершённые записи без отзыва и отметки о приглашении (repository/review)
+type ReviewStore interface {
+	PromptCandidates(from, to time.Time, limit int) ([]models.Record, error)
+	ClaimPrompt(recordID uint, at time.Time) (bool, error)
+	ReleasePrompt(recordID uint) error
+}
+
+// ReviewSender — приглашение оценить запись в Telegram
+type ReviewSender interface {
+	ReviewPromptNotify(recordID uint, telegramID int64, title, message string) error
+}
+
+// ReviewPrompt после окончания подтверждённой записи один раз предлагает клиенту
+// оценить визит кнопками со звёздами
+type ReviewPrompt struct {
+	store  ReviewStore
+	sender ReviewSender
+	logger *logrus.Logger
+}
+
+func NewReviewPrompt(db *gorm.DB, logger *logrus.Logger) *ReviewPrompt {
+	return &ReviewPrompt{
+		store:  reviewrepo.NewRepository(db, logger),
+		logger: logger,
+	}
+}
+
+// WithSender подключает отправку приглашений в Telegram
+func (p *ReviewPrompt) WithSender(snd ReviewSender) *ReviewPrompt {
+	p.sender = snd
+	return p
+}
+
+// StartReviewPrompt раз в минуту ищет записи, которые пора предложить оценить
+func (p *ReviewPrompt) StartReviewPrompt(ctx context.Context) {
+	runEvery(ctx, p.logger, "ReviewPrompt", time.Minute, p.sendPrompts)
+}
+
+func (p *ReviewPrompt) sendPrompts(now time.Time) {
+	to := now.Add(-reviewPromptDelay)
+	records, err := p.store.PromptCandidates(to.Add(-reviewPromptWindow), to, reviewPromptBatch)
+	if err != nil {
+		p.logger.WithError(err).Warn("review prompt: query failed")
+		return
+	}
+	for _, r := range records {
+		p.send(r, now)
+	}
+}
+
+// send отправляет приглашение один раз: отметка ставится до отправки
+// и снимается, если бот приглашение не принял
+func (p *ReviewPrompt) send(r models.Record, now time.Time) {
+	claimed, err := p.store.ClaimPrompt(r.ID, now)
+	if err != nil || !claimed {
+		return
+	}
+	loc := timefmt.Location(nil, r.Client.Timezone, r.Slot.Master.Timezone)
+	title := "Как прошёл визит?"
+	message := fmt.Sprintf("%s у мастера %s, %s.\nОцените визит — это поможет другим клиентам",
+		r.Slot.Service.Name, r.Slot.Master.FirstName, r.Slot.StartTime.In(loc).Format("02.01 15:04"))
+	if err := p.sender.ReviewPromptNotify(r.ID, r.Client.TelegramID, title, message); err != nil {
+		p.logger.WithError(err).WithField("record_id", r.ID).Warn("review prompt: not sent")
+		_ = p.store.ReleasePrompt(r.ID)
+	}
+}
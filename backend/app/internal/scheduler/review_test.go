@@ -0,0 +1,122 @@
+package reminder
+
+import (
+	"app/pkg/models"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+type fakeReviewStore struct {
+	records  []models.Record
+	prompted map[uint]bool
+}
+
+func (f *fakeReviewStore) PromptCandidates(from, to time.Time, limit int) ([]models.Record, error) {
+	var out []models.Record
+	for _, r := range f.records {
+		if !f.prompted[r.ID] && !r.Slot.EndTime.Before(from) && r.Slot.EndTime.Before(to) && len(out) < limit {
+			out = append(out, r)
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeReviewStore) ClaimPrompt(recordID uint, _ time.Time) (bool, error) {
+	if f.prompted[recordID] {
+		return false, nil
+	}
+	f.prompted[recordID] = true
+	return true, nil
+}
+
+func (f *fakeReviewStore) ReleasePrompt(recordID uint) error {
+	delete(f.prompted, recordID)
+	return nil
+}
+
+type reviewPrompt struct {
+	recordID   uint
+	telegramID int64
+	message    string
+}
+
+type fakeReviewSender struct {
+	sent []reviewPrompt
+	err  error
+}
+
+func (f *fakeReviewSender) ReviewPromptNotify(recordID uint, telegramID int64, _, message string) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, reviewPrompt{recordID, telegramID, message})
+	return nil
+}
+
+func newTestReviewPrompt(t *testing.T, end time.Time) (*ReviewPrompt, *fakeReviewStore, *fakeReviewSender) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	slot := models.Slot{
+		StartTime: end.Add(-time.Hour), EndTime: end,
+		Service: models.Service{Name: "Стрижка"}, Master: models.User{FirstName: "Анна"},
+	}
+	store := &fakeReviewStore{
+		records:  []models.Record{{ID: 7, Status: "confirm", Client: models.User{TelegramID: 42, Timezone: "UTC"}, Slot: slot}},
+		prompted: map[uint]bool{},
+	}
+	snd := &fakeReviewSender{}
+	return &ReviewPrompt{store: store, sender: snd, logger: logger}, store, snd
+}
+
+func TestReviewPromptAfterDelay(t *testing.T) {
+	end := time.Date(2030, 1, 14, 11, 0, 0, 0, time.UTC)
+	p, _, snd := newTestReviewPrompt(t, end)
+
+	p.sendPrompts(end.Add(10 * time.Minute))
+	if len(snd.sent) != 0 {
+		t.Fatalf("sent %d prompts 10 minutes after the slot, want none", len(snd.sent))
+	}
+	p.sendPrompts(end.Add(reviewPromptDelay + time.Minute))
+	p.sendPrompts(end.Add(reviewPromptDelay + 2*time.Minute))
+	if len(snd.sent) != 1 {
+		t.Fatalf("sent %d prompts, want exactly one", len(snd.sent))
+	}
+	got := snd.sent[0]
+	if got.recordID != 7 || got.telegramID != 42 || !strings.Contains(got.message, "Стрижка у мастера Анна, 14.01 10:00") {
+		t.Errorf("prompt = %+v", got)
+	}
+}
+
+func TestReviewPromptWindow(t *testing.T) {
+	end := time.Date(2030, 1, 14, 11, 0, 0, 0, time.UTC)
+	p, _, snd := newTestReviewPrompt(t, end)
+
+	p.sendPrompts(end.Add(reviewPromptDelay + reviewPromptWindow + time.Minute))
+	if len(snd.sent) != 0 {
+		t.Fatalf("sent %d prompts after the window, want none", len(snd.sent))
+	}
+}
+
+func TestReviewPromptRetriedWhenNotAccepted(t *testing.T) {
+	end := time.Date(2030, 1, 14, 11, 0, 0, 0, time.UTC)
+	p, store, snd := newTestReviewPrompt(t, end)
+	now := end.Add(reviewPromptDelay + time.Minute)
+
+	snd.err = errors.New("bot is down")
+	p.sendPrompts(now)
+	if len(store.prompted) != 0 {
+		t.Fatalf("prompted = %v after failed delivery, want released", store.prompted)
+	}
+
+	snd.err = nil
+	p.sendPrompts(now.Add(time.Minute))
+	if len(snd.sent) != 1 {
+		t.Fatalf("sent %d prompts after retry, want 1", len(snd.sent))
+	}
+}
@@ -1,12 +1,16 @@
 package main
 
 import (
+	deliveryRepo "app/http/repository/delivery"
 	"app/http/router"
+	"app/http/sender"
 	"app/pkg/closer"
 	"context"
 	"net/http"
+	"os"
 	"time"
 
+	"app/internal/config"
 	"app/internal/database"
 	reminder "app/internal/scheduler"
 
@@ -15,28 +19,56 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		}
+	}
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	logger := logrus.New()
 	logger.SetLevel(logrus.DebugLevel)
 
-	database.Init()
-	db := database.GetDB()
+	cfg, err := config.Load("")
+	if err != nil {
+		logger.Fatalf("Invalid configuration: %v", err)
+	}
+	logger.Infof("Configuration loaded:\n%s", cfg)
+
+	db := database.Init(cfg.Database)
+	if err := database.PrepareSchema(db.DB, cfg.Database.MigrateOnStart); err != nil {
+		logger.Fatalf("Database schema check failed: %v", err)
+	}
 
+	gin.SetMode(cfg.Server.GinMode)
 	r := gin.Default()
 	httpServer := router.NewHTTPServer(&http.Server{
-		Addr:    ":8090",
+		Addr:    cfg.Server.Addr(),
 		Handler: r,
 	}, "server")
 
-	client := router.NewClient(db.DB, logger, httpServer, r)
+	notifier := sender.New(cfg.Telegram.HTTPBase, cfg.Telegram.HTTPSecret).
+		WithOutbox(deliveryRepo.NewRepository(db.DB, logger))
+	client := router.NewClient(db.DB, logger, httpServer, r, cfg, notifier)
 
 	// Start background reminders (1-hour before confirmed records)
 	reminderCtx, stopReminder := context.WithCancel(ctx)
 	defer stopReminder()
-	rem := reminder.NewReminder(db.DB, logger)
+	rem := reminder.NewReminder(db.DB, logger).WithSender(notifier)
 	rem.StartReminder(reminderCtx)
+	// Утренняя сводка мастерам на том же планировщике
+	reminder.NewDigest(db.DB, logger).WithSender(notifier).StartDigest(reminderCtx)
+	// Приглашения оценить визит после окончания записи
+	reminder.NewReviewPrompt(db.DB, logger).WithSender(notifier).StartReviewPrompt(reminderCtx)
+	// Очистка персональных данных удалённых пользователей по сроку хранения
+	reminder.NewRetention(db.DB, logger, cfg.Retention.UserDays).StartRetention(reminderCtx)
+	// Снятие неоплаченных броней (и оставшихся после отключения провайдера предоплаты)
+	reminder.NewPaymentHolds(db.DB, logger).WithSender(notifier).StartPaymentHolds(reminderCtx)
 
 	manager := closer.NewManager(logger)
 	manager.AddGraceful(httpServer)
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"app/internal/config"
+	"app/internal/database"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+)
+
+const migrateUsage = `Usage: app migrate <command> [flags]
+
+Commands:
+  up                 apply all pending migrations
+  down [-steps N]    revert the last N applied migrations (default 1)
+  status             show applied and pending migrations
+  create [-dir DIR] NAME
+                     create empty up/down SQL files in DIR (default "migrations")
+`
+
+// runMigrate выполняет подкоманду migrate и возвращает код выхода
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	cmd, args := args[0], args[1:]
+	fs := flag.NewFlagSet("migrate "+cmd, flag.ContinueOnError)
+	steps := fs.Int("steps", 1, "number of migrations to revert")
+	dir := fs.String("dir", "migrations", "directory for new migration files")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if cmd == "create" {
+		if fs.NArg() != 1 {
+			fmt.Fprint(os.Stderr, migrateUsage)
+			return 2
+		}
+		m, err := database.NewMigrator(nil)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		files, err := m.CreateMigration(*dir, fs.Arg(0))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, f := range files {
+			fmt.Println("created", f)
+		}
+		return 0
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	db := database.Init(cfg.Database)
+	defer db.Shutdown(context.Background())
+	m, err := database.NewMigrator(db.DB)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	switch cmd {
+	case "up":
+		n, err := m.Up()
+		fmt.Printf("applied %d migration(s)\n", n)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case "down":
+		n, err := m.Down(*steps)
+		fmt.Printf("reverted %d migration(s)\n", n)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, st := range statuses {
+			applied := "pending"
+			if st.AppliedAt != nil {
+				applied = st.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", st.Version, st.Name, applied)
+		}
+		w.Flush()
+	default:
+		fmt.Fprint(os.Stderr, migrateUsage)
+		return 2
+	}
+	return 0
+}
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "ad_click_stats";
+DROP TABLE IF EXISTS "notifications";
+DROP TABLE IF EXISTS "records";
+DROP TABLE IF EXISTS "slots";
+DROP TABLE IF EXISTS "services";
+DROP TABLE IF EXISTS "user_roles";
+DROP TABLE IF EXISTS "users";
@@ -0,0 +1,87 @@
+-- Baseline: schema previously created by gorm AutoMigrate.
+-- Every statement is idempotent so databases created by AutoMigrate can be adopted as is.
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid DEFAULT gen_random_uuid(),
+    "phone" text NOT NULL,
+    "telegram_id" bigint,
+    "first_name" text NOT NULL,
+    "surname" text NOT NULL,
+    "timezone" text DEFAULT 'Europe/Moscow',
+    "active" boolean DEFAULT false,
+    "consent_given_at" timestamptz,
+    "privacy_policy_accepted_at" timestamptz,
+    "terms_accepted_at" timestamptz,
+    "phone_verified_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "uni_users_phone" UNIQUE ("phone")
+);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "phone_verified_at" timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_telegram_id" ON "users" ("telegram_id");
+
+CREATE TABLE IF NOT EXISTS "user_roles" (
+    "user_id" uuid NOT NULL,
+    "role" text,
+    PRIMARY KEY ("user_id", "role"),
+    CONSTRAINT "fk_users_roles" FOREIGN KEY ("user_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_user_roles_user_id" ON "user_roles" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "services" (
+    "id" bigserial,
+    "master_id" uuid,
+    "name" text,
+    "price" decimal,
+    "description" text,
+    "duration" bigint,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_services" FOREIGN KEY ("master_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_service_master" ON "services" ("master_id");
+
+CREATE TABLE IF NOT EXISTS "slots" (
+    "id" bigserial,
+    "master_id" uuid NOT NULL,
+    "start_time" timestamptz,
+    "end_time" timestamptz,
+    "is_booked" boolean DEFAULT false,
+    "service_id" bigint NOT NULL,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_slots_service" FOREIGN KEY ("service_id") REFERENCES "services"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_slots_master" FOREIGN KEY ("master_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS "idx_slot_master_time" ON "slots" ("master_id", "start_time");
+
+CREATE TABLE IF NOT EXISTS "records" (
+    "id" bigserial,
+    "slot_id" bigint NOT NULL,
+    "client_id" uuid NOT NULL,
+    "status" text DEFAULT 'pending',
+    "created_at" timestamptz DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_records_slot" FOREIGN KEY ("slot_id") REFERENCES "slots"("id") ON DELETE CASCADE,
+    CONSTRAINT "fk_records_client" FOREIGN KEY ("client_id") REFERENCES "users"("id") ON DELETE CASCADE
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_record_slot_client" ON "records" ("slot_id", "client_id");
+
+CREATE TABLE IF NOT EXISTS "notifications" (
+    "id" bigserial,
+    "user_id" text,
+    "type" text,
+    "title" text,
+    "message" text,
+    "is_read" boolean DEFAULT false,
+    "created_at" timestamptz,
+    "expires_at" timestamptz,
+    "metadata" jsonb,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_notification_user" ON "notifications" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "ad_click_stats" (
+    "id" bigserial,
+    "clicks1" bigint,
+    "clicks2" bigint,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
@@ -0,0 +1,111 @@
+package migrations
+
+import (
+	"app/pkg/phone"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func init() {
+	// Слияние аккаунтов необратимо, поэтому Down не задан
+	register(Migration{Version: 2, Name: "normalize_phones", Up: normalizePhones})
+}
+
+// phoneUser — строка users в схеме версии 2. Миграция не использует models.User:
+// живая модель меняется вместе со схемой (например, deleted_at из 0013), а миграция — нет
+type phoneUser struct {
+	ID              uuid.UUID
+	Phone           string
+	TelegramID      int64
+	Active          bool
+	PhoneVerifiedAt *time.Time
+}
+
+// phoneRegion — регион, в котором были записаны номера без кода страны на момент миграции.
+// Зафиксирован здесь, чтобы результат не зависел от текущей конфигурации
+const phoneRegion = "RU"
+
+// normalizePhones приводит телефоны пользователей к E.164 и сливает аккаунты,
+// которые раньше считались разными из-за формата записи ("+7…", "8…", "7…").
+// Данные дубликатов (роли, услуги, слоты, записи, уведомления) переносятся
+// на основной аккаунт, после чего дубликаты удаляются.
+func normalizePhones(db *gorm.DB) error {
+	var users []phoneUser
+	if err := db.Table("users").Select("id", "phone", "telegram_id", "active", "phone_verified_at").Find(&users).Error; err != nil {
+		return err
+	}
+
+	groups := make(map[string][]phoneUser)
+	for _, u := range users {
+		normalized, err := phone.NormalizeRegion(u.Phone, phoneRegion)
+		if err != nil {
+			log.Printf("migrations.normalizePhones: skip user id=%s: invalid phone %q", u.ID, u.Phone)
+			continue
+		}
+		groups[normalized] = append(groups[normalized], u)
+	}
+
+	for normalized, group := range groups {
+		if len(group) == 1 && group[0].Phone == normalized {
+			continue
+		}
+		if err := mergePhoneGroup(db, normalized, group); err != nil {
+			return err
+		}
+	}
+
+	// Все существующие аккаунты зарегистрированы через контакт в боте
+	return db.Exec(`UPDATE users SET phone_verified_at = ? WHERE phone_verified_at IS NULL`, time.Now()).Error
+}
+
+// mergePhoneGroup оставляет один аккаунт из группы и переносит на него данные остальных
+func mergePhoneGroup(tx *gorm.DB, normalized string, group []phoneUser) error {
+	sort.SliceStable(group, func(i, j int) bool { return keepBefore(group[i], group[j], normalized) })
+	keeper := group[0]
+
+	for _, dup := range group[1:] {
+		log.Printf("migrations.normalizePhones: merge user id=%s into id=%s (%s)", dup.ID, keeper.ID, normalized)
+		steps := []struct {
+			sql  string
+			args []interface{}
+		}{
+			{`INSERT INTO user_roles (user_id, role) SELECT ?, role FROM user_roles WHERE user_id = ? ON CONFLICT DO NOTHING`, []interface{}{keeper.ID, dup.ID}},
+			{`DELETE FROM records r WHERE r.client_id = ? AND EXISTS (SELECT 1 FROM records k WHERE k.client_id = ? AND k.slot_id = r.slot_id)`, []interface{}{dup.ID, keeper.ID}},
+			{`UPDATE records SET client_id = ? WHERE client_id = ?`, []interface{}{keeper.ID, dup.ID}},
+			{`UPDATE slots SET master_id = ? WHERE master_id = ?`, []interface{}{keeper.ID, dup.ID}},
+			{`UPDATE services SET master_id = ? WHERE master_id = ?`, []interface{}{keeper.ID, dup.ID}},
+			{`UPDATE notifications SET user_id = ? WHERE user_id = ?`, []interface{}{keeper.ID, dup.ID}},
+			{`DELETE FROM users WHERE id = ?`, []interface{}{dup.ID}},
+		}
+		for _, step := range steps {
+			if err := tx.Exec(step.sql, step.args...).Error; err != nil {
+				log.Printf("migrations.normalizePhones: merge failed: %v", err)
+				return err
+			}
+		}
+		if keeper.TelegramID == 0 && dup.TelegramID != 0 {
+			keeper.TelegramID = dup.TelegramID
+		}
+	}
+
+	return tx.Exec(`UPDATE users SET phone = ?, telegram_id = NULLIF(?, 0) WHERE id = ?`, normalized, keeper.TelegramID, keeper.ID).Error
+}
+
+// keepBefore задаёт приоритет основного аккаунта: подтверждённый телефон,
+// затем активный, затем уже записанный в E.164.
+func keepBefore(a, b phoneUser, normalized string) bool {
+	if (a.PhoneVerifiedAt != nil) != (b.PhoneVerifiedAt != nil) {
+		return a.PhoneVerifiedAt != nil
+	}
+	if a.Active != b.Active {
+		return a.Active
+	}
+	if (a.Phone == normalized) != (b.Phone == normalized) {
+		return a.Phone == normalized
+	}
+	return a.ID.String() < b.ID.String()
+}
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS "telegram_deliveries" (
+    "id" uuid NOT NULL,
+    "kind" text NOT NULL,
+    "telegram_id" bigint NOT NULL,
+    "status" text NOT NULL DEFAULT 'pending',
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "message_id" bigint NOT NULL DEFAULT 0,
+    "last_error" text NOT NULL DEFAULT '',
+    "created_at" timestamptz NOT NULL,
+    "updated_at" timestamptz NOT NULL,
+    "delivered_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_telegram_deliveries_status" ON "telegram_deliveries" ("status", "created_at");
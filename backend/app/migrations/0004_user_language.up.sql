@@ -0,0 +1,2 @@
+-- Язык интерфейса бота; пустая строка — язык ещё не выбран, бот берёт language_code из Telegram
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "language" text NOT NULL DEFAULT '';
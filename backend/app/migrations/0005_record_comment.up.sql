@@ -0,0 +1,2 @@
+-- Комментарий клиента к записи для мастера; пустая строка — комментария нет
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "comment" text NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "master_digests";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "digest_time";
@@ -0,0 +1,11 @@
+-- Время утренней сводки мастера в его таймзоне ("08:00"); пустая строка — сводка выключена
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "digest_time" text NOT NULL DEFAULT '';
+
+-- Отправленные сводки: не больше одной за день (daily) и за неделю (weekly) даже после перезапуска
+CREATE TABLE IF NOT EXISTS "master_digests" (
+    "master_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "kind" text NOT NULL,
+    "period" date NOT NULL,
+    "sent_at" timestamptz NOT NULL,
+    PRIMARY KEY ("master_id", "kind", "period")
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS "idx_service_organization";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "organization_id";
+DROP TABLE IF EXISTS "organization_members";
+DROP TABLE IF EXISTS "organizations";
@@ -0,0 +1,39 @@
+-- Организации (салоны): несколько мастеров с общими услугами, клиентами и администраторами.
+-- Личная организация мастера-одиночки отмечена personal_owner_id — у пользователя она одна.
+CREATE TABLE IF NOT EXISTS "organizations" (
+    "id" uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    "name" text NOT NULL,
+    "personal_owner_id" uuid UNIQUE REFERENCES "users" ("id") ON DELETE CASCADE,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS "organization_members" (
+    "organization_id" uuid NOT NULL REFERENCES "organizations" ("id") ON DELETE CASCADE,
+    "user_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "role" text NOT NULL CHECK ("role" IN ('owner', 'manager', 'master', 'receptionist')),
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    PRIMARY KEY ("organization_id", "user_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_organization_members_user" ON "organization_members" ("user_id");
+
+-- Услуга принадлежит организации и назначена мастеру (master_id).
+-- RESTRICT: услуги с историей записей уходят в архив, а не удаляются вместе с организацией
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "organization_id" uuid REFERENCES "organizations" ("id") ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS "idx_service_organization" ON "services" ("organization_id");
+
+-- Мастера с услугами переезжают в личные организации, где они владельцы
+INSERT INTO "organizations" ("name", "personal_owner_id")
+SELECT COALESCE(NULLIF(trim(u."first_name" || ' ' || u."surname"), ''), u."phone"), u."id"
+FROM "users" u
+WHERE EXISTS (SELECT 1 FROM "services" s WHERE s."master_id" = u."id")
+ON CONFLICT ("personal_owner_id") DO NOTHING;
+
+INSERT INTO "organization_members" ("organization_id", "user_id", "role")
+SELECT "id", "personal_owner_id", 'owner'
+FROM "organizations"
+WHERE "personal_owner_id" IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+UPDATE "services" SET "organization_id" = o."id"
+FROM "organizations" o
+WHERE o."personal_owner_id" = "services"."master_id" AND "services"."organization_id" IS NULL;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS "service_resources";
+DROP TABLE IF EXISTS "resources";
@@ -0,0 +1,19 @@
+-- Ресурсы организации (кресла, кабинеты, оборудование): quantity одновременно занятых слотов.
+-- Неактивный ресурс (ремонт, простой) блокирует новые слоты и записи на услуги, которым он нужен.
+CREATE TABLE IF NOT EXISTS "resources" (
+    "id" bigserial PRIMARY KEY,
+    "organization_id" uuid NOT NULL REFERENCES "organizations" ("id") ON DELETE CASCADE,
+    "name" text NOT NULL,
+    "quantity" integer NOT NULL CHECK ("quantity" > 0),
+    "active" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS "idx_resource_organization" ON "resources" ("organization_id");
+
+-- Услуга занимает по одной единице каждого привязанного ресурса на время слота
+CREATE TABLE IF NOT EXISTS "service_resources" (
+    "service_id" bigint NOT NULL REFERENCES "services" ("id") ON DELETE CASCADE,
+    "resource_id" bigint NOT NULL REFERENCES "resources" ("id") ON DELETE CASCADE,
+    PRIMARY KEY ("service_id", "resource_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_service_resources_resource" ON "service_resources" ("resource_id");
@@ -0,0 +1,3 @@
+ALTER TABLE "slots" DROP COLUMN IF EXISTS "location_id";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "location_id";
+DROP TABLE IF EXISTS "locations";
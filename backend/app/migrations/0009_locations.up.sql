@@ -0,0 +1,19 @@
+-- Места оказания услуг: адрес, координаты для карты, таймзона и часы работы.
+-- working_hours — JSON-массив [{"weekday":1,"opens":"10:00","closes":"20:00"}], пустой — без ограничений.
+CREATE TABLE IF NOT EXISTS "locations" (
+    "id" bigserial PRIMARY KEY,
+    "organization_id" uuid NOT NULL REFERENCES "organizations" ("id") ON DELETE CASCADE,
+    "name" text NOT NULL,
+    "address" text NOT NULL,
+    "latitude" double precision,
+    "longitude" double precision,
+    "timezone" text NOT NULL,
+    "working_hours" jsonb NOT NULL DEFAULT '[]',
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    CHECK (("latitude" IS NULL) = ("longitude" IS NULL))
+);
+CREATE INDEX IF NOT EXISTS "idx_location_organization" ON "locations" ("organization_id");
+
+-- Слот получает место услуги при создании и сохраняет его, даже если услугу потом перенесут
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "location_id" bigint REFERENCES "locations" ("id") ON DELETE SET NULL;
+ALTER TABLE "slots" ADD COLUMN IF NOT EXISTS "location_id" bigint REFERENCES "locations" ("id") ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "idx_services_search";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "search_vector";
+DROP INDEX IF EXISTS "idx_users_search";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "search_vector";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "rating_count";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "rating";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "directory_opt_out";
@@ -0,0 +1,22 @@
+-- Публичный каталог мастеров: отказ от показа, рейтинг для сортировки и полнотекстовый поиск.
+-- rating и rating_count — агрегат отзывов, хранится в users, чтобы сортировать каталог без подзапросов.
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "directory_opt_out" boolean NOT NULL DEFAULT false;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "rating" double precision NOT NULL DEFAULT 0;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "rating_count" integer NOT NULL DEFAULT 0;
+
+-- Имена и описания индексируются сразу в двух конфигурациях: запрос на русском
+-- находит «стрижки» по «стрижка», на английском — «haircuts» по «haircut»
+-- Имя мастера весит как название услуги (A), описание услуги — меньше (B)
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "search_vector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce("first_name", '') || ' ' || coalesce("surname", '')), 'A') ||
+    setweight(to_tsvector('english', coalesce("first_name", '') || ' ' || coalesce("surname", '')), 'A')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_users_search" ON "users" USING gin ("search_vector");
+
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "search_vector" tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('russian', coalesce("name", '')), 'A') ||
+    setweight(to_tsvector('english', coalesce("name", '')), 'A') ||
+    setweight(to_tsvector('russian', coalesce("description", '')), 'B') ||
+    setweight(to_tsvector('english', coalesce("description", '')), 'B')
+) STORED;
+CREATE INDEX IF NOT EXISTS "idx_services_search" ON "services" USING gin ("search_vector");
@@ -0,0 +1,6 @@
+-- Рейтинг мастера без отзывов теряет смысл
+UPDATE "users" SET "rating" = 0, "rating_count" = 0;
+ALTER TABLE "records" DROP COLUMN IF EXISTS "review_prompted_at";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "rating_count";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "rating";
+DROP TABLE IF EXISTS "reviews";
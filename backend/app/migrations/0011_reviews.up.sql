@@ -0,0 +1,27 @@
+-- Отзывы клиентов о завершённых записях: один отзыв на запись.
+-- Удаление записи или клиента не удаляет отзыв: иначе рейтинг мастера менялся бы задним числом
+CREATE TABLE IF NOT EXISTS "reviews" (
+    "id" bigserial PRIMARY KEY,
+    "record_id" bigint UNIQUE REFERENCES "records" ("id") ON DELETE SET NULL,
+    "client_id" uuid REFERENCES "users" ("id") ON DELETE SET NULL,
+    "master_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "service_id" bigint REFERENCES "services" ("id") ON DELETE SET NULL,
+    "rating" smallint NOT NULL CHECK ("rating" BETWEEN 1 AND 5),
+    "text" text NOT NULL DEFAULT '',
+    "reply" text NOT NULL DEFAULT '',
+    "replied_at" timestamptz,
+    "hidden" boolean NOT NULL DEFAULT false,
+    "flagged" boolean NOT NULL DEFAULT false,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    "updated_at" timestamptz NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS "idx_review_master" ON "reviews" ("master_id", "created_at" DESC);
+CREATE INDEX IF NOT EXISTS "idx_review_service" ON "reviews" ("service_id");
+CREATE INDEX IF NOT EXISTS "idx_review_flagged" ON "reviews" ("created_at") WHERE "flagged";
+
+-- Рейтинг услуги хранится рядом с ней, как users.rating у мастера (0010_directory)
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "rating" double precision NOT NULL DEFAULT 0;
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "rating_count" integer NOT NULL DEFAULT 0;
+
+-- Приглашение оценить визит отправляется один раз
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "review_prompted_at" timestamptz;
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS "record_add_ons";
+ALTER TABLE "records" DROP COLUMN IF EXISTS "duration";
+ALTER TABLE "records" DROP COLUMN IF EXISTS "price";
+ALTER TABLE "records" DROP COLUMN IF EXISTS "variant_name";
+ALTER TABLE "records" DROP COLUMN IF EXISTS "variant_id";
+DROP TABLE IF EXISTS "service_add_ons";
+DROP TABLE IF EXISTS "service_variants";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "category_id";
+DROP TABLE IF EXISTS "service_categories";
@@ -0,0 +1,56 @@
+-- Категории услуг мастера: витрина группирует услуги по ним
+CREATE TABLE IF NOT EXISTS "service_categories" (
+    "id" bigserial PRIMARY KEY,
+    "master_id" uuid NOT NULL REFERENCES "users" ("id") ON DELETE CASCADE,
+    "name" varchar(100) NOT NULL,
+    "position" integer NOT NULL DEFAULT 0
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_service_category_name" ON "service_categories" ("master_id", lower("name"));
+
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "category_id" bigint REFERENCES "service_categories" ("id") ON DELETE SET NULL;
+CREATE INDEX IF NOT EXISTS "idx_service_category" ON "services" ("category_id");
+
+-- Варианты услуги (короткие/средние/длинные волосы) со своей ценой и длительностью
+CREATE TABLE IF NOT EXISTS "service_variants" (
+    "id" bigserial PRIMARY KEY,
+    "service_id" bigint NOT NULL REFERENCES "services" ("id") ON DELETE CASCADE,
+    "name" varchar(100) NOT NULL,
+    "price" decimal NOT NULL CHECK ("price" >= 0),
+    "duration" integer NOT NULL CHECK ("duration" > 0),
+    "position" integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "idx_service_variant_service" ON "service_variants" ("service_id");
+
+-- Дополнительные опции: добавляют к цене и длительности выбранного варианта
+CREATE TABLE IF NOT EXISTS "service_add_ons" (
+    "id" bigserial PRIMARY KEY,
+    "service_id" bigint NOT NULL REFERENCES "services" ("id") ON DELETE CASCADE,
+    "name" varchar(100) NOT NULL,
+    "price" decimal NOT NULL CHECK ("price" >= 0),
+    "duration" integer NOT NULL DEFAULT 0 CHECK ("duration" >= 0),
+    "position" integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "idx_service_add_on_service" ON "service_add_ons" ("service_id");
+
+-- Запись хранит выбор клиента и цену с длительностью на момент записи:
+-- изменение прайса не меняет уже сделанные записи
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "variant_id" bigint REFERENCES "service_variants" ("id") ON DELETE SET NULL;
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "variant_name" varchar(100) NOT NULL DEFAULT '';
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "price" decimal NOT NULL DEFAULT 0;
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "duration" integer NOT NULL DEFAULT 0;
+
+UPDATE "records" r
+SET "price" = COALESCE(s."price", 0), "duration" = COALESCE(s."duration", 0)
+FROM "slots" sl
+JOIN "services" s ON s."id" = sl."service_id"
+WHERE sl."id" = r."slot_id";
+
+CREATE TABLE IF NOT EXISTS "record_add_ons" (
+    "id" bigserial PRIMARY KEY,
+    "record_id" bigint NOT NULL REFERENCES "records" ("id") ON DELETE CASCADE,
+    "add_on_id" bigint REFERENCES "service_add_ons" ("id") ON DELETE SET NULL,
+    "name" varchar(100) NOT NULL,
+    "price" decimal NOT NULL,
+    "duration" integer NOT NULL DEFAULT 0
+);
+CREATE INDEX IF NOT EXISTS "idx_record_add_on_record" ON "record_add_ons" ("record_id");
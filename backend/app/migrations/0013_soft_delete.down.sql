@@ -0,0 +1,25 @@
+-- Без deleted_at архив не отличить от действующих данных, а удаление архивных строк каскадом
+-- стёрло бы их записи и отзывы. Пока архив не пуст, откат отказывается выполняться:
+-- строки нужно восстановить (POST /admin/archive/.../restore) или удалить вручную
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM "users" WHERE "deleted_at" IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM "services" WHERE "deleted_at" IS NOT NULL)
+        OR EXISTS (SELECT 1 FROM "slots" WHERE "deleted_at" IS NOT NULL) THEN
+        RAISE EXCEPTION '0013_soft_delete: archived users, services or slots exist, restore or delete them before rolling back';
+    END IF;
+END
+$$;
+
+DROP INDEX IF EXISTS "idx_users_telegram_id";
+DROP INDEX IF EXISTS "idx_users_phone";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_telegram_id" ON "users" ("telegram_id");
+ALTER TABLE "users" ADD CONSTRAINT "uni_users_phone" UNIQUE ("phone");
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "purged_at";
+DROP INDEX IF EXISTS "idx_users_deleted_at";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "deleted_at";
+DROP INDEX IF EXISTS "idx_slots_deleted_at";
+ALTER TABLE "slots" DROP COLUMN IF EXISTS "deleted_at";
+DROP INDEX IF EXISTS "idx_services_deleted_at";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "deleted_at";
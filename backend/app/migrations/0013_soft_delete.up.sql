@@ -0,0 +1,15 @@
+-- Мягкое удаление: услуги, слоты и пользователи уходят в архив, записи о визитах остаются
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_services_deleted_at" ON "services" ("deleted_at");
+ALTER TABLE "slots" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_slots_deleted_at" ON "slots" ("deleted_at");
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "deleted_at" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_users_deleted_at" ON "users" ("deleted_at");
+-- purged_at — когда по сроку хранения стёрты персональные данные; восстановить такого пользователя нельзя
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "purged_at" timestamptz;
+
+-- Телефон и Telegram освобождаются при удалении: человек может зарегистрироваться заново
+ALTER TABLE "users" DROP CONSTRAINT IF EXISTS "uni_users_phone";
+DROP INDEX IF EXISTS "idx_users_telegram_id";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_phone" ON "users" ("phone") WHERE "deleted_at" IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_telegram_id" ON "users" ("telegram_id") WHERE "deleted_at" IS NULL;
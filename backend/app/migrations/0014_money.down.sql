@@ -0,0 +1,9 @@
+ALTER TABLE "records" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "currency";
+ALTER TABLE "users" DROP COLUMN IF EXISTS "currency";
+
+ALTER TABLE "record_add_ons" ALTER COLUMN "price" TYPE decimal USING "price" / 100.0;
+ALTER TABLE "records" ALTER COLUMN "price" TYPE decimal USING "price" / 100.0;
+ALTER TABLE "service_add_ons" ALTER COLUMN "price" TYPE decimal USING "price" / 100.0;
+ALTER TABLE "service_variants" ALTER COLUMN "price" TYPE decimal USING "price" / 100.0;
+ALTER TABLE "services" ALTER COLUMN "price" TYPE decimal USING "price" / 100.0;
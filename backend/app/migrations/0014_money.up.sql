@@ -0,0 +1,15 @@
+-- Деньги хранятся целыми числами в минимальных единицах валюты (копейки, центы)
+ALTER TABLE "services" ALTER COLUMN "price" TYPE bigint USING round("price" * 100);
+ALTER TABLE "service_variants" ALTER COLUMN "price" TYPE bigint USING round("price" * 100);
+ALTER TABLE "service_add_ons" ALTER COLUMN "price" TYPE bigint USING round("price" * 100);
+ALTER TABLE "records" ALTER COLUMN "price" TYPE bigint USING round("price" * 100);
+ALTER TABLE "record_add_ons" ALTER COLUMN "price" TYPE bigint USING round("price" * 100);
+
+-- Валюта мастера — по умолчанию для новых услуг; у услуги своя, у записи — снимок на момент записи
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "currency" varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "currency" varchar(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "currency" varchar(3) NOT NULL DEFAULT 'RUB';
+UPDATE "records" AS r
+SET "currency" = s."currency"
+FROM "slots" AS sl JOIN "services" AS s ON s."id" = sl."service_id"
+WHERE sl."id" = r."slot_id";
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS "payments";
+
+DROP INDEX IF EXISTS "idx_record_hold";
+ALTER TABLE "records" DROP COLUMN IF EXISTS "hold_until";
+
+ALTER TABLE "services" DROP COLUMN IF EXISTS "deposit";
+ALTER TABLE "services" DROP COLUMN IF EXISTS "prepayment";
@@ -0,0 +1,24 @@
+-- Предоплата: услуга требует задаток или полную оплату при записи
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "prepayment" varchar(16) NOT NULL DEFAULT '';
+ALTER TABLE "services" ADD COLUMN IF NOT EXISTS "deposit" bigint NOT NULL DEFAULT 0;
+
+-- Запись в статусе awaiting_payment держит слот до hold_until
+ALTER TABLE "records" ADD COLUMN IF NOT EXISTS "hold_until" timestamptz;
+CREATE INDEX IF NOT EXISTS "idx_record_hold" ON "records" ("hold_until") WHERE "status" = 'awaiting_payment';
+
+-- Платежи у провайдера. Удаление записи не удаляет платёж: по нему мог пройти возврат
+CREATE TABLE IF NOT EXISTS "payments" (
+    "id" bigserial PRIMARY KEY,
+    "record_id" bigint REFERENCES "records" ("id") ON DELETE SET NULL,
+    "provider" varchar(32) NOT NULL,
+    "external_id" text NOT NULL,
+    "amount" bigint NOT NULL CHECK ("amount" > 0),
+    "currency" varchar(3) NOT NULL,
+    "status" varchar(16) NOT NULL DEFAULT 'pending',
+    "confirmation_url" text NOT NULL DEFAULT '',
+    "expires_at" timestamptz NOT NULL,
+    "paid_at" timestamptz,
+    "created_at" timestamptz NOT NULL DEFAULT now(),
+    UNIQUE ("provider", "external_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_payment_record" ON "payments" ("record_id");
@@ -0,0 +1,93 @@
+// Package migrations содержит версионированные миграции схемы БД.
+// SQL-миграции лежат рядом в файлах NNNN_name.up.sql / NNNN_name.down.sql
+// и встраиваются в бинарник; миграции данных на Go регистрируются через register.
+package migrations
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+//go:embed *.sql
+var sqlFiles embed.FS
+
+var fileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration — одна версия схемы. Down == nil означает необратимую миграцию.
+type Migration struct {
+	Version int64
+	Name    string
+	Up      func(tx *gorm.DB) error
+	Down    func(tx *gorm.DB) error
+}
+
+var goMigrations []Migration
+
+// register добавляет миграцию, написанную на Go
+func register(m Migration) {
+	goMigrations = append(goMigrations, m)
+}
+
+// All возвращает все миграции, отсортированные по версии
+func All() ([]Migration, error) {
+	byVersion := make(map[int64]*Migration)
+
+	entries, err := fs.ReadDir(sqlFiles, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		match := fileName.FindStringSubmatch(e.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migrations: unexpected file name %q", e.Name())
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		body, err := sqlFiles.ReadFile(e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		} else if m.Name != match[2] {
+			return nil, fmt.Errorf("migrations: version %d has different names %q and %q", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = execSQL(string(body))
+		} else {
+			m.Down = execSQL(string(body))
+		}
+	}
+
+	for i := range goMigrations {
+		gm := goMigrations[i]
+		if _, ok := byVersion[gm.Version]; ok {
+			return nil, fmt.Errorf("migrations: duplicate version %d", gm.Version)
+		}
+		byVersion[gm.Version] = &gm
+	}
+
+	result := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == nil {
+			return nil, fmt.Errorf("migrations: version %d (%s) has no up migration", m.Version, m.Name)
+		}
+		result = append(result, *m)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Version < result[j].Version })
+	return result, nil
+}
+
+func execSQL(query string) func(tx *gorm.DB) error {
+	return func(tx *gorm.DB) error {
+		return tx.Exec(query).Error
+	}
+}
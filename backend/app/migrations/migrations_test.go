@@ -0,0 +1,56 @@
+package migrations
+
+import (
+	"strings"
+	"testing"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestAllOrderedAndComplete(t *testing.T) {
+	all, err := All()
+	if err != nil {
+		t.Fatalf("All() error = %v", err)
+	}
+	if len(all) < 2 {
+		t.Fatalf("All() returned %d migrations, want at least baseline and normalize_phones", len(all))
+	}
+	if all[0].Version != 1 || all[0].Name != "baseline" || all[0].Down == nil {
+		t.Fatalf("first migration = %d_%s, want reversible 1_baseline", all[0].Version, all[0].Name)
+	}
+	for i, m := range all {
+		if m.Version != int64(i+1) {
+			t.Fatalf("migration %d_%s: versions must be sequential, want %d", m.Version, m.Name, i+1)
+		}
+		if m.Up == nil {
+			t.Fatalf("migration %d_%s has no up step", m.Version, m.Name)
+		}
+	}
+}
+
+// Миграции данных пишут SQL по схеме своей версии: scope живых моделей
+// (например, deleted_at из 0013) ломает migrate up на пустой базе
+func TestNormalizePhonesIgnoresLiveModels(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=127.0.0.1 user=dry dbname=dry"}),
+		&gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var queries []string
+	collect := func(tx *gorm.DB) { queries = append(queries, tx.Statement.SQL.String()) }
+	_ = db.Callback().Query().After("gorm:query").Register("test:collect_query", collect)
+	_ = db.Callback().Raw().After("gorm:raw").Register("test:collect_raw", collect)
+
+	if err := normalizePhones(db); err != nil {
+		t.Fatalf("normalizePhones() error = %v", err)
+	}
+	if len(queries) == 0 {
+		t.Fatal("normalizePhones() ran no queries")
+	}
+	for _, q := range queries {
+		if strings.Contains(q, "deleted_at") {
+			t.Errorf("query depends on a later schema: %s", q)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Package geo считает расстояния между точками на поверхности Земли по формуле гаверсинусов.
+package geo
+
+import "math"
+
+// EarthRadiusKm — средний радиус Земли, им же пользуется SQL-запрос каталога
+const EarthRadiusKm = 6371.0
+
+// Point — широта и долгота в градусах
+type Point struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Valid сообщает, что широта в -90..90, а долгота в -180..180
+func (p Point) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180
+}
+
+// DistanceKm — расстояние между a и b по дуге большого круга в километрах
+func DistanceKm(a, b Point) float64 {
+	lat1, lat2 := radians(a.Latitude), radians(b.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(b.Longitude - a.Longitude)
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+func radians(deg float64) float64 {
+	return deg * math.Pi / 180
+}
@@ -0,0 +1,39 @@
+package geo
+
+import (
+	"math"
+	"testing"
+)
+
+func TestDistanceKm(t *testing.T) {
+	moscow := Point{Latitude: 55.7558, Longitude: 37.6173}
+	petersburg := Point{Latitude: 59.9343, Longitude: 30.3351}
+	tests := []struct {
+		name string
+		a, b Point
+		want float64
+	}{
+		{name: "same point", a: moscow, b: moscow, want: 0},
+		{name: "Moscow to Saint Petersburg", a: moscow, b: petersburg, want: 634},
+		{name: "symmetric", a: petersburg, b: moscow, want: 634},
+		{name: "antipodes", a: Point{0, 0}, b: Point{0, 180}, want: math.Pi * EarthRadiusKm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DistanceKm(tt.a, tt.b); math.Abs(got-tt.want) > 1 {
+				t.Fatalf("DistanceKm() = %.1f, want %.1f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValid(t *testing.T) {
+	for _, p := range []Point{{91, 0}, {0, -181}} {
+		if p.Valid() {
+			t.Errorf("%+v.Valid() = true", p)
+		}
+	}
+	if !(Point{-90, 180}).Valid() {
+		t.Error("edge point must be valid")
+	}
+}
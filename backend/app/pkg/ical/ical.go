@@ -0,0 +1,118 @@
+// Package ical собирает файл календаря (RFC 5545) с одним событием —
+// запись клиента, которую бот отправляет кнопкой «Добавить в календарь».
+package ical
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Статусы события (RFC 5545, 3.8.1.11)
+const (
+	StatusTentative = "TENTATIVE"
+	StatusConfirmed = "CONFIRMED"
+	StatusCancelled = "CANCELLED"
+)
+
+const (
+	utcLayout = "20060102T150405Z"
+	// lineLimit — максимальная длина строки в октетах без CRLF
+	lineLimit = 75
+)
+
+// Event — событие календаря. Время записывается в UTC: календарь
+// сам покажет его в таймзоне устройства.
+type Event struct {
+	UID         string
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	// Geo — координаты места (GEO); nil — не указывать
+	Geo    *Geo
+	Status string
+	// Stamp — время формирования файла (DTSTAMP)
+	Stamp time.Time
+}
+
+// Geo — широта и долгота места события в градусах
+type Geo struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Marshal возвращает содержимое .ics с одним событием
+func Marshal(e Event) []byte {
+	var b strings.Builder
+	write := func(name, value string) {
+		b.WriteString(fold(name + ":" + value))
+		b.WriteString("\r\n")
+	}
+
+	write("BEGIN", "VCALENDAR")
+	write("VERSION", "2.0")
+	write("PRODID", "-//TimeSlot Hub//Records//RU")
+	write("CALSCALE", "GREGORIAN")
+	write("METHOD", "PUBLISH")
+	write("BEGIN", "VEVENT")
+	write("UID", escape(e.UID))
+	write("DTSTAMP", e.Stamp.UTC().Format(utcLayout))
+	write("DTSTART", e.Start.UTC().Format(utcLayout))
+	if !e.End.IsZero() {
+		write("DTEND", e.End.UTC().Format(utcLayout))
+	}
+	write("SUMMARY", escape(e.Summary))
+	if e.Description != "" {
+		write("DESCRIPTION", escape(e.Description))
+	}
+	if e.Location != "" {
+		write("LOCATION", escape(e.Location))
+	}
+	if e.Geo != nil {
+		// GEO — два числа через «;» без экранирования (RFC 5545, 3.8.1.6)
+		write("GEO", strconv.FormatFloat(e.Geo.Latitude, 'f', 6, 64)+";"+strconv.FormatFloat(e.Geo.Longitude, 'f', 6, 64))
+	}
+	if e.Status != "" {
+		write("STATUS", e.Status)
+	}
+	write("END", "VEVENT")
+	write("END", "VCALENDAR")
+	return []byte(b.String())
+}
+
+// escape экранирует текстовое значение (RFC 5545, 3.3.11)
+func escape(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+// fold переносит строку длиннее 75 октетов: продолжение начинается с пробела.
+// Многобайтовые символы UTF-8 не разрываются.
+func fold(line string) string {
+	if len(line) <= lineLimit {
+		return line
+	}
+	var b strings.Builder
+	limit := lineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// Пробел в начале строки-продолжения тоже считается
+		limit = lineLimit - 1
+	}
+	b.WriteString(line)
+	return b.String()
+}
@@ -0,0 +1,82 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func TestMarshal(t *testing.T) {
+	omsk := time.FixedZone("Asia/Omsk", 6*3600)
+	got := string(Marshal(Event{
+		UID:         "record-7@timeslot-hub",
+		Start:       time.Date(2030, 1, 15, 15, 0, 0, 0, omsk),
+		End:         time.Date(2030, 1, 15, 16, 30, 0, 0, omsk),
+		Summary:     "Стрижка, укладка",
+		Description: "Мастер: Анна\nк 15:00; без опозданий",
+		Status:      StatusConfirmed,
+		Stamp:       time.Date(2029, 12, 1, 10, 0, 0, 0, time.UTC),
+	}))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:record-7@timeslot-hub\r\n",
+		"DTSTAMP:20291201T100000Z\r\n",
+		"DTSTART:20300115T090000Z\r\n",
+		"DTEND:20300115T103000Z\r\n",
+		`SUMMARY:Стрижка\, укладка` + "\r\n",
+		`DESCRIPTION:Мастер: Анна\nк 15:00\; без опозданий` + "\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Marshal() missing %q in\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "LOCATION") || strings.Contains(got, "GEO") {
+		t.Errorf("Marshal() wrote empty LOCATION or GEO:\n%s", got)
+	}
+}
+
+func TestMarshalLocation(t *testing.T) {
+	got := string(Marshal(Event{
+		UID:      "record-8@timeslot-hub",
+		Start:    time.Date(2030, 1, 15, 9, 0, 0, 0, time.UTC),
+		Summary:  "Маникюр",
+		Location: "Салон, ул. Ленина, 1",
+		Geo:      &Geo{Latitude: 55.751244, Longitude: 37.618423},
+		Stamp:    time.Date(2029, 12, 1, 10, 0, 0, 0, time.UTC),
+	}))
+	for _, want := range []string{
+		`LOCATION:Салон\, ул. Ленина\, 1` + "\r\n",
+		"GEO:55.751244;37.618423\r\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Marshal() missing %q in\n%s", want, got)
+		}
+	}
+}
+
+func TestFold(t *testing.T) {
+	line := "DESCRIPTION:" + strings.Repeat("комментарий ", 20)
+	folded := fold(line)
+	parts := strings.Split(folded, "\r\n")
+	if len(parts) < 2 {
+		t.Fatalf("fold() did not split a %d-octet line", len(line))
+	}
+	for i, p := range parts {
+		if len(p) > lineLimit {
+			t.Errorf("part %d is %d octets, want <= %d", i, len(p), lineLimit)
+		}
+		if !utf8.ValidString(p) {
+			t.Errorf("part %d splits a UTF-8 character: %q", i, p)
+		}
+		if i > 0 && !strings.HasPrefix(p, " ") {
+			t.Errorf("continuation %d must start with a space: %q", i, p)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != line {
+		t.Errorf("unfolded = %q, want %q", unfolded, line)
+	}
+}
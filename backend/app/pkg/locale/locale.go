@@ -0,0 +1,36 @@
+// Package locale выбирает язык текстов, которые API отправляет пользователю: уведомлений
+// на сайте и сообщений в Telegram. Языки те же, что у каталогов бота: ru (по умолчанию) и en.
+package locale
+
+import "contract/money"
+
+const (
+	RU = "ru"
+	EN = "en"
+)
+
+// Of — язык по коду из User.Language; пустой и неподдерживаемый код — RU
+func Of(code string) string {
+	if code == EN {
+		return EN
+	}
+	return RU
+}
+
+// Text — текст уведомления на всех языках API
+type Text struct {
+	RU, EN string
+}
+
+// In возвращает текст на языке lang
+func (t Text) In(lang string) string {
+	if Of(lang) == EN {
+		return t.EN
+	}
+	return t.RU
+}
+
+// Money — сумма в минимальных единицах валюты по правилам языка lang: «1 500 ₽», «₽1,500»
+func Money(amount int64, currency, lang string) string {
+	return money.Format(amount, currency, Of(lang))
+}
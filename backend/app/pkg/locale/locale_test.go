@@ -0,0 +1,23 @@
+package locale_test
+
+import (
+	"app/pkg/locale"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	text := locale.Text{RU: "Запись подтверждена", EN: "Booking confirmed"}
+	for lang, want := range map[string]string{"en": text.EN, "ru": text.RU, "": text.RU, "de": text.RU} {
+		if got := text.In(lang); got != want {
+			t.Errorf("In(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
+
+func TestMoney(t *testing.T) {
+	for lang, want := range map[string]string{"en": "₽1,500.50", "ru": "1 500,50 ₽", "": "1 500,50 ₽"} {
+		if got := locale.Money(150050, "RUB", lang); got != want {
+			t.Errorf("Money(%q) = %q, want %q", lang, got, want)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+package models
+
+import "gorm.io/gorm"
+
+// WithArchived — условие для Preload, которое подгружает и архивные строки.
+// Записи и отзывы ссылаются на слоты, услуги и пользователей, удалённых позже,
+// и история должна показывать их как были
+func WithArchived(db *gorm.DB) *gorm.DB {
+	return db.Unscoped()
+}
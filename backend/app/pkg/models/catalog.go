@@ -0,0 +1,51 @@
+package models
+
+import (
+	"contract"
+
+	"github.com/google/uuid"
+)
+
+// ServiceCategory — категория услуг мастера
+type ServiceCategory struct {
+	ID       uint      `json:"id" gorm:"primaryKey"`
+	MasterID uuid.UUID `json:"master_id" gorm:"type:uuid;not null"`
+	Name     string    `json:"name" gorm:"not null"`
+	Position int       `json:"position" gorm:"not null;default:0"`
+}
+
+// ServiceVariant — вариант услуги со своей ценой и длительностью (короткие/средние/длинные волосы)
+type ServiceVariant struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	ServiceID uint   `json:"service_id" gorm:"not null;index:idx_service_variant_service"`
+	Name      string `json:"name" gorm:"not null"`
+	Price     int64  `json:"price" gorm:"not null"`
+	Duration  int    `json:"duration" gorm:"not null"`
+	Position  int    `json:"position" gorm:"not null;default:0"`
+}
+
+// ServiceAddOn — дополнительная опция услуги: добавляет к цене и длительности (в минутах)
+type ServiceAddOn struct {
+	ID        uint   `json:"id" gorm:"primaryKey"`
+	ServiceID uint   `json:"service_id" gorm:"not null;index:idx_service_add_on_service"`
+	Name      string `json:"name" gorm:"not null"`
+	Price     int64  `json:"price" gorm:"not null"`
+	Duration  int    `json:"duration" gorm:"not null;default:0"`
+	Position  int    `json:"position" gorm:"not null;default:0"`
+}
+
+// RecordAddOn — опция, выбранная при записи: название, цена и длительность на тот момент.
+// AddOnID обнуляется, если мастер удалил опцию
+type RecordAddOn struct {
+	ID       uint   `json:"-" gorm:"primaryKey"`
+	RecordID uint   `json:"-" gorm:"not null;index:idx_record_add_on_record"`
+	AddOnID  *uint  `json:"add_on_id,omitempty"`
+	Name     string `json:"name" gorm:"not null"`
+	Price    int64  `json:"price" gorm:"not null"`
+	Duration int    `json:"duration" gorm:"not null;default:0"`
+}
+
+// Contract converts a category to the wire format
+func (c ServiceCategory) Contract() contract.ServiceCategory {
+	return contract.ServiceCategory{ID: c.ID, MasterID: c.MasterID, Name: c.Name, Position: c.Position}
+}
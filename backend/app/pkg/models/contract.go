@@ -0,0 +1,104 @@
+package models
+
+import "contract"
+
+// Contract converts a service to the wire format shared with the Telegram bot
+func (s Service) Contract() contract.Service {
+	out := contract.Service{
+		ID:          s.ID,
+		MasterID:    s.MasterID,
+		Name:        s.Name,
+		Price:       s.Price,
+		Currency:    s.Currency,
+		Description: s.Description,
+		Duration:    s.Duration,
+
+		OrganizationID: s.OrganizationID,
+		Rating:         s.Rating,
+		RatingCount:    s.RatingCount,
+		CategoryID:     s.CategoryID,
+		Prepayment:     s.Prepayment,
+		Deposit:        s.Deposit,
+	}
+	for _, v := range s.Variants {
+		out.Variants = append(out.Variants, contract.ServiceVariant{ID: v.ID, Name: v.Name, Price: v.Price, Duration: v.Duration, Position: v.Position})
+	}
+	for _, a := range s.AddOns {
+		out.AddOns = append(out.AddOns, contract.ServiceAddOn{ID: a.ID, Name: a.Name, Price: a.Price, Duration: a.Duration, Position: a.Position})
+	}
+	return out
+}
+
+// Contract converts a user to the wire format shared with the Telegram bot
+func (u User) Contract() contract.User {
+	out := contract.User{
+		ID:         u.ID,
+		Phone:      u.Phone,
+		TelegramID: u.TelegramID,
+		FirstName:  u.FirstName,
+		Surname:    u.Surname,
+		Timezone:   u.Timezone,
+		Language:   u.Language,
+		Currency:   u.Currency,
+		DigestTime: u.DigestTime,
+		Active:     u.Active,
+	}
+	for _, r := range u.Roles {
+		out.Roles = append(out.Roles, contract.UserRole{UserID: r.UserID, Role: r.Role})
+	}
+	for _, s := range u.Services {
+		out.Services = append(out.Services, s.Contract())
+	}
+	return out
+}
+
+// Contract converts a slot to the wire format shared with the Telegram bot
+func (s Slot) Contract() contract.Slot {
+	return contract.Slot{
+		ID:        s.ID,
+		MasterID:  s.MasterID,
+		StartTime: s.StartTime,
+		EndTime:   s.EndTime,
+		IsBooked:  s.IsBooked,
+		ServiceID: s.ServiceID,
+		Service:   s.Service.Contract(),
+		Master:    s.Master.Contract(),
+	}
+}
+
+// Contract converts a record to the wire format shared with the Telegram bot
+func (r Record) Contract() contract.Record {
+	out := contract.Record{
+		ID:          r.ID,
+		SlotID:      r.SlotID,
+		ClientID:    r.ClientID,
+		Status:      r.Status,
+		Comment:     r.Comment,
+		CreatedAt:   r.CreatedAt,
+		VariantID:   r.VariantID,
+		VariantName: r.VariantName,
+		Price:       r.Price,
+		Currency:    r.Currency,
+		Duration:    r.Duration,
+		HoldUntil:   r.HoldUntil,
+		Slot:        r.Slot.Contract(),
+		Client:      r.Client.Contract(),
+	}
+	if r.Payment != nil {
+		p := r.Payment.Contract()
+		out.Payment = &p
+	}
+	for _, a := range r.AddOns {
+		out.AddOns = append(out.AddOns, contract.RecordAddOn{AddOnID: a.AddOnID, Name: a.Name, Price: a.Price, Duration: a.Duration})
+	}
+	return out
+}
+
+// ContractRecords converts records to the wire format shared with the Telegram bot
+func ContractRecords(records []Record) []contract.Record {
+	out := make([]contract.Record, 0, len(records))
+	for _, r := range records {
+		out = append(out, r.Contract())
+	}
+	return out
+}
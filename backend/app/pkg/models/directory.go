@@ -0,0 +1,44 @@
+package models
+
+import (
+	"app/pkg/geo"
+	"time"
+)
+
+// Порядок выдачи каталога мастеров
+const (
+	DirectorySortRelevance = "relevance" // по совпадению с запросом; без запроса — как next_slot
+	DirectorySortNextSlot  = "next_slot" // по ближайшему свободному слоту
+	DirectorySortRating    = "rating"    // по рейтингу, затем по числу оценок
+	DirectorySortDistance  = "distance"  // по расстоянию до ближайшего места услуг
+)
+
+// DirectoryQuery — фильтры публичного каталога. Нулевые значения — без ограничения.
+// Ценовые, длительностные и географические фильтры применяются к услугам:
+// мастер попадает в выдачу, если хотя бы одна его услуга подходит под все сразу
+type DirectoryQuery struct {
+	Text string
+	// MinPrice и MaxPrice — в минимальных единицах валюты; Currency — только услуги в этой валюте (ISO 4217)
+	MinPrice    int64
+	MaxPrice    int64
+	Currency    string
+	MinDuration int
+	MaxDuration int
+	// Near и RadiusKm — услуги, место которых не дальше RadiusKm от Near; Near нужен и для сортировки по расстоянию
+	Near     *geo.Point
+	RadiusKm float64
+	// FreeWithin — у подходящей услуги есть свободный слот в ближайшие FreeWithin
+	FreeWithin time.Duration
+	Sort       string
+	Limit      int
+	Offset     int
+	Now        time.Time
+}
+
+// DirectoryHit — мастер из каталога: Master.Services — только подходящие услуги,
+// DistanceKm — до ближайшего места этих услуг (nil без Near или без координат)
+type DirectoryHit struct {
+	Master     User
+	DistanceKm *float64
+	Rank       float64
+}
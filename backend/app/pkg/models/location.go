@@ -0,0 +1,41 @@
+package models
+
+import (
+	"contract"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WorkingHours — интервал работы места в один из дней недели
+type WorkingHours = contract.WorkingHours
+
+// Location — место, где мастер принимает клиентов. Пустые WorkingHours — без ограничений по времени
+type Location struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	OrganizationID uuid.UUID      `json:"organization_id" gorm:"type:uuid;not null;index:idx_location_organization"`
+	Name           string         `json:"name" gorm:"not null"`
+	Address        string         `json:"address" gorm:"not null"`
+	Latitude       *float64       `json:"latitude,omitempty"`
+	Longitude      *float64       `json:"longitude,omitempty"`
+	Timezone       string         `json:"timezone" gorm:"not null"`
+	WorkingHours   []WorkingHours `json:"working_hours" gorm:"type:jsonb;serializer:json;not null"`
+	CreatedAt      time.Time      `json:"created_at"`
+}
+
+// Line — адрес одной строкой для уведомлений и календаря: «Название, адрес»
+func (l Location) Line() string {
+	if l.Name == "" || strings.Contains(l.Address, l.Name) {
+		return l.Address
+	}
+	return l.Name + ", " + l.Address
+}
+
+// Venue — место для карты в Telegram; false, если координаты не заданы
+func (l Location) Venue() (contract.Venue, bool) {
+	if l.Latitude == nil || l.Longitude == nil {
+		return contract.Venue{}, false
+	}
+	return contract.Venue{Title: l.Name, Address: l.Address, Latitude: *l.Latitude, Longitude: *l.Longitude}, true
+}
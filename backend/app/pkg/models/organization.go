@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Роли участников организации
+const (
+	OrgRoleOwner        = "owner"        // всё, включая управляющих и удаление участников
+	OrgRoleManager      = "manager"      // услуги, участники-мастера, решения по записям за мастеров
+	OrgRoleMaster       = "master"       // ведёт назначенные ему услуги и свои слоты
+	OrgRoleReceptionist = "receptionist" // видит слоты и записи организации
+)
+
+// Organization — салон или личная организация мастера (PersonalOwnerID != nil)
+type Organization struct {
+	ID              uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Name            string     `json:"name" gorm:"not null"`
+	PersonalOwnerID *uuid.UUID `json:"personal_owner_id,omitempty" gorm:"type:uuid;uniqueIndex"`
+	CreatedAt       time.Time  `json:"created_at"`
+
+	Members []OrganizationMember `json:"members,omitempty" gorm:"foreignKey:OrganizationID; constraint:OnDelete:CASCADE"`
+}
+
+// OrganizationMember — участник организации в одной из ролей OrgRole*
+type OrganizationMember struct {
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;primaryKey"`
+	UserID         uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey;index:idx_organization_members_user"`
+	Role           string    `json:"role" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+
+	User User `json:"user" gorm:"foreignKey:UserID; constraint:OnDelete:CASCADE"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"contract"
+	"time"
+)
+
+// Статусы платежа; refunded — деньги вернулись клиенту (оплата пришла после снятия брони)
+const (
+	PaymentPending   = "pending"
+	PaymentSucceeded = "succeeded"
+	PaymentCanceled  = "canceled"
+	PaymentRefunded  = "refunded"
+)
+
+// Payment — предоплата записи у платёжного провайдера.
+// RecordID обнуляется при удалении записи — платёж остаётся для сверки с провайдером
+type Payment struct {
+	ID              uint       `json:"id" gorm:"primaryKey"`
+	RecordID        *uint      `json:"record_id,omitempty" gorm:"index:idx_payment_record"`
+	Provider        string     `json:"provider" gorm:"not null;uniqueIndex:idx_payment_external"`
+	ExternalID      string     `json:"-" gorm:"not null;uniqueIndex:idx_payment_external"`
+	Amount          int64      `json:"amount" gorm:"not null"`
+	Currency        string     `json:"currency" gorm:"not null"`
+	Status          string     `json:"status" gorm:"not null;default:pending"`
+	ConfirmationURL string     `json:"confirmation_url,omitempty" gorm:"not null;default:''"`
+	ExpiresAt       time.Time  `json:"expires_at" gorm:"not null"`
+	PaidAt          *time.Time `json:"paid_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// Contract converts a payment to the wire format shared with the Telegram bot
+func (p Payment) Contract() contract.Payment {
+	return contract.Payment{
+		ID:              p.ID,
+		RecordID:        p.RecordID,
+		Provider:        p.Provider,
+		Amount:          p.Amount,
+		Currency:        p.Currency,
+		Status:          p.Status,
+		ConfirmationURL: p.ConfirmationURL,
+		ExpiresAt:       p.ExpiresAt,
+		PaidAt:          p.PaidAt,
+	}
+}
@@ -8,19 +8,46 @@ import (
 
 // Record represents slot booking table
 type Record struct {
-	ID        uint      `json:"id"        gorm:"primaryKey; column:id"`
-	SlotID    uint      `json:"slot_id"   gorm:"column:slot_id; not null; uniqueIndex:idx_record_slot_client"`
-	ClientID  uuid.UUID `json:"client_id" gorm:"column:client_id; not null; uniqueIndex:idx_record_slot_client"`
-	Status    string    `json:"status" gorm:"column:status; default:pending"`
+	ID       uint      `json:"id"        gorm:"primaryKey; column:id"`
+	SlotID   uint      `json:"slot_id"   gorm:"column:slot_id; not null; uniqueIndex:idx_record_slot_client"`
+	ClientID uuid.UUID `json:"client_id" gorm:"column:client_id; not null; uniqueIndex:idx_record_slot_client"`
+	Status   string    `json:"status" gorm:"column:status; default:pending"`
+	// Comment — комментарий клиента для мастера
+	Comment   string    `json:"comment" gorm:"column:comment; not null; default:''"`
 	CreatedAt time.Time `json:"created_at" gorm:"timestamptz; column:created_at; default:CURRENT_TIMESTAMP"`
+	// ReviewPromptedAt — когда клиенту отправлено приглашение оценить визит
+	ReviewPromptedAt *time.Time `json:"-" gorm:"column:review_prompted_at"`
+	// VariantID и AddOnIDs — выбор клиента при записи; без варианта действуют цена и длительность услуги
+	VariantID *uint  `json:"variant_id,omitempty" gorm:"column:variant_id"`
+	AddOnIDs  []uint `json:"add_on_ids,omitempty" gorm:"-"`
+	// VariantName, Price, Currency, Duration и AddOns — снимок на момент записи: изменение прайса их не меняет
+	VariantName string        `json:"variant_name" gorm:"column:variant_name; not null; default:''"`
+	Price       int64         `json:"price" gorm:"column:price; not null; default:0"`
+	Currency    string        `json:"currency" gorm:"column:currency; not null; default:'RUB'"`
+	Duration    int           `json:"duration" gorm:"column:duration; not null; default:0"`
+	AddOns      []RecordAddOn `json:"add_ons,omitempty" gorm:"foreignKey:RecordID"`
+	// HoldUntil — запись ждёт оплаты (awaiting_payment) и держит слот до этого времени
+	HoldUntil *time.Time `json:"hold_until,omitempty" gorm:"column:hold_until"`
+	Payment   *Payment   `json:"payment,omitempty" gorm:"foreignKey:RecordID"`
 
 	// Expose slot in JSON so Telegram can render date/time/service/master
 	Slot   Slot `json:"slot" gorm:"foreignKey:SlotID; constraint:OnDelete:CASCADE"`
 	Client User `json:"client" gorm:"foreignKey:ClientID; constraint:OnDelete:CASCADE"`
 }
+
+// RecordAwaitingPayment — запись создана, но услуга требует предоплату: слот держится до HoldUntil.
+// После оплаты запись подтверждается, после HoldUntil — отменяется
+const RecordAwaitingPayment = "awaiting_payment"
+
+// Completed — визит состоялся: запись подтверждена и слот уже закончился
+func (r Record) Completed(now time.Time) bool {
+	return r.Status == "confirm" && !r.Slot.EndTime.IsZero() && !r.Slot.EndTime.After(now)
+}
+
 type RecordResponce struct {
 	ID        uint      `json:"id"`
 	Status    string    `json:"status"`
+	Comment   string    `json:"comment"`
 	CreatedAt time.Time `json:"created_at"`
 
 	ClientID         uuid.UUID `json:"client_id"`
@@ -29,10 +56,12 @@ type RecordResponce struct {
 	ClientSurname    string    `json:"client_surname"`
 	ClientPhone      string    `json:"client_phone"`
 
-	SlotID       uint    `json:"slot_id"`
-	SlotName     string  `json:"slot_name"`
-	SlotPrice    float64 `json:"slot_price"`
-	SlotDuration int     `json:"slot_duration"`
+	// SlotPrice, SlotCurrency и SlotDuration — снимок записи, а не текущий прайс услуги
+	SlotID       uint   `json:"slot_id"`
+	SlotName     string `json:"slot_name"`
+	SlotPrice    int64  `json:"slot_price"`
+	SlotCurrency string `json:"slot_currency"`
+	SlotDuration int    `json:"slot_duration"`
 
 	MasterID         uuid.UUID `json:"master_id"`
 	MasterTelegramID int64     `json:"master_telegram_id"`
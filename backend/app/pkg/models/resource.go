@@ -0,0 +1,28 @@
+package models
+
+import (
+	"contract"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resource — кресло, кабинет или оборудование организации; одновременно
+// заняты могут быть не больше Quantity слотов услуг, которым он нужен
+type Resource struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	OrganizationID uuid.UUID `json:"organization_id" gorm:"type:uuid;not null;index:idx_resource_organization"`
+	Name           string    `json:"name" gorm:"not null"`
+	Quantity       int       `json:"quantity" gorm:"not null"`
+	Active         bool      `json:"active" gorm:"not null;default:true"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ServiceResource — ресурс, который услуга занимает на время слота (одну единицу)
+type ServiceResource struct {
+	ServiceID  uint `gorm:"primaryKey"`
+	ResourceID uint `gorm:"primaryKey;index:idx_service_resources_resource"`
+}
+
+// ResourceUtilization is the wire format of resource load for a period
+type ResourceUtilization = contract.ResourceUtilization
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Review — отзыв клиента о завершённой записи: оценка 1–5, текст и публичный ответ мастера.
+// Скрытые администратором отзывы не показываются и не входят в рейтинг.
+// RecordID и ClientID обнуляются при удалении записи или клиента — отзыв и рейтинг остаются
+type Review struct {
+	ID        uint       `json:"id" gorm:"primaryKey"`
+	RecordID  *uint      `json:"record_id,omitempty" gorm:"uniqueIndex"`
+	ClientID  *uuid.UUID `json:"client_id,omitempty" gorm:"type:uuid"`
+	MasterID  uuid.UUID  `json:"master_id" gorm:"type:uuid;not null;index:idx_review_master"`
+	ServiceID *uint      `json:"service_id,omitempty" gorm:"index:idx_review_service"`
+	Rating    int        `json:"rating" gorm:"not null"`
+	Text      string     `json:"text" gorm:"not null;default:''"`
+	Reply     string     `json:"reply" gorm:"not null;default:''"`
+	RepliedAt *time.Time `json:"replied_at,omitempty"`
+	Hidden    bool       `json:"hidden" gorm:"not null;default:false"`
+	Flagged   bool       `json:"flagged" gorm:"not null;default:false"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+
+	Client  *User    `json:"-" gorm:"foreignKey:ClientID"`
+	Service *Service `json:"-" gorm:"foreignKey:ServiceID"`
+}
+
+// ReviewFilter — выборка отзывов; nil-поля не ограничивают выдачу
+type ReviewFilter struct {
+	MasterID  *uuid.UUID
+	ServiceID *uint
+	Hidden    *bool
+	Flagged   *bool
+	Limit     int
+	Offset    int
+}
@@ -1,27 +1,58 @@
 package models
 
 import (
+	"contract"
+
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 type Service struct {
-	ID          uint      `json:"id"`
-	MasterID    uuid.UUID `json:"master_id" gorm:"index:idx_service_master"`
-	Name        string    `json:"name"`
-	Price       float64   `json:"price"`
-	Description string    `json:"description"`
-	Duration    int       `json:"duration"`
+	ID       uint      `json:"id"`
+	MasterID uuid.UUID `json:"master_id" gorm:"index:idx_service_master"`
+	Name     string    `json:"name"`
+	// Price — в минимальных единицах Currency (копейки, центы), см. contract/money
+	Price       int64  `json:"price"`
+	Currency    string `json:"currency" gorm:"not null;default:'RUB'"`
+	Description string `json:"description"`
+	Duration    int    `json:"duration"`
+	// OrganizationID — организация, которой принадлежит услуга; MasterID — назначенный мастер.
+	// Внешний ключ ON DELETE RESTRICT (0007): организацию с услугами не удалить, услуги архивируются
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty" gorm:"type:uuid; index:idx_service_organization"`
+	// LocationID — где оказывается услуга; новые слоты получают это место
+	LocationID *uint `json:"location_id,omitempty"`
+	// Rating и RatingCount — агрегат видимых отзывов, пересчитывается при каждом изменении отзыва
+	Rating      float64 `json:"rating" gorm:"not null;default:0"`
+	RatingCount int     `json:"rating_count" gorm:"not null;default:0"`
+	// CategoryID — категория мастера; меняется через PUT /service/{id}/category
+	CategoryID *uint `json:"category_id,omitempty"`
+	// Variants и AddOns — варианты и опции услуги; без вариантов действуют Price и Duration.
+	// Меняются только через свои маршруты, Create и Save услуги их не трогают
+	Variants []ServiceVariant `json:"variants,omitempty" gorm:"foreignKey:ServiceID"`
+	AddOns   []ServiceAddOn   `json:"add_ons,omitempty" gorm:"foreignKey:ServiceID"`
+	// Prepayment — что клиент оплачивает при записи: "" — ничего, deposit — задаток Deposit, full — всю цену записи
+	Prepayment string `json:"prepayment" gorm:"not null;default:''"`
+	Deposit    int64  `json:"deposit" gorm:"not null;default:0"`
+	// DeletedAt — услуга в архиве: скрыта из каталога, но остаётся в истории записей
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"index"`
 }
-type ServiceResponse struct {
-	ID          uint      `json:"id"`
-	MasterID    uuid.UUID `json:"master_id"`
-	Name        string    `json:"name"`
-	Price       float64   `json:"price"`
-	Description string    `json:"description"`
-	Duration    int       `json:"duration"`
 
-	MasterTelegramID int64  `json:"master_telegram_id"`
-	MasterName       string `json:"master_name"`
-	MasterSurname    string `json:"master_surname"`
-	MasterPhone      string `json:"master_phone"`
+// Варианты предоплаты услуги
+const (
+	PrepaymentDeposit = "deposit"
+	PrepaymentFull    = "full"
+)
+
+// Due — сколько клиент платит при записи с ценой price: задаток не больше цены, 0 — без предоплаты
+func (s Service) Due(price int64) int64 {
+	switch s.Prepayment {
+	case PrepaymentFull:
+		return price
+	case PrepaymentDeposit:
+		return min(s.Deposit, price)
+	}
+	return 0
 }
+
+// ServiceResponse is the wire format shared with the Telegram bot
+type ServiceResponse = contract.ServiceResponse
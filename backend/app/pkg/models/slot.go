@@ -1,9 +1,11 @@
 package models
 
 import (
+	"contract"
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // Slot represents master slots table
@@ -14,23 +16,23 @@ type Slot struct {
 	EndTime   time.Time `json:"end_time"    gorm:"column:end_time"`
 	IsBooked  bool      `json:"is_booked"   gorm:"column:is_booked; default:false"`
 	ServiceID uint      `json:"service_id"  gorm:"column:service_id; not null"`
+	// LocationID — место услуги на момент создания слота
+	LocationID *uint `json:"location_id,omitempty" gorm:"column:location_id"`
+	// DeletedAt — слот в архиве; записи на него сохраняются
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"column:deleted_at; index"`
 
-	Service Service `json:"service" gorm:"foreignKey:ServiceID; constraint:OnDelete:CASCADE"`
-	Master  User    `json:"master" gorm:"foreignKey:MasterID; constraint:OnDelete:CASCADE"`
+	Service  Service   `json:"service" gorm:"foreignKey:ServiceID; constraint:OnDelete:CASCADE"`
+	Master   User      `json:"master" gorm:"foreignKey:MasterID; constraint:OnDelete:CASCADE"`
+	Location *Location `json:"location,omitempty" gorm:"foreignKey:LocationID; constraint:OnDelete:SET NULL"`
 }
-type SlotResponse struct {
-	ID        uint      `json:"id"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	IsBooked  bool      `json:"is_booked"`
 
-	ServiceName        string  `json:"service_name"`
-	ServiceDescription string  `json:"service_description"`
-	ServicePrice       float64 `json:"service_price"`
-	ServiceDuration    int     `json:"service_duration"`
-
-	MasterTelegramID int64  `json:"master_telegram_id"`
-	MasterName       string `json:"master_name"`
-	MasterSurname    string `json:"master_surname"`
-	MasterPhone      string `json:"master_phone"`
+// Address — адрес слота одной строкой или "", если место не задано или не загружено
+func (s Slot) Address() string {
+	if s.Location == nil {
+		return ""
+	}
+	return s.Location.Line()
 }
+
+// SlotResponse is the wire format shared with the Telegram bot
+type SlotResponse = contract.SlotResponse
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Статусы доставки сообщения в Telegram
+const (
+	DeliveryPending = "pending" // передано боту, ждём отчёта
+	DeliverySent    = "sent"    // бот отправил сообщение
+	DeliveryFailed  = "failed"  // бот не смог доставить или был недоступен
+)
+
+// TelegramDelivery — запись outbox об уведомлении, отправленном через бота.
+// Создаётся при передаче уведомления боту, статус обновляется по отчёту бота.
+type TelegramDelivery struct {
+	ID          uuid.UUID  `json:"id" gorm:"type:uuid;primaryKey"`
+	Kind        string     `json:"kind"`
+	TelegramID  int64      `json:"telegram_id"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	MessageID   int        `json:"message_id"`
+	LastError   string     `json:"last_error"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeliveredAt *time.Time `json:"delivered_at"`
+}
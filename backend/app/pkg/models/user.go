@@ -4,20 +4,34 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 // User represents users table
 type User struct {
-	ID                      uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
-	Phone                   string    `json:"phone" gorm:"unique; not null; column:phone"`
-	TelegramID              int64     `json:"telegram_id" gorm:"uniqueIndex; column:telegram_id"`
-	FirstName               string    `json:"first_name" gorm:"column:first_name; not null"`
-	Surname                 string    `json:"surname" gorm:"column:surname; not null"`
-	Timezone                string    `json:"timezone" gorm:"column:timezone; default:'Europe/Moscow'"`
-	Active                  bool      `json:"active" gorm:"column:active; default:false"`
-	ConsentGivenAt          time.Time `json:"consent_given_at" gorm:"timestamptz; column:consent_given_at"`
-	PrivacyPolicyAcceptedAt time.Time `json:"privacy_policy_accepted_at" gorm:"timestamptz; column:privacy_policy_accepted_at"`
-	TermsAcceptedAt         time.Time `json:"terms_accepted_at" gorm:"timestamptz; column:terms_accepted_at"`
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primaryKey;default:gen_random_uuid()"`
+	Phone      string    `json:"phone" gorm:"not null; column:phone"`
+	TelegramID int64     `json:"telegram_id" gorm:"column:telegram_id"`
+	FirstName  string    `json:"first_name" gorm:"column:first_name; not null"`
+	Surname    string    `json:"surname" gorm:"column:surname; not null"`
+	Timezone   string    `json:"timezone" gorm:"column:timezone; default:'Europe/Moscow'"`
+	Language   string    `json:"language" gorm:"column:language; not null; default:''"`
+	// Currency — ISO 4217, валюта новых услуг мастера
+	Currency                string     `json:"currency" gorm:"column:currency; not null; default:'RUB'"`
+	DigestTime              string     `json:"digest_time" gorm:"column:digest_time; not null; default:''"`
+	Active                  bool       `json:"active" gorm:"column:active; default:false"`
+	ConsentGivenAt          time.Time  `json:"consent_given_at" gorm:"timestamptz; column:consent_given_at"`
+	PrivacyPolicyAcceptedAt time.Time  `json:"privacy_policy_accepted_at" gorm:"timestamptz; column:privacy_policy_accepted_at"`
+	TermsAcceptedAt         time.Time  `json:"terms_accepted_at" gorm:"timestamptz; column:terms_accepted_at"`
+	PhoneVerifiedAt         *time.Time `json:"phone_verified_at" gorm:"timestamptz; column:phone_verified_at"`
+	// DirectoryOptOut — мастер не показывается в каталоге и inline-поиске, прямые ссылки работают
+	DirectoryOptOut bool `json:"directory_opt_out" gorm:"column:directory_opt_out; not null; default:false"`
+	// Rating и RatingCount — средняя оценка мастера и число видимых отзывов (пересчитывает usecase/review)
+	Rating      float64 `json:"rating" gorm:"column:rating; not null; default:0"`
+	RatingCount int     `json:"rating_count" gorm:"column:rating_count; not null; default:0"`
+	// DeletedAt — пользователь в архиве; PurgedAt — когда по сроку хранения стёрты его персональные данные
+	DeletedAt gorm.DeletedAt `json:"deleted_at,omitempty" gorm:"column:deleted_at; index"`
+	PurgedAt  *time.Time     `json:"purged_at,omitempty" gorm:"column:purged_at"`
 
 	Roles    []UserRole `json:"roles"       gorm:"foreignKey:UserID; default:'[]'; constraint:OnDelete:CASCADE"`
 	Services []Service  `json:"services"    gorm:"foreignKey:MasterID; default:'[]'; constraint:OnDelete:CASCADE"`
@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sync"
+)
+
+// SignatureHeader — заголовок с подписью вебхука локального провайдера
+const SignatureHeader = "X-Payment-Signature"
+
+// Fake — локальный провайдер для разработки и тестов: денег не списывает,
+// оплату подтверждает вебхук, подписанный общим секретом (см. Sign)
+type Fake struct {
+	secret string
+
+	mu      sync.Mutex
+	refunds map[string]int64
+}
+
+func NewFake(secret string) *Fake {
+	return &Fake{secret: secret, refunds: make(map[string]int64)}
+}
+
+func (f *Fake) Name() string { return "fake" }
+
+// Create выдаёт случайный ID; ссылка на оплату — ReturnURL с параметром payment_id
+func (f *Fake) Create(_ context.Context, intent Intent) (Created, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return Created{}, err
+	}
+	id := "fake_" + hex.EncodeToString(buf)
+	link := intent.ReturnURL
+	if u, err := url.Parse(intent.ReturnURL); err == nil && intent.ReturnURL != "" {
+		q := u.Query()
+		q.Set("payment_id", id)
+		u.RawQuery = q.Encode()
+		link = u.String()
+	}
+	return Created{ExternalID: id, ConfirmationURL: link}, nil
+}
+
+// Refund запоминает возврат — его видно через Refunded
+func (f *Fake) Refund(_ context.Context, externalID string, amount int64, _ string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.refunds[externalID] += amount
+	return nil
+}
+
+// Refunded — сколько возвращено по платежу
+func (f *Fake) Refunded(externalID string) int64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.refunds[externalID]
+}
+
+// fakeEvent — тело вебхука локального провайдера
+type fakeEvent struct {
+	ID       string `json:"id"`
+	Status   string `json:"status"`
+	Amount   int64  `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// ParseWebhook принимает JSON {"id","status","amount","currency"} с подписью Sign в SignatureHeader.
+// Без секрета вебхуки не принимаются вовсе
+func (f *Fake) ParseWebhook(_ context.Context, header http.Header, body []byte) (Event, error) {
+	got, err := hex.DecodeString(header.Get(SignatureHeader))
+	if f.secret == "" || err != nil || !hmac.Equal(got, sign(f.secret, body)) {
+		return Event{}, ErrInvalidSignature
+	}
+	var ev fakeEvent
+	if err := json.Unmarshal(body, &ev); err != nil || ev.ID == "" {
+		return Event{}, ErrInvalidEvent
+	}
+	switch ev.Status {
+	case StatusSucceeded, StatusCanceled, StatusPending:
+	default:
+		return Event{}, ErrInvalidEvent
+	}
+	return Event{ExternalID: ev.ID, Status: ev.Status, Amount: ev.Amount, Currency: ev.Currency}, nil
+}
+
+// Sign — подпись тела вебхука для SignatureHeader: hex(HMAC-SHA256(secret, body))
+func Sign(secret string, body []byte) string {
+	return hex.EncodeToString(sign(secret, body))
+}
+
+func sign(secret string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,61 @@
+// Package payment — платёжные провайдеры для предоплаты записей.
+// Сервис записей создаёт через Provider платёж, клиент оплачивает его по ссылке,
+// провайдер присылает вебхук, подлинность которого проверяет ParseWebhook.
+package payment
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Статусы платежа у провайдера
+const (
+	StatusPending   = "pending"
+	StatusSucceeded = "succeeded"
+	StatusCanceled  = "canceled"
+)
+
+var (
+	// ErrInvalidSignature — вебхук не прошёл проверку подлинности
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrInvalidEvent — тело вебхука нельзя разобрать
+	ErrInvalidEvent = errors.New("invalid webhook event")
+)
+
+// Intent — платёж, который нужно создать у провайдера
+type Intent struct {
+	// Reference — ключ идемпотентности: повторный запрос с тем же ключом не создаёт второй платёж
+	Reference string
+	// Amount — в минимальных единицах Currency
+	Amount      int64
+	Currency    string
+	Description string
+	// ReturnURL — куда провайдер вернёт клиента после оплаты
+	ReturnURL string
+}
+
+// Created — платёж, созданный у провайдера; клиент оплачивает его по ConfirmationURL
+type Created struct {
+	ExternalID      string
+	ConfirmationURL string
+}
+
+// Event — проверенное уведомление провайдера об изменении платежа
+type Event struct {
+	ExternalID string
+	Status     string
+	Amount     int64
+	Currency   string
+}
+
+// Provider — платёжный провайдер
+type Provider interface {
+	// Name — имя провайдера, сохраняется в платеже
+	Name() string
+	Create(ctx context.Context, intent Intent) (Created, error)
+	// Refund возвращает клиенту amount по оплаченному платежу
+	Refund(ctx context.Context, externalID string, amount int64, currency string) error
+	// ParseWebhook проверяет подлинность вебхука и возвращает событие; подделка — ErrInvalidSignature
+	ParseWebhook(ctx context.Context, header http.Header, body []byte) (Event, error)
+}
@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestFakeWebhook(t *testing.T) {
+	body := []byte(`{"id":"fake_1","status":"succeeded","amount":50000,"currency":"RUB"}`)
+	tests := []struct {
+		name    string
+		secret  string
+		sig     string
+		body    []byte
+		wantErr error
+	}{
+		{name: "signed", secret: "s3cret", sig: Sign("s3cret", body), body: body},
+		{name: "wrong secret", secret: "s3cret", sig: Sign("other", body), body: body, wantErr: ErrInvalidSignature},
+		{name: "no signature", secret: "s3cret", body: body, wantErr: ErrInvalidSignature},
+		{name: "provider without secret", secret: "", sig: Sign("", body), body: body, wantErr: ErrInvalidSignature},
+		{name: "unknown status", secret: "s3cret", sig: Sign("s3cret", []byte(`{"id":"fake_1","status":"paid"}`)), body: []byte(`{"id":"fake_1","status":"paid"}`), wantErr: ErrInvalidEvent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(SignatureHeader, tt.sig)
+			ev, err := NewFake(tt.secret).ParseWebhook(context.Background(), header, tt.body)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseWebhook() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (ev.ExternalID != "fake_1" || ev.Status != StatusSucceeded || ev.Amount != 50000 || ev.Currency != "RUB") {
+				t.Errorf("ParseWebhook() = %+v", ev)
+			}
+		})
+	}
+}
+
+func TestFakeCreate(t *testing.T) {
+	created, err := NewFake("s").Create(context.Background(), Intent{Reference: "record-1", Amount: 100, Currency: "RUB", ReturnURL: "https://example.com/records?tab=1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(created.ConfirmationURL)
+	if err != nil || u.Query().Get("payment_id") != created.ExternalID || u.Query().Get("tab") != "1" {
+		t.Errorf("ConfirmationURL = %q, want return URL with payment_id=%s", created.ConfirmationURL, created.ExternalID)
+	}
+}
+
+func TestYooKassa(t *testing.T) {
+	var created map[string]any
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, ok := r.BasicAuth(); !ok || user != "shop" || pass != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/payments":
+			if r.Header.Get("Idempotence-Key") != "record-7" {
+				t.Errorf("Idempotence-Key = %q", r.Header.Get("Idempotence-Key"))
+			}
+			data, _ := io.ReadAll(r.Body)
+			_ = json.Unmarshal(data, &created)
+			_, _ = w.Write([]byte(`{"id":"pay-1","status":"pending","confirmation":{"type":"redirect","confirmation_url":"https://yoomoney.example/pay-1"}}`))
+		case r.Method == http.MethodGet && r.URL.Path == "/payments/pay-1":
+			_, _ = w.Write([]byte(`{"id":"pay-1","status":"succeeded","amount":{"value":"500.50","currency":"RUB"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer api.Close()
+
+	y := NewYooKassa("shop", "key", api.URL)
+	got, err := y.Create(context.Background(), Intent{Reference: "record-7", Amount: 50050, Currency: "RUB", ReturnURL: "https://example.com"})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if got.ExternalID != "pay-1" || got.ConfirmationURL != "https://yoomoney.example/pay-1" {
+		t.Errorf("Create() = %+v", got)
+	}
+	if amount, _ := created["amount"].(map[string]any); amount["value"] != "500.50" || amount["currency"] != "RUB" {
+		t.Errorf("request amount = %v, want 500.50 RUB", created["amount"])
+	}
+
+	// Тело уведомления не доверяется: статус и сумма берутся из API
+	ev, err := y.ParseWebhook(context.Background(), http.Header{}, []byte(`{"event":"payment.succeeded","object":{"id":"pay-1","status":"succeeded","amount":{"value":"1.00","currency":"RUB"}}}`))
+	if err != nil {
+		t.Fatalf("ParseWebhook() error = %v", err)
+	}
+	if ev.ExternalID != "pay-1" || ev.Status != StatusSucceeded || ev.Amount != 50050 {
+		t.Errorf("ParseWebhook() = %+v, want pay-1 succeeded 50050", ev)
+	}
+	if _, err := y.ParseWebhook(context.Background(), http.Header{}, []byte(`{"object":{"id":"forged"}}`)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("ParseWebhook(unknown payment) error = %v, want ErrInvalidSignature", err)
+	}
+}
@@ -0,0 +1,145 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"contract/money"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultYooKassaBase — адрес API ЮKassa
+const DefaultYooKassaBase = "https://api.yookassa.ru/v3"
+
+// YooKassa — провайдер ЮKassa (API v3, basic-авторизация shopId:secretKey).
+// ЮKassa не подписывает уведомления, поэтому ParseWebhook не доверяет телу
+// и перечитывает платёж по ID из API
+type YooKassa struct {
+	shopID    string
+	secretKey string
+	base      string
+	client    *http.Client
+}
+
+func NewYooKassa(shopID, secretKey, base string) *YooKassa {
+	if base == "" {
+		base = DefaultYooKassaBase
+	}
+	return &YooKassa{
+		shopID:    shopID,
+		secretKey: secretKey,
+		base:      strings.TrimRight(base, "/"),
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (y *YooKassa) Name() string { return "yookassa" }
+
+type ykAmount struct {
+	Value    string `json:"value"`
+	Currency string `json:"currency"`
+}
+
+type ykPayment struct {
+	ID           string   `json:"id"`
+	Status       string   `json:"status"`
+	Amount       ykAmount `json:"amount"`
+	Confirmation struct {
+		ConfirmationURL string `json:"confirmation_url"`
+	} `json:"confirmation"`
+}
+
+func (y *YooKassa) Create(ctx context.Context, intent Intent) (Created, error) {
+	req := map[string]any{
+		"amount":  ykAmount{Value: money.Decimal(intent.Amount, intent.Currency), Currency: intent.Currency},
+		"capture": true,
+		"confirmation": map[string]string{
+			"type":       "redirect",
+			"return_url": intent.ReturnURL,
+		},
+		"description": intent.Description,
+		"metadata":    map[string]string{"reference": intent.Reference},
+	}
+	var p ykPayment
+	if err := y.call(ctx, http.MethodPost, "/payments", intent.Reference, req, &p); err != nil {
+		return Created{}, err
+	}
+	return Created{ExternalID: p.ID, ConfirmationURL: p.Confirmation.ConfirmationURL}, nil
+}
+
+func (y *YooKassa) Refund(ctx context.Context, externalID string, amount int64, currency string) error {
+	req := map[string]any{
+		"payment_id": externalID,
+		"amount":     ykAmount{Value: money.Decimal(amount, currency), Currency: currency},
+	}
+	return y.call(ctx, http.MethodPost, "/refunds", "refund-"+externalID, req, nil)
+}
+
+// ParseWebhook берёт из уведомления только ID платежа; статус и сумма — из GET /payments/{id}
+func (y *YooKassa) ParseWebhook(ctx context.Context, _ http.Header, body []byte) (Event, error) {
+	var n struct {
+		Object struct {
+			ID string `json:"id"`
+		} `json:"object"`
+	}
+	if err := json.Unmarshal(body, &n); err != nil || n.Object.ID == "" {
+		return Event{}, ErrInvalidEvent
+	}
+	var p ykPayment
+	if err := y.call(ctx, http.MethodGet, "/payments/"+url.PathEscape(n.Object.ID), "", nil, &p); err != nil {
+		return Event{}, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+	amount, err := money.Parse(p.Amount.Value, p.Amount.Currency)
+	if err != nil {
+		return Event{}, ErrInvalidEvent
+	}
+	status := p.Status
+	if status == "waiting_for_capture" {
+		status = StatusPending
+	}
+	return Event{ExternalID: p.ID, Status: status, Amount: amount, Currency: p.Amount.Currency}, nil
+}
+
+// call выполняет запрос к API; key — Idempotence-Key для POST
+func (y *YooKassa) call(ctx context.Context, method, path, key string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, y.base+path, body)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth(y.shopID, y.secretKey)
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if key != "" {
+		req.Header.Set("Idempotence-Key", key)
+	}
+	resp, err := y.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("yookassa: %s %s: %w", method, path, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("yookassa: %s %s: status %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("yookassa: %s %s: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+// Package phone приводит номера телефонов к формату E.164 (+79991234567).
+package phone
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrEmpty   = errors.New("phone is empty")
+	ErrInvalid = errors.New("invalid phone number")
+)
+
+// region описывает правила национальной записи номера для страны.
+type region struct {
+	countryCode string
+	trunkPrefix string
+	nationalLen []int
+}
+
+// regions — страны, номера которых можно вводить в национальном формате.
+// Номера остальных стран принимаются только с кодом страны (+ или 00).
+var regions = map[string]region{
+	"RU": {countryCode: "7", trunkPrefix: "8", nationalLen: []int{10}},
+	"KZ": {countryCode: "7", trunkPrefix: "8", nationalLen: []int{10}},
+	"BY": {countryCode: "375", trunkPrefix: "80", nationalLen: []int{9}},
+	"UA": {countryCode: "380", trunkPrefix: "0", nationalLen: []int{9}},
+	"UZ": {countryCode: "998", nationalLen: []int{9}},
+	"AM": {countryCode: "374", trunkPrefix: "0", nationalLen: []int{8}},
+	"GE": {countryCode: "995", trunkPrefix: "0", nationalLen: []int{9}},
+	"US": {countryCode: "1", trunkPrefix: "1", nationalLen: []int{10}},
+	"GB": {countryCode: "44", trunkPrefix: "0", nationalLen: []int{9, 10}},
+}
+
+// DefaultRegion — регион по умолчанию для номеров без кода страны (phone.default_region).
+const DefaultRegion = "RU"
+
+// SupportedRegion сообщает, умеет ли пакет разбирать национальный формат региона.
+func SupportedRegion(region string) bool {
+	_, ok := regions[region]
+	return ok
+}
+
+// NormalizeRegion приводит номер к E.164: "+7 (999) 123-45-67", "89991234567"
+// и "79991234567" для региона RU дают один и тот же "+79991234567".
+func NormalizeRegion(raw string, regionCode string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", ErrEmpty
+	}
+
+	international := strings.HasPrefix(raw, "+")
+	var b strings.Builder
+	for i, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", ErrInvalid
+		}
+	}
+	digits := b.String()
+
+	if !international && strings.HasPrefix(digits, "00") {
+		digits = digits[2:]
+		international = true
+	}
+	if !international {
+		if reg, ok := regions[regionCode]; ok {
+			digits = toInternational(digits, reg)
+		}
+	}
+
+	if err := validate(digits); err != nil {
+		return "", err
+	}
+	return "+" + digits, nil
+}
+
+// toInternational дописывает код страны к номеру, записанному в национальном формате.
+func toInternational(digits string, reg region) string {
+	if reg.trunkPrefix != "" && strings.HasPrefix(digits, reg.trunkPrefix) &&
+		hasLen(reg, len(digits)-len(reg.trunkPrefix)) {
+		return reg.countryCode + digits[len(reg.trunkPrefix):]
+	}
+	if strings.HasPrefix(digits, reg.countryCode) && hasLen(reg, len(digits)-len(reg.countryCode)) {
+		return digits
+	}
+	if hasLen(reg, len(digits)) {
+		return reg.countryCode + digits
+	}
+	return digits
+}
+
+// validate проверяет длину по E.164 и, для известных кодов стран, длину национальной части.
+func validate(digits string) error {
+	if len(digits) < 8 || len(digits) > 15 || digits[0] == '0' {
+		return ErrInvalid
+	}
+	for _, reg := range regions {
+		if strings.HasPrefix(digits, reg.countryCode) {
+			if !hasLen(reg, len(digits)-len(reg.countryCode)) {
+				return ErrInvalid
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func hasLen(reg region, n int) bool {
+	for _, l := range reg.nationalLen {
+		if l == n {
+			return true
+		}
+	}
+	return false
+}
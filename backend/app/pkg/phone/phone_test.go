@@ -0,0 +1,42 @@
+package phone
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		region  string
+		want    string
+		wantErr error
+	}{
+		{name: "ru e164", raw: "+79991234567", region: "RU", want: "+79991234567"},
+		{name: "ru trunk prefix", raw: "89991234567", region: "RU", want: "+79991234567"},
+		{name: "ru without plus", raw: "79991234567", region: "RU", want: "+79991234567"},
+		{name: "ru national", raw: "9991234567", region: "RU", want: "+79991234567"},
+		{name: "ru formatted", raw: "+7 (999) 123-45-67", region: "RU", want: "+79991234567"},
+		{name: "international prefix", raw: "0079991234567", region: "RU", want: "+79991234567"},
+		{name: "by trunk prefix", raw: "80291234567", region: "BY", want: "+375291234567"},
+		{name: "foreign number keeps country", raw: "+375291234567", region: "RU", want: "+375291234567"},
+		{name: "unknown region needs code", raw: "+4915112345678", region: "DE", want: "+4915112345678"},
+		{name: "ru too short", raw: "+7999123456", region: "RU", wantErr: ErrInvalid},
+		{name: "letters", raw: "+7999abc4567", region: "RU", wantErr: ErrInvalid},
+		{name: "plus in the middle", raw: "7999+1234567", region: "RU", wantErr: ErrInvalid},
+		{name: "empty", raw: "  ", region: "RU", wantErr: ErrEmpty},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeRegion(tt.raw, tt.region)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NormalizeRegion(%q) error = %v, want %v", tt.raw, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeRegion(%q) = %q, want %q", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
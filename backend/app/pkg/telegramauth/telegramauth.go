@@ -0,0 +1,140 @@
+// Package telegramauth проверяет подпись данных, которые Telegram передаёт
+// сайту через Login Widget и мини-приложению через Web App initData.
+package telegramauth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxAge — максимальный возраст auth_date, после которого данные считаются устаревшими.
+const DefaultMaxAge = 24 * time.Hour
+
+var (
+	ErrMissingBotToken = errors.New("telegram bot token is not configured")
+	ErrMissingHash     = errors.New("hash is missing")
+	ErrInvalidHash     = errors.New("hash mismatch")
+	ErrMissingAuthDate = errors.New("auth_date is missing")
+	ErrExpired         = errors.New("auth_date is too old")
+	ErrMissingUser     = errors.New("user is missing")
+)
+
+// User — данные пользователя Telegram, прошедшие проверку подписи.
+type User struct {
+	ID           int64  `json:"id"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Username     string `json:"username"`
+	PhotoURL     string `json:"photo_url"`
+	LanguageCode string `json:"language_code"`
+}
+
+// VerifyLoginWidget проверяет поля Login Widget (id, first_name, ..., auth_date, hash).
+// Ключ подписи — SHA256(botToken).
+func VerifyLoginWidget(fields map[string]string, botToken string, maxAge time.Duration, now time.Time) (*User, error) {
+	if botToken == "" {
+		return nil, ErrMissingBotToken
+	}
+	secret := sha256.Sum256([]byte(botToken))
+	if err := verify(fields, secret[:], maxAge, now); err != nil {
+		return nil, err
+	}
+
+	id, err := strconv.ParseInt(fields["id"], 10, 64)
+	if err != nil || id <= 0 {
+		return nil, ErrMissingUser
+	}
+	return &User{
+		ID:        id,
+		FirstName: fields["first_name"],
+		LastName:  fields["last_name"],
+		Username:  fields["username"],
+		PhotoURL:  fields["photo_url"],
+	}, nil
+}
+
+// VerifyInitData проверяет строку initData мини-приложения (Telegram.WebApp.initData).
+// Ключ подписи — HMAC_SHA256("WebAppData", botToken).
+func VerifyInitData(initData string, botToken string, maxAge time.Duration, now time.Time) (*User, error) {
+	if botToken == "" {
+		return nil, ErrMissingBotToken
+	}
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid init data: %w", err)
+	}
+	fields := make(map[string]string, len(values))
+	for k := range values {
+		fields[k] = values.Get(k)
+	}
+
+	mac := hmac.New(sha256.New, []byte("WebAppData"))
+	mac.Write([]byte(botToken))
+	if err := verify(fields, mac.Sum(nil), maxAge, now); err != nil {
+		return nil, err
+	}
+
+	raw, ok := fields["user"]
+	if !ok {
+		return nil, ErrMissingUser
+	}
+	var user User
+	if err := json.Unmarshal([]byte(raw), &user); err != nil || user.ID <= 0 {
+		return nil, ErrMissingUser
+	}
+	return &user, nil
+}
+
+// verify сверяет hash с HMAC-SHA256 от data-check-string и проверяет свежесть auth_date.
+func verify(fields map[string]string, secret []byte, maxAge time.Duration, now time.Time) error {
+	hash := fields["hash"]
+	if hash == "" {
+		return ErrMissingHash
+	}
+	expected, err := hex.DecodeString(hash)
+	if err != nil {
+		return ErrInvalidHash
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(DataCheckString(fields)))
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return ErrInvalidHash
+	}
+
+	unix, err := strconv.ParseInt(fields["auth_date"], 10, 64)
+	if err != nil {
+		return ErrMissingAuthDate
+	}
+	if maxAge > 0 && now.Sub(time.Unix(unix, 0)) > maxAge {
+		return ErrExpired
+	}
+	return nil
+}
+
+// DataCheckString собирает строку key=value, отсортированную по ключу и
+// разделённую "\n", исключая поле hash.
+func DataCheckString(fields map[string]string) string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		if k == "hash" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+fields[k])
+	}
+	return strings.Join(pairs, "\n")
+}
@@ -0,0 +1,83 @@
+// Package timefmt форматирует время записей в уведомлениях: в таймзоне получателя,
+// а если у мастера другое смещение — дополнительно во времени мастера.
+package timefmt
+
+import (
+	"app/pkg/locale"
+	"fmt"
+	"time"
+	_ "time/tzdata" // таймзоны доступны и в образе без /usr/share/zoneinfo
+)
+
+const (
+	// DefaultZone — таймзона пользователей, которые свою не выбрали
+	DefaultZone = "Europe/Moscow"
+
+	dateTimeLayout = "02.01.2006 15:04"
+	clockLayout    = "15:04"
+)
+
+var defaultLocation = func() *time.Location {
+	if loc, err := time.LoadLocation(DefaultZone); err == nil {
+		return loc
+	}
+	return time.FixedZone(DefaultZone, 3*3600)
+}()
+
+// Valid сообщает, что name — известная таймзона IANA.
+// "Local" не принимается: на разных серверах она означает разное.
+func Valid(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// Location возвращает первую известную таймзону из names.
+// Если ни одна не подошла — fallback, а без него DefaultZone.
+func Location(fallback *time.Location, names ...string) *time.Location {
+	for _, name := range names {
+		if !Valid(name) {
+			continue
+		}
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return defaultLocation
+}
+
+// masterTime — подпись времени мастера в Span
+var masterTime = locale.Text{RU: ", у мастера ", EN: ", master's time "}
+
+// Span форматирует интервал для получателя: "10.03.2030 12:00 - 13:30 (Asia/Omsk)".
+// Если смещение мастера в начале интервала другое, добавляет его время:
+// ", у мастера 10.03.2030 11:00 - 12:30 (Asia/Yekaterinburg)".
+func Span(start, end time.Time, recipient, master *time.Location) string {
+	return SpanIn(locale.RU, start, end, recipient, master)
+}
+
+// SpanIn — Span с подписью времени мастера на языке получателя lang
+func SpanIn(lang string, start, end time.Time, recipient, master *time.Location) string {
+	text := span(start, end, recipient)
+	if master == nil {
+		return text
+	}
+	_, recipientOffset := start.In(recipient).Zone()
+	_, masterOffset := start.In(master).Zone()
+	if recipientOffset != masterOffset {
+		text += masterTime.In(lang) + span(start, end, master)
+	}
+	return text
+}
+
+func span(start, end time.Time, loc *time.Location) string {
+	if end.IsZero() {
+		return fmt.Sprintf("%s (%s)", start.In(loc).Format(dateTimeLayout), loc)
+	}
+	return fmt.Sprintf("%s - %s (%s)", start.In(loc).Format(dateTimeLayout), end.In(loc).Format(clockLayout), loc)
+}
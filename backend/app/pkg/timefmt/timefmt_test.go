@@ -0,0 +1,47 @@
+package timefmt
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLocation(t *testing.T) {
+	fallback := time.FixedZone("fallback", 0)
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{names: []string{"Asia/Omsk", "Europe/Samara"}, want: "Asia/Omsk"},
+		{names: []string{"", "Europe/Samara"}, want: "Europe/Samara"},
+		{names: []string{"Mars/Olympus", "Local"}, want: "fallback"},
+	}
+	for _, tt := range tests {
+		if got := Location(fallback, tt.names...).String(); got != tt.want {
+			t.Errorf("Location(%q) = %s, want %s", tt.names, got, tt.want)
+		}
+	}
+	if got := Location(nil).String(); got != DefaultZone {
+		t.Errorf("Location(nil) = %s, want %s", got, DefaultZone)
+	}
+}
+
+func TestSpan(t *testing.T) {
+	start := time.Date(2030, 3, 10, 6, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	omsk := Location(nil, "Asia/Omsk")
+	yekb := Location(nil, "Asia/Yekaterinburg")
+
+	want := "10.03.2030 12:00 - 13:30 (Asia/Omsk), у мастера 10.03.2030 11:00 - 12:30 (Asia/Yekaterinburg)"
+	if got := Span(start, end, omsk, yekb); got != want {
+		t.Errorf("Span() = %q, want %q", got, want)
+	}
+	if got, want := SpanIn("en", start, end, omsk, yekb), "10.03.2030 12:00 - 13:30 (Asia/Omsk), master's time 10.03.2030 11:00 - 12:30 (Asia/Yekaterinburg)"; got != want {
+		t.Errorf("SpanIn(en) = %q, want %q", got, want)
+	}
+	if got, want := Span(start, end, omsk, omsk), "10.03.2030 12:00 - 13:30 (Asia/Omsk)"; got != want {
+		t.Errorf("Span() same zone = %q, want %q", got, want)
+	}
+	if got, want := Span(start, time.Time{}, yekb, nil), "10.03.2030 11:00 (Asia/Yekaterinburg)"; got != want {
+		t.Errorf("Span() without end = %q, want %q", got, want)
+	}
+}
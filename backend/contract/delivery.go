@@ -0,0 +1,9 @@
+package contract
+
+// DeliveryReport — итог отправки уведомления ботом (POST /telegram/delivery/{id})
+type DeliveryReport struct {
+	Status    string `json:"status"`
+	Attempts  int    `json:"attempts"`
+	MessageID int    `json:"message_id"`
+	Error     string `json:"error,omitempty"`
+}
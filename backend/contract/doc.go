@@ -0,0 +1,8 @@
+// Package contract описывает модели запросов и ответов HTTP API,
+// которыми обмениваются сервис app и Telegram-бот.
+//
+// Модуль подключается в оба сервиса через replace (../contract), поэтому
+// изменение формата ответа видно компилятору с обеих сторон. Здесь только
+// формы JSON: без тегов gorm и без бизнес-логики. Общие правила записи денег
+// (минимальные единицы, валюты, формат для людей) — в подпакете money.
+package contract
@@ -0,0 +1,6 @@
+package contract
+
+// ErrorResponse — тело ответа API с кодом, отличным от 2xx
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
@@ -0,0 +1,5 @@
+module contract
+
+go 1.24.3
+
+require github.com/google/uuid v1.6.0
@@ -0,0 +1,34 @@
+package contract
+
+// WorkingHours — интервал работы места в день недели Weekday (0 — воскресенье … 6 — суббота),
+// Opens и Closes — "ЧЧ:ММ" в таймзоне места
+type WorkingHours struct {
+	Weekday int    `json:"weekday"`
+	Opens   string `json:"opens"`
+	Closes  string `json:"closes"`
+}
+
+// LocationRequest — создание и изменение места организации
+// (POST /organization/{id}/locations, PUT /organization/{id}/locations/{location_id});
+// координаты задаются парой или не задаются вовсе
+type LocationRequest struct {
+	Name         string         `json:"name"`
+	Address      string         `json:"address"`
+	Latitude     *float64       `json:"latitude,omitempty"`
+	Longitude    *float64       `json:"longitude,omitempty"`
+	Timezone     string         `json:"timezone"`
+	WorkingHours []WorkingHours `json:"working_hours"`
+}
+
+// ServiceLocation — место услуги (PUT /organization/{id}/services/{service_id}/location); 0 — без места
+type ServiceLocation struct {
+	LocationID uint `json:"location_id"`
+}
+
+// Venue — место записи, которое бот отправляет клиенту картой после подтверждения
+type Venue struct {
+	Title     string  `json:"title"`
+	Address   string  `json:"address"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
@@ -0,0 +1,49 @@
+package contract
+
+import "github.com/google/uuid"
+
+// MasterCard — мастер в поиске inline-режима бота: услуги и ближайшие свободные слоты
+type MasterCard struct {
+	TelegramID int64  `json:"telegram_id"`
+	FirstName  string `json:"first_name"`
+	Surname    string `json:"surname"`
+	Timezone   string `json:"timezone"`
+
+	Services  []Service      `json:"services"`
+	NextSlots []SlotResponse `json:"next_slots"`
+}
+
+// MasterSearch — ответ GET /telegram/master/search
+type MasterSearch struct {
+	Message string       `json:"message"`
+	Data    []MasterCard `json:"data"`
+}
+
+// DirectoryMaster — мастер в публичном каталоге: только подходящие под фильтры услуги
+// и их ближайшие свободные слоты. Telegram ID и телефон в каталог не попадают.
+type DirectoryMaster struct {
+	ID          uuid.UUID `json:"id"`
+	FirstName   string    `json:"first_name"`
+	Surname     string    `json:"surname"`
+	Timezone    string    `json:"timezone"`
+	Rating      float64   `json:"rating"`
+	RatingCount int       `json:"rating_count"`
+	// DistanceKm — до ближайшего места услуг; только при поиске рядом с точкой
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+
+	Services  []Service      `json:"services"`
+	NextSlots []SlotResponse `json:"next_slots"`
+}
+
+// DirectorySearch — ответ GET /directory/masters
+type DirectorySearch struct {
+	Message string            `json:"message"`
+	Data    []DirectoryMaster `json:"data"`
+	Limit   int               `json:"limit"`
+	Offset  int               `json:"offset"`
+}
+
+// DirectoryOptOut — отказ мастера от показа в каталоге (PUT /user/directory)
+type DirectoryOptOut struct {
+	OptOut bool `json:"opt_out"`
+}
@@ -0,0 +1,159 @@
+// Package money — суммы в минимальных единицах валюты (копейки, центы) и их запись для людей.
+//
+// Пакет общий для app и бота: обе стороны должны одинаково переводить
+// «1500,50» в 150050 и обратно, иначе цена исказится при передаче.
+package money
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Default — валюта мастеров и услуг, для которых она не выбрана
+const Default = "RUB"
+
+// nbsp — неразрывный пробел между тройками цифр и перед знаком валюты
+const nbsp = "\u00a0"
+
+// ErrInvalidAmount — строку нельзя прочитать как неотрицательную сумму в этой валюте
+var ErrInvalidAmount = errors.New("invalid amount")
+
+type currency struct {
+	// exponent — знаков после запятой: 100 копеек в рубле — 2, у иены дробной части нет — 0
+	exponent int
+	symbol   string
+}
+
+// currencies — поддерживаемые валюты ISO 4217
+var currencies = map[string]currency{
+	"RUB": {2, "₽"},
+	"BYN": {2, "Br"},
+	"KZT": {2, "₸"},
+	"UAH": {2, "₴"},
+	"AMD": {2, "֏"},
+	"GEL": {2, "₾"},
+	"KGS": {2, "сом"},
+	"UZS": {2, "сўм"},
+	"TRY": {2, "₺"},
+	"USD": {2, "$"},
+	"EUR": {2, "€"},
+	"GBP": {2, "£"},
+	"JPY": {0, "¥"},
+}
+
+// Known — code есть среди поддерживаемых валют
+func Known(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+// Normalize приводит код к верхнему регистру; пустой код — Default
+func Normalize(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return Default
+	}
+	return code
+}
+
+func lookup(code string) currency {
+	if c, ok := currencies[code]; ok {
+		return c
+	}
+	return currency{exponent: 2, symbol: code}
+}
+
+// Format записывает сумму amount в минимальных единицах для языка lang:
+// "ru" (и неизвестные) — «1 500,50 ₽», "en" — «₽1,500.50». Нулевые копейки не пишутся.
+// Разделители — неразрывные пробелы, чтобы сумма не переносилась по строкам
+func Format(amount int64, code, lang string) string {
+	c := lookup(code)
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	group, point := nbsp, ","
+	if lang == "en" {
+		group, point = ",", "."
+	}
+	unit := int64(1)
+	for range c.exponent {
+		unit *= 10
+	}
+	number := grouped(strconv.FormatInt(amount/unit, 10), group)
+	if frac := amount % unit; frac != 0 {
+		number += point + strconv.FormatInt(unit+frac, 10)[1:]
+	}
+	if lang == "en" {
+		if utf8.RuneCountInString(c.symbol) == 1 {
+			return sign + c.symbol + number
+		}
+		return sign + c.symbol + nbsp + number
+	}
+	return sign + number + nbsp + c.symbol
+}
+
+// Decimal записывает сумму для платёжных API: точка и ровно столько знаков, сколько у валюты —
+// 150050 RUB — «1500.50», 1500 JPY — «1500»
+func Decimal(amount int64, code string) string {
+	c := lookup(code)
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	if c.exponent == 0 {
+		return sign + digits
+	}
+	if pad := c.exponent + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	cut := len(digits) - c.exponent
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+// grouped разбивает целую часть на тройки цифр
+func grouped(digits, sep string) string {
+	var b strings.Builder
+	for i, d := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteString(sep)
+		}
+		b.WriteRune(d)
+	}
+	return b.String()
+}
+
+// Parse читает сумму, введённую человеком, в минимальных единицах валюты code:
+// "1500", "1 500", "1500,5", "1500.50 ₽", "1500 руб." — 150000 и 150050 копеек.
+// Знаков после запятой не больше, чем у валюты
+func Parse(text, code string) (int64, error) {
+	c := lookup(code)
+	raw := strings.TrimSpace(text)
+	for _, suffix := range []string{c.symbol, code, strings.ToLower(code), "руб.", "руб"} {
+		raw = strings.TrimSpace(strings.TrimSuffix(raw, suffix))
+	}
+	raw = strings.TrimSpace(strings.TrimPrefix(raw, c.symbol))
+	raw = strings.NewReplacer(" ", "", nbsp, "", "\u202f", "", ",", ".").Replace(raw)
+	whole, frac, hasFrac := strings.Cut(raw, ".")
+	if whole == "" || len(frac) > c.exponent || (hasFrac && frac == "") || !digitsOnly(whole) || !digitsOnly(frac) {
+		return 0, ErrInvalidAmount
+	}
+	frac += strings.Repeat("0", c.exponent-len(frac))
+	amount, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return amount, nil
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
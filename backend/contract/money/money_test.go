@@ -0,0 +1,71 @@
+package money
+
+import "testing"
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		amount int64
+		code   string
+		lang   string
+		want   string
+	}{
+		{150000, "RUB", "ru", "1\u00a0500\u00a0₽"},
+		{150050, "RUB", "ru", "1\u00a0500,50\u00a0₽"},
+		{5, "RUB", "", "0,05\u00a0₽"},
+		{123456789, "USD", "en", "$1,234,567.89"},
+		{2000, "BYN", "en", "Br\u00a020"},
+		{1500, "JPY", "ru", "1\u00a0500\u00a0¥"},
+		{-100, "EUR", "en", "-€1"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.amount, tt.code, tt.lang); got != tt.want {
+			t.Errorf("Format(%d, %s, %q) = %q, want %q", tt.amount, tt.code, tt.lang, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		text    string
+		code    string
+		want    int64
+		wantErr bool
+	}{
+		{text: "1500", code: "RUB", want: 150000},
+		{text: "1 500,5", code: "RUB", want: 150050},
+		{text: "1500.50 ₽", code: "RUB", want: 150050},
+		{text: "1500 руб.", code: "RUB", want: 150000},
+		{text: "$19.99", code: "USD", want: 1999},
+		{text: "1500", code: "JPY", want: 1500},
+		{text: "15.5", code: "JPY", wantErr: true},
+		{text: "1,505", code: "RUB", wantErr: true},
+		{text: "-10", code: "RUB", wantErr: true},
+		{text: "10.", code: "RUB", wantErr: true},
+		{text: "цена", code: "RUB", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.text, tt.code)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("Parse(%q, %s) = %d, %v, want %d, error %v", tt.text, tt.code, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestDecimal(t *testing.T) {
+	tests := []struct {
+		amount int64
+		code   string
+		want   string
+	}{
+		{150000, "RUB", "1500.00"},
+		{150050, "RUB", "1500.50"},
+		{5, "USD", "0.05"},
+		{1500, "JPY", "1500"},
+		{-250, "EUR", "-2.50"},
+	}
+	for _, tt := range tests {
+		if got := Decimal(tt.amount, tt.code); got != tt.want {
+			t.Errorf("Decimal(%d, %s) = %q, want %q", tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package contract
+
+import "github.com/google/uuid"
+
+// OrganizationCreate — создание организации (POST /organization) и переименование (PUT /organization/{id})
+type OrganizationCreate struct {
+	Name string `json:"name"`
+}
+
+// OrganizationMemberAdd — добавление участника по телефону или telegram_id (POST /organization/{id}/members)
+type OrganizationMemberAdd struct {
+	Phone      string `json:"phone"`
+	TelegramID int64  `json:"telegram_id"`
+	Role       string `json:"role"`
+}
+
+// OrganizationMemberRole — смена роли участника (PUT /organization/{id}/members/{user_id})
+type OrganizationMemberRole struct {
+	Role string `json:"role"`
+}
+
+// OrganizationService — услуга организации, назначенная мастеру (POST /organization/{id}/services)
+type OrganizationService struct {
+	MasterID uuid.UUID `json:"master_id"`
+	Name     string    `json:"name"`
+	Price    int64     `json:"price"`
+	// Currency — ISO 4217; пустая — валюта мастера
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	Duration    int    `json:"duration"`
+}
+
+// OrganizationServiceAssign — передача услуги другому мастеру (PUT /organization/{id}/services/{service_id}/master)
+type OrganizationServiceAssign struct {
+	MasterID uuid.UUID `json:"master_id"`
+}
+
+// OrganizationRecordStatus — решение управляющего по записи за мастера
+// (POST /organization/{id}/records/{record_id}/status): confirm или reject
+type OrganizationRecordStatus struct {
+	Status string `json:"status"`
+}
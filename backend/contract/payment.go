@@ -0,0 +1,19 @@
+package contract
+
+import "time"
+
+// Payment — предоплата записи через платёжного провайдера.
+// Status: pending — ждём оплату по ConfirmationURL, succeeded, canceled, refunded
+type Payment struct {
+	ID uint `json:"id"`
+	// RecordID — пусто, если запись уже удалена
+	RecordID *uint  `json:"record_id,omitempty"`
+	Provider string `json:"provider"`
+	// Amount — в минимальных единицах Currency
+	Amount          int64      `json:"amount"`
+	Currency        string     `json:"currency"`
+	Status          string     `json:"status"`
+	ConfirmationURL string     `json:"confirmation_url,omitempty"`
+	ExpiresAt       time.Time  `json:"expires_at"`
+	PaidAt          *time.Time `json:"paid_at,omitempty"`
+}
@@ -0,0 +1,89 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Record — запись клиента на слот
+type Record struct {
+	ID        uint      `json:"id"`
+	SlotID    uint      `json:"slot_id"`
+	ClientID  uuid.UUID `json:"client_id"`
+	Status    string    `json:"status"`
+	Comment   string    `json:"comment"`
+	CreatedAt time.Time `json:"created_at"`
+	// VariantID и AddOnIDs — выбор клиента при записи; без варианта действует базовая цена услуги
+	VariantID *uint  `json:"variant_id,omitempty"`
+	AddOnIDs  []uint `json:"add_on_ids,omitempty"`
+	// VariantName, Price, Currency, Duration и AddOns — снимок на момент записи;
+	// Price — в минимальных единицах Currency
+	VariantName string        `json:"variant_name,omitempty"`
+	Price       int64         `json:"price"`
+	Currency    string        `json:"currency"`
+	Duration    int           `json:"duration"`
+	AddOns      []RecordAddOn `json:"add_ons,omitempty"`
+	// HoldUntil — до какого времени слот держится за записью в статусе awaiting_payment;
+	// Payment — предоплата записи, если услуга её требует
+	HoldUntil *time.Time `json:"hold_until,omitempty"`
+	Payment   *Payment   `json:"payment,omitempty"`
+
+	Slot   Slot `json:"slot"`
+	Client User `json:"client"`
+}
+
+// RecordAddOn — опция, выбранная при записи, с ценой и длительностью на тот момент
+type RecordAddOn struct {
+	AddOnID  *uint  `json:"add_on_id,omitempty"`
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Duration int    `json:"duration"`
+}
+
+// RecordFilter — запрос записей клиента по статусу (POST /record/user/filter)
+type RecordFilter struct {
+	UserID string `json:"user_id"`
+	Status string `json:"status"`
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+}
+
+// RecordPage — страница записей клиента
+type RecordPage struct {
+	Message string   `json:"message"`
+	Total   int      `json:"total"`
+	Page    int      `json:"page"`
+	Limit   int      `json:"limit"`
+	HasNext bool     `json:"has_next"`
+	HasPrev bool     `json:"has_prev"`
+	Records []Record `json:"records"`
+}
+
+// RecordStatusUpdate — смена статуса записи (POST /telegram/record/master/status)
+type RecordStatusUpdate struct {
+	RecordID uint   `json:"record_id"`
+	Status   string `json:"status"`
+}
+
+// CreatedRecord — ответ POST /telegram/record/master/create; у записи, ждущей оплаты, заполнен Payment
+type CreatedRecord struct {
+	Message string `json:"message"`
+	Data    Record `json:"data"`
+}
+
+// UpcomingRecords — ответ GET /telegram/record/master/upcoming/{telegram_id}
+type UpcomingRecords struct {
+	Message string   `json:"message"`
+	Data    []Record `json:"data"`
+}
+
+// RecordReschedule — перенос записи клиентом на другой слот (POST /record/client/{record_id}/reschedule)
+type RecordReschedule struct {
+	SlotID uint `json:"slot_id"`
+}
+
+// RecordComment — комментарий клиента к записи для мастера (POST /record/client/{record_id}/comment)
+type RecordComment struct {
+	Comment string `json:"comment"`
+}
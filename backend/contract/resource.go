@@ -0,0 +1,33 @@
+package contract
+
+import "github.com/google/uuid"
+
+// ResourceRequest — создание и изменение ресурса организации
+// (POST /organization/{id}/resources, PUT /organization/{id}/resources/{resource_id});
+// Active == nil — ресурс доступен (при создании) или не меняется (при изменении)
+type ResourceRequest struct {
+	Name     string `json:"name"`
+	Quantity int    `json:"quantity"`
+	Active   *bool  `json:"active,omitempty"`
+}
+
+// ServiceResources — ресурсы, которые занимает услуга (PUT /organization/{id}/services/{service_id}/resources)
+type ServiceResources struct {
+	ResourceIDs []uint `json:"resource_ids"`
+}
+
+// ResourceUtilization — загрузка ресурса подтверждёнными записями за период.
+// CapacityMinutes = Quantity × длина периода; Utilization = BookedMinutes / CapacityMinutes;
+// Peak — наибольшее число одновременно занятых единиц
+type ResourceUtilization struct {
+	ResourceID      uint      `json:"resource_id"`
+	OrganizationID  uuid.UUID `json:"organization_id"`
+	Name            string    `json:"name"`
+	Quantity        int       `json:"quantity"`
+	Active          bool      `json:"active"`
+	BookedSlots     int       `json:"booked_slots"`
+	BookedMinutes   int       `json:"booked_minutes"`
+	CapacityMinutes int       `json:"capacity_minutes"`
+	Utilization     float64   `json:"utilization"`
+	Peak            int       `json:"peak"`
+}
@@ -0,0 +1,59 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReviewRequest — оценка 1–5 и необязательный текст отзыва клиента о записи
+// (POST и PUT /review/record/{record_id})
+type ReviewRequest struct {
+	Rating int    `json:"rating"`
+	Text   string `json:"text"`
+}
+
+// ReviewReply — публичный ответ мастера на отзыв (PUT /review/{id}/reply); пустой текст удаляет ответ
+type ReviewReply struct {
+	Text string `json:"text"`
+}
+
+// ReviewModeration — решение администратора по отзыву (PUT /admin/reviews/{id}); nil — не менять
+type ReviewModeration struct {
+	Hidden  *bool `json:"hidden,omitempty"`
+	Flagged *bool `json:"flagged,omitempty"`
+}
+
+// Review — отзыв клиента. В публичной выдаче клиент показан только по имени,
+// Hidden и Flagged заполняются только для администратора
+type Review struct {
+	ID          uint       `json:"id"`
+	MasterID    uuid.UUID  `json:"master_id"`
+	ServiceID   *uint      `json:"service_id,omitempty"`
+	ServiceName string     `json:"service_name,omitempty"`
+	ClientName  string     `json:"client_name"`
+	Rating      int        `json:"rating"`
+	Text        string     `json:"text"`
+	Reply       string     `json:"reply,omitempty"`
+	RepliedAt   *time.Time `json:"replied_at,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+
+	RecordID *uint `json:"record_id,omitempty"`
+	Hidden   bool  `json:"hidden,omitempty"`
+	Flagged  bool  `json:"flagged,omitempty"`
+}
+
+// RatingSummary — средняя оценка (0, если отзывов нет) и число видимых отзывов
+type RatingSummary struct {
+	Rating float64 `json:"rating"`
+	Count  int     `json:"count"`
+}
+
+// ReviewList — страница отзывов о мастере или услуге вместе с их рейтингом
+type ReviewList struct {
+	Message string        `json:"message"`
+	Summary RatingSummary `json:"summary"`
+	Data    []Review      `json:"data"`
+	Limit   int           `json:"limit"`
+	Offset  int           `json:"offset"`
+}
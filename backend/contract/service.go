@@ -0,0 +1,92 @@
+package contract
+
+import "github.com/google/uuid"
+
+// Service — услуга мастера
+type Service struct {
+	ID       uint      `json:"id"`
+	MasterID uuid.UUID `json:"master_id"`
+	Name     string    `json:"name"`
+	// Price — цена в минимальных единицах валюты Currency (копейки, центы), см. пакет money
+	Price       int64  `json:"price"`
+	Currency    string `json:"currency"`
+	Description string `json:"description"`
+	Duration    int    `json:"duration"`
+	// OrganizationID — организация, которой принадлежит услуга (личная у мастера-одиночки)
+	OrganizationID *uuid.UUID `json:"organization_id,omitempty"`
+	// Rating и RatingCount — средняя оценка и число видимых отзывов об услуге
+	Rating      float64 `json:"rating"`
+	RatingCount int     `json:"rating_count"`
+	// CategoryID — категория мастера, в которой показывается услуга
+	CategoryID *uint `json:"category_id,omitempty"`
+	// Variants — варианты услуги со своей ценой и длительностью; без них действуют Price и Duration
+	Variants []ServiceVariant `json:"variants,omitempty"`
+	// AddOns — дополнительные опции, добавляют к цене и длительности
+	AddOns []ServiceAddOn `json:"add_ons,omitempty"`
+	// Prepayment — что клиент оплачивает при записи: "" — ничего, deposit — задаток Deposit, full — всю цену.
+	// Deposit — в минимальных единицах Currency
+	Prepayment string `json:"prepayment,omitempty"`
+	Deposit    int64  `json:"deposit,omitempty"`
+}
+
+// ServiceCategory — категория услуг мастера
+type ServiceCategory struct {
+	ID       uint      `json:"id"`
+	MasterID uuid.UUID `json:"master_id"`
+	Name     string    `json:"name"`
+	Position int       `json:"position"`
+}
+
+// ServiceVariant — вариант услуги, например «короткие волосы»; цена в валюте услуги
+type ServiceVariant struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Duration int    `json:"duration"`
+	Position int    `json:"position"`
+}
+
+// ServiceAddOn — дополнительная опция услуги; Duration — сколько минут она добавляет, цена в валюте услуги
+type ServiceAddOn struct {
+	ID       uint   `json:"id"`
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Duration int    `json:"duration"`
+	Position int    `json:"position"`
+}
+
+// ServiceCategoryRequest — создание и изменение категории (POST /service/categories, PUT /service/categories/{id})
+type ServiceCategoryRequest struct {
+	Name     string `json:"name"`
+	Position int    `json:"position"`
+}
+
+// ServiceOptionRequest — создание и изменение варианта или опции услуги
+// (POST /service/{id}/variants, POST /service/{id}/add-ons и PUT по ID)
+type ServiceOptionRequest struct {
+	Name     string `json:"name"`
+	Price    int64  `json:"price"`
+	Duration int    `json:"duration"`
+	Position int    `json:"position"`
+}
+
+// ServiceCategoryAssign — перенос услуги в категорию (PUT /service/{id}/category); nil — без категории
+type ServiceCategoryAssign struct {
+	CategoryID *uint `json:"category_id"`
+}
+
+// ServiceResponse — услуга вместе с данными мастера
+type ServiceResponse struct {
+	ID          uint      `json:"id"`
+	MasterID    uuid.UUID `json:"master_id"`
+	Name        string    `json:"name"`
+	Price       int64     `json:"price"`
+	Currency    string    `json:"currency"`
+	Description string    `json:"description"`
+	Duration    int       `json:"duration"`
+
+	MasterTelegramID int64  `json:"master_telegram_id"`
+	MasterName       string `json:"master_name"`
+	MasterSurname    string `json:"master_surname"`
+	MasterPhone      string `json:"master_phone"`
+}
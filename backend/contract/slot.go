@@ -0,0 +1,50 @@
+package contract
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Slot — слот мастера с вложенными услугой и мастером
+type Slot struct {
+	ID        uint      `json:"id"`
+	MasterID  uuid.UUID `json:"master_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	IsBooked  bool      `json:"is_booked"`
+	ServiceID uint      `json:"service_id"`
+
+	Service Service `json:"service"`
+	Master  User    `json:"master"`
+}
+
+// SlotResponse — слот в плоском виде (GET /slot/one/{id}, GET /slot/{user_id})
+type SlotResponse struct {
+	ID        uint      `json:"id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	IsBooked  bool      `json:"is_booked"`
+
+	ServiceID          uint   `json:"service_id"`
+	ServiceName        string `json:"service_name"`
+	ServiceDescription string `json:"service_description"`
+	// ServicePrice — в минимальных единицах ServiceCurrency
+	ServicePrice    int64  `json:"service_price"`
+	ServiceCurrency string `json:"service_currency"`
+	ServiceDuration int    `json:"service_duration"`
+
+	MasterTelegramID int64  `json:"master_telegram_id"`
+	MasterName       string `json:"master_name"`
+	MasterSurname    string `json:"master_surname"`
+	MasterPhone      string `json:"master_phone"`
+	MasterTimezone   string `json:"master_timezone"`
+}
+
+// CreateSlot — создание слота мастером (POST /slot/master/create)
+type CreateSlot struct {
+	MasterID  uuid.UUID `json:"master_id"`
+	ServiceID uint      `json:"service_id"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+}
@@ -0,0 +1,67 @@
+package contract
+
+import "github.com/google/uuid"
+
+// User — пользователь в ответах API
+type User struct {
+	ID         uuid.UUID `json:"id"`
+	Phone      string    `json:"phone"`
+	TelegramID int64     `json:"telegram_id"`
+	FirstName  string    `json:"first_name"`
+	Surname    string    `json:"surname"`
+	Timezone   string    `json:"timezone"`
+	Language   string    `json:"language"`
+	Currency   string    `json:"currency"`
+	DigestTime string    `json:"digest_time"`
+	Active     bool      `json:"active"`
+
+	Roles    []UserRole `json:"roles"`
+	Services []Service  `json:"services"`
+}
+
+// UserRole — роль пользователя
+type UserRole struct {
+	UserID uuid.UUID `json:"user_id"`
+	Role   string    `json:"role"`
+}
+
+// UserRegister — регистрация пользователя через бота (POST /user/register)
+type UserRegister struct {
+	Phone      string `json:"phone"`
+	TelegramID int64  `json:"telegram_id"`
+	FirstName  string `json:"first_name"`
+	Surname    string `json:"surname"`
+	Token      string `json:"token"`
+	Active     bool   `json:"active"`
+	// Language — language_code из Telegram; API сохраняет его, если язык поддерживается
+	Language string `json:"language,omitempty"`
+}
+
+// AuthStatus — ответ GET /user/check/{telegram_id}; Authenticated — пользователь есть и его номер подтверждён контактом
+type AuthStatus struct {
+	Authenticated bool `json:"authenticated"`
+}
+
+// TimezoneUpdate — смена часового пояса ботом (PUT /telegram/user/timezone)
+type TimezoneUpdate struct {
+	TelegramID int64  `json:"telegram_id"`
+	Timezone   string `json:"timezone"`
+}
+
+// LanguageUpdate — смена языка интерфейса ботом (PUT /telegram/user/language)
+type LanguageUpdate struct {
+	TelegramID int64  `json:"telegram_id"`
+	Language   string `json:"language"`
+}
+
+// CurrencyUpdate — валюта мастера по умолчанию для новых услуг (PUT /user/currency), ISO 4217
+type CurrencyUpdate struct {
+	Currency string `json:"currency"`
+}
+
+// DigestUpdate — время утренней сводки мастера ботом (PUT /telegram/user/digest).
+// DigestTime — "ЧЧ:ММ" в таймзоне мастера, пустая строка выключает сводку.
+type DigestUpdate struct {
+	TelegramID int64  `json:"telegram_id"`
+	DigestTime string `json:"digest_time"`
+}
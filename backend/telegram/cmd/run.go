@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	adapter "telegram-bot/internal/adapter/backendapi"
 	callback "telegram-bot/internal/adapter/callback"
 	appHandler "telegram-bot/internal/app/login"
@@ -13,7 +16,10 @@ import (
 	mybot "telegram-bot/internal/bot"
 	"telegram-bot/internal/config"
 	msgHandler "telegram-bot/internal/handlers/message"
+	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/logger"
+	"telegram-bot/internal/outbound"
 	botServer "telegram-bot/internal/transport/bot"
 	httpTransport "telegram-bot/internal/transport/http"
 	"telegram-bot/pkg/closer"
@@ -22,6 +28,41 @@ import (
 	"github.com/go-telegram/bot"
 )
 
+// webhookFromConfig возвращает параметры webhook или nil для режима polling
+func webhookFromConfig(cfg config.Config) (*botServer.Webhook, error) {
+	switch cfg.BotMode {
+	case "", "polling":
+		return nil, nil
+	case "webhook":
+		if cfg.WebhookURL == "" || cfg.WebhookSecret == "" {
+			return nil, fmt.Errorf("TELEGRAM_WEBHOOK_URL and TELEGRAM_WEBHOOK_SECRET are required for BOT_MODE=webhook")
+		}
+		return &botServer.Webhook{
+			URL:    strings.TrimRight(cfg.WebhookURL, "/") + cfg.WebhookPath,
+			Secret: cfg.WebhookSecret,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown BOT_MODE %q (expected polling or webhook)", cfg.BotMode)
+	}
+}
+
+// userProfile загружает выбранные пользователем язык и таймзону из API.
+// Незарегистрированный пользователь их не выбирал — это не ошибка.
+func userProfile(client *adapter.Client) i18n.Loader {
+	return func(ctx context.Context, telegramID int64) (i18n.Profile, error) {
+		user, err := client.GetUserByTelegramID(ctx, telegramID)
+		// API отвечает 4xx для незарегистрированных и неактивных пользователей
+		var apiErr *adapter.APIError
+		if errors.As(err, &apiErr) && apiErr.Status < 500 && apiErr.Status != http.StatusTooManyRequests {
+			return i18n.Profile{}, nil
+		}
+		if err != nil {
+			return i18n.Profile{}, err
+		}
+		return i18n.Profile{Language: user.Language, Timezone: user.Timezone}, nil
+	}
+}
+
 // LaunchBot starts Telegram bot and HTTP server
 func LaunchBot() {
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
@@ -39,14 +80,26 @@ func LaunchBot() {
 		log.Fatal("cmd.launchBot: BACKEND_BASE_URL environment variable is required")
 		return
 	}
+	webhook, err := webhookFromConfig(cfg)
+	if err != nil {
+		log.WithError(err).Error("cmd.launchBot: invalid bot mode configuration")
+		return
+	}
 
 	mybot.InitRateLimiter()
 	closeRateLimiter := mybot.NewCloseRateLimiter("close-rate-limiter")
 
 	log.Info("cmd.launchBot: rate limiter initialized")
 
+	// Один клиент API на весь процесс: повторы и circuit breaker
+	// учитывают все обращения бота, а не каждое нажатие по отдельности
+	apiClient := adapter.New(cfg.BackendBaseURL, cfg.InternalToken, log)
+	shared.SetClient(apiClient)
+	i18n.SetResolver(i18n.NewResolver(userProfile(apiClient)))
+
 	opts := []bot.Option{
-		bot.WithDefaultHandler(callback.UniversalHandler),
+		bot.WithDefaultHandler(callback.UniversalHandler(apiClient, cfg)),
+		bot.WithMiddlewares(mybot.LanguageHintMiddleware),
 	}
 	b, err := bot.New(cfg.BotToken, opts...)
 	if err != nil {
@@ -55,32 +108,63 @@ func LaunchBot() {
 	}
 	log.Info("cmd.launchBot: telegram bot instance created successfully")
 
+	stateDB, err := openStateStores(ctx, cfg)
+	if err != nil {
+		log.WithError(err).Error("cmd.launchBot: failed to open state stores")
+		return
+	}
+	log.Infof("cmd.launchBot: state store backend is %s", cfg.StateStore)
+
 	stateManager := msgHandler.GetStateManager()
 	stateManager.StartCleanupRoutine()
 	log.Info("cmd.launchBot: message state manager initialized and cleanup routine started")
 
-	apiClient := adapter.New(cfg.BackendBaseURL, log)
 	loginSvc := appHandler.New(apiClient, log)
 	slotsSvc := appSlots.New(apiClient, log)
 	recordsSvc := appRecords.New(apiClient, log)
 
-	serverBot := botServer.NewServer(b, log, loginSvc, slotsSvc, recordsSvc, "bot")
+	serverBot := botServer.NewServer(b, log, apiClient, loginSvc, slotsSvc, recordsSvc, "bot")
+	if webhook != nil {
+		serverBot.WithWebhook(*webhook)
+	}
 	log.Info("cmd.launchBot: registering bot command handlers")
 	serverBot.RegisterHandlers()
 
-	botHandler := msgHandler.NewHandler(b, log)
+	// Уведомления из API уходят через очередь с лимитами Telegram,
+	// итог доставки возвращается в API для outbox
+	dispatcher := outbound.New(b.SendMessage, outbound.DefaultConfig(), log, "outbound").
+		WithVenueSender(b.SendVenue).
+		WithReporter(func(ctx context.Context, r outbound.Result) error {
+			report := adapter.DeliveryReport{Status: r.Status, Attempts: r.Attempts, MessageID: r.MessageID}
+			if r.Err != nil {
+				report.Error = r.Err.Error()
+			}
+			return apiClient.ReportDelivery(ctx, r.DeliveryID, report)
+		})
+	dispatcher.Start()
+	botHandler := msgHandler.NewHandler(b, log).WithDispatcher(dispatcher)
 
 	httpServer := http.Server{
 		Addr:    ":8091",
 		Handler: http.NewServeMux(),
 	}
 	httpClient := httpTransport.NewHttpClient("server", &httpServer, b, botHandler, log)
+	if webhook != nil {
+		httpClient.WithWebhook(cfg.WebhookPath, webhook.Secret, b.WebhookHandler())
+	}
+	log.Infof("cmd.launchBot: bot mode is %s", cfg.BotMode)
 
 	manager := closer.NewManager(log)
-	manager.AddGraceful(httpClient)
+	// Останавливаются в обратном порядке: сначала перестаём принимать запросы,
+	// затем бот дорабатывает очередь обновлений
 	manager.AddGraceful(closeRateLimiter)
-	manager.AddCloser(stateManager)
 	manager.AddGraceful(serverBot)
+	manager.AddGraceful(dispatcher)
+	manager.AddGraceful(httpClient)
+	manager.AddCloser(stateManager)
+	if stateDB != nil {
+		manager.AddCloser(stateDB)
+	}
 	go func() {
 		if err := httpClient.Run(); err != nil {
 			log.Infof("http-client error: %v", err)
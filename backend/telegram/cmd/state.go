@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/config"
+	"telegram-bot/internal/fsm"
+	"telegram-bot/internal/handlers/manage"
+	msgHandler "telegram-bot/internal/handlers/message"
+	hRecord "telegram-bot/internal/handlers/record"
+	"telegram-bot/internal/state"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// dialogTTL — сколько живёт незаконченный диалог (сценарии /newslot и т.п.)
+const dialogTTL = 24 * time.Hour
+
+// stateDB закрывает подключение к базе состояний при остановке бота
+type stateDB struct {
+	db *sql.DB
+}
+
+func (s *stateDB) Close(context.Context) error { return s.db.Close() }
+func (s *stateDB) Name() string                { return "state-db" }
+
+// openStateStores подключает хранилища состояний сообщений и диалогов.
+// memory: состояния сообщений в памяти, диалоги в файле cfg.StateFile.
+// postgres: и то и другое в PostgreSQL, переживает перезапуск и общее для экземпляров.
+// Там же хранятся данные inline-кнопок, не поместившиеся в 64 байта.
+func openStateStores(ctx context.Context, cfg config.Config) (*stateDB, error) {
+	switch cfg.StateStore {
+	case "", "memory":
+		fsmStore, err := fsm.NewStore(cfg.StateFile, dialogTTL)
+		if err != nil {
+			return nil, fmt.Errorf("open dialog state file %s: %w", cfg.StateFile, err)
+		}
+		fsm.SetMachine(fsm.NewMachine(fsmStore, append(manage.Flows(), hRecord.Flows()...)...))
+		callbackdata.SetDefault(callbackdata.NewCodec(callbackSecret(cfg), callbackdata.NewMemoryStore()))
+		return nil, nil
+	case "postgres":
+		if cfg.StateDatabaseURL == "" {
+			return nil, fmt.Errorf("STATE_DATABASE_URL is required for STATE_STORE=postgres")
+		}
+		db, err := sql.Open("pgx", cfg.StateDatabaseURL)
+		if err != nil {
+			return nil, fmt.Errorf("open state database: %w", err)
+		}
+		pingCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		if err := db.PingContext(pingCtx); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("ping state database: %w", err)
+		}
+
+		messages := state.NewSQLStore(db, msgHandler.StateTTL)
+		dialogs := fsm.NewSQLStore(db, dialogTTL)
+		payloads := callbackdata.NewSQLStore(db)
+		if err := messages.Migrate(pingCtx); err != nil {
+			db.Close()
+			return nil, err
+		}
+		if err := dialogs.Migrate(pingCtx); err != nil {
+			db.Close()
+			return nil, err
+		}
+		if err := payloads.Migrate(pingCtx); err != nil {
+			db.Close()
+			return nil, err
+		}
+		msgHandler.GetStateManager().WithStore(messages)
+		fsm.SetMachine(fsm.NewMachine(dialogs, append(manage.Flows(), hRecord.Flows()...)...))
+		callbackdata.SetDefault(callbackdata.NewCodec(callbackSecret(cfg), payloads))
+		return &stateDB{db: db}, nil
+	default:
+		return nil, fmt.Errorf("unknown STATE_STORE %q (expected memory or postgres)", cfg.StateStore)
+	}
+}
+
+// callbackSecret — ключ подписи inline-кнопок. Без CALLBACK_SECRET ключ выводится
+// из токена бота, чтобы кнопки в старых сообщениях работали после перезапуска.
+func callbackSecret(cfg config.Config) []byte {
+	if cfg.CallbackSecret != "" {
+		return []byte(cfg.CallbackSecret)
+	}
+	return callbackdata.DeriveSecret(cfg.BotToken)
+}
@@ -0,0 +1,114 @@
+package backendapi
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// BreakerConfig — параметры circuit breaker
+type BreakerConfig struct {
+	// FailureThreshold — сколько сбоев подряд размыкают цепь
+	FailureThreshold int
+	// OpenTimeout — сколько запросы отклоняются без обращения к API;
+	// затем пропускается один пробный запрос
+	OpenTimeout time.Duration
+}
+
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{FailureThreshold: 5, OpenTimeout: 30 * time.Second}
+}
+
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// outcomeIgnored — запрос отменил вызывающий, о состоянии API это ничего не говорит
+	outcomeIgnored
+)
+
+// outcomeOf: сбоем считаются только недоступность API и 5xx,
+// ответы 4xx означают, что API работает
+func outcomeOf(ctx context.Context, err error) outcome {
+	switch {
+	case err == nil:
+		return outcomeSuccess
+	case ctx.Err() != nil:
+		return outcomeIgnored
+	case errors.Is(err, ErrUnavailable):
+		return outcomeFailure
+	}
+	return outcomeSuccess
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+type breaker struct {
+	mu       sync.Mutex
+	cfg      BreakerConfig
+	now      func() time.Time
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func newBreaker(cfg BreakerConfig) *breaker {
+	return &breaker{cfg: cfg, now: time.Now}
+}
+
+// allow разрешает запрос. После OpenTimeout пропускает ровно один пробный
+// запрос, остальные отклоняются до его результата.
+func (b *breaker) allow() bool {
+	if b.cfg.FailureThreshold <= 0 {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		return false
+	}
+	return true
+}
+
+// record учитывает результат запроса и сообщает, разомкнулась ли цепь
+func (b *breaker) record(o outcome) bool {
+	if b.cfg.FailureThreshold <= 0 {
+		return false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch o {
+	case outcomeSuccess:
+		b.state, b.failures = breakerClosed, 0
+	case outcomeFailure:
+		b.failures++
+		if b.state == breakerHalfOpen || b.failures >= b.cfg.FailureThreshold {
+			opened := b.state != breakerOpen
+			b.state, b.openedAt = breakerOpen, b.now()
+			return opened
+		}
+	case outcomeIgnored:
+		// Пробный запрос отменён — следующий вызов попробует снова
+		if b.state == breakerHalfOpen {
+			b.state, b.openedAt = breakerOpen, b.now().Add(-b.cfg.OpenTimeout)
+		}
+	}
+	return false
+}
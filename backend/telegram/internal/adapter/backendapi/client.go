@@ -1,22 +1,192 @@
 package backendapi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/sirupsen/logrus"
 )
 
+// Client — клиент HTTP API сервиса app. Создаётся один раз при старте бота
+// и передаётся обработчикам: повторы и circuit breaker работают на весь процесс.
 type Client struct {
 	baseURL string
 	http    *http.Client
 	logger  *logrus.Logger
+	token   string
+	retry   RetryPolicy
+	breaker *breaker
+	sleep   func(ctx context.Context, d time.Duration) error
 }
 
-func New(baseURL string, logger *logrus.Logger) *Client {
+// New создаёт клиент API по адресу baseURL. token подписывает внутренние маршруты
+// и запросы от имени пользователя (заголовок X-Internal-Token)
+func New(baseURL, token string, logger *logrus.Logger) *Client {
 	return &Client{
-		baseURL: baseURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
 		http:    &http.Client{Timeout: 10 * time.Second},
 		logger:  logger,
+		token:   token,
+		retry:   DefaultRetryPolicy(),
+		breaker: newBreaker(DefaultBreakerConfig()),
+		sleep:   sleepContext,
+	}
+}
+
+// WithHTTPClient подменяет HTTP-клиент (таймауты, транспорт в тестах)
+func (c *Client) WithHTTPClient(h *http.Client) *Client {
+	c.http = h
+	return c
+}
+
+// WithRetry задаёт политику повторов идемпотентных запросов
+func (c *Client) WithRetry(p RetryPolicy) *Client {
+	c.retry = p
+	return c
+}
+
+// WithBreaker задаёт параметры circuit breaker
+func (c *Client) WithBreaker(cfg BreakerConfig) *Client {
+	c.breaker = newBreaker(cfg)
+	return c
+}
+
+// request описывает один вызов API
+type request struct {
+	// name — имя метода клиента для логов и текста ошибки
+	name   string
+	method string
+	path   string
+	body   any
+	// out — куда декодировать JSON ответа; *[]byte получает тело как есть
+	out any
+	// internal подписывает запрос внутренним токеном
+	internal bool
+	// actAs — пользователь (мастер или клиент), от имени которого бот обращается к защищённым маршрутам
+	actAs int64
+	// userID передаётся в X-User-ID (подтверждение удаления аккаунта)
+	userID string
+	// idempotent разрешает повторять POST: повторный вызов не меняет результат
+	idempotent bool
+}
+
+// retryable — можно ли безопасно повторить запрос после сбоя
+func (r request) retryable() bool {
+	switch r.method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return r.idempotent
+}
+
+// do выполняет запрос: повторяет временные сбои идемпотентных вызовов
+// и не ходит в API, пока разомкнут circuit breaker
+func (c *Client) do(ctx context.Context, r request) error {
+	var body []byte
+	if r.body != nil {
+		var err error
+		if body, err = json.Marshal(r.body); err != nil {
+			return fmt.Errorf("Adapter.BackendAPI.%s: encode: %w", r.name, err)
+		}
+	}
+
+	attempts := 1
+	if r.retryable() && c.retry.MaxAttempts > 1 {
+		attempts = c.retry.MaxAttempts
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		if !c.breaker.allow() {
+			err = ErrCircuitOpen
+			break
+		}
+		err = c.once(ctx, r, body)
+		if c.breaker.record(outcomeOf(ctx, err)) {
+			c.logger.Warnf("Adapter.BackendAPI: circuit opened after %s: %v", r.name, err)
+		}
+		if err == nil {
+			return nil
+		}
+		if attempt >= attempts || !temporary(err) || ctx.Err() != nil {
+			break
+		}
+		delay := c.retry.backoff(attempt)
+		var apiErr *APIError
+		if errors.As(err, &apiErr) && apiErr.RetryAfter > delay {
+			delay = apiErr.RetryAfter
+		}
+		c.logger.Warnf("Adapter.BackendAPI.%s: attempt %d/%d failed: %v, retry in %s", r.name, attempt, attempts, err, delay)
+		if c.sleep(ctx, delay) != nil {
+			break
+		}
+	}
+	c.logger.Errorf("Adapter.BackendAPI.%s: %v", r.name, err)
+	return fmt.Errorf("Adapter.BackendAPI.%s: %w", r.name, err)
+}
+
+func (c *Client) once(ctx context.Context, r request, body []byte) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, r.method, c.baseURL+r.path, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if (r.internal || r.actAs != 0) && c.token != "" {
+		req.Header.Set("X-Internal-Token", c.token)
+	}
+	if r.actAs != 0 {
+		req.Header.Set("X-Telegram-ID", strconv.FormatInt(r.actAs, 10))
+	}
+	if r.userID != "" {
+		req.Header.Set("X-User-ID", r.userID)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return &transportError{err: err}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newAPIError(resp)
+	}
+	if r.out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if raw, ok := r.out.(*[]byte); ok {
+		if *raw, err = io.ReadAll(resp.Body); err != nil {
+			return &transportError{err: err}
+		}
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(r.out); err != nil {
+		return fmt.Errorf("decode: %w", err)
+	}
+	return nil
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
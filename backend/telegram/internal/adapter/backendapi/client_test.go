@@ -0,0 +1,215 @@
+package backendapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/pkg/models"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeAPI отвечает кодами из statuses по очереди, последним — на все остальные запросы
+type fakeAPI struct {
+	*httptest.Server
+	calls atomic.Int32
+}
+
+func newFakeAPI(t *testing.T, body string, statuses ...int) *fakeAPI {
+	t.Helper()
+	f := &fakeAPI{}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(f.calls.Add(1))
+		status := statuses[len(statuses)-1]
+		if n <= len(statuses) {
+			status = statuses[n-1]
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func newTestClient(url string) (*Client, *[]time.Duration) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	var delays []time.Duration
+	c := New(url, "", logger).WithRetry(RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+	c.sleep = func(_ context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return c, &delays
+}
+
+func TestIdempotentCallIsRetried(t *testing.T) {
+	api := newFakeAPI(t, `{"id": 7, "name": "Стрижка"}`, http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+	c, delays := newTestClient(api.URL)
+
+	service, err := c.GetServiceByID(context.Background(), 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Name != "Стрижка" || api.calls.Load() != 3 {
+		t.Fatalf("service = %+v after %d calls", service, api.calls.Load())
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("delays = %v, want 2 pauses", *delays)
+	}
+	for i, d := range *delays {
+		if ceiling := 100 * time.Millisecond << i; d < 0 || d > ceiling {
+			t.Errorf("delay %d = %s, want within [0, %s]", i, d, ceiling)
+		}
+	}
+}
+
+func TestNonIdempotentCallIsNotRetried(t *testing.T) {
+	api := newFakeAPI(t, `{}`, http.StatusServiceUnavailable)
+	c, _ := newTestClient(api.URL)
+
+	err := c.ConfirmLogin(context.Background(), 1)
+	if !errors.Is(err, ErrUnavailable) {
+		t.Fatalf("ConfirmLogin() error = %v, want ErrUnavailable", err)
+	}
+	if api.calls.Load() != 1 {
+		t.Fatalf("POST sent %d times, want 1", api.calls.Load())
+	}
+}
+
+func TestClientErrorIsNotRetried(t *testing.T) {
+	api := newFakeAPI(t, `{"error": "Слот уже занят"}`, http.StatusConflict)
+	c, _ := newTestClient(api.URL)
+
+	err := c.DeleteSlot(context.Background(), 1, 5)
+	if !errors.Is(err, ErrConflict) || errors.Is(err, ErrUnavailable) {
+		t.Fatalf("DeleteSlot() error = %v, want ErrConflict", err)
+	}
+	if api.calls.Load() != 1 {
+		t.Fatalf("409 retried: %d calls", api.calls.Load())
+	}
+	if got := UserMessage(i18n.For(i18n.RU), err); got != "Слот уже занят" {
+		t.Fatalf("UserMessage() = %q", got)
+	}
+}
+
+func TestCheckAuthTreatsRefusalAsUnregistered(t *testing.T) {
+	api := newFakeAPI(t, `{"error": "User inactive"}`, http.StatusBadRequest)
+	c, _ := newTestClient(api.URL)
+
+	ok, err := c.CheckAuth(context.Background(), 1)
+	if ok || err != nil {
+		t.Fatalf("CheckAuth() = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	api := newFakeAPI(t, `[]`, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK)
+	c, _ := newTestClient(api.URL)
+	c.WithRetry(RetryPolicy{MaxAttempts: 1}).WithBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	now := time.Now()
+	c.breaker.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.GetServicesByTelegramID(ctx, 1); !errors.Is(err, ErrUnavailable) {
+			t.Fatalf("call %d error = %v", i, err)
+		}
+	}
+	if _, err := c.GetServicesByTelegramID(ctx, 1); !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("open circuit error = %v, want ErrCircuitOpen", err)
+	}
+	if api.calls.Load() != 2 {
+		t.Fatalf("request sent while circuit open: %d calls", api.calls.Load())
+	}
+
+	// После OpenTimeout пропускается пробный запрос; успех замыкает цепь
+	now = now.Add(time.Minute)
+	if _, err := c.GetServicesByTelegramID(ctx, 1); err != nil {
+		t.Fatalf("probe error = %v", err)
+	}
+	if _, err := c.GetServicesByTelegramID(ctx, 1); err != nil {
+		t.Fatalf("after probe error = %v", err)
+	}
+}
+
+func TestRetryStopsWhenContextCancelled(t *testing.T) {
+	api := newFakeAPI(t, `{}`, http.StatusServiceUnavailable)
+	c, _ := newTestClient(api.URL)
+	ctx, cancel := context.WithCancel(context.Background())
+	c.sleep = func(ctx context.Context, _ time.Duration) error {
+		cancel()
+		return ctx.Err()
+	}
+
+	if _, err := c.GetServiceByID(ctx, 1); err == nil {
+		t.Fatal("GetServiceByID() error = nil")
+	}
+	if api.calls.Load() != 1 {
+		t.Fatalf("retried after cancel: %d calls", api.calls.Load())
+	}
+}
+
+func TestRecordCalendarReturnsRawBody(t *testing.T) {
+	const ics = "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n"
+	var telegramID string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		telegramID = r.Header.Get("X-Telegram-ID")
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		_, _ = w.Write([]byte(ics))
+	}))
+	t.Cleanup(api.Close)
+	c, _ := newTestClient(api.URL)
+
+	got, err := c.RecordCalendar(context.Background(), 2001, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != ics || telegramID != "2001" {
+		t.Fatalf("RecordCalendar() = %q as %q, want the file as client 2001", got, telegramID)
+	}
+}
+
+func TestSearchMastersEncodesQuery(t *testing.T) {
+	var query string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("q")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"message":"Success","data":[{"telegram_id":1001,"first_name":"Анна"}]}`))
+	}))
+	t.Cleanup(api.Close)
+	c, _ := newTestClient(api.URL)
+
+	got, err := c.SearchMasters(context.Background(), "стрижка & укладка", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "стрижка & укладка" || len(got) != 1 || got[0].TelegramID != 1001 {
+		t.Fatalf("SearchMasters() = %+v for q=%q", got, query)
+	}
+}
+
+func TestActAsSignsWithInjectedToken(t *testing.T) {
+	var token, telegramID string
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, telegramID = r.Header.Get("X-Internal-Token"), r.Header.Get("X-Telegram-ID")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	t.Cleanup(api.Close)
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	c := New(api.URL, "secret", logger)
+	if err := c.CreateService(context.Background(), 42, models.Service{Name: "Стрижка"}); err != nil {
+		t.Fatalf("CreateService() error = %v", err)
+	}
+	if token != "secret" || telegramID != "42" {
+		t.Fatalf("headers = %q %q, want the injected token and telegram id", token, telegramID)
+	}
+}
@@ -0,0 +1,25 @@
+package backendapi
+
+import (
+	"context"
+	"contract"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// DeliveryReport — итог отправки уведомления для outbox API
+type DeliveryReport = contract.DeliveryReport
+
+// ReportDelivery сообщает API, доставлено ли уведомление с данным delivery_id.
+// API принимает повторный отчёт с тем же статусом, поэтому запрос повторяется.
+func (c *Client) ReportDelivery(ctx context.Context, deliveryID string, report DeliveryReport) error {
+	return c.do(ctx, request{
+		name:       "ReportDelivery",
+		method:     http.MethodPost,
+		path:       fmt.Sprintf("/telegram/delivery/%s", url.PathEscape(deliveryID)),
+		body:       report,
+		internal:   true,
+		idempotent: true,
+	})
+}
@@ -0,0 +1,128 @@
+package backendapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"telegram-bot/internal/i18n"
+	"time"
+)
+
+// Ошибки клиента. Проверяются через errors.Is: *APIError сопоставляется
+// с ними по коду ответа, поэтому обработчикам не нужно разбирать статусы.
+var (
+	// ErrUnavailable — API не ответило или ответило 5xx
+	ErrUnavailable = errors.New("backend unavailable")
+	// ErrCircuitOpen — запрос не отправлялся: API недавно подряд не отвечало
+	ErrCircuitOpen = fmt.Errorf("%w: circuit open", ErrUnavailable)
+	// ErrRateLimited — API ответило 429
+	ErrRateLimited  = errors.New("backend rate limited")
+	ErrInvalid      = errors.New("invalid request")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrNotFound     = errors.New("not found")
+	ErrConflict     = errors.New("conflict")
+)
+
+// APIError — ответ API с кодом, отличным от 2xx
+type APIError struct {
+	Status int
+	// Message — текст из поля "error" ответа
+	Message string
+	// RetryAfter — значение заголовка Retry-After, если API его прислало
+	RetryAfter time.Duration
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("status=%d", e.Status)
+	}
+	return fmt.Sprintf("status=%d: %s", e.Status, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnavailable:
+		return e.Status >= 500
+	case ErrRateLimited:
+		return e.Status == http.StatusTooManyRequests
+	case ErrInvalid:
+		return e.Status == http.StatusBadRequest || e.Status == http.StatusUnprocessableEntity
+	case ErrUnauthorized:
+		return e.Status == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.Status == http.StatusForbidden
+	case ErrNotFound:
+		return e.Status == http.StatusNotFound
+	case ErrConflict:
+		return e.Status == http.StatusConflict
+	}
+	return false
+}
+
+// newAPIError читает из ответа текст ошибки и Retry-After
+func newAPIError(resp *http.Response) *APIError {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	var payload struct {
+		Error string `json:"error"`
+	}
+	_ = json.Unmarshal(body, &payload)
+	apiErr := &APIError{Status: resp.StatusCode, Message: payload.Error}
+	if sec, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && sec > 0 {
+		apiErr.RetryAfter = time.Duration(sec) * time.Second
+	}
+	return apiErr
+}
+
+// transportError — запрос не дошёл до API или ответ не получен
+type transportError struct {
+	err error
+}
+
+func (e *transportError) Error() string        { return e.err.Error() }
+func (e *transportError) Unwrap() error        { return e.err }
+func (e *transportError) Is(target error) bool { return target == ErrUnavailable }
+
+// temporary — сбой, после которого запрос имеет смысл повторить
+func temporary(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Status {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	return errors.Is(err, ErrUnavailable)
+}
+
+// UserMessage возвращает текст ошибки для пользователя бота на его языке.
+// Для отказов API (4xx) показывает причину из ответа, если она есть.
+func UserMessage(l i18n.Localizer, err error) string {
+	var apiErr *APIError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, ErrUnavailable):
+		return l.T("api.unavailable")
+	case errors.Is(err, ErrRateLimited):
+		return l.T("api.rate_limited")
+	case errors.As(err, &apiErr) && apiErr.Message != "":
+		return apiErr.Message
+	case errors.Is(err, ErrNotFound):
+		return l.T("api.not_found")
+	case errors.Is(err, ErrForbidden), errors.Is(err, ErrUnauthorized):
+		return l.T("api.forbidden")
+	case errors.Is(err, ErrConflict):
+		return l.T("api.conflict")
+	case errors.Is(err, ErrInvalid):
+		return l.T("api.invalid")
+	}
+	return l.T("api.failed")
+}
@@ -0,0 +1,30 @@
+package backendapi
+
+import (
+	"context"
+	"contract"
+	"net/http"
+	"net/url"
+	"strconv"
+	"telegram-bot/pkg/models"
+)
+
+// SearchMasters ищет мастеров по имени или названию услуги для inline-режима;
+// пустой query возвращает всех мастеров, первыми — с ближайшими свободными слотами
+func (c *Client) SearchMasters(ctx context.Context, query string, limit int) ([]models.MasterCard, error) {
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("limit", strconv.Itoa(limit))
+	var out contract.MasterSearch
+	err := c.do(ctx, request{
+		name:     "SearchMasters",
+		method:   http.MethodGet,
+		path:     "/telegram/master/search?" + params.Encode(),
+		out:      &out,
+		internal: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Data, nil
+}
@@ -1,148 +1,142 @@
 package backendapi
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"contract"
 	"fmt"
 	"net/http"
-	"os"
-	mymodels "telegram-bot/pkg/models"
+	"telegram-bot/pkg/models"
 )
 
-func (c *Client) CreateRecord(ctx context.Context, req mymodels.Record) (string, bool) {
-	url := fmt.Sprintf("%s/telegram/record/master/create", c.baseURL)
-	body, _ := json.Marshal(req)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	httpReq.Header.Set("Content-Type", "application/json")
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-	resp, err := c.http.Do(httpReq)
+// CreateRecord записывает клиента на слот и возвращает созданную запись
+func (c *Client) CreateRecord(ctx context.Context, req models.Record) (*models.Record, error) {
+	var out contract.CreatedRecord
+	err := c.do(ctx, request{
+		name:     "CreateRecord",
+		method:   http.MethodPost,
+		path:     "/telegram/record/master/create",
+		body:     req,
+		out:      &out,
+		internal: true,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.RegisterUser: %v", err)
-		return err.Error(), false
+		return nil, err
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Sprintf("status=%d", resp.StatusCode), false
-	}
-	return "ok", true
+	return &out.Data, nil
 }
 
-func (c *Client) GetUserRecordsFiltered(ctx context.Context, telegramID int64, status string, page, limit int) (*userFilterResponse, error) {
+// GetUserRecordsFiltered возвращает страницу записей клиента с данным статусом
+func (c *Client) GetUserRecordsFiltered(ctx context.Context, telegramID int64, status string, page, limit int) (*contract.RecordPage, error) {
 	user, err := c.GetUserByTelegramID(ctx, telegramID)
 	if err != nil {
-		return nil, fmt.Errorf("GetUserRecordsFiltered: %v", err)
-	}
-	url := fmt.Sprintf("%s/record/user/filter", c.baseURL)
-	payload := userFilterRequest{UserID: user.ID.String(), Status: status, Page: page, Limit: limit}
-	body, _ := json.Marshal(payload)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	httpReq.Header.Set("Content-Type", "application/json")
-	resp, err := c.http.Do(httpReq)
+		return nil, err
+	}
+	var out contract.RecordPage
+	err = c.do(ctx, request{
+		name:   "GetUserRecordsFiltered",
+		method: http.MethodPost,
+		path:   "/record/user/filter",
+		body:   contract.RecordFilter{UserID: user.ID.String(), Status: status, Page: page, Limit: limit},
+		out:    &out,
+		// Выборка без побочных эффектов, POST только ради тела запроса
+		idempotent: true,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUserRecordsFiltered: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserRecordsFiltered: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserRecordsFiltered: status=%d", resp.StatusCode)
-	}
-	var out userFilterResponse
-	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUserRecordsFiltered: decode: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserRecordsFiltered: %v", err)
+		return nil, err
 	}
 	return &out, nil
 }
-func (c *Client) GetRecords(ctx context.Context, userID int64) ([]mymodels.Record, error) {
+
+func (c *Client) GetRecords(ctx context.Context, userID int64) ([]models.Record, error) {
 	user, err := c.GetUserByTelegramID(ctx, userID)
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
-	}
-	url := fmt.Sprintf("%s/record/%s", c.baseURL, user.ID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	resp, err := c.http.Do(httpReq)
+		return nil, err
+	}
+	var records []models.Record
+	err = c.do(ctx, request{
+		name:   "GetRecords",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/record/%s", user.ID),
+		out:    &records,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
-	}
-	var records []mymodels.Record
-	if err := json.NewDecoder(resp.Body).Decode(&records); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetRecords: decode: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetRecords: %v", err)
+		return nil, err
 	}
 	return records, nil
 }
 
-func (c *Client) UpdateRecordStatus(ctx context.Context, recordID uint, status string) (string, bool) {
-	url := fmt.Sprintf("%s/telegram/record/master/status", c.baseURL)
-	payload := updateRecordStatusRequest{RecordID: recordID, Status: status}
-	body, _ := json.Marshal(payload)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	httpReq.Header.Set("Content-Type", "application/json")
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.UpdateRecordStatus: %v", err)
-		return err.Error(), false
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Sprintf("status=%d", resp.StatusCode), false
-	}
-	return "ok", true
+// UpdateRecordStatus переводит запись в статус status; повтор с тем же статусом безопасен
+func (c *Client) UpdateRecordStatus(ctx context.Context, recordID uint, status string) error {
+	return c.do(ctx, request{
+		name:       "UpdateRecordStatus",
+		method:     http.MethodPost,
+		path:       "/telegram/record/master/status",
+		body:       contract.RecordStatusUpdate{RecordID: recordID, Status: status},
+		internal:   true,
+		idempotent: true,
+	})
 }
 
 // GetUpcomingRecordsByMasterTelegramID получает предстоящие записи мастера
-func (c *Client) GetUpcomingRecordsByMasterTelegramID(ctx context.Context, masterTelegramID int64) ([]map[string]interface{}, error) {
-	url := fmt.Sprintf("%s/telegram/record/master/upcoming/%d", c.baseURL, masterTelegramID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-
-	resp, err := c.http.Do(httpReq)
+func (c *Client) GetUpcomingRecordsByMasterTelegramID(ctx context.Context, masterTelegramID int64) ([]models.Record, error) {
+	var out contract.UpcomingRecords
+	err := c.do(ctx, request{
+		name:     "GetUpcomingRecordsByMasterTelegramID",
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/telegram/record/master/upcoming/%d", masterTelegramID),
+		out:      &out,
+		internal: true,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUpcomingRecordsByMasterTelegramID: %v", err)
-		return nil, fmt.Errorf("request error: %v", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
+	return out.Data, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		c.logger.Errorf("Adapter.BackendAPI.GetUpcomingRecordsByMasterTelegramID: status=%d", resp.StatusCode)
-		return nil, fmt.Errorf("status=%d", resp.StatusCode)
-	}
+// CancelRecordByClient отменяет запись клиента telegramID; API проверяет, что запись его
+func (c *Client) CancelRecordByClient(ctx context.Context, telegramID int64, recordID uint) error {
+	return c.do(ctx, request{
+		name:   "CancelRecordByClient",
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/record/client/%d/cancel", recordID),
+		actAs:  telegramID,
+	})
+}
 
-	var result struct {
-		Message string                   `json:"message"`
-		Data    []map[string]interface{} `json:"data"`
-	}
+// RescheduleRecordByClient переносит запись клиента на другой слот той же услуги у того же мастера
+func (c *Client) RescheduleRecordByClient(ctx context.Context, telegramID int64, recordID, slotID uint) error {
+	return c.do(ctx, request{
+		name:   "RescheduleRecordByClient",
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/record/client/%d/reschedule", recordID),
+		body:   contract.RecordReschedule{SlotID: slotID},
+		actAs:  telegramID,
+	})
+}
 
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUpcomingRecordsByMasterTelegramID: decode: %v", err)
-		return nil, fmt.Errorf("decode error: %v", err)
-	}
+// CommentRecordByClient сохраняет комментарий клиента к записи, API пересылает его мастеру
+func (c *Client) CommentRecordByClient(ctx context.Context, telegramID int64, recordID uint, comment string) error {
+	return c.do(ctx, request{
+		name:   "CommentRecordByClient",
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/record/client/%d/comment", recordID),
+		body:   contract.RecordComment{Comment: comment},
+		actAs:  telegramID,
+	})
+}
 
-	return result.Data, nil
+// RecordCalendar возвращает запись клиента в формате .ics
+func (c *Client) RecordCalendar(ctx context.Context, telegramID int64, recordID uint) ([]byte, error) {
+	var ics []byte
+	err := c.do(ctx, request{
+		name:   "RecordCalendar",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/record/client/%d/ics", recordID),
+		out:    &ics,
+		actAs:  telegramID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ics, nil
 }
@@ -0,0 +1,34 @@
+package backendapi
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// RetryPolicy — повторы идемпотентных запросов при временных сбоях
+// (сеть, 429, 502–504). Пауза выбирается случайно от нуля до
+// BaseDelay·2^(n-1), но не больше MaxDelay (full jitter).
+type RetryPolicy struct {
+	// MaxAttempts — общее число попыток, включая первую
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 200 * time.Millisecond, MaxDelay: 2 * time.Second}
+}
+
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := p.BaseDelay
+	for i := 1; i < attempt && ceiling < p.MaxDelay; i++ {
+		ceiling *= 2
+	}
+	if p.MaxDelay > 0 && ceiling > p.MaxDelay {
+		ceiling = p.MaxDelay
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int64N(int64(ceiling) + 1))
+}
@@ -0,0 +1,30 @@
+package backendapi
+
+import (
+	"context"
+	"contract"
+	"fmt"
+	"net/http"
+)
+
+// CreateReview оставляет отзыв клиента о завершённой записи; ErrConflict — отзыв уже есть
+func (c *Client) CreateReview(ctx context.Context, telegramID int64, recordID uint, review contract.ReviewRequest) error {
+	return c.do(ctx, request{
+		name:   "CreateReview",
+		method: http.MethodPost,
+		path:   fmt.Sprintf("/review/record/%d", recordID),
+		body:   review,
+		actAs:  telegramID,
+	})
+}
+
+// UpdateReview меняет оценку и текст отзыва клиента о записи
+func (c *Client) UpdateReview(ctx context.Context, telegramID int64, recordID uint, review contract.ReviewRequest) error {
+	return c.do(ctx, request{
+		name:   "UpdateReview",
+		method: http.MethodPut,
+		path:   fmt.Sprintf("/review/record/%d", recordID),
+		body:   review,
+		actAs:  telegramID,
+	})
+}
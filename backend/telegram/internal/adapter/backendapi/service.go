@@ -0,0 +1,60 @@
+package backendapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"telegram-bot/pkg/models"
+)
+
+// GetServicesByTelegramID возвращает услуги мастера
+func (c *Client) GetServicesByTelegramID(ctx context.Context, telegramID int64) ([]models.Service, error) {
+	var services []models.Service
+	err := c.do(ctx, request{
+		name:   "GetServicesByTelegramID",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/service/master/%d", telegramID),
+		out:    &services,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return services, nil
+}
+
+// GetServiceByID возвращает услугу по ID
+func (c *Client) GetServiceByID(ctx context.Context, serviceID uint) (*models.Service, error) {
+	var service models.Service
+	err := c.do(ctx, request{
+		name:   "GetServiceByID",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/service/%d", serviceID),
+		out:    &service,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &service, nil
+}
+
+// CreateService создаёт услугу от имени мастера
+func (c *Client) CreateService(ctx context.Context, telegramID int64, service models.Service) error {
+	return c.do(ctx, request{
+		name:   "CreateService",
+		method: http.MethodPost,
+		path:   "/service/create",
+		body:   service,
+		actAs:  telegramID,
+	})
+}
+
+// UpdateService обновляет услугу мастера (передаются все поля)
+func (c *Client) UpdateService(ctx context.Context, telegramID int64, service models.Service) error {
+	return c.do(ctx, request{
+		name:   "UpdateService",
+		method: http.MethodPut,
+		path:   "/service/update",
+		body:   service,
+		actAs:  telegramID,
+	})
+}
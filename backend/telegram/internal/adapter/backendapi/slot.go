@@ -2,65 +2,75 @@ package backendapi
 
 import (
 	"context"
-	"encoding/json"
+	"contract"
 	"fmt"
 	"net/http"
 	"telegram-bot/pkg/models"
 )
 
-func (c *Client) GetSlotsByTelegramID(ctx context.Context, masterID int64) ([]models.SlotResponse, bool) {
+func (c *Client) GetSlotsByTelegramID(ctx context.Context, masterID int64) ([]models.SlotResponse, error) {
 	user, err := c.GetUserByTelegramID(ctx, masterID)
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetSlotsByTelegramID: %v", err)
-		return nil, false
-	}
-	url := fmt.Sprintf("%s/slot/%s", c.baseURL, user.ID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetSlotsByTelegramID: %v", err)
-		return nil, false
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, false
+		return nil, err
 	}
 	var slots []models.SlotResponse
-	if err := json.NewDecoder(resp.Body).Decode(&slots); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetSlotsByTelegramID: decode: %v", err)
-		return nil, false
+	err = c.do(ctx, request{
+		name:   "GetSlotsByTelegramID",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/slot/%s", user.ID),
+		out:    &slots,
+	})
+	if err != nil {
+		return nil, err
 	}
-	return slots, true
+	return slots, nil
 }
 
-func (c *Client) GetSlotByID(ctx context.Context, slotID uint) (*models.SlotResponse, bool) {
-	url := fmt.Sprintf("%s/slot/one/%d", c.baseURL, slotID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	resp, err := c.http.Do(httpReq)
+func (c *Client) GetSlotByID(ctx context.Context, slotID uint) (*models.SlotResponse, error) {
+	var slot models.SlotResponse
+	err := c.do(ctx, request{
+		name:   "GetSlotByID",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/slot/one/%d", slotID),
+		out:    &slot,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetSlotsByTelegramID: %v", err)
-		return nil, false
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, false
-	}
-	var slot *models.SlotResponse
-	if err := json.NewDecoder(resp.Body).Decode(&slot); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetSlotsByTelegramID: decode: %v", err)
-		return nil, false
+		return nil, err
 	}
-	return slot, true
+	return &slot, nil
 }
 
-func (c *Client) DeleteSlotsByTelegramID(ctx context.Context, masterID uint) bool {
-	url := fmt.Sprintf("%s/slot/master/%d", c.baseURL, masterID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodDelete, url, nil)
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.DeleteSlotsByTelegramID: %v", err)
-		return false
-	}
-	defer resp.Body.Close()
-	return resp.StatusCode == http.StatusOK
+func (c *Client) DeleteSlotsByTelegramID(ctx context.Context, masterID uint) error {
+	return c.do(ctx, request{
+		name:   "DeleteSlotsByTelegramID",
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/slot/master/%d", masterID),
+	})
+}
+
+// CreateSlot создаёт слот от имени мастера
+func (c *Client) CreateSlot(ctx context.Context, telegramID int64, slot models.Slot) error {
+	return c.do(ctx, request{
+		name:   "CreateSlot",
+		method: http.MethodPost,
+		path:   "/slot/master/create",
+		// Вложенные service/master не отправляем: API ждёт только идентификаторы
+		body: contract.CreateSlot{
+			MasterID:  slot.MasterID,
+			ServiceID: slot.ServiceID,
+			StartTime: slot.StartTime,
+			EndTime:   slot.EndTime,
+		},
+		actAs: telegramID,
+	})
+}
+
+// DeleteSlot удаляет один слот мастера; API проверяет владельца
+func (c *Client) DeleteSlot(ctx context.Context, telegramID int64, slotID uint) error {
+	return c.do(ctx, request{
+		name:   "DeleteSlot",
+		method: http.MethodDelete,
+		path:   fmt.Sprintf("/slot/master/one/%d", slotID),
+		actAs:  telegramID,
+	})
 }
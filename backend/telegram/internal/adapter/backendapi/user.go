@@ -1,161 +1,112 @@
 package backendapi
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"contract"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
-	"os"
 	"telegram-bot/pkg/models"
-	mymodels "telegram-bot/pkg/models"
 )
 
-func (c *Client) RegisterUser(ctx context.Context, req mymodels.UserRegister) (string, bool) {
-	url := fmt.Sprintf("%s/user/register", c.baseURL)
-	body, _ := json.Marshal(req)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
-	httpReq.Header.Set("Content-Type", "application/json")
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.RegisterUser: %v", err)
-		return err.Error(), false
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Sprintf("status=%d", resp.StatusCode), false
-	}
-	return "ok", true
+// RegisterUser регистрирует пользователя по контакту из Telegram.
+// Контакт, переданный через бота, считается подтверждённым номером —
+// бэкенд доверяет ему только при наличии внутреннего токена.
+func (c *Client) RegisterUser(ctx context.Context, req models.UserRegister) error {
+	return c.do(ctx, request{
+		name:     "RegisterUser",
+		method:   http.MethodPost,
+		path:     "/user/register",
+		body:     req,
+		internal: true,
+	})
 }
 
-func (c *Client) CheckAuth(ctx context.Context, telegramID int64) (string, bool) {
-	url := fmt.Sprintf("%s/user/check/%d", c.baseURL, telegramID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.CheckAuth: %v", err)
-		return err.Error(), false
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		c.logger.Errorf("Adapter.BackendAPI.CheckAuth: bad status: %d, body: %s", resp.StatusCode, string(body))
-		return fmt.Sprintf("server returned status %d", resp.StatusCode), false
+// CheckAuth сообщает, зарегистрирован ли пользователь. Ошибка возвращается
+// только если API не удалось спросить: отказ 4xx означает «не зарегистрирован».
+func (c *Client) CheckAuth(ctx context.Context, telegramID int64) (bool, error) {
+	var out contract.AuthStatus
+	err := c.do(ctx, request{
+		name:   "CheckAuth",
+		method: http.MethodGet,
+		path:   fmt.Sprintf("/user/check/%d", telegramID),
+		out:    &out,
+	})
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.Status < 500 && apiErr.Status != http.StatusTooManyRequests {
+		return false, nil
 	}
-
-	httpResp := struct {
-		Authenticated bool `json:"authenticated"`
-	}{}
-
-	if err := json.NewDecoder(resp.Body).Decode(&httpResp); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.CheckAuth: decode: %v", err)
-		return "failed to decode response", false
+	if err != nil {
+		return false, err
 	}
-	return resp.Status, httpResp.Authenticated
+	return out.Authenticated, nil
 }
 
-func (c *Client) ConfirmLogin(ctx context.Context, telegramID int64) (string, bool) {
-	url := fmt.Sprintf("%s/user/confirm-login/%d", c.baseURL, telegramID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPost, url, nil)
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.ConfirmLogin: %v", err)
-		return err.Error(), false
-	}
-	defer resp.Body.Close()
-	return resp.Status, resp.StatusCode == http.StatusOK
+// ConfirmLogin подтверждает вход на сайт, запрошенный из браузера
+func (c *Client) ConfirmLogin(ctx context.Context, telegramID int64) error {
+	return c.do(ctx, request{
+		name:     "ConfirmLogin",
+		method:   http.MethodPost,
+		path:     fmt.Sprintf("/user/confirm-login/%d", telegramID),
+		internal: true,
+	})
 }
 
 // UpdateTimezoneInternal updates user's timezone by telegram_id via internal endpoint
 func (c *Client) UpdateTimezoneInternal(ctx context.Context, telegramID int64, timezone string) error {
-	url := fmt.Sprintf("%s/telegram/user/timezone", c.baseURL)
-	body, _ := json.Marshal(map[string]interface{}{
-		"telegram_id": telegramID,
-		"timezone":    timezone,
+	return c.do(ctx, request{
+		name:     "UpdateTimezoneInternal",
+		method:   http.MethodPut,
+		path:     "/telegram/user/timezone",
+		body:     contract.TimezoneUpdate{TelegramID: telegramID, Timezone: timezone},
+		internal: true,
 	})
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader(body))
-	httpReq.Header.Set("Content-Type", "application/json")
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.UpdateTimezoneInternal: %v", err)
-		return err
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("status=%d", resp.StatusCode)
-	}
-	return nil
-}
-func (c *Client) GetUserByTelegramID(ctx context.Context, userID int64) (*models.User, error) {
-	url := fmt.Sprintf("%s/user/g3tter/%d", c.baseURL, userID)
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
-	resp, err := c.http.Do(httpReq)
-	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUserByTelegramID: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserByTelegramID: %v", err)
-	}
-	defer resp.Body.Close()
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserByTelegramID: %s", resp.Status)
-	}
-	var user *models.User
-	if err := json.NewDecoder(resp.Body).Decode(&user); err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.GetUserByTelegramID: decode: %v", err)
-		return nil, fmt.Errorf("Adapter.BackendAPI.GetUserByTelegramID: %v", err)
-	}
-	return user, nil
 }
 
-// ConfirmAccountDeletion подтверждает удаление аккаунта
-func (c *Client) ConfirmAccountDeletion(userUUID string) error {
-	url := fmt.Sprintf("%s/user/confirm-deletion", c.baseURL)
-	httpReq, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, url, nil)
-
-	// Добавляем заголовки для аутентификации
-	secret := os.Getenv("INTERNAL_TOKEN")
-	if secret == "" {
-		secret = os.Getenv("TELEGRAM_HTTP_SECRET")
-	}
-	if secret != "" {
-		httpReq.Header.Set("X-Internal-Token", secret)
-	}
+// UpdateLanguageInternal сохраняет язык интерфейса бота по telegram_id
+func (c *Client) UpdateLanguageInternal(ctx context.Context, telegramID int64, language string) error {
+	return c.do(ctx, request{
+		name:     "UpdateLanguageInternal",
+		method:   http.MethodPut,
+		path:     "/telegram/user/language",
+		body:     contract.LanguageUpdate{TelegramID: telegramID, Language: language},
+		internal: true,
+	})
+}
 
-	// Добавляем user_id в заголовок для идентификации пользователя
-	httpReq.Header.Set("X-User-ID", userUUID)
+// UpdateDigestTime задаёт время утренней сводки мастера ("08:00"); пустая строка выключает сводку
+func (c *Client) UpdateDigestTime(ctx context.Context, telegramID int64, digestTime string) error {
+	return c.do(ctx, request{
+		name:     "UpdateDigestTime",
+		method:   http.MethodPut,
+		path:     "/telegram/user/digest",
+		body:     contract.DigestUpdate{TelegramID: telegramID, DigestTime: digestTime},
+		internal: true,
+	})
+}
 
-	resp, err := c.http.Do(httpReq)
+func (c *Client) GetUserByTelegramID(ctx context.Context, userID int64) (*models.User, error) {
+	var user models.User
+	err := c.do(ctx, request{
+		name:     "GetUserByTelegramID",
+		method:   http.MethodGet,
+		path:     fmt.Sprintf("/user/g3tter/%d", userID),
+		out:      &user,
+		internal: true,
+	})
 	if err != nil {
-		c.logger.Errorf("Adapter.BackendAPI.ConfirmAccountDeletion: %v", err)
-		return fmt.Errorf("Adapter.BackendAPI.ConfirmAccountDeletion: %v", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		c.logger.Errorf("Adapter.BackendAPI.ConfirmAccountDeletion: status=%d", resp.StatusCode)
-		return fmt.Errorf("Adapter.BackendAPI.ConfirmAccountDeletion: status=%d", resp.StatusCode)
+		return nil, err
 	}
+	return &user, nil
+}
 
-	return nil
+// ConfirmAccountDeletion подтверждает удаление аккаунта
+func (c *Client) ConfirmAccountDeletion(ctx context.Context, userUUID string) error {
+	return c.do(ctx, request{
+		name:     "ConfirmAccountDeletion",
+		method:   http.MethodPost,
+		path:     "/user/confirm-deletion",
+		internal: true,
+		userID:   userUUID,
+	})
 }
@@ -2,52 +2,34 @@ package callback
 
 import (
 	"log"
-	"strconv"
-	"strings"
+	"telegram-bot/internal/handlers/manage"
 	"telegram-bot/internal/handlers/master"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/sirupsen/logrus"
 )
 
-// UpcomingPage обрабатывает пагинацию предстоящих записей мастера
-func (h *CallBackHandler) UpcomingPage() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-
-	if len(parts) < 3 {
-		log.Printf("Invalid upcoming_page callback data: %s", callbackData)
-		return
-	}
-
-	page, err := strconv.Atoi(parts[1])
-	if err != nil {
-		log.Printf("Invalid page number in upcoming_page callback: %s", parts[1])
-		return
-	}
-
-	telegramID, err := strconv.ParseInt(parts[2], 10, 64)
-	if err != nil {
-		log.Printf("Invalid telegram_id in upcoming_page callback: %s", parts[2])
-		return
-	}
+// UpcomingPage обрабатывает пагинацию предстоящих записей мастера: {page}/{masterTelegramID}
+func (h *CallBackHandler) UpcomingPage(p Params) {
+	page, telegramID := p.Int(0), p.Int64(1)
 
 	// Получаем записи с backend
 	records, err := h.client.GetUpcomingRecordsByMasterTelegramID(h.ctx, telegramID)
 	if err != nil {
 		log.Printf("Failed to get upcoming records: %v", err)
-		h.answerCallBackQuery("Ошибка при получении записей", true)
+		h.answerError(err)
 		return
 	}
 
 	// Создаем handler для форматирования
-	masterHandler := master.NewHandler(logrus.New())
+	masterHandler := master.NewHandler(logrus.New(), h.client)
 
 	// Форматируем и отправляем страницу
 	const limit = 5
-	text, totalPages := masterHandler.FormatUpcomingRecordsPage(records, page, limit, telegramID)
-	keyboard := masterHandler.BuildUpcomingRecordsPagination(page, totalPages, telegramID)
+	l := i18n.ForUser(h.ctx, h.userID)
+	text, totalPages := masterHandler.FormatUpcomingRecordsPage(l, records, page, limit, telegramID)
+	keyboard := masterHandler.BuildUpcomingRecordsPagination(l, page, totalPages, telegramID)
 
 	// Редактируем сообщение
 	if h.messageID != 0 {
@@ -65,3 +47,8 @@ func (h *CallBackHandler) UpcomingPage() {
 
 	h.answerCallBackQuery("", false)
 }
+
+// Manage передаёт нажатия в сценарии управления услугами и слотами: {action}/{arg}
+func (h *CallBackHandler) Manage(p Params) {
+	manage.NewHandler(logrus.New(), h.client).HandleCallback(h.ctx, h.b, h.update, p.String(0), p.String(1))
+}
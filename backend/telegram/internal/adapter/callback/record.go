@@ -2,12 +2,15 @@ package callback
 
 import (
 	"fmt"
+	"html"
 	"log"
 	"strconv"
-	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/fsm"
 	"telegram-bot/internal/handlers/components"
-	"telegram-bot/internal/handlers/message"
 	record "telegram-bot/internal/handlers/record"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/utils"
 	mymodels "telegram-bot/pkg/models"
 
@@ -16,381 +19,407 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
-func (h *CallBackHandler) Records() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		status := parts[1]
-		if status == "all" {
-			status = ""
-		}
-		pageStr := parts[2]
-		page, err := strconv.Atoi(pageStr)
-		if err != nil {
-			page = 1
-		}
-		// При пагинации записей — редактируем конкретное сообщение
-		svc := record.NewService(h.b, logrus.New())
-		svc.EditUserRecordsPage(h.ctx, h.userID, h.userID, status, page, h.messageID)
-		h.answerCallBackQuery(fmt.Sprintf("Стр. %d", page), false)
+// Records — пагинация записей пользователя: {status}/{page}
+func (h *CallBackHandler) Records(p Params) {
+	status, page := p.String(0), p.Int(1)
+	if status == "all" {
+		status = ""
 	}
+	// При пагинации записей — редактируем конкретное сообщение
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	svc.EditUserRecordsPage(h.ctx, h.userID, h.userID, status, page, h.messageID)
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.page", page), false)
 }
 
-func (h *CallBackHandler) RecordsTime() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		mode := parts[1]
-		status := "all"
-		page := 1
-
-		// Определяем количество параметров и их значения
-		if len(parts) >= 4 {
-			// Формат: records_time/{mode}/{status}/{page}
-			status = parts[2]
-			if p, err := strconv.Atoi(parts[3]); err == nil {
-				page = p
-			}
-		} else if len(parts) >= 3 {
-			// Формат: records_time/{mode}/{page} (старый формат для обратной совместимости)
-			if p, err := strconv.Atoi(parts[2]); err == nil {
-				page = p
-			}
-		}
-
-		svc := record.NewService(h.b, logrus.New())
-		if mode == "chooser" {
-			// Вернуться к выбору пула с сохранением статуса
-			var text string
-			var futureCallback, pastCallback string
-
-			if status != "" && status != "all" {
-				statusText := "все записи"
-				switch status {
-				case "confirm":
-					statusText = "подтвержденные записи"
-				case "reject":
-					statusText = "отклоненные записи"
-				case "pending":
-					statusText = "записи в ожидании"
-				}
-				text = fmt.Sprintf("%s<b>Мои записи (%s)</b>\n<i>Выберите пул записей для просмотра</i>", components.Header(), statusText)
-				futureCallback = fmt.Sprintf("records_time/future/%s/1", status)
-				pastCallback = fmt.Sprintf("records_time/past/%s/1", status)
-			} else {
-				text = fmt.Sprintf("%s<b>Мои записи</b>\n<i>Выберите пул записей для просмотра</i>", components.Header())
-				futureCallback = "records_time/future/all/1"
-				pastCallback = "records_time/past/all/1"
-			}
-
-			kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-				{{Text: "Будущие", CallbackData: futureCallback}},
-				{{Text: "Прошедшие", CallbackData: pastCallback}},
-			}}
-			_ = messageEditor.EditUserRecords(h.ctx, h.b, h.userID, "chooser", 1, text, kb)
+// RecordsTime — пулы записей по времени: {future|past|chooser}/{status}/{page}
+func (h *CallBackHandler) RecordsTime(p Params) {
+	mode, status, page := p.String(0), p.String(1), p.Int(2)
+	l := i18n.ForUser(h.ctx, h.userID)
+
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	if mode == "chooser" {
+		// Вернуться к выбору пула с сохранением статуса
+		var text string
+		var futureCallback, pastCallback string
+
+		if status != "" && status != "all" {
+			text = components.Header() + l.T("records.my_chooser_kind", record.RecordsKind(l, status))
+			futureCallback = callbackdata.Encode(callbackdata.RouteRecordsTime, "future", status, "1")
+			pastCallback = callbackdata.Encode(callbackdata.RouteRecordsTime, "past", status, "1")
 		} else {
-			svc.EditUserRecordsTimePage(h.ctx, h.userID, h.userID, mode, status, page, h.messageID)
+			text = components.Header() + l.T("records.my_chooser")
+			futureCallback = callbackdata.Encode(callbackdata.RouteRecordsTime, "future", "all", "1")
+			pastCallback = callbackdata.Encode(callbackdata.RouteRecordsTime, "past", "all", "1")
 		}
-		h.answerCallBackQuery("Обновлено", false)
+
+		kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("pool.future"), CallbackData: futureCallback}},
+			{{Text: l.T("pool.past"), CallbackData: pastCallback}},
+		}}
+		_ = messageEditor.EditUserRecords(h.ctx, h.b, h.userID, h.messageID, "chooser", 1, text, kb)
+	} else {
+		svc.EditUserRecordsTimePage(h.ctx, h.userID, h.userID, mode, status, page, h.messageID)
 	}
+	h.answerCallBackQuery(l.T("callback.updated"), false)
 }
 func (h *CallBackHandler) CheckUserAuth(userID int64) bool {
-	_, exist := h.client.CheckAuth(h.ctx, userID)
+	exist, err := h.client.CheckAuth(h.ctx, userID)
+	if err != nil {
+		h.answerError(err)
+		return false
+	}
 	if !exist {
-		msgText := fmt.Sprintf(
-			"%s"+
-				"<blockquote> ℹ️ Для начала использования бота, вам необходимо зарегистрироваться!\n\nДля регистрации /start</blockquote>",
-			components.Header(),
-		)
 		h.b.SendMessage(h.ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgText,
+			Text:      components.Header() + i18n.ForUser(h.ctx, userID).T("start.register_first"),
 		})
 		return false
 	}
 	return true
 }
 
-func (h *CallBackHandler) BookMove() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 2 {
-		slotIDStr := parts[1]
-		slotID, err := strconv.Atoi(slotIDStr)
-		if err != nil {
-			log.Printf("Invalid slot ID in booking callback: %s", slotIDStr)
-			return
-		}
-		if !h.CheckUserAuth(h.userID) {
-			log.Printf("User not authorizated: %s", slotIDStr)
-			return
-		}
-		// Получаем пользователя (для client_id)
-		user, err := h.client.GetUserByTelegramID(h.ctx, h.userID)
-		if err != nil || user == nil {
-			log.Printf("GetUserByTelegramID failed: %v", err)
-			// Редактируем сообщение, на котором была нажата кнопка
-			if h.messageID != 0 {
-				log.Printf("Editing message %d for user %d - user check error", h.messageID, h.userID)
-				messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, msgErrorWithCheckUser, nil)
-			} else {
-				log.Printf("MessageID is 0, sending new message to user %d - user check error", h.userID)
-				h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-					ChatID:    h.userID,
-					Text:      msgErrorWithCheckUser,
-					ParseMode: models.ParseModeHTML,
-				})
-			}
-			h.answerCallBackQuery("Ошибка: пользователь не найден", false)
-			return
+// BookMove записывает клиента на слот: {slotID}
+func (h *CallBackHandler) BookMove(p Params) {
+	slotID := p.Uint(0)
+	if !h.CheckUserAuth(h.userID) {
+		log.Printf("User not authorizated: %d", slotID)
+		return
+	}
+	l := i18n.ForUser(h.ctx, h.userID)
+	// Получаем пользователя (для client_id)
+	user, err := h.client.GetUserByTelegramID(h.ctx, h.userID)
+	if err != nil || user == nil {
+		log.Printf("GetUserByTelegramID failed: %v", err)
+		// Редактируем сообщение, на котором была нажата кнопка
+		if h.messageID != 0 {
+			log.Printf("Editing message %d for user %d - user check error", h.messageID, h.userID)
+			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, msgErrorWithCheckUser(l), nil)
+		} else {
+			log.Printf("MessageID is 0, sending new message to user %d - user check error", h.userID)
+			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+				ChatID:    h.userID,
+				Text:      msgErrorWithCheckUser(l),
+				ParseMode: models.ParseModeHTML,
+			})
 		}
-		// Получаем информацию о слоте для отображения деталей
-		slot, ok := h.client.GetSlotByID(h.ctx, uint(slotID))
-		if !ok {
-			log.Printf("GetSlotByID failed for slot: %d", slotID)
-			errorText := fmt.Sprintf("%s⚠️ Ошибка\n<i>Не удалось получить информацию о слоте</i>", components.Header())
-			if h.messageID != 0 {
-				log.Printf("Editing message %d for user %d - slot info error", h.messageID, h.userID)
-				messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, errorText, nil)
-			} else {
-				log.Printf("MessageID is 0, sending new message to user %d - slot info error", h.userID)
-				h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-					ChatID:    h.userID,
-					Text:      errorText,
-					ParseMode: models.ParseModeHTML,
-				})
-			}
-			h.answerCallBackQuery("Ошибка получения информации о слоте", false)
-			return
+		h.answerCallBackQuery(l.T("book.user_not_found"), false)
+		return
+	}
+	// Получаем информацию о слоте для отображения деталей
+	slot, err := h.client.GetSlotByID(h.ctx, slotID)
+	if err != nil {
+		log.Printf("GetSlotByID failed for slot %d: %v", slotID, err)
+		errorText := components.APIError(l, err, components.Error(l, l.T("book.slot_failed")))
+		if h.messageID != 0 {
+			log.Printf("Editing message %d for user %d - slot info error", h.messageID, h.userID)
+			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, errorText, nil)
+		} else {
+			log.Printf("MessageID is 0, sending new message to user %d - slot info error", h.userID)
+			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+				ChatID:    h.userID,
+				Text:      errorText,
+				ParseMode: models.ParseModeHTML,
+			})
 		}
+		h.answerCallBackQuery(l.T("book.slot_failed"), false)
+		return
+	}
 
-		// Формируем запрос на создание записи
-		req := mymodels.Record{
-			SlotID:   uint(slotID),
-			ClientID: user.ID,
-			Status:   "pending",
-		}
-		msg, ok := h.client.CreateRecord(h.ctx, req)
-		if !ok {
-			log.Printf("CreateRecord failed: %s", msg)
-			// Редактируем сообщение, на котором была нажата кнопка
-			errorText := fmt.Sprintf("%s⚠️ Ошибка\n<i>Не удалось создать запись: %s</i>", components.Header(), msg)
-			if h.messageID != 0 {
-				log.Printf("Editing message %d for user %d - create record error", h.messageID, h.userID)
-				messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, errorText, nil)
-			} else {
-				log.Printf("MessageID is 0, sending new message to user %d - create record error", h.userID)
-				h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-					ChatID:    h.userID,
-					Text:      errorText,
-					ParseMode: models.ParseModeHTML,
-				})
-			}
-			h.answerCallBackQuery("Ошибка создания записи", false)
-			return
+	// Формируем запрос на создание записи
+	req := mymodels.Record{
+		SlotID:   slotID,
+		ClientID: user.ID,
+		Status:   "pending",
+	}
+	created, err := h.client.CreateRecord(h.ctx, req)
+	if err != nil {
+		log.Printf("CreateRecord failed: %v", err)
+		// Редактируем сообщение, на котором была нажата кнопка
+		errorText := components.Error(l, l.T("book.failed", html.EscapeString(adapter.UserMessage(l, err))))
+		if h.messageID != 0 {
+			log.Printf("Editing message %d for user %d - create record error", h.messageID, h.userID)
+			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, errorText, nil)
+		} else {
+			log.Printf("MessageID is 0, sending new message to user %d - create record error", h.userID)
+			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+				ChatID:    h.userID,
+				Text:      errorText,
+				ParseMode: models.ParseModeHTML,
+			})
 		}
+		h.answerCallBackQuery(l.T("book.failed_short"), false)
+		return
+	}
 
-		// Форматируем время с учетом таймзоны мастера
-		date := utils.FormatDateInLocation(slot.MasterTimezone, slot.StartTime)
-		startTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.StartTime)
-		endTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.EndTime)
-
-		// Получаем метку таймзоны для отображения (обязательно таймзона мастера)
-		tzLabel := slot.MasterTimezone
-		if tzLabel == "" {
-			tzLabel = "Europe/Moscow" // на московскую таймзону, если таймзона не указана
+	// Форматируем время в таймзоне пользователя, а если он её не выбрал — мастера.
+	// Дата совпадает с ключом группировки списка слотов.
+	tzLabel := utils.ViewerZone(l.Zone(), slot.MasterTimezone)
+	date := utils.FormatDateInLocation(tzLabel, slot.StartTime)
+	startTime := utils.FormatTimeOnlyInLocation(tzLabel, slot.StartTime)
+	endTime := utils.FormatTimeOnlyInLocation(tzLabel, slot.EndTime)
+
+	// Получаем смещение таймзоны для отображения
+	tzOffset := utils.GetTimezoneOffset(tzLabel)
+
+	// Формируем время с таймзоной (таймзона только у времени, не у даты)
+	timeWithTZ := fmt.Sprintf("%s - %s (TZ: %s %s)", startTime, endTime, tzLabel, tzOffset)
+	timeWithTZ = utils.WithMasterTime(l, slot.MasterTimezone, slot.StartTime, slot.EndTime, timeWithTZ)
+
+	// Услуга с предоплатой: слот держится, пока клиент платит по ссылке, мастер узнает о записи после оплаты
+	status, hint := l.T("book.status_pending"), l.T("book.hint_pending")
+	var payKeyboard *models.InlineKeyboardMarkup
+	if p := created.Payment; p != nil && created.Status == "awaiting_payment" {
+		status = l.T("book.status_awaiting_payment")
+		hint = l.T("book.hint_payment", l.Money(p.Amount, p.Currency), utils.FormatTimeOnlyInLocation(tzLabel, p.ExpiresAt))
+		if p.ConfirmationURL != "" {
+			payKeyboard = &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+				{{Text: l.T("book.button.pay", l.Money(p.Amount, p.Currency)), URL: p.ConfirmationURL}},
+			}}
 		}
+	}
 
-		// Получаем смещение таймзоны для отображения
-		tzOffset := utils.GetTimezoneOffset(tzLabel)
-
-		// Формируем время с таймзоной (таймзона только у времени, не у даты)
-		timeWithTZ := fmt.Sprintf("%s - %s (TZ: %s %s)", startTime, endTime, tzLabel, tzOffset)
-
-		// Формируем детальное сообщение о записи
-		confirmText := fmt.Sprintf("%s✅ <b>Вы успешно записались!</b>\n\n"+
-			"<b>Детали записи:</b>\n"+
-			"<blockquote>"+
-			"<b>Мастер:</b> <code>%s %s</code>\n"+
-			"<b>Услуга:</b> <code>%s</code>\n"+
-			"<b>Дата:</b> <code>%s</code>\n"+
-			"<b>Время:</b> <code>%s</code>\n"+
-			"<b>Длительность:</b> <code>%d мин.</code>\n"+
-			"<b>Стоимость:</b> <code>%.0f руб.</code>\n"+
-			"<b>Статус:</b> <code>⏳ Ожидает подтверждения</code>\n"+
-			"</blockquote>\n\n"+
-			"<i>Ожидайте подтверждения записи от мастера</i>\n\n"+
-			"<i>Для просмотра всех ваших записей введите команду /allrecords</i>",
-			components.Header(),
-			slot.MasterName, slot.MasterSurname,
-			slot.ServiceName,
-			date,
-			timeWithTZ,
-			slot.ServiceDuration,
-			slot.ServicePrice)
-
-		// Удаляем исходное сообщение и отправляем новое (как в TryConfirmLogin)
-		if h.messageID != 0 {
-			log.Printf("Deleting message %d and sending new confirmation to user %d", h.messageID, h.userID)
-			_, _ = h.b.DeleteMessage(h.ctx, &bot.DeleteMessageParams{ChatID: h.userID, MessageID: h.messageID})
-		}
-		h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-			ChatID:    h.userID,
-			Text:      confirmText,
-			ParseMode: models.ParseModeHTML,
-		})
-		// Удаляем состояние сообщения с деталями слота после успешного бронирования
-		messageEditor.RemoveMessageState(h.userID, "slot_details")
-		h.answerCallBackQuery("✅ Заявка отправлена", false)
+	// Формируем детальное сообщение о записи
+	confirmText := components.Header() + l.T("book.created",
+		slot.MasterName, slot.MasterSurname,
+		slot.ServiceName,
+		date,
+		timeWithTZ,
+		slot.ServiceDuration,
+		l.Money(slot.ServicePrice, slot.ServiceCurrency),
+		status, hint)
+
+	// Удаляем исходное сообщение и отправляем новое (как в TryConfirmLogin)
+	if h.messageID != 0 {
+		log.Printf("Deleting message %d and sending new confirmation to user %d", h.messageID, h.userID)
+		_, _ = h.b.DeleteMessage(h.ctx, &bot.DeleteMessageParams{ChatID: h.userID, MessageID: h.messageID})
+	}
+	params := &bot.SendMessageParams{
+		ChatID:    h.userID,
+		Text:      confirmText,
+		ParseMode: models.ParseModeHTML,
 	}
+	if payKeyboard != nil {
+		params.ReplyMarkup = payKeyboard
+	}
+	h.b.SendMessage(h.ctx, params)
+	// Удаляем состояние сообщения с деталями слота после успешного бронирования
+	messageEditor.RemoveMessageState(h.ctx, h.userID, "slot_details")
+	h.answerCallBackQuery(l.T("book.sent"), false)
 }
 
-func (h *CallBackHandler) AllRecordsTime() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		mode := parts[1]
-		status := "all"
-		page := 1
-
-		// Определяем количество параметров и их значения
-		if len(parts) >= 4 {
-			// Формат: all_records_time/{mode}/{status}/{page}
-			status = parts[2]
-			if p, err := strconv.Atoi(parts[3]); err == nil {
-				page = p
-			}
-		}
-
-		svc := record.NewService(h.b, logrus.New())
-		if mode == "chooser" {
-			// Вернуться к выбору времени для всех записей
-			text := fmt.Sprintf("%s<b>Все мои записи</b>\n<i>Выберите пул записей для просмотра</i>", components.Header())
-			kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-				{{Text: "Будущие", CallbackData: "all_records_time/future/all/1"}},
-				{{Text: "Прошедшие", CallbackData: "all_records_time/past/all/1"}},
-			}}
-			_ = messageEditor.EditUserRecords(h.ctx, h.b, h.userID, "chooser", 1, text, kb)
-		} else {
-			// Показать все записи по времени
-			svc.EditUserRecordsTimePage(h.ctx, h.userID, h.userID, mode, status, page, h.messageID)
-		}
-		h.answerCallBackQuery("Обновлено", false)
+// AllRecordsTime — все записи по времени: {future|past|chooser}/{status}/{page}
+func (h *CallBackHandler) AllRecordsTime(p Params) {
+	mode, status, page := p.String(0), p.String(1), p.Int(2)
+	l := i18n.ForUser(h.ctx, h.userID)
+
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	if mode == "chooser" {
+		// Вернуться к выбору времени для всех записей
+		text := components.Header() + l.T("records.chooser")
+		kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("pool.future"), CallbackData: callbackdata.Encode(callbackdata.RouteAllRecordsTime, "future", "all", "1")}},
+			{{Text: l.T("pool.past"), CallbackData: callbackdata.Encode(callbackdata.RouteAllRecordsTime, "past", "all", "1")}},
+		}}
+		_ = messageEditor.EditUserRecords(h.ctx, h.b, h.userID, h.messageID, "chooser", 1, text, kb)
+	} else {
+		// Показать все записи по времени
+		svc.EditUserRecordsTimePage(h.ctx, h.userID, h.userID, mode, status, page, h.messageID)
 	}
+	h.answerCallBackQuery(l.T("callback.updated"), false)
 }
 
-func (h *CallBackHandler) RecordAction() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		action := parts[1] // confirm или reject
-		recordIDStr := parts[2]
-		userID := callbackQuery.From.ID
-
-		// Парсим recordID
-		recordID, err := strconv.ParseUint(recordIDStr, 10, 0)
-		fmt.Println(recordID)
-		if err != nil {
-			log.Printf("Invalid record ID in record_action callback: %s", recordIDStr)
-			h.answerCallBackQuery("Ошибка: неверный ID записи", false)
-			return
-		}
+// RecordAction подтверждает или отклоняет запись: {confirm|reject}/{recordID}/{masterTelegramID}.
+// Кнопка выдаётся мастеру записи, чужие нажатия отсекает ownedBy.
+func (h *CallBackHandler) RecordAction(p Params) {
+	action, recordID := p.String(0), p.Uint(1)
+	userID := h.userID
+	if action != "confirm" && action != "reject" {
+		h.answerStale()
+		return
+	}
 
-		// Отправляем запрос на изменение статуса записи
-		status := action
-		msg, ok := h.client.UpdateRecordStatus(h.ctx, uint(recordID), status)
-		if !ok {
-			log.Printf("UpdateRecordStatus failed: %s", msg)
-			h.answerCallBackQuery("Ошибка обновления статуса", false)
-			return
-		}
+	// Отправляем запрос на изменение статуса записи
+	status := action
+	if err := h.client.UpdateRecordStatus(h.ctx, recordID, status); err != nil {
+		log.Printf("UpdateRecordStatus failed: %v", err)
+		h.answerError(err)
+		return
+	}
 
-		var actionText string
-		var emoji string
-		if action == "confirm" {
-			actionText = "подтверждена"
-			emoji = "✅"
-		} else {
-			actionText = "отклонена"
-			emoji = "❌"
-		}
+	l := i18n.ForUser(h.ctx, userID)
+	emoji := "✅"
+	if action == "reject" {
+		emoji = "❌"
+	}
+	actionText := l.T("record.action." + action)
 
-		// Обновляем сообщение с результатом действия
-		newText := fmt.Sprintf("%s🆕 Новая запись\n<b>Запись %s</b>\n\n%s <b>Запись %s</b>",
-			components.Header(),
-			recordIDStr,
-			emoji,
-			actionText)
+	// Обновляем сообщение с результатом действия
+	newText := components.Header() + l.T("record.action.result", recordID, emoji, actionText)
 
-		// Убираем кнопки и показываем результат
-		keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
+	// Убираем кнопки и показываем результат
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 
-		// Редактируем сообщение, на котором была нажата кнопка
-		messageEditor.EditSpecificMessage(h.ctx, h.b, userID, h.messageID, newText, keyboard)
+	// Редактируем сообщение, на котором была нажата кнопка
+	messageEditor.EditSpecificMessage(h.ctx, h.b, userID, h.messageID, newText, keyboard)
 
-		// Используем AnswerCallbackQuery для уведомления пользователя
-		h.answerCallBackQuery(fmt.Sprintf("Запись %s", actionText), true)
-	}
+	// Используем AnswerCallbackQuery для уведомления пользователя
+	h.answerCallBackQuery(l.T("record.action.toast", actionText), true)
 }
 
-// AccountDeletion обрабатывает callback'и для удаления аккаунта
-func (h *CallBackHandler) AccountDeletion() {
-	parts := strings.Split(h.query, "/")
-	if len(parts) < 2 {
-		h.answerCallBackQuery("Ошибка в данных", true)
+// DigestRecordAction подтверждает или отклоняет заявку из сводки мастера: {confirm|reject}/{recordID}/{masterTelegramID}.
+// Текст сводки остаётся, из клавиатуры убирается строка обработанной заявки.
+func (h *CallBackHandler) DigestRecordAction(p Params) {
+	action, recordID := p.String(0), p.Uint(1)
+	if action != "confirm" && action != "reject" {
+		h.answerStale()
+		return
+	}
+	if err := h.client.UpdateRecordStatus(h.ctx, recordID, action); err != nil {
+		log.Printf("UpdateRecordStatus failed: %v", err)
+		h.answerError(err)
 		return
 	}
 
-	action := parts[1]
-	userID := h.userID
+	id := strconv.FormatUint(uint64(recordID), 10)
+	rows := [][]models.InlineKeyboardButton{}
+	if msg := h.update.CallbackQuery.Message.Message; msg != nil && msg.ReplyMarkup != nil {
+		for _, row := range msg.ReplyMarkup.InlineKeyboard {
+			if len(row) > 0 && row[0].CallbackData == callbackdata.Encode(callbackdata.RouteDigestAction, "confirm", id, p.String(2)) {
+				continue
+			}
+			rows = append(rows, row)
+		}
+	}
+	h.b.EditMessageReplyMarkup(h.ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      h.userID,
+		MessageID:   h.messageID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	})
 
-	switch action {
-	case "cancel":
-		// Отменяем удаление аккаунта
-		messageEditor := message.NewMessageEditor()
-		newText := "❌ <b>Удаление аккаунта отменено</b>\n\nВаш аккаунт остается активным. Если у вас есть вопросы, обратитесь в поддержку."
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("record.action.digest_"+action, recordID), false)
+}
 
-		// Убираем кнопки
-		keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
+// AccountDeletionCancel отменяет удаление аккаунта
+func (h *CallBackHandler) AccountDeletionCancel(Params) {
+	l := i18n.ForUser(h.ctx, h.userID)
+	newText := l.T("account.delete_cancelled")
 
-		// Редактируем сообщение
-		messageEditor.EditSpecificMessage(h.ctx, h.b, userID, h.messageID, newText, keyboard)
-		h.answerCallBackQuery("Удаление отменено", false)
+	// Убираем кнопки
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 
-	case "confirm":
-		if len(parts) < 3 {
-			h.answerCallBackQuery("Ошибка: не указан ID пользователя", true)
-			return
-		}
+	// Редактируем сообщение
+	messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, newText, keyboard)
+	h.answerCallBackQuery(l.T("account.delete_cancelled_short"), false)
+}
 
-		userUUID := parts[2]
+// AccountDeletionConfirm подтверждает удаление аккаунта: {userUUID}/{telegramID}.
+// Кнопка выдаётся владельцу аккаунта, чужие нажатия отсекает ownedBy.
+func (h *CallBackHandler) AccountDeletionConfirm(p Params) {
+	// Отправляем запрос на подтверждение удаления в бэкенд
+	if err := h.client.ConfirmAccountDeletion(h.ctx, p.String(0)); err != nil {
+		log.Printf("AccountDeletion confirm error: %v", err)
+		h.answerError(err)
+		return
+	}
 
-		// Отправляем запрос на подтверждение удаления в бэкенд
-		err := h.client.ConfirmAccountDeletion(userUUID)
-		if err != nil {
-			log.Printf("AccountDeletion confirm error: %v", err)
-			h.answerCallBackQuery("Ошибка при удалении аккаунта", true)
-			return
-		}
+	// Показываем сообщение об успешном удалении
+	l := i18n.ForUser(h.ctx, h.userID)
+	newText := l.T("account.deleted")
 
-		// Показываем сообщение об успешном удалении
-		messageEditor := message.NewMessageEditor()
-		newText := "✅ <b>Аккаунт успешно удален</b>\n\nВсе ваши данные были безвозвратно удалены из системы. Спасибо за использование нашего сервиса!"
+	// Убираем кнопки
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 
-		// Убираем кнопки
-		keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
+	// Редактируем сообщение
+	messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, newText, keyboard)
+	h.answerCallBackQuery(l.T("account.deleted_short"), false)
+}
+
+// RecordCard — карточка записи клиента с действиями: {recordID}.
+// Возврат к карточке прерывает ввод комментария или отзыва.
+func (h *CallBackHandler) RecordCard(p Params) {
+	if sess, ok := fsm.GetMachine().Current(h.userID); ok && (sess.Flow == record.FlowComment || sess.Flow == record.FlowReview) {
+		_ = fsm.GetMachine().Finish(h.userID)
+	}
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.ShowRecord(h.ctx, h.userID, p.Uint(0), h.messageID, ""), "")
+}
+
+// RecordCancel — отмена записи клиентом: {recordID}/{ask|yes}
+func (h *CallBackHandler) RecordCancel(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	switch p.String(1) {
+	case "ask":
+		h.recordResult(svc.AskCancel(h.ctx, h.userID, p.Uint(0), h.messageID), "")
+	case "yes":
+		h.recordResult(svc.Cancel(h.ctx, h.userID, p.Uint(0), h.messageID), "record.cancelled")
+	default:
+		h.answerStale()
+	}
+}
+
+// RecordSlots — свободные слоты мастера для переноса записи: {recordID}/{page}
+func (h *CallBackHandler) RecordSlots(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.ShowSlots(h.ctx, h.userID, p.Uint(0), p.Int(1), h.messageID), "")
+}
+
+// RecordMove — перенос записи на выбранный слот: {recordID}/{slotID}
+func (h *CallBackHandler) RecordMove(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.Reschedule(h.ctx, h.userID, p.Uint(0), p.Uint(1), h.messageID), "record.rescheduled")
+}
 
-		// Редактируем сообщение
-		messageEditor.EditSpecificMessage(h.ctx, h.b, userID, h.messageID, newText, keyboard)
-		h.answerCallBackQuery("Аккаунт удален", false)
+// RecordComment — начало ввода комментария к записи: {recordID}
+func (h *CallBackHandler) RecordComment(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.AskComment(h.ctx, h.userID, p.Uint(0), h.messageID), "")
+}
+
+// RecordCalendar — файл .ics с записью: {recordID}
+func (h *CallBackHandler) RecordCalendar(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.SendCalendar(h.ctx, h.userID, p.Uint(0)), "")
+}
 
+// Review — оценка визита: {recordID}/{rating}; 0 — показать выбор оценки.
+// Права проверяет API: оценить можно только свою состоявшуюся запись.
+func (h *CallBackHandler) Review(p Params) {
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	rating := p.Int(1)
+	switch {
+	case rating == 0:
+		h.recordResult(svc.AskRating(h.ctx, h.userID, p.Uint(0), h.messageID), "")
+	case rating >= 1 && rating <= 5:
+		h.recordResult(svc.Rate(h.ctx, h.userID, p.Uint(0), rating, h.messageID), "")
 	default:
-		h.answerCallBackQuery("Неизвестное действие", true)
+		h.answerStale()
+	}
+}
+
+// ReviewText — начало ввода текста отзыва: {recordID}/{rating}
+func (h *CallBackHandler) ReviewText(p Params) {
+	rating := p.Int(1)
+	if rating < 1 || rating > 5 {
+		h.answerStale()
+		return
+	}
+	svc := record.NewService(h.b, logrus.New(), h.client)
+	h.recordResult(svc.AskReviewText(h.ctx, h.userID, p.Uint(0), rating, h.messageID), "")
+}
+
+// recordResult отвечает на нажатие кнопки действия с записью:
+// при ошибке — причиной во всплывающем окне, иначе текстом по ключу done
+func (h *CallBackHandler) recordResult(err error, done string) {
+	l := i18n.ForUser(h.ctx, h.userID)
+	if err != nil {
+		log.Printf("record action failed: %v", err)
+		h.answerCallBackQuery(record.UserMessage(l, err), true)
+		return
+	}
+	text := ""
+	if done != "" {
+		text = l.T(done)
 	}
+	h.answerCallBackQuery(text, false)
 }
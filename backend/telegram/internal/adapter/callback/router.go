@@ -0,0 +1,140 @@
+package callback
+
+import (
+	"errors"
+	"log"
+	"strconv"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/i18n"
+
+	"github.com/google/uuid"
+)
+
+// Kind — тип аргумента маршрута; аргументы проверяются до вызова обработчика
+type Kind int
+
+const (
+	KindString Kind = iota
+	KindInt
+	KindInt64
+	KindUint
+	KindUUID
+)
+
+var errForbidden = errors.New("callback: action not allowed for this user")
+
+// Params — проверенные аргументы нажатия. Типы совпадают с Route.Params,
+// поэтому методы не возвращают ошибок.
+type Params struct {
+	args []string
+}
+
+func (p Params) String(i int) string { return p.args[i] }
+
+func (p Params) Int(i int) int {
+	v, _ := strconv.Atoi(p.args[i])
+	return v
+}
+
+func (p Params) Int64(i int) int64 {
+	v, _ := strconv.ParseInt(p.args[i], 10, 64)
+	return v
+}
+
+func (p Params) Uint(i int) uint {
+	v, _ := strconv.ParseUint(p.args[i], 10, 0)
+	return uint(v)
+}
+
+// Route — обработчик нажатий одного маршрута
+type Route struct {
+	Name   string
+	Params []Kind
+	// Authorize проверяет, что нажавший вправе выполнить действие; nil — доступно всем
+	Authorize func(h *CallBackHandler, p Params) error
+	Handle    func(h *CallBackHandler, p Params)
+}
+
+// Router находит маршрут по подписанным данным кнопки
+type Router struct {
+	routes map[string]Route
+}
+
+func NewRouter() *Router {
+	return &Router{routes: make(map[string]Route)}
+}
+
+// Handle регистрирует маршрут; повторная регистрация имени — ошибка программы
+func (r *Router) Handle(route Route) *Router {
+	if _, exists := r.routes[route.Name]; exists {
+		panic("callback: route registered twice: " + route.Name)
+	}
+	r.routes[route.Name] = route
+	return r
+}
+
+// Dispatch проверяет подпись, аргументы и права и вызывает обработчик маршрута
+func (r *Router) Dispatch(h *CallBackHandler) {
+	payload, err := callbackdata.Decode(h.ctx, h.query)
+	if err != nil {
+		if errors.Is(err, callbackdata.ErrSignature) {
+			log.Printf("Callback with bad signature from user %d: %q", h.userID, h.query)
+		}
+		h.answerStale()
+		return
+	}
+	route, ok := r.routes[payload.Route]
+	if !ok {
+		log.Printf("Unknown callback route %q from user %d", payload.Route, h.userID)
+		h.answerStale()
+		return
+	}
+	params, err := parseParams(route.Params, payload.Args)
+	if err != nil {
+		log.Printf("Invalid %s callback args %q: %v", route.Name, payload.Args, err)
+		h.answerStale()
+		return
+	}
+	if route.Authorize != nil {
+		if err := route.Authorize(h, params); err != nil {
+			log.Printf("Callback %s denied for user %d: %v", route.Name, h.userID, err)
+			h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.forbidden"), true)
+			return
+		}
+	}
+	route.Handle(h, params)
+}
+
+func parseParams(kinds []Kind, args []string) (Params, error) {
+	if len(args) != len(kinds) {
+		return Params{}, errors.New("wrong number of arguments")
+	}
+	for i, kind := range kinds {
+		var err error
+		switch kind {
+		case KindInt:
+			_, err = strconv.Atoi(args[i])
+		case KindInt64:
+			_, err = strconv.ParseInt(args[i], 10, 64)
+		case KindUint:
+			_, err = strconv.ParseUint(args[i], 10, 0)
+		case KindUUID:
+			_, err = uuid.Parse(args[i])
+		}
+		if err != nil {
+			return Params{}, err
+		}
+	}
+	return Params{args: args}, nil
+}
+
+// ownedBy разрешает действие только пользователю, чей Telegram ID
+// записан в аргументе i. Аргумент подписан ботом, поэтому подменить его нельзя.
+func ownedBy(i int) func(h *CallBackHandler, p Params) error {
+	return func(h *CallBackHandler, p Params) error {
+		if p.Int64(i) != h.userID {
+			return errForbidden
+		}
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package callback
+
+import (
+	"errors"
+	"telegram-bot/internal/callbackdata"
+	"testing"
+)
+
+func TestParseParams(t *testing.T) {
+	kinds := []Kind{KindString, KindUint, KindInt64}
+	p, err := parseParams(kinds, []string{"confirm", "42", "-100"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.String(0) != "confirm" || p.Uint(1) != 42 || p.Int64(2) != -100 {
+		t.Fatalf("params = %v", p.args)
+	}
+
+	for _, args := range [][]string{
+		{"confirm", "42"},
+		{"confirm", "-1", "100"},
+		{"confirm", "42", "abc"},
+	} {
+		if _, err := parseParams(kinds, args); err == nil {
+			t.Errorf("parseParams(%q) error = nil", args)
+		}
+	}
+	if _, err := parseParams([]Kind{KindUUID}, []string{"not-a-uuid"}); err == nil {
+		t.Error("invalid UUID accepted")
+	}
+}
+
+func TestOwnedBy(t *testing.T) {
+	p, _ := parseParams([]Kind{KindString, KindUint, KindInt64}, []string{"confirm", "7", "111"})
+	check := ownedBy(2)
+
+	if err := check(&CallBackHandler{userID: 111}, p); err != nil {
+		t.Fatalf("owner denied: %v", err)
+	}
+	if err := check(&CallBackHandler{userID: 222}, p); !errors.Is(err, errForbidden) {
+		t.Fatalf("stranger error = %v, want errForbidden", err)
+	}
+}
+
+// Кнопки действий с записью, слотом или услугой привязаны к пользователю, которому выданы
+func TestActionRoutesAreOwned(t *testing.T) {
+	for _, name := range []string{
+		callbackdata.RouteMasterDate, callbackdata.RouteSlot, callbackdata.RouteBook, callbackdata.RouteSlotsTime,
+		callbackdata.RouteRecordCard, callbackdata.RouteRecordCancel, callbackdata.RouteRecordSlots,
+		callbackdata.RouteRecordMove, callbackdata.RouteRecordComment, callbackdata.RouteRecordCalendar,
+		callbackdata.RouteReview, callbackdata.RouteReviewText, callbackdata.RouteRecordAction,
+		callbackdata.RouteDigestAction, callbackdata.RouteDigest, callbackdata.RouteDeleteConfirm,
+		callbackdata.RouteManage, callbackdata.RouteUpcomingPage,
+	} {
+		route, ok := routes.routes[name]
+		if !ok {
+			t.Errorf("route %q is not registered", name)
+			continue
+		}
+		if route.Authorize == nil {
+			t.Errorf("route %q has no Authorize", name)
+		}
+	}
+}
@@ -1,166 +1,89 @@
 package callback
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"strconv"
-	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/handlers/components"
 	"telegram-bot/internal/handlers/shared"
-	"telegram-bot/internal/utils"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 )
 
-func (h *CallBackHandler) SlotMove() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 2 {
-		slotIDStr := parts[1]
-		slotID, err := strconv.Atoi(slotIDStr)
-		if err != nil {
-			log.Printf("Invalid slot ID in callback: %s", slotIDStr)
-			return
-		}
-		slot, ok := h.client.GetSlotByID(h.ctx, uint(slotID))
-		fmt.Printf("%+v", slot)
-		if !ok {
-			log.Printf("Invalid get slot: %s", slotIDStr)
-			return
-		}
-		log.Printf("User %d selected slot: %d", h.userID, slotID)
-
-		var statusSlot string
-		if !slot.IsBooked {
-			statusSlot = "Свободен"
-		} else {
-			statusSlot = "Забронирован"
-		}
-		// Форматируем время с учетом таймзоны мастера
-		date := utils.FormatDateInLocation(slot.MasterTimezone, slot.StartTime)
-		startTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.StartTime)
-		endTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.EndTime)
-
-		// Получаем метку таймзоны для отображения (обязательно таймзона мастера)
-		tzLabel := slot.MasterTimezone
-		if tzLabel == "" {
-			tzLabel = "Europe/Moscow" // на московскую таймзону, если таймзона не указана
-		}
-
-		// Получаем смещение таймзоны для отображения
-		tzOffset := utils.GetTimezoneOffset(tzLabel)
-
-		// Формируем inline-кнопки действий
-		buttons := [][]models.InlineKeyboardButton{}
-		if !slot.IsBooked {
-			buttons = append(buttons, []models.InlineKeyboardButton{{
-				Text:         "📝 Записаться",
-				CallbackData: fmt.Sprintf("book/%d", slotID),
-			}})
-		}
-
-		// Добавляем кнопку "Назад к слотам"
-		buttons = append(buttons, []models.InlineKeyboardButton{{
-			Text:         "⬅️ Назад к слотам",
-			CallbackData: fmt.Sprintf("back_to_slots/%d/%s/1", slot.MasterTelegramID, date),
-		}})
-		keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
-
-		// Формируем время с таймзоной (таймзона только у времени, не у даты)
-		timeWithTZ := fmt.Sprintf("%s — %s (TZ: %s %s)", startTime, endTime, tzLabel, tzOffset)
-		slotDetailsText := fmt.Sprintf("%sПользователь:\n<code>%s %s</code>\n\nСлот:\n<blockquote><code>%s</code> [ %s ]\nДата: <code>%s</code>\nУслуга: <code>%s</code>\nДополнительно:\n %d мин. / %s руб.\n</blockquote>\n\n", components.Header(),
-			slot.MasterName, slot.MasterSurname, timeWithTZ, statusSlot, date, slot.ServiceName, slot.ServiceDuration, formatFloat(slot.ServicePrice))
-
-		// Редактируем сообщение, на котором была нажата кнопка
-		if h.messageID != 0 {
-			log.Printf("Editing message %d for user %d - slot details", h.messageID, h.userID)
-			err = messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, slotDetailsText, keyboard)
-			if err != nil {
-				log.Printf("Failed to edit slot details message: %v", err)
-			}
-		} else {
-			log.Printf("MessageID is 0, sending new message to user %d - slot details", h.userID)
-			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-				ChatID:      h.userID,
-				Text:        slotDetailsText,
-				ParseMode:   models.ParseModeHTML,
-				ReplyMarkup: keyboard,
-			})
-		}
-
-		// Отвечаем на callback query
-		h.answerCallBackQuery("Выбран слот", false)
+// SlotMove показывает карточку слота: {slotID}
+func (h *CallBackHandler) SlotMove(p Params) {
+	slotID := p.Uint(0)
+	slot, err := h.client.GetSlotByID(h.ctx, slotID)
+	if errors.Is(err, adapter.ErrUnavailable) {
+		log.Printf("GetSlotByID %d: %v", slotID, err)
+		h.answerError(err)
+		return
 	}
-}
+	if err != nil {
+		log.Printf("Invalid get slot %d: %v", slotID, err)
+		h.answerStale()
+		return
+	}
+	log.Printf("User %d selected slot: %d", h.userID, slotID)
 
-func (h *CallBackHandler) BackToSlots() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 4 {
-		masterTelegramStr := parts[1]
-		targetDate := parts[2]
-		pageStr := parts[3]
-		page, err := strconv.Atoi(pageStr)
-		if err != nil {
-			log.Printf("Invalid page number in back_to_slots callback: %s", pageStr)
-			return
-		}
+	l := i18n.ForUser(h.ctx, h.userID)
+	slotDetailsText, keyboard := shared.SlotCard(l, h.userID, slot)
 
-		masterTelegramID, err := strconv.ParseInt(masterTelegramStr, 10, 64)
+	// Редактируем сообщение, на котором была нажата кнопка
+	if h.messageID != 0 {
+		log.Printf("Editing message %d for user %d - slot details", h.messageID, h.userID)
+		err = messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, slotDetailsText, keyboard)
 		if err != nil {
-			log.Printf("Invalid master telegram id in back_to_slots callback: %s", masterTelegramStr)
-			return
+			log.Printf("Failed to edit slot details message: %v", err)
 		}
-
-		log.Printf("Going back to slots for date: %s, page: %d for user: %d, master: %d", targetDate, page, h.userID, masterTelegramID)
-
-		// Удаляем состояние деталей слота
-		messageEditor.RemoveMessageState(h.userID, "slot_details")
-
-		// Показываем слоты (это будет редактировать сообщение пагинации)
-		shared.SendPaginatedSlots(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
-		h.answerCallBackQuery("Возврат к слотам", false)
+	} else {
+		log.Printf("MessageID is 0, sending new message to user %d - slot details", h.userID)
+		h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+			ChatID:      h.userID,
+			Text:        slotDetailsText,
+			ParseMode:   models.ParseModeHTML,
+			ReplyMarkup: keyboard,
+		})
 	}
+
+	// Отвечаем на callback query
+	h.answerCallBackQuery(l.T("callback.slot_selected"), false)
 }
 
-func (h *CallBackHandler) SlotsTime() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		mode := parts[1]
-		masterIDStr := parts[2]
-		page := 1
+// BackToSlots возвращает из карточки слота к списку: {masterTelegramID}/{date}/{page}
+func (h *CallBackHandler) BackToSlots(p Params) {
+	masterTelegramID, targetDate, page := p.Int64(0), p.String(1), p.Int(2)
 
-		// Определяем количество параметров и их значения
-		if len(parts) >= 4 {
-			// Формат: slots_time/{mode}/{masterID}/{page}
-			if p, err := strconv.Atoi(parts[3]); err == nil {
-				page = p
-			}
-		}
+	log.Printf("Going back to slots for date: %s, page: %d for user: %d, master: %d", targetDate, page, h.userID, masterTelegramID)
 
-		masterID, err := strconv.ParseInt(masterIDStr, 10, 64)
-		if err != nil {
-			log.Printf("Invalid master ID in slots_time callback: %s", masterIDStr)
-			return
-		}
+	// Удаляем состояние деталей слота
+	messageEditor.RemoveMessageState(h.ctx, h.userID, "slot_details")
 
-		if mode == "chooser" {
-			// Вернуться к выбору времени
-			text := fmt.Sprintf("%s<b>Мои слоты</b>\n<i>Выберите пул слотов для просмотра</i>", components.Header())
-			kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-				{{Text: "Будущие", CallbackData: fmt.Sprintf("slots_time/future/%d/1", masterID)}},
-				{{Text: "Прошедшие", CallbackData: fmt.Sprintf("slots_time/past/%d/1", masterID)}},
-			}}
-			_ = messageEditor.EditSlotsPagination(h.ctx, h.b, h.userID, masterID, "chooser", 1, text, kb)
-		} else {
-			// Показать слоты по времени
-			shared.SendSlotsByTime(h.ctx, h.b, h.userID, masterID, mode, page, h.messageID)
-		}
-		h.answerCallBackQuery("Обновлено", false)
+	// Показываем слоты (это будет редактировать сообщение пагинации)
+	shared.SendPaginatedSlots(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.back_to_slots"), false)
+}
+
+// SlotsTime — слоты мастера по времени: {future|past|chooser}/{masterTelegramID}/{page}
+func (h *CallBackHandler) SlotsTime(p Params) {
+	mode, masterID, page := p.String(0), p.Int64(1), p.Int(2)
+	l := i18n.ForUser(h.ctx, h.userID)
+
+	if mode == "chooser" {
+		// Вернуться к выбору времени
+		text := components.Header() + l.T("slots.chooser")
+		kb := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+			{{Text: l.T("pool.future"), CallbackData: callbackdata.Encode(callbackdata.RouteSlotsTime, "future", strconv.FormatInt(masterID, 10), "1")}},
+			{{Text: l.T("pool.past"), CallbackData: callbackdata.Encode(callbackdata.RouteSlotsTime, "past", strconv.FormatInt(masterID, 10), "1")}},
+		}}
+		_ = messageEditor.EditSlotsPagination(h.ctx, h.b, h.userID, h.messageID, masterID, "chooser", 1, text, kb)
+	} else {
+		// Показать слоты по времени
+		shared.SendSlotsByTime(h.ctx, h.b, h.userID, masterID, mode, page, h.messageID)
 	}
+	h.answerCallBackQuery(l.T("callback.updated"), false)
 }
@@ -30,14 +30,14 @@ func NewCallBackHandler(handler Handler,
 }
 
 type Handler struct {
-	client adapter.Client
+	client *adapter.Client
 	config config.Config
 	ctx    context.Context
 	b      *bot.Bot
 	update *models.Update
 }
 
-func NewHandler(client adapter.Client,
+func NewHandler(client *adapter.Client,
 	config config.Config,
 	ctx context.Context,
 	b *bot.Bot,
@@ -3,31 +3,75 @@ package callback
 import (
 	"context"
 	"log"
-	"strings"
 	adapter "telegram-bot/internal/adapter/backendapi"
 	mybot "telegram-bot/internal/bot"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/config"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
-	"github.com/sirupsen/logrus"
 )
 
-// UniversalHandler обработчик всех Inline/Reply keyboard нажатий
-func UniversalHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
-	cfg := config.Load()
-	client := adapter.New(cfg.BackendBaseURL, logrus.New())
-	handler := NewHandler(*client, cfg, ctx, b, update)
-	if update == nil {
-		log.Printf("UniversalHandler: " + "error, update is nil")
-		return
+// routes — все маршруты inline-кнопок. Права проверяются здесь, до обработчика:
+// ownedBy — кнопка выдана конкретному пользователю (клиенту или мастеру записи, владельцу
+// аккаунта, слота или услуги). Остальные маршруты показывают нажавшему его собственные
+// данные или настройки и доступны любому.
+var routes = NewRouter().
+	Handle(Route{Name: callbackdata.RouteNoop, Handle: (*CallBackHandler).Noop}).
+	Handle(Route{Name: callbackdata.RouteConfirmLogin, Handle: (*CallBackHandler).TryConfirmLogin}).
+	Handle(Route{Name: callbackdata.RouteTimezone, Params: []Kind{KindString}, Handle: (*CallBackHandler).SelectTimezone}).
+	Handle(Route{Name: callbackdata.RouteTimezoneGroups, Handle: (*CallBackHandler).TimezoneRegions}).
+	Handle(Route{Name: callbackdata.RouteTimezoneRegion, Params: []Kind{KindString, KindInt}, Handle: (*CallBackHandler).TimezoneRegion}).
+	Handle(Route{Name: callbackdata.RouteTimezoneSearch, Params: []Kind{KindString, KindInt}, Handle: (*CallBackHandler).TimezoneSearch}).
+	Handle(Route{Name: callbackdata.RouteTimezoneLocate, Handle: (*CallBackHandler).TimezoneLocate}).
+	Handle(Route{Name: callbackdata.RouteLanguage, Params: []Kind{KindString}, Handle: (*CallBackHandler).SelectLanguage}).
+	Handle(Route{Name: callbackdata.RouteDigest, Params: []Kind{KindString, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).SelectDigest}).
+	Handle(Route{Name: callbackdata.RouteMasterDate, Params: []Kind{KindInt64, KindString, KindInt}, Authorize: ownedBy(0), Handle: (*CallBackHandler).DateMove}).
+	Handle(Route{Name: callbackdata.RouteClientDate, Params: []Kind{KindInt64, KindString, KindInt}, Handle: (*CallBackHandler).DateMoveClient}).
+	Handle(Route{Name: callbackdata.RouteSlot, Params: []Kind{KindUint, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).SlotMove}).
+	Handle(Route{Name: callbackdata.RouteBook, Params: []Kind{KindUint, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).BookMove}).
+	Handle(Route{Name: callbackdata.RouteBackToSlots, Params: []Kind{KindInt64, KindString, KindInt}, Handle: (*CallBackHandler).BackToSlots}).
+	Handle(Route{Name: callbackdata.RouteRecords, Params: []Kind{KindString, KindInt}, Handle: (*CallBackHandler).Records}).
+	Handle(Route{Name: callbackdata.RouteRecordsTime, Params: []Kind{KindString, KindString, KindInt}, Handle: (*CallBackHandler).RecordsTime}).
+	Handle(Route{Name: callbackdata.RouteAllRecordsTime, Params: []Kind{KindString, KindString, KindInt}, Handle: (*CallBackHandler).AllRecordsTime}).
+	Handle(Route{Name: callbackdata.RouteSlotsTime, Params: []Kind{KindString, KindInt64, KindInt}, Authorize: ownedBy(1), Handle: (*CallBackHandler).SlotsTime}).
+	Handle(Route{Name: callbackdata.RouteClientSlots, Params: []Kind{KindInt64, KindInt}, Handle: (*CallBackHandler).ClientSlots}).
+	Handle(Route{Name: callbackdata.RouteRecordCard, Params: []Kind{KindUint, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).RecordCard}).
+	Handle(Route{Name: callbackdata.RouteRecordCancel, Params: []Kind{KindUint, KindString, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).RecordCancel}).
+	Handle(Route{Name: callbackdata.RouteRecordSlots, Params: []Kind{KindUint, KindInt, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).RecordSlots}).
+	Handle(Route{Name: callbackdata.RouteRecordMove, Params: []Kind{KindUint, KindUint, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).RecordMove}).
+	Handle(Route{Name: callbackdata.RouteRecordComment, Params: []Kind{KindUint, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).RecordComment}).
+	Handle(Route{Name: callbackdata.RouteRecordCalendar, Params: []Kind{KindUint, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).RecordCalendar}).
+	Handle(Route{Name: callbackdata.RouteReview, Params: []Kind{KindUint, KindInt, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).Review}).
+	Handle(Route{Name: callbackdata.RouteReviewText, Params: []Kind{KindUint, KindInt, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).ReviewText}).
+	Handle(Route{Name: callbackdata.RouteRecordAction, Params: []Kind{KindString, KindUint, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).RecordAction}).
+	Handle(Route{Name: callbackdata.RouteDigestAction, Params: []Kind{KindString, KindUint, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).DigestRecordAction}).
+	Handle(Route{Name: callbackdata.RouteDeleteCancel, Handle: (*CallBackHandler).AccountDeletionCancel}).
+	Handle(Route{Name: callbackdata.RouteDeleteConfirm, Params: []Kind{KindUUID, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).AccountDeletionConfirm}).
+	Handle(Route{Name: callbackdata.RouteManage, Params: []Kind{KindString, KindString, KindInt64}, Authorize: ownedBy(2), Handle: (*CallBackHandler).Manage}).
+	Handle(Route{Name: callbackdata.RouteUpcomingPage, Params: []Kind{KindInt, KindInt64}, Authorize: ownedBy(1), Handle: (*CallBackHandler).UpcomingPage})
+
+// UniversalHandler возвращает обработчик всех Inline/Reply keyboard нажатий.
+// Клиент API общий для всех нажатий: создаётся один раз при старте бота.
+func UniversalHandler(client *adapter.Client, cfg config.Config) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update == nil {
+			log.Printf("UniversalHandler: " + "error, update is nil")
+			return
+		}
+		handleUpdate(NewHandler(client, cfg, ctx, b, update))
 	}
+}
+
+func handleUpdate(handler *Handler) {
+	ctx, b, update := handler.ctx, handler.b, handler.update
 
 	// rate limit
 	if update.CallbackQuery != nil {
 		userID := update.CallbackQuery.From.ID
 		if !mybot.CanExecuteCallback(userID) {
-			answerCallBackQuery(ctx, b, update, "⏳ Слишком быстро! Подождите немного.", true)
+			answerCallBackQuery(ctx, b, update, i18n.ForUser(ctx, userID).T("callback.too_fast"), true)
 			return
 		}
 	}
@@ -37,6 +81,12 @@ func UniversalHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 		handler.ContactHandler()
 	}
 
+	// Геопозиция, отправленная кнопкой выбора таймзоны
+	if update.Message != nil && update.Message.Location != nil && update.Message.From != nil &&
+		update.Message.Chat.Type == models.ChatTypePrivate {
+		handler.LocationHandler()
+	}
+
 	// Обработка событий нажатия на InlineKeyboard
 	if update.CallbackQuery != nil {
 		callbackQuery := update.CallbackQuery
@@ -55,74 +105,6 @@ func UniversalHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 		if callbackQuery.Message.Message != nil {
 			messageID = callbackQuery.Message.Message.ID
 		}
-		callbackHandler := NewCallBackHandler(*handler, messageID, userID, callbackData)
-
-		// Событие Подтвердить вход
-		if callbackData == "ConfirmLogin" {
-			callbackHandler.TryConfirmLogin()
-		}
-		// Выбор таймзоны: tz/{?}
-		if strings.HasPrefix(callbackData, "tz/") {
-			callbackHandler.SelectTimezone()
-		}
-		// Обработка навигации по датам и страницам слотов
-		if strings.HasPrefix(callbackData, "date/") {
-			callbackHandler.DateMove()
-		}
-		// Обработка навигации по датам для клиентского просмотра (только будущие)
-		if strings.HasPrefix(callbackData, "client_date/") {
-			callbackHandler.DateMoveClient()
-		}
-		// Обработка выбора конкретного слота
-		if strings.HasPrefix(callbackData, "slot/") {
-			callbackHandler.SlotMove()
-		}
-		// Обработка создания записи на слот
-		if strings.HasPrefix(callbackData, "book/") {
-			callbackHandler.BookMove()
-		}
-		// Обработка кнопки "Назад к слотам"
-		if strings.HasPrefix(callbackData, "back_to_slots/") {
-			callbackHandler.BackToSlots()
-		}
-		// Обработка неактивных кнопок
-		if callbackData == "noop" {
-			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: callbackQuery.ID,
-				Text:            "Текущая страница",
-			})
-		}
-		// Пагинация по записям пользователя: records/{status}/{page}
-		if strings.HasPrefix(callbackData, "records/") {
-			callbackHandler.Records()
-		}
-		// Пулы записей по времени: records_time/{future|past|chooser}/{status}/{page}
-		if strings.HasPrefix(callbackData, "records_time/") {
-			callbackHandler.RecordsTime()
-		}
-		// Обработка слотов по времени: slots_time/{future|past|chooser}/{masterID}/{page}
-		if strings.HasPrefix(callbackData, "slots_time/") {
-			callbackHandler.SlotsTime()
-		}
-		// Обработка клиентских слотов: client_slots/{masterID}/{page}
-		if strings.HasPrefix(callbackData, "client_slots/") {
-			callbackHandler.ClientSlots()
-		}
-		// Обработка всех записей по времени: all_records_time/{future|past|chooser}/{status}/{page}
-		if strings.HasPrefix(callbackData, "all_records_time/") {
-			callbackHandler.AllRecordsTime()
-		}
-		// Обработка действий с записями: record_action/{confirm|reject}/{recordID}
-		if strings.HasPrefix(callbackData, "record_action/") {
-			callbackHandler.RecordAction()
-		}
-		// Обработка удаления аккаунта: account_deletion/{cancel|confirm}/{userUUID}
-		if strings.HasPrefix(callbackData, "account_deletion/") {
-			callbackHandler.AccountDeletion()
-		}
-		// Обработка пагинации предстоящих записей мастера: upcoming_page/{page}/{telegramID}
-		if strings.HasPrefix(callbackData, "upcoming_page/") {
-			callbackHandler.UpcomingPage()
-		}
+		routes.Dispatch(NewCallBackHandler(*handler, messageID, userID, callbackData))
 	}
 }
@@ -1,13 +1,17 @@
 package callback
 
 import (
-	"fmt"
+	"html"
 	"log"
-	"strconv"
 	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/config"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/handlers/digest"
 	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/handlers/timezone"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/zones"
 	"telegram-bot/pkg/encrypt"
 	mymodels "telegram-bot/pkg/models"
 
@@ -18,44 +22,40 @@ import (
 func (h *Handler) ContactHandler() {
 	contact := h.update.Message.Contact
 	userID := h.update.Message.From.ID
+	l := i18n.ForUser(h.ctx, userID)
 	if contact.UserID != userID {
 		h.b.SendMessage(h.ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgIdDoesntMatch,
+			Text:      components.Header() + l.T("auth.contact_mismatch"),
 		})
 		return
 	}
 
-	token, err := encrypt.GenerateToken(userID, contact.PhoneNumber)
+	// Токен подписывает тот же номер, что уйдёт в API
+	phone := contactPhone(contact.PhoneNumber)
+	token, err := encrypt.GenerateToken(userID, phone)
 	if err != nil {
 		log.Printf("UniversalHandler: " + "error with generate token")
 		return
 	}
 
 	userRequest := mymodels.UserRegister{
-		Phone:      contact.PhoneNumber,
+		Phone:      phone,
 		TelegramID: userID,
 		FirstName:  contact.FirstName,
 		Surname:    contact.LastName,
 		Token:      token,
 		Active:     true,
+		Language:   h.update.Message.From.LanguageCode,
 	}
 	var msgText string
-	message, success := h.client.RegisterUser(h.ctx, userRequest)
-	if !success {
-		msgText = fmt.Sprintf(
-			"%s"+"⚠️ Ошибка\n<i>Не удалось зарегистрироваться!</i>\n"+
-				"Номер: <code>%s</code>\n"+
-				"Имя: <code>%s %s</code>\n"+
-				"<blockquote><i>Сообщение: %s\n</i></blockquote>", components.Header(), contact.PhoneNumber, contact.FirstName, contact.LastName, message)
+	if err := h.client.RegisterUser(h.ctx, userRequest); err != nil {
+		msgText = components.Header() + l.T("register.failed",
+			phone, contact.FirstName, contact.LastName, html.EscapeString(adapter.UserMessage(l, err)))
 	} else {
-		msgText = fmt.Sprintf(
-			"%s \n✔️ Успешно <i>Регистрация прошла!</i>\n"+
-				"Номер: <code>%s</code>\n"+
-				"Имя: <code>%s %s</code>\n\n"+
-				`<a href='%s'>Перейти на сайт</a>`, components.Header(),
-			contact.PhoneNumber, contact.FirstName, contact.LastName, strings.TrimSuffix(config.Load().PublicSiteURL, "/"))
+		msgText = components.Header() + l.T("register.done",
+			phone, contact.FirstName, contact.LastName, strings.TrimSuffix(config.Load().PublicSiteURL, "/"))
 	}
 
 	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
@@ -65,62 +65,86 @@ func (h *Handler) ContactHandler() {
 	})
 
 	// После регистрации предлагаем выбрать таймзону
-	timezoneKeyboard := [][]models.InlineKeyboardButton{
-		{{Text: "Москва (Europe/Moscow, +03:00)", CallbackData: "tz/Europe/Moscow"}},
-		{{Text: "Екатеринбург (Asia/Yekaterinburg, +05:00)", CallbackData: "tz/Asia/Yekaterinburg"}},
-		{{Text: "Новосибирск (Asia/Novosibirsk, +07:00)", CallbackData: "tz/Asia/Novosibirsk"}},
-		{{Text: "Омск (Asia/Omsk, +06:00)", CallbackData: "tz/Asia/Omsk"}},
-		{{Text: "Самара (Europe/Samara, +04:00)", CallbackData: "tz/Europe/Samara"}},
+	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+		ChatID:      userID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        timezone.Prompt(l),
+		ReplyMarkup: timezone.RegionsKeyboard(l),
+	})
+}
+
+// contactPhone возвращает номер из контакта Telegram в международном формате. Telegram присылает
+// номер с кодом страны, но часто без «+»: без него API разобрало бы «79991234567» как национальный
+func contactPhone(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "+") {
+		return raw
 	}
+	return "+" + raw
+}
+
+// LocationHandler предлагает таймзону по отправленной геопозиции
+func (h *Handler) LocationHandler() {
+	location := h.update.Message.Location
+	userID := h.update.Message.From.ID
+	l := i18n.ForUser(h.ctx, userID)
+
+	// Убираем reply-кнопку «Отправить геопозицию»: inline-кнопки в том же сообщении не поместятся
 	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
-		ChatID:    userID,
-		ParseMode: models.ParseModeHTML,
-		Text:      "Выберите вашу таймзону:",
-		ReplyMarkup: &models.InlineKeyboardMarkup{
-			InlineKeyboard: timezoneKeyboard,
-		},
+		ChatID:      userID,
+		Text:        l.T("timezone.location_received"),
+		ReplyMarkup: &models.ReplyKeyboardRemove{RemoveKeyboard: true},
+	})
+	text, keyboard := timezone.Detected(l, location.Latitude, location.Longitude)
+	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+		ChatID:      userID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        text,
+		ReplyMarkup: keyboard,
 	})
 }
 
-func (h *CallBackHandler) TryConfirmLogin() {
+func (h *CallBackHandler) TryConfirmLogin(Params) {
 	// Всегда отвечаем на callback, чтобы кнопка "крутилка" исчезала у пользователя
-	h.answerCallBackQuery("Обрабатываю…", false)
+	l := i18n.ForUser(h.ctx, h.userID)
+	h.answerCallBackQuery(l.T("callback.processing"), false)
 
-	msg, ok := h.client.CheckAuth(h.ctx, h.userID)
-	if !ok {
-		log.Printf("!ok with CheckAuth: %v", msg)
+	exist, err := h.client.CheckAuth(h.ctx, h.userID)
+	if err != nil || !exist {
+		log.Printf("CheckAuth: registered=%v err=%v", exist, err)
+		text := components.APIError(l, err, msgNotRegister(l))
 		// Редактируем сообщение, на котором была нажата кнопка
 		if h.messageID != 0 {
 			log.Printf("Editing message %d for user %d - user not registered", h.messageID, h.userID)
-			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, msgNotRegister, nil)
+			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, text, nil)
 		} else {
 			log.Printf("MessageID is 0, sending new message to user %d - user not registered", h.userID)
 			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
 				ChatID:    h.userID,
-				Text:      msgNotRegister,
+				Text:      text,
 				ParseMode: models.ParseModeHTML,
 			})
 		}
-		h.answerCallBackQuery("Ошибка авторизации", false)
+		h.answerCallBackQuery(l.T("auth.failed"), false)
 		return
 	}
 	// fmt.Printf("%+v", h)
-	msg, ok = h.client.ConfirmLogin(h.ctx, h.userID)
-	if !ok {
-		log.Printf("!ok with ConfirmLogin: %v", msg)
+	if err := h.client.ConfirmLogin(h.ctx, h.userID); err != nil {
+		log.Printf("ConfirmLogin: %v", err)
+		text := components.APIError(l, err, msgErrorWithConfirmLogin(l))
 		// Редактируем сообщение, на котором была нажата кнопка
 		if h.messageID != 0 {
 			log.Printf("Editing message %d for user %d - confirm login error", h.messageID, h.userID)
-			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, msgErrorWithConfirmLogin, nil)
+			messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, text, nil)
 		} else {
 			log.Printf("MessageID is 0, sending new message to user %d - confirm login error", h.userID)
 			h.b.SendMessage(h.ctx, &bot.SendMessageParams{
 				ChatID:    h.userID,
-				Text:      msgErrorWithConfirmLogin,
+				Text:      text,
 				ParseMode: models.ParseModeHTML,
 			})
 		}
-		h.answerCallBackQuery("Ошибка подтверждения входа", false)
+		h.answerCallBackQuery(l.T("auth.login_failed_short"), false)
 		return
 	}
 	// Новое поведение: удаляем исходное сообщение и отправляем новое сообщение об успехе
@@ -129,57 +153,129 @@ func (h *CallBackHandler) TryConfirmLogin() {
 	}
 	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
 		ChatID:    h.userID,
-		Text:      SuccessConfirmLogin,
+		Text:      components.Header() + l.T("auth.login_confirmed"),
 		ParseMode: models.ParseModeHTML,
 	})
-	h.answerCallBackQuery("Вход подтвержден", false)
+	h.answerCallBackQuery(l.T("auth.login_confirmed_short"), false)
 }
 
-func (h *CallBackHandler) SelectTimezone() {
-	callbackQuery := h.update.CallbackQuery
-	data := callbackQuery.Data // tz/<IANA>
-	if !strings.HasPrefix(data, "tz/") {
-		h.answerCallBackQuery("Неверный формат таймзоны", true)
+// SelectTimezone сохраняет выбранную таймзону: {IANA}
+func (h *CallBackHandler) SelectTimezone(p Params) {
+	tz := p.String(0)
+	if !zones.Valid(tz) {
+		h.answerStale()
 		return
 	}
-	tz := strings.TrimPrefix(data, "tz/")
 	if err := h.client.UpdateTimezoneInternal(h.ctx, h.userID, tz); err != nil {
-		h.answerCallBackQuery("Не удалось сохранить таймзону", true)
+		log.Printf("UpdateTimezoneInternal: %v", err)
+		h.answerError(err)
 		return
 	}
+	i18n.RememberZone(h.userID, tz)
+
 	// Уведомляем пользователя и убираем инлайн-клавиатуру
-	h.b.EditMessageText(h.ctx, &bot.EditMessageTextParams{
-		ChatID:    h.userID,
-		MessageID: callbackQuery.Message.Message.ID,
-		Text:      fmt.Sprintf("Таймзона установлена: %s", tz),
-		ParseMode: models.ParseModeHTML,
-	})
-	h.answerCallBackQuery("Сохранено", false)
+	l := i18n.ForUser(h.ctx, h.userID)
+	if h.messageID != 0 {
+		h.b.EditMessageText(h.ctx, &bot.EditMessageTextParams{
+			ChatID:    h.userID,
+			MessageID: h.messageID,
+			Text:      l.T("timezone.saved", timezone.Label(l, tz)),
+			ParseMode: models.ParseModeHTML,
+		})
+	}
+	h.answerCallBackQuery(l.T("common.saved"), false)
 }
 
-func (h *CallBackHandler) ClientSlots() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	if len(parts) >= 3 {
-		masterIDStr := parts[1]
-		pageStr := parts[2]
-
-		masterID, err := strconv.ParseInt(masterIDStr, 10, 64)
-		if err != nil {
-			log.Printf("Invalid master ID in client_slots callback: %s", masterIDStr)
-			return
-		}
+// TimezoneRegions возвращает выбор таймзоны к списку регионов
+func (h *CallBackHandler) TimezoneRegions(Params) {
+	l := i18n.ForUser(h.ctx, h.userID)
+	messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, timezone.Prompt(l), timezone.RegionsKeyboard(l))
+	h.answerCallBackQuery("", false)
+}
 
-		page, err := strconv.Atoi(pageStr)
-		if err != nil {
-			log.Printf("Invalid page number in client_slots callback: %s", pageStr)
-			return
-		}
+// TimezoneRegion показывает города региона: {region}/{page}
+func (h *CallBackHandler) TimezoneRegion(p Params) {
+	if len(zones.InRegion(p.String(0))) == 0 {
+		h.answerStale()
+		return
+	}
+	text, keyboard := timezone.RegionPage(i18n.ForUser(h.ctx, h.userID), p.String(0), p.Int(1))
+	messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, text, keyboard)
+	h.answerCallBackQuery("", false)
+}
+
+// TimezoneSearch перелистывает результаты поиска таймзоны: {query}/{page}
+func (h *CallBackHandler) TimezoneSearch(p Params) {
+	text, keyboard := timezone.SearchPage(i18n.ForUser(h.ctx, h.userID), p.String(0), p.Int(1))
+	messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, text, keyboard)
+	h.answerCallBackQuery("", false)
+}
+
+// TimezoneLocate просит отправить геопозицию: reply-кнопку нельзя добавить
+// редактированием, поэтому отправляем новое сообщение
+func (h *CallBackHandler) TimezoneLocate(Params) {
+	text, keyboard := timezone.LocationRequest(i18n.ForUser(h.ctx, h.userID))
+	h.b.SendMessage(h.ctx, &bot.SendMessageParams{
+		ChatID:      h.userID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	h.answerCallBackQuery("", false)
+}
 
-		// Отправляем только будущие слоты для клиента
-		shared.SendFutureSlotsForClient(h.ctx, h.b, h.userID, masterID, page, h.messageID)
-		h.answerCallBackQuery("Обновлено", false)
+// SelectLanguage сохраняет язык интерфейса: {lang}
+func (h *CallBackHandler) SelectLanguage(p Params) {
+	lang, supported := i18n.Parse(p.String(0))
+	if !supported {
+		h.answerStale()
+		return
+	}
+	if err := h.client.UpdateLanguageInternal(h.ctx, h.userID, string(lang)); err != nil {
+		log.Printf("UpdateLanguageInternal: %v", err)
+		h.answerError(err)
+		return
+	}
+	i18n.Remember(h.userID, lang)
 
+	// Отвечаем уже на новом языке
+	l := i18n.For(lang)
+	if h.messageID != 0 {
+		h.b.EditMessageText(h.ctx, &bot.EditMessageTextParams{
+			ChatID:    h.userID,
+			MessageID: h.messageID,
+			Text:      l.T("language.saved", l.T("language.self_name")),
+			ParseMode: models.ParseModeHTML,
+		})
 	}
+	h.answerCallBackQuery(l.T("common.saved"), false)
+}
+
+// SelectDigest сохраняет время утренней сводки мастера: {HHMM|off}
+func (h *CallBackHandler) SelectDigest(p Params) {
+	digestTime, ok := digest.ParseChoice(p.String(0))
+	if !ok {
+		h.answerStale()
+		return
+	}
+	if err := h.client.UpdateDigestTime(h.ctx, h.userID, digestTime); err != nil {
+		log.Printf("UpdateDigestTime: %v", err)
+		h.answerError(err)
+		return
+	}
+	l := i18n.ForUser(h.ctx, h.userID)
+	if h.messageID != 0 {
+		messageEditor.EditSpecificMessage(h.ctx, h.b, h.userID, h.messageID, digest.Text(l, digestTime), digest.Keyboard(l, h.userID))
+	}
+	if digestTime == "" {
+		h.answerCallBackQuery(l.T("digest.disabled"), false)
+		return
+	}
+	h.answerCallBackQuery(l.T("digest.saved", digestTime), false)
+}
+
+// ClientSlots показывает клиенту будущие слоты мастера: {masterTelegramID}/{page}
+func (h *CallBackHandler) ClientSlots(p Params) {
+	// Отправляем только будущие слоты для клиента
+	shared.SendFutureSlotsForClient(h.ctx, h.b, h.userID, p.Int64(0), p.Int(1), h.messageID)
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.updated"), false)
 }
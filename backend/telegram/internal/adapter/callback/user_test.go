@@ -0,0 +1,16 @@
+package callback
+
+import "testing"
+
+func TestContactPhone(t *testing.T) {
+	for raw, want := range map[string]string{
+		"79991234567":   "+79991234567",
+		"+79991234567":  "+79991234567",
+		" 380501234567": "+380501234567",
+		"":              "",
+	} {
+		if got := contactPhone(raw); got != want {
+			t.Errorf("contactPhone(%q) = %q, want %q", raw, got, want)
+		}
+	}
+}
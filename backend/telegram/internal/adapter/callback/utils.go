@@ -2,28 +2,33 @@ package callback
 
 import (
 	"context"
-	"fmt"
 	"log"
-	"strconv"
-	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/handlers/components"
 	"telegram-bot/internal/handlers/message"
 	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 )
 
-var (
-	msgNotRegister           = fmt.Sprintf("%s⚠️ Ошибка\n<i>🔒 Пожалуйста, сначала зарегистрируйтесь через /start</i>", components.Header())
-	msgIdDoesntMatch         = fmt.Sprintf("%s⚠️ Ошибка\n<blockquote><i>TelegramID контакта не совпадает с вашим ID, проверьте, что вы передаёте свой контакт!</i></blockquote>", components.Header())
-	empty                    = fmt.Sprintf("%s", components.Header())
-	msgErrorWithConfirmLogin = fmt.Sprintf("%s⚠️ Ошибка\n<i>🔒 Не удалось подтвердить вход</i>", components.Header())
-	SuccessConfirmLogin      = fmt.Sprintf("%s✔️ Успешно\n<i>Вход подтверждён!</i>", components.Header())
-	msgErrorWithCheckUser    = fmt.Sprintf("%s⚠️ Ошибка\n<i>🔒Не удалось определить пользователя. Пройдите регистрацию /start если вы ещё не зарегистрировались</i>", components.Header())
-	SuccessCreateRecord      = fmt.Sprintf("%s✔️ Успешно\n<i>Заявка на запись отправлена. Ожидайте ответа на заявку от мастера.", components.Header())
-	messageEditor            = message.NewMessageEditor()
-)
+var messageEditor = message.NewMessageEditor()
+
+// msgNotRegister — просьба зарегистрироваться перед действием
+func msgNotRegister(l i18n.Localizer) string {
+	return components.Error(l, l.T("auth.register_first"))
+}
+
+// msgErrorWithCheckUser — пользователя не удалось найти в API
+func msgErrorWithCheckUser(l i18n.Localizer) string {
+	return components.Error(l, l.T("auth.unknown_user"))
+}
+
+// msgErrorWithConfirmLogin — API не подтвердило вход на сайт
+func msgErrorWithConfirmLogin(l i18n.Localizer) string {
+	return components.Error(l, l.T("auth.login_failed"))
+}
 
 func (h *CallBackHandler) answerCallBackQuery(text string, showAlert bool) {
 	callbackQuery := h.update.CallbackQuery
@@ -33,6 +38,19 @@ func (h *CallBackHandler) answerCallBackQuery(text string, showAlert bool) {
 		ShowAlert:       showAlert,
 	})
 }
+
+// answerStale отвечает на нажатие кнопки, данные которой уже нельзя обработать
+// (сообщение устарело, слот удалён, состояние потеряно после перезапуска),
+// чтобы у пользователя не висел индикатор загрузки
+func (h *CallBackHandler) answerStale() {
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.stale"), true)
+}
+
+// answerError показывает ошибку API всплывающим окном на языке пользователя
+func (h *CallBackHandler) answerError(err error) {
+	h.answerCallBackQuery(adapter.UserMessage(i18n.ForUser(h.ctx, h.userID), err), true)
+}
+
 func answerCallBackQuery(ctx context.Context, b *bot.Bot, update *models.Update, text string, showAlert bool) {
 	callbackQuery := update.CallbackQuery
 	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
@@ -41,67 +59,27 @@ func answerCallBackQuery(ctx context.Context, b *bot.Bot, update *models.Update,
 		ShowAlert:       showAlert,
 	})
 }
-func (h *CallBackHandler) DateMove() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	// date/{masterTelegramID}/{date}/{page}
-	if len(parts) >= 4 {
-		masterTelegramStr := parts[1]
-		targetDate := parts[2]
-		pageStr := parts[3]
-		page, err := strconv.Atoi(pageStr)
-		if err != nil {
-			log.Printf("Invalid page number in callback: %s", pageStr)
-			return
-		}
 
-		// masterID берём из callback data
-		masterTelegramID, err := strconv.ParseInt(masterTelegramStr, 10, 64)
-		if err != nil {
-			log.Printf("Invalid master telegram id in callback: %s", masterTelegramStr)
-			return
-		}
-
-		log.Printf("Navigating to date: %s, page: %d for user: %d, master: %d", targetDate, page, h.userID, masterTelegramID)
-
-		// Навигация по датам будет редактировать сообщение пагинации
-		shared.SendPaginatedSlots(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
-
-		// Отвечаем на callback query
-		h.answerCallBackQuery(fmt.Sprintf("Переход к %s, страница %d", targetDate, page), false)
-	}
+// Noop отвечает на нажатие неактивной кнопки
+func (h *CallBackHandler) Noop(Params) {
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.current_page"), false)
 }
 
-func (h *CallBackHandler) DateMoveClient() {
-	callbackQuery := h.update.CallbackQuery
-	callbackData := callbackQuery.Data
-	parts := strings.Split(callbackData, "/")
-	// client_date/{masterTelegramID}/{date}/{page}
-	if len(parts) >= 4 {
-		masterTelegramStr := parts[1]
-		targetDate := parts[2]
-		pageStr := parts[3]
-		page, err := strconv.Atoi(pageStr)
-		if err != nil {
-			log.Printf("Invalid page number in callback: %s", pageStr)
-			return
-		}
+// DateMove — навигация мастера по датам и страницам слотов: {masterTelegramID}/{date}/{page}
+func (h *CallBackHandler) DateMove(p Params) {
+	masterTelegramID, targetDate, page := p.Int64(0), p.String(1), p.Int(2)
+	log.Printf("Navigating to date: %s, page: %d for user: %d, master: %d", targetDate, page, h.userID, masterTelegramID)
 
-		masterTelegramID, err := strconv.ParseInt(masterTelegramStr, 10, 64)
-		if err != nil {
-			log.Printf("Invalid master telegram id in callback: %s", masterTelegramStr)
-			return
-		}
+	// Навигация по датам будет редактировать сообщение пагинации
+	shared.SendPaginatedSlots(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
 
-		shared.SendPaginatedFutureSlotsForClient(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
-		h.answerCallBackQuery(fmt.Sprintf("Переход к %s, страница %d", targetDate, page), false)
-	}
+	// Отвечаем на callback query
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.goto_page", targetDate, page), false)
 }
 
-func formatFloat(num float64) string {
-	// Форматируем с двумя знаками после запятой
-	str := fmt.Sprintf("%.2f", num)
-	// Заменяем точку на запятую
-	return strings.Replace(str, ".", ",", -1)
+// DateMoveClient — навигация клиента по датам (только будущие слоты): {masterTelegramID}/{date}/{page}
+func (h *CallBackHandler) DateMoveClient(p Params) {
+	masterTelegramID, targetDate, page := p.Int64(0), p.String(1), p.Int(2)
+	shared.SendPaginatedFutureSlotsForClient(h.ctx, h.b, h.userID, masterTelegramID, targetDate, page, h.messageID)
+	h.answerCallBackQuery(i18n.ForUser(h.ctx, h.userID).T("callback.goto_page", targetDate, page), false)
 }
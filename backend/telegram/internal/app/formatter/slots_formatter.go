@@ -3,7 +3,10 @@ package formatter
 import (
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/utils"
 	mymodels "telegram-bot/pkg/models"
 	"time"
@@ -24,6 +27,10 @@ type SlotPaginationData struct {
 	TimeType     time.Time
 	MasterInfo   MasterInfo
 	IsClientView bool
+	// ViewerTelegramID — кому показан список: кнопки слотов привязаны к нему
+	ViewerTelegramID int64
+	// Zone — таймзона зрителя: в ней сгруппированы даты и показано время слотов
+	Zone string
 }
 
 // MasterInfo содержит информацию о мастере
@@ -40,8 +47,8 @@ type DateGroup struct {
 	Slots []mymodels.SlotResponse
 }
 
-// GroupSlotsByDate группирует слоты по датам и сортирует их
-func GroupSlotsByDate(slots []mymodels.SlotResponse) []DateGroup {
+// GroupSlotsByDate группирует слоты по датам в таймзоне zone и сортирует их
+func GroupSlotsByDate(slots []mymodels.SlotResponse, zone string) []DateGroup {
 	if len(slots) == 0 {
 		return []DateGroup{}
 	}
@@ -54,7 +61,7 @@ func GroupSlotsByDate(slots []mymodels.SlotResponse) []DateGroup {
 	// Группируем по датам
 	dateMap := make(map[string][]mymodels.SlotResponse)
 	for _, slot := range slots {
-		date := utils.FormatDateInMoscow(slot.StartTime)
+		date := utils.FormatDateInLocation(zone, slot.StartTime)
 		dateMap[date] = append(dateMap[date], slot)
 	}
 
@@ -77,13 +84,15 @@ func GroupSlotsByDate(slots []mymodels.SlotResponse) []DateGroup {
 	return groups
 }
 
-// CreatePaginatedSlots создает пагинированные слоты для конкретной даты
-func CreatePaginatedSlots(allSlots []mymodels.SlotResponse, targetDate string, page int, slotsPerPage int, restrictNavigation bool) *SlotPaginationData {
+// CreatePaginatedSlots создает пагинированные слоты для конкретной даты.
+// Даты считаются в таймзоне зрителя viewerZone, а если она не выбрана — в таймзоне мастера.
+func CreatePaginatedSlots(allSlots []mymodels.SlotResponse, viewerZone string, targetDate string, page int, slotsPerPage int, restrictNavigation bool) *SlotPaginationData {
 	if len(allSlots) == 0 {
 		return nil
 	}
 
-	groups := GroupSlotsByDate(allSlots)
+	zone := utils.ViewerZone(viewerZone, allSlots[0].MasterTimezone)
+	groups := GroupSlotsByDate(allSlots, zone)
 
 	// Находим нужную дату
 	var targetGroup *DateGroup
@@ -132,7 +141,7 @@ func CreatePaginatedSlots(allSlots []mymodels.SlotResponse, targetDate string, p
 	var nextDate, prevDate string
 
 	if restrictNavigation {
-		todayStr := utils.FormatDateInMoscow(time.Now())
+		todayStr := utils.FormatDateInLocation(zone, time.Now())
 		todayParsed, _ := time.Parse("02-01-2006", todayStr)
 
 		if currentIndex > 0 {
@@ -185,29 +194,29 @@ func CreatePaginatedSlots(allSlots []mymodels.SlotResponse, targetDate string, p
 			Surname:    allSlots[0].MasterSurname,
 		},
 		IsClientView: restrictNavigation,
+		Zone:         zone,
 	}
 }
 
-func ParseSlots(slots []mymodels.SlotResponse) string {
+// ParseSlots — список слотов мастера текстом на языке l
+func ParseSlots(l i18n.Localizer, slots []mymodels.SlotResponse) string {
 	if len(slots) == 0 {
 		return ""
 	}
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("<b>ID Пользователя: </b><code>%d</code>\n\n", slots[0].MasterTelegramID))
-	b.WriteString(fmt.Sprintf("Имя: <b>%s %s</b>\n", slots[0].MasterName, slots[0].MasterSurname))
-	b.WriteString("🟩 [ Свободен ]\n🟥 [ Забронирован ]\n\n")
+	b.WriteString(l.T("slots.legend", slots[0].MasterTelegramID, slots[0].MasterName, slots[0].MasterSurname))
 
 	currentDate := utils.FormatDateInLocation(slots[0].MasterTimezone, slots[0].StartTime)
 	tzLabel := slots[0].MasterTimezone
 	if tzLabel == "" {
 		tzLabel = "Europe/Moscow"
 	}
-	b.WriteString(fmt.Sprintf("Дата: <code>%s</code>  TZ: <code>%s</code>\n", currentDate, tzLabel))
+	b.WriteString(l.T("slots.date_zone", currentDate, tzLabel))
 
 	for _, s := range slots {
 		date := utils.FormatDateInLocation(s.MasterTimezone, s.StartTime)
 		if date != currentDate {
-			b.WriteString(fmt.Sprintf("Дата: <code>%s</code>\n", date))
+			b.WriteString(l.T("slots.date", date))
 			currentDate = date
 		}
 		startTime := utils.FormatTimeOnlyInLocation(s.MasterTimezone, s.StartTime)
@@ -220,27 +229,27 @@ func ParseSlots(slots []mymodels.SlotResponse) string {
 	}
 	return b.String()
 }
-func CreateInlineKeyboardSlots(slots []mymodels.SlotResponse) (*models.InlineKeyboardMarkup, string) {
+
+// CreateInlineKeyboardSlots — список слотов на языке l с кнопками карточек для пользователя viewerID
+func CreateInlineKeyboardSlots(l i18n.Localizer, viewerID int64, slots []mymodels.SlotResponse) (*models.InlineKeyboardMarkup, string) {
 	if len(slots) == 0 {
 		return nil, ""
 	}
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("<b>ID Пользователя: </b><code>%d</code>\n\n", slots[0].MasterTelegramID))
-	b.WriteString(fmt.Sprintf("Имя: <b>%s %s</b>\n", slots[0].MasterName, slots[0].MasterSurname))
-	b.WriteString("🟩 [ Свободен ]\n🟥 [ Забронирован ]\n\n")
+	b.WriteString(l.T("slots.legend", slots[0].MasterTelegramID, slots[0].MasterName, slots[0].MasterSurname))
 
 	currentDate := utils.FormatDateInLocation(slots[0].MasterTimezone, slots[0].StartTime)
 	tzLabel := slots[0].MasterTimezone
 	if tzLabel == "" {
 		tzLabel = "Europe/Moscow"
 	}
-	b.WriteString(fmt.Sprintf("Дата: <code>%s</code>  TZ: <code>%s</code>\n", currentDate, tzLabel))
+	b.WriteString(l.T("slots.date_zone", currentDate, tzLabel))
 
 	result := [][]models.InlineKeyboardButton{}
 	for _, s := range slots {
 		date := utils.FormatDateInLocation(s.MasterTimezone, s.StartTime)
 		if date != currentDate {
-			b.WriteString(fmt.Sprintf("Дата: <code>%s</code>\n", date))
+			b.WriteString(l.T("slots.date", date))
 			currentDate = date
 		}
 		startTime := utils.FormatTimeOnlyInLocation(s.MasterTimezone, s.StartTime)
@@ -253,7 +262,7 @@ func CreateInlineKeyboardSlots(slots []mymodels.SlotResponse) (*models.InlineKey
 		result = append(result, []models.InlineKeyboardButton{
 			{
 				Text:         fmt.Sprintf("🕒 %s – %s. [ %s ] %s", startTime, endTime, s.ServiceName, color),
-				CallbackData: fmt.Sprintf("slot/%d", s.ID),
+				CallbackData: callbackdata.Encode(callbackdata.RouteSlot, strconv.FormatUint(uint64(s.ID), 10), strconv.FormatInt(viewerID, 10)),
 			},
 		})
 	}
@@ -262,39 +271,30 @@ func CreateInlineKeyboardSlots(slots []mymodels.SlotResponse) (*models.InlineKey
 	}, b.String()
 }
 
-// CreatePaginatedInlineKeyboard создает пагинированную клавиатуру с навигацией
-func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.InlineKeyboardMarkup, string) {
+// CreatePaginatedInlineKeyboard создает пагинированную клавиатуру с навигацией.
+// Время слотов — в таймзоне зрителя l, время мастера — вторым, если оно отличается.
+func CreatePaginatedInlineKeyboard(l i18n.Localizer, paginationData *SlotPaginationData) (*models.InlineKeyboardMarkup, string) {
 	if paginationData == nil || len(paginationData.Slots) == 0 {
 		return nil, ""
 	}
 
 	var b strings.Builder
 
-	b.WriteString(fmt.Sprintf("<b>ID Пользователя: </b><code>%d</code>\n\n", paginationData.MasterInfo.TelegramID))
-	b.WriteString(fmt.Sprintf("Имя: <b>%s %s</b>\n", paginationData.MasterInfo.Name, paginationData.MasterInfo.Surname))
-	b.WriteString("🟩 [ Свободен ]\n🟥 [ Забронирован ]\n\n")
+	b.WriteString(l.T("slots.legend", paginationData.MasterInfo.TelegramID, paginationData.MasterInfo.Name, paginationData.MasterInfo.Surname))
 
-	// Примечание: CurrentDate уже сформирована в нужной TZ на этапе группировки
-	tzLabel := ""
-	if len(paginationData.Slots) > 0 {
-		tzLabel = paginationData.Slots[0].MasterTimezone
-	}
-	if tzLabel == "" {
-		tzLabel = "Europe/Moscow"
-	}
-	b.WriteString(fmt.Sprintf("Дата: <code>%s</code>  TZ: <code>%s</code>\n", paginationData.CurrentDate, tzLabel))
+	// Примечание: CurrentDate уже сформирована в таймзоне зрителя на этапе группировки
+	b.WriteString(l.T("slots.date_zone", paginationData.CurrentDate, paginationData.Zone))
 
 	if paginationData.TotalPages > 1 {
-		b.WriteString(fmt.Sprintf("Страница: <code>%d/%d</code>\n\n", paginationData.CurrentPage, paginationData.TotalPages))
+		b.WriteString(l.T("slots.page", paginationData.CurrentPage, paginationData.TotalPages))
 	} else {
 		b.WriteString("\n")
 	}
 
 	var keyboard [][]models.InlineKeyboardButton
 
+	viewer := l.WithZone(paginationData.Zone)
 	for _, slot := range paginationData.Slots {
-		startTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.StartTime)
-		endTime := utils.FormatTimeOnlyInLocation(slot.MasterTimezone, slot.EndTime)
 		color := "🟩"
 		if slot.IsBooked {
 			color = "🟥"
@@ -302,8 +302,8 @@ func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.
 
 		keyboard = append(keyboard, []models.InlineKeyboardButton{
 			{
-				Text:         fmt.Sprintf("🕒 %s – %s. [ %s ] %s", startTime, endTime, slot.ServiceName, color),
-				CallbackData: fmt.Sprintf("slot/%d", slot.ID),
+				Text:         fmt.Sprintf("🕒 %s. [ %s ] %s", utils.FormatSpan(viewer, slot.MasterTimezone, slot.StartTime, slot.EndTime), slot.ServiceName, color),
+				CallbackData: callbackdata.Encode(callbackdata.RouteSlot, strconv.FormatUint(uint64(slot.ID), 10), strconv.FormatInt(paginationData.ViewerTelegramID, 10)),
 			},
 		})
 	}
@@ -314,7 +314,7 @@ func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.
 	if paginationData.HasPrevDate {
 		navButtons = append(navButtons, models.InlineKeyboardButton{
 			Text:         "⬅️ " + paginationData.PrevDate,
-			CallbackData: fmt.Sprintf("%s/%d/%s/1", tern(paginationData.IsClientView, "client_date", "date"), paginationData.MasterInfo.TelegramID, paginationData.PrevDate),
+			CallbackData: dateCallback(paginationData, paginationData.PrevDate, 1),
 		})
 	}
 
@@ -322,19 +322,19 @@ func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.
 		if paginationData.CurrentPage > 1 {
 			navButtons = append(navButtons, models.InlineKeyboardButton{
 				Text:         "◀️",
-				CallbackData: fmt.Sprintf("%s/%d/%s/%d", tern(paginationData.IsClientView, "client_date", "date"), paginationData.MasterInfo.TelegramID, paginationData.CurrentDate, paginationData.CurrentPage-1),
+				CallbackData: dateCallback(paginationData, paginationData.CurrentDate, paginationData.CurrentPage-1),
 			})
 		}
 
 		navButtons = append(navButtons, models.InlineKeyboardButton{
 			Text:         fmt.Sprintf("%d/%d", paginationData.CurrentPage, paginationData.TotalPages),
-			CallbackData: "noop", // Неактивная кнопка для отображения текущей страницы
+			CallbackData: callbackdata.Encode(callbackdata.RouteNoop), // Неактивная кнопка для отображения текущей страницы
 		})
 
 		if paginationData.CurrentPage < paginationData.TotalPages {
 			navButtons = append(navButtons, models.InlineKeyboardButton{
 				Text:         "▶️",
-				CallbackData: fmt.Sprintf("%s/%d/%s/%d", tern(paginationData.IsClientView, "client_date", "date"), paginationData.MasterInfo.TelegramID, paginationData.CurrentDate, paginationData.CurrentPage+1),
+				CallbackData: dateCallback(paginationData, paginationData.CurrentDate, paginationData.CurrentPage+1),
 			})
 		}
 	}
@@ -342,7 +342,7 @@ func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.
 	if paginationData.HasNextDate {
 		navButtons = append(navButtons, models.InlineKeyboardButton{
 			Text:         paginationData.NextDate + " ➡️",
-			CallbackData: fmt.Sprintf("%s/%d/%s/1", tern(paginationData.IsClientView, "client_date", "date"), paginationData.MasterInfo.TelegramID, paginationData.NextDate),
+			CallbackData: dateCallback(paginationData, paginationData.NextDate, 1),
 		})
 	}
 
@@ -356,9 +356,11 @@ func CreatePaginatedInlineKeyboard(paginationData *SlotPaginationData) (*models.
 	}, b.String()
 }
 
-func tern(cond bool, a, b string) string {
-	if cond {
-		return a
+// dateCallback — кнопка перехода к дате: клиентский просмотр показывает только будущие слоты
+func dateCallback(pd *SlotPaginationData, date string, page int) string {
+	route := callbackdata.RouteMasterDate
+	if pd.IsClientView {
+		route = callbackdata.RouteClientDate
 	}
-	return b
+	return callbackdata.Encode(route, strconv.FormatInt(pd.MasterInfo.TelegramID, 10), date, strconv.Itoa(page))
 }
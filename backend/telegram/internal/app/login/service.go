@@ -17,14 +17,14 @@ func New(api *adapter.Client, logger *logrus.Logger) *Service {
 	return &Service{api: api, logger: logger}
 }
 
-func (s *Service) RegisterUser(ctx context.Context, req mymodels.UserRegister) (string, bool) {
+func (s *Service) RegisterUser(ctx context.Context, req mymodels.UserRegister) error {
 	return s.api.RegisterUser(ctx, req)
 }
 
-func (s *Service) CheckAuth(ctx context.Context, telegramID int64) (string, bool) {
+func (s *Service) CheckAuth(ctx context.Context, telegramID int64) (bool, error) {
 	return s.api.CheckAuth(ctx, telegramID)
 }
 
-func (s *Service) ConfirmLogin(ctx context.Context, telegramID int64) (string, bool) {
+func (s *Service) ConfirmLogin(ctx context.Context, telegramID int64) error {
 	return s.api.ConfirmLogin(ctx, telegramID)
 }
@@ -21,7 +21,7 @@ func New(api *adapter.Client, logger *logrus.Logger) *Service {
 func (s *Service) GetByMasterID(ctx context.Context, masterID int64) ([]mymodels.Record, error) {
 	records, err := s.api.GetRecords(ctx, masterID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch slots")
+		return nil, fmt.Errorf("failed to fetch records: %w", err)
 	}
 	return records, nil
 }
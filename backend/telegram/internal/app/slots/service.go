@@ -19,16 +19,16 @@ func New(api *adapter.Client, logger *logrus.Logger) *Service {
 }
 
 func (s *Service) GetByMasterID(ctx context.Context, masterID int64) ([]mymodels.SlotResponse, error) {
-	slots, ok := s.api.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		return nil, fmt.Errorf("failed to fetch slots")
+	slots, err := s.api.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch slots: %w", err)
 	}
 	return slots, nil
 }
 
 func (s *Service) DeleteByMasterID(ctx context.Context, masterID uint) error {
-	if ok := s.api.DeleteSlotsByTelegramID(ctx, masterID); !ok {
-		return fmt.Errorf("failed to delete slots")
+	if err := s.api.DeleteSlotsByTelegramID(ctx, masterID); err != nil {
+		return fmt.Errorf("failed to delete slots: %w", err)
 	}
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -27,7 +28,7 @@ func AuthMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
 		if err != nil || responce.StatusCode != http.StatusOK {
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: userID,
-				Text:   "🔒 Пожалуйста, сначала зарегистрируйтесь через /start",
+				Text:   i18n.ForUser(ctx, userID).T("auth.register_first"),
 			})
 			return
 		}
@@ -36,3 +37,21 @@ func AuthMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
 		next(ctx, b, update)
 	}
 }
+
+// LanguageHintMiddleware передаёт i18n язык клиента Telegram (language_code):
+// он используется, пока пользователь не выбрал язык через /language
+func LanguageHintMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update != nil {
+			switch {
+			case update.Message != nil && update.Message.From != nil:
+				i18n.Hint(update.Message.From.ID, update.Message.From.LanguageCode)
+			case update.CallbackQuery != nil:
+				i18n.Hint(update.CallbackQuery.From.ID, update.CallbackQuery.From.LanguageCode)
+			case update.InlineQuery != nil && update.InlineQuery.From != nil:
+				i18n.Hint(update.InlineQuery.From.ID, update.InlineQuery.From.LanguageCode)
+			}
+		}
+		next(ctx, b, update)
+	}
+}
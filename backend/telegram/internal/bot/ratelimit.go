@@ -2,10 +2,11 @@ package bot
 
 import (
 	"context"
-	"fmt"
+	"html"
 	"sync"
 	"telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
 	"time"
 
 	"github.com/go-telegram/bot"
@@ -230,7 +231,7 @@ func CommandRateLimitMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
 		if !CanExecuteCommand(userID) {
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: userID,
-				Text:   "⏳ Слишком быстро! Подождите немного перед следующей командой.",
+				Text:   i18n.ForUser(ctx, userID).T("command.too_fast"),
 			})
 			return
 		}
@@ -251,7 +252,7 @@ func CallbackRateLimitMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
 		if !CanExecuteCallback(userID) {
 			b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "⏳ Слишком быстро! Подождите немного.",
+				Text:            i18n.ForUser(ctx, userID).T("callback.too_fast"),
 				ShowAlert:       true,
 			})
 			return
@@ -270,13 +271,18 @@ func CommandAuthMiddleware(next bot.HandlerFunc, client *backendapi.Client) bot.
 
 		userID := update.Message.From.ID
 
-		_, exist := client.CheckAuth(ctx, userID)
+		l := i18n.ForUser(ctx, userID)
+		exist, err := client.CheckAuth(ctx, userID)
+		if err != nil {
+			b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:    userID,
+				ParseMode: models.ParseModeHTML,
+				Text:      components.Error(l, html.EscapeString(backendapi.UserMessage(l, err))),
+			})
+			return
+		}
 		if !exist {
-			msgText := fmt.Sprintf(
-				"%s"+
-					"<blockquote> ℹ️ Для начала использования бота, вам необходимо зарегистрироваться!\n\nДля регистрации /start</blockquote>",
-				components.Header(),
-			)
+			msgText := components.Header() + l.T("start.register_first")
 			b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID:    userID,
 				ParseMode: models.ParseModeHTML,
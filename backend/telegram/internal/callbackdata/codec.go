@@ -0,0 +1,197 @@
+// Package callbackdata кодирует данные inline-кнопок: маршрут и аргументы
+// подписываются HMAC, чтобы пользователь не мог подделать нажатие (подтвердить
+// чужую запись, перебрать UUID). Telegram ограничивает callback_data 64 байтами;
+// данные, которые не помещаются, сохраняются в Store, а в кнопку попадает ключ.
+//
+// Формат: route|arg1|arg2~SIG или *KEY~SIG, где SIG — первые 8 байт
+// HMAC-SHA256 в base64url (11 символов).
+package callbackdata
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// MaxLen — предел Telegram для callback_data
+	MaxLen = 64
+	// PayloadTTL — сколько хранятся данные, не поместившиеся в кнопку
+	PayloadTTL = 7 * 24 * time.Hour
+
+	argSep    = "|"
+	sigSep    = "~"
+	storedTag = "*"
+	sigBytes  = 8
+	keyBytes  = 9
+	// Истёкшие данные удаляются на каждом sweepEvery-м сохранении
+	sweepEvery = 256
+)
+
+var (
+	// ErrMalformed — данные не похожи на выданные ботом
+	ErrMalformed = errors.New("callbackdata: malformed")
+	// ErrSignature — подпись не сошлась: данные подделаны или подписаны другим ключом
+	ErrSignature = errors.New("callbackdata: bad signature")
+	// ErrExpired — сохранённые данные удалены или истекли
+	ErrExpired = errors.New("callbackdata: expired")
+)
+
+var sigLen = base64.RawURLEncoding.EncodedLen(sigBytes)
+
+// Payload — расшифрованное нажатие: маршрут и его аргументы
+type Payload struct {
+	Route string   `json:"route"`
+	Args  []string `json:"args"`
+}
+
+// Codec подписывает и проверяет данные кнопок
+type Codec struct {
+	key   []byte
+	store Store
+	ttl   time.Duration
+	puts  atomic.Int64
+}
+
+func NewCodec(secret []byte, store Store) *Codec {
+	return &Codec{key: secret, store: store, ttl: PayloadTTL}
+}
+
+// Encode возвращает подписанные данные кнопки. Если они длиннее MaxLen
+// или аргумент содержит разделитель, данные сохраняются в Store.
+func (c *Codec) Encode(ctx context.Context, route string, args ...string) (string, error) {
+	if route == "" || strings.ContainsAny(route, argSep+sigSep+storedTag) {
+		return "", fmt.Errorf("callbackdata: invalid route %q", route)
+	}
+	inline := true
+	for _, a := range args {
+		if strings.Contains(a, argSep) {
+			inline = false
+		}
+	}
+	if inline {
+		raw := strings.Join(append([]string{route}, args...), argSep)
+		if data := c.sign(raw); len(data) <= MaxLen {
+			return data, nil
+		}
+	}
+
+	key, err := randomKey()
+	if err != nil {
+		return "", err
+	}
+	p := Payload{Route: route, Args: args}
+	if err := c.store.Put(ctx, key, p, time.Now().Add(c.ttl)); err != nil {
+		return "", fmt.Errorf("callbackdata: store payload: %w", err)
+	}
+	if c.puts.Add(1)%sweepEvery == 0 {
+		if _, err := c.store.DeleteExpired(ctx); err != nil {
+			log.Printf("callbackdata: delete expired: %v", err)
+		}
+	}
+	return c.sign(storedTag + key), nil
+}
+
+// Decode проверяет подпись и возвращает маршрут с аргументами
+func (c *Codec) Decode(ctx context.Context, data string) (Payload, error) {
+	raw, sig, ok := cutLast(data, sigSep)
+	if !ok || len(sig) != sigLen || raw == "" {
+		return Payload{}, ErrMalformed
+	}
+	if !hmac.Equal([]byte(sig), []byte(c.mac(raw))) {
+		return Payload{}, ErrSignature
+	}
+	if key, stored := strings.CutPrefix(raw, storedTag); stored {
+		return c.store.Get(ctx, key)
+	}
+	parts := strings.Split(raw, argSep)
+	return Payload{Route: parts[0], Args: parts[1:]}, nil
+}
+
+func (c *Codec) sign(raw string) string {
+	return raw + sigSep + c.mac(raw)
+}
+
+func (c *Codec) mac(raw string) string {
+	m := hmac.New(sha256.New, c.key)
+	m.Write([]byte(raw))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil)[:sigBytes])
+}
+
+func cutLast(s, sep string) (before, after string, found bool) {
+	if i := strings.LastIndex(s, sep); i >= 0 {
+		return s[:i], s[i+len(sep):], true
+	}
+	return s, "", false
+}
+
+func randomKey() (string, error) {
+	b := make([]byte, keyBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("callbackdata: random key: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DeriveSecret выводит ключ подписи из токена бота, если отдельный
+// CALLBACK_SECRET не задан: кнопки остаются валидными после перезапуска
+func DeriveSecret(botToken string) []byte {
+	m := hmac.New(sha256.New, []byte("telegram-callback-data"))
+	m.Write([]byte(botToken))
+	return m.Sum(nil)
+}
+
+var (
+	defaultMu    sync.RWMutex
+	defaultCodec = newEphemeralCodec()
+)
+
+// newEphemeralCodec — кодек со случайным ключом до вызова SetDefault (тесты, локальный запуск)
+func newEphemeralCodec() *Codec {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return NewCodec(secret, NewMemoryStore())
+}
+
+// SetDefault задаёт кодек, которым пользуются Encode и Decode
+func SetDefault(c *Codec) {
+	defaultMu.Lock()
+	defer defaultMu.Unlock()
+	defaultCodec = c
+}
+
+// Default возвращает текущий кодек
+func Default() *Codec {
+	defaultMu.RLock()
+	defer defaultMu.RUnlock()
+	return defaultCodec
+}
+
+// Encode подписывает данные кнопки кодеком по умолчанию. Клавиатуры строятся
+// без контекста запроса, поэтому ошибка хранилища не пробрасывается: кнопка
+// получает данные RouteNoop и ответит, что сообщение устарело.
+func Encode(route string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	c := Default()
+	data, err := c.Encode(ctx, route, args...)
+	if err != nil {
+		log.Printf("callbackdata: encode %s: %v", route, err)
+		return c.sign(RouteNoop)
+	}
+	return data
+}
+
+// Decode проверяет данные кнопки кодеком по умолчанию
+func Decode(ctx context.Context, data string) (Payload, error) {
+	return Default().Decode(ctx, data)
+}
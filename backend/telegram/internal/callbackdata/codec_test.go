@@ -0,0 +1,87 @@
+package callbackdata
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecodeInline(t *testing.T) {
+	c := NewCodec([]byte("secret"), NewMemoryStore())
+	ctx := context.Background()
+
+	data, err := c.Encode(ctx, RouteTimezone, "America/Argentina/Buenos_Aires")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxLen || strings.HasPrefix(data, storedTag) {
+		t.Fatalf("data = %q, want inline payload within %d bytes", data, MaxLen)
+	}
+	p, err := c.Decode(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Payload{Route: RouteTimezone, Args: []string{"America/Argentina/Buenos_Aires"}}
+	if !reflect.DeepEqual(p, want) {
+		t.Fatalf("Decode() = %+v, want %+v", p, want)
+	}
+}
+
+func TestDecodeRejectsForgery(t *testing.T) {
+	c := NewCodec([]byte("secret"), NewMemoryStore())
+	ctx := context.Background()
+	data, _ := c.Encode(ctx, RouteRecordAction, "confirm", "10", "111")
+
+	forged := strings.Replace(data, "|10|", "|11|", 1)
+	if _, err := c.Decode(ctx, forged); !errors.Is(err, ErrSignature) {
+		t.Fatalf("forged payload error = %v, want ErrSignature", err)
+	}
+	other := NewCodec([]byte("other"), NewMemoryStore())
+	if _, err := other.Decode(ctx, data); !errors.Is(err, ErrSignature) {
+		t.Fatalf("foreign key error = %v, want ErrSignature", err)
+	}
+	for _, legacy := range []string{"record_action/confirm/10", "noop", ""} {
+		if _, err := c.Decode(ctx, legacy); !errors.Is(err, ErrMalformed) {
+			t.Errorf("Decode(%q) error = %v, want ErrMalformed", legacy, err)
+		}
+	}
+}
+
+func TestLongPayloadIsStored(t *testing.T) {
+	store := NewMemoryStore()
+	c := NewCodec([]byte("secret"), store)
+	ctx := context.Background()
+	args := []string{"0b6f3f0e-2f6c-4f59-9c57-0b9f3c1f2a11", "-1001234567890"}
+
+	data, err := c.Encode(ctx, RouteDeleteConfirm, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) > MaxLen || !strings.HasPrefix(data, storedTag) {
+		t.Fatalf("data = %q, want short stored reference", data)
+	}
+	p, err := c.Decode(ctx, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Route != RouteDeleteConfirm || !reflect.DeepEqual(p.Args, args) {
+		t.Fatalf("Decode() = %+v", p)
+	}
+
+	// Аргумент с разделителем тоже уходит в хранилище и возвращается без искажений
+	data, _ = c.Encode(ctx, RouteManage, "field", "a|b")
+	if p, err := c.Decode(ctx, data); err != nil || p.Args[1] != "a|b" {
+		t.Fatalf("Decode() = %+v, %v", p, err)
+	}
+
+	store.now = func() time.Time { return time.Now().Add(PayloadTTL + time.Minute) }
+	if _, err := c.Decode(ctx, data); !errors.Is(err, ErrExpired) {
+		t.Fatalf("expired payload error = %v, want ErrExpired", err)
+	}
+	if n, _ := store.DeleteExpired(ctx); n != 2 {
+		t.Fatalf("DeleteExpired() = %d, want 2", n)
+	}
+}
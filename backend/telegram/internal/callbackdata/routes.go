@@ -0,0 +1,41 @@
+package callbackdata
+
+// Маршруты inline-кнопок. Имена короткие: вместе с аргументами и подписью
+// данные должны уложиться в 64 байта. В комментарии — аргументы маршрута.
+// Кнопки действий с записью, слотом или услугой последним аргументом несут
+// Telegram ID пользователя, которому выданы: пересланную кнопку нажать нельзя.
+const (
+	RouteNoop           = "noop"  // неактивная кнопка
+	RouteConfirmLogin   = "login" // подтверждение входа на сайт
+	RouteTimezone       = "tz"    // {IANA}
+	RouteTimezoneGroups = "tzg"   // регионы таймзон
+	RouteTimezoneRegion = "tzr"   // {region}/{page}
+	RouteTimezoneSearch = "tzq"   // {query}/{page}
+	RouteTimezoneLocate = "tzl"   // запрос геопозиции для определения таймзоны
+	RouteLanguage       = "lang"  // {ru|en}
+	RouteMasterDate     = "d"     // {masterTelegramID}/{date}/{page}
+	RouteClientDate     = "cd"    // {masterTelegramID}/{date}/{page}
+	RouteSlot           = "s"     // {slotID}/{viewerTelegramID}
+	RouteBook           = "b"     // {slotID}/{clientTelegramID}
+	RouteBackToSlots    = "bs"    // {masterTelegramID}/{date}/{page}
+	RouteRecords        = "r"     // {status}/{page}
+	RouteRecordsTime    = "rt"    // {future|past|chooser}/{status}/{page}
+	RouteAllRecordsTime = "art"   // {future|past|chooser}/{status}/{page}
+	RouteSlotsTime      = "st"    // {future|past|chooser}/{masterTelegramID}/{page}
+	RouteClientSlots    = "cs"    // {masterTelegramID}/{page}
+	RouteRecordAction   = "ra"    // {confirm|reject}/{recordID}/{masterTelegramID}
+	RouteRecordCard     = "rc"    // {recordID}/{clientTelegramID} — запись клиента с действиями
+	RouteRecordCancel   = "rcx"   // {recordID}/{ask|yes}/{clientTelegramID}
+	RouteRecordSlots    = "rcs"   // {recordID}/{page}/{clientTelegramID} — слоты для переноса
+	RouteRecordMove     = "rcm"   // {recordID}/{slotID}/{clientTelegramID}
+	RouteRecordComment  = "rcc"   // {recordID}/{clientTelegramID}
+	RouteRecordCalendar = "rci"   // {recordID}/{clientTelegramID}
+	RouteDigest         = "dg"    // {HHMM|off}/{masterTelegramID} — время утренней сводки мастера
+	RouteDigestAction   = "dra"   // {confirm|reject}/{recordID}/{masterTelegramID} — заявка из сводки
+	RouteReview         = "rv"    // {recordID}/{rating}/{clientTelegramID} — оценка визита, 0 — выбор оценки
+	RouteReviewText     = "rvt"   // {recordID}/{rating}/{clientTelegramID} — ввод текста отзыва
+	RouteDeleteCancel   = "delx"  // отмена удаления аккаунта
+	RouteDeleteConfirm  = "del"   // {userUUID}/{telegramID}
+	RouteManage         = "m"     // {action}/{arg}/{masterTelegramID}
+	RouteUpcomingPage   = "up"    // {page}/{masterTelegramID}
+)
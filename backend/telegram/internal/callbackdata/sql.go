@@ -0,0 +1,78 @@
+package callbackdata
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SQLStore хранит данные кнопок в PostgreSQL (таблица bot_callback_payloads),
+// чтобы кнопки работали после перезапуска и на любом экземпляре бота
+type SQLStore struct {
+	db  *sql.DB
+	now func() time.Time
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db, now: time.Now}
+}
+
+// Migrate создаёт таблицу, если её ещё нет
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS bot_callback_payloads (
+	key        TEXT        PRIMARY KEY,
+	payload    JSONB       NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_bot_callback_payloads_expires_at ON bot_callback_payloads (expires_at);`)
+	if err != nil {
+		return fmt.Errorf("callbackdata: migrate: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Put(ctx context.Context, key string, p Payload, expiresAt time.Time) error {
+	raw, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("callbackdata: encode payload: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx,
+		`INSERT INTO bot_callback_payloads (key, payload, expires_at) VALUES ($1, $2, $3)`,
+		key, string(raw), expiresAt.UTC())
+	if err != nil {
+		return fmt.Errorf("callbackdata: put: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(ctx context.Context, key string) (Payload, error) {
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT payload FROM bot_callback_payloads WHERE key = $1 AND expires_at > $2`,
+		key, s.now().UTC(),
+	).Scan(&raw)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Payload{}, ErrExpired
+	}
+	if err != nil {
+		return Payload{}, fmt.Errorf("callbackdata: get: %w", err)
+	}
+	var p Payload
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return Payload{}, fmt.Errorf("callbackdata: decode payload: %w", err)
+	}
+	return p, nil
+}
+
+func (s *SQLStore) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM bot_callback_payloads WHERE expires_at <= $1`, s.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("callbackdata: delete expired: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,67 @@
+package callbackdata
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store хранит данные кнопок, не поместившиеся в 64 байта
+type Store interface {
+	// Put сохраняет данные под ключом key до expiresAt
+	Put(ctx context.Context, key string, p Payload, expiresAt time.Time) error
+	// Get возвращает данные или ErrExpired
+	Get(ctx context.Context, key string) (Payload, error)
+	// DeleteExpired удаляет истёкшие данные и возвращает их количество
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type storedPayload struct {
+	Payload
+	expiresAt time.Time
+}
+
+// MemoryStore хранит данные в памяти процесса
+type MemoryStore struct {
+	mu       sync.Mutex
+	now      func() time.Time
+	payloads map[string]storedPayload
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{now: time.Now, payloads: make(map[string]storedPayload)}
+}
+
+func (s *MemoryStore) Put(_ context.Context, key string, p Payload, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.payloads[key] = storedPayload{Payload: p, expiresAt: expiresAt}
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string) (Payload, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sp, ok := s.payloads[key]
+	if !ok || !s.now().Before(sp.expiresAt) {
+		return Payload{}, ErrExpired
+	}
+	return sp.Payload, nil
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	now := s.now()
+	for key, sp := range s.payloads {
+		if !now.Before(sp.expiresAt) {
+			delete(s.payloads, key)
+			n++
+		}
+	}
+	return n, nil
+}
@@ -10,21 +10,57 @@ import (
 type Config struct {
 	BotToken       string
 	BackendBaseURL string
+	// InternalToken — секрет X-Internal-Token для внутренних маршрутов API
+	// и запросов от имени пользователя; по умолчанию TELEGRAM_HTTP_SECRET
+	InternalToken  string
 	PublicSiteURL  string
 	BotLink        string
 	SupportContact string
+	// StateFile — файл с незаконченными диалогами (сценарии /newslot и т.п.)
+	StateFile string
+	// StateStore — где хранить состояния сообщений и диалогов: memory или postgres
+	StateStore string
+	// StateDatabaseURL — строка подключения к PostgreSQL для StateStore=postgres
+	StateDatabaseURL string
+	// BotMode — способ получения обновлений: polling или webhook
+	BotMode string
+	// WebhookURL — публичный адрес HTTP-сервера бота (без пути) для режима webhook
+	WebhookURL string
+	// WebhookPath — путь обработчика webhook на HTTP-сервере бота (:8091)
+	WebhookPath string
+	// WebhookSecret — значение заголовка X-Telegram-Bot-Api-Secret-Token
+	WebhookSecret string
+	// CallbackSecret — ключ HMAC-подписи данных inline-кнопок; пустой — выводится из BotToken
+	CallbackSecret string
 }
 
 func Load() Config {
 	return Config{
-		BotToken:       GetEnv("BOT_TOKEN", ""),
-		BackendBaseURL: GetEnv("BACKEND_BASE_URL", "http://localhost:8090"),
-		PublicSiteURL:  GetEnv("PUBLIC_SITE_URL", "https://your.domain"),
-		BotLink:        GetEnv("TELEGRAM_BOT_LINK", "https://t.me/your_telegram_bot"),
-		SupportContact: GetEnv("SUPPORT_CONTACT", "@your_support_contact"),
+		BotToken:         GetEnv("BOT_TOKEN", ""),
+		BackendBaseURL:   GetEnv("BACKEND_BASE_URL", "http://localhost:8090"),
+		InternalToken:    internalToken(),
+		PublicSiteURL:    GetEnv("PUBLIC_SITE_URL", "https://your.domain"),
+		BotLink:          GetEnv("TELEGRAM_BOT_LINK", "https://t.me/your_telegram_bot"),
+		SupportContact:   GetEnv("SUPPORT_CONTACT", "@your_support_contact"),
+		StateFile:        GetEnv("FSM_STATE_FILE", "data/fsm_state.json"),
+		StateStore:       GetEnv("STATE_STORE", "memory"),
+		StateDatabaseURL: GetEnv("STATE_DATABASE_URL", ""),
+		BotMode:          GetEnv("BOT_MODE", "polling"),
+		WebhookURL:       GetEnv("TELEGRAM_WEBHOOK_URL", ""),
+		WebhookPath:      GetEnv("TELEGRAM_WEBHOOK_PATH", "/telegram/webhook"),
+		WebhookSecret:    GetEnv("TELEGRAM_WEBHOOK_SECRET", ""),
+		CallbackSecret:   GetEnv("CALLBACK_SECRET", ""),
 	}
 }
 
+// internalToken — INTERNAL_TOKEN, а если он не задан — общий секрет TELEGRAM_HTTP_SECRET
+func internalToken() string {
+	if token := GetEnv("INTERNAL_TOKEN", ""); token != "" {
+		return token
+	}
+	return GetEnv("TELEGRAM_HTTP_SECRET", "")
+}
+
 func GetEnv(key, defaultValue string) string {
 	if err := godotenv.Load("/telegram/.env"); err != nil {
 		log.Printf("Notice: Could not load .env file from /telegram/.env: %v", err)
@@ -0,0 +1,120 @@
+package fsm
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// State — шаг сценария, например "service.name"
+type State string
+
+var (
+	// ErrNoSession — у пользователя нет активного сценария
+	ErrNoSession = errors.New("fsm: no active session")
+	// ErrUnknownFlow — сценарий не зарегистрирован
+	ErrUnknownFlow = errors.New("fsm: unknown flow")
+	// ErrTransition — переход не разрешён из текущего состояния
+	// (обычно это нажатие на кнопку из старого сообщения)
+	ErrTransition = errors.New("fsm: transition not allowed")
+)
+
+// Flow описывает сценарий: начальное состояние и разрешённые переходы
+type Flow struct {
+	Name        string
+	Initial     State
+	Transitions map[State][]State
+}
+
+func (f Flow) allowed(from, to State) bool {
+	for _, s := range f.Transitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Machine ведёт пользователей по зарегистрированным сценариям
+type Machine struct {
+	store SessionStore
+	flows map[string]Flow
+}
+
+func NewMachine(store SessionStore, flows ...Flow) *Machine {
+	m := &Machine{store: store, flows: make(map[string]Flow, len(flows))}
+	for _, f := range flows {
+		m.flows[f.Name] = f
+	}
+	return m
+}
+
+// Start начинает сценарий заново, сбрасывая предыдущий
+func (m *Machine) Start(userID int64, flow string, data map[string]string) (Session, error) {
+	f, ok := m.flows[flow]
+	if !ok {
+		return Session{}, fmt.Errorf("%w: %s", ErrUnknownFlow, flow)
+	}
+	sess := Session{UserID: userID, Flow: f.Name, State: f.Initial, Data: data}
+	if sess.Data == nil {
+		sess.Data = map[string]string{}
+	}
+	if err := m.store.Put(sess); err != nil {
+		return Session{}, err
+	}
+	return sess, nil
+}
+
+// Current возвращает активную сессию пользователя
+func (m *Machine) Current(userID int64) (Session, bool) {
+	sess, err := m.store.Get(userID)
+	return sess, err == nil
+}
+
+// Advance переводит сессию в состояние to, дописывая data.
+// Переход проверяется по таблице сценария.
+func (m *Machine) Advance(userID int64, to State, data map[string]string) (Session, error) {
+	sess, err := m.store.Get(userID)
+	if err != nil {
+		return Session{}, err
+	}
+	f, ok := m.flows[sess.Flow]
+	if !ok {
+		return Session{}, fmt.Errorf("%w: %s", ErrUnknownFlow, sess.Flow)
+	}
+	if sess.State != to && !f.allowed(sess.State, to) {
+		return Session{}, fmt.Errorf("%w: %s -> %s", ErrTransition, sess.State, to)
+	}
+	sess.State = to
+	for k, v := range data {
+		sess.Data[k] = v
+	}
+	if err := m.store.Put(sess); err != nil {
+		return Session{}, err
+	}
+	return sess, nil
+}
+
+// Finish завершает сценарий пользователя
+func (m *Machine) Finish(userID int64) error {
+	return m.store.Delete(userID)
+}
+
+var (
+	machineMu      sync.RWMutex
+	defaultMachine = NewMachine(NewMemoryStore(0))
+)
+
+// SetMachine задаёт глобальную машину состояний (вызывается при старте бота)
+func SetMachine(m *Machine) {
+	machineMu.Lock()
+	defer machineMu.Unlock()
+	defaultMachine = m
+}
+
+// GetMachine возвращает глобальную машину состояний
+func GetMachine() *Machine {
+	machineMu.RLock()
+	defer machineMu.RUnlock()
+	return defaultMachine
+}
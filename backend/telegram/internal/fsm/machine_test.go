@@ -0,0 +1,93 @@
+package fsm
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+var testFlow = Flow{
+	Name:    "booking",
+	Initial: "date",
+	Transitions: map[State][]State{
+		"date": {"time"},
+		"time": {"confirm", "date"},
+	},
+}
+
+func TestMachineTransitions(t *testing.T) {
+	m := NewMachine(NewMemoryStore(0), testFlow)
+
+	if _, err := m.Advance(1, "time", nil); !errors.Is(err, ErrNoSession) {
+		t.Fatalf("Advance() without session error = %v", err)
+	}
+	if _, err := m.Start(1, "unknown", nil); !errors.Is(err, ErrUnknownFlow) {
+		t.Fatalf("Start(unknown) error = %v", err)
+	}
+	if _, err := m.Start(1, "booking", map[string]string{"service": "7"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Advance(1, "confirm", nil); !errors.Is(err, ErrTransition) {
+		t.Fatalf("Advance(date -> confirm) error = %v", err)
+	}
+	sess, err := m.Advance(1, "time", map[string]string{"date": "2026-10-20"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sess.State != "time" || sess.Get("service") != "7" || sess.Get("date") != "2026-10-20" {
+		t.Fatalf("session = %+v", sess)
+	}
+	// Повтор текущего шага разрешён (например, листание месяцев в календаре)
+	if _, err := m.Advance(1, "time", nil); err != nil {
+		t.Fatalf("Advance(same state) error = %v", err)
+	}
+	if err := m.Finish(1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := m.Current(1); ok {
+		t.Fatal("session still active after Finish")
+	}
+}
+
+func TestStorePersistsAndExpires(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "fsm.json")
+	store, err := NewStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(Session{UserID: 1, Flow: "booking", State: "time", Data: map[string]string{"date": "2026-10-20"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(Session{UserID: 2, Flow: "booking", State: "date"}); err != nil {
+		t.Fatal(err)
+	}
+
+	reopened, err := NewStore(path, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sess, err := reopened.Get(1)
+	if err != nil || sess.State != "time" || sess.Get("date") != "2026-10-20" {
+		t.Fatalf("Get(1) after reopen = %+v, %v", sess, err)
+	}
+
+	reopened.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	if _, err := reopened.Get(2); !errors.Is(err, ErrNoSession) {
+		t.Fatal("expired session returned")
+	}
+}
+
+func TestSessionIsCopied(t *testing.T) {
+	store := NewMemoryStore(0)
+	data := map[string]string{"a": "1"}
+	if err := store.Put(Session{UserID: 1, Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	data["a"] = "2"
+	sess, _ := store.Get(1)
+	sess.Data["a"] = "3"
+	if got, _ := store.Get(1); got.Get("a") != "1" {
+		t.Fatalf("stored data mutated: %q", got.Get("a"))
+	}
+}
@@ -0,0 +1,110 @@
+package fsm
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// sqlTimeout ограничивает запросы: методы SessionStore вызываются без контекста
+const sqlTimeout = 5 * time.Second
+
+// SQLStore хранит сессии сценариев в PostgreSQL (таблица bot_fsm_sessions),
+// чтобы диалог можно было продолжить на любом экземпляре бота
+type SQLStore struct {
+	db  *sql.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewSQLStore(db *sql.DB, ttl time.Duration) *SQLStore {
+	return &SQLStore{db: db, ttl: ttl, now: time.Now}
+}
+
+// Migrate создаёт таблицу сессий, если её ещё нет
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS bot_fsm_sessions (
+	user_id    BIGINT      PRIMARY KEY,
+	flow       TEXT        NOT NULL,
+	state      TEXT        NOT NULL,
+	data       JSONB       NOT NULL DEFAULT '{}',
+	updated_at TIMESTAMPTZ NOT NULL
+);`)
+	if err != nil {
+		return fmt.Errorf("fsm: migrate: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(userID int64) (Session, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlTimeout)
+	defer cancel()
+
+	sess := Session{UserID: userID}
+	var raw []byte
+	err := s.db.QueryRowContext(ctx,
+		`SELECT flow, state, data, updated_at FROM bot_fsm_sessions WHERE user_id = $1`, userID,
+	).Scan(&sess.Flow, &sess.State, &raw, &sess.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Session{}, ErrNoSession
+	}
+	if err != nil {
+		return Session{}, fmt.Errorf("fsm: get: %w", err)
+	}
+	if s.ttl > 0 && s.now().Sub(sess.UpdatedAt) > s.ttl {
+		return Session{}, ErrNoSession
+	}
+	if err := json.Unmarshal(raw, &sess.Data); err != nil {
+		return Session{}, fmt.Errorf("fsm: decode: %w", err)
+	}
+	if sess.Data == nil {
+		sess.Data = map[string]string{}
+	}
+	return sess, nil
+}
+
+func (s *SQLStore) Put(sess Session) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlTimeout)
+	defer cancel()
+
+	raw, err := json.Marshal(sess.Data)
+	if err != nil {
+		return fmt.Errorf("fsm: encode: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `
+INSERT INTO bot_fsm_sessions (user_id, flow, state, data, updated_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id) DO UPDATE SET
+	flow = EXCLUDED.flow, state = EXCLUDED.state, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at`,
+		sess.UserID, sess.Flow, string(sess.State), string(raw), s.now().UTC())
+	if err != nil {
+		return fmt.Errorf("fsm: put: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Delete(userID int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sqlTimeout)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM bot_fsm_sessions WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("fsm: delete: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired удаляет сессии старше TTL
+func (s *SQLStore) DeleteExpired(ctx context.Context) (int64, error) {
+	if s.ttl <= 0 {
+		return 0, nil
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM bot_fsm_sessions WHERE updated_at < $1`, s.now().Add(-s.ttl).UTC())
+	if err != nil {
+		return 0, fmt.Errorf("fsm: delete expired: %w", err)
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,157 @@
+package fsm
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Session — состояние диалога одного пользователя
+type Session struct {
+	UserID    int64             `json:"user_id"`
+	Flow      string            `json:"flow"`
+	State     State             `json:"state"`
+	Data      map[string]string `json:"data"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// Get возвращает значение из данных сессии
+func (s Session) Get(key string) string {
+	return s.Data[key]
+}
+
+func (s Session) clone() Session {
+	data := make(map[string]string, len(s.Data))
+	for k, v := range s.Data {
+		data[k] = v
+	}
+	s.Data = data
+	return s
+}
+
+// SessionStore — хранилище сессий сценариев
+type SessionStore interface {
+	Get(userID int64) (Session, error)
+	Put(sess Session) error
+	Delete(userID int64) error
+}
+
+// Store хранит сессии в памяти и, если задан путь, сохраняет их в JSON-файл,
+// чтобы незаконченные диалоги переживали перезапуск бота
+type Store struct {
+	mu       sync.Mutex
+	path     string
+	ttl      time.Duration
+	sessions map[int64]Session
+	now      func() time.Time
+}
+
+// NewMemoryStore создаёт хранилище без сохранения на диск
+func NewMemoryStore(ttl time.Duration) *Store {
+	return &Store{
+		ttl:      ttl,
+		sessions: make(map[int64]Session),
+		now:      time.Now,
+	}
+}
+
+// NewStore открывает хранилище; пустой path — только память
+func NewStore(path string, ttl time.Duration) (*Store, error) {
+	s := NewMemoryStore(ttl)
+	s.path = path
+	if path == "" {
+		return s, nil
+	}
+	raw, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fsm: read %s: %w", path, err)
+	}
+	var sessions []Session
+	if len(raw) > 0 {
+		if err := json.Unmarshal(raw, &sessions); err != nil {
+			return nil, fmt.Errorf("fsm: decode %s: %w", path, err)
+		}
+	}
+	for _, sess := range sessions {
+		if !s.expired(sess) {
+			s.sessions[sess.UserID] = sess
+		}
+	}
+	return s, nil
+}
+
+// Get возвращает активную сессию пользователя или ErrNoSession
+func (s *Store) Get(userID int64) (Session, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[userID]
+	if !ok {
+		return Session{}, ErrNoSession
+	}
+	if s.expired(sess) {
+		delete(s.sessions, userID)
+		return Session{}, ErrNoSession
+	}
+	return sess.clone(), nil
+}
+
+// Put сохраняет сессию пользователя
+func (s *Store) Put(sess Session) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess = sess.clone()
+	sess.UpdatedAt = s.now()
+	s.sessions[sess.UserID] = sess
+	return s.flush()
+}
+
+// Delete завершает сессию пользователя
+func (s *Store) Delete(userID int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.sessions[userID]; !ok {
+		return nil
+	}
+	delete(s.sessions, userID)
+	return s.flush()
+}
+
+func (s *Store) expired(sess Session) bool {
+	return s.ttl > 0 && s.now().Sub(sess.UpdatedAt) > s.ttl
+}
+
+// flush атомарно перезаписывает файл: пишем во временный и переименовываем
+func (s *Store) flush() error {
+	if s.path == "" {
+		return nil
+	}
+	sessions := make([]Session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	raw, err := json.Marshal(sessions)
+	if err != nil {
+		return fmt.Errorf("fsm: encode: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("fsm: mkdir: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, raw, 0o600); err != nil {
+		return fmt.Errorf("fsm: write: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("fsm: rename: %w", err)
+	}
+	return nil
+}
@@ -1,23 +1,18 @@
 package components
 
 import (
-	"fmt"
+	"errors"
 	"strings"
+	"telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/config"
+	"telegram-bot/internal/i18n"
 )
 
 func Header() string {
 	return "<code>🫟 melot</code>\n\n"
 }
-func Info() string {
-	information :=
-		fmt.Sprintf(
-			"%s"+
-				"ℹ️ Информация\n"+
-				"<i>Данное приложение разработано для упрощения взаимодействия пользователей в области предоставления услуг</i>"+
-				""+
-				"", Header())
-	return information
+func Info(l i18n.Localizer) string {
+	return Header() + l.T("info.about")
 }
 func HelpAccount() string {
 	cfg := config.Load()
@@ -26,3 +21,20 @@ func HelpAccount() string {
 	}
 	return cfg.SupportContact
 }
+
+// Error — блок «⚠️ Ошибка» с текстом text на языке l
+func Error(l i18n.Localizer, text string) string {
+	return Header() + l.T("error.block", text)
+}
+
+// APIError — текст для пользователя, когда запрос к API не удался.
+// Если API недоступно, просит повторить позже, иначе возвращает fallback.
+func APIError(l i18n.Localizer, err error, fallback string) string {
+	switch {
+	case errors.Is(err, backendapi.ErrUnavailable):
+		return Error(l, l.T("api.unavailable"))
+	case errors.Is(err, backendapi.ErrRateLimited):
+		return Error(l, l.T("api.rate_limited"))
+	}
+	return fallback
+}
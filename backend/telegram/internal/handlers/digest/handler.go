@@ -0,0 +1,91 @@
+package digest
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Times — время утренней сводки на выбор, в таймзоне мастера
+var Times = []string{"07:00", "08:00", "09:00", "10:00"}
+
+// Off — значение кнопки, выключающей сводку
+const Off = "off"
+
+type Handler struct {
+	logger *logrus.Logger
+	client *adapter.Client
+}
+
+func NewHandler(logger *logrus.Logger, client *adapter.Client) *Handler {
+	return &Handler{logger: logger, client: client}
+}
+
+// HandlerDigest показывает текущее время сводки мастера и кнопки выбора
+func (h *Handler) HandlerDigest(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if !shared.IsValidPrivateMessage(update) {
+		return
+	}
+	chatID := shared.ExtractUserID(update)
+	h.logger.WithField("user_id", chatID).Info("Handler.Digest: showing digest settings")
+	l := i18n.ForUser(ctx, chatID)
+
+	user, err := h.client.GetUserByTelegramID(ctx, chatID)
+	if err != nil {
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, ParseMode: models.ParseModeHTML, Text: components.APIError(l, err, components.Error(l, l.T("digest.load_failed")))})
+		return
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        Text(l, user.DigestTime),
+		ReplyMarkup: Keyboard(l, chatID),
+	})
+}
+
+// Text — описание сводки и выбранное время (digestTime == "" — сводка выключена)
+func Text(l i18n.Localizer, digestTime string) string {
+	current := l.T("digest.state_off")
+	if digestTime != "" {
+		current = l.T("digest.state_on", digestTime)
+	}
+	return components.Header() + l.T("digest.prompt", current)
+}
+
+// Keyboard — кнопки выбора времени сводки и её выключения для мастера owner
+func Keyboard(l i18n.Localizer, owner int64) *models.InlineKeyboardMarkup {
+	ownerID := strconv.FormatInt(owner, 10)
+	row := make([]models.InlineKeyboardButton, 0, len(Times))
+	for _, t := range Times {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         t,
+			CallbackData: callbackdata.Encode(callbackdata.RouteDigest, strings.ReplaceAll(t, ":", ""), ownerID),
+		})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+		row,
+		{{Text: l.T("digest.button.off"), CallbackData: callbackdata.Encode(callbackdata.RouteDigest, Off, ownerID)}},
+	}}
+}
+
+// ParseChoice переводит аргумент кнопки ("0800" или Off) во время для API ("08:00" или "")
+func ParseChoice(arg string) (string, bool) {
+	if arg == Off {
+		return "", true
+	}
+	for _, t := range Times {
+		if strings.ReplaceAll(t, ":", "") == arg {
+			return t, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,159 @@
+package inline
+
+import (
+	"context"
+	"html"
+	"strconv"
+	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/utils"
+	mymodels "telegram-bot/pkg/models"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MaxResults — карточек в ответе на inline-запрос
+	MaxResults = 10
+	// maxServices — услуг в карточке, остальные только числом
+	maxServices = 5
+	// cacheTime — сколько секунд Telegram отдаёт ответ на тот же запрос из кэша
+	cacheTime = 30
+)
+
+type Handler struct {
+	logger *logrus.Logger
+	client *adapter.Client
+}
+
+func NewHandler(logger *logrus.Logger, client *adapter.Client) *Handler {
+	return &Handler{logger: logger, client: client}
+}
+
+// Match — обновление с inline-запросом (@bot <мастер или услуга> в любом чате)
+func Match(update *models.Update) bool {
+	return update.InlineQuery != nil
+}
+
+// HandleInlineQuery отвечает карточками мастеров, у которых имя или услуга совпадают с запросом;
+// пустой запрос показывает мастеров с ближайшими свободными слотами
+func (h *Handler) HandleInlineQuery(ctx context.Context, b *bot.Bot, update *models.Update) {
+	q := update.InlineQuery
+	log := h.logger.WithFields(logrus.Fields{"user_id": q.From.ID, "query": q.Query})
+	l := i18n.ForUser(ctx, q.From.ID)
+
+	masters, err := h.client.SearchMasters(ctx, q.Query, MaxResults)
+	if err != nil {
+		// Пустой ответ без кэша: следующий символ запроса спросит API заново
+		log.WithError(err).Warn("Handler.Inline: search failed")
+		b.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{InlineQueryID: q.ID, Results: []models.InlineQueryResult{}, IsPersonal: true})
+		return
+	}
+	results := make([]models.InlineQueryResult, 0, len(masters))
+	for _, m := range masters {
+		results = append(results, Article(l, m))
+	}
+	if _, err := b.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+		InlineQueryID: q.ID,
+		Results:       results,
+		CacheTime:     cacheTime,
+		// Тексты карточек на языке того, кто спрашивает
+		IsPersonal: true,
+	}); err != nil {
+		log.WithError(err).Warn("Handler.Inline: answer failed")
+		return
+	}
+	log.WithField("count", len(results)).Info("Handler.Inline: answered")
+}
+
+// Article — результат inline-запроса: в чат уходит карточка мастера с услугами,
+// ближайшими свободными слотами и ссылками на запись в боте
+func Article(l i18n.Localizer, m mymodels.MasterCard) *models.InlineQueryResultArticle {
+	article := &models.InlineQueryResultArticle{
+		ID:          strconv.FormatInt(m.TelegramID, 10),
+		Title:       masterName(m),
+		Description: description(l, m),
+		InputMessageContent: &models.InputTextMessageContent{
+			MessageText: Card(l, m),
+			ParseMode:   models.ParseModeHTML,
+		},
+	}
+	if kb := Keyboard(l, m); kb != nil {
+		article.ReplyMarkup = kb
+	}
+	return article
+}
+
+// Card — текст карточки; время слотов в таймзоне мастера: карточку читают в чужих чатах
+func Card(l i18n.Localizer, m mymodels.MasterCard) string {
+	var b strings.Builder
+	b.WriteString("<b>" + html.EscapeString(masterName(m)) + "</b>\n\n")
+
+	b.WriteString(l.T("inline.services") + "\n<blockquote>")
+	for i, s := range m.Services {
+		if i == maxServices {
+			b.WriteString(l.T("inline.more", len(m.Services)-maxServices) + "\n")
+			break
+		}
+		b.WriteString(l.T("inline.service", html.EscapeString(s.Name), l.Money(s.Price, s.Currency), s.Duration) + "\n")
+	}
+	b.WriteString("</blockquote>\n")
+
+	if len(m.NextSlots) == 0 {
+		b.WriteString("<i>" + l.T("inline.no_slots") + "</i>")
+		return b.String()
+	}
+	b.WriteString(l.T("inline.slots", m.Timezone) + "\n<blockquote>")
+	for _, s := range m.NextSlots {
+		b.WriteString("• " + html.EscapeString(slotLabel(m, s)) + "\n")
+	}
+	b.WriteString("</blockquote>")
+	return b.String()
+}
+
+// Keyboard — по кнопке «Записаться» на каждый слот и ссылка на всё расписание.
+// Кнопки-ссылки, а не callback: карточка живёт в чужом чате, запись идёт в личке с ботом.
+// Без TELEGRAM_BOT_LINK ссылок не собрать — карточка уходит без кнопок.
+func Keyboard(l i18n.Localizer, m mymodels.MasterCard) *models.InlineKeyboardMarkup {
+	schedule := shared.BotStartLink(strconv.FormatInt(m.TelegramID, 10))
+	if schedule == "" {
+		return nil
+	}
+	rows := make([][]models.InlineKeyboardButton, 0, len(m.NextSlots)+1)
+	for _, s := range m.NextSlots {
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text: l.T("inline.button.book", slotLabel(m, s)),
+			URL:  shared.BotStartLink(shared.SlotStartPayload(s.ID)),
+		}})
+	}
+	rows = append(rows, []models.InlineKeyboardButton{{Text: l.T("inline.button.schedule"), URL: schedule}})
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func description(l i18n.Localizer, m mymodels.MasterCard) string {
+	names := make([]string, 0, len(m.Services))
+	for _, s := range m.Services {
+		names = append(names, s.Name)
+	}
+	next := l.T("inline.no_slots")
+	if len(m.NextSlots) > 0 {
+		next = l.T("inline.next", slotTime(m, m.NextSlots[0]))
+	}
+	return strings.Join(names, ", ") + "\n" + next
+}
+
+func masterName(m mymodels.MasterCard) string {
+	return strings.TrimSpace(m.FirstName + " " + m.Surname)
+}
+
+func slotTime(m mymodels.MasterCard, s mymodels.SlotResponse) string {
+	return utils.FormatDateInLocation(m.Timezone, s.StartTime) + " " + utils.FormatTimeOnlyInLocation(m.Timezone, s.StartTime)
+}
+
+func slotLabel(m mymodels.MasterCard, s mymodels.SlotResponse) string {
+	return slotTime(m, s) + " " + s.ServiceName
+}
@@ -0,0 +1,40 @@
+package language
+
+import (
+	"context"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+type Handler struct{ logger *logrus.Logger }
+
+func NewHandler(logger *logrus.Logger) *Handler { return &Handler{logger: logger} }
+
+// HandlerLanguage shows bot interface language selection keyboard
+func (h *Handler) HandlerLanguage(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if !shared.IsValidPrivateMessage(update) {
+		return
+	}
+	chatID := shared.ExtractUserID(update)
+	h.logger.WithField("user_id", chatID).Info("Handler.Language: showing language selector")
+
+	// Название языка — на нём самом, чтобы его нашёл и тот, кто не понимает текущий
+	keyboard := make([][]models.InlineKeyboardButton, 0, len(i18n.Supported))
+	for _, lang := range i18n.Supported {
+		keyboard = append(keyboard, []models.InlineKeyboardButton{{
+			Text:         i18n.For(lang).T("language.self_name"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteLanguage, string(lang)),
+		}})
+	}
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        i18n.ForUser(ctx, chatID).T("language.prompt"),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: keyboard},
+	})
+}
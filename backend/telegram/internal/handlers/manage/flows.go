@@ -0,0 +1,79 @@
+package manage
+
+import "telegram-bot/internal/fsm"
+
+// Сценарии управления услугами и слотами
+const (
+	FlowNewService  = "newservice"
+	FlowEditService = "editservice"
+	FlowNewSlot     = "newslot"
+	FlowDeleteSlot  = "deleteslot"
+)
+
+// Шаги сценариев
+const (
+	StateServiceName        fsm.State = "service.name"
+	StateServiceDuration    fsm.State = "service.duration"
+	StateServicePrice       fsm.State = "service.price"
+	StateServiceDescription fsm.State = "service.description"
+	StateServiceConfirm     fsm.State = "service.confirm"
+
+	StateEditChoose fsm.State = "edit.choose"
+	StateEditField  fsm.State = "edit.field"
+	StateEditValue  fsm.State = "edit.value"
+
+	StateSlotService fsm.State = "slot.service"
+	StateSlotDate    fsm.State = "slot.date"
+	StateSlotTime    fsm.State = "slot.time"
+	StateSlotConfirm fsm.State = "slot.confirm"
+
+	StateDeleteConfirm fsm.State = "delete.confirm"
+)
+
+// Flows возвращает сценарии для регистрации в fsm.Machine
+func Flows() []fsm.Flow {
+	return []fsm.Flow{
+		{
+			Name:    FlowNewService,
+			Initial: StateServiceName,
+			Transitions: map[fsm.State][]fsm.State{
+				StateServiceName:        {StateServiceDuration},
+				StateServiceDuration:    {StateServicePrice},
+				StateServicePrice:       {StateServiceDescription},
+				StateServiceDescription: {StateServiceConfirm},
+			},
+		},
+		{
+			Name:    FlowEditService,
+			Initial: StateEditChoose,
+			Transitions: map[fsm.State][]fsm.State{
+				StateEditChoose: {StateEditField},
+				StateEditField:  {StateEditValue, StateEditChoose},
+				StateEditValue:  {StateEditField},
+			},
+		},
+		{
+			Name:    FlowNewSlot,
+			Initial: StateSlotService,
+			Transitions: map[fsm.State][]fsm.State{
+				StateSlotService: {StateSlotDate},
+				StateSlotDate:    {StateSlotTime, StateSlotService},
+				StateSlotTime:    {StateSlotConfirm, StateSlotDate},
+				StateSlotConfirm: {StateSlotTime},
+			},
+		},
+		{
+			Name:    FlowDeleteSlot,
+			Initial: StateDeleteConfirm,
+		},
+	}
+}
+
+// ownsFlow — сценарий относится к управлению услугами и слотами
+func ownsFlow(flow string) bool {
+	switch flow {
+	case FlowNewService, FlowEditService, FlowNewSlot, FlowDeleteSlot:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,689 @@
+package manage
+
+import (
+	"context"
+	"contract/money"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/fsm"
+	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/utils"
+	"telegram-bot/pkg/models"
+	"time"
+
+	"github.com/go-telegram/bot"
+	botmodels "github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// Handler ведёт мастера по сценариям создания услуг и слотов.
+// Состояние диалога хранится в fsm.Machine, поэтому сценарий можно
+// продолжить после перезапуска бота.
+type Handler struct {
+	logger  *logrus.Logger
+	client  *adapter.Client
+	machine *fsm.Machine
+	now     func() time.Time
+}
+
+func NewHandler(logger *logrus.Logger, client *adapter.Client) *Handler {
+	return &Handler{
+		logger:  logger,
+		client:  client,
+		machine: fsm.GetMachine(),
+		now:     time.Now,
+	}
+}
+
+// msgError — общая ошибка сценария, когда причину показывать не нужно
+func msgError(l i18n.Localizer) string {
+	return components.Error(l, l.T("api.failed"))
+}
+
+// HandlerNewService — /newservice
+func (h *Handler) HandlerNewService(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+	userID := update.Message.From.ID
+	l := i18n.ForUser(ctx, userID)
+	master, err := h.client.GetUserByTelegramID(ctx, userID)
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.NewService: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+	if _, err := h.machine.Start(userID, FlowNewService, map[string]string{"master_id": master.ID.String(), "currency": money.Normalize(master.Currency)}); err != nil {
+		h.logger.Errorf("Handler.Manage.NewService: start: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+	h.send(ctx, b, userID, components.Header()+l.T("manage.service.step_name"), cancelKeyboard(l, userID))
+}
+
+// HandlerEditService — /editservice
+func (h *Handler) HandlerEditService(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+	userID := update.Message.From.ID
+	l := i18n.ForUser(ctx, userID)
+	if _, err := h.machine.Start(userID, FlowEditService, nil); err != nil {
+		h.logger.Errorf("Handler.Manage.EditService: start: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+	h.showServices(ctx, b, l, userID, 0, "edit", l.T("manage.edit.title"))
+}
+
+// HandlerNewSlot — /newslot
+func (h *Handler) HandlerNewSlot(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+	userID := update.Message.From.ID
+	l := i18n.ForUser(ctx, userID)
+	master, err := h.client.GetUserByTelegramID(ctx, userID)
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.NewSlot: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+	data := map[string]string{"master_id": master.ID.String(), "tz": master.Timezone}
+	if _, err := h.machine.Start(userID, FlowNewSlot, data); err != nil {
+		h.logger.Errorf("Handler.Manage.NewSlot: start: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+	h.showServices(ctx, b, l, userID, 0, "svc", l.T("manage.slot.title"))
+}
+
+// HandlerCancel — /cancel прерывает текущий сценарий
+func (h *Handler) HandlerCancel(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	if update.Message == nil || update.Message.From == nil {
+		return
+	}
+	userID := update.Message.From.ID
+	l := i18n.ForUser(ctx, userID)
+	if _, ok := h.machine.Current(userID); !ok {
+		h.send(ctx, b, userID, components.Header()+l.T("manage.no_action"), nil)
+		return
+	}
+	if err := h.machine.Finish(userID); err != nil {
+		h.logger.Errorf("Handler.Manage.Cancel: %v", err)
+	}
+	h.send(ctx, b, userID, components.Header()+l.T("manage.cancelled"), nil)
+}
+
+// MatchInput отбирает текстовые сообщения (не команды) пользователей с активным сценарием управления
+func (h *Handler) MatchInput(update *botmodels.Update) bool {
+	if update == nil || update.Message == nil || update.Message.From == nil {
+		return false
+	}
+	if update.Message.Chat.Type != botmodels.ChatTypePrivate {
+		return false
+	}
+	text := strings.TrimSpace(update.Message.Text)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+	sess, ok := h.machine.Current(update.Message.From.ID)
+	return ok && ownsFlow(sess.Flow)
+}
+
+// HandleInput обрабатывает текст, введённый на текущем шаге сценария
+func (h *Handler) HandleInput(ctx context.Context, b *bot.Bot, update *botmodels.Update) {
+	userID := update.Message.From.ID
+	text := update.Message.Text
+	sess, ok := h.machine.Current(userID)
+	if !ok {
+		return
+	}
+	l := i18n.ForUser(ctx, userID)
+
+	switch sess.State {
+	case StateServiceName:
+		name, err := parseName(l, text)
+		if err != nil {
+			h.send(ctx, b, userID, "⚠️ "+err.Error(), cancelKeyboard(l, userID))
+			return
+		}
+		if h.advance(ctx, b, l, userID, StateServiceDuration, map[string]string{"name": name}) {
+			h.send(ctx, b, userID, l.T("manage.service.step_duration"), cancelKeyboard(l, userID))
+		}
+	case StateServiceDuration:
+		duration, err := parseDuration(l, text)
+		if err != nil {
+			h.send(ctx, b, userID, "⚠️ "+err.Error(), cancelKeyboard(l, userID))
+			return
+		}
+		if h.advance(ctx, b, l, userID, StateServicePrice, map[string]string{"duration": strconv.Itoa(duration)}) {
+			h.send(ctx, b, userID, l.T("manage.service.step_price", sess.Get("currency")), cancelKeyboard(l, userID))
+		}
+	case StateServicePrice:
+		price, err := parsePrice(l, text, sess.Get("currency"))
+		if err != nil {
+			h.send(ctx, b, userID, "⚠️ "+err.Error(), cancelKeyboard(l, userID))
+			return
+		}
+		if h.advance(ctx, b, l, userID, StateServiceDescription, map[string]string{"price": strconv.FormatInt(price, 10)}) {
+			h.send(ctx, b, userID, l.T("manage.service.step_description"), skipKeyboard(l, userID))
+		}
+	case StateServiceDescription:
+		description, err := parseDescription(l, text)
+		if err != nil {
+			h.send(ctx, b, userID, "⚠️ "+err.Error(), skipKeyboard(l, userID))
+			return
+		}
+		h.confirmService(ctx, b, l, userID, 0, description)
+	case StateEditValue:
+		h.applyEdit(ctx, b, l, userID, sess, text)
+	case StateSlotTime:
+		hour, minute, err := parseClock(l, text)
+		if err != nil {
+			h.send(ctx, b, userID, "⚠️ "+err.Error(), nil)
+			return
+		}
+		h.chooseTime(ctx, b, l, userID, 0, sess, hour, minute)
+	default:
+		h.send(ctx, b, userID, l.T("manage.use_buttons"), nil)
+	}
+}
+
+// HandleCallback обрабатывает нажатия маршрута callbackdata.RouteManage: {action}/{arg}/{masterTelegramID};
+// чужие нажатия отсекает роутер по последнему аргументу
+func (h *Handler) HandleCallback(ctx context.Context, b *bot.Bot, update *botmodels.Update, action, arg string) {
+	query := update.CallbackQuery
+	if query == nil {
+		return
+	}
+	userID := query.From.ID
+	l := i18n.ForUser(ctx, userID)
+	messageID := 0
+	if query.Message.Message != nil {
+		messageID = query.Message.Message.ID
+	}
+
+	switch action {
+	case "cancel", "done":
+		if err := h.machine.Finish(userID); err != nil {
+			h.logger.Errorf("Handler.Manage.Callback: finish: %v", err)
+		}
+		text := l.T("manage.cancelled")
+		if action == "done" {
+			text = l.T("manage.done")
+		}
+		h.edit(ctx, b, userID, messageID, components.Header()+text, nil)
+	case "skip":
+		if _, ok := h.expect(ctx, b, query, l, StateServiceDescription); ok {
+			h.confirmService(ctx, b, l, userID, messageID, "")
+		}
+	case "confirm":
+		sess, ok := h.machine.Current(userID)
+		switch {
+		case ok && sess.State == StateServiceConfirm:
+			h.createService(ctx, b, l, userID, messageID, sess)
+		case ok && sess.State == StateSlotConfirm:
+			h.createSlot(ctx, b, l, userID, messageID, sess)
+		default:
+			h.answer(ctx, b, query, l.T("manage.stale"), true)
+			return
+		}
+	case "svc":
+		if _, ok := h.expect(ctx, b, query, l, StateSlotService); ok {
+			h.chooseService(ctx, b, l, userID, messageID, arg)
+		}
+	case "month":
+		sess, ok := h.expect(ctx, b, query, l, StateSlotDate)
+		if !ok {
+			return
+		}
+		month, err := time.ParseInLocation("2006-01", arg, h.location(sess))
+		if err != nil {
+			h.answer(ctx, b, query, "", false)
+			return
+		}
+		h.edit(ctx, b, userID, messageID, h.slotHeader(l, sess, l.T("manage.slot.step_date")), datePicker(l, userID, month, h.now().In(h.location(sess))))
+	case "date":
+		sess, ok := h.expect(ctx, b, query, l, StateSlotDate)
+		if !ok {
+			return
+		}
+		day, err := time.ParseInLocation("2006-01-02", arg, h.location(sess))
+		if err != nil {
+			h.answer(ctx, b, query, "", false)
+			return
+		}
+		sess, err = h.machine.Advance(userID, StateSlotTime, map[string]string{"date": arg})
+		if err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		h.edit(ctx, b, userID, messageID,
+			h.slotHeader(l, sess, l.T("manage.slot.step_time")),
+			timePicker(l, userID, day, h.now().In(h.location(sess))))
+	case "time":
+		sess, ok := h.expect(ctx, b, query, l, StateSlotTime)
+		if !ok {
+			return
+		}
+		if len(arg) != 4 {
+			h.answer(ctx, b, query, "", false)
+			return
+		}
+		hour, _ := strconv.Atoi(arg[:2])
+		minute, _ := strconv.Atoi(arg[2:])
+		h.chooseTime(ctx, b, l, userID, messageID, sess, hour, minute)
+	case "back":
+		h.back(ctx, b, query, l, userID, messageID, arg)
+	case "edit":
+		if _, ok := h.expect(ctx, b, query, l, StateEditChoose); !ok {
+			return
+		}
+		serviceID, err := strconv.ParseUint(arg, 10, 64)
+		if err != nil {
+			h.answer(ctx, b, query, "", false)
+			return
+		}
+		if _, err := h.machine.Advance(userID, StateEditField, map[string]string{"service_id": arg}); err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		h.showServiceCard(ctx, b, l, userID, messageID, uint(serviceID), "")
+	case "field":
+		if _, ok := h.expect(ctx, b, query, l, StateEditField); !ok {
+			return
+		}
+		prompt, known := fieldPrompts[arg]
+		if !known {
+			h.answer(ctx, b, query, "", false)
+			return
+		}
+		if _, err := h.machine.Advance(userID, StateEditValue, map[string]string{"field": arg}); err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		h.edit(ctx, b, userID, messageID, l.T(prompt), cancelKeyboard(l, userID))
+	case "slot_delete":
+		h.askDeleteSlot(ctx, b, l, userID, messageID, arg)
+	case "slot_delete_ok":
+		h.deleteSlot(ctx, b, query, l, userID, messageID, arg)
+	}
+	h.answer(ctx, b, query, "", false)
+}
+
+// fieldPrompts — ключи каталога с подсказкой для каждого изменяемого поля услуги
+var fieldPrompts = map[string]string{
+	"name":        "manage.edit.name",
+	"description": "manage.edit.description",
+	"duration":    "manage.edit.duration",
+	"price":       "manage.edit.price",
+}
+
+func (h *Handler) back(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, l i18n.Localizer, userID int64, messageID int, target string) {
+	sess, ok := h.machine.Current(userID)
+	if !ok {
+		h.answer(ctx, b, query, l.T("manage.stale"), true)
+		return
+	}
+	switch target {
+	case "service":
+		if _, err := h.machine.Advance(userID, StateSlotService, nil); err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		h.showServices(ctx, b, l, userID, messageID, "svc", l.T("manage.slot.title"))
+	case "date":
+		sess, err := h.machine.Advance(userID, StateSlotDate, nil)
+		if err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		month := h.now().In(h.location(sess))
+		if day, err := time.ParseInLocation("2006-01-02", sess.Get("date"), h.location(sess)); err == nil {
+			month = day
+		}
+		h.edit(ctx, b, userID, messageID, h.slotHeader(l, sess, l.T("manage.slot.step_date")), datePicker(l, userID, month, h.now().In(h.location(sess))))
+	case "time":
+		sess, err := h.machine.Advance(userID, StateSlotTime, nil)
+		if err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		day, _ := time.ParseInLocation("2006-01-02", sess.Get("date"), h.location(sess))
+		h.edit(ctx, b, userID, messageID,
+			h.slotHeader(l, sess, l.T("manage.slot.step_time")),
+			timePicker(l, userID, day, h.now().In(h.location(sess))))
+	case "services":
+		if _, err := h.machine.Advance(userID, StateEditChoose, nil); err != nil {
+			h.fail(ctx, b, query, l, err)
+			return
+		}
+		h.showServices(ctx, b, l, userID, messageID, "edit", l.T("manage.edit.title"))
+	default:
+		h.logger.Warnf("Handler.Manage.Back: unknown target %q in state %s", target, sess.State)
+	}
+}
+
+// confirmService показывает итог новой услуги перед созданием
+func (h *Handler) confirmService(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, description string) {
+	sess, err := h.machine.Advance(userID, StateServiceConfirm, map[string]string{"description": description})
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ConfirmService: %v", err)
+		h.edit(ctx, b, userID, messageID, l.T("manage.use_buttons"), nil)
+		return
+	}
+	price, _ := strconv.ParseInt(sess.Get("price"), 10, 64)
+	duration, _ := strconv.Atoi(sess.Get("duration"))
+	text := components.Header() + l.T("manage.service.confirm",
+		html.EscapeString(sess.Get("name")), l.T("common.minutes", duration), l.Money(price, sess.Get("currency")), html.EscapeString(orDash(description)))
+	h.edit(ctx, b, userID, messageID, text, confirmKeyboard(l, userID, ""))
+}
+
+func (h *Handler) createService(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, sess fsm.Session) {
+	masterID, err := uuid.Parse(sess.Get("master_id"))
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.CreateService: bad master_id: %v", err)
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	duration, _ := strconv.Atoi(sess.Get("duration"))
+	price, _ := strconv.ParseInt(sess.Get("price"), 10, 64)
+	service := models.Service{
+		MasterID:    masterID,
+		Name:        sess.Get("name"),
+		Description: sess.Get("description"),
+		Duration:    duration,
+		Price:       price,
+		Currency:    sess.Get("currency"),
+	}
+	if err := h.client.CreateService(ctx, userID, service); err != nil {
+		h.edit(ctx, b, userID, messageID, apiErrorText(l, err), confirmKeyboard(l, userID, ""))
+		return
+	}
+	if err := h.machine.Finish(userID); err != nil {
+		h.logger.Errorf("Handler.Manage.CreateService: finish: %v", err)
+	}
+	h.edit(ctx, b, userID, messageID,
+		components.Header()+l.T("manage.service.created", html.EscapeString(service.Name)), nil)
+}
+
+// applyEdit сохраняет новое значение поля услуги
+func (h *Handler) applyEdit(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, sess fsm.Session, text string) {
+	serviceID, _ := strconv.ParseUint(sess.Get("service_id"), 10, 64)
+	service, err := h.client.GetServiceByID(ctx, uint(serviceID))
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ApplyEdit: %v", err)
+		h.send(ctx, b, userID, msgError(l), nil)
+		return
+	}
+
+	switch sess.Get("field") {
+	case "name":
+		service.Name, err = parseName(l, text)
+	case "description":
+		service.Description, err = parseDescription(l, text)
+	case "duration":
+		service.Duration, err = parseDuration(l, text)
+	case "price":
+		service.Price, err = parsePrice(l, text, service.Currency)
+	default:
+		err = errors.New(l.T("manage.invalid.field"))
+	}
+	if err != nil {
+		h.send(ctx, b, userID, "⚠️ "+err.Error(), cancelKeyboard(l, userID))
+		return
+	}
+	if err := h.client.UpdateService(ctx, userID, *service); err != nil {
+		h.send(ctx, b, userID, apiErrorText(l, err), cancelKeyboard(l, userID))
+		return
+	}
+	if _, err := h.machine.Advance(userID, StateEditField, nil); err != nil {
+		h.logger.Errorf("Handler.Manage.ApplyEdit: advance: %v", err)
+	}
+	h.showServiceCard(ctx, b, l, userID, 0, service.ID, l.T("manage.saved"))
+}
+
+func (h *Handler) chooseService(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, arg string) {
+	serviceID, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil {
+		return
+	}
+	service, err := h.client.GetServiceByID(ctx, uint(serviceID))
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ChooseService: %v", err)
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	sess, err := h.machine.Advance(userID, StateSlotDate, map[string]string{
+		"service_id":   arg,
+		"service_name": service.Name,
+		"duration":     strconv.Itoa(service.Duration),
+	})
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ChooseService: advance: %v", err)
+		return
+	}
+	now := h.now().In(h.location(sess))
+	h.edit(ctx, b, userID, messageID, h.slotHeader(l, sess, l.T("manage.slot.step_date")), datePicker(l, userID, now, now))
+}
+
+// chooseTime фиксирует время начала и показывает итог слота
+func (h *Handler) chooseTime(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, sess fsm.Session, hour, minute int) {
+	loc := h.location(sess)
+	day, err := time.ParseInLocation("2006-01-02", sess.Get("date"), loc)
+	if err != nil {
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	start := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+	if !start.After(h.now()) {
+		h.edit(ctx, b, userID, messageID, l.T("manage.slot.past"), timePicker(l, userID, day, h.now().In(loc)))
+		return
+	}
+	sess, err = h.machine.Advance(userID, StateSlotConfirm, map[string]string{"time": start.Format("15:04")})
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ChooseTime: advance: %v", err)
+		h.edit(ctx, b, userID, messageID, l.T("manage.use_buttons"), nil)
+		return
+	}
+	start, end := h.slotBounds(sess)
+	text := h.slotHeader(l, sess, l.T("manage.slot.confirm",
+		start.Format(l.DateLayout()), start.Format(l.TimeLayout()), end.Format(l.TimeLayout()), loc.String(), utils.GetTimezoneOffset(loc.String())))
+	h.edit(ctx, b, userID, messageID, text, confirmKeyboard(l, userID, "time"))
+}
+
+func (h *Handler) createSlot(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, sess fsm.Session) {
+	masterID, err := uuid.Parse(sess.Get("master_id"))
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.CreateSlot: bad master_id: %v", err)
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	serviceID, _ := strconv.ParseUint(sess.Get("service_id"), 10, 64)
+	start, end := h.slotBounds(sess)
+	slot := models.Slot{
+		MasterID:  masterID,
+		ServiceID: uint(serviceID),
+		StartTime: start.UTC(),
+		EndTime:   end.UTC(),
+	}
+	if err := h.client.CreateSlot(ctx, userID, slot); err != nil {
+		h.edit(ctx, b, userID, messageID, apiErrorText(l, err), confirmKeyboard(l, userID, "time"))
+		return
+	}
+	if err := h.machine.Finish(userID); err != nil {
+		h.logger.Errorf("Handler.Manage.CreateSlot: finish: %v", err)
+	}
+	h.edit(ctx, b, userID, messageID,
+		components.Header()+l.T("manage.slot.created", start.Format(l.DateLayout()), start.Format(l.TimeLayout())), nil)
+}
+
+// askDeleteSlot просит подтвердить удаление слота из карточки слота
+func (h *Handler) askDeleteSlot(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, arg string) {
+	if _, err := strconv.ParseUint(arg, 10, 64); err != nil {
+		return
+	}
+	if _, err := h.machine.Start(userID, FlowDeleteSlot, map[string]string{"slot_id": arg}); err != nil {
+		h.logger.Errorf("Handler.Manage.AskDeleteSlot: %v", err)
+		return
+	}
+	kb := &botmodels.InlineKeyboardMarkup{InlineKeyboard: [][]botmodels.InlineKeyboardButton{
+		{button(userID, l.T("manage.button.delete_slot"), "slot_delete_ok/"+arg)},
+		cancelRow(l, userID),
+	}}
+	h.edit(ctx, b, userID, messageID,
+		components.Header()+l.T("manage.slot.delete_prompt"), kb)
+}
+
+func (h *Handler) deleteSlot(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, l i18n.Localizer, userID int64, messageID int, arg string) {
+	sess, ok := h.expect(ctx, b, query, l, StateDeleteConfirm)
+	if !ok || sess.Get("slot_id") != arg {
+		return
+	}
+	slotID, _ := strconv.ParseUint(arg, 10, 64)
+	if err := h.client.DeleteSlot(ctx, userID, uint(slotID)); err != nil {
+		h.edit(ctx, b, userID, messageID, apiErrorText(l, err), nil)
+	} else {
+		h.edit(ctx, b, userID, messageID, components.Header()+l.T("manage.slot.deleted"), nil)
+	}
+	if err := h.machine.Finish(userID); err != nil {
+		h.logger.Errorf("Handler.Manage.DeleteSlot: finish: %v", err)
+	}
+}
+
+func (h *Handler) showServices(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, action, title string) {
+	services, err := h.client.GetServicesByTelegramID(ctx, userID)
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ShowServices: %v", err)
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	if len(services) == 0 {
+		if err := h.machine.Finish(userID); err != nil {
+			h.logger.Errorf("Handler.Manage.ShowServices: finish: %v", err)
+		}
+		h.edit(ctx, b, userID, messageID, components.Header()+l.T("manage.no_services"), nil)
+		return
+	}
+	h.edit(ctx, b, userID, messageID, components.Header()+title, serviceChooser(l, userID, services, action))
+}
+
+func (h *Handler) showServiceCard(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, messageID int, serviceID uint, prefix string) {
+	service, err := h.client.GetServiceByID(ctx, serviceID)
+	if err != nil {
+		h.logger.Errorf("Handler.Manage.ShowServiceCard: %v", err)
+		h.edit(ctx, b, userID, messageID, msgError(l), nil)
+		return
+	}
+	text := components.Header() + prefix + l.T("manage.service.card",
+		html.EscapeString(service.Name), l.T("common.minutes", service.Duration), l.Money(service.Price, service.Currency), html.EscapeString(orDash(service.Description)))
+	h.edit(ctx, b, userID, messageID, text, fieldChooser(l, userID))
+}
+
+// expect проверяет, что сценарий пользователя находится в шаге state;
+// иначе кнопка из старого сообщения — сообщаем об этом
+func (h *Handler) expect(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, l i18n.Localizer, state fsm.State) (fsm.Session, bool) {
+	sess, ok := h.machine.Current(query.From.ID)
+	if !ok || sess.State != state {
+		h.answer(ctx, b, query, l.T("manage.stale"), true)
+		return fsm.Session{}, false
+	}
+	return sess, true
+}
+
+// advance переводит сценарий на следующий шаг при текстовом вводе
+func (h *Handler) advance(ctx context.Context, b *bot.Bot, l i18n.Localizer, userID int64, to fsm.State, data map[string]string) bool {
+	if _, err := h.machine.Advance(userID, to, data); err != nil {
+		h.logger.Errorf("Handler.Manage.Advance: %v", err)
+		h.send(ctx, b, userID, l.T("manage.use_buttons"), nil)
+		return false
+	}
+	return true
+}
+
+func (h *Handler) fail(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, l i18n.Localizer, err error) {
+	h.logger.Errorf("Handler.Manage.Callback: %v", err)
+	if errors.Is(err, fsm.ErrTransition) || errors.Is(err, fsm.ErrNoSession) {
+		h.answer(ctx, b, query, l.T("manage.stale"), true)
+		return
+	}
+	h.answer(ctx, b, query, l.T("manage.failed_short"), true)
+}
+
+func (h *Handler) slotHeader(l i18n.Localizer, sess fsm.Session, body string) string {
+	duration, _ := strconv.Atoi(sess.Get("duration"))
+	return components.Header() + l.T("manage.slot.header", html.EscapeString(sess.Get("service_name")), l.T("common.minutes", duration), body)
+}
+
+// slotBounds возвращает начало и конец слота по выбранным дате, времени и длительности услуги
+func (h *Handler) slotBounds(sess fsm.Session) (time.Time, time.Time) {
+	start, _ := time.ParseInLocation("2006-01-02 15:04", sess.Get("date")+" "+sess.Get("time"), h.location(sess))
+	duration, _ := strconv.Atoi(sess.Get("duration"))
+	return start, start.Add(time.Duration(duration) * time.Minute)
+}
+
+// location — таймзона мастера; по умолчанию московская, как и в остальных сообщениях бота
+func (h *Handler) location(sess fsm.Session) *time.Location {
+	if loc, err := time.LoadLocation(sess.Get("tz")); err == nil && sess.Get("tz") != "" {
+		return loc
+	}
+	if loc, err := time.LoadLocation("Europe/Moscow"); err == nil {
+		return loc
+	}
+	return time.Local
+}
+
+func (h *Handler) send(ctx context.Context, b *bot.Bot, chatID int64, text string, kb *botmodels.InlineKeyboardMarkup) {
+	params := &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: botmodels.ParseModeHTML}
+	if kb != nil {
+		params.ReplyMarkup = kb
+	}
+	if _, err := b.SendMessage(ctx, params); err != nil {
+		h.logger.Errorf("Handler.Manage.Send: %v", err)
+	}
+}
+
+// edit редактирует сообщение с кнопками; без messageID отправляет новое
+func (h *Handler) edit(ctx context.Context, b *bot.Bot, chatID int64, messageID int, text string, kb *botmodels.InlineKeyboardMarkup) {
+	if messageID == 0 {
+		h.send(ctx, b, chatID, text, kb)
+		return
+	}
+	params := &bot.EditMessageTextParams{ChatID: chatID, MessageID: messageID, Text: text, ParseMode: botmodels.ParseModeHTML}
+	if kb != nil {
+		params.ReplyMarkup = kb
+	}
+	if _, err := b.EditMessageText(ctx, params); err != nil {
+		h.logger.Errorf("Handler.Manage.Edit: %v", err)
+	}
+}
+
+func (h *Handler) answer(ctx context.Context, b *bot.Bot, query *botmodels.CallbackQuery, text string, alert bool) {
+	b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: query.ID,
+		Text:            text,
+		ShowAlert:       alert,
+	})
+}
+
+// apiErrorText показывает пользователю причину отказа API, если она есть
+func apiErrorText(l i18n.Localizer, err error) string {
+	var apiErr *adapter.APIError
+	if errors.Is(err, adapter.ErrUnavailable) || errors.As(err, &apiErr) && apiErr.Message != "" {
+		return components.Error(l, html.EscapeString(adapter.UserMessage(l, err)))
+	}
+	return msgError(l)
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "—"
+	}
+	return s
+}
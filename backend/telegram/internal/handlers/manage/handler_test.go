@@ -0,0 +1,270 @@
+package manage
+
+import (
+	"context"
+	"contract"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/fsm"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	botmodels "github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const masterTelegramID = 42
+
+var masterID = uuid.MustParse("7f1c2a9e-3b4d-4e5f-8a6b-1c2d3e4f5a6b")
+
+// apiCall — запрос бота к API, изменяющий данные
+type apiCall struct {
+	method, path string
+	token        string
+	telegramID   string
+	body         []byte
+}
+
+// fakeServer отвечает и как Telegram Bot API (/bot...), и как API сервиса
+type fakeServer struct {
+	mu       sync.Mutex
+	texts    []string
+	calls    []apiCall
+	services map[uint]contract.Service
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if strings.HasPrefix(r.URL.Path, "/bot") {
+		_ = r.ParseMultipartForm(1 << 20)
+		var result any = true
+		if text := r.FormValue("text"); text != "" {
+			f.texts = append(f.texts, text)
+			result = map[string]any{"message_id": len(f.texts), "date": 0, "chat": map[string]any{"id": masterTelegramID, "type": "private"}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user/g3tter/42":
+		writeJSON(w, contract.User{ID: masterID, Currency: "RUB", Timezone: "Europe/Moscow"})
+	case r.Method == http.MethodGet && r.URL.Path == "/service/master/42":
+		list := make([]contract.Service, 0, len(f.services))
+		for _, s := range f.services {
+			list = append(list, s)
+		}
+		writeJSON(w, list)
+	case r.Method == http.MethodGet && r.URL.Path == "/service/7":
+		writeJSON(w, f.services[7])
+	case r.Method != http.MethodGet:
+		body, _ := io.ReadAll(r.Body)
+		f.calls = append(f.calls, apiCall{
+			method: r.Method, path: r.URL.Path, body: body,
+			token: r.Header.Get("X-Internal-Token"), telegramID: r.Header.Get("X-Telegram-ID"),
+		})
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (f *fakeServer) lastText() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.texts) == 0 {
+		return ""
+	}
+	return f.texts[len(f.texts)-1]
+}
+
+func (f *fakeServer) onlyCall(t *testing.T, method, path string, into any) apiCall {
+	t.Helper()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.calls) != 1 || f.calls[0].method != method || f.calls[0].path != path {
+		t.Fatalf("api calls = %+v, want one %s %s", f.calls, method, path)
+	}
+	if err := json.Unmarshal(f.calls[0].body, into); err != nil {
+		t.Fatalf("decode %s body: %v", path, err)
+	}
+	return f.calls[0]
+}
+
+type fixture struct {
+	h   *Handler
+	b   *bot.Bot
+	api *fakeServer
+}
+
+func newFixture(t *testing.T, now time.Time) *fixture {
+	t.Helper()
+	api := &fakeServer{services: map[uint]contract.Service{
+		7: {ID: 7, MasterID: masterID, Name: "Стрижка", Duration: 60, Price: 150000, Currency: "RUB"},
+	}}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	b, err := bot.New("123:test", bot.WithServerURL(srv.URL), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := &Handler{
+		logger:  logger,
+		client:  adapter.New(srv.URL, "secret", logger),
+		machine: fsm.NewMachine(fsm.NewMemoryStore(0), Flows()...),
+		now:     func() time.Time { return now },
+	}
+	return &fixture{h: h, b: b, api: api}
+}
+
+func (f *fixture) command(handler bot.HandlerFunc, text string) {
+	handler(context.Background(), f.b, f.message(text))
+}
+
+func (f *fixture) input(text string) {
+	f.h.HandleInput(context.Background(), f.b, f.message(text))
+}
+
+func (f *fixture) press(action, arg string) {
+	f.h.HandleCallback(context.Background(), f.b, &botmodels.Update{CallbackQuery: &botmodels.CallbackQuery{
+		ID:      "q",
+		From:    botmodels.User{ID: masterTelegramID},
+		Message: botmodels.MaybeInaccessibleMessage{Message: &botmodels.Message{ID: 1}},
+	}}, action, arg)
+}
+
+func (f *fixture) message(text string) *botmodels.Update {
+	return &botmodels.Update{Message: &botmodels.Message{
+		Text: text,
+		From: &botmodels.User{ID: masterTelegramID},
+		Chat: botmodels.Chat{ID: masterTelegramID, Type: botmodels.ChatTypePrivate},
+	}}
+}
+
+func (f *fixture) state(t *testing.T) fsm.State {
+	t.Helper()
+	sess, ok := f.h.machine.Current(masterTelegramID)
+	if !ok {
+		return ""
+	}
+	return sess.State
+}
+
+func TestNewServiceFlow(t *testing.T) {
+	f := newFixture(t, time.Now())
+
+	f.command(f.h.HandlerNewService, "/newservice")
+	f.input("Маникюр")
+	f.input("полчаса")
+	if got := f.state(t); got != StateServiceDuration {
+		t.Fatalf("state after bad duration = %q, want %q", got, StateServiceDuration)
+	}
+	f.input("45 мин")
+	f.input("1 500")
+	f.press("skip", "")
+	if got := f.state(t); got != StateServiceConfirm {
+		t.Fatalf("state before confirm = %q, want %q", got, StateServiceConfirm)
+	}
+	if text := f.api.lastText(); !strings.Contains(text, "Маникюр") || !strings.Contains(text, "45") {
+		t.Fatalf("confirm screen = %q", text)
+	}
+	f.press("confirm", "")
+
+	var created contract.Service
+	call := f.api.onlyCall(t, http.MethodPost, "/service/create", &created)
+	if call.token != "secret" || call.telegramID != "42" {
+		t.Errorf("request signed as %q/%q, want the master", call.token, call.telegramID)
+	}
+	if created.MasterID != masterID || created.Name != "Маникюр" || created.Duration != 45 || created.Price != 150000 || created.Currency != "RUB" {
+		t.Errorf("created service = %+v", created)
+	}
+	if got := f.state(t); got != "" {
+		t.Errorf("flow still active in %q", got)
+	}
+}
+
+func TestEditServiceFlow(t *testing.T) {
+	f := newFixture(t, time.Now())
+
+	f.command(f.h.HandlerEditService, "/editservice")
+	f.press("edit", "7")
+	f.press("field", "price")
+	if got := f.state(t); got != StateEditValue {
+		t.Fatalf("state = %q, want %q", got, StateEditValue)
+	}
+	f.input("2000")
+
+	var updated contract.Service
+	call := f.api.onlyCall(t, http.MethodPut, "/service/update", &updated)
+	if call.telegramID != "42" {
+		t.Errorf("X-Telegram-ID = %q, want the master", call.telegramID)
+	}
+	if updated.ID != 7 || updated.Price != 200000 || updated.Name != "Стрижка" || updated.Duration != 60 {
+		t.Errorf("updated service = %+v, want only the price changed", updated)
+	}
+	if got := f.state(t); got != StateEditField {
+		t.Errorf("state after save = %q, want %q", got, StateEditField)
+	}
+}
+
+func TestNewSlotFlow(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("tzdata is not available")
+	}
+	f := newFixture(t, time.Date(2026, 10, 19, 12, 0, 0, 0, moscow))
+
+	f.command(f.h.HandlerNewSlot, "/newslot")
+	f.press("svc", "7")
+	f.press("date", "2026-10-19")
+	f.input("09:00")
+	if text := f.api.lastText(); !strings.Contains(text, "⚠️") {
+		t.Fatalf("past time accepted: %q", text)
+	}
+	if got := f.state(t); got != StateSlotTime {
+		t.Fatalf("state after past time = %q, want %q", got, StateSlotTime)
+	}
+	f.press("back", "date")
+	f.press("date", "2026-10-20")
+	f.press("time", "1030")
+	if got := f.state(t); got != StateSlotConfirm {
+		t.Fatalf("state = %q, want %q", got, StateSlotConfirm)
+	}
+	f.press("confirm", "")
+
+	var created contract.CreateSlot
+	call := f.api.onlyCall(t, http.MethodPost, "/slot/master/create", &created)
+	if call.token != "secret" || call.telegramID != "42" {
+		t.Errorf("request signed as %q/%q, want the master", call.token, call.telegramID)
+	}
+	wantStart := time.Date(2026, 10, 20, 7, 30, 0, 0, time.UTC)
+	if created.MasterID != masterID || created.ServiceID != 7 || !created.StartTime.Equal(wantStart) || !created.EndTime.Equal(wantStart.Add(time.Hour)) {
+		t.Errorf("created slot = %+v, want 07:30–08:30 UTC", created)
+	}
+}
+
+func TestStaleButtonDoesNotCreate(t *testing.T) {
+	f := newFixture(t, time.Now())
+
+	f.press("confirm", "")
+	f.press("time", "1030")
+	if len(f.api.calls) != 0 {
+		t.Fatalf("api calls without a flow: %+v", f.api.calls)
+	}
+}
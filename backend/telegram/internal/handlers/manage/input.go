@@ -0,0 +1,69 @@
+package manage
+
+import (
+	"contract/money"
+	"errors"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/i18n"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	maxNameLength        = 100
+	maxDescriptionLength = 500
+	maxDuration          = 12 * 60
+)
+
+func parseName(l i18n.Localizer, text string) (string, error) {
+	name := strings.TrimSpace(text)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", errors.New(l.T("manage.invalid.name", maxNameLength))
+	}
+	return name, nil
+}
+
+func parseDescription(l i18n.Localizer, text string) (string, error) {
+	description := strings.TrimSpace(text)
+	if description == "-" {
+		return "", nil
+	}
+	if utf8.RuneCountInString(description) > maxDescriptionLength {
+		return "", errors.New(l.T("manage.invalid.description", maxDescriptionLength))
+	}
+	return description, nil
+}
+
+// parseDuration принимает длительность в минутах: "60", "60 мин", "60 min"
+func parseDuration(l i18n.Localizer, text string) (int, error) {
+	raw := strings.TrimSuffix(strings.TrimSpace(text), ".")
+	raw = strings.TrimSuffix(strings.TrimSuffix(raw, "мин"), "min")
+	minutes, err := strconv.Atoi(strings.TrimSpace(raw))
+	if err != nil || minutes <= 0 || minutes > maxDuration {
+		return 0, errors.New(l.T("manage.invalid.duration", maxDuration))
+	}
+	return minutes, nil
+}
+
+// parsePrice принимает цену в валюте currency: "1500", "1500,50", "1 500 ₽" —
+// и возвращает её в минимальных единицах (копейках)
+func parsePrice(l i18n.Localizer, text, currency string) (int64, error) {
+	price, err := money.Parse(text, currency)
+	if err != nil {
+		return 0, errors.New(l.T("manage.invalid.price"))
+	}
+	return price, nil
+}
+
+// parseClock принимает время "09:30" или "9.30"
+func parseClock(l i18n.Localizer, text string) (hour, minute int, err error) {
+	raw := strings.Replace(strings.TrimSpace(text), ".", ":", 1)
+	t, perr := time.Parse("15:04", raw)
+	if perr != nil {
+		if t, perr = time.Parse("3:04", raw); perr != nil {
+			return 0, 0, errors.New(l.T("manage.invalid.time"))
+		}
+	}
+	return t.Hour(), t.Minute(), nil
+}
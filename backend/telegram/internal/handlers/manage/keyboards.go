@@ -0,0 +1,158 @@
+package manage
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/pkg/models"
+	"time"
+
+	botmodels "github.com/go-telegram/bot/models"
+)
+
+const (
+	// Сетка времени в пикере: с 07:00 до 22:30 с шагом 30 минут
+	pickerFirstMinute = 7 * 60
+	pickerLastMinute  = 22*60 + 30
+	pickerStep        = 30
+	pickerMonthsAhead = 6
+)
+
+// button — кнопка сценария мастера owner; data имеет вид action или action/arg
+func button(owner int64, text, data string) botmodels.InlineKeyboardButton {
+	action, arg, _ := strings.Cut(data, "/")
+	return botmodels.InlineKeyboardButton{Text: text, CallbackData: callbackdata.Encode(callbackdata.RouteManage, action, arg, strconv.FormatInt(owner, 10))}
+}
+
+func noop(text string) botmodels.InlineKeyboardButton {
+	return botmodels.InlineKeyboardButton{Text: text, CallbackData: callbackdata.Encode(callbackdata.RouteNoop)}
+}
+
+func cancelRow(l i18n.Localizer, owner int64) []botmodels.InlineKeyboardButton {
+	return []botmodels.InlineKeyboardButton{button(owner, l.T("manage.button.cancel"), "cancel")}
+}
+
+// serviceChooser — список услуг мастера; action: "svc" (новый слот) или "edit"
+func serviceChooser(l i18n.Localizer, owner int64, services []models.Service, action string) *botmodels.InlineKeyboardMarkup {
+	rows := make([][]botmodels.InlineKeyboardButton, 0, len(services)+1)
+	for _, s := range services {
+		text := l.T("manage.service.option", s.Name, l.T("common.minutes", s.Duration), l.Money(s.Price, s.Currency))
+		rows = append(rows, []botmodels.InlineKeyboardButton{button(owner, text, fmt.Sprintf("%s/%d", action, s.ID))})
+	}
+	rows = append(rows, cancelRow(l, owner))
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// datePicker — календарь месяца month; прошедшие дни неактивны
+func datePicker(l i18n.Localizer, owner int64, month, now time.Time) *botmodels.InlineKeyboardMarkup {
+	loc := now.Location()
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	thisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, loc)
+
+	months := strings.Fields(l.T("calendar.months"))
+	title := first.Format("01")
+	if len(months) == 12 {
+		title = months[first.Month()-1]
+	}
+	weekdays := make([]botmodels.InlineKeyboardButton, 0, 7)
+	for _, day := range strings.Fields(l.T("calendar.weekdays")) {
+		weekdays = append(weekdays, noop(day))
+	}
+	rows := [][]botmodels.InlineKeyboardButton{
+		{noop(fmt.Sprintf("%s %d", title, first.Year()))},
+		weekdays,
+	}
+
+	// Неделя начинается с понедельника
+	offset := (int(first.Weekday()) + 6) % 7
+	week := make([]botmodels.InlineKeyboardButton, 0, 7)
+	for i := 0; i < offset; i++ {
+		week = append(week, noop(" "))
+	}
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		if day.Before(today) {
+			week = append(week, noop("·"))
+		} else {
+			week = append(week, button(owner, fmt.Sprintf("%d", day.Day()), "date/"+day.Format("2006-01-02")))
+		}
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]botmodels.InlineKeyboardButton, 0, 7)
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, noop(" "))
+		}
+		rows = append(rows, week)
+	}
+
+	nav := []botmodels.InlineKeyboardButton{}
+	if first.After(thisMonth) {
+		nav = append(nav, button(owner, "◀️", "month/"+first.AddDate(0, -1, 0).Format("2006-01")))
+	}
+	if first.Before(thisMonth.AddDate(0, pickerMonthsAhead, 0)) {
+		nav = append(nav, button(owner, "▶️", "month/"+first.AddDate(0, 1, 0).Format("2006-01")))
+	}
+	if len(nav) > 0 {
+		rows = append(rows, nav)
+	}
+	rows = append(rows, []botmodels.InlineKeyboardButton{button(owner, l.T("manage.button.back_service"), "back/service")}, cancelRow(l, owner))
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// timePicker — сетка времени начала на дату day; прошедшее время скрыто
+func timePicker(l i18n.Localizer, owner int64, day, now time.Time) *botmodels.InlineKeyboardMarkup {
+	rows := [][]botmodels.InlineKeyboardButton{}
+	row := make([]botmodels.InlineKeyboardButton, 0, 4)
+	for m := pickerFirstMinute; m <= pickerLastMinute; m += pickerStep {
+		at := time.Date(day.Year(), day.Month(), day.Day(), m/60, m%60, 0, 0, day.Location())
+		if !at.After(now) {
+			continue
+		}
+		row = append(row, button(owner, at.Format(l.TimeLayout()), "time/"+at.Format("1504")))
+		if len(row) == 4 {
+			rows = append(rows, row)
+			row = make([]botmodels.InlineKeyboardButton, 0, 4)
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+	rows = append(rows, []botmodels.InlineKeyboardButton{button(owner, l.T("manage.button.back_date"), "back/date")}, cancelRow(l, owner))
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// confirmKeyboard — подтверждение создания; back — куда вернуться ("" — без кнопки)
+func confirmKeyboard(l i18n.Localizer, owner int64, back string) *botmodels.InlineKeyboardMarkup {
+	rows := [][]botmodels.InlineKeyboardButton{{button(owner, l.T("manage.button.confirm"), "confirm")}}
+	if back != "" {
+		rows = append(rows, []botmodels.InlineKeyboardButton{button(owner, l.T("manage.button.back"), "back/"+back)})
+	}
+	rows = append(rows, cancelRow(l, owner))
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// fieldChooser — выбор поля услуги для изменения
+func fieldChooser(l i18n.Localizer, owner int64) *botmodels.InlineKeyboardMarkup {
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: [][]botmodels.InlineKeyboardButton{
+		{button(owner, l.T("manage.field.name"), "field/name"), button(owner, l.T("manage.field.description"), "field/description")},
+		{button(owner, l.T("manage.field.duration"), "field/duration"), button(owner, l.T("manage.field.price"), "field/price")},
+		{button(owner, l.T("manage.button.back_services"), "back/services")},
+		{button(owner, l.T("manage.button.done"), "done")},
+	}}
+}
+
+func skipKeyboard(l i18n.Localizer, owner int64) *botmodels.InlineKeyboardMarkup {
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: [][]botmodels.InlineKeyboardButton{
+		{button(owner, l.T("manage.button.skip"), "skip")},
+		cancelRow(l, owner),
+	}}
+}
+
+func cancelKeyboard(l i18n.Localizer, owner int64) *botmodels.InlineKeyboardMarkup {
+	return &botmodels.InlineKeyboardMarkup{InlineKeyboard: [][]botmodels.InlineKeyboardButton{cancelRow(l, owner)}}
+}
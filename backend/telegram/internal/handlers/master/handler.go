@@ -3,12 +3,14 @@ package master
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	adapter "telegram-bot/internal/adapter/backendapi"
-	"telegram-bot/internal/config"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/utils"
-	"time"
+	mymodels "telegram-bot/pkg/models"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -20,9 +22,7 @@ type Handler struct {
 	client *adapter.Client
 }
 
-func NewHandler(logger *logrus.Logger) *Handler {
-	cfg := config.Load()
-	client := adapter.New(cfg.BackendBaseURL, logger)
+func NewHandler(logger *logrus.Logger, client *adapter.Client) *Handler {
 	return &Handler{logger: logger, client: client}
 }
 
@@ -37,13 +37,15 @@ func (h *Handler) HandlerUpcomingRecords(ctx context.Context, b *bot.Bot, update
 
 	h.logger.WithField("telegram_id", telegramID).Info("Handler.UpcomingRecords: fetching upcoming records")
 
+	l := i18n.ForUser(ctx, telegramID)
+
 	// Получаем предстоящие записи из backend
 	records, err := h.client.GetUpcomingRecordsByMasterTelegramID(ctx, telegramID)
 	if err != nil {
 		h.logger.Errorf("Handler.UpcomingRecords: failed to get records: %v", err)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    chatID,
-			Text:      fmt.Sprintf("%s❌ Не удалось получить список записей", components.Header()),
+			Text:      components.APIError(l, err, components.Header()+l.T("upcoming.failed")),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -52,25 +54,25 @@ func (h *Handler) HandlerUpcomingRecords(ctx context.Context, b *bot.Bot, update
 	if len(records) == 0 {
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    chatID,
-			Text:      fmt.Sprintf("%s<b>Предстоящие записи</b>\n\n<i>У вас пока нет предстоящих подтвержденных записей</i>", components.Header()),
+			Text:      components.Header() + l.T("upcoming.none"),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
 	}
 
 	// Показываем первую страницу (страница 1)
-	h.showUpcomingRecordsPage(ctx, b, chatID, telegramID, records, 1, 0)
+	h.showUpcomingRecordsPage(ctx, b, l, chatID, telegramID, records, 1, 0)
 }
 
 // showUpcomingRecordsPage показывает страницу записей с пагинацией
-func (h *Handler) showUpcomingRecordsPage(ctx context.Context, b *bot.Bot, chatID int64, telegramID int64, records []map[string]interface{}, page int, messageID int) {
+func (h *Handler) showUpcomingRecordsPage(ctx context.Context, b *bot.Bot, l i18n.Localizer, chatID int64, telegramID int64, records []mymodels.Record, page int, messageID int) {
 	const limit = 5
 
 	// Форматируем список записей для текущей страницы
-	text, totalPages := h.FormatUpcomingRecordsPage(records, page, limit, telegramID)
+	text, totalPages := h.FormatUpcomingRecordsPage(l, records, page, limit, telegramID)
 
 	// Создаем клавиатуру для пагинации
-	keyboard := h.BuildUpcomingRecordsPagination(page, totalPages, telegramID)
+	keyboard := h.BuildUpcomingRecordsPagination(l, page, totalPages, telegramID)
 
 	if messageID > 0 {
 		// Редактируем существующее сообщение
@@ -96,12 +98,12 @@ func (h *Handler) showUpcomingRecordsPage(ctx context.Context, b *bot.Bot, chatI
 }
 
 // FormatUpcomingRecordsPage форматирует страницу записей с пагинацией (экспортируемая)
-func (h *Handler) FormatUpcomingRecordsPage(records []map[string]interface{}, page int, limit int, telegramID int64) (string, int) {
+func (h *Handler) FormatUpcomingRecordsPage(l i18n.Localizer, records []mymodels.Record, page int, limit int, telegramID int64) (string, int) {
 	b := strings.Builder{}
-	b.WriteString(fmt.Sprintf("%s<b>📅 Предстоящие записи</b>\n\n", components.Header()))
+	b.WriteString(components.Header() + l.T("upcoming.title"))
 
 	if len(records) == 0 {
-		b.WriteString("<i>У вас нет предстоящих записей</i>")
+		b.WriteString(l.T("upcoming.empty"))
 		return b.String(), 1
 	}
 
@@ -118,37 +120,24 @@ func (h *Handler) FormatUpcomingRecordsPage(records []map[string]interface{}, pa
 	// Группируем записи по датам
 	type dateGroup struct {
 		date    string
-		records []map[string]interface{}
+		records []mymodels.Record
 	}
 
 	dateGroups := make(map[string]*dateGroup)
 	dateOrder := []string{}
 
 	for _, r := range pageRecords {
-		slotData, _ := r["slot"].(map[string]interface{})
-		masterData, _ := slotData["master"].(map[string]interface{})
-
-		// Получаем таймзону мастера
-		masterTimezone := ""
-		if tz, ok := masterData["timezone"].(string); ok {
-			masterTimezone = tz
-		}
-		if masterTimezone == "" {
-			masterTimezone = "Europe/Moscow"
-		}
+		masterTimezone := recordTimezone(r)
 
 		// Получаем дату
-		startTimeStr, _ := slotData["start_time"].(string)
-		date := "не задано"
-		if startTimeStr != "" {
-			if startTime, err := parseTime(startTimeStr); err == nil && !startTime.IsZero() {
-				date = utils.FormatDateInLocation(masterTimezone, startTime)
-			}
+		date := l.T("record.not_set")
+		if !r.Slot.StartTime.IsZero() {
+			date = utils.FormatDateInLocale(l.Lang(), masterTimezone, r.Slot.StartTime)
 		}
 
 		// Добавляем запись в группу по дате
 		if _, exists := dateGroups[date]; !exists {
-			dateGroups[date] = &dateGroup{date: date, records: []map[string]interface{}{}}
+			dateGroups[date] = &dateGroup{date: date}
 			dateOrder = append(dateOrder, date)
 		}
 		dateGroups[date].records = append(dateGroups[date].records, r)
@@ -163,76 +152,37 @@ func (h *Handler) FormatUpcomingRecordsPage(records []map[string]interface{}, pa
 
 		// Записи в этой дате
 		for j, r := range group.records {
-			slotData, _ := r["slot"].(map[string]interface{})
-			clientData, _ := r["client"].(map[string]interface{})
-			serviceData, _ := slotData["service"].(map[string]interface{})
-			masterData, _ := slotData["master"].(map[string]interface{})
-
-			// Получаем таймзону мастера
-			masterTimezone := ""
-			if tz, ok := masterData["timezone"].(string); ok {
-				masterTimezone = tz
-			}
-			if masterTimezone == "" {
-				masterTimezone = "Europe/Moscow"
-			}
+			masterTimezone := recordTimezone(r)
 
 			// Форматируем времена
-			startTimeStr, _ := slotData["start_time"].(string)
-			endTimeStr, _ := slotData["end_time"].(string)
-
 			start := "--:--"
 			end := "--:--"
-
-			if startTimeStr != "" {
-				if startTime, err := parseTime(startTimeStr); err == nil && !startTime.IsZero() {
-					start = utils.FormatTimeOnlyInLocation(masterTimezone, startTime)
-				}
+			if !r.Slot.StartTime.IsZero() {
+				start = utils.FormatTimeOnlyInLocale(l.Lang(), masterTimezone, r.Slot.StartTime)
 			}
-
-			if endTimeStr != "" {
-				if endTime, err := parseTime(endTimeStr); err == nil && !endTime.IsZero() {
-					end = utils.FormatTimeOnlyInLocation(masterTimezone, endTime)
-				}
+			if !r.Slot.EndTime.IsZero() {
+				end = utils.FormatTimeOnlyInLocale(l.Lang(), masterTimezone, r.Slot.EndTime)
 			}
 
 			// Получаем смещение таймзоны
 			tzOffset := utils.GetTimezoneOffset(masterTimezone)
 
 			// Получаем имя клиента
-			clientName := "Неизвестный клиент"
-			if firstName, ok := clientData["first_name"].(string); ok && firstName != "" {
-				if surname, ok := clientData["surname"].(string); ok && surname != "" {
-					clientName = fmt.Sprintf("%s %s", firstName, surname)
-				} else {
-					clientName = firstName
-				}
-			}
-
-			// Получаем телефон клиента
-			clientPhone := ""
-			if phone, ok := clientData["phone"].(string); ok {
-				clientPhone = phone
+			clientName := l.T("upcoming.unknown_client")
+			if r.Client.FirstName != "" {
+				clientName = strings.TrimSpace(r.Client.FirstName + " " + r.Client.Surname)
 			}
 
 			// Получаем название услуги
-			serviceName := "Неизвестная услуга"
-			if name, ok := serviceData["name"].(string); ok && name != "" {
-				serviceName = name
-			}
-
-			// Получаем длительность и цену услуги
-			duration := 0
-			if d, ok := serviceData["duration"].(float64); ok {
-				duration = int(d)
+			serviceName := l.T("record.unknown_service")
+			if r.Slot.Service.Name != "" {
+				serviceName = r.Slot.Service.Name
 			}
 
 			// Формируем текст записи
 			b.WriteString("<blockquote>")
-			b.WriteString(fmt.Sprintf("<b>🕐 Время:</b> <code>%s - %s (TZ: %s %s)</code>\n", start, end, masterTimezone, tzOffset))
-			b.WriteString(fmt.Sprintf("<b>📍 Услуга:</b> <code>%s</code>\n", serviceName))
-			b.WriteString(fmt.Sprintf("<b>🧍🏼 Клиент:</b> <code>%s</code> (<code>%s</code>)\n", clientName, clientPhone))
-			b.WriteString(fmt.Sprintf("<b>⏱ Длительность:</b> <code>%d мин.</code>\n", duration))
+			b.WriteString(l.T("upcoming.record", start, end, masterTimezone, tzOffset, serviceName, clientName, r.Client.Phone,
+				l.T("common.minutes", r.Slot.Service.Duration)))
 			b.WriteString("</blockquote>")
 
 			// Добавляем разделитель между записями одного дня
@@ -248,13 +198,13 @@ func (h *Handler) FormatUpcomingRecordsPage(records []map[string]interface{}, pa
 	}
 
 	// Добавляем информацию о странице
-	b.WriteString(fmt.Sprintf("\n\n<i>Страница %d из %d</i>", page, totalPages))
+	b.WriteString(l.T("upcoming.page", page, totalPages))
 
 	return b.String(), totalPages
 }
 
 // BuildUpcomingRecordsPagination создает клавиатуру для пагинации (экспортируемая)
-func (h *Handler) BuildUpcomingRecordsPagination(page int, totalPages int, telegramID int64) *models.InlineKeyboardMarkup {
+func (h *Handler) BuildUpcomingRecordsPagination(l i18n.Localizer, page int, totalPages int, telegramID int64) *models.InlineKeyboardMarkup {
 	buttons := [][]models.InlineKeyboardButton{}
 
 	row := []models.InlineKeyboardButton{}
@@ -262,16 +212,16 @@ func (h *Handler) BuildUpcomingRecordsPagination(page int, totalPages int, teleg
 	// Кнопка "Назад"
 	if page > 1 {
 		row = append(row, models.InlineKeyboardButton{
-			Text:         "⬅️ Назад",
-			CallbackData: fmt.Sprintf("upcoming_page/%d/%d", page-1, telegramID),
+			Text:         l.T("upcoming.button.prev"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteUpcomingPage, strconv.Itoa(page-1), strconv.FormatInt(telegramID, 10)),
 		})
 	}
 
 	// Кнопка "Вперед"
 	if page < totalPages {
 		row = append(row, models.InlineKeyboardButton{
-			Text:         "Вперед ➡️",
-			CallbackData: fmt.Sprintf("upcoming_page/%d/%d", page+1, telegramID),
+			Text:         l.T("upcoming.button.next"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteUpcomingPage, strconv.Itoa(page+1), strconv.FormatInt(telegramID, 10)),
 		})
 	}
 
@@ -282,21 +232,10 @@ func (h *Handler) BuildUpcomingRecordsPagination(page int, totalPages int, teleg
 	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
 }
 
-// parseTime парсит строку времени в формате ISO 8601
-func parseTime(s string) (time.Time, error) {
-	// Пробуем различные форматы
-	formats := []string{
-		"2006-01-02T15:04:05Z07:00",
-		"2006-01-02T15:04:05Z",
-		"2006-01-02T15:04:05",
-		"2006-01-02 15:04:05",
+// recordTimezone — часовой пояс мастера записи, по умолчанию Europe/Moscow
+func recordTimezone(r mymodels.Record) string {
+	if r.Slot.Master.Timezone != "" {
+		return r.Slot.Master.Timezone
 	}
-
-	for _, format := range formats {
-		if t, err := time.Parse(format, s); err == nil {
-			return t, nil
-		}
-	}
-
-	return time.Time{}, fmt.Errorf("unable to parse time: %s", s)
+	return "Europe/Moscow"
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"strings"
+	"telegram-bot/internal/state"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -44,24 +45,28 @@ func (e *MessageEditor) SafeEditMessage(ctx context.Context, b *bot.Bot, chatID
 	return nil
 }
 
-// SendOrEditMessage отправляет новое сообщение или редактирует существующее
-func (e *MessageEditor) SendOrEditMessage(ctx context.Context, b *bot.Bot, chatID, userID int64, messageType string, text string, replyMarkup *models.InlineKeyboardMarkup, context map[string]interface{}) error {
-	// Проверяем, есть ли активное сообщение этого типа
-	if state, exists := e.stateManager.GetMessageState(chatID, userID, messageType); exists {
-		log.Printf("Found existing message state for %s: chatID=%d, messageID=%d", messageType, state.ChatID, state.MessageID)
+// SendOrEditMessage редактирует сообщение этого типа или отправляет новое.
+// messageID — сообщение, на котором нажата кнопка (0, если это команда): оно
+// редактируется в первую очередь, поэтому потерянное после перезапуска состояние
+// восстанавливается по нему, а не порождает дубль сообщения.
+func (e *MessageEditor) SendOrEditMessage(ctx context.Context, b *bot.Bot, chatID, userID int64, messageID int, messageType string, text string, replyMarkup *models.InlineKeyboardMarkup, c state.Context) error {
+	prev, exists := e.stateManager.GetMessageState(ctx, chatID, userID, messageType)
+	target := messageID
+	if target == 0 && exists {
+		target = prev.MessageID
+	}
 
-		// Пытаемся отредактировать существующее сообщение
-		err := e.SafeEditMessage(ctx, b, state.ChatID, state.MessageID, text, replyMarkup)
+	if target != 0 {
+		err := e.SafeEditMessage(ctx, b, chatID, target, text, replyMarkup)
 		if err == nil {
-			log.Printf("Successfully edited message %d in chat %d for type %s", state.MessageID, state.ChatID, messageType)
-			// Обновляем контекст состояния
-			e.stateManager.UpdateMessageState(chatID, userID, messageType, context)
+			log.Printf("Successfully edited message %d in chat %d for type %s", target, chatID, messageType)
+			if !exists || !e.stateManager.UpdateMessageState(ctx, prev, target, c) {
+				// Состояния нет (перезапуск, истёк TTL) — восстанавливаем по отредактированному сообщению
+				e.stateManager.SetMessageState(ctx, chatID, userID, target, messageType, c)
+			}
 			return nil
 		}
-
-		log.Printf("Failed to edit message %d in chat %d for type %s: %v", state.MessageID, state.ChatID, messageType, err)
-		// Если редактирование не удалось, удаляем старое состояние
-		e.stateManager.RemoveMessageState(chatID, userID, messageType)
+		log.Printf("Failed to edit message %d in chat %d for type %s: %v", target, chatID, messageType, err)
 	}
 
 	// Отправляем новое сообщение
@@ -80,30 +85,30 @@ func (e *MessageEditor) SendOrEditMessage(ctx context.Context, b *bot.Bot, chatI
 
 	log.Printf("Successfully sent new message %d to chat %d for user %d, type %s", sentMsg.ID, chatID, userID, messageType)
 	// Сохраняем состояние нового сообщения
-	e.stateManager.SetMessageState(chatID, userID, sentMsg.ID, messageType, context)
+	e.stateManager.SetMessageState(ctx, chatID, userID, sentMsg.ID, messageType, c)
 
 	return nil
 }
 
 // EditSlotsPagination редактирует сообщение с пагинацией слотов
-func (e *MessageEditor) EditSlotsPagination(ctx context.Context, b *bot.Bot, userID, masterID int64, targetDate string, page int, text string, replyMarkup *models.InlineKeyboardMarkup) error {
-	context := map[string]interface{}{
-		"masterID":   masterID,
-		"targetDate": targetDate,
-		"page":       page,
+func (e *MessageEditor) EditSlotsPagination(ctx context.Context, b *bot.Bot, userID int64, messageID int, masterID int64, targetDate string, page int, text string, replyMarkup *models.InlineKeyboardMarkup) error {
+	c := state.Context{
+		MasterID:   masterID,
+		TargetDate: targetDate,
+		Page:       page,
 	}
 
-	return e.SendOrEditMessage(ctx, b, userID, userID, "slots_pagination", text, replyMarkup, context)
+	return e.SendOrEditMessage(ctx, b, userID, userID, messageID, "slots_pagination", text, replyMarkup, c)
 }
 
 // EditSlotDetails редактирует сообщение с деталями слота
-func (e *MessageEditor) EditSlotDetails(ctx context.Context, b *bot.Bot, userID int64, slotID uint, text string, replyMarkup *models.InlineKeyboardMarkup) error {
-	context := map[string]interface{}{
-		"slotID": slotID,
+func (e *MessageEditor) EditSlotDetails(ctx context.Context, b *bot.Bot, userID int64, messageID int, slotID uint, text string, replyMarkup *models.InlineKeyboardMarkup) error {
+	c := state.Context{
+		SlotID: slotID,
 	}
 
 	// ВАЖНО: редактируем то же сообщение, что и пагинация, чтобы не плодить новые
-	return e.SendOrEditMessage(ctx, b, userID, userID, "slots_pagination", text, replyMarkup, context)
+	return e.SendOrEditMessage(ctx, b, userID, userID, messageID, "slots_pagination", text, replyMarkup, c)
 }
 
 // EditUserRecords редактирует/отправляет сообщение со списком записей пользователя
@@ -111,55 +116,22 @@ func (e *MessageEditor) EditUserRecords(
 	ctx context.Context,
 	b *bot.Bot,
 	userID int64,
+	messageID int,
 	status string,
 	page int,
 	text string,
 	replyMarkup *models.InlineKeyboardMarkup,
 ) error {
-	context := map[string]interface{}{
-		"status": status,
-		"page":   page,
+	c := state.Context{
+		Status: status,
+		Page:   page,
 	}
-	return e.SendOrEditMessage(ctx, b, userID, userID, "user_records", text, replyMarkup, context)
+	return e.SendOrEditMessage(ctx, b, userID, userID, messageID, "user_records", text, replyMarkup, c)
 }
 
 // EditMessage редактирует сообщение по типу
 func (e *MessageEditor) EditMessage(ctx context.Context, b *bot.Bot, userID int64, messageType string, text string, replyMarkup *models.InlineKeyboardMarkup) error {
-	// Проверяем, есть ли активное сообщение этого типа
-	if state, exists := e.stateManager.GetMessageState(userID, userID, messageType); exists {
-		log.Printf("Found existing message state for %s: chatID=%d, messageID=%d", messageType, state.ChatID, state.MessageID)
-
-		// Пытаемся отредактировать существующее сообщение
-		err := e.SafeEditMessage(ctx, b, state.ChatID, state.MessageID, text, replyMarkup)
-		if err == nil {
-			log.Printf("Successfully edited message %d in chat %d for type %s", state.MessageID, state.ChatID, messageType)
-			return nil
-		}
-
-		log.Printf("Failed to edit message %d in chat %d for type %s: %v", state.MessageID, state.ChatID, messageType, err)
-		// Если редактирование не удалось, удаляем старое состояние
-		e.stateManager.RemoveMessageState(userID, userID, messageType)
-	}
-
-	// Отправляем новое сообщение
-	log.Printf("Sending new message to user %d for type %s", userID, messageType)
-	sentMsg, err := b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID:      userID,
-		Text:        text,
-		ParseMode:   models.ParseModeHTML,
-		ReplyMarkup: replyMarkup,
-	})
-
-	if err != nil {
-		log.Printf("Failed to send message to user %d: %v", userID, err)
-		return err
-	}
-
-	log.Printf("Successfully sent new message %d to chat %d for user %d, type %s", sentMsg.ID, userID, userID, messageType)
-	// Сохраняем состояние нового сообщения
-	e.stateManager.SetMessageState(userID, userID, sentMsg.ID, messageType, map[string]interface{}{})
-
-	return nil
+	return e.SendOrEditMessage(ctx, b, userID, userID, 0, messageType, text, replyMarkup, state.Context{})
 }
 
 // EditSpecificMessage редактирует конкретное сообщение по ID
@@ -191,6 +163,6 @@ func (e *MessageEditor) EditSpecificMessage(ctx context.Context, b *bot.Bot, cha
 }
 
 // RemoveMessageState удаляет состояние сообщения (например, после успешного бронирования)
-func (e *MessageEditor) RemoveMessageState(userID int64, messageType string) {
-	e.stateManager.RemoveMessageState(userID, userID, messageType)
+func (e *MessageEditor) RemoveMessageState(ctx context.Context, userID int64, messageType string) {
+	e.stateManager.RemoveMessageState(ctx, userID, userID, messageType)
 }
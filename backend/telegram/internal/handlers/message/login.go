@@ -3,21 +3,18 @@ package message
 import (
 	"context"
 	"fmt"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/outbound"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 )
 
-var (
-	loginMessageBase = fmt.Sprintf(
-		"%s"+
-			"<i>🆕 Уведомление</i>\n"+
-			"<i>Чтобы подтвердить вход в аккаунт, нажмите кнопку «✔️ Подтвердить»</i>", components.Header())
-)
-
-func (h *Handler) SendLoginMessage(ctx context.Context, b *bot.Bot, userID int64, ip string, loc string) {
-	msg := loginMessageBase
+func (h *Handler) SendLoginMessage(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, ip string, loc string) error {
+	l := i18n.ForUser(ctx, userID)
+	msg := components.Header() + l.T("auth.login_prompt")
 	// enrich with ip/location if provided
 	meta := ""
 	if ip != "" {
@@ -27,21 +24,21 @@ func (h *Handler) SendLoginMessage(ctx context.Context, b *bot.Bot, userID int64
 		if meta != "" {
 			meta += " | "
 		}
-		meta += fmt.Sprintf("Локация: <code>%s</code>", loc)
+		meta += l.T("auth.login_location", loc)
 	}
 	if meta != "" {
 		msg += "\n" + meta
 	}
-	b.SendMessage(ctx, &bot.SendMessageParams{
+	return h.send(ctx, b, &bot.SendMessageParams{
 		ChatID:    userID,
 		Text:      msg,
 		ParseMode: models.ParseModeHTML,
 		ReplyMarkup: &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: "✔️ Подтвердить", CallbackData: "ConfirmLogin"},
+					{Text: l.T("start.confirm_button"), CallbackData: callbackdata.Encode(callbackdata.RouteConfirmLogin)},
 				},
 			},
 		},
-	})
+	}, outbound.PriorityHigh, deliveryID)
 }
@@ -3,83 +3,160 @@ package message
 import (
 	"context"
 	"fmt"
+	"html"
+	"strconv"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/outbound"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 )
 
 // SendPlainMessage отправляет простое текстовое сообщение пользователю
-func (h *Handler) SendPlainMessage(ctx context.Context, b *bot.Bot, userID int64, title, message string) {
-	msg := fmt.Sprintf("%s🆕 Уведомление\n<b>%s</b><i>%s</i>\n", components.Header(), title, message)
+func (h *Handler) SendPlainMessage(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, title, message string) error {
+	msg := components.Header() + i18n.ForUser(ctx, userID).T("notify.plain", title, message)
 	// text := title
 	// if message != "" { if title != "" { text += "\n" } text += message }
-	b.SendMessage(ctx, &bot.SendMessageParams{
+	return h.send(ctx, b, &bot.SendMessageParams{
 		ChatID:    userID,
 		Text:      msg,
 		ParseMode: models.ParseModeHTML,
-	})
+	}, outbound.PriorityNormal, deliveryID)
 }
 
 // SendRecordNotification отправляет уведомление о новой записи с кнопками действий (для мастера)
-func (h *Handler) SendRecordNotification(ctx context.Context, b *bot.Bot, userID int64, recordID string, title, message string) {
-	msg := fmt.Sprintf("%s🆕 Новая запись\n<b>%s</b>\n<i>%s</i>\n\nВыберите действие:", components.Header(), title, message)
+func (h *Handler) SendRecordNotification(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, recordID string, title, message string) error {
+	l := i18n.ForUser(ctx, userID)
+	msg := components.Header() + l.T("notify.record", title, message)
+	// Кнопки подписаны на мастера-получателя: нажать их может только он
+	owner := strconv.FormatInt(userID, 10)
 
 	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
 		{
-			{Text: "✅ Подтвердить", CallbackData: fmt.Sprintf("record_action/confirm/%s", recordID)},
-			{Text: "❌ Отклонить", CallbackData: fmt.Sprintf("record_action/reject/%s", recordID)},
+			{Text: l.T("notify.button.confirm"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordAction, "confirm", recordID, owner)},
+			{Text: l.T("notify.button.reject"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordAction, "reject", recordID, owner)},
 		},
 	}}
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
+	return h.send(ctx, b, &bot.SendMessageParams{
 		ChatID:      userID,
 		Text:        msg,
 		ParseMode:   models.ParseModeHTML,
 		ReplyMarkup: keyboard,
-	})
+	}, outbound.PriorityNormal, deliveryID)
 }
 
-// SendRecordStatusNotification отправляет уведомление об изменении статуса записи (для клиента, без кнопок)
-func (h *Handler) SendRecordStatusNotification(ctx context.Context, b *bot.Bot, userID int64, title, message string) {
-	msg := fmt.Sprintf("%s🆕 Уведомление\n<b>%s</b>\n<i>%s</i>", components.Header(), title, message)
+// SendVenue отправляет место записи картой — обычно сразу после сообщения о подтверждении
+func (h *Handler) SendVenue(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, title, address string, latitude, longitude float64) error {
+	params := &bot.SendVenueParams{
+		ChatID:    userID,
+		Latitude:  latitude,
+		Longitude: longitude,
+		Title:     title,
+		Address:   address,
+	}
+	if h.outbound != nil {
+		return h.outbound.Enqueue(outbound.Message{Venue: params, Priority: outbound.PriorityNormal, DeliveryID: deliveryID})
+	}
+	_, err := b.SendVenue(ctx, params)
+	return err
+}
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
+// DigestRecord — заявка из сводки мастера, к ней добавляются кнопки подтверждения и отклонения
+type DigestRecord struct {
+	RecordID uint   `json:"record_id"`
+	Label    string `json:"label"`
+}
+
+// SendDigest отправляет мастеру утреннюю или недельную сводку.
+// Под сводкой — по строке кнопок на каждую заявку, ждущую решения.
+func (h *Handler) SendDigest(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, title, message string, pending []DigestRecord) error {
+	msg := fmt.Sprintf("%s📋 <b>%s</b>\n\n%s", components.Header(), html.EscapeString(title), html.EscapeString(message))
+	params := &bot.SendMessageParams{
 		ChatID:    userID,
 		Text:      msg,
 		ParseMode: models.ParseModeHTML,
-	})
+	}
+	if len(pending) > 0 {
+		// Кнопки подписаны на мастера-получателя: нажать их может только он
+		owner := strconv.FormatInt(userID, 10)
+		rows := make([][]models.InlineKeyboardButton, 0, len(pending))
+		for _, p := range pending {
+			recordID := strconv.FormatUint(uint64(p.RecordID), 10)
+			rows = append(rows, []models.InlineKeyboardButton{
+				{Text: "✅ " + p.Label, CallbackData: callbackdata.Encode(callbackdata.RouteDigestAction, "confirm", recordID, owner)},
+				{Text: "❌", CallbackData: callbackdata.Encode(callbackdata.RouteDigestAction, "reject", recordID, owner)},
+			})
+		}
+		params.ReplyMarkup = &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+	}
+	return h.send(ctx, b, params, outbound.PriorityNormal, deliveryID)
 }
 
-// SendAccountDeletionConfirmation отправляет запрос на подтверждение удаления аккаунта
-func (h *Handler) SendAccountDeletionConfirmation(ctx context.Context, b *bot.Bot, userID int64, userUUID string) {
-	msg := fmt.Sprintf(`%s⚠️ <b>Удаление аккаунта</b>
+// SendReviewPrompt предлагает клиенту оценить завершённую запись кнопками со звёздами
+func (h *Handler) SendReviewPrompt(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, recordID uint, title, message string) error {
+	msg := fmt.Sprintf("%s⭐ <b>%s</b>\n\n%s", components.Header(), html.EscapeString(title), html.EscapeString(message))
+	return h.send(ctx, b, &bot.SendMessageParams{
+		ChatID:      userID,
+		Text:        msg,
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: ReviewKeyboard(userID, recordID),
+	}, outbound.PriorityNormal, deliveryID)
+}
 
-Вы запросили удаление своего аккаунта. Это действие <b>необратимо</b> и приведет к:
+// ReviewKeyboard — оценки от 1 до 5 для записи recordID клиента owner
+func ReviewKeyboard(owner int64, recordID uint) *models.InlineKeyboardMarkup {
+	id, ownerID := strconv.FormatUint(uint64(recordID), 10), strconv.FormatInt(owner, 10)
+	row := make([]models.InlineKeyboardButton, 0, 5)
+	for rating := 1; rating <= 5; rating++ {
+		n := strconv.Itoa(rating)
+		row = append(row, models.InlineKeyboardButton{Text: n + "⭐", CallbackData: callbackdata.Encode(callbackdata.RouteReview, id, n, ownerID)})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
 
-• Удалению всех ваших данных
-• Удалению всех созданных слотов
-• Удалению всех услуг
-• Удалению всех записей
-• Удалению уведомлений
+// SendRecordStatusNotification отправляет уведомление об изменении статуса записи (для клиента, без кнопок)
+func (h *Handler) SendRecordStatusNotification(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, title, message string) error {
+	msg := components.Header() + i18n.ForUser(ctx, userID).T("notify.status", title, message)
 
-<b>Вы уверены, что хотите продолжить?</b>
+	return h.send(ctx, b, &bot.SendMessageParams{
+		ChatID:    userID,
+		Text:      msg,
+		ParseMode: models.ParseModeHTML,
+	}, outbound.PriorityNormal, deliveryID)
+}
 
-<i>Если вы случайно нажали кнопку удаления, просто проигнорируйте это сообщение.</i>`, components.Header())
+// SendAccountDeletionConfirmation отправляет запрос на подтверждение удаления аккаунта
+func (h *Handler) SendAccountDeletionConfirmation(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, userUUID string) error {
+	l := i18n.ForUser(ctx, userID)
+	msg := components.Header() + l.T("account.delete_prompt")
 
 	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
 		{
-			{Text: "❌ Отменить", CallbackData: "account_deletion/cancel"},
+			{Text: l.T("account.button.cancel"), CallbackData: callbackdata.Encode(callbackdata.RouteDeleteCancel)},
 		},
 		{
-			{Text: "⚠️ ДА, УДАЛИТЬ АККАУНТ", CallbackData: fmt.Sprintf("account_deletion/confirm/%s", userUUID)},
+			{Text: l.T("account.button.confirm"), CallbackData: callbackdata.Encode(callbackdata.RouteDeleteConfirm, userUUID, strconv.FormatInt(userID, 10))},
 		},
 	}}
 
-	b.SendMessage(ctx, &bot.SendMessageParams{
+	return h.send(ctx, b, &bot.SendMessageParams{
 		ChatID:      userID,
 		Text:        msg,
 		ParseMode:   models.ParseModeHTML,
 		ReplyMarkup: keyboard,
-	})
+	}, outbound.PriorityHigh, deliveryID)
+}
+
+// SendPhoneCode отправляет одноразовый код подтверждения номера, указанного при регистрации на сайте
+func (h *Handler) SendPhoneCode(ctx context.Context, b *bot.Bot, deliveryID string, userID int64, phone, code string) error {
+	msg := components.Header() + i18n.ForUser(ctx, userID).T("notify.phone_code", phone, code)
+
+	return h.send(ctx, b, &bot.SendMessageParams{
+		ChatID:    userID,
+		Text:      msg,
+		ParseMode: models.ParseModeHTML,
+	}, outbound.PriorityHigh, deliveryID)
 }
@@ -2,110 +2,120 @@ package message
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"log"
 	"sync"
+	"telegram-bot/internal/state"
 	"time"
 )
 
-// MessageState хранит информацию о состоянии сообщения
-type MessageState struct {
-	MessageID   int
-	ChatID      int64
-	UserID      int64
-	MessageType string                 // "slots_pagination", "slot_details", "booking_confirmation"
-	Context     map[string]interface{} // дополнительные данные контекста
-	CreatedAt   time.Time
-	LastUpdated time.Time
-}
+const (
+	// StateTTL — сколько живёт указатель на сообщение без обновлений
+	StateTTL = 24 * time.Hour
+	// stateCleanupInterval — период удаления истёкших состояний
+	stateCleanupInterval = 30 * time.Minute
+)
 
-// MessageStateManager управляет состояниями сообщений
+// MessageStateManager управляет состояниями сообщений поверх state.Store.
+// По умолчанию состояния хранятся в памяти; при старте бота можно подключить
+// SQL-хранилище, чтобы они переживали перезапуск и были общими для экземпляров.
 type MessageStateManager struct {
-	states        map[string]*MessageState // ключ: chatID_userID_messageType
-	mutex         sync.RWMutex
+	mu            sync.RWMutex
+	store         state.Store
 	cleanupCtx    context.Context
 	cleanupCancel context.CancelFunc
 	name          string
 }
 
 var globalStateManager = &MessageStateManager{
-	states: make(map[string]*MessageState),
+	store: state.NewMemoryStore(StateTTL),
+	name:  "message-state-manager",
 }
 
 // GetStateManager возвращает глобальный менеджер состояний
 func GetStateManager() *MessageStateManager {
-	if globalStateManager.name == "" {
-		globalStateManager.name = "message-state-manager"
-	}
 	return globalStateManager
 }
 
-// generateKey создает уникальный ключ для состояния
-func (m *MessageStateManager) generateKey(chatID, userID int64, messageType string) string {
-	return fmt.Sprintf("%d_%d_%s", chatID, userID, messageType)
+// WithStore подменяет хранилище состояний (вызывается при старте бота)
+func (m *MessageStateManager) WithStore(store state.Store) *MessageStateManager {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store = store
+	return m
 }
 
-// SetMessageState сохраняет состояние сообщения
-func (m *MessageStateManager) SetMessageState(chatID, userID int64, messageID int, messageType string, context map[string]interface{}) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	key := m.generateKey(chatID, userID, messageType)
-	now := time.Now()
-
-	m.states[key] = &MessageState{
-		MessageID:   messageID,
-		ChatID:      chatID,
-		UserID:      userID,
-		MessageType: messageType,
-		Context:     context,
-		CreatedAt:   now,
-		LastUpdated: now,
-	}
+func (m *MessageStateManager) backend() state.Store {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.store
 }
 
-// GetMessageState получает состояние сообщения
-func (m *MessageStateManager) GetMessageState(chatID, userID int64, messageType string) (*MessageState, bool) {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
+func key(chatID, userID int64, messageType string) state.Key {
+	return state.Key{ChatID: chatID, UserID: userID, MessageType: messageType}
+}
 
-	key := m.generateKey(chatID, userID, messageType)
-	state, exists := m.states[key]
-	return state, exists
+// SetMessageState сохраняет состояние нового сообщения, заменяя предыдущее
+func (m *MessageStateManager) SetMessageState(ctx context.Context, chatID, userID int64, messageID int, messageType string, c state.Context) {
+	st := state.MessageState{Key: key(chatID, userID, messageType), MessageID: messageID, Context: c}
+	if _, err := m.backend().Set(ctx, st); err != nil {
+		log.Printf("MessageStateManager.Set %s: %v", messageType, err)
+	}
 }
 
-// UpdateMessageState обновляет существующее состояние
-func (m *MessageStateManager) UpdateMessageState(chatID, userID int64, messageType string, context map[string]interface{}) bool {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
+// GetMessageState получает состояние сообщения
+func (m *MessageStateManager) GetMessageState(ctx context.Context, chatID, userID int64, messageType string) (state.MessageState, bool) {
+	st, err := m.backend().Get(ctx, key(chatID, userID, messageType))
+	if err != nil {
+		if !errors.Is(err, state.ErrNotFound) {
+			log.Printf("MessageStateManager.Get %s: %v", messageType, err)
+		}
+		return state.MessageState{}, false
+	}
+	return st, true
+}
 
-	key := m.generateKey(chatID, userID, messageType)
-	if state, exists := m.states[key]; exists {
-		state.Context = context
-		state.LastUpdated = time.Now()
-		return true
+// UpdateMessageState обновляет состояние, прочитанное ранее (prev), с проверкой версии.
+// Если состояние успел изменить другой обработчик, перечитывает его и повторяет один раз.
+func (m *MessageStateManager) UpdateMessageState(ctx context.Context, prev state.MessageState, messageID int, c state.Context) bool {
+	store := m.backend()
+	for attempt := 0; attempt < 2; attempt++ {
+		next := prev
+		next.MessageID = messageID
+		next.Context = c
+		_, err := store.Update(ctx, next)
+		if err == nil {
+			return true
+		}
+		if !errors.Is(err, state.ErrConflict) {
+			if !errors.Is(err, state.ErrNotFound) {
+				log.Printf("MessageStateManager.Update %s: %v", prev.MessageType, err)
+			}
+			return false
+		}
+		if prev, err = store.Get(ctx, prev.Key); err != nil {
+			return false
+		}
 	}
 	return false
 }
 
 // RemoveMessageState удаляет состояние сообщения
-func (m *MessageStateManager) RemoveMessageState(chatID, userID int64, messageType string) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	key := m.generateKey(chatID, userID, messageType)
-	delete(m.states, key)
+func (m *MessageStateManager) RemoveMessageState(ctx context.Context, chatID, userID int64, messageType string) {
+	if err := m.backend().Delete(ctx, key(chatID, userID, messageType)); err != nil {
+		log.Printf("MessageStateManager.Remove %s: %v", messageType, err)
+	}
 }
 
-// CleanupOldStates удаляет старые состояния (старше указанного времени)
-func (m *MessageStateManager) CleanupOldStates(maxAge time.Duration) {
-	m.mutex.Lock()
-	defer m.mutex.Unlock()
-
-	now := time.Now()
-	for key, state := range m.states {
-		if now.Sub(state.LastUpdated) > maxAge {
-			delete(m.states, key)
-		}
+// CleanupOldStates удаляет истёкшие состояния
+func (m *MessageStateManager) CleanupOldStates(ctx context.Context) {
+	n, err := m.backend().DeleteExpired(ctx)
+	if err != nil {
+		log.Printf("MessageStateManager.Cleanup: %v", err)
+		return
+	}
+	if n > 0 {
+		log.Printf("MessageStateManager.Cleanup: removed %d expired states", n)
 	}
 }
 
@@ -113,13 +123,13 @@ func (m *MessageStateManager) CleanupOldStates(maxAge time.Duration) {
 func (m *MessageStateManager) StartCleanupRoutine() {
 	m.cleanupCtx, m.cleanupCancel = context.WithCancel(context.Background())
 	go func() {
-		ticker := time.NewTicker(30 * time.Minute) // очистка каждые 30 минут
+		ticker := time.NewTicker(stateCleanupInterval)
 		defer ticker.Stop()
 
 		for {
 			select {
 			case <-ticker.C:
-				m.CleanupOldStates(24 * time.Hour) // удаляем состояния старше 24 часов
+				m.CleanupOldStates(m.cleanupCtx)
 			case <-m.cleanupCtx.Done():
 				return
 			}
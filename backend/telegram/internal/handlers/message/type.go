@@ -1,6 +1,9 @@
 package message
 
 import (
+	"context"
+	"telegram-bot/internal/outbound"
+
 	"github.com/go-telegram/bot"
 	"github.com/sirupsen/logrus"
 )
@@ -10,8 +13,9 @@ const (
 )
 
 type Handler struct {
-	bot    *bot.Bot
-	logger *logrus.Logger
+	bot      *bot.Bot
+	logger   *logrus.Logger
+	outbound *outbound.Dispatcher
 }
 
 func NewHandler(b *bot.Bot, logger *logrus.Logger) *Handler {
@@ -20,3 +24,19 @@ func NewHandler(b *bot.Bot, logger *logrus.Logger) *Handler {
 		logger: logger,
 	}
 }
+
+// WithDispatcher направляет уведомления через очередь исходящих сообщений
+func (h *Handler) WithDispatcher(d *outbound.Dispatcher) *Handler {
+	h.outbound = d
+	return h
+}
+
+// send ставит уведомление в очередь; без очереди отправляет сразу.
+// deliveryID — id записи outbox в API, по нему очередь сообщит итог доставки.
+func (h *Handler) send(ctx context.Context, b *bot.Bot, params *bot.SendMessageParams, priority outbound.Priority, deliveryID string) error {
+	if h.outbound != nil {
+		return h.outbound.Enqueue(outbound.Message{Params: params, Priority: priority, DeliveryID: deliveryID})
+	}
+	_, err := b.SendMessage(ctx, params)
+	return err
+}
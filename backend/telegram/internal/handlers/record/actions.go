@@ -0,0 +1,337 @@
+package record
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"sort"
+	"strconv"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/fsm"
+	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/utils"
+	mymodels "telegram-bot/pkg/models"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// MaxCommentLength — как в API: длиннее комментарий не сохранится
+	MaxCommentLength = 500
+	// slotsPerPage — слотов для переноса на странице
+	slotsPerPage = 6
+)
+
+var (
+	// ErrRecordNotFound — записи нет среди записей клиента (удалена или чужая кнопка)
+	ErrRecordNotFound = errors.New("record not found")
+	// ErrRecordInactive — запись отменена, отклонена или уже началась
+	ErrRecordInactive = errors.New("record is not active")
+)
+
+// ShowRecord показывает карточку записи клиента с действиями.
+// notice — строка над карточкой с результатом последнего действия.
+func (s *Service) ShowRecord(ctx context.Context, telegramID int64, recordID uint, messageID int, notice string) error {
+	l := i18n.ForUser(ctx, telegramID)
+	rec, err := s.findRecord(ctx, telegramID, recordID)
+	if err != nil {
+		return err
+	}
+	text := components.Header()
+	if notice != "" {
+		text += notice + "\n\n"
+	}
+	text += l.T("record.details_title") + s.formatRecordsText(l, []mymodels.Record{rec})
+	if rec.Comment != "" {
+		text += l.T("record.comment_line", html.EscapeString(rec.Comment))
+	}
+	s.show(ctx, telegramID, messageID, text, recordKeyboard(l, telegramID, rec, time.Now()))
+	return nil
+}
+
+// AskCancel просит подтвердить отмену записи
+func (s *Service) AskCancel(ctx context.Context, telegramID int64, recordID uint, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	if _, err := s.activeRecord(ctx, telegramID, recordID); err != nil {
+		return err
+	}
+	id, owner := strconv.FormatUint(uint64(recordID), 10), strconv.FormatInt(telegramID, 10)
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("record.cancel_confirm"), &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{{
+			{Text: l.T("record.button.cancel_yes"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCancel, id, "yes", owner)},
+			{Text: l.T("record.button.back_card"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCard, id, owner)},
+		}},
+	})
+	return nil
+}
+
+// Cancel отменяет запись; мастера уведомляет API
+func (s *Service) Cancel(ctx context.Context, telegramID int64, recordID uint, messageID int) error {
+	if err := s.client.CancelRecordByClient(ctx, telegramID, recordID); err != nil {
+		return err
+	}
+	return s.ShowRecord(ctx, telegramID, recordID, messageID, i18n.ForUser(ctx, telegramID).T("record.cancelled"))
+}
+
+// ShowSlots показывает свободные будущие слоты той же услуги мастера для переноса записи
+func (s *Service) ShowSlots(ctx context.Context, telegramID int64, recordID uint, page, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	rec, err := s.activeRecord(ctx, telegramID, recordID)
+	if err != nil {
+		return err
+	}
+	all, err := s.client.GetSlotsByTelegramID(ctx, rec.Slot.Master.TelegramID)
+	if err != nil {
+		return err
+	}
+	free := rescheduleOptions(all, rec.Slot, time.Now())
+
+	id, owner := strconv.FormatUint(uint64(recordID), 10), strconv.FormatInt(telegramID, 10)
+	back := []models.InlineKeyboardButton{{Text: l.T("record.button.back_card"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCard, id, owner)}}
+	if len(free) == 0 {
+		s.show(ctx, telegramID, messageID, components.Header()+l.T("record.slots_prompt")+"\n\n"+l.T("record.slots_empty"),
+			&models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{back}})
+		return nil
+	}
+
+	pages := pagesCount(len(free), slotsPerPage)
+	page = min(max(page, 1), pages)
+	start := (page - 1) * slotsPerPage
+	end := min(start+slotsPerPage, len(free))
+
+	var keyboard [][]models.InlineKeyboardButton
+	for _, sl := range free[start:end] {
+		zone := utils.ViewerZone(l.Zone(), sl.MasterTimezone)
+		keyboard = append(keyboard, []models.InlineKeyboardButton{{
+			Text: l.T("record.slot_button",
+				utils.FormatDateInLocale(l.Lang(), zone, sl.StartTime),
+				utils.FormatSpan(l, sl.MasterTimezone, sl.StartTime, sl.EndTime),
+				sl.ServiceName),
+			CallbackData: callbackdata.Encode(callbackdata.RouteRecordMove, id, strconv.FormatUint(uint64(sl.ID), 10), owner),
+		}})
+	}
+	if pages > 1 {
+		var nav []models.InlineKeyboardButton
+		if page > 1 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "◀️", CallbackData: callbackdata.Encode(callbackdata.RouteRecordSlots, id, strconv.Itoa(page-1), owner)})
+		}
+		nav = append(nav, models.InlineKeyboardButton{Text: l.T("record.slots_page", page, pages), CallbackData: callbackdata.Encode(callbackdata.RouteNoop)})
+		if page < pages {
+			nav = append(nav, models.InlineKeyboardButton{Text: "▶️", CallbackData: callbackdata.Encode(callbackdata.RouteRecordSlots, id, strconv.Itoa(page+1), owner)})
+		}
+		keyboard = append(keyboard, nav)
+	}
+	keyboard = append(keyboard, back)
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("record.slots_prompt"), &models.InlineKeyboardMarkup{InlineKeyboard: keyboard})
+	return nil
+}
+
+// Reschedule переносит запись на слот slotID; мастера уведомляет API
+func (s *Service) Reschedule(ctx context.Context, telegramID int64, recordID, slotID uint, messageID int) error {
+	if err := s.client.RescheduleRecordByClient(ctx, telegramID, recordID, slotID); err != nil {
+		return err
+	}
+	return s.ShowRecord(ctx, telegramID, recordID, messageID, i18n.ForUser(ctx, telegramID).T("record.rescheduled"))
+}
+
+// AskComment начинает ввод комментария: следующий текст пользователя уйдёт мастеру
+func (s *Service) AskComment(ctx context.Context, telegramID int64, recordID uint, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	if _, err := s.activeRecord(ctx, telegramID, recordID); err != nil {
+		return err
+	}
+	id := strconv.FormatUint(uint64(recordID), 10)
+	if _, err := fsm.GetMachine().Start(telegramID, FlowComment, map[string]string{"record_id": id}); err != nil {
+		return err
+	}
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("record.comment_prompt", MaxCommentLength), &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{{
+			{Text: l.T("record.button.back_card"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCard, id, strconv.FormatInt(telegramID, 10))},
+		}},
+	})
+	return nil
+}
+
+// SaveComment отправляет комментарий из сценария FlowComment.
+// Если текст не подошёл, сценарий продолжается и пользователь может прислать другой.
+func (s *Service) SaveComment(ctx context.Context, telegramID int64, sess fsm.Session, comment string) {
+	l := i18n.ForUser(ctx, telegramID)
+	recordID, err := strconv.ParseUint(sess.Data["record_id"], 10, 0)
+	if err != nil {
+		_ = fsm.GetMachine().Finish(telegramID)
+		return
+	}
+	if n := len([]rune(comment)); n == 0 || n > MaxCommentLength {
+		s.send(ctx, telegramID, l.T("record.comment_invalid", MaxCommentLength), nil)
+		return
+	}
+	if err := s.client.CommentRecordByClient(ctx, telegramID, uint(recordID), comment); err != nil {
+		s.logger.WithError(err).Warn("Handlers.Record.SaveComment: request failed")
+		_ = fsm.GetMachine().Finish(telegramID)
+		s.send(ctx, telegramID, components.APIError(l, err, components.Error(l, html.EscapeString(UserMessage(l, err)))), nil)
+		return
+	}
+	if err := fsm.GetMachine().Finish(telegramID); err != nil {
+		s.logger.WithError(err).Warn("Handlers.Record.SaveComment: finish flow")
+	}
+	if err := s.ShowRecord(ctx, telegramID, uint(recordID), 0, l.T("record.commented")); err != nil {
+		s.send(ctx, telegramID, components.Header()+l.T("record.commented"), nil)
+	}
+}
+
+// SendCalendar отправляет запись файлом .ics
+func (s *Service) SendCalendar(ctx context.Context, telegramID int64, recordID uint) error {
+	l := i18n.ForUser(ctx, telegramID)
+	ics, err := s.client.RecordCalendar(ctx, telegramID, recordID)
+	if err != nil {
+		return err
+	}
+	_, err = s.bot.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID:   telegramID,
+		Document: &models.InputFileUpload{Filename: fmt.Sprintf("record-%d.ics", recordID), Data: bytes.NewReader(ics)},
+		Caption:  l.T("record.calendar_caption"),
+	})
+	return err
+}
+
+// UserMessage — текст ошибки действия с записью для пользователя
+func UserMessage(l i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, ErrRecordNotFound):
+		return l.T("record.not_found")
+	case errors.Is(err, ErrRecordInactive):
+		return l.T("record.inactive")
+	case errors.Is(err, ErrReviewExists):
+		return l.T("review.exists")
+	case errors.Is(err, ErrNotCompleted):
+		return l.T("review.not_completed")
+	}
+	return adapter.UserMessage(l, err)
+}
+
+// findRecord ищет запись среди записей клиента: так бот не покажет чужую
+func (s *Service) findRecord(ctx context.Context, telegramID int64, recordID uint) (mymodels.Record, error) {
+	resp, err := s.client.GetUserRecordsFiltered(ctx, telegramID, "", 1, 1000)
+	if err != nil {
+		return mymodels.Record{}, err
+	}
+	for _, r := range resp.Records {
+		if r.ID == recordID {
+			return r, nil
+		}
+	}
+	return mymodels.Record{}, ErrRecordNotFound
+}
+
+// activeRecord — запись клиента, с которой ещё можно что-то сделать
+func (s *Service) activeRecord(ctx context.Context, telegramID int64, recordID uint) (mymodels.Record, error) {
+	rec, err := s.findRecord(ctx, telegramID, recordID)
+	if err != nil {
+		return rec, err
+	}
+	if !recordActive(rec, time.Now()) {
+		return rec, ErrRecordInactive
+	}
+	return rec, nil
+}
+
+func (s *Service) show(ctx context.Context, chatID int64, messageID int, text string, keyboard *models.InlineKeyboardMarkup) {
+	if messageID > 0 {
+		if err := s.editor.EditSpecificMessage(ctx, s.bot, chatID, messageID, text, keyboard); err != nil {
+			s.logger.WithError(err).Error("Failed to edit record message")
+		}
+		return
+	}
+	s.send(ctx, chatID, text, keyboard)
+}
+
+func (s *Service) send(ctx context.Context, chatID int64, text string, keyboard *models.InlineKeyboardMarkup) {
+	params := &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+	if _, err := s.bot.SendMessage(ctx, params); err != nil {
+		s.logger.WithError(err).Error("Failed to send record message")
+	}
+}
+
+// recordActive — запись не отменена, не отклонена и ещё не началась
+func recordActive(r mymodels.Record, now time.Time) bool {
+	return !recordClosed(r) && r.Slot.StartTime.After(now)
+}
+
+func recordClosed(r mymodels.Record) bool {
+	return r.Status == "cancel" || r.Status == "reject"
+}
+
+// recordKeyboard — действия с записью клиента owner: для активной все, для прошедшей
+// календарь, для состоявшейся ещё и оценка визита
+func recordKeyboard(l i18n.Localizer, owner int64, r mymodels.Record, now time.Time) *models.InlineKeyboardMarkup {
+	id, ownerID := strconv.FormatUint(uint64(r.ID), 10), strconv.FormatInt(owner, 10)
+	var keyboard [][]models.InlineKeyboardButton
+	if recordActive(r, now) {
+		keyboard = append(keyboard,
+			[]models.InlineKeyboardButton{
+				{Text: l.T("record.button.cancel"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCancel, id, "ask", ownerID)},
+				{Text: l.T("record.button.reschedule"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordSlots, id, "1", ownerID)},
+			},
+			[]models.InlineKeyboardButton{
+				{Text: l.T("record.button.comment"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordComment, id, ownerID)},
+			},
+		)
+	}
+	if !recordClosed(r) {
+		keyboard = append(keyboard, []models.InlineKeyboardButton{
+			{Text: l.T("record.button.calendar"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCalendar, id, ownerID)},
+		})
+	}
+	if recordCompleted(r, now) {
+		keyboard = append(keyboard, []models.InlineKeyboardButton{
+			{Text: l.T("record.button.review"), CallbackData: callbackdata.Encode(callbackdata.RouteReview, id, "0", ownerID)},
+		})
+	}
+	keyboard = append(keyboard, []models.InlineKeyboardButton{
+		{Text: l.T("record.button.back"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordsTime, "future", "all", "1")},
+	})
+	return &models.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// recordButtons — по кнопке на каждую запись страницы клиента owner, открывают карточку записи
+func recordButtons(l i18n.Localizer, owner int64, records []mymodels.Record) [][]models.InlineKeyboardButton {
+	var rows [][]models.InlineKeyboardButton
+	for _, r := range records {
+		service := r.Slot.Service.Name
+		if service == "" {
+			service = l.T("record.unknown_service")
+		}
+		when := l.T("record.not_set")
+		if !r.Slot.StartTime.IsZero() {
+			zone := utils.ViewerZone(l.Zone(), r.Slot.Master.Timezone)
+			when = utils.FormatDateInLocale(l.Lang(), zone, r.Slot.StartTime) + " " + utils.FormatTimeOnlyInLocale(l.Lang(), zone, r.Slot.StartTime)
+		}
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         l.T("record.open_button", service, when),
+			CallbackData: callbackdata.Encode(callbackdata.RouteRecordCard, strconv.FormatUint(uint64(r.ID), 10), strconv.FormatInt(owner, 10)),
+		}})
+	}
+	return rows
+}
+
+// rescheduleOptions — свободные будущие слоты мастера на ту же услугу, кроме текущего, по времени начала.
+// На слот другой услуги API запись не перенесёт: цена и длительность записи зафиксированы
+func rescheduleOptions(slots []mymodels.SlotResponse, current mymodels.Slot, now time.Time) []mymodels.SlotResponse {
+	var out []mymodels.SlotResponse
+	for _, sl := range slots {
+		if sl.ID != current.ID && sl.ServiceID == current.ServiceID && !sl.IsBooked && sl.StartTime.After(now) {
+			out = append(out, sl)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].StartTime.Before(out[j].StartTime) })
+	return out
+}
@@ -0,0 +1,23 @@
+package record
+
+import "telegram-bot/internal/fsm"
+
+// FlowComment — ввод комментария клиента к записи
+const FlowComment = "recordcomment"
+
+// StateComment — ждём текст комментария
+const StateComment fsm.State = "record.comment"
+
+// FlowReview — ввод текста отзыва о завершённой записи
+const FlowReview = "recordreview"
+
+// StateReview — ждём текст отзыва
+const StateReview fsm.State = "record.review"
+
+// Flows возвращает сценарии для регистрации в fsm.Machine
+func Flows() []fsm.Flow {
+	return []fsm.Flow{
+		{Name: FlowComment, Initial: StateComment},
+		{Name: FlowReview, Initial: StateReview},
+	}
+}
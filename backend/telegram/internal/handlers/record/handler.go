@@ -3,6 +3,8 @@ package record
 import (
 	"context"
 	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/fsm"
 	"telegram-bot/internal/handlers/shared"
 
 	"github.com/go-telegram/bot"
@@ -14,8 +16,8 @@ type Handler struct {
 	service *Service
 }
 
-func NewHandler(b *bot.Bot, logger *logrus.Logger) *Handler {
-	return &Handler{service: NewService(b, logger)}
+func NewHandler(b *bot.Bot, logger *logrus.Logger, client *adapter.Client) *Handler {
+	return &Handler{service: NewService(b, logger, client)}
 }
 
 func (h *Handler) HandlerGetUserRecords(ctx context.Context, b *bot.Bot, update *models.Update) {
@@ -46,3 +48,55 @@ func (h *Handler) HandlerGetAllRecords(ctx context.Context, b *bot.Bot, update *
 	h.service.logger.WithField("user_id", userID).Info("Handler.Record.HandlerGetAllRecords: fetching all records")
 	h.service.SendAllRecords(ctx, userID, userID)
 }
+
+// MatchCommentInput отбирает текстовые сообщения (не команды) пользователей, которые пишут комментарий к записи
+func (h *Handler) MatchCommentInput(update *models.Update) bool {
+	if update == nil || update.Message == nil || update.Message.From == nil {
+		return false
+	}
+	if update.Message.Chat.Type != models.ChatTypePrivate {
+		return false
+	}
+	text := strings.TrimSpace(update.Message.Text)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+	sess, ok := fsm.GetMachine().Current(update.Message.From.ID)
+	return ok && sess.Flow == FlowComment
+}
+
+// HandleCommentInput сохраняет комментарий клиента к записи
+func (h *Handler) HandleCommentInput(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	sess, ok := fsm.GetMachine().Current(userID)
+	if !ok || sess.Flow != FlowComment {
+		return
+	}
+	h.service.SaveComment(ctx, userID, sess, strings.TrimSpace(update.Message.Text))
+}
+
+// MatchReviewInput отбирает текстовые сообщения (не команды) пользователей, которые пишут отзыв о визите
+func (h *Handler) MatchReviewInput(update *models.Update) bool {
+	if update == nil || update.Message == nil || update.Message.From == nil {
+		return false
+	}
+	if update.Message.Chat.Type != models.ChatTypePrivate {
+		return false
+	}
+	text := strings.TrimSpace(update.Message.Text)
+	if text == "" || strings.HasPrefix(text, "/") {
+		return false
+	}
+	sess, ok := fsm.GetMachine().Current(update.Message.From.ID)
+	return ok && sess.Flow == FlowReview
+}
+
+// HandleReviewInput публикует текст отзыва клиента
+func (h *Handler) HandleReviewInput(ctx context.Context, b *bot.Bot, update *models.Update) {
+	userID := update.Message.From.ID
+	sess, ok := fsm.GetMachine().Current(userID)
+	if !ok || sess.Flow != FlowReview {
+		return
+	}
+	h.service.SaveReview(ctx, userID, sess, strings.TrimSpace(update.Message.Text))
+}
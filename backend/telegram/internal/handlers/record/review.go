@@ -0,0 +1,119 @@
+package record
+
+import (
+	"context"
+	"contract"
+	"errors"
+	"html"
+	"strconv"
+	"strings"
+	adapter "telegram-bot/internal/adapter/backendapi"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/fsm"
+	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/handlers/message"
+	"telegram-bot/internal/i18n"
+	mymodels "telegram-bot/pkg/models"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// MaxReviewLength — как в API: длиннее отзыв не сохранится
+const MaxReviewLength = 2000
+
+var (
+	// ErrReviewExists — визит уже оценён
+	ErrReviewExists = errors.New("review exists")
+	// ErrNotCompleted — запись не подтверждена или ещё не закончилась
+	ErrNotCompleted = errors.New("record is not completed")
+)
+
+// AskRating показывает выбор оценки для завершённой записи клиента
+func (s *Service) AskRating(ctx context.Context, telegramID int64, recordID uint, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	rec, err := s.findRecord(ctx, telegramID, recordID)
+	if err != nil {
+		return err
+	}
+	if !recordCompleted(rec, time.Now()) {
+		return ErrNotCompleted
+	}
+	keyboard := message.ReviewKeyboard(telegramID, recordID)
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, []models.InlineKeyboardButton{
+		{Text: l.T("record.button.back_card"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordCard,
+			strconv.FormatUint(uint64(recordID), 10), strconv.FormatInt(telegramID, 10))},
+	})
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("review.rate"), keyboard)
+	return nil
+}
+
+// Rate сохраняет оценку визита и предлагает дописать текст отзыва
+func (s *Service) Rate(ctx context.Context, telegramID int64, recordID uint, rating, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	err := s.client.CreateReview(ctx, telegramID, recordID, contract.ReviewRequest{Rating: rating})
+	switch {
+	case errors.Is(err, adapter.ErrConflict):
+		return ErrReviewExists
+	case errors.Is(err, adapter.ErrInvalid):
+		return ErrNotCompleted
+	case err != nil:
+		return err
+	}
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("review.saved", stars(rating)), &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{{
+			{Text: l.T("review.button.text"), CallbackData: callbackdata.Encode(callbackdata.RouteReviewText,
+				strconv.FormatUint(uint64(recordID), 10), strconv.Itoa(rating), strconv.FormatInt(telegramID, 10))},
+		}},
+	})
+	return nil
+}
+
+// AskReviewText начинает ввод текста отзыва: следующий текст пользователя будет опубликован
+func (s *Service) AskReviewText(ctx context.Context, telegramID int64, recordID uint, rating, messageID int) error {
+	l := i18n.ForUser(ctx, telegramID)
+	data := map[string]string{"record_id": strconv.FormatUint(uint64(recordID), 10), "rating": strconv.Itoa(rating)}
+	if _, err := fsm.GetMachine().Start(telegramID, FlowReview, data); err != nil {
+		return err
+	}
+	s.show(ctx, telegramID, messageID, components.Header()+l.T("review.text_prompt", stars(rating), MaxReviewLength),
+		&models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}})
+	return nil
+}
+
+// SaveReview публикует текст отзыва из сценария FlowReview вместе с выбранной оценкой.
+// Если текст не подошёл, сценарий продолжается и пользователь может прислать другой.
+func (s *Service) SaveReview(ctx context.Context, telegramID int64, sess fsm.Session, text string) {
+	l := i18n.ForUser(ctx, telegramID)
+	recordID, err := strconv.ParseUint(sess.Data["record_id"], 10, 0)
+	rating, ratingErr := strconv.Atoi(sess.Data["rating"])
+	if err != nil || ratingErr != nil {
+		_ = fsm.GetMachine().Finish(telegramID)
+		return
+	}
+	if n := len([]rune(text)); n == 0 || n > MaxReviewLength {
+		s.send(ctx, telegramID, l.T("review.text_invalid", MaxReviewLength), nil)
+		return
+	}
+	err = s.client.UpdateReview(ctx, telegramID, uint(recordID), contract.ReviewRequest{Rating: rating, Text: text})
+	if finishErr := fsm.GetMachine().Finish(telegramID); finishErr != nil {
+		s.logger.WithError(finishErr).Warn("Handlers.Record.SaveReview: finish flow")
+	}
+	if err != nil {
+		s.logger.WithError(err).Warn("Handlers.Record.SaveReview: request failed")
+		s.send(ctx, telegramID, components.APIError(l, err, components.Error(l, html.EscapeString(UserMessage(l, err)))), nil)
+		return
+	}
+	s.send(ctx, telegramID, components.Header()+l.T("review.published"), nil)
+}
+
+// recordCompleted — подтверждённая запись, слот которой закончился
+func recordCompleted(r mymodels.Record, now time.Time) bool {
+	return r.Status == "confirm" && !r.Slot.EndTime.IsZero() && !r.Slot.EndTime.After(now)
+}
+
+// stars — оценка звёздами: «★★★★☆»
+func stars(rating int) string {
+	rating = min(max(rating, 0), 5)
+	return strings.Repeat("★", rating) + strings.Repeat("☆", 5-rating)
+}
@@ -4,11 +4,14 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	adapter "telegram-bot/internal/adapter/backendapi"
-	"telegram-bot/internal/config"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/handlers/components"
 	"telegram-bot/internal/handlers/message"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/state"
 	"telegram-bot/internal/utils"
 	mymodels "telegram-bot/pkg/models"
 	"time"
@@ -25,9 +28,7 @@ type Service struct {
 	editor *message.MessageEditor
 }
 
-func NewService(bot *bot.Bot, logger *logrus.Logger) *Service {
-	cfg := config.Load()
-	client := adapter.New(cfg.BackendBaseURL, logger)
+func NewService(bot *bot.Bot, logger *logrus.Logger, client *adapter.Client) *Service {
 	return &Service{bot: bot, logger: logger, client: client, editor: message.NewMessageEditor()}
 }
 
@@ -36,6 +37,7 @@ func (s *Service) SendUserRecords(ctx context.Context, chatID int64, telegramID
 		page = 1
 	}
 	const limit = 10
+	l := i18n.ForUser(ctx, telegramID)
 
 	// Определяем статус для запроса
 	queryStatus := status
@@ -46,7 +48,7 @@ func (s *Service) SendUserRecords(ctx context.Context, chatID int64, telegramID
 	resp, err := s.client.GetUserRecordsFiltered(ctx, telegramID, queryStatus, 1, 1000)
 	if err != nil {
 		s.logger.WithError(err).Warn("Handlers.Record.SendUserRecords: filter request failed")
-		s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: "❌ Не удалось получить записи"})
+		s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, ParseMode: models.ParseModeHTML, Text: components.APIError(l, err, l.T("records.load_failed"))})
 		return
 	}
 
@@ -55,18 +57,7 @@ func (s *Service) SendUserRecords(ctx context.Context, chatID int64, telegramID
 	future, _ := splitRecordsByTime(filteredRecords)
 
 	if len(future) == 0 {
-		statusText := "записи"
-		if status != "" && status != "all" {
-			switch status {
-			case "confirm":
-				statusText = "подтвержденные записи"
-			case "reject":
-				statusText = "отклоненные записи"
-			case "pending":
-				statusText = "записи в ожидании"
-			}
-		}
-		text := fmt.Sprintf("%s<b>Мои записи (%s)</b>\n<i>У вас пока нет предстоящих %s</i>", components.Header(), statusText, statusText)
+		text := fmt.Sprintf("%s%s\n%s", components.Header(), l.T("records.title", RecordsKind(l, status)), l.T("records.empty_upcoming"))
 		s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML})
 		return
 	}
@@ -83,52 +74,39 @@ func (s *Service) SendUserRecords(ctx context.Context, chatID int64, telegramID
 	}
 	pageRecords := future[start:end]
 
-	statusText := "записи"
-	if status != "" && status != "all" {
-		switch status {
-		case "confirm":
-			statusText = "подтвержденные записи"
-		case "reject":
-			statusText = "отклоненные записи"
-		case "pending":
-			statusText = "записи в ожидании"
-		}
-	}
-
-	text := fmt.Sprintf("%s<b>Мои записи (%s)</b> (стр. %d/%d)\n\n", components.Header(), statusText, page, pages) + s.formatRecordsText(pageRecords)
-	keyboard := s.buildRecordsPaginationKeyboard(status, page, pages)
+	text := recordsHeader(l, l.T("records.title", RecordsKind(l, status)), len(future), page, pages) + s.formatRecordsText(l, pageRecords)
+	keyboard := s.buildRecordsPaginationKeyboard(l, telegramID, pageRecords, status, page, pages)
 
 	sent, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML, ReplyMarkup: keyboard})
 	if err == nil {
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(chatID, chatID, "user_records")
-		sm.SetMessageState(chatID, chatID, sent.ID, "user_records", map[string]interface{}{"timeType": "future_only", "page": page, "status": status})
+		sm.SetMessageState(ctx, chatID, chatID, sent.ID, "user_records", state.Context{TimeType: "future_only", Page: page, Status: status})
 	}
 }
 
 // SendAllRecords отправляет все записи с меню выбора будущих/прошедших
 func (s *Service) SendAllRecords(ctx context.Context, chatID int64, telegramID int64) {
 	const limit = 10
+	l := i18n.ForUser(ctx, telegramID)
 	_, err := s.client.GetUserRecordsFiltered(ctx, telegramID, "", 1, 1000)
 	if err != nil {
 		s.logger.WithError(err).Warn("Handlers.Record.SendAllRecords: filter request failed")
-		s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: "❌ Не удалось получить записи"})
+		s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, ParseMode: models.ParseModeHTML, Text: components.APIError(l, err, l.T("records.load_failed"))})
 		return
 	}
 
 	// Показываем меню выбора времени (Будущие/Прошедшие)
-	text := fmt.Sprintf("%s<b>Все мои записи</b>\n<i>Выберите пул записей для просмотра</i>", components.Header())
+	text := components.Header() + l.T("records.chooser")
 	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-		{{Text: "Будущие", CallbackData: "all_records_time/future/all/1"}},
-		{{Text: "Прошедшие", CallbackData: "all_records_time/past/all/1"}},
+		{{Text: l.T("pool.future"), CallbackData: callbackdata.Encode(callbackdata.RouteAllRecordsTime, "future", "all", "1")}},
+		{{Text: l.T("pool.past"), CallbackData: callbackdata.Encode(callbackdata.RouteAllRecordsTime, "past", "all", "1")}},
 	}}
 
 	// Всегда новое сообщение и установка указателя состояния
 	sent, err := s.bot.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text, ParseMode: models.ParseModeHTML, ReplyMarkup: keyboard})
 	if err == nil {
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(chatID, chatID, "all_records")
-		sm.SetMessageState(chatID, chatID, sent.ID, "all_records", map[string]interface{}{"timeType": "chooser", "page": 1, "status": "all"})
+		sm.SetMessageState(ctx, chatID, chatID, sent.ID, "all_records", state.Context{TimeType: "chooser", Page: 1, Status: "all"})
 	}
 }
 
@@ -138,6 +116,7 @@ func (s *Service) EditUserRecordsPage(ctx context.Context, chatID int64, telegra
 		page = 1
 	}
 	const limit = 10
+	l := i18n.ForUser(ctx, telegramID)
 
 	// Определяем статус для запроса
 	queryStatus := status
@@ -166,20 +145,8 @@ func (s *Service) EditUserRecordsPage(ctx context.Context, chatID int64, telegra
 	}
 	pageRecords := future[start:end]
 
-	statusText := "записи"
-	if status != "" && status != "all" {
-		switch status {
-		case "confirm":
-			statusText = "подтвержденные записи"
-		case "reject":
-			statusText = "отклоненные записи"
-		case "pending":
-			statusText = "записи в ожидании"
-		}
-	}
-
-	text := fmt.Sprintf("%s<b>Мои записи (%s)</b> (стр. %d/%d)\n\n", components.Header(), statusText, page, pages) + s.formatRecordsText(pageRecords)
-	keyboard := s.buildRecordsPaginationKeyboard(status, page, pages)
+	text := recordsHeader(l, l.T("records.title", RecordsKind(l, status)), len(future), page, pages) + s.formatRecordsText(l, pageRecords)
+	keyboard := s.buildRecordsPaginationKeyboard(l, telegramID, pageRecords, status, page, pages)
 
 	// Редактируем конкретное сообщение, если messageID > 0, иначе отправляем новое
 	if messageID > 0 {
@@ -202,8 +169,7 @@ func (s *Service) EditUserRecordsPage(ctx context.Context, chatID int64, telegra
 
 		// Устанавливаем состояние для нового сообщения
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(chatID, chatID, "user_records")
-		sm.SetMessageState(chatID, chatID, sent.ID, "user_records", map[string]interface{}{"timeType": "future_only", "page": page, "status": status})
+		sm.SetMessageState(ctx, chatID, chatID, sent.ID, "user_records", state.Context{TimeType: "future_only", Page: page, Status: status})
 	}
 }
 
@@ -213,6 +179,7 @@ func (s *Service) EditUserRecordsTimePage(ctx context.Context, chatID int64, tel
 		page = 1
 	}
 	const limit = 10
+	l := i18n.ForUser(ctx, telegramID)
 
 	// Определяем статус для запроса
 	queryStatus := status
@@ -234,24 +201,16 @@ func (s *Service) EditUserRecordsTimePage(ctx context.Context, chatID int64, tel
 	var title string
 	if timeType == "past" {
 		list = past
-		title = "Прошедшие записи"
+		title = l.T("records.past_title")
 	} else {
 		list = future
-		title = "Будущие записи"
+		title = l.T("records.future_title")
 	}
 
 	// Добавляем информацию о статусе в заголовок
-	if status != "" && status != "all" {
-		statusText := ""
-		switch status {
-		case "confirm":
-			statusText = " (подтвержденные)"
-		case "reject":
-			statusText = " (отклоненные)"
-		case "pending":
-			statusText = " (в ожидании)"
-		}
-		title += statusText
+	switch status {
+	case "confirm", "reject", "pending":
+		title += l.T("records.filter." + status)
 	}
 
 	pages := pagesCount(len(list), limit)
@@ -264,8 +223,8 @@ func (s *Service) EditUserRecordsTimePage(ctx context.Context, chatID int64, tel
 		end = len(list)
 	}
 	pageRecords := list[start:end]
-	text := fmt.Sprintf("%s<b>%s</b> (стр. %d/%d)\n\n", components.Header(), title, page, pages) + s.formatRecordsText(pageRecords)
-	keyboard := s.buildRecordsTimePaginationKeyboard(timeType, status, page, pages)
+	text := recordsHeader(l, "<b>"+title+"</b>", len(list), page, pages) + s.formatRecordsText(l, pageRecords)
+	keyboard := s.buildRecordsTimePaginationKeyboard(l, telegramID, pageRecords, timeType, status, page, pages)
 
 	// Редактируем конкретное сообщение, если messageID > 0, иначе отправляем новое
 	if messageID > 0 {
@@ -288,17 +247,29 @@ func (s *Service) EditUserRecordsTimePage(ctx context.Context, chatID int64, tel
 
 		// Устанавливаем состояние для нового сообщения
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(chatID, chatID, "user_records")
-		sm.SetMessageState(chatID, chatID, sent.ID, "user_records", map[string]interface{}{"timeType": timeType, "page": page, "status": status})
+		sm.SetMessageState(ctx, chatID, chatID, sent.ID, "user_records", state.Context{TimeType: timeType, Page: page, Status: status})
+	}
+}
+
+// RecordsKind — подпись списка записей с фильтром по статусу
+func RecordsKind(l i18n.Localizer, status string) string {
+	switch status {
+	case "confirm", "reject", "pending":
+		return l.T("records.kind." + status)
 	}
+	return l.T("records.kind.all")
+}
+
+// recordsHeader — заголовок страницы списка: название, число записей и номер страницы
+func recordsHeader(l i18n.Localizer, title string, total, page, pages int) string {
+	return fmt.Sprintf("%s%s %s\n<i>%s</i>\n\n", components.Header(), title, l.T("records.page", page, pages), l.N("records.count", total))
 }
 
-func (s *Service) formatRecordsText(records []mymodels.Record) string {
+func (s *Service) formatRecordsText(l i18n.Localizer, records []mymodels.Record) string {
 	b := strings.Builder{}
-	// b.WriteString(fmt.Sprintf("%sВаши записи (стр. %d/%d)\n\n", components.Header(), page, total))
 
 	if len(records) == 0 {
-		b.WriteString("<i>У вас нет записей.</i>")
+		b.WriteString(l.T("records.none"))
 		return b.String()
 	}
 
@@ -307,40 +278,48 @@ func (s *Service) formatRecordsText(records []mymodels.Record) string {
 		masterTimezone := r.Slot.Master.Timezone
 		// Если таймзона не указана, используем московскую как fallback
 		if masterTimezone == "" {
-			masterTimezone = "Europe/Moscow"
+			masterTimezone = utils.DefaultZone
 		}
+		// Время показываем в таймзоне клиента, если он её выбрал
+		zone := utils.ViewerZone(l.Zone(), masterTimezone)
 
 		// Защита от нулевых дат: если время по нулям — выводим "не задано"
-		date := "не задано"
+		date := ""
 		start := "--:--"
 		end := "--:--"
 		if !r.Slot.StartTime.IsZero() {
-			// Используем таймзону мастера для форматирования
-			date = utils.FormatDateInLocation(masterTimezone, r.Slot.StartTime)
-			start = utils.FormatTimeOnlyInLocation(masterTimezone, r.Slot.StartTime)
+			// Используем таймзону клиента и формат языка пользователя
+			date = utils.FormatDateInLocale(l.Lang(), zone, r.Slot.StartTime)
+			start = utils.FormatTimeOnlyInLocale(l.Lang(), zone, r.Slot.StartTime)
 		}
 		if !r.Slot.EndTime.IsZero() {
-			end = utils.FormatTimeOnlyInLocation(masterTimezone, r.Slot.EndTime)
+			end = utils.FormatTimeOnlyInLocale(l.Lang(), zone, r.Slot.EndTime)
 		}
 
 		// Определяем статус записи с эмодзи
 		statusEmoji := "⏳"
-		statusText := "Ожидает подтверждения"
+		statusText := l.T("record.status.default")
 		switch r.Status {
 		case "confirm":
 			statusEmoji = "✅"
-			statusText = "Подтверждена"
+			statusText = l.T("record.status.confirm")
 		case "reject":
 			statusEmoji = "❌"
-			statusText = "Отклонена"
+			statusText = l.T("record.status.reject")
 		case "pending":
 			statusEmoji = "⏳"
-			statusText = "В ожидании"
+			statusText = l.T("record.status.pending")
+		case "cancel":
+			statusEmoji = "🚫"
+			statusText = l.T("record.status.cancel")
+		case "awaiting_payment":
+			statusEmoji = "💳"
+			statusText = l.T("record.status.awaiting_payment")
 		}
 
 		// Получаем информацию о мастере и услуге
-		masterName := "Неизвестный мастер"
-		serviceName := "Неизвестная услуга"
+		masterName := l.T("record.unknown_master")
+		serviceName := l.T("record.unknown_service")
 
 		if r.Slot.Master.FirstName != "" || r.Slot.Master.Surname != "" {
 			masterName = fmt.Sprintf("%s %s", r.Slot.Master.FirstName, r.Slot.Master.Surname)
@@ -351,25 +330,22 @@ func (s *Service) formatRecordsText(records []mymodels.Record) string {
 		}
 
 		// Получаем смещение таймзоны для отображения
-		tzOffset := utils.GetTimezoneOffset(masterTimezone)
+		tzOffset := utils.GetTimezoneOffset(zone)
 
-		// Формируем текст времени с указанием таймзоны (всегда показываем таймзону)
-		timeText := fmt.Sprintf("%s - %s (TZ: %s %s)", start, end, masterTimezone, tzOffset)
+		// Формируем текст времени с указанием таймзоны (всегда показываем таймзону),
+		// время мастера — вторым, если у него другое смещение
+		timeText := fmt.Sprintf("%s - %s (TZ: %s %s)", start, end, zone, tzOffset)
+		if !r.Slot.StartTime.IsZero() {
+			timeText = utils.WithMasterTime(l, masterTimezone, r.Slot.StartTime, r.Slot.EndTime, timeText)
+		}
 
 		// Формируем текст даты с указанием таймзоны
-		dateText := date
-		if date != "не задано" {
-			dateText = fmt.Sprintf("%s (TZ: %s %s)", date, masterTimezone, tzOffset)
+		dateText := l.T("record.not_set")
+		if date != "" {
+			dateText = fmt.Sprintf("%s (TZ: %s %s)", date, zone, tzOffset)
 		}
 
-		b.WriteString(fmt.Sprintf(
-			"<blockquote>"+
-				"<code>%s — %s</code>\n"+
-				// "<b>Мастер:</b> <code>%s</code>\n"+
-				"<b>Статус:</b> <code>%s %s</code>\n"+
-				"<b>Дата:</b> <code>%s</code>\n"+
-				"<b>Время:</b> <code>%s</code>\n"+
-				"</blockquote>",
+		b.WriteString(l.T("record.card",
 			serviceName,
 			masterName,
 			statusEmoji,
@@ -382,20 +358,20 @@ func (s *Service) formatRecordsText(records []mymodels.Record) string {
 }
 
 // buildRecordsPaginationKeyboard создает inline-кнопки для листания страниц записей
-func (s *Service) buildRecordsPaginationKeyboard(status string, page, total int) *models.InlineKeyboardMarkup {
-	buttons := [][]models.InlineKeyboardButton{}
+func (s *Service) buildRecordsPaginationKeyboard(l i18n.Localizer, owner int64, records []mymodels.Record, status string, page, total int) *models.InlineKeyboardMarkup {
+	buttons := recordButtons(l, owner, records)
 	// prev
 	if page > 1 {
 		buttons = append(buttons, []models.InlineKeyboardButton{{
-			Text:         "⬅️ Предыдущая",
-			CallbackData: fmt.Sprintf("records/%s/%d", safeStatus(status), page-1),
+			Text:         l.T("pager.prev"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteRecords, safeStatus(status), strconv.Itoa(page-1)),
 		}})
 	}
 	// next
 	if page < total {
 		buttons = append(buttons, []models.InlineKeyboardButton{{
-			Text:         "Следующая ➡️",
-			CallbackData: fmt.Sprintf("records/%s/%d", safeStatus(status), page+1),
+			Text:         l.T("pager.next"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteRecords, safeStatus(status), strconv.Itoa(page+1)),
 		}})
 	}
 	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
@@ -407,20 +383,20 @@ func safeStatus(status string) string {
 	}
 	return status
 }
-func (s *Service) buildRecordsTimePaginationKeyboard(timeType string, status string, page, total int) *models.InlineKeyboardMarkup {
-	buttons := [][]models.InlineKeyboardButton{}
+func (s *Service) buildRecordsTimePaginationKeyboard(l i18n.Localizer, owner int64, records []mymodels.Record, timeType string, status string, page, total int) *models.InlineKeyboardMarkup {
+	buttons := recordButtons(l, owner, records)
 	if page > 1 {
 		buttons = append(buttons, []models.InlineKeyboardButton{{
-			Text: "⬅️ Предыдущая", CallbackData: fmt.Sprintf("records_time/%s/%s/%d", timeType, status, page-1),
+			Text: l.T("pager.prev"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordsTime, timeType, safeStatus(status), strconv.Itoa(page-1)),
 		}})
 	}
 	if page < total {
 		buttons = append(buttons, []models.InlineKeyboardButton{{
-			Text: "Следующая ➡️", CallbackData: fmt.Sprintf("records_time/%s/%s/%d", timeType, status, page+1),
+			Text: l.T("pager.next"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordsTime, timeType, safeStatus(status), strconv.Itoa(page+1)),
 		}})
 	}
 	// Добавим кнопку вернуться к выбору пула
-	buttons = append(buttons, []models.InlineKeyboardButton{{Text: "◀️ К выбору", CallbackData: "records_time/chooser/1"}})
+	buttons = append(buttons, []models.InlineKeyboardButton{{Text: l.T("pool.back"), CallbackData: callbackdata.Encode(callbackdata.RouteRecordsTime, "chooser", safeStatus(status), "1")}})
 	return &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
 }
 
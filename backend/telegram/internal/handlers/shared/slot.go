@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/utils"
+	mymodels "telegram-bot/pkg/models"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// SlotCard — карточка слота с кнопками «Записаться», «Удалить слот» (только мастеру)
+// и «Назад к слотам» для пользователя viewerID
+func SlotCard(l i18n.Localizer, viewerID int64, slot *mymodels.SlotResponse) (string, *models.InlineKeyboardMarkup) {
+	slotID := strconv.FormatUint(uint64(slot.ID), 10)
+	statusSlot := l.T("slots.status_free")
+	if slot.IsBooked {
+		statusSlot = l.T("slots.status_booked")
+	}
+	// Форматируем время в таймзоне пользователя, а если он её не выбрал — мастера.
+	// Дата совпадает с ключом группировки списка слотов.
+	tzLabel := utils.ViewerZone(l.Zone(), slot.MasterTimezone)
+	date := utils.FormatDateInLocation(tzLabel, slot.StartTime)
+	startTime := utils.FormatTimeOnlyInLocale(l.Lang(), tzLabel, slot.StartTime)
+	endTime := utils.FormatTimeOnlyInLocale(l.Lang(), tzLabel, slot.EndTime)
+
+	// Получаем смещение таймзоны для отображения
+	tzOffset := utils.GetTimezoneOffset(tzLabel)
+
+	// Формируем inline-кнопки действий
+	buttons := [][]models.InlineKeyboardButton{}
+	if !slot.IsBooked {
+		buttons = append(buttons, []models.InlineKeyboardButton{{
+			Text:         l.T("slot.button.book"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteBook, slotID, strconv.FormatInt(viewerID, 10)),
+		}})
+	}
+
+	// Мастер может удалить свой слот
+	if viewerID == slot.MasterTelegramID {
+		buttons = append(buttons, []models.InlineKeyboardButton{{
+			Text:         l.T("slot.button.delete"),
+			CallbackData: callbackdata.Encode(callbackdata.RouteManage, "slot_delete", slotID, strconv.FormatInt(viewerID, 10)),
+		}})
+	}
+
+	// Добавляем кнопку "Назад к слотам"
+	buttons = append(buttons, []models.InlineKeyboardButton{{
+		Text:         l.T("slot.button.back"),
+		CallbackData: callbackdata.Encode(callbackdata.RouteBackToSlots, strconv.FormatInt(slot.MasterTelegramID, 10), date, "1"),
+	}})
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+
+	// Формируем время с таймзоной (таймзона только у времени, не у даты)
+	timeWithTZ := fmt.Sprintf("%s — %s (TZ: %s %s)", startTime, endTime, tzLabel, tzOffset)
+	timeWithTZ = utils.WithMasterTime(l, slot.MasterTimezone, slot.StartTime, slot.EndTime, timeWithTZ)
+	text := components.Header() + l.T("slot.card",
+		slot.MasterName, slot.MasterSurname, timeWithTZ, statusSlot, utils.FormatDateInLocale(l.Lang(), tzLabel, slot.StartTime), slot.ServiceName, l.T("common.minutes", slot.ServiceDuration), l.Money(slot.ServicePrice, slot.ServiceCurrency))
+	return text, keyboard
+}
+
+// SendSlotCard отправляет карточку слота новым сообщением (переход по ссылке из inline-режима)
+func SendSlotCard(ctx context.Context, b *bot.Bot, userID int64, slotID uint) {
+	l := i18n.ForUser(ctx, userID)
+	slot, err := client.GetSlotByID(ctx, slotID)
+	if err != nil {
+		log.Errorf("SendSlotCard: get slot %d: %v", slotID, err)
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    userID,
+			ParseMode: models.ParseModeHTML,
+			Text:      components.APIError(l, err, components.Error(l, l.T("slot.not_found"))),
+		})
+		return
+	}
+	text, keyboard := SlotCard(l, userID, slot)
+	b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      userID,
+		ParseMode:   models.ParseModeHTML,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+}
@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	adapter "telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/app/formatter"
+	"telegram-bot/internal/callbackdata"
 	"telegram-bot/internal/config"
 	"telegram-bot/internal/handlers/components"
 	"telegram-bot/internal/handlers/message"
+	"telegram-bot/internal/i18n"
 	"telegram-bot/internal/logger"
+	"telegram-bot/internal/state"
 	mymodels "telegram-bot/pkg/models"
 	"time"
 
@@ -21,15 +25,23 @@ import (
 var (
 	cfg           = config.Load()
 	log           = logger.New()
-	client        = adapter.New(cfg.BackendBaseURL, log)
+	client        = adapter.New(cfg.BackendBaseURL, cfg.InternalToken, log)
 	messageEditor = message.NewMessageEditor()
 )
-var (
-	msgErrorWithGetSlots   = fmt.Sprintf("%s⚠️ Ошибка\n<i>Произошла ошибка при получении слотов</i>", components.Header())
-	msgErrorWithPagination = fmt.Sprintf("%s⚠️ Ошибка\n<i>Произошла ошибка при пагинации данных</i>", components.Header())
-	msgNotSlots            = fmt.Sprintf("%sℹ️ Сообщение\n<i>Нет активных слотов</i>", components.Header())
-	msgErrorWithKeyboard   = fmt.Sprintf("%s⚠️ Ошибка\n<i>Произошла ошибка при обработки кнопок</i>", components.Header())
-)
+
+// SetClient подменяет клиент API общим экземпляром, созданным при старте бота
+func SetClient(c *adapter.Client) {
+	client = c
+}
+
+func msgErrorWithGetSlots(l i18n.Localizer) string {
+	return components.Error(l, l.T("error.get_slots"))
+}
+func msgErrorWithPagination(l i18n.Localizer) string {
+	return components.Error(l, l.T("error.pagination"))
+}
+func msgNotSlots(l i18n.Localizer) string          { return components.Header() + l.T("slots.none") }
+func msgErrorWithKeyboard(l i18n.Localizer) string { return components.Error(l, l.T("error.keyboard")) }
 
 func IsValidPrivateMessage(update *models.Update) bool {
 	return formatter.IsValidPrivateMessage(update)
@@ -40,31 +52,22 @@ func ExtractUserID(update *models.Update) int64 {
 }
 
 func SendGetUserLink(ctx context.Context, b *bot.Bot, userID int64) {
+	l := i18n.ForUser(ctx, userID)
 	user, err := client.GetUserByTelegramID(ctx, userID)
 	if err != nil {
 		log.Errorf("Failed to get slots for masterID: %d", userID)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgErrorWithGetSlots,
+			Text:      components.APIError(l, err, msgErrorWithGetSlots(l)),
 		})
 		return
 	}
-	msg := fmt.Sprintf(
-		"%s"+
-			"Пользователь: <code>%s %s</code>\n\n"+
-			"Мои услуги:\n"+
-			"%s"+
-			"Вы можете просмотреть:\n"+
-			`<a href="%s">Моё расписание в Телеграме</a>`+
-			"\n"+
-			`<a href="%s/master/%s">Моё расписание на сайте</a>`+
-			"\n\n",
-		components.Header(),
+	msg := components.Header() + l.T("link.text",
 		user.FirstName,
 		user.Surname,
-		PaginationUserServices(user.Services),
-		buildBotStartLink(cfg.BotLink, userID),
+		PaginationUserServices(l, user.Services),
+		buildBotStartLink(cfg.BotLink, strconv.FormatInt(userID, 10)),
 		strings.TrimSuffix(cfg.PublicSiteURL, "/"),
 		user.ID)
 	b.SendMessage(ctx, &bot.SendMessageParams{
@@ -73,9 +76,9 @@ func SendGetUserLink(ctx context.Context, b *bot.Bot, userID int64) {
 		Text:      msg,
 	})
 }
-func PaginationUserServices(services []mymodels.Service) string {
+func PaginationUserServices(l i18n.Localizer, services []mymodels.Service) string {
 	if len(services) == 0 {
-		return "<i>Услуги отсутствуют</i>"
+		return l.T("link.no_services")
 	}
 	var s strings.Builder
 	for _, service := range services {
@@ -84,13 +87,14 @@ func PaginationUserServices(services []mymodels.Service) string {
 	return s.String()
 }
 func SendGetUserSlots(ctx context.Context, b *bot.Bot, userID int64, masterID int64) {
-	slots, ok := client.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		log.Errorf("Failed to get slots for masterID: %d", masterID)
+	l := i18n.ForUser(ctx, userID)
+	slots, err := client.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		log.Errorf("Failed to get slots for masterID: %d: %v", masterID, err)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgErrorWithGetSlots,
+			Text:      components.APIError(l, err, msgErrorWithGetSlots(l)),
 		})
 		return
 	}
@@ -99,7 +103,7 @@ func SendGetUserSlots(ctx context.Context, b *bot.Bot, userID int64, masterID in
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgNotSlots,
+			Text:      msgNotSlots(l),
 		})
 		return
 	}
@@ -107,26 +111,51 @@ func SendGetUserSlots(ctx context.Context, b *bot.Bot, userID int64, masterID in
 	log.Infof("Found %d slots for masterID: %d", len(slots), masterID)
 
 	// Показываем меню выбора времени (Будущие/Прошедшие)
-	text := fmt.Sprintf("%s<b>Мои слоты</b>\n<i>Выберите пул слотов для просмотра</i>", components.Header())
+	text := components.Header() + l.T("slots.chooser")
 	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-		{{Text: "Будущие", CallbackData: fmt.Sprintf("slots_time/future/%d/1", masterID)}},
-		{{Text: "Прошедшие", CallbackData: fmt.Sprintf("slots_time/past/%d/1", masterID)}},
+		{{Text: l.T("pool.future"), CallbackData: slotsTimeCallback("future", masterID)}},
+		{{Text: l.T("pool.past"), CallbackData: slotsTimeCallback("past", masterID)}},
 	}}
 
 	// Отправляем новое сообщение и устанавливаем состояние
 	sent, err := b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: text, ReplyMarkup: keyboard})
 	if err == nil {
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(userID, userID, "slots_time_chooser")
-		sm.SetMessageState(userID, userID, sent.ID, "slots_time_chooser", map[string]interface{}{
-			"masterID": masterID,
-			"timeType": "chooser",
-			"page":     1,
+		sm.SetMessageState(ctx, userID, userID, sent.ID, "slots_time_chooser", state.Context{
+			MasterID: masterID,
+			TimeType: "chooser",
+			Page:     1,
 		})
 	}
 }
 
-func buildBotStartLink(botLink string, userID int64) string {
+// slotStartPrefix — префикс аргумента /start со ссылкой на слот: s<slotID>.
+// Без префикса аргумент — telegram_id мастера
+const slotStartPrefix = "s"
+
+// BotStartLink — ссылка на бота, открывающая его с /start payload
+func BotStartLink(payload string) string {
+	return buildBotStartLink(cfg.BotLink, payload)
+}
+
+// SlotStartPayload — аргумент /start, открывающий карточку слота для записи
+func SlotStartPayload(slotID uint) string {
+	return slotStartPrefix + strconv.FormatUint(uint64(slotID), 10)
+}
+
+// ParseSlotStartPayload разбирает аргумент /start вида s<slotID>
+func ParseSlotStartPayload(payload string) (uint, bool) {
+	if !strings.HasPrefix(payload, slotStartPrefix) {
+		return 0, false
+	}
+	id, err := strconv.ParseUint(payload[len(slotStartPrefix):], 10, 0)
+	if err != nil || id == 0 {
+		return 0, false
+	}
+	return uint(id), true
+}
+
+func buildBotStartLink(botLink string, payload string) string {
 	base := strings.TrimSpace(botLink)
 	if base == "" {
 		return ""
@@ -135,7 +164,7 @@ func buildBotStartLink(botLink string, userID int64) string {
 	if strings.Contains(base, "?") {
 		separator = "&"
 	}
-	return fmt.Sprintf("%s%vstart=%d", base, separator, userID)
+	return fmt.Sprintf("%s%vstart=%s", base, separator, payload)
 }
 
 // SendFutureSlotsForClient отправляет только будущие слоты для клиента
@@ -143,13 +172,14 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 	if page <= 0 {
 		page = 1
 	}
-	slots, ok := client.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		log.Errorf("Failed to get slots for masterID: %d", masterID)
+	l := i18n.ForUser(ctx, userID)
+	slots, err := client.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		log.Errorf("Failed to get slots for masterID: %d: %v", masterID, err)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgErrorWithGetSlots,
+			Text:      components.APIError(l, err, msgErrorWithGetSlots(l)),
 		})
 		return
 	}
@@ -158,7 +188,7 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgNotSlots,
+			Text:      msgNotSlots(l),
 		})
 		return
 	}
@@ -169,7 +199,7 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 	filteredSlots := filterSlotsByTime(slots, "future")
 
 	if len(filteredSlots) == 0 {
-		text := fmt.Sprintf("%s<b>Слоты мастера</b>\n<i>У мастера пока нет доступных слотов</i>", components.Header())
+		text := components.Header() + l.T("slots.master_empty")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
@@ -179,13 +209,13 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 	}
 
 	// Создаем пагинированные слоты для будущих слотов
-	paginationData := formatter.CreatePaginatedSlots(filteredSlots, "", page, 10, true)
+	paginationData := formatter.CreatePaginatedSlots(filteredSlots, l.Zone(), "", page, 10, true)
 
 	if paginationData == nil {
 		log.Errorf("Failed to create pagination data for future slots")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithPagination,
+			Text:      msgErrorWithPagination(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -195,20 +225,21 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 	}
 	// Проставим masterID в пагинационные данные
 	paginationData.MasterInfo.TelegramID = masterID
+	paginationData.ViewerTelegramID = userID
 	paginationData.IsClientView = true
-	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(paginationData)
+	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(l, paginationData)
 
 	if inlineKeyboard == nil {
 		log.Errorf("Failed to create inline keyboard for pagination data")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithKeyboard,
+			Text:      msgErrorWithKeyboard(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
 	}
 
-	text = fmt.Sprintf("%s<b>Доступные слоты мастера</b>\n\n%s", components.Header(), text)
+	text = fmt.Sprintf("%s%s\n\n%s", components.Header(), l.T("slots.master_title"), text)
 
 	// Редактируем конкретное сообщение, если messageID > 0, иначе отправляем новое
 	if messageID > 0 {
@@ -231,45 +262,46 @@ func SendFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID int64, mas
 
 		// Устанавливаем состояние для нового сообщения
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(userID, userID, "client_slots")
-		sm.SetMessageState(userID, userID, sent.ID, "client_slots", map[string]interface{}{
-			"masterID": masterID,
-			"timeType": "future",
-			"page":     page,
+		sm.SetMessageState(ctx, userID, userID, sent.ID, "client_slots", state.Context{
+			MasterID: masterID,
+			TimeType: "future",
+			Page:     page,
 		})
 	}
 }
 
 // SendPaginatedFutureSlotsForClient paginates future-only slots by target date for client view
 func SendPaginatedFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID, masterID int64, targetDate string, page int, messageID int) {
-	slots, ok := client.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		log.Errorf("Failed to get slots for masterID: %d", masterID)
-		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: msgErrorWithGetSlots})
+	l := i18n.ForUser(ctx, userID)
+	slots, err := client.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		log.Errorf("Failed to get slots for masterID: %d: %v", masterID, err)
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: components.APIError(l, err, msgErrorWithGetSlots(l))})
 		return
 	}
 	if len(slots) == 0 {
-		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: msgNotSlots})
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: msgNotSlots(l)})
 		return
 	}
 	filteredSlots := filterSlotsByTime(slots, "future")
 	if len(filteredSlots) == 0 {
-		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: msgNotSlots})
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, ParseMode: models.ParseModeHTML, Text: msgNotSlots(l)})
 		return
 	}
-	paginationData := formatter.CreatePaginatedSlots(filteredSlots, targetDate, page, 10, true)
+	paginationData := formatter.CreatePaginatedSlots(filteredSlots, l.Zone(), targetDate, page, 10, true)
 	if paginationData == nil {
-		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, Text: msgErrorWithPagination})
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, Text: msgErrorWithPagination(l)})
 		return
 	}
 	paginationData.MasterInfo.TelegramID = masterID
+	paginationData.ViewerTelegramID = userID
 	paginationData.IsClientView = true
-	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(paginationData)
+	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(l, paginationData)
 	if inlineKeyboard == nil {
-		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, Text: msgErrorWithKeyboard})
+		b.SendMessage(ctx, &bot.SendMessageParams{ChatID: userID, Text: msgErrorWithKeyboard(l)})
 		return
 	}
-	text = fmt.Sprintf("%s<b>Доступные слоты мастера</b>\n\n%s", components.Header(), text)
+	text = fmt.Sprintf("%s%s\n\n%s", components.Header(), l.T("slots.master_title"), text)
 	if messageID > 0 {
 		if err := messageEditor.EditSpecificMessage(ctx, b, userID, messageID, text, inlineKeyboard); err != nil {
 			log.Errorf("Failed to edit future slots message: %v", err)
@@ -281,13 +313,14 @@ func SendPaginatedFutureSlotsForClient(ctx context.Context, b *bot.Bot, userID,
 
 // SendPaginatedSlots отправляет пагинированные слоты для конкретной даты и страницы
 func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64, targetDate string, page int, messageID int) {
-	slots, ok := client.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		log.Errorf("Failed to get slots for masterID: %d", masterID)
+	l := i18n.ForUser(ctx, userID)
+	slots, err := client.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		log.Errorf("Failed to get slots for masterID: %d: %v", masterID, err)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgErrorWithGetSlots,
+			Text:      components.APIError(l, err, msgErrorWithGetSlots(l)),
 		})
 		return
 	}
@@ -295,7 +328,7 @@ func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64,
 		log.Infof("No slots found for masterID: %d", masterID)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgNotSlots,
+			Text:      msgNotSlots(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -304,13 +337,13 @@ func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64,
 	log.Infof("Found %d slots for masterID: %d, requesting date: %s, page: %d", len(slots), masterID, targetDate, page)
 
 	// Создаем пагинированные слоты для конкретной даты и страницы
-	paginationData := formatter.CreatePaginatedSlots(slots, targetDate, page, 10, false)
+	paginationData := formatter.CreatePaginatedSlots(slots, l.Zone(), targetDate, page, 10, false)
 
 	if paginationData == nil {
 		log.Errorf("Failed to create pagination data for date %s, page %d", targetDate, page)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithPagination,
+			Text:      msgErrorWithPagination(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -318,13 +351,14 @@ func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64,
 
 	// Проставим masterID в пагинационные данные для корректного формирования callback
 	paginationData.MasterInfo.TelegramID = masterID
-	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(paginationData)
+	paginationData.ViewerTelegramID = userID
+	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(l, paginationData)
 
 	if inlineKeyboard == nil {
 		log.Errorf("Failed to create inline keyboard for pagination data")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithKeyboard,
+			Text:      msgErrorWithKeyboard(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -352,10 +386,10 @@ func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64,
 		} else {
 			// Устанавливаем состояние для нового сообщения
 			sm := message.GetStateManager()
-			sm.SetMessageState(userID, userID, sent.ID, "slots_pagination", map[string]interface{}{
-				"masterID":   masterID,
-				"targetDate": targetDate,
-				"page":       page,
+			sm.SetMessageState(ctx, userID, userID, sent.ID, "slots_pagination", state.Context{
+				MasterID:   masterID,
+				TargetDate: targetDate,
+				Page:       page,
 			})
 		}
 	}
@@ -363,13 +397,14 @@ func SendPaginatedSlots(ctx context.Context, b *bot.Bot, userID, masterID int64,
 
 // SendSlotsByTime отправляет слоты, отфильтрованные по времени (будущие/прошедшие)
 func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, timeType string, page int, messageID int) {
-	slots, ok := client.GetSlotsByTelegramID(ctx, masterID)
-	if !ok {
-		log.Errorf("Failed to get slots for masterID: %d", masterID)
+	l := i18n.ForUser(ctx, userID)
+	slots, err := client.GetSlotsByTelegramID(ctx, masterID)
+	if err != nil {
+		log.Errorf("Failed to get slots for masterID: %d: %v", masterID, err)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
-			Text:      msgErrorWithGetSlots,
+			Text:      components.APIError(l, err, msgErrorWithGetSlots(l)),
 		})
 		return
 	}
@@ -377,7 +412,7 @@ func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, ti
 		log.Infof("No slots found for masterID: %d", masterID)
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgNotSlots,
+			Text:      msgNotSlots(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -389,13 +424,9 @@ func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, ti
 	filteredSlots := filterSlotsByTime(slots, timeType)
 
 	if len(filteredSlots) == 0 {
-		timeText := "будущих"
-		if timeType == "past" {
-			timeText = "прошедших"
-		}
-		text := fmt.Sprintf("%s<b>Мои слоты (%s)</b>\n<i>У вас пока нет %s слотов</i>", components.Header(), timeText, timeText)
+		text := fmt.Sprintf("%s%s\n%s", components.Header(), l.T("slots."+poolKey(timeType)+"_title"), l.T("slots."+poolKey(timeType)+"_empty"))
 		keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "◀️ К выбору", CallbackData: fmt.Sprintf("slots_time/chooser/%d", masterID)}},
+			{{Text: l.T("pool.back"), CallbackData: slotsTimeCallback("chooser", masterID)}},
 		}}
 
 		// Отправляем новое сообщение вместо редактирования
@@ -412,23 +443,22 @@ func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, ti
 
 		// Устанавливаем состояние для нового сообщения
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(userID, userID, "slots_time_chooser")
-		sm.SetMessageState(userID, userID, sent.ID, "slots_time_chooser", map[string]interface{}{
-			"masterID": masterID,
-			"timeType": timeType,
-			"page":     page,
+		sm.SetMessageState(ctx, userID, userID, sent.ID, "slots_time_chooser", state.Context{
+			MasterID: masterID,
+			TimeType: timeType,
+			Page:     page,
 		})
 		return
 	}
 
 	// Создаем пагинированные слоты для отфильтрованного списка
-	paginationData := formatter.CreatePaginatedSlots(filteredSlots, "", page, 10, false)
+	paginationData := formatter.CreatePaginatedSlots(filteredSlots, l.Zone(), "", page, 10, false)
 
 	if paginationData == nil {
 		log.Errorf("Failed to create pagination data for filtered slots")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithPagination,
+			Text:      msgErrorWithPagination(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
@@ -436,29 +466,26 @@ func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, ti
 
 	// Проставим masterID в пагинационные данные
 	paginationData.MasterInfo.TelegramID = masterID
-	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(paginationData)
+	paginationData.ViewerTelegramID = userID
+	inlineKeyboard, text := formatter.CreatePaginatedInlineKeyboard(l, paginationData)
 
 	if inlineKeyboard == nil {
 		log.Errorf("Failed to create inline keyboard for pagination data")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
-			Text:      msgErrorWithKeyboard,
+			Text:      msgErrorWithKeyboard(l),
 			ParseMode: models.ParseModeHTML,
 		})
 		return
 	}
 
 	// Добавляем кнопку возврата к выбору времени
-	timeText := "будущих"
-	if timeType == "past" {
-		timeText = "прошедших"
-	}
-	text = fmt.Sprintf("%s<b>Мои слоты (%s)</b>\n\n%s", components.Header(), timeText, text)
+	text = fmt.Sprintf("%s%s\n\n%s", components.Header(), l.T("slots."+poolKey(timeType)+"_title"), text)
 
 	// Добавляем кнопку "К выбору" в клавиатуру
 	if inlineKeyboard.InlineKeyboard != nil {
 		inlineKeyboard.InlineKeyboard = append(inlineKeyboard.InlineKeyboard, []models.InlineKeyboardButton{
-			{Text: "◀️ К выбору", CallbackData: fmt.Sprintf("slots_time/chooser/%d", masterID)},
+			{Text: l.T("pool.back"), CallbackData: slotsTimeCallback("chooser", masterID)},
 		})
 	}
 
@@ -485,11 +512,10 @@ func SendSlotsByTime(ctx context.Context, b *bot.Bot, userID, masterID int64, ti
 
 		// Устанавливаем состояние для нового сообщения
 		sm := message.GetStateManager()
-		sm.RemoveMessageState(userID, userID, "slots_time_chooser")
-		sm.SetMessageState(userID, userID, sent.ID, "slots_time_chooser", map[string]interface{}{
-			"masterID": masterID,
-			"timeType": timeType,
-			"page":     page,
+		sm.SetMessageState(ctx, userID, userID, sent.ID, "slots_time_chooser", state.Context{
+			MasterID: masterID,
+			TimeType: timeType,
+			Page:     page,
 		})
 	}
 }
@@ -542,3 +568,16 @@ func filterSlotsByTime(slots []mymodels.SlotResponse, timeType string) []mymodel
 
 	return filtered
 }
+
+// slotsTimeCallback — кнопка пула слотов мастера (future, past или chooser), первая страница
+func slotsTimeCallback(mode string, masterID int64) string {
+	return callbackdata.Encode(callbackdata.RouteSlotsTime, mode, strconv.FormatInt(masterID, 10), "1")
+}
+
+// poolKey — часть ключа каталога для пула слотов: future или past
+func poolKey(timeType string) string {
+	if timeType == "past" {
+		return "past"
+	}
+	return "future"
+}
@@ -2,11 +2,12 @@ package start
 
 import (
 	"context"
-	"fmt"
 	"strconv"
+	"strings"
 	"telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/handlers/components"
 	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -17,10 +18,6 @@ const (
 	command_start = "/start"
 )
 
-var (
-	msgErrorWithArgument = fmt.Sprintf("%s ⚠️ Ошибка\n<i>Неверный аргумент пользователя!</i>", components.Header())
-)
-
 type Handler struct {
 	service *Service
 }
@@ -46,12 +43,21 @@ func (h *Handler) StartHandlerWithArgument(ctx context.Context, b *bot.Bot, upda
 		return
 	}
 	userID := shared.ExtractUserID(update)
-	masterID, err := strconv.ParseInt(update.Message.Text[len(command_start)+1:], 10, 0)
+	arg := strings.TrimSpace(update.Message.Text[len(command_start)+1:])
+	// Ссылка «Записаться» из inline-режима ведёт сразу на карточку слота
+	if slotID, ok := shared.ParseSlotStartPayload(arg); ok {
+		h.service.logger.WithFields(logrus.Fields{"user_id": userID, "slot_id": slotID}).Info("Handler.Start.StartHandlerWithArgument: showing slot")
+		shared.SendSlotCard(ctx, b, userID, slotID)
+		return
+	}
+	masterID, err := strconv.ParseInt(arg, 10, 0)
 	if err != nil {
 		h.service.logger.WithError(err).WithField("user_id", userID).Warn("Handler.Start.StartHandlerWithArgument: bad argument")
+		l := i18n.ForUser(ctx, userID)
 		b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: userID,
-			Text:   msgErrorWithArgument,
+			ChatID:    userID,
+			ParseMode: models.ParseModeHTML,
+			Text:      components.Error(l, l.T("error.bad_argument")),
 		})
 		return
 	}
@@ -2,11 +2,11 @@ package start
 
 import (
 	"context"
-	"fmt"
 	"strings"
 	"telegram-bot/internal/adapter/backendapi"
 	"telegram-bot/internal/config"
 	"telegram-bot/internal/handlers/components"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -14,24 +14,14 @@ import (
 )
 
 var (
-	cfg          = config.Load()
-	publicSite   = strings.TrimSuffix(cfg.PublicSiteURL, "/")
-	startMessage = fmt.Sprintf(
-		"%s"+
-			"💬 Привет!\n"+
-			"Для дальнейшей работы с ботом, требуется чтобы вы зарегистрировались, нажав кнопку «Подтвердить».\n"+
-			"\n<blockquote><b>Нажав, вы делитесь контактом и подтверждаете:</b>\n"+
-			`• <a href="%s/privacy">Согласие на обработку персональных данных</a>`+
-			"\n"+
-			`• <a href="%s/terms">Согласие с Пользовательским соглашением</a>`+
-			"\n"+
-			"• Что ваш номер будет использоваться для входа и идентификации мастером вас, как пользователя\n</blockquote>"+
-			"\n"+
-			"\nАккаунт можно будет в любое время удалить, на сайте в самом низу вкладки «Профиль»\n"+
-			`<a href="%s/about">Узнать больше о нас</a>`+
-			"\n", components.Header(), publicSite, publicSite, publicSite)
+	cfg        = config.Load()
+	publicSite = strings.TrimSuffix(cfg.PublicSiteURL, "/")
 )
 
+func startMessage(l i18n.Localizer) string {
+	return components.Header() + l.T("start.greeting", publicSite)
+}
+
 type Service struct {
 	bot    *bot.Bot
 	client *backendapi.Client
@@ -47,14 +37,19 @@ func NewService(bot *bot.Bot, logger *logrus.Logger, client *backendapi.Client)
 }
 
 func (s *Service) SendConfirmMsg(ctx context.Context, b *bot.Bot, userID int64) {
-
-	_, exist := s.client.CheckAuth(ctx, userID)
+	l := i18n.ForUser(ctx, userID)
+	exist, err := s.client.CheckAuth(ctx, userID)
+	if err != nil {
+		// Не предлагаем регистрацию заново: API просто не ответило
+		b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:    userID,
+			ParseMode: models.ParseModeHTML,
+			Text:      components.APIError(l, err, ""),
+		})
+		return
+	}
 	if exist {
-		msgText := fmt.Sprintf(
-			"%s"+
-				"<blockquote> ℹ️ Вы уже зарегистрированы!\n\n<b>Повторная регистрация не требуется</b> </blockquote>",
-			components.Header(),
-		)
+		msgText := components.Header() + l.T("start.already_registered")
 		b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID:    userID,
 			ParseMode: models.ParseModeHTML,
@@ -64,12 +59,12 @@ func (s *Service) SendConfirmMsg(ctx context.Context, b *bot.Bot, userID int64)
 	}
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:    userID,
-		Text:      startMessage,
+		Text:      startMessage(l),
 		ParseMode: models.ParseModeHTML,
 		ReplyMarkup: &models.ReplyKeyboardMarkup{
 			Keyboard: [][]models.KeyboardButton{
 				{
-					{Text: "✔️ Подтвердить", RequestContact: true},
+					{Text: l.T("start.confirm_button"), RequestContact: true},
 				},
 			},
 		},
@@ -2,7 +2,9 @@ package timezone
 
 import (
 	"context"
+	"strings"
 	"telegram-bot/internal/handlers/shared"
+	"telegram-bot/internal/i18n"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
@@ -13,25 +15,39 @@ type Handler struct{ logger *logrus.Logger }
 
 func NewHandler(logger *logrus.Logger) *Handler { return &Handler{logger: logger} }
 
-// HandlerTimezone shows timezone selection keyboard
+// HandlerTimezone shows timezone selection keyboard.
+// "/timezone" начинает выбор с регионов, "/timezone город" сразу ищет таймзону.
 func (h *Handler) HandlerTimezone(ctx context.Context, b *bot.Bot, update *models.Update) {
 	if !shared.IsValidPrivateMessage(update) {
 		return
 	}
+	query, ok := commandArgument(update.Message.Text)
+	if !ok {
+		return
+	}
 	chatID := shared.ExtractUserID(update)
 	h.logger.WithField("user_id", chatID).Info("Handler.Timezone: showing timezone selector")
 
-	keyboard := [][]models.InlineKeyboardButton{
-		{{Text: "Москва (Europe/Moscow, +03:00)", CallbackData: "tz/Europe/Moscow"}},
-		{{Text: "Екатеринбург (Asia/Yekaterinburg, +05:00)", CallbackData: "tz/Asia/Yekaterinburg"}},
-		{{Text: "Самара (Europe/Samara, +04:00)", CallbackData: "tz/Europe/Samara"}},
-		{{Text: "Омск (Asia/Omsk, +06:00)", CallbackData: "tz/Asia/Omsk"}},
-		{{Text: "Новосибирск (Asia/Novosibirsk, +07:00)", CallbackData: "tz/Asia/Novosibirsk"}},
+	l := i18n.ForUser(ctx, chatID)
+	text, keyboard := Prompt(l), RegionsKeyboard(l)
+	if query != "" {
+		text, keyboard = SearchPage(l, query, 1)
 	}
 	b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
 		ParseMode:   models.ParseModeHTML,
-		Text:        "При создании слотов, клиенты будут видеть время в вашей таймзоне. Выберите пожалуйста вашу таймзону:",
-		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: keyboard},
+		Text:        text,
+		ReplyMarkup: keyboard,
 	})
 }
+
+// commandArgument возвращает текст после /timezone (или /timezone@bot).
+// ok = false, если это другая команда с тем же префиксом.
+func commandArgument(text string) (arg string, ok bool) {
+	command, arg, _ := strings.Cut(strings.TrimSpace(text), " ")
+	command, _, _ = strings.Cut(command, "@")
+	if command != "/timezone" {
+		return "", false
+	}
+	return strings.TrimSpace(arg), true
+}
@@ -0,0 +1,178 @@
+package timezone
+
+import (
+	"html"
+	"strconv"
+	"telegram-bot/internal/callbackdata"
+	"telegram-bot/internal/i18n"
+	"telegram-bot/internal/zones"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// citiesPerPage — городов на странице выбора, по два в ряд
+	citiesPerPage = 16
+	// utc предлагается отдельной кнопкой: её нет в zone.tab
+	utc = "UTC"
+)
+
+// Prompt — первый шаг выбора таймзоны с текущей таймзоной пользователя
+func Prompt(l i18n.Localizer) string {
+	current := l.T("timezone.unset")
+	if l.Zone() != "" {
+		current = Label(l, l.Zone())
+	}
+	return l.T("timezone.prompt", current)
+}
+
+// RegionsKeyboard — регионы, UTC и определение таймзоны по геопозиции
+// (в /timezone и после регистрации)
+func RegionsKeyboard(l i18n.Localizer) *models.InlineKeyboardMarkup {
+	keyboard := [][]models.InlineKeyboardButton{{{
+		Text:         l.T("timezone.locate_button"),
+		CallbackData: callbackdata.Encode(callbackdata.RouteTimezoneLocate),
+	}}}
+	var row []models.InlineKeyboardButton
+	for _, region := range zones.Regions() {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         regionName(l, region),
+			CallbackData: callbackdata.Encode(callbackdata.RouteTimezoneRegion, region, "1"),
+		})
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	row = append(row, zoneButton(l, utc))
+	keyboard = append(keyboard, row)
+	return &models.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// RegionPage — города региона, страница page
+func RegionPage(l i18n.Localizer, region string, page int) (string, *models.InlineKeyboardMarkup) {
+	text := l.T("timezone.region_prompt", regionName(l, region))
+	return text, citiesKeyboard(l, zones.InRegion(region), page, callbackdata.RouteTimezoneRegion, region)
+}
+
+// SearchPage — таймзоны, найденные по названию города, страница page
+func SearchPage(l i18n.Localizer, query string, page int) (string, *models.InlineKeyboardMarkup) {
+	found := zones.Search(query)
+	if len(found) == 0 {
+		return l.T("timezone.search_empty", html.EscapeString(query)), RegionsKeyboard(l)
+	}
+	text := l.T("timezone.search_prompt", html.EscapeString(query))
+	return text, citiesKeyboard(l, found, page, callbackdata.RouteTimezoneSearch, query)
+}
+
+// LocationRequest — просьба поделиться геопозицией с reply-кнопкой отправки
+func LocationRequest(l i18n.Localizer) (string, *models.ReplyKeyboardMarkup) {
+	return l.T("timezone.locate_prompt"), &models.ReplyKeyboardMarkup{
+		Keyboard:        [][]models.KeyboardButton{{{Text: l.T("timezone.send_location"), RequestLocation: true}}},
+		ResizeKeyboard:  true,
+		OneTimeKeyboard: true,
+	}
+}
+
+// Detected — таймзона, найденная по геопозиции, с подтверждением и ручным выбором
+func Detected(l i18n.Localizer, lat, lon float64) (string, *models.InlineKeyboardMarkup) {
+	zone := zones.Nearest(lat, lon)
+	now := time.Now().In(location(zone.Name)).Format(l.TimeLayout())
+	text := l.T("timezone.detected", cityName(l, zone), zone.Name, now)
+	return text, &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{
+		{{Text: l.T("timezone.confirm_button", cityName(l, zone)), CallbackData: callbackdata.Encode(callbackdata.RouteTimezone, zone.Name)}},
+		{{Text: l.T("timezone.manual_button"), CallbackData: callbackdata.Encode(callbackdata.RouteTimezoneGroups)}},
+	}}
+}
+
+// Label — название таймзоны для сообщений: "Омск (Asia/Omsk, +06:00)"
+func Label(l i18n.Localizer, name string) string {
+	offset := zones.Offset(name, time.Now())
+	z, ok := zones.Lookup(name)
+	if !ok {
+		return l.T("timezone.button", name, offset)
+	}
+	return l.T("timezone.button", cityName(l, z), name+", "+offset)
+}
+
+// citiesKeyboard — зоны list по два в ряд с перелистыванием страниц.
+// Кнопки страниц ведут на route с аргументами {arg}/{page}.
+func citiesKeyboard(l i18n.Localizer, list []zones.Zone, page int, route, arg string) *models.InlineKeyboardMarkup {
+	pages := (len(list) + citiesPerPage - 1) / citiesPerPage
+	if page > pages {
+		page = pages
+	}
+	if page < 1 {
+		page = 1
+	}
+	start := (page - 1) * citiesPerPage
+	end := min(start+citiesPerPage, len(list))
+
+	var keyboard [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+	for _, z := range list[start:end] {
+		row = append(row, zoneButton(l, z.Name))
+		if len(row) == 2 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+
+	if pages > 1 {
+		var nav []models.InlineKeyboardButton
+		if page > 1 {
+			nav = append(nav, models.InlineKeyboardButton{Text: "◀️", CallbackData: callbackdata.Encode(route, arg, strconv.Itoa(page-1))})
+		}
+		nav = append(nav, models.InlineKeyboardButton{
+			Text:         l.T("timezone.page", page, pages),
+			CallbackData: callbackdata.Encode(callbackdata.RouteNoop),
+		})
+		if page < pages {
+			nav = append(nav, models.InlineKeyboardButton{Text: "▶️", CallbackData: callbackdata.Encode(route, arg, strconv.Itoa(page+1))})
+		}
+		keyboard = append(keyboard, nav)
+	}
+	keyboard = append(keyboard, []models.InlineKeyboardButton{{
+		Text:         l.T("timezone.regions_button"),
+		CallbackData: callbackdata.Encode(callbackdata.RouteTimezoneGroups),
+	}})
+	return &models.InlineKeyboardMarkup{InlineKeyboard: keyboard}
+}
+
+// zoneButton — кнопка выбора таймзоны: "Омск (+06:00)"
+func zoneButton(l i18n.Localizer, name string) models.InlineKeyboardButton {
+	title := name
+	if z, ok := zones.Lookup(name); ok {
+		title = cityName(l, z)
+	}
+	return models.InlineKeyboardButton{
+		Text:         l.T("timezone.button", title, zones.Offset(name, time.Now())),
+		CallbackData: callbackdata.Encode(callbackdata.RouteTimezone, name),
+	}
+}
+
+// cityName — переведённое название города, если оно есть в каталоге, иначе из zone.tab
+func cityName(l i18n.Localizer, z zones.Zone) string {
+	if key := "timezone.city." + z.Name; l.Has(key) {
+		return l.T(key)
+	}
+	return z.City
+}
+
+func regionName(l i18n.Localizer, region string) string {
+	if key := "timezone.region." + region; l.Has(key) {
+		return l.T(key)
+	}
+	return region
+}
+
+func location(name string) *time.Location {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc
+	}
+	return time.UTC
+}
@@ -0,0 +1,51 @@
+package i18n
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed locales/*.json
+var locales embed.FS
+
+// message — строка каталога. Обычные сообщения — просто текст, сообщения
+// с числом — объект с формами множественного числа ({"one": ..., "many": ...}).
+type message struct {
+	text  string
+	forms map[string]string
+}
+
+func (m *message) UnmarshalJSON(raw []byte) error {
+	if err := json.Unmarshal(raw, &m.text); err == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, &m.forms)
+}
+
+var catalogs = mustLoadCatalogs()
+
+func mustLoadCatalogs() map[Lang]map[string]message {
+	out := make(map[Lang]map[string]message, len(Supported))
+	for _, lang := range Supported {
+		raw, err := locales.ReadFile(fmt.Sprintf("locales/%s.json", lang))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: catalog %s: %v", lang, err))
+		}
+		var msgs map[string]message
+		if err := json.Unmarshal(raw, &msgs); err != nil {
+			panic(fmt.Sprintf("i18n: catalog %s: %v", lang, err))
+		}
+		out[lang] = msgs
+	}
+	return out
+}
+
+// lookup ищет сообщение в каталоге языка, затем в каталоге Default
+func lookup(lang Lang, key string) (message, bool) {
+	if m, ok := catalogs[lang][key]; ok {
+		return m, true
+	}
+	m, ok := catalogs[Default][key]
+	return m, ok
+}
@@ -0,0 +1,137 @@
+package i18n
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for key, m := range catalogs[Default] {
+		for _, lang := range Supported {
+			other, ok := catalogs[lang][key]
+			if !ok {
+				t.Errorf("%s: missing key %q", lang, key)
+				continue
+			}
+			if (m.forms == nil) != (other.forms == nil) {
+				t.Errorf("%s: key %q plural mismatch", lang, key)
+			}
+		}
+	}
+	for _, lang := range Supported {
+		if len(catalogs[lang]) != len(catalogs[Default]) {
+			t.Errorf("%s: %d keys, want %d", lang, len(catalogs[lang]), len(catalogs[Default]))
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	ru, en := For(RU), For(EN)
+	for n, want := range map[int]string{1: "1 запись", 3: "3 записи", 5: "5 записей", 11: "11 записей", 21: "21 запись", 104: "104 записи"} {
+		if got := ru.N("records.count", n); got != want {
+			t.Errorf("ru N(%d) = %q, want %q", n, got, want)
+		}
+	}
+	if got := en.N("records.count", 1); got != "1 booking" {
+		t.Errorf("en N(1) = %q", got)
+	}
+	if got := en.N("records.count", 2); got != "2 bookings" {
+		t.Errorf("en N(2) = %q", got)
+	}
+	if got := en.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("missing key = %q", got)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	if got := For(RU).Money(150050, "RUB"); got != "1\u00a0500,50\u00a0₽" {
+		t.Errorf("ru Money = %q", got)
+	}
+	if got := For(EN).Money(150000, "USD"); got != "$1,500" {
+		t.Errorf("en Money = %q", got)
+	}
+}
+
+func TestParse(t *testing.T) {
+	for code, want := range map[string]Lang{"en": EN, "en-US": EN, "RU": RU, "de": Default, "": Default} {
+		if got, _ := Parse(code); got != want {
+			t.Errorf("Parse(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
+
+func TestResolver(t *testing.T) {
+	ctx := context.Background()
+	stored := map[int64]Profile{1: {Language: "en", Timezone: "Asia/Omsk"}, 2: {}}
+	calls := 0
+	var failing bool
+	r := NewResolver(func(_ context.Context, id int64) (Profile, error) {
+		calls++
+		if failing {
+			return Profile{}, errors.New("api down")
+		}
+		return stored[id], nil
+	})
+
+	if got := r.Resolve(ctx, 1); got.Lang() != EN || got.Zone() != "Asia/Omsk" {
+		t.Fatalf("stored profile = %q %q, want en Asia/Omsk", got.Lang(), got.Zone())
+	}
+	r.Resolve(ctx, 1)
+	if calls != 1 {
+		t.Fatalf("loader calls = %d, want cached", calls)
+	}
+
+	r.Hint(2, "en-GB")
+	if got := r.Resolve(ctx, 2).Lang(); got != EN {
+		t.Fatalf("telegram hint = %q, want en", got)
+	}
+	if got := r.Resolve(ctx, 3).Lang(); got != Default {
+		t.Fatalf("no language = %q, want default", got)
+	}
+
+	r.Remember(1, RU)
+	r.RememberZone(1, "Europe/Samara")
+	if got := r.Resolve(ctx, 1); got.Lang() != RU || got.Zone() != "Europe/Samara" {
+		t.Fatalf("remembered profile = %q %q, want ru Europe/Samara", got.Lang(), got.Zone())
+	}
+
+	// Ошибка API не кэшируется: следующий запрос снова спрашивает API
+	failing = true
+	r.now = func() time.Time { return time.Now().Add(2 * CacheTTL) }
+	before := calls
+	r.Resolve(ctx, 1)
+	r.Resolve(ctx, 1)
+	if calls-before != 2 {
+		t.Fatalf("loader calls after error = %d, want 2", calls-before)
+	}
+}
+
+func TestResolverCachesAreBounded(t *testing.T) {
+	now := time.Now()
+	r := NewResolver(nil).WithLimit(3)
+	r.now = func() time.Time { return now }
+
+	r.Hint(1, "en")
+	now = now.Add(2 * CacheTTL)
+	for id := int64(2); id <= 4; id++ {
+		r.Hint(id, "en")
+	}
+	if len(r.hints) != 3 {
+		t.Fatalf("hints = %d, want 3", len(r.hints))
+	}
+	// Первой вытесняется устаревшая подсказка, свежие остаются
+	if _, ok := r.hints[1]; ok {
+		t.Fatal("expired hint kept")
+	}
+	for id := int64(5); id <= 20; id++ {
+		r.Hint(id, "en")
+	}
+	if len(r.hints) != 3 {
+		t.Fatalf("hints = %d, want 3", len(r.hints))
+	}
+	if got := r.Resolve(context.Background(), 20).Lang(); got != EN {
+		t.Fatalf("latest hint = %q, want en", got)
+	}
+}
@@ -0,0 +1,32 @@
+package i18n
+
+import "strings"
+
+// Lang — язык интерфейса бота (код ISO 639-1)
+type Lang string
+
+const (
+	RU Lang = "ru"
+	EN Lang = "en"
+
+	// Default — язык, если пользователь его не выбрал и Telegram не прислал language_code
+	Default = RU
+)
+
+// Supported — языки, для которых есть каталог сообщений, в порядке показа в /language
+var Supported = []Lang{RU, EN}
+
+// Parse приводит код языка Telegram ("en", "en-US", "pt-br") к поддерживаемому.
+// Второе значение false, если язык не поддерживается — тогда возвращается Default.
+func Parse(code string) (Lang, bool) {
+	code = strings.ToLower(strings.TrimSpace(code))
+	if base, _, found := strings.Cut(code, "-"); found {
+		code = base
+	}
+	for _, l := range Supported {
+		if string(l) == code {
+			return l, true
+		}
+	}
+	return Default, false
+}
@@ -0,0 +1,87 @@
+package i18n
+
+import (
+	"contract/money"
+	"fmt"
+)
+
+// Localizer форматирует сообщения на одном языке и знает таймзону получателя
+type Localizer struct {
+	lang Lang
+	zone string
+}
+
+// For возвращает локализатор языка lang; неподдерживаемый язык заменяется на Default
+func For(lang Lang) Localizer {
+	if _, ok := catalogs[lang]; !ok {
+		lang = Default
+	}
+	return Localizer{lang: lang}
+}
+
+// WithZone возвращает локализатор с таймзоной получателя (IANA)
+func (l Localizer) WithZone(zone string) Localizer {
+	l.zone = zone
+	return l
+}
+
+// Zone — таймзона получателя; "" — неизвестна
+func (l Localizer) Zone() string { return l.zone }
+
+func (l Localizer) Lang() Lang {
+	if l.lang == "" {
+		return Default
+	}
+	return l.lang
+}
+
+// Has сообщает, что в каталоге есть сообщение key
+func (l Localizer) Has(key string) bool {
+	_, ok := lookup(l.Lang(), key)
+	return ok
+}
+
+// T возвращает сообщение key, подставляя args через fmt.Sprintf.
+// Отсутствующий ключ возвращается как есть, чтобы пропуск был заметен в чате.
+func (l Localizer) T(key string, args ...any) string {
+	m, ok := lookup(l.Lang(), key)
+	if !ok {
+		return key
+	}
+	text := m.text
+	if m.forms != nil {
+		text = m.forms[formOther]
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// N возвращает форму сообщения key для числа n. Число подставляется первым
+// аргументом: "%d записей" или "%[1]d records".
+func (l Localizer) N(key string, n int, args ...any) string {
+	m, ok := lookup(l.Lang(), key)
+	if !ok {
+		return key
+	}
+	text := m.text
+	if m.forms != nil {
+		text = m.forms[pluralForm(l.Lang(), n)]
+		if text == "" {
+			text = m.forms[formOther]
+		}
+	}
+	return fmt.Sprintf(text, append([]any{n}, args...)...)
+}
+
+// DateLayout — формат даты языка для time.Format
+func (l Localizer) DateLayout() string { return l.T("format.date") }
+
+// TimeLayout — формат времени языка для time.Format
+func (l Localizer) TimeLayout() string { return l.T("format.time") }
+
+// Money — сумма в минимальных единицах валюты currency по правилам языка: «1 500 ₽», «₽1,500»
+func (l Localizer) Money(amount int64, currency string) string {
+	return money.Format(amount, currency, string(l.Lang()))
+}
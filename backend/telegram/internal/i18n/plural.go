@@ -0,0 +1,33 @@
+package i18n
+
+// Формы множественного числа по правилам CLDR для поддерживаемых языков
+const (
+	formOne   = "one"
+	formFew   = "few"
+	formMany  = "many"
+	formOther = "other"
+)
+
+// pluralForm выбирает форму для числа n: ru — one/few/many, en — one/other
+func pluralForm(lang Lang, n int) string {
+	if n < 0 {
+		n = -n
+	}
+	switch lang {
+	case RU:
+		mod10, mod100 := n%10, n%100
+		switch {
+		case mod10 == 1 && mod100 != 11:
+			return formOne
+		case mod10 >= 2 && mod10 <= 4 && (mod100 < 12 || mod100 > 14):
+			return formFew
+		default:
+			return formMany
+		}
+	default:
+		if n == 1 {
+			return formOne
+		}
+		return formOther
+	}
+}
@@ -0,0 +1,201 @@
+package i18n
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CacheTTL — сколько бот помнит язык и таймзону пользователя, не спрашивая API
+const CacheTTL = time.Hour
+
+// CacheLimit — сколько пользователей резолвер держит в памяти; при переполнении
+// сначала вытесняются устаревшие записи, затем произвольные
+const CacheLimit = 10000
+
+// Profile — настройки пользователя из API, влияющие на тексты бота
+type Profile struct {
+	Language string // "" — язык не выбран
+	Timezone string // IANA; "" — неизвестна
+}
+
+// Loader возвращает настройки пользователя из API.
+// Незарегистрированный пользователь — не ошибка: Loader возвращает пустой Profile.
+type Loader func(ctx context.Context, telegramID int64) (Profile, error)
+
+type cachedProfile struct {
+	stored    Lang // "" — пользователь язык не выбирал
+	zone      string
+	expiresAt time.Time
+}
+
+type cachedHint struct {
+	lang      Lang
+	expiresAt time.Time
+}
+
+// Resolver определяет язык пользователя: выбранный через /language и сохранённый в API,
+// иначе language_code из Telegram, иначе Default. Вместе с языком запоминает таймзону.
+type Resolver struct {
+	mu    sync.Mutex
+	load  Loader
+	ttl   time.Duration
+	limit int
+	now   func() time.Time
+	cache map[int64]cachedProfile
+	hints map[int64]cachedHint
+}
+
+func NewResolver(load Loader) *Resolver {
+	return &Resolver{
+		load:  load,
+		ttl:   CacheTTL,
+		limit: CacheLimit,
+		now:   time.Now,
+		cache: make(map[int64]cachedProfile),
+		hints: make(map[int64]cachedHint),
+	}
+}
+
+// WithTTL задаёт время жизни кэша
+func (r *Resolver) WithTTL(ttl time.Duration) *Resolver {
+	r.ttl = ttl
+	return r
+}
+
+// WithLimit задаёт, сколько пользователей хранится в кэше профилей и подсказок
+func (r *Resolver) WithLimit(limit int) *Resolver {
+	r.limit = limit
+	return r
+}
+
+// Resolve возвращает локализатор пользователя. Ошибка API не кэшируется:
+// до следующего успешного ответа используется подсказка Telegram, таймзона неизвестна.
+func (r *Resolver) Resolve(ctx context.Context, telegramID int64) Localizer {
+	r.mu.Lock()
+	entry, ok := r.cache[telegramID]
+	var hint Lang
+	if h, found := r.hints[telegramID]; found && r.now().Before(h.expiresAt) {
+		hint = h.lang
+	}
+	r.mu.Unlock()
+
+	if !ok || !r.now().Before(entry.expiresAt) {
+		if r.load == nil {
+			return For(orDefault(hint))
+		}
+		profile, err := r.load(ctx, telegramID)
+		if err != nil {
+			return For(orDefault(hint))
+		}
+		entry = cachedProfile{zone: profile.Timezone, expiresAt: r.now().Add(r.ttl)}
+		if lang, supported := Parse(profile.Language); supported {
+			entry.stored = lang
+		}
+		r.mu.Lock()
+		store(r.cache, telegramID, entry, r.limit, func(e cachedProfile) bool {
+			return !r.now().Before(e.expiresAt)
+		})
+		r.mu.Unlock()
+	}
+	lang := entry.stored
+	if lang == "" {
+		lang = orDefault(hint)
+	}
+	return For(lang).WithZone(entry.zone)
+}
+
+// Remember запоминает язык, только что сохранённый в API
+func (r *Resolver) Remember(telegramID int64, lang Lang) {
+	r.update(telegramID, func(e *cachedProfile) { e.stored = lang })
+}
+
+// RememberZone запоминает таймзону, только что сохранённую в API
+func (r *Resolver) RememberZone(telegramID int64, zone string) {
+	r.update(telegramID, func(e *cachedProfile) { e.zone = zone })
+}
+
+// update меняет закэшированный профиль. Если профиля в кэше нет, менять нечего:
+// при следующем обращении он загрузится из API уже с новым значением.
+func (r *Resolver) update(telegramID int64, change func(*cachedProfile)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.cache[telegramID]
+	if !ok {
+		return
+	}
+	change(&entry)
+	r.cache[telegramID] = entry
+}
+
+// Hint запоминает language_code из Telegram; неподдерживаемые языки игнорируются
+func (r *Resolver) Hint(telegramID int64, code string) {
+	lang, supported := Parse(code)
+	if !supported {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	store(r.hints, telegramID, cachedHint{lang: lang, expiresAt: r.now().Add(r.ttl)}, r.limit, func(h cachedHint) bool {
+		return !r.now().Before(h.expiresAt)
+	})
+}
+
+// store кладёт значение в m, не давая ему вырасти больше limit: перед добавлением
+// нового ключа удаляются устаревшие записи, а если их не хватило — произвольные
+func store[V any](m map[int64]V, key int64, value V, limit int, expired func(V) bool) {
+	if _, ok := m[key]; !ok && limit > 0 && len(m) >= limit {
+		for k, v := range m {
+			if expired(v) {
+				delete(m, k)
+			}
+		}
+		for k := range m {
+			if len(m) < limit {
+				break
+			}
+			delete(m, k)
+		}
+	}
+	m[key] = value
+}
+
+func orDefault(lang Lang) Lang {
+	if lang == "" {
+		return Default
+	}
+	return lang
+}
+
+var (
+	resolverMu      sync.RWMutex
+	defaultResolver = NewResolver(nil)
+)
+
+// SetResolver задаёт резолвер, которым пользуются ForUser, Remember и Hint
+// (вызывается при старте бота)
+func SetResolver(r *Resolver) {
+	resolverMu.Lock()
+	defer resolverMu.Unlock()
+	defaultResolver = r
+}
+
+func resolver() *Resolver {
+	resolverMu.RLock()
+	defer resolverMu.RUnlock()
+	return defaultResolver
+}
+
+// ForUser возвращает локализатор на языке и в таймзоне пользователя
+func ForUser(ctx context.Context, telegramID int64) Localizer {
+	return resolver().Resolve(ctx, telegramID)
+}
+
+// Remember запоминает выбранный пользователем язык в резолвере по умолчанию
+func Remember(telegramID int64, lang Lang) { resolver().Remember(telegramID, lang) }
+
+// RememberZone запоминает выбранную пользователем таймзону в резолвере по умолчанию
+func RememberZone(telegramID int64, zone string) { resolver().RememberZone(telegramID, zone) }
+
+// Hint передаёт резолверу по умолчанию language_code из Telegram
+func Hint(telegramID int64, code string) { resolver().Hint(telegramID, code) }
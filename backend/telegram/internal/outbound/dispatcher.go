@@ -0,0 +1,437 @@
+// Package outbound — очередь исходящих сообщений бота с учётом лимитов Telegram:
+// не больше 30 сообщений в секунду на бота и одного в секунду на чат.
+// Ответ 429 откладывает чат на retry_after, временные ошибки повторяются с backoff,
+// итог доставки сообщается обратно в API (outbox).
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+// Priority — порядок обслуживания очереди: сначала High, затем Normal, затем Low
+type Priority int
+
+const (
+	PriorityLow    Priority = iota // рассылки
+	PriorityNormal                 // уведомления о записях
+	PriorityHigh                   // коды, подтверждение входа и удаления аккаунта
+)
+
+const priorities = 3
+
+// Статусы, которые уходят в API
+const (
+	StatusSent   = "sent"
+	StatusFailed = "failed"
+)
+
+var (
+	ErrQueueFull = errors.New("outbound: queue is full")
+	ErrClosed    = errors.New("outbound: dispatcher is shut down")
+)
+
+// Message — сообщение в очереди: текст (Params) или место на карте (Venue).
+// DeliveryID — id записи outbox в API; пустой DeliveryID означает, что об итоге сообщать не нужно.
+type Message struct {
+	Params     *bot.SendMessageParams
+	Venue      *bot.SendVenueParams
+	Priority   Priority
+	DeliveryID string
+}
+
+// Result — итог доставки сообщения
+type Result struct {
+	DeliveryID string
+	Status     string
+	Attempts   int
+	MessageID  int
+	Err        error
+}
+
+// SendFunc отправляет сообщение (обычно (*bot.Bot).SendMessage)
+type SendFunc func(ctx context.Context, params *bot.SendMessageParams) (*models.Message, error)
+
+// VenueFunc отправляет место на карте (обычно (*bot.Bot).SendVenue)
+type VenueFunc func(ctx context.Context, params *bot.SendVenueParams) (*models.Message, error)
+
+// ReportFunc сообщает итог доставки в API
+type ReportFunc func(ctx context.Context, r Result) error
+
+// Config — лимиты и параметры повторов
+type Config struct {
+	GlobalRate  float64       // сообщений в секунду на бота
+	ChatRate    float64       // сообщений в секунду на чат
+	MaxAttempts int           // попыток при временных ошибках (429 не считается)
+	QueueSize   int           // максимум сообщений в очереди
+	BaseBackoff time.Duration // пауза перед первым повтором, далее удваивается
+	MaxBackoff  time.Duration
+	SendTimeout time.Duration
+}
+
+// DefaultConfig — лимиты из документации Telegram Bot API
+func DefaultConfig() Config {
+	return Config{
+		GlobalRate:  30,
+		ChatRate:    1,
+		MaxAttempts: 5,
+		QueueSize:   10000,
+		BaseBackoff: time.Second,
+		MaxBackoff:  time.Minute,
+		SendTimeout: 15 * time.Second,
+	}
+}
+
+type item struct {
+	msg       Message
+	chat      string
+	attempts  int
+	failures  int
+	notBefore time.Time
+}
+
+type Dispatcher struct {
+	send   SendFunc
+	venue  VenueFunc
+	report ReportFunc
+	cfg    Config
+	logger *logrus.Logger
+	name   string
+	now    func() time.Time
+
+	mu     sync.Mutex
+	queues [priorities][]*item
+	size   int
+	global *tokenBucket
+	chats  map[string]*tokenBucket
+	closed bool
+
+	wake    chan struct{}
+	results chan Result
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func New(send SendFunc, cfg Config, logger *logrus.Logger, name string) *Dispatcher {
+	now := time.Now
+	return &Dispatcher{
+		send:    send,
+		cfg:     cfg,
+		logger:  logger,
+		name:    name,
+		now:     now,
+		global:  newTokenBucket(cfg.GlobalRate, int(cfg.GlobalRate), now()),
+		chats:   make(map[string]*tokenBucket),
+		wake:    make(chan struct{}, 1),
+		results: make(chan Result, 1024),
+		done:    make(chan struct{}),
+	}
+}
+
+// WithReporter включает отчёты о доставке в API
+func (d *Dispatcher) WithReporter(report ReportFunc) *Dispatcher {
+	d.report = report
+	return d
+}
+
+// WithVenueSender включает отправку мест (Message.Venue)
+func (d *Dispatcher) WithVenueSender(venue VenueFunc) *Dispatcher {
+	d.venue = venue
+	return d
+}
+
+// Enqueue ставит сообщение в очередь. Ошибка означает, что сообщение не принято
+// (очередь переполнена или идёт остановка) и вызывающему стоит повторить позже.
+func (d *Dispatcher) Enqueue(msg Message) error {
+	var chat any
+	switch {
+	case msg.Params != nil:
+		chat = msg.Params.ChatID
+	case msg.Venue != nil && d.venue == nil:
+		return errors.New("outbound: venue sender is not configured")
+	case msg.Venue != nil:
+		chat = msg.Venue.ChatID
+	default:
+		return errors.New("outbound: message without params")
+	}
+	if msg.Priority < PriorityLow || msg.Priority > PriorityHigh {
+		msg.Priority = PriorityNormal
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return ErrClosed
+	}
+	if d.size >= d.cfg.QueueSize {
+		return ErrQueueFull
+	}
+	it := &item{msg: msg, chat: fmt.Sprint(chat)}
+	d.queues[msg.Priority] = append(d.queues[msg.Priority], it)
+	d.size++
+	d.signal()
+	return nil
+}
+
+// Len — число сообщений в очереди
+func (d *Dispatcher) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.size
+}
+
+// Start запускает обработку очереди в фоне
+func (d *Dispatcher) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	d.mu.Lock()
+	d.cancel = cancel
+	d.mu.Unlock()
+
+	var reporters sync.WaitGroup
+	reporters.Add(1)
+	go func() {
+		defer reporters.Done()
+		for r := range d.results {
+			d.deliverReport(r)
+		}
+	}()
+	go func() {
+		d.run(ctx)
+		close(d.results)
+		reporters.Wait()
+		close(d.done)
+	}()
+}
+
+// Shutdown перестаёт принимать сообщения и дожидается, пока очередь опустеет.
+// Если ctx истёк раньше, оставшиеся сообщения отмечаются как недоставленные.
+func (d *Dispatcher) Shutdown(ctx context.Context) error {
+	d.mu.Lock()
+	d.closed = true
+	d.signal()
+	cancel := d.cancel
+	d.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+	}
+	cancel()
+	<-d.done
+	return ctx.Err()
+}
+
+func (d *Dispatcher) Name() string { return d.name }
+
+func (d *Dispatcher) signal() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (d *Dispatcher) run(ctx context.Context) {
+	timer := time.NewTimer(time.Hour)
+	defer timer.Stop()
+	for {
+		it, wait, drained := d.next()
+		if drained {
+			return
+		}
+		if it != nil {
+			d.deliver(ctx, it)
+			continue
+		}
+
+		var tick <-chan time.Time
+		if wait > 0 {
+			timer.Reset(wait)
+			tick = timer.C
+		}
+		select {
+		case <-d.wake:
+		case <-tick:
+		case <-ctx.Done():
+			d.abandon(ctx.Err())
+			return
+		}
+		timer.Stop()
+	}
+}
+
+// next выбирает следующее сообщение: по приоритету, в порядке постановки,
+// пропуская чаты, исчерпавшие лимит. Если отправлять пока нечего, возвращает
+// время ожидания (0 — ждать новых сообщений).
+func (d *Dispatcher) next() (*item, time.Duration, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.size == 0 {
+		return nil, 0, d.closed
+	}
+	now := d.now()
+	if wait := d.global.wait(now); wait > 0 {
+		return nil, wait, false
+	}
+
+	var minWait time.Duration
+	later := func(w time.Duration) {
+		if minWait == 0 || w < minWait {
+			minWait = w
+		}
+	}
+	// Чат, у которого первое сообщение ещё не готово, пропускаем целиком,
+	// чтобы не нарушить порядок сообщений внутри чата
+	blocked := make(map[string]bool)
+	for p := PriorityHigh; p >= PriorityLow; p-- {
+		for i, it := range d.queues[p] {
+			if blocked[it.chat] {
+				continue
+			}
+			if it.notBefore.After(now) {
+				blocked[it.chat] = true
+				later(it.notBefore.Sub(now))
+				continue
+			}
+			bucket := d.chatBucket(it.chat, now)
+			if wait := bucket.wait(now); wait > 0 {
+				blocked[it.chat] = true
+				later(wait)
+				continue
+			}
+			d.queues[p] = append(d.queues[p][:i], d.queues[p][i+1:]...)
+			d.size--
+			d.global.take(now)
+			bucket.take(now)
+			d.forgetIdleChats(now)
+			return it, 0, false
+		}
+	}
+	return nil, minWait, false
+}
+
+func (d *Dispatcher) chatBucket(chat string, now time.Time) *tokenBucket {
+	b, ok := d.chats[chat]
+	if !ok {
+		b = newTokenBucket(d.cfg.ChatRate, 1, now)
+		d.chats[chat] = b
+	}
+	return b
+}
+
+// forgetIdleChats не даёт карте лимитов расти бесконечно
+func (d *Dispatcher) forgetIdleChats(now time.Time) {
+	if len(d.chats) < 4096 {
+		return
+	}
+	for chat, b := range d.chats {
+		if b.full(now) {
+			delete(d.chats, chat)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, it *item) {
+	it.attempts++
+	sendCtx, cancel := context.WithTimeout(ctx, d.cfg.SendTimeout)
+	var msg *models.Message
+	var err error
+	if it.msg.Params != nil {
+		msg, err = d.send(sendCtx, it.msg.Params)
+	} else {
+		msg, err = d.venue(sendCtx, it.msg.Venue)
+	}
+	cancel()
+
+	var tooMany *bot.TooManyRequestsError
+	switch {
+	case err == nil:
+		d.finish(it, StatusSent, msg.ID, nil)
+	case errors.As(err, &tooMany):
+		retry := time.Duration(tooMany.RetryAfter) * time.Second
+		if retry <= 0 {
+			retry = time.Second
+		}
+		d.logger.Warnf("Outbound: chat %s rate limited, retry after %s", it.chat, retry)
+		d.retry(it, retry)
+	case permanent(err):
+		d.finish(it, StatusFailed, 0, err)
+	case ctx.Err() != nil:
+		d.finish(it, StatusFailed, 0, ctx.Err())
+	default:
+		it.failures++
+		if it.failures >= d.cfg.MaxAttempts {
+			d.finish(it, StatusFailed, 0, err)
+			return
+		}
+		backoff := d.cfg.BaseBackoff << (it.failures - 1)
+		if backoff > d.cfg.MaxBackoff {
+			backoff = d.cfg.MaxBackoff
+		}
+		d.logger.Warnf("Outbound: send to chat %s failed (attempt %d): %v", it.chat, it.attempts, err)
+		d.retry(it, backoff)
+	}
+}
+
+// permanent — ошибки, которые не исправятся повтором (бот заблокирован, неверный запрос)
+func permanent(err error) bool {
+	return errors.Is(err, bot.ErrorForbidden) ||
+		errors.Is(err, bot.ErrorBadRequest) ||
+		errors.Is(err, bot.ErrorUnauthorized) ||
+		errors.Is(err, bot.ErrorNotFound) ||
+		bot.IsMigrateError(err)
+}
+
+// retry возвращает сообщение в начало его очереди, чтобы сохранить порядок в чате
+func (d *Dispatcher) retry(it *item, after time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	it.notBefore = d.now().Add(after)
+	p := it.msg.Priority
+	d.queues[p] = append([]*item{it}, d.queues[p]...)
+	d.size++
+	d.signal()
+}
+
+func (d *Dispatcher) finish(it *item, status string, messageID int, err error) {
+	if err != nil {
+		d.logger.Errorf("Outbound: message to chat %s dropped after %d attempt(s): %v", it.chat, it.attempts, err)
+	}
+	if d.report == nil || it.msg.DeliveryID == "" {
+		return
+	}
+	d.results <- Result{DeliveryID: it.msg.DeliveryID, Status: status, Attempts: it.attempts, MessageID: messageID, Err: err}
+}
+
+// abandon отмечает недоставленными сообщения, оставшиеся в очереди при остановке
+func (d *Dispatcher) abandon(err error) {
+	d.mu.Lock()
+	var left []*item
+	for p := range d.queues {
+		left = append(left, d.queues[p]...)
+		d.queues[p] = nil
+	}
+	d.size = 0
+	d.mu.Unlock()
+
+	for _, it := range left {
+		d.finish(it, StatusFailed, 0, fmt.Errorf("not sent before shutdown: %w", err))
+	}
+}
+
+func (d *Dispatcher) deliverReport(r Result) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := d.report(ctx, r); err != nil {
+		d.logger.Warnf("Outbound: report delivery %s (%s): %v", r.DeliveryID, r.Status, err)
+	}
+}
@@ -0,0 +1,208 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTelegram записывает отправленные сообщения; fail позволяет вернуть ошибку на i-й вызов
+type fakeTelegram struct {
+	mu    sync.Mutex
+	sent  []string
+	times []time.Time
+	calls int
+	fail  func(call int, text string) error
+}
+
+func (f *fakeTelegram) send(_ context.Context, p *bot.SendMessageParams) (*models.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.fail != nil {
+		if err := f.fail(f.calls, p.Text); err != nil {
+			return nil, err
+		}
+	}
+	f.sent = append(f.sent, p.Text)
+	f.times = append(f.times, time.Now())
+	return &models.Message{ID: f.calls}, nil
+}
+
+type reports struct {
+	mu  sync.Mutex
+	got map[string]Result
+}
+
+func (r *reports) report(_ context.Context, res Result) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.got[res.DeliveryID] = res
+	return nil
+}
+
+func testConfig() Config {
+	cfg := DefaultConfig()
+	cfg.GlobalRate = 1000
+	cfg.ChatRate = 1000
+	cfg.BaseBackoff = 10 * time.Millisecond
+	cfg.MaxAttempts = 3
+	return cfg
+}
+
+func newTestDispatcher(cfg Config, tg *fakeTelegram) (*Dispatcher, *reports) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	rep := &reports{got: map[string]Result{}}
+	return New(tg.send, cfg, logger, "outbound").WithReporter(rep.report), rep
+}
+
+func msg(chat int64, text string, p Priority) Message {
+	return Message{Params: &bot.SendMessageParams{ChatID: chat, Text: text}, Priority: p, DeliveryID: text}
+}
+
+func shutdown(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+}
+
+func TestPriorityOrder(t *testing.T) {
+	tg := &fakeTelegram{}
+	d, _ := newTestDispatcher(testConfig(), tg)
+	for i, m := range []Message{msg(1, "low", PriorityLow), msg(2, "normal", PriorityNormal), msg(3, "high", PriorityHigh)} {
+		if err := d.Enqueue(m); err != nil {
+			t.Fatalf("Enqueue(%d) error = %v", i, err)
+		}
+	}
+	d.Start()
+	shutdown(t, d)
+
+	if got := fmt.Sprint(tg.sent); got != "[high normal low]" {
+		t.Fatalf("sent = %s", got)
+	}
+}
+
+func TestPerChatLimit(t *testing.T) {
+	cfg := testConfig()
+	cfg.ChatRate = 10
+	tg := &fakeTelegram{}
+	d, _ := newTestDispatcher(cfg, tg)
+	for _, m := range []Message{msg(1, "a1", PriorityNormal), msg(1, "a2", PriorityNormal), msg(2, "b1", PriorityNormal), msg(1, "a3", PriorityNormal)} {
+		_ = d.Enqueue(m)
+	}
+	d.Start()
+	shutdown(t, d)
+
+	// Другой чат не ждёт лимита первого, порядок внутри чата сохранён
+	if got := fmt.Sprint(tg.sent); got != "[a1 b1 a2 a3]" {
+		t.Fatalf("sent = %s", got)
+	}
+	if gap := tg.times[3].Sub(tg.times[2]); gap < 80*time.Millisecond {
+		t.Fatalf("messages to one chat sent %s apart, want ~100ms", gap)
+	}
+}
+
+func TestRetryAfterAndRetries(t *testing.T) {
+	tg := &fakeTelegram{fail: func(call int, text string) error {
+		switch {
+		case text == "throttled" && call == 1:
+			return &bot.TooManyRequestsError{Message: "too many requests", RetryAfter: 1}
+		case text == "flaky":
+			return errors.New("connection reset")
+		case text == "blocked":
+			return fmt.Errorf("%w, bot was blocked by the user", bot.ErrorForbidden)
+		}
+		return nil
+	}}
+	d, rep := newTestDispatcher(testConfig(), tg)
+	start := time.Now()
+	for _, m := range []Message{msg(1, "throttled", PriorityNormal), msg(2, "flaky", PriorityNormal), msg(3, "blocked", PriorityNormal)} {
+		_ = d.Enqueue(m)
+	}
+	d.Start()
+	shutdown(t, d)
+
+	want := map[string]struct {
+		status   string
+		attempts int
+	}{
+		"throttled": {StatusSent, 2},
+		"flaky":     {StatusFailed, 3},
+		"blocked":   {StatusFailed, 1},
+	}
+	for id, w := range want {
+		got := rep.got[id]
+		if got.Status != w.status || got.Attempts != w.attempts {
+			t.Fatalf("%s: report = %+v, want status=%s attempts=%d", id, got, w.status, w.attempts)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Fatalf("retry_after not respected: finished in %s", elapsed)
+	}
+}
+
+func TestEnqueueLimits(t *testing.T) {
+	cfg := testConfig()
+	cfg.QueueSize = 1
+	d, rep := newTestDispatcher(cfg, &fakeTelegram{})
+	if err := d.Enqueue(msg(1, "first", PriorityNormal)); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.Enqueue(msg(1, "second", PriorityNormal)); !errors.Is(err, ErrQueueFull) {
+		t.Fatalf("Enqueue() on full queue error = %v", err)
+	}
+	d.Start()
+	shutdown(t, d)
+	if err := d.Enqueue(msg(1, "late", PriorityNormal)); !errors.Is(err, ErrClosed) {
+		t.Fatalf("Enqueue() after Shutdown error = %v", err)
+	}
+	if rep.got["first"].Status != StatusSent {
+		t.Fatalf("queued message not drained on shutdown: %+v", rep.got)
+	}
+}
+
+func (f *fakeTelegram) sendVenue(_ context.Context, p *bot.SendVenueParams) (*models.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	f.sent = append(f.sent, "venue:"+p.Title)
+	f.times = append(f.times, time.Now())
+	return &models.Message{ID: f.calls}, nil
+}
+
+func TestVenueFollowsText(t *testing.T) {
+	tg := &fakeTelegram{}
+	d, rep := newTestDispatcher(testConfig(), tg)
+	venue := Message{Venue: &bot.SendVenueParams{ChatID: int64(1), Title: "Салон"}, Priority: PriorityNormal, DeliveryID: "venue"}
+	if err := d.Enqueue(venue); err == nil {
+		t.Fatal("Enqueue() of a venue without venue sender should fail")
+	}
+
+	d.WithVenueSender(tg.sendVenue)
+	for _, m := range []Message{msg(1, "confirmed", PriorityNormal), venue} {
+		if err := d.Enqueue(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d.Start()
+	shutdown(t, d)
+
+	if got := fmt.Sprint(tg.sent); got != "[confirmed venue:Салон]" {
+		t.Fatalf("sent = %s", got)
+	}
+	if rep.got["venue"].Status != StatusSent {
+		t.Fatalf("venue delivery = %+v", rep.got["venue"])
+	}
+}
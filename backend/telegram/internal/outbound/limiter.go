@@ -0,0 +1,46 @@
+package outbound
+
+import "time"
+
+// tokenBucket — классический token bucket: rate токенов в секунду, не больше burst
+type tokenBucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int, now time.Time) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: now}
+}
+
+func (b *tokenBucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.burst {
+			b.tokens = b.burst
+		}
+		b.last = now
+	}
+}
+
+// wait возвращает, сколько ждать до появления токена (0 — токен есть)
+func (b *tokenBucket) wait(now time.Time) time.Duration {
+	b.refill(now)
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// take забирает токен; вызывается только после wait(now) == 0
+func (b *tokenBucket) take(now time.Time) {
+	b.refill(now)
+	b.tokens--
+}
+
+// full — корзина полная, т.е. чат давно не получал сообщений и её можно забыть
+func (b *tokenBucket) full(now time.Time) bool {
+	b.refill(now)
+	return b.tokens >= b.burst
+}
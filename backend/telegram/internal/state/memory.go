@@ -0,0 +1,99 @@
+package state
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore хранит состояния в памяти процесса
+type MemoryStore struct {
+	mu     sync.Mutex
+	ttl    time.Duration
+	now    func() time.Time
+	states map[Key]MessageState
+}
+
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:    ttl,
+		now:    time.Now,
+		states: make(map[Key]MessageState),
+	}
+}
+
+func (s *MemoryStore) Get(_ context.Context, key Key) (MessageState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	st, ok := s.states[key]
+	if !ok || s.expired(st) {
+		return MessageState{}, ErrNotFound
+	}
+	return st, nil
+}
+
+func (s *MemoryStore) Set(_ context.Context, st MessageState) (MessageState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	st.CreatedAt = now
+	st.Version = 1
+	if prev, ok := s.states[st.Key]; ok {
+		st.Version = prev.Version + 1
+	}
+	return s.store(st, now), nil
+}
+
+func (s *MemoryStore) Update(_ context.Context, st MessageState) (MessageState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	prev, ok := s.states[st.Key]
+	if !ok || s.expired(prev) {
+		return MessageState{}, ErrNotFound
+	}
+	if prev.Version != st.Version {
+		return MessageState{}, ErrConflict
+	}
+	st.CreatedAt = prev.CreatedAt
+	st.Version = prev.Version + 1
+	return s.store(st, s.now()), nil
+}
+
+func (s *MemoryStore) Delete(_ context.Context, key Key) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.states, key)
+	return nil
+}
+
+func (s *MemoryStore) DeleteExpired(_ context.Context) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var n int64
+	for key, st := range s.states {
+		if s.expired(st) {
+			delete(s.states, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (s *MemoryStore) store(st MessageState, now time.Time) MessageState {
+	st.UpdatedAt = now
+	st.ExpiresAt = time.Time{}
+	if s.ttl > 0 {
+		st.ExpiresAt = now.Add(s.ttl)
+	}
+	s.states[st.Key] = st
+	return st
+}
+
+func (s *MemoryStore) expired(st MessageState) bool {
+	return !st.ExpiresAt.IsZero() && !s.now().Before(st.ExpiresAt)
+}
@@ -0,0 +1,72 @@
+package state
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryStoreVersioning(t *testing.T) {
+	ctx := context.Background()
+	s := NewMemoryStore(time.Hour)
+	key := Key{ChatID: 1, UserID: 1, MessageType: "slots_pagination"}
+
+	if _, err := s.Get(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get() on empty store error = %v", err)
+	}
+	if _, err := s.Update(ctx, MessageState{Key: key, Version: 1}); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Update() on empty store error = %v", err)
+	}
+
+	first, err := s.Set(ctx, MessageState{Key: key, MessageID: 10, Context: Context{MasterID: 5, Page: 1}})
+	if err != nil || first.Version != 1 {
+		t.Fatalf("Set() = %+v, %v", first, err)
+	}
+
+	next := first
+	next.Context.Page = 2
+	second, err := s.Update(ctx, next)
+	if err != nil || second.Version != 2 || second.Context.Page != 2 {
+		t.Fatalf("Update() = %+v, %v", second, err)
+	}
+
+	// Обработчик, прочитавший состояние до обновления, получает конфликт
+	stale := first
+	stale.Context.Page = 3
+	if _, err := s.Update(ctx, stale); !errors.Is(err, ErrConflict) {
+		t.Fatalf("Update(stale) error = %v", err)
+	}
+	got, _ := s.Get(ctx, key)
+	if got.Context.Page != 2 || got.CreatedAt != first.CreatedAt {
+		t.Fatalf("state after conflict = %+v", got)
+	}
+}
+
+func TestMemoryStoreExpires(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	s := NewMemoryStore(time.Hour)
+	s.now = func() time.Time { return now }
+
+	live := Key{ChatID: 1, UserID: 1, MessageType: "user_records"}
+	old := Key{ChatID: 1, UserID: 1, MessageType: "slot_details"}
+	if _, err := s.Set(ctx, MessageState{Key: old, MessageID: 1}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(45 * time.Minute)
+	if _, err := s.Set(ctx, MessageState{Key: live, MessageID: 2}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Minute)
+
+	if _, err := s.Get(ctx, old); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("Get(expired) error = %v", err)
+	}
+	if n, err := s.DeleteExpired(ctx); err != nil || n != 1 {
+		t.Fatalf("DeleteExpired() = %d, %v", n, err)
+	}
+	if _, err := s.Get(ctx, live); err != nil {
+		t.Fatalf("Get(live) error = %v", err)
+	}
+}
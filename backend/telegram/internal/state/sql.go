@@ -0,0 +1,153 @@
+package state
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SQLStore хранит состояния в PostgreSQL (таблица bot_message_states).
+// Драйвер регистрируется вызывающей стороной, хранилище работает с *sql.DB.
+type SQLStore struct {
+	db  *sql.DB
+	ttl time.Duration
+	now func() time.Time
+}
+
+func NewSQLStore(db *sql.DB, ttl time.Duration) *SQLStore {
+	return &SQLStore{db: db, ttl: ttl, now: time.Now}
+}
+
+// Migrate создаёт таблицу состояний, если её ещё нет
+func (s *SQLStore) Migrate(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS bot_message_states (
+	chat_id      BIGINT      NOT NULL,
+	user_id      BIGINT      NOT NULL,
+	message_type TEXT        NOT NULL,
+	message_id   INTEGER     NOT NULL,
+	context      JSONB       NOT NULL DEFAULT '{}',
+	version      BIGINT      NOT NULL,
+	created_at   TIMESTAMPTZ NOT NULL,
+	updated_at   TIMESTAMPTZ NOT NULL,
+	expires_at   TIMESTAMPTZ,
+	PRIMARY KEY (chat_id, user_id, message_type)
+);
+CREATE INDEX IF NOT EXISTS idx_bot_message_states_expires_at ON bot_message_states (expires_at);`)
+	if err != nil {
+		return fmt.Errorf("state: migrate: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) Get(ctx context.Context, key Key) (MessageState, error) {
+	st := MessageState{Key: key}
+	var (
+		raw     []byte
+		expires sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx, `
+SELECT message_id, context, version, created_at, updated_at, expires_at
+FROM bot_message_states
+WHERE chat_id = $1 AND user_id = $2 AND message_type = $3 AND (expires_at IS NULL OR expires_at > $4)`,
+		key.ChatID, key.UserID, key.MessageType, s.now().UTC(),
+	).Scan(&st.MessageID, &raw, &st.Version, &st.CreatedAt, &st.UpdatedAt, &expires)
+	if errors.Is(err, sql.ErrNoRows) {
+		return MessageState{}, ErrNotFound
+	}
+	if err != nil {
+		return MessageState{}, fmt.Errorf("state: get: %w", err)
+	}
+	if err := json.Unmarshal(raw, &st.Context); err != nil {
+		return MessageState{}, fmt.Errorf("state: decode context: %w", err)
+	}
+	st.ExpiresAt = expires.Time
+	return st, nil
+}
+
+func (s *SQLStore) Set(ctx context.Context, st MessageState) (MessageState, error) {
+	raw, err := json.Marshal(st.Context)
+	if err != nil {
+		return MessageState{}, fmt.Errorf("state: encode context: %w", err)
+	}
+	now := s.now().UTC()
+	st.CreatedAt, st.UpdatedAt, st.ExpiresAt = now, now, s.expiresAt(now)
+	err = s.db.QueryRowContext(ctx, `
+INSERT INTO bot_message_states (chat_id, user_id, message_type, message_id, context, version, created_at, updated_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, 1, $6, $6, $7)
+ON CONFLICT (chat_id, user_id, message_type) DO UPDATE SET
+	message_id = EXCLUDED.message_id,
+	context    = EXCLUDED.context,
+	version    = bot_message_states.version + 1,
+	created_at = EXCLUDED.created_at,
+	updated_at = EXCLUDED.updated_at,
+	expires_at = EXCLUDED.expires_at
+RETURNING version`,
+		st.ChatID, st.UserID, st.MessageType, st.MessageID, string(raw), now, nullTime(st.ExpiresAt),
+	).Scan(&st.Version)
+	if err != nil {
+		return MessageState{}, fmt.Errorf("state: set: %w", err)
+	}
+	return st, nil
+}
+
+func (s *SQLStore) Update(ctx context.Context, st MessageState) (MessageState, error) {
+	raw, err := json.Marshal(st.Context)
+	if err != nil {
+		return MessageState{}, fmt.Errorf("state: encode context: %w", err)
+	}
+	now := s.now().UTC()
+	st.UpdatedAt, st.ExpiresAt = now, s.expiresAt(now)
+	err = s.db.QueryRowContext(ctx, `
+UPDATE bot_message_states
+SET message_id = $4, context = $5, version = version + 1, updated_at = $6, expires_at = $7
+WHERE chat_id = $1 AND user_id = $2 AND message_type = $3 AND version = $8
+	AND (expires_at IS NULL OR expires_at > $6)
+RETURNING version, created_at`,
+		st.ChatID, st.UserID, st.MessageType, st.MessageID, string(raw), now, nullTime(st.ExpiresAt), st.Version,
+	).Scan(&st.Version, &st.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Строка есть, но с другой версией — конфликт; иначе состояние удалено или истекло
+		if _, getErr := s.Get(ctx, st.Key); getErr == nil {
+			return MessageState{}, ErrConflict
+		}
+		return MessageState{}, ErrNotFound
+	}
+	if err != nil {
+		return MessageState{}, fmt.Errorf("state: update: %w", err)
+	}
+	return st, nil
+}
+
+func (s *SQLStore) Delete(ctx context.Context, key Key) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM bot_message_states WHERE chat_id = $1 AND user_id = $2 AND message_type = $3`,
+		key.ChatID, key.UserID, key.MessageType)
+	if err != nil {
+		return fmt.Errorf("state: delete: %w", err)
+	}
+	return nil
+}
+
+func (s *SQLStore) DeleteExpired(ctx context.Context) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		`DELETE FROM bot_message_states WHERE expires_at IS NOT NULL AND expires_at <= $1`, s.now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("state: delete expired: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+func (s *SQLStore) expiresAt(now time.Time) time.Time {
+	if s.ttl <= 0 {
+		return time.Time{}
+	}
+	return now.Add(s.ttl)
+}
+
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
@@ -0,0 +1,62 @@
+// Package state хранит указатели на сообщения бота (пагинация, выбор времени,
+// детали слота), чтобы их можно было редактировать при следующих нажатиях.
+// Хранилище вынесено за интерфейс: в памяти для одного экземпляра и в SQL,
+// чтобы состояние переживало перезапуск и было общим для нескольких экземпляров.
+package state
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	// ErrNotFound — состояния нет или оно истекло
+	ErrNotFound = errors.New("state: not found")
+	// ErrConflict — состояние изменил кто-то другой (версия не совпала)
+	ErrConflict = errors.New("state: version conflict")
+)
+
+// Key идентифицирует сообщение определённого типа у пользователя
+type Key struct {
+	ChatID      int64
+	UserID      int64
+	MessageType string
+}
+
+// Context — данные, с которыми было отрисовано сообщение
+type Context struct {
+	MasterID   int64  `json:"master_id,omitempty"`
+	SlotID     uint   `json:"slot_id,omitempty"`
+	TargetDate string `json:"target_date,omitempty"`
+	TimeType   string `json:"time_type,omitempty"`
+	Status     string `json:"status,omitempty"`
+	Page       int    `json:"page,omitempty"`
+}
+
+// MessageState — сохранённое сообщение бота.
+// Version увеличивается при каждой записи и используется для оптимистичной блокировки.
+type MessageState struct {
+	Key
+	MessageID int
+	Context   Context
+	Version   int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Store — хранилище состояний сообщений
+type Store interface {
+	// Get возвращает состояние или ErrNotFound
+	Get(ctx context.Context, key Key) (MessageState, error)
+	// Set записывает состояние независимо от текущей версии (новое сообщение)
+	Set(ctx context.Context, st MessageState) (MessageState, error)
+	// Update записывает состояние, только если сохранённая версия равна st.Version;
+	// иначе ErrConflict (или ErrNotFound, если состояния уже нет)
+	Update(ctx context.Context, st MessageState) (MessageState, error)
+	// Delete удаляет состояние; отсутствие состояния не ошибка
+	Delete(ctx context.Context, key Key) error
+	// DeleteExpired удаляет истёкшие состояния и возвращает их количество
+	DeleteExpired(ctx context.Context) (int64, error)
+}
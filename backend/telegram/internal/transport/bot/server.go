@@ -2,55 +2,84 @@ package bot
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"telegram-bot/internal/adapter/backendapi"
 	appLogin "telegram-bot/internal/app/login"
 	appRecords "telegram-bot/internal/app/record"
 	appSlots "telegram-bot/internal/app/slots"
 	botMiddleware "telegram-bot/internal/bot"
-	"telegram-bot/internal/config"
+	hDigest "telegram-bot/internal/handlers/digest"
 	hInfo "telegram-bot/internal/handlers/info"
+	hInline "telegram-bot/internal/handlers/inline"
+	hLanguage "telegram-bot/internal/handlers/language"
+	hManage "telegram-bot/internal/handlers/manage"
 	hMaster "telegram-bot/internal/handlers/master"
 	hRecord "telegram-bot/internal/handlers/record"
 	hSlot "telegram-bot/internal/handlers/slot"
 	hStart "telegram-bot/internal/handlers/start"
 	hTimezone "telegram-bot/internal/handlers/timezone"
-	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/sirupsen/logrus"
 )
 
+// Webhook — параметры режима webhook. Обновления принимает HTTP-сервер бота
+// (transport/http), Server только регистрирует адрес и обрабатывает очередь.
+type Webhook struct {
+	// URL — полный публичный адрес обработчика, включая путь
+	URL string
+	// Secret — значение заголовка X-Telegram-Bot-Api-Secret-Token
+	Secret string
+}
+
 type Server struct {
-	bot      *bot.Bot
-	logger   *logrus.Logger
-	login    *appLogin.Service
-	slots    *appSlots.Service
-	records  *appRecords.Service
+	bot     *bot.Bot
+	logger  *logrus.Logger
+	client  *backendapi.Client
+	login   *appLogin.Service
+	slots   *appSlots.Service
+	records *appRecords.Service
+	webhook *Webhook
+	name    string
+
+	mu       sync.Mutex
 	stopFunc context.CancelFunc
-	name     string
+	done     chan struct{}
 }
 
-func NewServer(b *bot.Bot, logger *logrus.Logger, login *appLogin.Service, slots *appSlots.Service, records *appRecords.Service, name string) *Server {
+func NewServer(b *bot.Bot, logger *logrus.Logger, client *backendapi.Client, login *appLogin.Service, slots *appSlots.Service, records *appRecords.Service, name string) *Server {
 	return &Server{
 		bot:     b,
 		logger:  logger,
+		client:  client,
 		login:   login,
 		slots:   slots,
 		records: records,
 		name:    name,
+		done:    make(chan struct{}),
 	}
 }
 
+// WithWebhook переключает сервер с long polling на webhook
+func (s *Server) WithWebhook(w Webhook) *Server {
+	s.webhook = &w
+	return s
+}
+
 func (s *Server) RegisterHandlers() {
-	cfg := config.Load()
-	client := backendapi.New(cfg.BackendBaseURL, logrus.New())
+	client := s.client
 	s.logger.Infof("Transport.Bot.Server.RegisterHandlers: begin")
 	startHandler := hStart.NewHandler(s.bot, s.logger, client)
 	slotHandler := hSlot.NewHandler(s.bot, s.logger)
-	recordHandler := hRecord.NewHandler(s.bot, s.logger)
+	recordHandler := hRecord.NewHandler(s.bot, s.logger, client)
 	infoHandler := hInfo.NewHandler(s.logger)
 	timezoneHandler := hTimezone.NewHandler(s.logger)
-	masterHandler := hMaster.NewHandler(s.logger)
+	languageHandler := hLanguage.NewHandler(s.logger)
+	masterHandler := hMaster.NewHandler(s.logger, client)
+	digestHandler := hDigest.NewHandler(s.logger, client)
+	manageHandler := hManage.NewHandler(s.logger, client)
+	inlineHandler := hInline.NewHandler(s.logger, client)
 
 	// Применяем rate limiting middleware к командам
 	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, botMiddleware.CommandRateLimitMiddleware(startHandler.StartHandler))
@@ -61,28 +90,75 @@ func (s *Server) RegisterHandlers() {
 	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/link", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(slotHandler.HandlerGetUserLink), client))
 	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/info", bot.MatchTypeExact, botMiddleware.CommandRateLimitMiddleware(infoHandler.InfoHandler))
 	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/upcoming", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(masterHandler.HandlerUpcomingRecords), client))
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/digest", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(digestHandler.HandlerDigest), client))
 	// /timezone requires auth, rate-limited
-	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/timezone", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(timezoneHandler.HandlerTimezone), client))
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/timezone", bot.MatchTypePrefix, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(timezoneHandler.HandlerTimezone), client))
+	// /language requires auth: the choice is stored on the user in API
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/language", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(languageHandler.HandlerLanguage), client))
+
+	// Управление услугами и слотами: пошаговые сценарии на fsm
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/newservice", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(manageHandler.HandlerNewService), client))
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/editservice", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(manageHandler.HandlerEditService), client))
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/newslot", bot.MatchTypeExact, botMiddleware.CommandAuthMiddleware(botMiddleware.CommandRateLimitMiddleware(manageHandler.HandlerNewSlot), client))
+	s.bot.RegisterHandler(bot.HandlerTypeMessageText, "/cancel", bot.MatchTypeExact, botMiddleware.CommandRateLimitMiddleware(manageHandler.HandlerCancel))
+	// Текстовый ввод на шагах сценария
+	s.bot.RegisterHandlerMatchFunc(manageHandler.MatchInput, botMiddleware.CommandRateLimitMiddleware(manageHandler.HandleInput))
+	s.bot.RegisterHandlerMatchFunc(recordHandler.MatchCommentInput, botMiddleware.CommandRateLimitMiddleware(recordHandler.HandleCommentInput))
+	s.bot.RegisterHandlerMatchFunc(recordHandler.MatchReviewInput, botMiddleware.CommandRateLimitMiddleware(recordHandler.HandleReviewInput))
+
+	// Inline-режим: @bot <мастер или услуга> в любом чате. Без rate limit — Telegram
+	// сам присылает запрос на каждый набранный символ, а повторы отдаёт из кэша
+	s.bot.RegisterHandlerMatchFunc(hInline.Match, inlineHandler.HandleInlineQuery)
 
 	s.logger.Infof("Transport.Bot.Server.RegisterHandlers: registered handlers with rate limiting")
 }
 
+// Start получает обновления до отмены ctx или вызова Shutdown.
+// В режиме webhook сначала регистрирует адрес через SetWebhook, в режиме polling
+// снимает ранее зарегистрированный webhook: иначе Telegram отклоняет getUpdates.
 func (s *Server) Start(ctx context.Context) error {
+	defer close(s.done)
+
+	s.mu.Lock()
 	ctx, s.stopFunc = context.WithCancel(ctx)
+	s.mu.Unlock()
+
+	if s.webhook != nil {
+		if _, err := s.bot.SetWebhook(ctx, &bot.SetWebhookParams{
+			URL:         s.webhook.URL,
+			SecretToken: s.webhook.Secret,
+		}); err != nil {
+			return fmt.Errorf("set webhook: %w", err)
+		}
+		s.logger.Infof("Transport.Bot.Server.Start: webhook registered at %s", s.webhook.URL)
+		s.bot.StartWebhook(ctx)
+		return nil
+	}
 
+	if _, err := s.bot.DeleteWebhook(ctx, &bot.DeleteWebhookParams{}); err != nil {
+		return fmt.Errorf("delete webhook: %w", err)
+	}
 	s.logger.Infof("Transport.Bot.Server.Start: polling start")
 	s.bot.Start(ctx)
 	return nil
 }
+
+// Shutdown останавливает получение обновлений и ждёт, пока обработчики
+// доберут очередь. Webhook не снимается: пока бот перезапускается,
+// Telegram копит обновления и доставит их новому экземпляру.
 func (s *Server) Shutdown(ctx context.Context) error {
-	if s.stopFunc != nil {
-		s.stopFunc()
+	s.mu.Lock()
+	stop := s.stopFunc
+	s.mu.Unlock()
+	if stop == nil {
+		return nil
 	}
+	stop()
 
 	select {
 	case <-ctx.Done():
 		return ctx.Err()
-	case <-time.After(10 * time.Second):
+	case <-s.done:
 	}
 	s.logger.Infof("%s shutdown complete", s.name)
 	return nil
@@ -0,0 +1,87 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+func TestServerPollingShutsDown(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		methods []string
+	)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		mu.Lock()
+		methods = append(methods, method)
+		first := method == "getUpdates" && strings.Count(strings.Join(methods, ","), "getUpdates") == 1
+		mu.Unlock()
+
+		var result any = true
+		if method == "getUpdates" {
+			result = []any{}
+			if first {
+				result = []any{map[string]any{
+					"update_id": 1,
+					"message":   map[string]any{"message_id": 1, "chat": map[string]any{"id": 42, "type": "private"}, "text": "ping"},
+				}}
+			} else {
+				// Long polling: держим запрос, пока бот не отменит его
+				select {
+				case <-r.Context().Done():
+				case <-time.After(time.Second):
+				}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+	}))
+	defer api.Close()
+
+	got := make(chan int64, 1)
+	b, err := bot.New("123:test", bot.WithServerURL(api.URL), bot.WithSkipGetMe(),
+		bot.WithDefaultHandler(func(_ context.Context, _ *bot.Bot, u *models.Update) { got <- u.Message.Chat.ID }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := NewServer(b, logrus.New(), nil, nil, nil, nil, "bot")
+
+	if err := srv.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() before Start error = %v", err)
+	}
+
+	started := make(chan error, 1)
+	go func() { started <- srv.Start(context.Background()) }()
+
+	select {
+	case chatID := <-got:
+		if chatID != 42 {
+			t.Fatalf("update chat id = %d", chatID)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("update was not delivered")
+	}
+	mu.Lock()
+	if len(methods) == 0 || methods[0] != "deleteWebhook" {
+		t.Fatalf("first call = %v, webhook must be removed before polling", methods)
+	}
+	mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-started; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
@@ -2,10 +2,10 @@ package http
 
 import (
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"telegram-bot/internal/handlers/message"
 )
 
 type recordNotifyRequest struct {
@@ -13,17 +13,64 @@ type recordNotifyRequest struct {
 	TelegramID int64  `json:"telegram_id"`
 	Title      string `json:"title"`
 	Message    string `json:"message"`
+	DeliveryID string `json:"delivery_id"`
 }
 
 type recordStatusNotifyRequest struct {
 	TelegramID int64  `json:"telegram_id"`
 	Title      string `json:"title"`
 	Message    string `json:"message"`
+	DeliveryID string `json:"delivery_id"`
+}
+
+type venueNotifyRequest struct {
+	TelegramID int64   `json:"telegram_id"`
+	Title      string  `json:"title"`
+	Address    string  `json:"address"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	DeliveryID string  `json:"delivery_id"`
+}
+
+type digestNotifyRequest struct {
+	TelegramID int64                  `json:"telegram_id"`
+	Title      string                 `json:"title"`
+	Message    string                 `json:"message"`
+	Pending    []message.DigestRecord `json:"pending"`
+	DeliveryID string                 `json:"delivery_id"`
+}
+
+type reviewNotifyRequest struct {
+	RecordID   uint   `json:"record_id"`
+	TelegramID int64  `json:"telegram_id"`
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+	DeliveryID string `json:"delivery_id"`
+}
+
+type phoneCodeRequest struct {
+	TelegramID int64  `json:"telegram_id"`
+	Phone      string `json:"phone"`
+	Code       string `json:"code"`
+	DeliveryID string `json:"delivery_id"`
 }
 
 type accountDeletionRequest struct {
 	UserID     string `json:"user_id"`
 	TelegramID int64  `json:"telegram_id"`
+	DeliveryID string `json:"delivery_id"`
+}
+
+// writeAccepted отвечает API: 200 — уведомление принято в очередь,
+// 503 — очередь переполнена или бот останавливается, уведомление нужно повторить
+func writeAccepted(w http.ResponseWriter, err error) {
+	if err != nil {
+		log.Printf("notify: message not accepted: %v", err)
+		http.Error(w, "очередь сообщений недоступна", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
 }
 
 // NotifyRecord принимает POST-запрос и отправляет сообщение пользователю в телеграм
@@ -50,10 +97,8 @@ func (h *HttpClient) NotifyRecord(w http.ResponseWriter, r *http.Request) {
 		recordID = "unknown"
 	}
 
-	h.messageHandler.SendRecordNotification(r.Context(), h.bot, req.TelegramID, recordID, req.Title, req.Message)
-
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(fmt.Sprintf("ok")))
+	err := h.messageHandler.SendRecordNotification(r.Context(), h.bot, req.DeliveryID, req.TelegramID, recordID, req.Title, req.Message)
+	writeAccepted(w, err)
 }
 
 // NotifyRecordStatus принимает POST-запрос и отправляет уведомление о статусе записи (без кнопок)
@@ -73,10 +118,75 @@ func (h *HttpClient) NotifyRecordStatus(w http.ResponseWriter, r *http.Request)
 	}
 	log.Printf("NotifyRecordStatus: to=%d title=%q", req.TelegramID, req.Title)
 
-	h.messageHandler.SendRecordStatusNotification(r.Context(), h.bot, req.TelegramID, req.Title, req.Message)
+	err := h.messageHandler.SendRecordStatusNotification(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.Title, req.Message)
+	writeAccepted(w, err)
+}
 
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(fmt.Sprintf("ok")))
+// NotifyVenue принимает POST-запрос и отправляет клиенту место записи картой
+func (h *HttpClient) NotifyVenue(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	var req venueNotifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректное тело запроса", http.StatusBadRequest)
+		return
+	}
+	if req.TelegramID == 0 || req.Title == "" || req.Address == "" {
+		http.Error(w, "telegram_id, title и address обязательны", http.StatusBadRequest)
+		return
+	}
+	if req.Latitude < -90 || req.Latitude > 90 || req.Longitude < -180 || req.Longitude > 180 {
+		http.Error(w, "Некорректные координаты", http.StatusBadRequest)
+		return
+	}
+	log.Printf("NotifyVenue: to=%d title=%q", req.TelegramID, req.Title)
+
+	err := h.messageHandler.SendVenue(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.Title, req.Address, req.Latitude, req.Longitude)
+	writeAccepted(w, err)
+}
+
+// NotifyDigest принимает POST-запрос со сводкой мастера и отправляет её с кнопками по заявкам
+func (h *HttpClient) NotifyDigest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	var req digestNotifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректное тело запроса", http.StatusBadRequest)
+		return
+	}
+	if req.TelegramID == 0 {
+		http.Error(w, "telegram_id обязателен", http.StatusBadRequest)
+		return
+	}
+	log.Printf("NotifyDigest: to=%d title=%q pending=%d", req.TelegramID, req.Title, len(req.Pending))
+
+	err := h.messageHandler.SendDigest(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.Title, req.Message, req.Pending)
+	writeAccepted(w, err)
+}
+
+// NotifyReview принимает POST-запрос и предлагает клиенту оценить завершённую запись кнопками со звёздами
+func (h *HttpClient) NotifyReview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	var req reviewNotifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректное тело запроса", http.StatusBadRequest)
+		return
+	}
+	if req.TelegramID == 0 || req.RecordID == 0 {
+		http.Error(w, "telegram_id и record_id обязательны", http.StatusBadRequest)
+		return
+	}
+	log.Printf("NotifyReview: to=%d record_id=%d", req.TelegramID, req.RecordID)
+
+	err := h.messageHandler.SendReviewPrompt(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.RecordID, req.Title, req.Message)
+	writeAccepted(w, err)
 }
 
 // NotifyAccountDeletion принимает POST-запрос и отправляет запрос на подтверждение удаления аккаунта
@@ -96,8 +206,27 @@ func (h *HttpClient) NotifyAccountDeletion(w http.ResponseWriter, r *http.Reques
 	}
 	log.Printf("NotifyAccountDeletion: to=%d user_id=%s", req.TelegramID, req.UserID)
 
-	h.messageHandler.SendAccountDeletionConfirmation(r.Context(), h.bot, req.TelegramID, req.UserID)
+	err := h.messageHandler.SendAccountDeletionConfirmation(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.UserID)
+	writeAccepted(w, err)
+}
 
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(fmt.Sprintf("ok")))
+// NotifyPhoneCode принимает POST-запрос и отправляет пользователю одноразовый код подтверждения номера
+func (h *HttpClient) NotifyPhoneCode(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	var req phoneCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Некорректное тело запроса", http.StatusBadRequest)
+		return
+	}
+	if req.TelegramID == 0 || req.Code == "" {
+		http.Error(w, "telegram_id и code обязательны", http.StatusBadRequest)
+		return
+	}
+	log.Printf("NotifyPhoneCode: to=%d", req.TelegramID)
+
+	err := h.messageHandler.SendPhoneCode(r.Context(), h.bot, req.DeliveryID, req.TelegramID, req.Phone, req.Code)
+	writeAccepted(w, err)
 }
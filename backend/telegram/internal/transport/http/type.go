@@ -23,6 +23,7 @@ type HttpClient struct {
 	logger         *logrus.Logger
 	name           string
 	server         *http.Server
+	webhook        *webhookRoute
 }
 
 func NewHttpClient(name string, server *http.Server, bot *bot.Bot, messageHandler *message.Handler, logger *logrus.Logger) *HttpClient {
@@ -90,6 +91,30 @@ func (h *HttpClient) Run() error {
 		}
 		h.NotifyRecordStatus(w, r)
 	})
+	mux.HandleFunc(notifyLink+"-venue", func(w http.ResponseWriter, r *http.Request) {
+		if !checkSecret(r) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden"))
+			return
+		}
+		h.NotifyVenue(w, r)
+	})
+	mux.HandleFunc(notifyLink+"-digest", func(w http.ResponseWriter, r *http.Request) {
+		if !checkSecret(r) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden"))
+			return
+		}
+		h.NotifyDigest(w, r)
+	})
+	mux.HandleFunc(notifyLink+"-review", func(w http.ResponseWriter, r *http.Request) {
+		if !checkSecret(r) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden"))
+			return
+		}
+		h.NotifyReview(w, r)
+	})
 	mux.HandleFunc(notifyLink+"-account-deletion", func(w http.ResponseWriter, r *http.Request) {
 		if !checkSecret(r) {
 			w.WriteHeader(http.StatusForbidden)
@@ -99,8 +124,22 @@ func (h *HttpClient) Run() error {
 		h.NotifyAccountDeletion(w, r)
 	})
 
-	h.logger.Infof("HTTP сервер для нотификаций запущен на :8091%v-login/{telegram_id}, POST %v-record, POST %v-record-status, POST %v-account-deletion", notifyLink, notifyLink, notifyLink, notifyLink)
-	if err := h.server.ListenAndServe(); err != nil {
+	mux.HandleFunc(notifyLink+"-phone-code", func(w http.ResponseWriter, r *http.Request) {
+		if !checkSecret(r) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte("forbidden"))
+			return
+		}
+		h.NotifyPhoneCode(w, r)
+	})
+
+	if h.webhook != nil {
+		mux.HandleFunc(h.webhook.path, h.Webhook)
+		h.logger.Infof("Telegram webhook принимается на :8091%s", h.webhook.path)
+	}
+
+	h.logger.Infof("HTTP сервер для нотификаций запущен на :8091%v-login/{telegram_id}, POST %v-record, POST %v-record-status, POST %v-venue, POST %v-digest, POST %v-review, POST %v-account-deletion, POST %v-phone-code", notifyLink, notifyLink, notifyLink, notifyLink, notifyLink, notifyLink, notifyLink, notifyLink)
+	if err := h.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("Ошибка запуска сервера: %v", err)
 	}
 	return nil
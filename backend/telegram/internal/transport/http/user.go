@@ -24,7 +24,10 @@ func (h *HttpClient) NotifyLogin(w http.ResponseWriter, r *http.Request) {
 	// optional ip/location from query
 	ip := r.URL.Query().Get("ip")
 	loc := r.URL.Query().Get("loc")
-	h.messageHandler.SendLoginMessage(r.Context(), h.bot, telegramID, ip, loc)
+	if err := h.messageHandler.SendLoginMessage(r.Context(), h.bot, r.URL.Query().Get("delivery_id"), telegramID, ip, loc); err != nil {
+		writeAccepted(w, err)
+		return
+	}
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte(fmt.Sprintf("Уведомление отправлено для пользователя %d", telegramID)))
 }
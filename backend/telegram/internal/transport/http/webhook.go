@@ -0,0 +1,37 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net/http"
+)
+
+// webhookSecretHeader — заголовок, которым Telegram подписывает запросы к webhook
+const webhookSecretHeader = "X-Telegram-Bot-Api-Secret-Token"
+
+type webhookRoute struct {
+	path    string
+	secret  string
+	handler http.Handler
+}
+
+// WithWebhook монтирует обработчик обновлений Telegram рядом с /notify-* маршрутами.
+// Запросы без верного X-Telegram-Bot-Api-Secret-Token отклоняются до разбора тела.
+func (h *HttpClient) WithWebhook(path, secret string, handler http.Handler) *HttpClient {
+	h.webhook = &webhookRoute{path: path, secret: secret, handler: handler}
+	return h
+}
+
+// Webhook принимает POST-запрос Telegram с обновлением и передаёт его боту
+func (h *HttpClient) Webhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Метод не поддерживается", http.StatusMethodNotAllowed)
+		return
+	}
+	got := r.Header.Get(webhookSecretHeader)
+	if subtle.ConstantTimeCompare([]byte(got), []byte(h.webhook.secret)) != 1 {
+		h.logger.Warnf("Webhook: rejected update from %s: bad secret token", r.RemoteAddr)
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	h.webhook.handler.ServeHTTP(w, r)
+}
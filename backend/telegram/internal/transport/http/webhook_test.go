@@ -0,0 +1,129 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	botServer "telegram-bot/internal/transport/bot"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/sirupsen/logrus"
+)
+
+// fakeBotAPI — локальный Bot API: отвечает ok на любой метод и запоминает вызовы
+type fakeBotAPI struct {
+	*httptest.Server
+	mu    sync.Mutex
+	calls map[string][]map[string]string
+}
+
+func newFakeBotAPI(t *testing.T) *fakeBotAPI {
+	f := &fakeBotAPI{calls: map[string][]map[string]string{}}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		params := map[string]string{}
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			for k, v := range r.MultipartForm.Value {
+				params[k] = v[0]
+			}
+		}
+		f.mu.Lock()
+		f.calls[method] = append(f.calls[method], params)
+		f.mu.Unlock()
+
+		var result any = true
+		if method == "sendMessage" {
+			result = models.Message{ID: 1}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"ok": true, "result": result})
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+func (f *fakeBotAPI) waitCall(t *testing.T, method string) map[string]string {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		f.mu.Lock()
+		calls := f.calls[method]
+		f.mu.Unlock()
+		if len(calls) > 0 {
+			return calls[0]
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%s was not called", method)
+	return nil
+}
+
+func (f *fakeBotAPI) count(method string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.calls[method])
+}
+
+func TestWebhookDeliversVerifiedUpdates(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b, err := bot.New("123:test", bot.WithServerURL(api.URL), bot.WithSkipGetMe(),
+		bot.WithDefaultHandler(func(ctx context.Context, b *bot.Bot, u *models.Update) {
+			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{ChatID: u.Message.Chat.ID, Text: "pong"})
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log := logrus.New()
+	srv := botServer.NewServer(b, log, nil, nil, nil, nil, "bot").
+		WithWebhook(botServer.Webhook{URL: "https://bot.example.com/telegram/webhook", Secret: "s3cret"})
+	started := make(chan error, 1)
+	go func() { started <- srv.Start(context.Background()) }()
+
+	set := api.waitCall(t, "setWebhook")
+	if set["url"] != "https://bot.example.com/telegram/webhook" || set["secret_token"] != "s3cret" {
+		t.Fatalf("setWebhook params = %v", set)
+	}
+
+	h := NewHttpClient("server", &http.Server{}, b, nil, log).WithWebhook("/telegram/webhook", "s3cret", b.WebhookHandler())
+	update := `{"update_id":1,"message":{"message_id":5,"chat":{"id":42,"type":"private"},"text":"ping"}}`
+
+	for _, secret := range []string{"", "wrong"} {
+		req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(update))
+		req.Header.Set(webhookSecretHeader, secret)
+		rec := httptest.NewRecorder()
+		h.Webhook(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Fatalf("secret %q: status = %d, want 401", secret, rec.Code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/telegram/webhook", strings.NewReader(update))
+	req.Header.Set(webhookSecretHeader, "s3cret")
+	rec := httptest.NewRecorder()
+	h.Webhook(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if sent := api.waitCall(t, "sendMessage"); sent["chat_id"] != "42" {
+		t.Fatalf("sendMessage params = %v", sent)
+	}
+	if n := api.count("sendMessage"); n != 1 {
+		t.Fatalf("sendMessage called %d times, rejected updates must not be processed", n)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-started; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+}
@@ -2,44 +2,31 @@ package utils
 
 import (
 	"fmt"
-	"os"
-	"sync"
+	"telegram-bot/internal/i18n"
 	"time"
+	_ "time/tzdata" // таймзоны доступны и в образе без /usr/share/zoneinfo
 )
 
-// Конфигурируемая временная зона для форматирования сообщений в Telegram.
-// Используем TELEGRAM_TIMEZONE или TIMEZONE, иначе системную time.Local.
-var (
-	tzOnce sync.Once
-	tzLoc  *time.Location
-)
+// DefaultZone — таймзона пользователей и мастеров, которые свою не выбрали
+const DefaultZone = "Europe/Moscow"
 
-func getTZ() *time.Location {
-	tzOnce.Do(func() {
-		name := os.Getenv("TELEGRAM_TIMEZONE")
-		if name == "" {
-			name = os.Getenv("TIMEZONE")
-		}
-		if name != "" {
-			if loc, err := time.LoadLocation(name); err == nil {
-				tzLoc = loc
-			}
+// ViewerZone — таймзона, в которой показывать время пользователю:
+// его собственная, иначе таймзона мастера, иначе DefaultZone
+func ViewerZone(viewer, master string) string {
+	for _, name := range []string{viewer, master} {
+		if name == "" || name == "Local" {
+			continue
 		}
-		if tzLoc == nil {
-			tzLoc = time.Local
+		if _, err := time.LoadLocation(name); err == nil {
+			return name
 		}
-	})
-	return tzLoc
+	}
+	return DefaultZone
 }
 
-func toTZ(t time.Time) time.Time { return t.In(getTZ()) }
-
-// Исторические имена функций оставляем, но теперь форматируем в выбранной TZ
-func FormatTimeInMoscow(t time.Time, layout string) string { return toTZ(t).Format(layout) }
-func FormatDateInMoscow(t time.Time) string                { return FormatTimeInMoscow(t, "02-01-2006") }
-func FormatTimeOnlyInMoscow(t time.Time) string            { return FormatTimeInMoscow(t, "15:04") }
-
-// Новые функции: форматирование по имени таймзоны (например, "Europe/Moscow")
+// Форматирование по имени таймзоны (например, "Europe/Moscow").
+// Формат даты не зависит от языка: дата служит ключом группировки слотов
+// и аргументом кнопок. Для текста пользователю — FormatDateInLocale.
 func FormatDateInLocation(locName string, t time.Time) string {
 	loc := loadLocationOrDefault(locName)
 	return t.In(loc).Format("02-01-2006")
@@ -50,32 +37,71 @@ func FormatTimeOnlyInLocation(locName string, t time.Time) string {
 	return t.In(loc).Format("15:04")
 }
 
+// FormatDateInLocale форматирует дату в таймзоне locName по правилам языка lang
+func FormatDateInLocale(lang i18n.Lang, locName string, t time.Time) string {
+	return t.In(loadLocationOrDefault(locName)).Format(i18n.For(lang).DateLayout())
+}
+
+// FormatTimeOnlyInLocale форматирует время в таймзоне locName по правилам языка lang
+func FormatTimeOnlyInLocale(lang i18n.Lang, locName string, t time.Time) string {
+	return t.In(loadLocationOrDefault(locName)).Format(i18n.For(lang).TimeLayout())
+}
+
+// FormatSpan форматирует интервал слота для получателя l: "12:00 – 13:30" в его таймзоне.
+// Если смещение мастера в начале слота другое, добавляет время мастера:
+// "12:00 – 13:30 (у мастера 11:00 – 12:30)".
+func FormatSpan(l i18n.Localizer, masterZone string, start, end time.Time) string {
+	return WithMasterTime(l, masterZone, start, end, formatSpan(l, ViewerZone(l.Zone(), masterZone), start, end))
+}
+
+// WithMasterTime дописывает к text время слота у мастера, если смещение мастера
+// отличается от смещения получателя l; иначе возвращает text как есть
+func WithMasterTime(l i18n.Localizer, masterZone string, start, end time.Time, text string) string {
+	viewer := ViewerZone(l.Zone(), masterZone)
+	master := ViewerZone(masterZone, "")
+	if offsetAt(viewer, start) == offsetAt(master, start) {
+		return text
+	}
+	return l.T("time.master", text, formatSpan(l, master, start, end))
+}
+
+func formatSpan(l i18n.Localizer, locName string, start, end time.Time) string {
+	text := FormatTimeOnlyInLocale(l.Lang(), locName, start)
+	if !end.IsZero() {
+		text += " – " + FormatTimeOnlyInLocale(l.Lang(), locName, end)
+	}
+	return text
+}
+
+func offsetAt(locName string, t time.Time) int {
+	_, offset := t.In(loadLocationOrDefault(locName)).Zone()
+	return offset
+}
+
 func loadLocationOrDefault(locName string) *time.Location {
-	if locName == "" {
-		// Если таймзона не указана, используем московскую
-		if moscowLoc, err := time.LoadLocation("Europe/Moscow"); err == nil {
-			return moscowLoc
+	if locName != "" && locName != "Local" {
+		if loc, err := time.LoadLocation(locName); err == nil {
+			return loc
 		}
-		return getTZ()
 	}
-	if loc, err := time.LoadLocation(locName); err == nil {
+	// Таймзона не указана или не загрузилась — используем московскую
+	if loc, err := time.LoadLocation(DefaultZone); err == nil {
 		return loc
 	}
-	// Если указанная таймзона не загрузилась, используем московскую
-	if moscowLoc, err := time.LoadLocation("Europe/Moscow"); err == nil {
-		return moscowLoc
-	}
-	return getTZ()
+	return time.FixedZone(DefaultZone, 3*3600)
 }
 
-// GetTimezoneOffset возвращает смещение в часах для указанной таймзоны в формате (+3) или (+5)
+// GetTimezoneOffset возвращает смещение таймзоны от UTC: "+3", "-4", "+5:30"
 func GetTimezoneOffset(locName string) string {
-	loc := loadLocationOrDefault(locName)
-	now := time.Now().In(loc)
-	_, offset := now.Zone()
-	hours := offset / 3600
-	if hours >= 0 {
-		return fmt.Sprintf("+%d", hours)
+	_, offset := time.Now().In(loadLocationOrDefault(locName)).Zone()
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	hours, minutes := offset/3600, offset%3600/60
+	if minutes != 0 {
+		return fmt.Sprintf("%s%d:%02d", sign, hours, minutes)
 	}
-	return fmt.Sprintf("%d", hours)
+	return fmt.Sprintf("%s%d", sign, hours)
 }
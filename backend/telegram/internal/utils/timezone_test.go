@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"strings"
+	"telegram-bot/internal/i18n"
+	"testing"
+	"time"
+)
+
+func TestFormatSpan(t *testing.T) {
+	start := time.Date(2030, 3, 10, 6, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Minute)
+	ru := i18n.For(i18n.RU)
+
+	tests := []struct {
+		name   string
+		viewer string
+		master string
+		want   string
+	}{
+		{name: "viewer zone with master time", viewer: "Asia/Omsk", master: "Asia/Yekaterinburg", want: "12:00 – 13:30 (у мастера 11:00 – 12:30)"},
+		{name: "same offset", viewer: "Asia/Yekaterinburg", master: "Asia/Yekaterinburg", want: "11:00 – 12:30"},
+		{name: "viewer without zone sees master time", viewer: "", master: "Asia/Yekaterinburg", want: "11:00 – 12:30"},
+		{name: "nobody chose a zone", viewer: "", master: "", want: "09:00 – 10:30"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatSpan(ru.WithZone(tt.viewer), tt.master, start, end); got != tt.want {
+				t.Errorf("FormatSpan() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if got := FormatSpan(i18n.For(i18n.EN).WithZone("Asia/Omsk"), "Asia/Yekaterinburg", start, end); !strings.Contains(got, "12:00 PM") {
+		t.Errorf("FormatSpan(en) = %q, want 12-hour time", got)
+	}
+}
+
+func TestGetTimezoneOffset(t *testing.T) {
+	for zone, want := range map[string]string{
+		"Asia/Kolkata":       "+5:30",
+		"Asia/Yekaterinburg": "+5",
+		"UTC":                "+0",
+		"Pacific/Marquesas":  "-9:30",
+	} {
+		if got := GetTimezoneOffset(zone); got != want {
+			t.Errorf("GetTimezoneOffset(%s) = %s, want %s", zone, got, want)
+		}
+	}
+}
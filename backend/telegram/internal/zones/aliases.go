@@ -0,0 +1,57 @@
+package zones
+
+import "strings"
+
+// aliases — русские названия городов для поиска: пользователи бота чаще
+// пишут «Екатеринбург», чем «Yekaterinburg»
+var aliases = map[string][]string{
+	"Europe/Kaliningrad": {"калининград"},
+	"Europe/Moscow":      {"москва", "санкт петербург", "петербург", "спб", "нижний новгород", "казань", "ростов", "краснодар", "сочи"},
+	"Europe/Kirov":       {"киров"},
+	"Europe/Volgograd":   {"волгоград"},
+	"Europe/Astrakhan":   {"астрахань"},
+	"Europe/Saratov":     {"саратов"},
+	"Europe/Ulyanovsk":   {"ульяновск"},
+	"Europe/Samara":      {"самара", "ижевск", "удмуртия"},
+	"Asia/Yekaterinburg": {"екатеринбург", "челябинск", "пермь", "тюмень", "уфа"},
+	"Asia/Omsk":          {"омск"},
+	"Asia/Novosibirsk":   {"новосибирск"},
+	"Asia/Barnaul":       {"барнаул", "алтай"},
+	"Asia/Tomsk":         {"томск"},
+	"Asia/Novokuznetsk":  {"новокузнецк", "кемерово"},
+	"Asia/Krasnoyarsk":   {"красноярск"},
+	"Asia/Irkutsk":       {"иркутск", "улан удэ"},
+	"Asia/Chita":         {"чита"},
+	"Asia/Yakutsk":       {"якутск"},
+	"Asia/Vladivostok":   {"владивосток", "хабаровск"},
+	"Asia/Magadan":       {"магадан"},
+	"Asia/Sakhalin":      {"сахалин", "южно сахалинск"},
+	"Asia/Kamchatka":     {"камчатка", "петропавловск камчатский"},
+	"Asia/Anadyr":        {"анадырь", "чукотка"},
+	"Europe/Minsk":       {"минск"},
+	"Europe/Kyiv":        {"киев", "київ"},
+	"Asia/Almaty":        {"алматы", "алма ата"},
+	"Asia/Tashkent":      {"ташкент"},
+	"Asia/Tbilisi":       {"тбилиси"},
+	"Asia/Yerevan":       {"ереван"},
+	"Asia/Baku":          {"баку"},
+	"Asia/Bishkek":       {"бишкек"},
+	"Europe/Istanbul":    {"стамбул"},
+	"Asia/Dubai":         {"дубай"},
+	"Asia/Bangkok":       {"бангкок", "пхукет"},
+	"Europe/Berlin":      {"берлин"},
+	"Europe/London":      {"лондон"},
+	"America/New_York":   {"нью йорк"},
+	"Europe/Belgrade":    {"белград"},
+	"Asia/Jerusalem":     {"иерусалим", "тель авив"},
+	"Europe/Chisinau":    {"кишинев", "кишинёв"},
+}
+
+func matchesAlias(name, query string) bool {
+	for _, alias := range aliases[name] {
+		if strings.Contains(alias, query) {
+			return true
+		}
+	}
+	return false
+}
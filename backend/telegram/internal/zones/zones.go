@@ -0,0 +1,200 @@
+// Package zones — каталог таймзон IANA для выбора в боте: регионы, города,
+// поиск по названию и определение таймзоны по геопозиции.
+package zones
+
+import (
+	_ "embed"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	_ "time/tzdata" // таймзоны доступны и в образе без /usr/share/zoneinfo
+)
+
+// zone.tab из tzdata (public domain): страна, координаты главного города, имя зоны
+//
+//go:embed zone.tab
+var zoneTab string
+
+// Zone — таймзона IANA с координатами её главного города
+type Zone struct {
+	Name   string // "America/Argentina/Buenos_Aires"
+	Region string // "America"
+	City   string // "Argentina/Buenos Aires"
+	Lat    float64
+	Lon    float64
+}
+
+var (
+	all      = mustParse(zoneTab)
+	byName   = index(all)
+	regions  = regionList(all)
+	byRegion = groupByRegion(all)
+)
+
+// Regions возвращает регионы (первая часть имени зоны) по алфавиту
+func Regions() []string { return regions }
+
+// InRegion возвращает зоны региона, отсортированные по городу
+func InRegion(region string) []Zone { return byRegion[region] }
+
+// Lookup возвращает зону каталога по имени IANA
+func Lookup(name string) (Zone, bool) {
+	z, ok := byName[name]
+	return z, ok
+}
+
+// Valid сообщает, что name — таймзона, известная Go (в том числе не из каталога, например "UTC")
+func Valid(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(name)
+	return err == nil
+}
+
+// Search ищет зоны по части названия города или имени IANA без учёта регистра;
+// "buenos aires", "Buenos_Aires" и "омск" находят свои зоны
+func Search(query string) []Zone {
+	q := normalize(query)
+	if q == "" {
+		return nil
+	}
+	var out []Zone
+	for _, z := range all {
+		if strings.Contains(normalize(z.Name), q) || matchesAlias(z.Name, q) {
+			out = append(out, z)
+		}
+	}
+	return out
+}
+
+// Nearest возвращает зону, главный город которой ближе всего к точке.
+// Границы зон не учитываются, поэтому у границ результат стоит подтвердить.
+func Nearest(lat, lon float64) Zone {
+	best, bestDist := all[0], math.Inf(1)
+	for _, z := range all {
+		if d := distance(lat, lon, z.Lat, z.Lon); d < bestDist {
+			best, bestDist = z, d
+		}
+	}
+	return best
+}
+
+// Offset возвращает текущее смещение зоны от UTC: "+03:00", "-09:30"
+func Offset(name string, at time.Time) string {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return ""
+	}
+	return at.In(loc).Format("-07:00")
+}
+
+func normalize(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.NewReplacer("_", " ", "-", " ").Replace(s)
+}
+
+// distance — расстояние по большому кругу в радианах
+func distance(lat1, lon1, lat2, lon2 float64) float64 {
+	const rad = math.Pi / 180
+	p1, p2 := lat1*rad, lat2*rad
+	dp, dl := (lat2-lat1)*rad, (lon2-lon1)*rad
+	a := math.Sin(dp/2)*math.Sin(dp/2) + math.Cos(p1)*math.Cos(p2)*math.Sin(dl/2)*math.Sin(dl/2)
+	return 2 * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+func mustParse(tab string) []Zone {
+	var out []Zone
+	for _, line := range strings.Split(tab, "\n") {
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		if len(cols) < 3 {
+			continue
+		}
+		lat, lon, ok := parseISO6709(cols[1])
+		if !ok {
+			panic("zones: bad coordinates in zone.tab: " + line)
+		}
+		region, city, _ := strings.Cut(cols[2], "/")
+		out = append(out, Zone{
+			Name:   cols[2],
+			Region: region,
+			City:   strings.ReplaceAll(city, "_", " "),
+			Lat:    lat,
+			Lon:    lon,
+		})
+	}
+	if len(out) == 0 {
+		panic("zones: empty zone.tab")
+	}
+	return out
+}
+
+// parseISO6709 разбирает координаты вида ±DDMM±DDDMM или ±DDMMSS±DDDMMSS
+func parseISO6709(s string) (lat, lon float64, ok bool) {
+	i := strings.IndexAny(s[1:], "+-") + 1
+	if i <= 0 {
+		return 0, 0, false
+	}
+	lat, ok1 := parseDMS(s[:i], 2)
+	lon, ok2 := parseDMS(s[i:], 3)
+	return lat, lon, ok1 && ok2
+}
+
+func parseDMS(s string, degDigits int) (float64, bool) {
+	if len(s) < 1+degDigits+2 {
+		return 0, false
+	}
+	sign := 1.0
+	if s[0] == '-' {
+		sign = -1
+	}
+	digits := s[1:]
+	deg, err1 := strconv.Atoi(digits[:degDigits])
+	min, err2 := strconv.Atoi(digits[degDigits : degDigits+2])
+	sec := 0
+	var err3 error
+	if len(digits) >= degDigits+4 {
+		sec, err3 = strconv.Atoi(digits[degDigits+2 : degDigits+4])
+	}
+	if err1 != nil || err2 != nil || err3 != nil {
+		return 0, false
+	}
+	return sign * (float64(deg) + float64(min)/60 + float64(sec)/3600), true
+}
+
+func index(zones []Zone) map[string]Zone {
+	out := make(map[string]Zone, len(zones))
+	for _, z := range zones {
+		out[z.Name] = z
+	}
+	return out
+}
+
+func regionList(zones []Zone) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, z := range zones {
+		if !seen[z.Region] {
+			seen[z.Region] = true
+			out = append(out, z.Region)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func groupByRegion(zones []Zone) map[string][]Zone {
+	out := map[string][]Zone{}
+	for _, z := range zones {
+		out[z.Region] = append(out[z.Region], z)
+	}
+	for _, list := range out {
+		sort.Slice(list, func(i, j int) bool { return list[i].City < list[j].City })
+	}
+	return out
+}
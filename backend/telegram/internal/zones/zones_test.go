@@ -0,0 +1,72 @@
+package zones
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCatalog(t *testing.T) {
+	if len(Regions()) < 8 {
+		t.Fatalf("Regions() = %v", Regions())
+	}
+	for _, region := range Regions() {
+		for _, z := range InRegion(region) {
+			if !Valid(z.Name) {
+				t.Errorf("zone %s from zone.tab is not loadable", z.Name)
+			}
+		}
+	}
+	z, ok := Lookup("America/Argentina/Buenos_Aires")
+	if !ok || z.Region != "America" || z.City != "Argentina/Buenos Aires" {
+		t.Fatalf("Lookup() = %+v, %v", z, ok)
+	}
+	if Valid("Local") || Valid("Mars/Olympus") || !Valid("UTC") {
+		t.Error("Valid() accepted a bad zone or rejected UTC")
+	}
+}
+
+func TestSearch(t *testing.T) {
+	for query, want := range map[string]string{
+		"buenos aires":  "America/Argentina/Buenos_Aires",
+		"Yekaterinburg": "Asia/Yekaterinburg",
+		"Омск":          "Asia/Omsk",
+		"петербург":     "Europe/Moscow",
+	} {
+		found := false
+		for _, z := range Search(query) {
+			found = found || z.Name == want
+		}
+		if !found {
+			t.Errorf("Search(%q) has no %s", query, want)
+		}
+	}
+	if got := Search("  "); got != nil {
+		t.Errorf("Search(blank) = %v", got)
+	}
+}
+
+func TestNearest(t *testing.T) {
+	for _, tt := range []struct {
+		lat, lon float64
+		want     string
+	}{
+		{55.75, 37.62, "Europe/Moscow"},      // Москва
+		{54.98, 73.37, "Asia/Omsk"},          // Омск
+		{40.71, -74.0, "America/New_York"},   // Нью-Йорк
+		{-33.87, 151.21, "Australia/Sydney"}, // Сидней
+	} {
+		if got := Nearest(tt.lat, tt.lon).Name; got != tt.want {
+			t.Errorf("Nearest(%v, %v) = %s, want %s", tt.lat, tt.lon, got, tt.want)
+		}
+	}
+}
+
+func TestOffset(t *testing.T) {
+	at := time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)
+	if got := Offset("Asia/Kolkata", at); got != "+05:30" {
+		t.Errorf("Offset(Kolkata) = %q", got)
+	}
+	if got := Offset("America/New_York", at); got != "-05:00" {
+		t.Errorf("Offset(New_York) = %q", got)
+	}
+}
@@ -21,7 +21,7 @@ func (m *Manager) Shutdown(ctx context.Context) error {
 	defer cancel()
 	var shutdownErrs []error
 
-	for i := len(m.gracefuls); i >= 0; i-- {
+	for i := len(m.gracefuls) - 1; i >= 0; i-- {
 		g := m.gracefuls[i]
 		m.logger.Infof("Shutting down %s...", g.Name())
 		if err := g.Shutdown(shutdownCtx); err != nil {
@@ -0,0 +1,35 @@
+package closer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+type recorder struct {
+	name  string
+	order *[]string
+}
+
+func (r recorder) Shutdown(context.Context) error { *r.order = append(*r.order, r.name); return nil }
+func (r recorder) Close(context.Context) error    { *r.order = append(*r.order, r.name); return nil }
+func (r recorder) Name() string                   { return r.name }
+
+func TestShutdownOrder(t *testing.T) {
+	var order []string
+	m := NewManager(nil)
+	m.AddGraceful(recorder{"bot", &order})
+	m.AddGraceful(recorder{"server", &order})
+	m.AddCloser(recorder{"state", &order})
+
+	if err := m.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	// Graceful — в обратном порядке добавления, затем closers
+	if want := []string{"server", "bot", "state"}; !reflect.DeepEqual(order, want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	if err := m.Shutdown(context.Background()); err == nil {
+		t.Fatal("second Shutdown() should fail")
+	}
+}
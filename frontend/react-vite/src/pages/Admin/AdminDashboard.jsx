@@ -2,6 +2,7 @@ import React, { useState, useEffect } from 'react';
 import { useNavigate } from 'react-router-dom';
 import './AdminDashboard.css';
 import { useToast } from '../../components/Toast';
+import { formatMoney } from '../../utils/money';
 
 const AdminDashboard = () => {
   const navigate = useNavigate();
@@ -657,7 +658,7 @@ const AdminDashboard = () => {
                 {visibleServices.map((service) => (
                   <tr key={service.id}>
                     <td>{service.name}</td>
-                    <td>{service.price} руб.</td>
+                    <td>{formatMoney(service.price, service.currency)}</td>
                     <td>{service.duration} мин.</td>
                     <td>
           <button
@@ -891,7 +892,7 @@ const AdminDashboard = () => {
                       {userDetail.services.map((service) => (
                         <tr key={service.id}>
                           <td>{service.name}</td>
-                          <td>{service.price} руб.</td>
+                          <td>{formatMoney(service.price, service.currency)}</td>
                           <td>{service.duration} мин.</td>
                         </tr>
                       ))}
@@ -980,7 +981,7 @@ const AdminDashboard = () => {
                 <strong>Описание:</strong> {slotDetail.service_description}
               </div>
               <div className="detail-item">
-                <strong>Цена:</strong> {slotDetail.service_price} руб.
+                <strong>Цена:</strong> {formatMoney(slotDetail.service_price, slotDetail.service_currency)}
               </div>
               <div className="detail-item">
                 <strong>Длительность:</strong> {slotDetail.service_duration} мин.
@@ -1023,7 +1024,7 @@ const AdminDashboard = () => {
                 <strong>Название:</strong> {serviceDetail.name}
               </div>
               <div className="detail-item">
-                <strong>Цена:</strong> {serviceDetail.price} руб.
+                <strong>Цена:</strong> {formatMoney(serviceDetail.price, serviceDetail.currency)}
               </div>
               <div className="detail-item">
                 <strong>Длительность:</strong> {serviceDetail.duration} мин.
@@ -1097,7 +1098,7 @@ const AdminDashboard = () => {
                 <strong>Название:</strong> {recordDetail.slot_name}
               </div>
               <div className="detail-item">
-                <strong>Цена:</strong> {recordDetail.slot_price} руб.
+                <strong>Цена:</strong> {formatMoney(recordDetail.slot_price, recordDetail.slot_currency)}
               </div>
               <div className="detail-item">
                 <strong>Длительность:</strong> {recordDetail.slot_duration} мин.
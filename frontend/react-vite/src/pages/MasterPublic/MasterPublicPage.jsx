@@ -6,6 +6,7 @@ import Sidebar from "../../components/Sidebar/Sidebar";
 import { getCurrentUser, isAuthenticated } from "../../utils/auth";
 import { useToast } from "../../components/Toast";
 import { formatTimeInLocal, formatDateForDisplay } from "../../utils/timeUtils";
+import { formatMoney } from "../../utils/money";
 import "./masterPublic.css";
 
 function fetchSlots(telegramId, serviceId, dateFilter) {
@@ -297,7 +298,7 @@ export default function MasterPublicPage() {
                   <div className="mp-service-price-duration">
                     <div className="mp-service-price">
                       <span className="mp-service-label">Цена:</span>
-                      <span className="mp-service-value">{selectedService.price} ₽</span>
+                      <span className="mp-service-value">{formatMoney(selectedService.price, selectedService.currency)}</span>
                     </div>
                     <div className="mp-service-duration">
                       <span className="mp-service-label">Длительность:</span>
@@ -366,6 +367,11 @@ export default function MasterPublicPage() {
                           statusText = "Заявка отправлена";
                           showButton = false;
                           break;
+                        case "awaiting_payment":
+                          statusClass = "user-pending";
+                          statusText = "Ожидает оплаты";
+                          showButton = false;
+                          break;
                         case "confirm":
                           statusClass = "user-confirmed";
                           statusText = "Одобрено";
@@ -1,3 +1,5 @@
+import { formatMoney } from "../../utils/money";
+
 export default function ApplicationsSection({
   processedApplications,
   statusFilter,
@@ -84,6 +86,12 @@ export default function ApplicationsSection({
                           class: "status-rejected",
                           icon: "❌",
                         };
+                      case "cancel":
+                        return {
+                          text: "Отменено клиентом",
+                          class: "status-rejected",
+                          icon: "🚫",
+                        };
                       default:
                         return {
                           text: "Неизвестно",
@@ -108,9 +116,9 @@ export default function ApplicationsSection({
                             <span className="service-name">
                               {service?.name || "Услуга"}
                             </span>
-                            {service?.price && (
+                            {record.price > 0 && (
                               <span className="service-price">
-                                {service.price} ₽
+                                {formatMoney(record.price, record.currency)}
                               </span>
                             )}
                           </div>
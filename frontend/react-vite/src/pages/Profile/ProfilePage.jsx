@@ -5,6 +5,7 @@ import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import { apiService } from "../../utils/api";
 import { useToast } from "../../components/Toast";
 import { formatTimeInLocal, formatDateForDisplay, formatTimeForForm, formatDateForForm } from "../../utils/timeUtils";
+import { formatMoney, fromMinorUnits, toMinorUnits } from "../../utils/money";
 import "./profile.css";
 import { handleLogout } from '../../utils/auth';
 import RecordStatusSelect from "./RecordStatusSelect";
@@ -552,7 +553,7 @@ const copyToClipboard = async (text) => {
     // Валидация формы
     const name = serviceForm.name.trim();
     const description = serviceForm.description.trim();
-    const price = parseFloat(serviceForm.price) || 0;
+    const price = parseFloat(String(serviceForm.price).replace(",", ".")) || 0;
     const duration = parseInt(serviceForm.duration) || 60;
     
     // Проверка названия
@@ -573,7 +574,7 @@ const copyToClipboard = async (text) => {
     }
     
     if (price > 1000000) {
-      showError("Цена не может превышать 1 000 000 рублей");
+      showError("Цена не может превышать 1 000 000");
       return;
     }
     
@@ -592,7 +593,8 @@ const copyToClipboard = async (text) => {
       master_id: userId,
       name,
       description,
-      price,
+      // API принимает цену в минимальных единицах валюты мастера
+      price: toMinorUnits(price, user?.currency),
       duration,
     };
     
@@ -776,7 +778,7 @@ const copyToClipboard = async (text) => {
                             <strong>Длительность:</strong> {selectedService.duration} мин
                             {selectedService.price && (
                               <span style={{ marginLeft: '15px' }}>
-                                <strong>Цена:</strong> {selectedService.price} ₽
+                                <strong>Цена:</strong> {formatMoney(selectedService.price, selectedService.currency)}
                               </span>
                             )}
                           </div>
@@ -954,7 +956,7 @@ const copyToClipboard = async (text) => {
                       ) : (
                         <div className="services-scrollable">
                           {(services || []).map((svc) => {
-                            const edit = serviceEditMap[svc.id] || { name: svc.name || '', description: svc.description || '', price: svc.price || 0, duration: svc.duration || 60 };
+                            const edit = serviceEditMap[svc.id] || { name: svc.name || '', description: svc.description || '', price: fromMinorUnits(svc.price, svc.currency), duration: svc.duration || 60 };
                             return (
                               <div key={svc.id} style={{background: 'white', display: 'grid', gap: 6, border: '1px solid #e6e3f1', borderRadius: 8, padding: 10 }}>
                                 <div style={{ display:'grid', gridTemplateColumns:'1fr 1fr', gap: 8 }}>
@@ -969,8 +971,8 @@ const copyToClipboard = async (text) => {
                                 </div>
                                 <div style={{ display:'grid', gridTemplateColumns:'1fr 1fr', gap: 8 }}>
                                   <div className="account-field">
-                                    <label className="account-sublabel">Цена (₽)</label>
-                                    <input className="account-input" placeholder="Цена (₽)" type="number" step="0.01" value={edit.price} onChange={(e)=> setServiceEditMap((m)=> ({...m, [svc.id]: { ...edit, price: parseFloat(e.target.value)||0 }}))} />
+                                    <label className="account-sublabel">Цена ({svc.currency || 'RUB'})</label>
+                                    <input className="account-input" placeholder="Цена" type="number" step="0.01" value={edit.price} onChange={(e)=> setServiceEditMap((m)=> ({...m, [svc.id]: { ...edit, price: parseFloat(e.target.value)||0 }}))} />
                                   </div>
                                   <div className="account-field">
                                     <label className="account-sublabel">Описание</label>
@@ -982,7 +984,7 @@ const copyToClipboard = async (text) => {
                                     openConfirm(
                                       'Сохранить изменения услуги',
                                       'Вы уверены, что хотите сохранить изменения услуги?',
-                                      () => apiService.service.update({ id: svc.id, master_id: userId, name: edit.name, description: edit.description, price: Number(edit.price)||0, duration: Number(edit.duration)||0 })
+                                      () => apiService.service.update({ id: svc.id, master_id: userId, name: edit.name, description: edit.description, price: toMinorUnits(edit.price, svc.currency), currency: svc.currency, duration: Number(edit.duration)||0 })
                                         .then(()=>{ 
                                           showSuccess('Услуга обновлена'); 
                                           queryClient.invalidateQueries({ queryKey: ['services', userId] });
@@ -1234,7 +1236,7 @@ const copyToClipboard = async (text) => {
               
               <div className="profile-row">
                 <div className="profile-field">
-                  <label>Цена (₽)</label>
+                  <label>Цена ({user?.currency || "RUB"})</label>
                   <input 
                     className="profile-input" 
                     type="number" 
@@ -8,6 +8,7 @@ import { apiService } from '../../utils/api';
 import { formatTimeInLocal, formatDateForDisplay } from '../../utils/timeUtils';
 import { isAuthenticated, getCurrentUser } from '../../utils/auth';
 import { useToast } from '../../components/Toast';
+import { formatMoney } from '../../utils/money';
 
 function fetchSlots(telegramId, serviceId, dateFilter) {
   return apiService.slot.getByMaster(telegramId).then((r) => {
@@ -262,7 +263,7 @@ export default function Slots() {
               <div className="slots-service-info">
                 <div className="slots-service-pair">
                   <div className="slots-service-key">Цена</div>
-                  <div className="slots-service-val">{selectedService.price} ₽</div>
+                  <div className="slots-service-val">{formatMoney(selectedService.price, selectedService.currency)}</div>
                 </div>
                 <div className="slots-service-pair">
                   <div className="slots-service-key">Длительность</div>
@@ -321,6 +322,11 @@ export default function Slots() {
                             statusText = "Заявка отправлена";
                             showButton = false;
                             break;
+                          case "awaiting_payment":
+                            statusClass = "user-pending";
+                            statusText = "Ожидает оплаты";
+                            showButton = false;
+                            break;
                           case "confirm":
                             statusClass = "user-confirmed";
                             statusText = "Одобрено";
@@ -331,6 +337,11 @@ export default function Slots() {
                             statusText = "Отклонено";
                             showButton = true;
                             break;
+                          case "cancel":
+                            statusClass = "user-rejected";
+                            statusText = "Отменено";
+                            showButton = false;
+                            break;
                           default:
                             statusClass = "user-pending";
                             statusText = "Заявка отправлена";
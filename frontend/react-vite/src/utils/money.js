@@ -0,0 +1,59 @@
+// Утилиты для денежных сумм: API передаёт цены целыми числами в минимальных
+// единицах валюты (копейки, центы) вместе с кодом валюты ISO 4217
+
+const DEFAULT_CURRENCY = "RUB";
+
+/**
+ * Число знаков после запятой у валюты: 2 у рубля, 0 у иены
+ * @param {string} currency - код валюты ISO 4217
+ * @returns {number}
+ */
+function fractionDigits(currency) {
+  try {
+    return new Intl.NumberFormat("ru-RU", { style: "currency", currency }).resolvedOptions().maximumFractionDigits;
+  } catch {
+    return 2;
+  }
+}
+
+/**
+ * Форматирует сумму из минимальных единиц: 150050, "RUB" → "1 500,50 ₽"
+ * @param {number} amount - сумма в минимальных единицах
+ * @param {string} [currency] - код валюты, по умолчанию RUB
+ * @returns {string}
+ */
+export function formatMoney(amount, currency = DEFAULT_CURRENCY) {
+  const code = currency || DEFAULT_CURRENCY;
+  const digits = fractionDigits(code);
+  const value = (Number(amount) || 0) / 10 ** digits;
+  try {
+    return new Intl.NumberFormat("ru-RU", {
+      style: "currency",
+      currency: code,
+      minimumFractionDigits: Number.isInteger(value) ? 0 : digits,
+    }).format(value);
+  } catch {
+    return `${value} ${code}`;
+  }
+}
+
+/**
+ * Переводит сумму из минимальных единиц в число для поля ввода: 150050 → 1500.5
+ * @param {number} amount - сумма в минимальных единицах
+ * @param {string} [currency] - код валюты
+ * @returns {number}
+ */
+export function fromMinorUnits(amount, currency = DEFAULT_CURRENCY) {
+  return (Number(amount) || 0) / 10 ** fractionDigits(currency || DEFAULT_CURRENCY);
+}
+
+/**
+ * Переводит введённую сумму в минимальные единицы: "1500,5" → 150050
+ * @param {string|number} value - сумма в основных единицах
+ * @param {string} [currency] - код валюты
+ * @returns {number}
+ */
+export function toMinorUnits(value, currency = DEFAULT_CURRENCY) {
+  const number = parseFloat(String(value ?? "").replace(",", ".")) || 0;
+  return Math.round(number * 10 ** fractionDigits(currency || DEFAULT_CURRENCY));
+}